@@ -72,11 +72,19 @@ func (src *ArgoCD) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.KustomizeBuildOptions = src.Spec.KustomizeBuildOptions
 	dst.Spec.KustomizeVersions = ConvertAlphaToBetaKustomizeVersions(src.Spec.KustomizeVersions)
 	dst.Spec.OIDCConfig = src.Spec.OIDCConfig
-	dst.Spec.Monitoring = v1beta1.ArgoCDMonitoringSpec(src.Spec.Monitoring)
+	dst.Spec.Monitoring = v1beta1.ArgoCDMonitoringSpec{
+		Enabled:        src.Spec.Monitoring.Enabled,
+		DisableMetrics: src.Spec.Monitoring.DisableMetrics,
+	}
 	dst.Spec.NodePlacement = (*v1beta1.ArgoCDNodePlacementSpec)(src.Spec.NodePlacement)
-	dst.Spec.Notifications = v1beta1.ArgoCDNotifications(src.Spec.Notifications)
+	dst.Spec.Notifications = *ConvertAlphaToBetaNotifications(&src.Spec.Notifications)
 	dst.Spec.Prometheus = *ConvertAlphaToBetaPrometheus(&src.Spec.Prometheus)
-	dst.Spec.RBAC = v1beta1.ArgoCDRBACSpec(src.Spec.RBAC)
+	dst.Spec.RBAC = v1beta1.ArgoCDRBACSpec{
+		DefaultPolicy:     src.Spec.RBAC.DefaultPolicy,
+		Policy:            src.Spec.RBAC.Policy,
+		Scopes:            src.Spec.RBAC.Scopes,
+		PolicyMatcherMode: src.Spec.RBAC.PolicyMatcherMode,
+	}
 	dst.Spec.Redis = *ConvertAlphaToBetaRedis(&src.Spec.Redis)
 	dst.Spec.Repo = *ConvertAlphaToBetaRepo(&src.Spec.Repo)
 	dst.Spec.RepositoryCredentials = src.Spec.RepositoryCredentials
@@ -141,11 +149,19 @@ func (dst *ArgoCD) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Spec.KustomizeBuildOptions = src.Spec.KustomizeBuildOptions
 	dst.Spec.KustomizeVersions = ConvertBetaToAlphaKustomizeVersions(src.Spec.KustomizeVersions)
 	dst.Spec.OIDCConfig = src.Spec.OIDCConfig
-	dst.Spec.Monitoring = ArgoCDMonitoringSpec(src.Spec.Monitoring)
+	dst.Spec.Monitoring = ArgoCDMonitoringSpec{
+		Enabled:        src.Spec.Monitoring.Enabled,
+		DisableMetrics: src.Spec.Monitoring.DisableMetrics,
+	}
 	dst.Spec.NodePlacement = (*ArgoCDNodePlacementSpec)(src.Spec.NodePlacement)
-	dst.Spec.Notifications = ArgoCDNotifications(src.Spec.Notifications)
+	dst.Spec.Notifications = *ConvertBetaToAlphaNotifications(&src.Spec.Notifications)
 	dst.Spec.Prometheus = *ConvertBetaToAlphaPrometheus(&src.Spec.Prometheus)
-	dst.Spec.RBAC = ArgoCDRBACSpec(src.Spec.RBAC)
+	dst.Spec.RBAC = ArgoCDRBACSpec{
+		DefaultPolicy:     src.Spec.RBAC.DefaultPolicy,
+		Policy:            src.Spec.RBAC.Policy,
+		Scopes:            src.Spec.RBAC.Scopes,
+		PolicyMatcherMode: src.Spec.RBAC.PolicyMatcherMode,
+	}
 	dst.Spec.Redis = *ConvertBetaToAlphaRedis(&src.Spec.Redis)
 	dst.Spec.Repo = *ConvertBetaToAlphaRepo(&src.Spec.Repo)
 	dst.Spec.RepositoryCredentials = src.Spec.RepositoryCredentials
@@ -270,6 +286,30 @@ func ConvertAlphaToBetaGrafana(src *ArgoCDGrafanaSpec) *v1beta1.ArgoCDGrafanaSpe
 	return dst
 }
 
+func ConvertAlphaToBetaNotifications(src *ArgoCDNotifications) *v1beta1.ArgoCDNotifications {
+	var dst *v1beta1.ArgoCDNotifications
+	if src != nil {
+		dst = &v1beta1.ArgoCDNotifications{
+			Replicas:                      src.Replicas,
+			Enabled:                       src.Enabled,
+			Env:                           src.Env,
+			Image:                         src.Image,
+			Version:                       src.Version,
+			Resources:                     src.Resources,
+			LogLevel:                      src.LogLevel,
+			DNSConfig:                     src.DNSConfig,
+			DNSPolicy:                     src.DNSPolicy,
+			PodSecurityContext:            src.PodSecurityContext,
+			SecurityContext:               src.SecurityContext,
+			TerminationGracePeriodSeconds: src.TerminationGracePeriodSeconds,
+			RootCASecretRef:               src.RootCASecretRef,
+			Volumes:                       src.Volumes,
+			VolumeMounts:                  src.VolumeMounts,
+		}
+	}
+	return dst
+}
+
 func ConvertAlphaToBetaPrometheus(src *ArgoCDPrometheusSpec) *v1beta1.ArgoCDPrometheusSpec {
 	var dst *v1beta1.ArgoCDPrometheusSpec
 	if src != nil {
@@ -290,7 +330,22 @@ func ConvertAlphaToBetaSSO(src *ArgoCDSSOSpec) *v1beta1.ArgoCDSSOSpec {
 		dst = &v1beta1.ArgoCDSSOSpec{
 			Provider: v1beta1.SSOProviderType(src.Provider),
 			Dex:      ConvertAlphaToBetaDex(src.Dex),
-			Keycloak: (*v1beta1.ArgoCDKeycloakSpec)(src.Keycloak),
+			Keycloak: ConvertAlphaToBetaKeycloak(src.Keycloak),
+		}
+	}
+	return dst
+}
+
+func ConvertAlphaToBetaKeycloak(src *ArgoCDKeycloakSpec) *v1beta1.ArgoCDKeycloakSpec {
+	var dst *v1beta1.ArgoCDKeycloakSpec
+	if src != nil {
+		dst = &v1beta1.ArgoCDKeycloakSpec{
+			Image:     src.Image,
+			Resources: src.Resources,
+			RootCA:    src.RootCA,
+			Version:   src.Version,
+			VerifyTLS: src.VerifyTLS,
+			Host:      src.Host,
 		}
 	}
 	return dst
@@ -340,7 +395,7 @@ func ConvertAlphaToBetaServer(src *ArgoCDServerSpec) *v1beta1.ArgoCDServerSpec {
 	var dst *v1beta1.ArgoCDServerSpec
 	if src != nil {
 		dst = &v1beta1.ArgoCDServerSpec{
-			Autoscale:        v1beta1.ArgoCDServerAutoscaleSpec(src.Autoscale),
+			Autoscale:        v1beta1.ArgoCDServerAutoscaleSpec{Enabled: src.Autoscale.Enabled, HPA: src.Autoscale.HPA},
 			GRPC:             *ConvertAlphaToBetaGRPC(&src.GRPC),
 			Host:             src.Host,
 			Ingress:          v1beta1.ArgoCDIngressSpec(src.Ingress),
@@ -490,6 +545,30 @@ func ConvertBetaToAlphaGrafana(src *v1beta1.ArgoCDGrafanaSpec) *ArgoCDGrafanaSpe
 	return dst
 }
 
+func ConvertBetaToAlphaNotifications(src *v1beta1.ArgoCDNotifications) *ArgoCDNotifications {
+	var dst *ArgoCDNotifications
+	if src != nil {
+		dst = &ArgoCDNotifications{
+			Replicas:                      src.Replicas,
+			Enabled:                       src.Enabled,
+			Env:                           src.Env,
+			Image:                         src.Image,
+			Version:                       src.Version,
+			Resources:                     src.Resources,
+			LogLevel:                      src.LogLevel,
+			DNSConfig:                     src.DNSConfig,
+			DNSPolicy:                     src.DNSPolicy,
+			PodSecurityContext:            src.PodSecurityContext,
+			SecurityContext:               src.SecurityContext,
+			TerminationGracePeriodSeconds: src.TerminationGracePeriodSeconds,
+			RootCASecretRef:               src.RootCASecretRef,
+			Volumes:                       src.Volumes,
+			VolumeMounts:                  src.VolumeMounts,
+		}
+	}
+	return dst
+}
+
 func ConvertBetaToAlphaPrometheus(src *v1beta1.ArgoCDPrometheusSpec) *ArgoCDPrometheusSpec {
 	var dst *ArgoCDPrometheusSpec
 	if src != nil {
@@ -510,7 +589,22 @@ func ConvertBetaToAlphaSSO(src *v1beta1.ArgoCDSSOSpec) *ArgoCDSSOSpec {
 		dst = &ArgoCDSSOSpec{
 			Provider: SSOProviderType(src.Provider),
 			Dex:      ConvertBetaToAlphaDex(src.Dex),
-			Keycloak: (*ArgoCDKeycloakSpec)(src.Keycloak),
+			Keycloak: ConvertBetaToAlphaKeycloak(src.Keycloak),
+		}
+	}
+	return dst
+}
+
+func ConvertBetaToAlphaKeycloak(src *v1beta1.ArgoCDKeycloakSpec) *ArgoCDKeycloakSpec {
+	var dst *ArgoCDKeycloakSpec
+	if src != nil {
+		dst = &ArgoCDKeycloakSpec{
+			Image:     src.Image,
+			Resources: src.Resources,
+			RootCA:    src.RootCA,
+			Version:   src.Version,
+			VerifyTLS: src.VerifyTLS,
+			Host:      src.Host,
 		}
 	}
 	return dst
@@ -559,7 +653,7 @@ func ConvertBetaToAlphaServer(src *v1beta1.ArgoCDServerSpec) *ArgoCDServerSpec {
 	var dst *ArgoCDServerSpec
 	if src != nil {
 		dst = &ArgoCDServerSpec{
-			Autoscale:        ArgoCDServerAutoscaleSpec(src.Autoscale),
+			Autoscale:        ArgoCDServerAutoscaleSpec{Enabled: src.Autoscale.Enabled, HPA: src.Autoscale.HPA},
 			GRPC:             *ConvertBetaToAlphaGRPC(&src.GRPC),
 			Host:             src.Host,
 			Ingress:          ArgoCDIngressSpec(src.Ingress),
@@ -0,0 +1,164 @@
+package v1alpha1
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+// ConvertManifest converts the v1alpha1 ArgoCD resources of the given YAML manifest to v1beta1, the same way the
+// conversion webhook does, so that upgrades can be checked before the resources are applied. The manifest may hold
+// several documents separated by ---, documents of other kinds are returned unchanged. Alongside the converted
+// manifest, a warning is returned for each deprecated field that was migrated.
+func ConvertManifest(manifest []byte) ([]byte, []string, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifest)))
+
+	var out bytes.Buffer
+	var warnings []string
+	for i := 0; ; i++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		converted, docWarnings, err := convertManifestDocument(doc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to convert document %d: %w", i+1, err)
+		}
+		warnings = append(warnings, docWarnings...)
+
+		if out.Len() > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(converted)
+		if !bytes.HasSuffix(converted, []byte("\n")) {
+			out.WriteString("\n")
+		}
+	}
+	return out.Bytes(), warnings, nil
+}
+
+// convertManifestDocument converts the given YAML document when it holds a v1alpha1 ArgoCD, and returns it unchanged
+// otherwise.
+func convertManifestDocument(doc []byte) ([]byte, []string, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+		return nil, nil, err
+	}
+	if typeMeta.APIVersion != GroupVersion.String() || typeMeta.Kind != "ArgoCD" {
+		return doc, nil, nil
+	}
+
+	src := &ArgoCD{}
+	if err := yaml.UnmarshalStrict(doc, src); err != nil {
+		return nil, nil, err
+	}
+	dst := &v1beta1.ArgoCD{}
+	if err := src.ConvertTo(dst); err != nil {
+		return nil, nil, err
+	}
+	dst.TypeMeta = metav1.TypeMeta{APIVersion: v1beta1.GroupVersion.String(), Kind: "ArgoCD"}
+
+	warnings := getDeprecatedFieldWarnings(src)
+
+	// Drop the zero values serialized from the typed resource that were not set in the document
+	data, err := json.Marshal(dst)
+	if err != nil {
+		return nil, nil, err
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, nil, err
+	}
+	original := map[string]interface{}{}
+	if err := yaml.Unmarshal(doc, &original); err != nil {
+		return nil, nil, err
+	}
+	pruneUnsetZeroValues(obj, original)
+
+	converted, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	return converted, warnings, nil
+}
+
+// pruneUnsetZeroValues will remove the fields of the given object holding a zero value, such as false, an empty string
+// or an empty object, which are not set in the given original object.
+func pruneUnsetZeroValues(obj map[string]interface{}, original map[string]interface{}) {
+	for key, value := range obj {
+		originalValue, set := original[key]
+		if nested, ok := value.(map[string]interface{}); ok {
+			originalNested, _ := originalValue.(map[string]interface{})
+			pruneUnsetZeroValues(nested, originalNested)
+		}
+		if !set && isZeroValue(value) {
+			delete(obj, key)
+		}
+	}
+}
+
+// isZeroValue returns true if the given decoded JSON value is null, false, zero, or an empty string, array or object.
+func isZeroValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// getDeprecatedFieldWarnings returns a warning for each deprecated field of the given ArgoCD migrated by the conversion
+// to v1beta1.
+func getDeprecatedFieldWarnings(cr *ArgoCD) []string {
+	var warnings []string
+	name := fmt.Sprintf("ArgoCD %s", cr.Name)
+	if cr.Namespace != "" {
+		name = fmt.Sprintf("ArgoCD %s/%s", cr.Namespace, cr.Name)
+	}
+
+	if cr.Spec.Dex != nil && !reflect.DeepEqual(cr.Spec.Dex, &ArgoCDDexSpec{}) && (cr.Spec.Dex.Config != "" || cr.Spec.Dex.OpenShiftOAuth) {
+		warnings = append(warnings, fmt.Sprintf("%s: .spec.dex was migrated to .spec.sso.dex with the dex provider", name))
+	}
+	if cr.Spec.SSO != nil {
+		for _, field := range []struct {
+			name string
+			set  bool
+		}{
+			{"image", cr.Spec.SSO.Image != ""},
+			{"version", cr.Spec.SSO.Version != ""},
+			{"verifyTLS", cr.Spec.SSO.VerifyTLS != nil},
+			{"resources", cr.Spec.SSO.Resources != nil},
+		} {
+			if field.set {
+				warnings = append(warnings, fmt.Sprintf("%s: .spec.sso.%s was migrated to .spec.sso.keycloak.%s", name, field.name, field.name))
+			}
+		}
+	}
+	return warnings
+}
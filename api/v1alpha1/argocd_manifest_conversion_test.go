@@ -0,0 +1,79 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+
+	v1beta1 "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestConvertManifest(t *testing.T) {
+	manifest := `apiVersion: argoproj.io/v1alpha1
+kind: ArgoCD
+metadata:
+  name: example-argocd
+  namespace: argocd
+spec:
+  dex:
+    openShiftOAuth: true
+  sso:
+    image: quay.io/keycloak/keycloak
+  server:
+    route:
+      enabled: true
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: example
+data:
+  key: value
+`
+	converted, warnings, err := ConvertManifest([]byte(manifest))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"ArgoCD argocd/example-argocd: .spec.dex was migrated to .spec.sso.dex with the dex provider",
+		"ArgoCD argocd/example-argocd: .spec.sso.image was migrated to .spec.sso.keycloak.image",
+	}, warnings)
+
+	docs := splitTestManifest(string(converted))
+	assert.Len(t, docs, 2)
+
+	beta := &v1beta1.ArgoCD{}
+	assert.NoError(t, yaml.UnmarshalStrict([]byte(docs[0]), beta))
+	assert.Equal(t, "argoproj.io/v1beta1", beta.APIVersion)
+	assert.Equal(t, v1beta1.SSOProviderTypeDex, beta.Spec.SSO.Provider)
+	assert.True(t, beta.Spec.SSO.Dex.OpenShiftOAuth)
+	assert.Equal(t, "quay.io/keycloak/keycloak", beta.Spec.SSO.Keycloak.Image)
+	assert.True(t, beta.Spec.Server.Route.Enabled)
+	assert.NotContains(t, docs[0], "status")
+	assert.NotContains(t, docs[0], "creationTimestamp")
+
+	// Other resources are left unchanged
+	assert.Equal(t, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: example\ndata:\n  key: value\n", docs[1])
+}
+
+func TestConvertManifest_unknownField(t *testing.T) {
+	manifest := `apiVersion: argoproj.io/v1alpha1
+kind: ArgoCD
+metadata:
+  name: example-argocd
+spec:
+  unknown: true
+`
+	_, _, err := ConvertManifest([]byte(manifest))
+	assert.ErrorContains(t, err, "failed to convert document 1")
+}
+
+func splitTestManifest(manifest string) []string {
+	var docs []string
+	for _, doc := range strings.Split(manifest, "---\n") {
+		if doc != "" {
+			docs = append(docs, doc)
+		}
+	}
+	return docs
+}
@@ -132,6 +132,17 @@ type ArgoCDApplicationControllerShardSpec struct {
 	// ClustersPerShard defines the maximum number of clusters managed by each argocd shard
 	// +kubebuilder:validation:Minimum=1
 	ClustersPerShard int32 `json:"clustersPerShard,omitempty"`
+
+	// AntiAffinityMode defines how the application controller shards are spread across nodes. With soft (default),
+	// the shards are preferably scheduled on different nodes, with hard they are required to run on different nodes.
+	// +kubebuilder:validation:Enum=soft;hard
+	AntiAffinityMode string `json:"antiAffinityMode,omitempty"`
+
+	// Algorithm defines how the clusters are distributed across the application controller shards. Defaults to the
+	// Argo CD default, legacy, which hashes the ID of each cluster. round-robin balances the number of clusters per
+	// shard, and consistent-hashing limits the clusters moved when the number of shards changes.
+	// +kubebuilder:validation:Enum=legacy;round-robin;consistent-hashing
+	Algorithm string `json:"algorithm,omitempty"`
 }
 
 // ArgoCDApplicationSet defines whether the Argo CD ApplicationSet controller should be installed.
@@ -271,6 +282,11 @@ type ArgoCDIngressSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingress Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// Hosts are the additional hostnames of the Ingress, next to the host of the component, e.g. a wildcard host such
+	// as *.argocd.example.com. Only honored by the Argo CD Server Ingress.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+
 	// IngressClassName for the Ingress resource.
 	IngressClassName *string `json:"ingressClassName,omitempty"`
 
@@ -284,6 +300,16 @@ type ArgoCDIngressSpec struct {
 	// ingress supports SNI.
 	// +optional
 	TLS []networkingv1.IngressTLS `json:"tls,omitempty"`
+
+	// TLSIssuer is the name of the cert-manager issuer requesting the certificate of the Ingress. Only honored by the
+	// Argo CD Server Ingress.
+	// +optional
+	TLSIssuer string `json:"tlsIssuer,omitempty"`
+
+	// TLSIssuerKind is the kind of the cert-manager issuer, either Issuer (default) or ClusterIssuer.
+	//+kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +optional
+	TLSIssuerKind string `json:"tlsIssuerKind,omitempty"`
 }
 
 // ArgoCDKeycloakSpec defines the desired state for the Keycloak component.
@@ -339,6 +365,36 @@ type ArgoCDNotifications struct {
 
 	// LogLevel describes the log level that should be used by the argocd-notifications. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug,info, error, and warn.
 	LogLevel string `json:"logLevel,omitempty"`
+
+	// DNSConfig defines the DNS parameters of the Notifications Controller pods, such as additional nameservers or search domains, which
+	// are merged with the configuration generated from DNSPolicy.
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// DNSPolicy is the DNS policy of the Notifications Controller pods. Defaults to ClusterFirst. The None policy requires DNSConfig.
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// PodSecurityContext defines the pod-level security attributes of the Notifications Controller pods, replacing the defaults set by
+	// the operator, which run the pods as non-root with the RuntimeDefault seccomp profile.
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext defines the security options of the Notifications Controller container, replacing the defaults set by the operator.
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// TerminationGracePeriodSeconds is the time given to the Notifications Controller pods to shut down gracefully before they are killed.
+	// Defaults to the Kubernetes default of 30 seconds.
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// RootCASecretRef references the key of a Secret holding PEM encoded CA certificates trusted by the Notifications
+	// Controller in addition to the system ones, e.g. to reach webhook or chat endpoints signed by a private CA, or a
+	// TLS intercepting proxy.
+	RootCASecretRef *corev1.SecretKeySelector `json:"rootCASecretRef,omitempty"`
+
+	// Volumes adds volumes to the Notifications Controller pods.
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts adds volumeMounts to the Notifications Controller container.
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
 }
 
 // ArgoCDPrometheusSpec defines the desired state for the Prometheus component.
@@ -484,12 +540,19 @@ type ArgoCDRouteSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Route Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// Host is the hostname of the Route, overriding the Host of the component, which is shared with its Ingress.
+	Host string `json:"host,omitempty"`
+
 	// Path the router watches for, to route traffic for to the service.
 	Path string `json:"path,omitempty"`
 
 	// TLS provides the ability to configure certificates and termination for the Route.
 	TLS *routev1.TLSConfig `json:"tls,omitempty"`
 
+	// TLSSecretRef references a Secret of type kubernetes.io/tls, e.g. managed by cert-manager, whose certificate,
+	// key and CA certificate are written into the TLS configuration of the Route, and kept in sync with the Secret.
+	TLSSecretRef *corev1.LocalObjectReference `json:"tlsSecretRef,omitempty"`
+
 	// WildcardPolicy if any for the route. Currently only 'Subdomain' or 'None' is allowed.
 	WildcardPolicy *routev1.WildcardPolicyType `json:"wildcardPolicy,omitempty"`
 }
@@ -567,6 +630,30 @@ type ArgoCDServerServiceSpec struct {
 	// Type is the ServiceType to use for the Service resource.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Type'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:text"}
 	Type corev1.ServiceType `json:"type"`
+
+	// Annotations is the map of annotations to add to the Service resource, e.g. to configure a cloud load balancer.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels is the map of labels to add to the Service resource.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// LoadBalancerSourceRanges restricts the client IP ranges allowed to reach a LoadBalancer Service.
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
+	// LoadBalancerClass is the class of the load balancer implementation of a LoadBalancer Service. It can only be
+	// set when the Service is created.
+	LoadBalancerClass *string `json:"loadBalancerClass,omitempty"`
+
+	// ExternalTrafficPolicy defines how traffic from outside the cluster is routed by a NodePort or LoadBalancer
+	// Service, either Cluster or Local.
+	// +kubebuilder:validation:Enum=Cluster;Local
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+
+	// IPFamilyPolicy is the IP family policy of the Service, overriding the one set for the whole instance.
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// IPFamilies are the IP families of the Service, overriding the ones set for the whole instance.
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
 }
 
 // Resource Customization for custom health check
@@ -891,6 +978,10 @@ type ArgoCDStatus struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Redis",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Redis string `json:"redis,omitempty"`
 
+	// RedisMode is the Redis mode the Argo CD components are currently connected to, either Standalone or HA.
+	// It lags behind spec.ha.enabled while Redis is migrated between the modes.
+	RedisMode string `json:"redisMode,omitempty"`
+
 	// Repo is a simple, high-level summary of where the Argo CD Repo component is in its lifecycle.
 	// There are four possible repo values:
 	// Pending: The Argo CD Repo component has been accepted by the Kubernetes system, but one or more of the required resources have not been created.
@@ -909,6 +1000,14 @@ type ArgoCDStatus struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Server",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Server string `json:"server,omitempty"`
 
+	// ServerReplicas is the number of Argo CD server Pods targeted by the server Deployment.
+	// It backs the scale subresource of the ArgoCD resource.
+	ServerReplicas int32 `json:"serverReplicas,omitempty"`
+
+	// ServerSelector is the label selector of the Argo CD server Pods, in string form.
+	// It backs the scale subresource of the ArgoCD resource.
+	ServerSelector string `json:"serverSelector,omitempty"`
+
 	// RepoTLSChecksum contains the SHA256 checksum of the latest known state of tls.crt and tls.key in the argocd-repo-server-tls secret.
 	RepoTLSChecksum string `json:"repoTLSChecksum,omitempty"`
 
@@ -917,6 +1016,26 @@ type ArgoCDStatus struct {
 
 	// Host is the hostname of the Ingress.
 	Host string `json:"host,omitempty"`
+
+	// Endpoints holds the URLs of the Argo CD endpoints exposed outside of the cluster, discovered from the created
+	// Routes, Ingresses and LoadBalancer Services. They are keyed by endpoint: server, grpc, applicationSetWebhook,
+	// keycloak and grafana.
+	Endpoints map[string]string `json:"endpoints,omitempty"`
+
+	// ComponentMessages holds, for each component that is not running, the reason reported by its workload or Pods,
+	// e.g. an image pull back-off or a crash loop. Components are keyed by the name of their status field.
+	ComponentMessages map[string]string `json:"componentMessages,omitempty"`
+
+	// ControllerShards holds the clusters assigned to each application controller shard, as a comma separated list of
+	// cluster names keyed by shard number, to debug an imbalance between the shards. It is only reported when the
+	// application controller is sharded with the legacy or round-robin algorithm.
+	ControllerShards map[string]string `json:"controllerShards,omitempty"`
+
+	// Conditions describe the latest observations of the state of the Argo CD instance.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // Banner defines an additional banner message to be displayed in Argo CD UI
@@ -945,6 +1064,17 @@ type SSHHostsSpec struct {
 	// Keys describes a custom set of SSH Known Hosts that you would like to
 	// have included in your ArgoCD server.
 	Keys string `json:"keys,omitempty"`
+
+	// RefreshInterval enables the periodic refresh of the default SSH Known Hosts from upstream Argo CD,
+	// and keeps the SSH Known Hosts ConfigMap in sync with this spec. When not set, the ConfigMap is
+	// only populated upon creation of the cluster.
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// MergeStrategy describes how the custom Keys are combined with the default SSH Known Hosts.
+	// With append (default), the custom keys are added after the default hosts. With merge, a custom
+	// key replaces the default entry for the same host and key type.
+	// +kubebuilder:validation:Enum=append;merge
+	MergeStrategy string `json:"mergeStrategy,omitempty"`
 }
 
 // WebhookServerSpec defines the options for the ApplicationSet Webhook Server component.
@@ -73,6 +73,17 @@ type ArgoCDExportSpec struct {
 	// Image is the container image to use for the export Job.
 	Image string `json:"image,omitempty"`
 
+	// Incremental exports only the resources that changed since the previous export. The first export is always a
+	// full export, subsequent exports are stored next to it and applied in order on import.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Incremental",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Incremental bool `json:"incremental,omitempty"`
+
+	// Retention defines how many and how long the exported archives are kept in the storage backend, older archives are
+	// pruned by the export. Only takes effect when Incremental is set, as a full export always replaces the previous
+	// one.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retention"
+	Retention *ArgoCDExportRetentionSpec `json:"retention,omitempty"`
+
 	// Schedule in Cron format, see https://en.wikipedia.org/wiki/Cron.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Schedule",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Schedule *string `json:"schedule,omitempty"`
@@ -81,6 +92,11 @@ type ArgoCDExportSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage"
 	Storage *ArgoCDExportStorageSpec `json:"storage,omitempty"`
 
+	// Trigger forces an immediate one-shot export each time it is set to a new value, e.g. the current time, even when
+	// a Schedule is configured. The last value that triggered an export is reported in the status.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Trigger",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	Trigger string `json:"trigger,omitempty"`
+
 	// Version is the tag/digest to use for the export Job container image.
 	Version string `json:"version,omitempty"`
 }
@@ -97,6 +113,34 @@ type ArgoCDExportStatus struct {
 	// Unknown: For some reason the state of the ArgoCDExport could not be obtained.
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Phase",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Phase string `json:"phase"`
+
+	// LastTrigger is the value of the Trigger that started the last one-shot export.
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Trigger",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	LastTrigger string `json:"lastTrigger,omitempty"`
+
+	// LastExportTime is the time the last successful export completed.
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Export Time",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	LastExportTime *metav1.Time `json:"lastExportTime,omitempty"`
+
+	// LastExportSize is the size in bytes of the encrypted data stored by the last successful export.
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Export Size",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	LastExportSize int64 `json:"lastExportSize,omitempty"`
+
+	// LastExportChecksum is the SHA-256 checksum of the encrypted data stored by the last successful export.
+	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Export Checksum",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	LastExportChecksum string `json:"lastExportChecksum,omitempty"`
+}
+
+// ArgoCDExportRetentionSpec defines the retention of the archives stored by an ArgoCDExport.
+type ArgoCDExportRetentionSpec struct {
+	// MaxAge is the maximum age of the full export that incremental exports are applied on. Once reached, the next
+	// export is a full export and the archives it replaces are pruned.
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// MaxBackups is the maximum number of archives kept, counting the full export and its incremental exports. Once
+	// reached, the next export is a full export and the archives it replaces are pruned. Defaults to 30.
+	//+kubebuilder:validation:Minimum=1
+	MaxBackups *int32 `json:"maxBackups,omitempty"`
 }
 
 // ArgoCDExportStorageSpec defines the desired state for ArgoCDExport storage options.
@@ -26,7 +26,9 @@ import (
 	autoscaling "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func init() {
@@ -42,6 +44,7 @@ func init() {
 // ArgoCD is the Schema for the argocds API
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.server.replicas,statuspath=.status.serverReplicas,selectorpath=.status.serverSelector
 // +operator-sdk:csv:customresourcedefinitions:resources={{ArgoCD,v1beta1,""}}
 // +operator-sdk:csv:customresourcedefinitions:resources={{ArgoCDExport,v1alpha1,""}}
 // +operator-sdk:csv:customresourcedefinitions:resources={{ConfigMap,v1,""}}
@@ -66,6 +69,61 @@ type ArgoCD struct {
 	Status ArgoCDStatus `json:"status,omitempty"`
 }
 
+// ArgoCDApplicationControllerClusterCacheSpec defines the tuning options of the cache the Argo CD Application
+// Controller keeps of the resources of each managed cluster. They are set through the ARGOCD_CLUSTER_CACHE_*
+// environment variables of the Application Controller, and override the same variables set in its Env.
+type ArgoCDApplicationControllerClusterCacheSpec struct {
+	// Attempts is the number of attempts of the cluster cache to list the resources of a cluster. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	Attempts *int32 `json:"attempts,omitempty"`
+
+	// ListPageBufferSize is the number of pages of resources buffered while listing them. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	ListPageBufferSize *int32 `json:"listPageBufferSize,omitempty"`
+
+	// ListPageSize is the number of resources listed per request. Defaults to 500.
+	// +kubebuilder:validation:Minimum=1
+	ListPageSize *int64 `json:"listPageSize,omitempty"`
+
+	// ListSemaphore is the maximum number of list requests run concurrently across all clusters. Defaults to 50.
+	// +kubebuilder:validation:Minimum=1
+	ListSemaphore *int64 `json:"listSemaphore,omitempty"`
+
+	// ResyncDuration is the period after which the cache of a cluster is fully invalidated and resynced. Defaults to 12h.
+	// +optional
+	ResyncDuration *metav1.Duration `json:"resyncDuration,omitempty"`
+
+	// RetryUseBackoff makes the retries of the cluster cache wait with an exponential backoff.
+	RetryUseBackoff *bool `json:"retryUseBackoff,omitempty"`
+
+	// SyncRetryTimeout is the delay before retrying to sync the cache of a cluster after a failure. Defaults to 10s.
+	// +optional
+	SyncRetryTimeout *metav1.Duration `json:"syncRetryTimeout,omitempty"`
+
+	// WatchResyncDuration is the period after which the watches of the resources of a cluster are restarted.
+	// Defaults to 10m.
+	// +optional
+	WatchResyncDuration *metav1.Duration `json:"watchResyncDuration,omitempty"`
+}
+
+// ArgoCDApplicationControllerPersistenceSpec defines the options for the volume persisting the state of the Argo CD
+// Application Controller between restarts.
+type ArgoCDApplicationControllerPersistenceSpec struct {
+	// Enabled mounts a PersistentVolumeClaim onto each Application Controller replica, created from a volume claim
+	// template of the StatefulSet.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MountPath is the path the volume is mounted at in the Application Controller container. Defaults to
+	// /home/argocd/cache.
+	MountPath string `json:"mountPath,omitempty"`
+
+	// StorageClass is the name of the StorageClass of the volume. The cluster default is used when not set.
+	StorageClass *string `json:"storageClass,omitempty"`
+
+	// Size is the requested size of the volume. Defaults to 1Gi.
+	Size *resource.Quantity `json:"size,omitempty"`
+}
+
 // ArgoCDApplicationControllerProcessorsSpec defines the options for the ArgoCD Application Controller processors.
 type ArgoCDApplicationControllerProcessorsSpec struct {
 	// Operation is the number of application operation processors.
@@ -107,6 +165,37 @@ type ArgoCDApplicationControllerSpec struct {
 	// +optional
 	AppSync *metav1.Duration `json:"appSync,omitempty"`
 
+	// AppHardResync is the period after which the Applications are refreshed with a hard refresh, bypassing the
+	// manifests cache. Hard refreshes are disabled by default.
+	// +optional
+	AppHardResync *metav1.Duration `json:"appHardResync,omitempty"`
+
+	// SelfHealTimeout is the delay before self-healing an out of sync Application again. Defaults to 5s.
+	// +optional
+	SelfHealTimeout *metav1.Duration `json:"selfHealTimeout,omitempty"`
+
+	// RepoServerTimeout is the timeout of the requests to the Repo server. Defaults to 60s.
+	// +optional
+	RepoServerTimeout *metav1.Duration `json:"repoServerTimeout,omitempty"`
+
+	// RepoErrorGracePeriod is the period during which errors of the Repo server are ignored before the Applications
+	// are reported with an Unknown sync status. Defaults to the AppSync period.
+	// +optional
+	RepoErrorGracePeriod *metav1.Duration `json:"repoErrorGracePeriod,omitempty"`
+
+	// StatusCacheExpiration is the expiration of the cached Application state. Defaults to 1h.
+	// +optional
+	StatusCacheExpiration *metav1.Duration `json:"statusCacheExpiration,omitempty"`
+
+	// DefaultCacheExpiration is the expiration of the other cached data of the Application Controller. Defaults to 24h.
+	// +optional
+	DefaultCacheExpiration *metav1.Duration `json:"defaultCacheExpiration,omitempty"`
+
+	// ClusterCache contains the tuning options of the cache of the resources of the managed clusters, e.g. to list the
+	// resources of very large clusters with smaller pages.
+	// +optional
+	ClusterCache *ArgoCDApplicationControllerClusterCacheSpec `json:"clusterCache,omitempty"`
+
 	// Sharding contains the options for the Application Controller sharding configuration.
 	Sharding ArgoCDApplicationControllerShardSpec `json:"sharding,omitempty"`
 
@@ -128,6 +217,52 @@ type ArgoCDApplicationControllerSpec struct {
 
 	// VolumeMounts adds volumeMounts to the Argo CD Controller container.
 	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// Persistence defines the volume persisting the state of the Application Controller between restarts, e.g. the
+	// snapshot of its cluster cache, to avoid resyncing the whole clusters after a restart.
+	Persistence *ArgoCDApplicationControllerPersistenceSpec `json:"persistence,omitempty"`
+
+	// DNSConfig defines the DNS parameters of the Application Controller pods, such as additional nameservers or search domains, which
+	// are merged with the configuration generated from DNSPolicy.
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// DNSPolicy is the DNS policy of the Application Controller pods. Defaults to ClusterFirst. The None policy requires DNSConfig.
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// PodAnnotations are added to the annotations of the Application Controller pods only, e.g. to configure their service mesh
+	// sidecar or the scraping of their metrics.
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// PodLabels are added to the labels of the Application Controller pods only, e.g. sidecar.istio.io/inject to opt them in a
+	// service mesh. The label selecting the pods cannot be overridden.
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// PodSecurityContext defines the pod-level security attributes of the Application Controller pods, replacing the defaults set by
+	// the operator, which run the pods as non-root with the RuntimeDefault seccomp profile.
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// PreStopDelaySeconds is the time the Application Controller keeps running after its pod is asked to stop, so that
+	// in-flight sync operations can complete before it receives SIGTERM, for instance when a node is drained. When set,
+	// the termination grace period defaults to this delay plus 30 seconds.
+	PreStopDelaySeconds *int64 `json:"preStopDelaySeconds,omitempty"`
+
+	// RBAC defines how the permissions of the Application Controller are generated. One of full (default), aggregated or
+	// minimal. The aggregated mode uses aggregated ClusterRoles, as spec.aggregatedClusterRoles does, and the minimal mode
+	// only grants access to the resource types listed in spec.resourceInclusions.
+	// +kubebuilder:validation:Enum=full;aggregated;minimal
+	RBAC ArgoCDControllerRBACMode `json:"rbac,omitempty"`
+
+	// SecurityContext defines the security options of the Application Controller container, replacing the defaults set by the operator.
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// TerminationGracePeriodSeconds is the time given to the Application Controller pods to shut down gracefully before they are killed.
+	// Defaults to the Kubernetes default of 30 seconds.
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// ServiceAccountAnnotations are the annotations set on the ServiceAccount of the Application Controller, e.g. to bind it
+	// to a cloud identity with eks.amazonaws.com/role-arn or iam.gke.io/gcp-service-account.
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
 }
 
 func (a *ArgoCDApplicationControllerSpec) IsEnabled() bool {
@@ -156,6 +291,62 @@ type ArgoCDApplicationControllerShardSpec struct {
 	// ClustersPerShard defines the maximum number of clusters managed by each argocd shard
 	// +kubebuilder:validation:Minimum=1
 	ClustersPerShard int32 `json:"clustersPerShard,omitempty"`
+
+	// AntiAffinityMode defines how the application controller shards are spread across nodes. With soft (default),
+	// the shards are preferably scheduled on different nodes, with hard they are required to run on different nodes.
+	// +kubebuilder:validation:Enum=soft;hard
+	AntiAffinityMode string `json:"antiAffinityMode,omitempty"`
+
+	// Algorithm defines how the clusters are distributed across the application controller shards. Defaults to the
+	// Argo CD default, legacy, which hashes the ID of each cluster. round-robin balances the number of clusters per
+	// shard, and consistent-hashing limits the clusters moved when the number of shards changes.
+	// +kubebuilder:validation:Enum=legacy;round-robin;consistent-hashing
+	Algorithm string `json:"algorithm,omitempty"`
+}
+
+const (
+	// ShardAntiAffinityModeSoft prefers scheduling application controller shards on different nodes.
+	ShardAntiAffinityModeSoft = "soft"
+
+	// ShardAntiAffinityModeHard requires scheduling application controller shards on different nodes.
+	ShardAntiAffinityModeHard = "hard"
+
+	// ShardingAlgorithmLegacy assigns each cluster to the shard of the hash of its ID.
+	ShardingAlgorithmLegacy = "legacy"
+
+	// ShardingAlgorithmRoundRobin assigns the clusters, sorted by ID, to the shards in turn.
+	ShardingAlgorithmRoundRobin = "round-robin"
+
+	// ShardingAlgorithmConsistentHashing assigns the clusters with a consistent hashing with bounded loads.
+	ShardingAlgorithmConsistentHashing = "consistent-hashing"
+)
+
+// ArgoCDAdminPasswordPolicySpec defines how the password of the admin user is managed.
+type ArgoCDAdminPasswordPolicySpec struct {
+	// RotateEvery is the interval at which the operator generates a new admin password, e.g. 720h. The password is
+	// not rotated when unset. Rotating the password invalidates the sessions of the admin user.
+	RotateEvery *metav1.Duration `json:"rotateEvery,omitempty"`
+
+	// SecretRef references a Secret in the namespace of the Argo CD instance the admin password is read from and
+	// published to, under the admin.password key, instead of the <name>-cluster Secret. The Secret is created if it
+	// does not exist.
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// DisableAfterSSO disables the admin user while the SSO provider of spec.sso is running, so that the admin
+	// password only remains usable to recover from an SSO outage.
+	DisableAfterSSO bool `json:"disableAfterSSO,omitempty"`
+}
+
+// ArgoCDBackupLabelsSpec defines how the resources of an Argo CD instance are labeled for Velero backups.
+type ArgoCDBackupLabelsSpec struct {
+	// Enabled adds the argocd.argoproj.io/backup=true label to the resources created by the operator, and to the
+	// Secrets holding the repositories and clusters of the instance, so that a Velero Backup selecting that label
+	// snapshots the state of the instance.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// StorageLocation is the name of the Velero BackupStorageLocation the labeled resources are meant to be backed
+	// up to, set in the argocd.argoproj.io/backup-storage-location annotation of the resources.
+	StorageLocation string `json:"storageLocation,omitempty"`
 }
 
 // ArgoCDApplicationSet defines whether the Argo CD ApplicationSet controller should be installed.
@@ -179,6 +370,11 @@ type ArgoCDApplicationSet struct {
 	// Resources defines the Compute Resources required by the container for ApplicationSet.
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// Replicas defines the number of replicas for the ApplicationSet controller. Leader election is enabled
+	// when more than one replica is requested, so that a standby replica takes over on failure. (optional)
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
 	// LogLevel describes the log level that should be used by the ApplicationSet controller. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug,info, error, and warn.
 	LogLevel string `json:"logLevel,omitempty"`
 
@@ -195,8 +391,84 @@ type ArgoCDApplicationSet struct {
 
 	// SCMProviders defines the list of allowed custom SCM provider API URLs
 	SCMProviders []string `json:"scmProviders,omitempty"`
+
+	// Policy restricts the changes the ApplicationSet controller makes to the Applications it generates: sync
+	// (default) creates, updates and deletes them, create-only only creates them, create-update does not delete them
+	// and create-delete does not update them.
+	// +kubebuilder:validation:Enum=sync;create-only;create-update;create-delete
+	Policy ArgoCDApplicationSetPolicy `json:"policy,omitempty"`
+
+	// EnableProgressiveSyncs enables the progressive syncs of the Applications generated by an ApplicationSet, which
+	// are rolled out step by step following the strategy of the ApplicationSet.
+	EnableProgressiveSyncs bool `json:"enableProgressiveSyncs,omitempty"`
+
+	// DryRun runs the ApplicationSet controller without creating, updating or deleting any Application, so that the
+	// changes it would make can be reviewed in its logs.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DNSConfig defines the DNS parameters of the ApplicationSet Controller pods, such as additional nameservers or search domains, which
+	// are merged with the configuration generated from DNSPolicy.
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// DNSPolicy is the DNS policy of the ApplicationSet Controller pods. Defaults to ClusterFirst. The None policy requires DNSConfig.
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// PodAnnotations are added to the annotations of the ApplicationSet Controller pods only, e.g. to configure their service mesh
+	// sidecar or the scraping of their metrics.
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// PodLabels are added to the labels of the ApplicationSet Controller pods only, e.g. sidecar.istio.io/inject to opt them in a
+	// service mesh. The label selecting the pods cannot be overridden.
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// PodSecurityContext defines the pod-level security attributes of the ApplicationSet Controller pods, replacing the defaults set by
+	// the operator, which run the pods as non-root with the RuntimeDefault seccomp profile.
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext defines the security options of the ApplicationSet Controller container, replacing the defaults set by the operator.
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// TerminationGracePeriodSeconds is the time given to the ApplicationSet Controller pods to shut down gracefully before they are killed.
+	// Defaults to the Kubernetes default of 30 seconds.
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// ServiceAccountAnnotations are the annotations set on the ServiceAccount of the ApplicationSet Controller, e.g. to bind it
+	// to a cloud identity with eks.amazonaws.com/role-arn or iam.gke.io/gcp-service-account.
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+
+	// InitContainers defines the list of initialization containers for the ApplicationSet Controller pods.
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// SidecarContainers defines the list of sidecar containers for the ApplicationSet Controller pods, e.g. the
+	// helper processes of plugin generators.
+	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
+
+	// Volumes adds volumes to the ApplicationSet Controller pods.
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts adds volumeMounts to the ApplicationSet Controller container.
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
 }
 
+// ArgoCDApplicationSetPolicy is the policy of the ApplicationSet controller, restricting the changes it makes to the
+// Applications it generates.
+type ArgoCDApplicationSetPolicy string
+
+const (
+	// ArgoCDApplicationSetPolicySync lets the ApplicationSet controller create, update and delete Applications.
+	ArgoCDApplicationSetPolicySync ArgoCDApplicationSetPolicy = "sync"
+
+	// ArgoCDApplicationSetPolicyCreateOnly only lets the ApplicationSet controller create Applications.
+	ArgoCDApplicationSetPolicyCreateOnly ArgoCDApplicationSetPolicy = "create-only"
+
+	// ArgoCDApplicationSetPolicyCreateUpdate lets the ApplicationSet controller create and update Applications.
+	ArgoCDApplicationSetPolicyCreateUpdate ArgoCDApplicationSetPolicy = "create-update"
+
+	// ArgoCDApplicationSetPolicyCreateDelete lets the ApplicationSet controller create and delete Applications.
+	ArgoCDApplicationSetPolicyCreateDelete ArgoCDApplicationSetPolicy = "create-delete"
+)
+
 func (a *ArgoCDApplicationSet) IsEnabled() bool {
 	return a.Enabled == nil || (a.Enabled != nil && *a.Enabled)
 }
@@ -243,6 +515,63 @@ type ArgoCDDexSpec struct {
 
 	// Env lets you specify environment variables for Dex.
 	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// DNSConfig defines the DNS parameters of the Dex pods, such as additional nameservers or search domains, which
+	// are merged with the configuration generated from DNSPolicy.
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// DNSPolicy is the DNS policy of the Dex pods. Defaults to ClusterFirst. The None policy requires DNSConfig.
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// PodSecurityContext defines the pod-level security attributes of the Dex pods, replacing the defaults set by
+	// the operator, which run the pods as non-root with the RuntimeDefault seccomp profile.
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext defines the security options of the Dex container, replacing the defaults set by the operator.
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// TerminationGracePeriodSeconds is the time given to the Dex pods to shut down gracefully before they are killed.
+	// Defaults to the Kubernetes default of 30 seconds.
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// StaticClients are additional OAuth2 clients registered in Dex, so that CLI tools and other applications can
+	// use the Dex server of Argo CD as an OIDC provider. Public clients use PKCE instead of a client secret.
+	StaticClients []ArgoCDDexStaticClient `json:"staticClients,omitempty"`
+
+	// RootCASecretRef references the key of a Secret holding PEM encoded CA certificates trusted by Dex in addition to
+	// the system ones, e.g. to connect to LDAP or OIDC endpoints signed by a private CA.
+	RootCASecretRef *corev1.SecretKeySelector `json:"rootCASecretRef,omitempty"`
+
+	// Volumes adds volumes to the Dex pods.
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts adds volumeMounts to the Dex container.
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// StartupProbe overrides the startup probe of the Dex container, which holds off the liveness probe until Dex has
+	// started. The fields set override the ones of the default probe, the others are kept.
+	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
+}
+
+// ArgoCDDexStaticClient defines an OAuth2 client registered in Dex.
+type ArgoCDDexStaticClient struct {
+	// ID is the client ID.
+	//+kubebuilder:validation:MinLength=1
+	ID string `json:"id"`
+
+	// Name is the display name of the client.
+	Name string `json:"name,omitempty"`
+
+	// SecretRef is the key of the Secret holding the client secret. The Secret must be in the namespace of the
+	// ArgoCD, and labeled with app.kubernetes.io/part-of=argocd so that Argo CD can read it.
+	SecretRef *corev1.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// RedirectURIs are the allowed redirect URIs of the client.
+	RedirectURIs []string `json:"redirectURIs,omitempty"`
+
+	// Public marks the client as public, e.g. a CLI or single page application that cannot keep a secret.
+	Public bool `json:"public,omitempty"`
 }
 
 // ArgoCDGrafanaSpec defines the desired state for the Grafana component.
@@ -251,6 +580,10 @@ type ArgoCDGrafanaSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// External registers the Prometheus of Argo CD as a datasource of an existing Grafana, instead of deploying one.
+	// Only the datasource is registered, the Argo CD dashboards are not provisioned.
+	External *ArgoCDGrafanaExternalSpec `json:"external,omitempty"`
+
 	// Host is the hostname to use for Ingress/Route resources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
@@ -278,6 +611,21 @@ type ArgoCDGrafanaSpec struct {
 	Version string `json:"version,omitempty"`
 }
 
+// ArgoCDGrafanaExternalSpec defines an existing Grafana the Prometheus of Argo CD is registered in as a datasource,
+// through a Secret provisioned by the Grafana datasource sidecar. The Argo CD dashboards must be imported separately.
+type ArgoCDGrafanaExternalSpec struct {
+	// URL is the URL of the existing Grafana, reported as the grafana endpoint in the status.
+	URL string `json:"url,omitempty"`
+
+	// DatasourceSecretRef is the Secret the operator writes the datasource into, labeled grafana_datasource=1 for the
+	// Grafana sidecar. Defaults to <argocd-name>-grafana-datasource.
+	DatasourceSecretRef *corev1.LocalObjectReference `json:"datasourceSecretRef,omitempty"`
+
+	// PrometheusURL is the URL of the Prometheus scraping the metrics of Argo CD. Defaults to the Prometheus deployed
+	// for the Argo CD instance.
+	PrometheusURL string `json:"prometheusURL,omitempty"`
+}
+
 // ArgoCDHASpec defines the desired state for High Availability support for Argo CD.
 type ArgoCDHASpec struct {
 	// Enabled will toggle HA support globally for Argo CD.
@@ -292,6 +640,164 @@ type ArgoCDHASpec struct {
 
 	// Resources defines the Compute Resources required by the container for HA.
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// RedisProxyMetrics will toggle the Prometheus metrics endpoint of the Redis HAProxy.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Redis Proxy Metrics",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:HA","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	RedisProxyMetrics bool `json:"redisProxyMetrics,omitempty"`
+
+	// SentinelPort is the port the Redis Sentinels listen on, and the Redis HAProxy queries them on. Defaults to 26379.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	SentinelPort int32 `json:"sentinelPort,omitempty"`
+
+	// HAProxy defines the configuration of the Redis HAProxy.
+	HAProxy *ArgoCDHAProxySpec `json:"haproxy,omitempty"`
+}
+
+// ArgoCDHAProxySpec defines the configuration of the Redis HAProxy rendered into its haproxy.cfg.
+type ArgoCDHAProxySpec struct {
+	// Timeouts defines the timeouts of the connections to the Redis servers and Sentinels.
+	Timeouts *ArgoCDHAProxyTimeoutsSpec `json:"timeouts,omitempty"`
+
+	// MaxConn is the maximum number of concurrent connections accepted by the Redis HAProxy.
+	// +kubebuilder:validation:Minimum=1
+	MaxConn int32 `json:"maxConn,omitempty"`
+
+	// AdditionalBackends are the backends added to the haproxy.cfg, e.g. to be used by the ConfigOverrides.
+	AdditionalBackends []ArgoCDHAProxyBackendSpec `json:"additionalBackends,omitempty"`
+
+	// ConfigOverrides is the configuration appended as is to the end of the haproxy.cfg, after the sections set by
+	// the operator.
+	ConfigOverrides string `json:"configOverrides,omitempty"`
+}
+
+// ArgoCDHAProxyTimeoutsSpec defines the timeouts of the Redis HAProxy. Each timeout uses the HAProxy time format,
+// e.g. 4s or 6m.
+type ArgoCDHAProxyTimeoutsSpec struct {
+	// Connect is the maximum time to wait for a connection to a Redis server to succeed. Defaults to 4s.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(us|ms|s|m|h|d)?$`
+	Connect string `json:"connect,omitempty"`
+
+	// Server is the maximum inactivity time on the Redis server side. Defaults to 6m.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(us|ms|s|m|h|d)?$`
+	Server string `json:"server,omitempty"`
+
+	// Client is the maximum inactivity time on the client side. Defaults to 6m.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(us|ms|s|m|h|d)?$`
+	Client string `json:"client,omitempty"`
+
+	// Check is the additional read timeout of the health checks, once the connection is established. Defaults to 2s.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(us|ms|s|m|h|d)?$`
+	Check string `json:"check,omitempty"`
+}
+
+// ArgoCDHAProxyBackendSpec defines a backend added to the haproxy.cfg of the Redis HAProxy.
+type ArgoCDHAProxyBackendSpec struct {
+	// Name is the name of the backend.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_.:-]+$`
+	Name string `json:"name"`
+
+	// Config are the lines of the backend section, e.g. its mode and servers.
+	Config []string `json:"config,omitempty"`
+}
+
+// ArgoCDImageOverridesSpec defines the container images of the Argo CD components for a node architecture. Each
+// image is a full image reference, and replaces the default image of the components.
+type ArgoCDImageOverridesSpec struct {
+	// Argo is the image of the Argo CD components: server, repo server, application controller, ApplicationSet and
+	// notifications controllers.
+	Argo string `json:"argo,omitempty"`
+
+	// Dex is the image of the Dex server.
+	Dex string `json:"dex,omitempty"`
+
+	// Redis is the image of the Redis servers.
+	Redis string `json:"redis,omitempty"`
+
+	// RedisHAProxy is the image of the Redis HA proxy.
+	RedisHAProxy string `json:"redisHAProxy,omitempty"`
+}
+
+// ArgoCDImageUpdaterApplicationsAPI is the API used by the Argo CD Image Updater to access the Applications.
+type ArgoCDImageUpdaterApplicationsAPI string
+
+const (
+	// ArgoCDImageUpdaterApplicationsAPIKubernetes accesses the Applications through the Kubernetes API.
+	ArgoCDImageUpdaterApplicationsAPIKubernetes ArgoCDImageUpdaterApplicationsAPI = "kubernetes"
+
+	// ArgoCDImageUpdaterApplicationsAPIArgoCD accesses the Applications through the Argo CD API server.
+	ArgoCDImageUpdaterApplicationsAPIArgoCD ArgoCDImageUpdaterApplicationsAPI = "argocd"
+)
+
+// ArgoCDImageUpdaterConfigSpec defines the configuration of the Argo CD Image Updater.
+type ArgoCDImageUpdaterConfigSpec struct {
+	// ApplicationsAPI is the API used to access the Applications, either kubernetes or argocd. Defaults to kubernetes.
+	// With the argocd API, the Image Updater authenticates to the Argo CD API server with the token stored in the
+	// argocd.token key of the <argocd-name>-image-updater-secret Secret.
+	//+kubebuilder:validation:Enum=kubernetes;argocd
+	ApplicationsAPI ArgoCDImageUpdaterApplicationsAPI `json:"applicationsAPI,omitempty"`
+
+	// Registries is the content of the registries.conf file, configuring the container registries.
+	Registries string `json:"registries,omitempty"`
+
+	// GitCommitUser is the user name used for the git write-back commits.
+	GitCommitUser string `json:"gitCommitUser,omitempty"`
+
+	// GitCommitEmail is the e-mail address used for the git write-back commits.
+	GitCommitEmail string `json:"gitCommitEmail,omitempty"`
+
+	// Interval is the interval between two image update checks. Defaults to 2m.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// ArgoCDImageUpdaterSpec defines the desired state for the Argo CD Image Updater component.
+type ArgoCDImageUpdaterSpec struct {
+	// Enabled defines whether the Argo CD Image Updater should be deployed or not.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:ImageUpdater","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled"`
+
+	// Image is the Argo CD Image Updater container image.
+	Image string `json:"image,omitempty"`
+
+	// Version is the Argo CD Image Updater container image tag.
+	Version string `json:"version,omitempty"`
+
+	// Config defines the configuration of the Argo CD Image Updater.
+	Config ArgoCDImageUpdaterConfigSpec `json:"config,omitempty"`
+
+	// Env lets you specify environment variables for the Argo CD Image Updater pods.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// LogLevel describes the log level that should be used by the Argo CD Image Updater. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug, info, error, and warn.
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// Resources defines the Compute Resources required by the container for the Argo CD Image Updater.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// ArgoCDInheritFromKind is the kind of the resource an ArgoCD instance inherits its spec from.
+// +kubebuilder:validation:Enum=ArgoCD;ConfigMap
+type ArgoCDInheritFromKind string
+
+const (
+	// ArgoCDInheritFromKindArgoCD inherits from the spec of an ArgoCD resource.
+	ArgoCDInheritFromKindArgoCD ArgoCDInheritFromKind = "ArgoCD"
+
+	// ArgoCDInheritFromKindConfigMap inherits from an ArgoCD spec held in YAML under the "spec" key of a ConfigMap.
+	ArgoCDInheritFromKindConfigMap ArgoCDInheritFromKind = "ConfigMap"
+)
+
+// ArgoCDInheritFromSpec references the resource an ArgoCD instance inherits its spec from. The resource must carry
+// the argocd.argoproj.io/template=true label.
+type ArgoCDInheritFromSpec struct {
+	// Kind is the kind of the referenced resource, either ArgoCD or ConfigMap. Defaults to ArgoCD.
+	Kind ArgoCDInheritFromKind `json:"kind,omitempty"`
+
+	// Name is the name of the referenced resource.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the referenced resource. Defaults to the namespace of the instance.
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // ArgoCDImportSpec defines the desired state for the ArgoCD import/restore process.
@@ -314,6 +820,11 @@ type ArgoCDIngressSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingress Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// Hosts are the additional hostnames of the Ingress, next to the host of the component, e.g. a wildcard host such
+	// as *.argocd.example.com. Only honored by the Argo CD Server Ingress.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+
 	// IngressClassName for the Ingress resource.
 	IngressClassName *string `json:"ingressClassName,omitempty"`
 
@@ -327,6 +838,16 @@ type ArgoCDIngressSpec struct {
 	// ingress supports SNI.
 	// +optional
 	TLS []networkingv1.IngressTLS `json:"tls,omitempty"`
+
+	// TLSIssuer is the name of the cert-manager issuer requesting the certificate of the Ingress. Only honored by the
+	// Argo CD Server Ingress.
+	// +optional
+	TLSIssuer string `json:"tlsIssuer,omitempty"`
+
+	// TLSIssuerKind is the kind of the cert-manager issuer, either Issuer (default) or ClusterIssuer.
+	//+kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +optional
+	TLSIssuerKind string `json:"tlsIssuerKind,omitempty"`
 }
 
 // ArgoCDKeycloakSpec defines the desired state for the Keycloak component.
@@ -348,6 +869,76 @@ type ArgoCDKeycloakSpec struct {
 
 	// Host is the hostname to use for Ingress/Route resources.
 	Host string `json:"host,omitempty"`
+
+	// Database is the PostgreSQL database Keycloak stores its state in, so that the Argo CD realm survives the
+	// restarts of Keycloak. Keycloak uses its ephemeral embedded database when not set. Only supported when Keycloak
+	// is not installed from an OpenShift Template.
+	Database *ArgoCDKeycloakDatabaseSpec `json:"database,omitempty"`
+
+	// PodSecurityContext defines the pod-level security attributes of the Keycloak pods, replacing the defaults set by
+	// the operator, which run the pods as non-root with the RuntimeDefault seccomp profile. Only supported when
+	// Keycloak is not installed from an OpenShift Template.
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext defines the security options of the Keycloak container, replacing the defaults set by the
+	// operator. Only supported when Keycloak is not installed from an OpenShift Template.
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// StartupProbe overrides the startup probe of the Keycloak container, which holds off the liveness and readiness
+	// probes until Keycloak has started, e.g. to allow for a longer first boot. The fields set override the ones of
+	// the default probe, the others are kept.
+	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
+}
+
+// ArgoCDKeycloakDatabaseSpec defines the database of Keycloak. Exactly one of External and ManagedPostgres must be
+// set.
+type ArgoCDKeycloakDatabaseSpec struct {
+	// External points Keycloak at an existing PostgreSQL database.
+	External *ArgoCDKeycloakExternalDatabaseSpec `json:"external,omitempty"`
+
+	// ManagedPostgres deploys a PostgreSQL StatefulSet for Keycloak, with its data on a PersistentVolumeClaim.
+	ManagedPostgres *ArgoCDKeycloakManagedPostgresSpec `json:"managedPostgres,omitempty"`
+}
+
+// ArgoCDKeycloakExternalDatabaseSpec defines an existing PostgreSQL database used by Keycloak.
+type ArgoCDKeycloakExternalDatabaseSpec struct {
+	// Host is the hostname of the PostgreSQL server.
+	Host string `json:"host"`
+
+	// Port is the port of the PostgreSQL server. Defaults to 5432.
+	Port int32 `json:"port,omitempty"`
+
+	// Database is the name of the database. Defaults to keycloak.
+	Database string `json:"database,omitempty"`
+
+	// SecretRef references a Secret in the namespace of the Argo CD instance holding the username and password keys
+	// Keycloak connects to the database with.
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+}
+
+// ArgoCDKeycloakManagedPostgresSpec defines the PostgreSQL StatefulSet deployed for Keycloak.
+type ArgoCDKeycloakManagedPostgresSpec struct {
+	// StorageClass is the name of the StorageClass of the volume of the database. The cluster default is used when
+	// not set. Changes are not applied to an existing database, see the KeycloakDatabaseStorageDrifted condition.
+	StorageClass *string `json:"storageClass,omitempty"`
+
+	// Size is the requested size of the volume of the database. Defaults to 1Gi. Changes are not applied to an
+	// existing database, see the KeycloakDatabaseStorageDrifted condition.
+	Size *resource.Quantity `json:"size,omitempty"`
+}
+
+// ArgoCDLabelPolicySpec defines the default labels set on the resources of an Argo CD instance.
+type ArgoCDLabelPolicySpec struct {
+	// AdditionalLabels are added to the default labels of the resources, e.g. to identify the owning team or the
+	// environment. They are merged over the labels of the ARGOCD_DEFAULT_LABELS environment variable of the operator,
+	// and cannot override the app.kubernetes.io/name, part-of and managed-by labels.
+	AdditionalLabels map[string]string `json:"additionalLabels,omitempty"`
+
+	// ManagedBy is the value of the app.kubernetes.io/managed-by label of the resources. Defaults to the
+	// ARGOCD_MANAGED_BY_LABEL_VALUE environment variable of the operator, or the name of the Argo CD instance.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`
+	ManagedBy string `json:"managedBy,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -382,6 +973,54 @@ type ArgoCDNotifications struct {
 
 	// LogLevel describes the log level that should be used by the argocd-notifications. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug,info, error, and warn.
 	LogLevel string `json:"logLevel,omitempty"`
+
+	// DNSConfig defines the DNS parameters of the Notifications Controller pods, such as additional nameservers or search domains, which
+	// are merged with the configuration generated from DNSPolicy.
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// DNSPolicy is the DNS policy of the Notifications Controller pods. Defaults to ClusterFirst. The None policy requires DNSConfig.
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// PodSecurityContext defines the pod-level security attributes of the Notifications Controller pods, replacing the defaults set by
+	// the operator, which run the pods as non-root with the RuntimeDefault seccomp profile.
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext defines the security options of the Notifications Controller container, replacing the defaults set by the operator.
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// TerminationGracePeriodSeconds is the time given to the Notifications Controller pods to shut down gracefully before they are killed.
+	// Defaults to the Kubernetes default of 30 seconds.
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// RootCASecretRef references the key of a Secret holding PEM encoded CA certificates trusted by the Notifications
+	// Controller in addition to the system ones, e.g. to reach webhook or chat endpoints signed by a private CA, or a
+	// TLS intercepting proxy.
+	RootCASecretRef *corev1.SecretKeySelector `json:"rootCASecretRef,omitempty"`
+
+	// Volumes adds volumes to the Notifications Controller pods.
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts adds volumeMounts to the Notifications Controller container.
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// Subscriptions are the default subscriptions of the Applications managed by the instance, rendered into the
+	// subscriptions key of the notifications ConfigMap, so that notifications can be sent without annotating every
+	// Application.
+	Subscriptions []ArgoCDNotificationsSubscription `json:"subscriptions,omitempty"`
+}
+
+// ArgoCDNotificationsSubscription defines a default subscription of the Applications to notifications.
+type ArgoCDNotificationsSubscription struct {
+	// Recipients are the destinations of the notifications, as <service>:<recipient>, e.g. slack:my-channel.
+	// +kubebuilder:validation:MinItems=1
+	Recipients []string `json:"recipients"`
+
+	// Triggers are the triggers sending the notifications, all the triggers when empty.
+	Triggers []string `json:"triggers,omitempty"`
+
+	// Selector is the label selector of the Applications subscribed, e.g. team=platform, all the Applications when empty.
+	Selector string `json:"selector,omitempty"`
 }
 
 // ArgoCDPrometheusSpec defines the desired state for the Prometheus component.
@@ -397,14 +1036,35 @@ type ArgoCDPrometheusSpec struct {
 	// Ingress defines the desired state for an Ingress for the Prometheus component.
 	Ingress ArgoCDIngressSpec `json:"ingress,omitempty"`
 
+	// Mode defines whether the operator manages a Prometheus for the ArgoCD, or only generates the ServiceMonitors
+	// scraped by an existing Prometheus of the prometheus-operator. Defaults to managed.
+	// +kubebuilder:validation:Enum=managed;external
+	Mode ArgoCDPrometheusMode `json:"mode,omitempty"`
+
 	// Route defines the desired state for an OpenShift Route for the Prometheus component.
 	Route ArgoCDRouteSpec `json:"route,omitempty"`
 
+	// ServiceMonitorLabels are labels added to the ServiceMonitors of the ArgoCD, matching the serviceMonitorSelector
+	// of the existing Prometheus in external mode.
+	ServiceMonitorLabels map[string]string `json:"serviceMonitorLabels,omitempty"`
+
 	// Size is the replica count for the Prometheus StatefulSet.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Size",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:podCount"}
 	Size *int32 `json:"size,omitempty"`
 }
 
+// ArgoCDPrometheusMode defines how the metrics of an Argo CD instance are collected.
+type ArgoCDPrometheusMode string
+
+const (
+	// ArgoCDPrometheusModeManaged creates a Prometheus scraping the ServiceMonitors of the instance. This is the
+	// default.
+	ArgoCDPrometheusModeManaged ArgoCDPrometheusMode = "managed"
+
+	// ArgoCDPrometheusModeExternal only creates the ServiceMonitors of the instance, scraped by an existing Prometheus.
+	ArgoCDPrometheusModeExternal ArgoCDPrometheusMode = "external"
+)
+
 // ArgoCDRBACSpec defines the desired state for the Argo CD RBAC configuration.
 type ArgoCDRBACSpec struct {
 	// DefaultPolicy is the name of the default role which Argo CD will falls back to, when
@@ -430,6 +1090,37 @@ type ArgoCDRBACSpec struct {
 	// PolicyMatcherMode configures the matchers function mode for casbin.
 	// There are two options for this, 'glob' for glob matcher or 'regex' for regex matcher.
 	PolicyMatcherMode *string `json:"policyMatcherMode,omitempty"`
+
+	// PolicyChunks are parts of the RBAC policy written to their own keys of the RBAC ConfigMap, policy.001.csv,
+	// policy.002.csv and so on, which Argo CD appends to Policy in the order of the chunks.
+	//+kubebuilder:validation:MaxItems=999
+	PolicyChunks []ArgoCDRBACPolicyChunkSpec `json:"policyChunks,omitempty"`
+}
+
+// ArgoCDRBACPolicyChunkSpec defines a part of the RBAC policy of an Argo CD instance.
+type ArgoCDRBACPolicyChunkSpec struct {
+	// Project restricts the policy rules of the chunk to the objects of the given AppProject, and its role assignments
+	// to the roles of the AppProject (optional).
+	Project string `json:"project,omitempty"`
+
+	// Policy is CSV containing user-defined RBAC policies and role definitions, in the same format as Policy.
+	Policy string `json:"policy"`
+}
+
+// ArgoCDRedisExporterSpec defines the desired state for the Redis metrics exporter.
+type ArgoCDRedisExporterSpec struct {
+	// Enabled adds a Prometheus exporter sidecar container to the Redis HA server pods, exposing the Redis metrics,
+	// e.g. the memory usage, on the metrics port.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Image is the Redis exporter container image.
+	Image string `json:"image,omitempty"`
+
+	// Version is the Redis exporter container image tag.
+	Version string `json:"version,omitempty"`
+
+	// Resources defines the Compute Resources required by the Redis exporter container.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // ArgoCDRedisSpec defines the desired state for the Redis server component.
@@ -438,6 +1129,12 @@ type ArgoCDRedisSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Redis","urn:alm:descriptor:com.tectonic.ui:text"}
 	Image string `json:"image,omitempty"`
 
+	// Port is the port Redis listens on, for the standalone Redis as well as the Redis HA servers and HAProxy, and the
+	// port the Argo CD components connect to. Defaults to 6379.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+
 	// Resources defines the Compute Resources required by the container for Redis.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Redis","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
@@ -452,6 +1149,7 @@ type ArgoCDRedisSpec struct {
 	// AutoTLS specifies the method to use for automatic TLS configuration for the redis server
 	// The value specified here can currently be:
 	// - openshift - Use the OpenShift service CA to request TLS config
+	// - self-signed - Use a certificate signed by the operator generated CA of the Argo CD instance
 	AutoTLS string `json:"autotls,omitempty"`
 
 	// Enabled is the flag to enable Redis during ArgoCD installation. (optional, default `true`)
@@ -459,15 +1157,97 @@ type ArgoCDRedisSpec struct {
 
 	// Remote specifies the remote URL of the Redis container. (optional, by default, a local instance managed by the operator is used.)
 	Remote *string `json:"remote,omitempty"`
+
+	// PodSecurityContext defines the pod-level security attributes of the Redis pods, replacing the defaults set by
+	// the operator, which run the pods as non-root with the RuntimeDefault seccomp profile.
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext defines the security options of the Redis container, replacing the defaults set by the operator.
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// TerminationGracePeriodSeconds is the time given to the Redis pods to shut down gracefully before they are killed.
+	// Defaults to the Kubernetes default of 30 seconds, or 60 seconds for the Redis HA server pods.
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// Exporter defines the Prometheus exporter of the Redis HA server pods.
+	Exporter *ArgoCDRedisExporterSpec `json:"exporter,omitempty"`
+
+	// InitResources defines the Compute Resources required by the init container of the Redis HA server pods.
+	// Defaults to the resources of the Redis HA containers.
+	InitResources *corev1.ResourceRequirements `json:"initResources,omitempty"`
+
+	// MaxMemory is the memory limit of the Redis data set, e.g. 512mb or 2gb, after which keys are evicted following
+	// MaxMemoryPolicy. Defaults to 0, no limit. It should be lower than the memory limit of the Redis container.
+	// +kubebuilder:validation:Pattern=`^[0-9]+([kKmMgG][bB]?)?$`
+	MaxMemory string `json:"maxmemory,omitempty"`
+
+	// MaxMemoryPolicy is the eviction policy of Redis once MaxMemory is reached. Defaults to the Redis default,
+	// noeviction, without HA, and to volatile-lru with HA.
+	// +kubebuilder:validation:Enum=noeviction;allkeys-lru;allkeys-lfu;allkeys-random;volatile-lru;volatile-lfu;volatile-random;volatile-ttl
+	MaxMemoryPolicy string `json:"maxmemoryPolicy,omitempty"`
+
+	// Save defines the snapshots of the Redis data set to disk, as pairs of seconds and number of changes, e.g.
+	// "3600 1 300 100". Snapshots are disabled when empty, as the Redis data is a cache rebuilt by Argo CD.
+	// +kubebuilder:validation:Pattern=`^([0-9]+ [0-9]+)( [0-9]+ [0-9]+)*$`
+	Save string `json:"save,omitempty"`
 }
 
 func (a *ArgoCDRedisSpec) IsEnabled() bool {
 	return a.Enabled == nil || (a.Enabled != nil && *a.Enabled)
 }
 
+// ArgoCDRepoCacheSpec defines the options for the volume backing the Argo CD Repo server cache.
+type ArgoCDRepoCacheSpec struct {
+	// Persistent backs the /tmp directory of the Repo server, where the git and helm caches live, with a
+	// PersistentVolumeClaim instead of an emptyDir volume.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Persistent Cache",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Repo","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	Persistent bool `json:"persistent,omitempty"`
+
+	// Ephemeral uses a generic ephemeral volume for each Repo server pod instead of a single PersistentVolumeClaim
+	// shared by all the replicas. The volume is removed together with its pod. Each pod gets its own volume as well when
+	// the Repo server runs several replicas without the ReadWriteMany access mode.
+	Ephemeral bool `json:"ephemeral,omitempty"`
+
+	// StorageClass is the name of the StorageClass of the cache volume. The cluster default is used when not set.
+	StorageClass *string `json:"storageClass,omitempty"`
+
+	// Size is the requested size of the cache volume. Defaults to 10Gi.
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// AccessModes are the access modes of the cache volume. Defaults to ReadWriteOnce.
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+}
+
+// ArgoCDEphemeralStorageSpec defines the local ephemeral storage used by an Argo CD component.
+type ArgoCDEphemeralStorageSpec struct {
+	// SizeLimits sets the sizeLimit of the emptyDir volumes of the component, keyed by volume name, e.g. tmp,
+	// plugins or gpg-keyring. A pod writing more than the limit to a volume is evicted, instead of filling up the
+	// disk of the node.
+	SizeLimits map[string]resource.Quantity `json:"sizeLimits,omitempty"`
+
+	// Request is the ephemeral-storage resource request of the component containers. It is ignored if an
+	// ephemeral-storage request is set in the resources of the component.
+	Request *resource.Quantity `json:"request,omitempty"`
+}
+
+// ArgoCDRepoAutoscaleSpec defines the desired state for autoscaling the Argo CD Repo server component.
+type ArgoCDRepoAutoscaleSpec struct {
+	// Enabled will toggle autoscaling support for the Argo CD Repo server component.
+	Enabled bool `json:"enabled"`
+
+	// KEDA defines the KEDA ScaledObject options for the Argo CD Repo server component.
+	KEDA *ArgoCDKEDASpec `json:"keda,omitempty"`
+}
+
 // ArgoCDRepoSpec defines the desired state for the Argo CD repo server component.
 type ArgoCDRepoSpec struct {
 
+	// Autoscale defines the autoscale options for the Argo CD Repo server component.
+	Autoscale ArgoCDRepoAutoscaleSpec `json:"autoscale,omitempty"`
+
+	// Cache defines the options for the volume backing the git and helm caches of the Repo server.
+	Cache *ArgoCDRepoCacheSpec `json:"cache,omitempty"`
+
 	// Extra Command arguments allows users to pass command line arguments to repo server workload. They get added to default command line arguments provided
 	// by the operator.
 	// Please note that the command line arguments provided as part of ExtraRepoCommandArgs will not overwrite the default command line arguments.
@@ -479,6 +1259,20 @@ type ArgoCDRepoSpec struct {
 	// LogFormat describes the log format that should be used by the Repo Server. Defaults to ArgoCDDefaultLogFormat if not configured. Valid options are text or json.
 	LogFormat string `json:"logFormat,omitempty"`
 
+	// GRPCMaxMessageSizeMB is the maximum size in megabytes of the gRPC messages exchanged with the Repo server, e.g.
+	// the manifests it generates, set on the Repo server, its plugin sidecars, the Server and the Application
+	// Controller. Defaults to the size of Argo CD, 100MB.
+	// +kubebuilder:validation:Minimum=1
+	GRPCMaxMessageSizeMB *int32 `json:"grpcMaxMessageSizeMB,omitempty"`
+
+	// MaxCombinedDirectoryManifestsSize is the maximum total size of the manifests of a directory Application, e.g.
+	// 50M. Defaults to the size of Argo CD, 10M.
+	MaxCombinedDirectoryManifestsSize *resource.Quantity `json:"maxCombinedDirectoryManifestsSize,omitempty"`
+
+	// StreamedManifestMaxExtractedSize is the maximum size of the manifests extracted from the archive streamed to
+	// the Repo server, e.g. a Helm chart, e.g. 2G. Defaults to the size of Argo CD, 1G.
+	StreamedManifestMaxExtractedSize *resource.Quantity `json:"streamedManifestMaxExtractedSize,omitempty"`
+
 	// MountSAToken describes whether you would like to have the Repo server mount the service account token
 	MountSAToken bool `json:"mountsatoken,omitempty"`
 
@@ -515,6 +1309,10 @@ type ArgoCDRepoSpec struct {
 	// Volumes adds volumes to the repo server deployment
 	Volumes []corev1.Volume `json:"volumes,omitempty"`
 
+	// EphemeralStorage defines the size limits of the emptyDir volumes of the repo server, and its ephemeral-storage
+	// request.
+	EphemeralStorage *ArgoCDEphemeralStorageSpec `json:"ephemeralStorage,omitempty"`
+
 	// VolumeMounts adds volumeMounts to the repo server container
 	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
 
@@ -524,47 +1322,240 @@ type ArgoCDRepoSpec struct {
 	// SidecarContainers defines the list of sidecar containers for the repo server deployment
 	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
 
+	// PluginResourceDefaults are the requests and limits of the sidecar containers of the repo server, which serve the
+	// config management plugins, for the resources they do not set, so that a plugin cannot starve the repo server.
+	PluginResourceDefaults *corev1.ResourceRequirements `json:"pluginResourceDefaults,omitempty"`
+
 	// Enabled is the flag to enable Repo Server during ArgoCD installation. (optional, default `true`)
 	Enabled *bool `json:"enabled,omitempty"`
 
 	// Remote specifies the remote URL of the Repo Server container. (optional, by default, a local instance managed by the operator is used.)
 	Remote *string `json:"remote,omitempty"`
+
+	// DNSConfig defines the DNS parameters of the Repo Server pods, such as additional nameservers or search domains, which
+	// are merged with the configuration generated from DNSPolicy.
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// DNSPolicy is the DNS policy of the Repo Server pods. Defaults to ClusterFirst. The None policy requires DNSConfig.
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// PodAnnotations are added to the annotations of the Repo Server pods only, e.g. to configure their service mesh
+	// sidecar or the scraping of their metrics.
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// PodLabels are added to the labels of the Repo Server pods only, e.g. sidecar.istio.io/inject to opt them in a
+	// service mesh. The label selecting the pods cannot be overridden.
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// PodSecurityContext defines the pod-level security attributes of the Repo Server pods, replacing the defaults set by
+	// the operator, which run the pods as non-root with the RuntimeDefault seccomp profile.
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext defines the security options of the Repo Server container, replacing the defaults set by the operator.
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// TerminationGracePeriodSeconds is the time given to the Repo Server pods to shut down gracefully before they are killed.
+	// Defaults to the Kubernetes default of 30 seconds.
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// ReadOnlyRootFilesystem runs all the containers of the Repo Server with a read-only root filesystem. The paths
+	// written by git, helm and GnuPG, and the /tmp of each sidecar, are backed by emptyDir volumes, unless mounted from
+	// the volumes of the Repo Server.
+	ReadOnlyRootFilesystem bool `json:"readOnlyRootFilesystem,omitempty"`
+
+	// ServiceAccountAnnotations are the annotations set on the ServiceAccount of the Repo Server, e.g. to bind it to a
+	// cloud identity with eks.amazonaws.com/role-arn or iam.gke.io/gcp-service-account. The operator creates the
+	// ServiceAccount when annotations are set, unless ServiceAccount names another one.
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
 }
 
 func (a *ArgoCDRepoSpec) IsEnabled() bool {
 	return a.Enabled == nil || (a.Enabled != nil && *a.Enabled)
 }
 
-// ArgoCDRouteSpec defines the desired state for an OpenShift Route.
-type ArgoCDRouteSpec struct {
-	// Annotations is the map of annotations to use for the Route resource.
-	Annotations map[string]string `json:"annotations,omitempty"`
+// ArgoCDOpenShiftSpec defines the options for the integration of Argo CD with the OpenShift web console.
+type ArgoCDOpenShiftSpec struct {
+	// ConsoleLink defines the options for a link to the Argo CD Server Route in the OpenShift web console.
+	ConsoleLink *ArgoCDConsoleLinkSpec `json:"consoleLink,omitempty"`
+}
+
+// ArgoCDConsoleLinkLocation is the location of a ConsoleLink in the OpenShift web console.
+type ArgoCDConsoleLinkLocation string
+
+const (
+	// ArgoCDConsoleLinkLocationApplicationMenu shows the link in a section of the application launcher menu.
+	ArgoCDConsoleLinkLocationApplicationMenu ArgoCDConsoleLinkLocation = "ApplicationMenu"
+
+	// ArgoCDConsoleLinkLocationHelpMenu shows the link in the help menu.
+	ArgoCDConsoleLinkLocationHelpMenu ArgoCDConsoleLinkLocation = "HelpMenu"
+
+	// ArgoCDConsoleLinkLocationUserMenu shows the link in the user menu.
+	ArgoCDConsoleLinkLocationUserMenu ArgoCDConsoleLinkLocation = "UserMenu"
+
+	// ArgoCDConsoleLinkLocationNamespaceDashboard shows the link on the dashboard of the namespace of the Argo CD
+	// instance.
+	ArgoCDConsoleLinkLocationNamespaceDashboard ArgoCDConsoleLinkLocation = "NamespaceDashboard"
+)
+
+// ArgoCDConsoleLinkSpec defines the options for a ConsoleLink to the Argo CD Server Route. The ConsoleLink is only
+// created while the Server Route is enabled.
+type ArgoCDConsoleLinkSpec struct {
+	// Enabled will toggle the creation of the ConsoleLink.
+	Enabled bool `json:"enabled"`
+
+	// ImageURL is the URL of the icon shown next to the link in the ApplicationMenu location.
+	ImageURL string `json:"imageURL,omitempty"`
+
+	// Location is where the link is shown in the OpenShift web console. Defaults to ApplicationMenu.
+	// +kubebuilder:validation:Enum=ApplicationMenu;HelpMenu;UserMenu;NamespaceDashboard
+	Location ArgoCDConsoleLinkLocation `json:"location,omitempty"`
+
+	// Section is the section of the ApplicationMenu the link is shown in. Defaults to Argo CD.
+	Section string `json:"section,omitempty"`
+
+	// Text is the text of the link. Defaults to the namespace and name of the Argo CD instance.
+	Text string `json:"text,omitempty"`
+}
+
+// ArgoCDRouteSpec defines the desired state for an OpenShift Route.
+type ArgoCDRouteSpec struct {
+	// Annotations is the map of annotations to use for the Route resource.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels is the map of labels to use for the Route resource
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Enabled will toggle the creation of the OpenShift Route.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Route Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled"`
+
+	// Host is the hostname of the Route, overriding the Host of the component, which is shared with its Ingress.
+	Host string `json:"host,omitempty"`
+
+	// Path the router watches for, to route traffic for to the service.
+	Path string `json:"path,omitempty"`
+
+	// TLS provides the ability to configure certificates and termination for the Route.
+	TLS *routev1.TLSConfig `json:"tls,omitempty"`
+
+	// TLSSecretRef references a Secret of type kubernetes.io/tls, e.g. managed by cert-manager, whose certificate,
+	// key and CA certificate are written into the TLS configuration of the Route, and kept in sync with the Secret.
+	TLSSecretRef *corev1.LocalObjectReference `json:"tlsSecretRef,omitempty"`
+
+	// WildcardPolicy if any for the route. Currently only 'Subdomain' or 'None' is allowed.
+	WildcardPolicy *routev1.WildcardPolicyType `json:"wildcardPolicy,omitempty"`
+}
+
+// ArgoCDServerAutoscaleSpec defines the desired state for autoscaling the Argo CD Server component.
+type ArgoCDServerAutoscaleSpec struct {
+	// Enabled will toggle autoscaling support for the Argo CD Server component.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autoscale Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled"`
+
+	// HPA defines the HorizontalPodAutoscaler options for the Argo CD Server component.
+	HPA *autoscaling.HorizontalPodAutoscalerSpec `json:"hpa,omitempty"`
+
+	// KEDA defines the KEDA ScaledObject options for the Argo CD Server component. When set, a KEDA
+	// ScaledObject is created instead of the HorizontalPodAutoscaler.
+	KEDA *ArgoCDKEDASpec `json:"keda,omitempty"`
+
+	// Mode selects how the Argo CD Server component is autoscaled: hpa, the default, creates a HorizontalPodAutoscaler
+	// or a KEDA ScaledObject, operator lets the operator scale the Deployment from the metrics of the server in
+	// Prometheus, for clusters without metrics-server.
+	//+kubebuilder:validation:Enum=hpa;operator
+	Mode ArgoCDServerAutoscaleMode `json:"mode,omitempty"`
+
+	// Operator defines the options of the operator autoscaler, used when the mode is operator.
+	Operator *ArgoCDServerOperatorAutoscaleSpec `json:"operator,omitempty"`
+}
+
+// ArgoCDServerAutoscaleMode is the autoscaler of the Argo CD Server.
+type ArgoCDServerAutoscaleMode string
+
+const (
+	// ArgoCDServerAutoscaleModeHPA autoscales the Argo CD Server with a HorizontalPodAutoscaler, or a KEDA
+	// ScaledObject when KEDA options are set. This is the default.
+	ArgoCDServerAutoscaleModeHPA ArgoCDServerAutoscaleMode = "hpa"
+
+	// ArgoCDServerAutoscaleModeOperator autoscales the Argo CD Server from its request rate and latency, read from
+	// Prometheus by the operator.
+	ArgoCDServerAutoscaleModeOperator ArgoCDServerAutoscaleMode = "operator"
+)
+
+// ArgoCDServerOperatorAutoscaleSpec defines the options of the operator autoscaler of the Argo CD Server.
+type ArgoCDServerOperatorAutoscaleSpec struct {
+	// PrometheusURL is the URL of the Prometheus API the metrics of the server are read from. Defaults to the
+	// Prometheus of the instance when spec.prometheus.enabled is set.
+	PrometheusURL string `json:"prometheusURL,omitempty"`
+
+	// MinReplicas is the minimum number of replicas of the server. Defaults to 1.
+	//+kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the maximum number of replicas of the server. Defaults to 3.
+	//+kubebuilder:validation:Minimum=1
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// TargetRequestsPerReplica is the rate of gRPC requests per second each replica should serve. Defaults to 50.
+	//+kubebuilder:validation:Minimum=1
+	TargetRequestsPerReplica *int32 `json:"targetRequestsPerReplica,omitempty"`
+
+	// TargetLatency is the 95th percentile latency of the gRPC requests above which a replica is added. Requires the
+	// gRPC time histogram of the server, enabled with ARGOCD_ENABLE_GRPC_TIME_HISTOGRAM. (optional)
+	TargetLatency *metav1.Duration `json:"targetLatency,omitempty"`
+
+	// Interval is the interval between two evaluations of the metrics. Defaults to 1m.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// ScaleDownDelay is the time to wait after a scaling before removing a replica. Defaults to 5m.
+	ScaleDownDelay *metav1.Duration `json:"scaleDownDelay,omitempty"`
+}
+
+// ArgoCDKEDASpec defines the options of the KEDA ScaledObject used to autoscale an Argo CD component.
+type ArgoCDKEDASpec struct {
+	// Triggers defines the KEDA triggers that activate and scale the component.
+	// +kubebuilder:validation:MinItems=1
+	Triggers []ArgoCDKEDATrigger `json:"triggers"`
+
+	// MinReplicaCount is the minimum number of replicas KEDA scales the component down to. (optional)
+	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
+
+	// MaxReplicaCount is the maximum number of replicas KEDA scales the component up to. (optional)
+	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
+
+	// PollingInterval is the interval in seconds at which KEDA checks the triggers. (optional)
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+
+	// CooldownPeriod is the period in seconds KEDA waits after the last active trigger before scaling back down. (optional)
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+}
 
-	// Labels is the map of labels to use for the Route resource
-	Labels map[string]string `json:"labels,omitempty"`
+// ArgoCDKEDATrigger defines a KEDA trigger, such as a Prometheus query or a queue depth.
+type ArgoCDKEDATrigger struct {
+	// Type is the KEDA scaler type of the trigger, e.g. prometheus or cpu.
+	Type string `json:"type"`
 
-	// Enabled will toggle the creation of the OpenShift Route.
-	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Route Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
-	Enabled bool `json:"enabled"`
+	// Name is the name of the trigger. (optional)
+	Name string `json:"name,omitempty"`
 
-	// Path the router watches for, to route traffic for to the service.
-	Path string `json:"path,omitempty"`
+	// MetricType is the type of the metric target of the trigger, one of AverageValue, Value or Utilization. (optional)
+	MetricType string `json:"metricType,omitempty"`
 
-	// TLS provides the ability to configure certificates and termination for the Route.
-	TLS *routev1.TLSConfig `json:"tls,omitempty"`
+	// Metadata is the scaler specific configuration of the trigger.
+	Metadata map[string]string `json:"metadata"`
 
-	// WildcardPolicy if any for the route. Currently only 'Subdomain' or 'None' is allowed.
-	WildcardPolicy *routev1.WildcardPolicyType `json:"wildcardPolicy,omitempty"`
+	// AuthenticationRef references the KEDA TriggerAuthentication used by the trigger. (optional)
+	AuthenticationRef *ArgoCDKEDAAuthenticationRef `json:"authenticationRef,omitempty"`
 }
 
-// ArgoCDServerAutoscaleSpec defines the desired state for autoscaling the Argo CD Server component.
-type ArgoCDServerAutoscaleSpec struct {
-	// Enabled will toggle autoscaling support for the Argo CD Server component.
-	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autoscale Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
-	Enabled bool `json:"enabled"`
+// ArgoCDKEDAAuthenticationRef references a KEDA TriggerAuthentication or ClusterTriggerAuthentication.
+type ArgoCDKEDAAuthenticationRef struct {
+	// Name is the name of the TriggerAuthentication.
+	Name string `json:"name"`
 
-	// HPA defines the HorizontalPodAutoscaler options for the Argo CD Server component.
-	HPA *autoscaling.HorizontalPodAutoscalerSpec `json:"hpa,omitempty"`
+	// Kind is the kind of the referenced resource, either TriggerAuthentication or ClusterTriggerAuthentication. (optional)
+	Kind string `json:"kind,omitempty"`
 }
 
 // ArgoCDServerGRPCSpec defines the desired state for the Argo CD Server GRPC options.
@@ -586,6 +1577,10 @@ type ArgoCDServerSpec struct {
 	// GRPC defines the state for the Argo CD Server GRPC options.
 	GRPC ArgoCDServerGRPCSpec `json:"grpc,omitempty"`
 
+	// GRPCWeb toggles the gRPC-web support of the Argo CD Server, so that clients can reach the API through
+	// ingress controllers that do not support HTTP/2.
+	GRPCWeb bool `json:"grpcWeb,omitempty"`
+
 	// InitContainers defines the list of initialization containers for the Argo CD Server component.
 	InitContainers []corev1.Container `json:"initContainers,omitempty"`
 
@@ -613,12 +1608,22 @@ type ArgoCDServerSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// RootPath is the sub path under which the Argo CD Server is served, e.g. /argocd. When set, it is used
+	// for the --rootpath and --basehref flags and as the default path of the Argo CD Server Ingress.
+	RootPath string `json:"rootPath,omitempty"`
+
+	// RolloutStrategy defines how new versions of the Argo CD Server are rolled out.
+	RolloutStrategy *ArgoCDServerRolloutStrategy `json:"rolloutStrategy,omitempty"`
+
 	// Route defines the desired state for an OpenShift Route for the Argo CD Server component.
 	Route ArgoCDRouteSpec `json:"route,omitempty"`
 
 	// Service defines the options for the Service backing the ArgoCD Server component.
 	Service ArgoCDServerServiceSpec `json:"service,omitempty"`
 
+	// Session defines the options for the user sessions of the Argo CD Server component.
+	Session *ArgoCDServerSessionSpec `json:"session,omitempty"`
+
 	// SidecarContainers defines the list of sidecar containers for the server deployment
 	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
 
@@ -627,28 +1632,156 @@ type ArgoCDServerSpec struct {
 
 	// Extra Command arguments that would append to the Argo CD server command.
 	// ExtraCommandArgs will not be added, if one of these commands is already part of the server command
-	// with same or different value.
+	// with same or different value, unless ExtraCommandArgsForceOverride is set.
 	ExtraCommandArgs []string `json:"extraCommandArgs,omitempty"`
 
+	// ExtraCommandArgsForceOverride replaces the arguments of the server command set by the operator with the
+	// ExtraCommandArgs that conflict with them, instead of dropping the ExtraCommandArgs. The operator can then no
+	// longer ensure the configuration of the server, use with care.
+	ExtraCommandArgsForceOverride bool `json:"extraCommandArgsForceOverride,omitempty"`
+
 	// Enabled is the flag to enable ArgoCD Server during ArgoCD installation. (optional, default `true`)
 	Enabled *bool `json:"enabled,omitempty"`
 
 	// Volumes adds volumes to the Argo CD Server container.
 	Volumes []corev1.Volume `json:"volumes,omitempty"`
 
+	// EphemeralStorage defines the size limits of the emptyDir volumes of the Argo CD Server, and its
+	// ephemeral-storage request.
+	EphemeralStorage *ArgoCDEphemeralStorageSpec `json:"ephemeralStorage,omitempty"`
+
 	// VolumeMounts adds volumeMounts to the Argo CD Server container.
 	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// DNSConfig defines the DNS parameters of the Argo CD Server pods, such as additional nameservers or search domains, which
+	// are merged with the configuration generated from DNSPolicy.
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// DNSPolicy is the DNS policy of the Argo CD Server pods. Defaults to ClusterFirst. The None policy requires DNSConfig.
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// PodAnnotations are added to the annotations of the Argo CD Server pods only, e.g. to configure their service mesh
+	// sidecar or the scraping of their metrics.
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// PodLabels are added to the labels of the Argo CD Server pods only, e.g. sidecar.istio.io/inject to opt them in a
+	// service mesh. The label selecting the pods cannot be overridden.
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// PodSecurityContext defines the pod-level security attributes of the Argo CD Server pods, replacing the defaults set by
+	// the operator, which run the pods as non-root with the RuntimeDefault seccomp profile.
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext defines the security options of the Argo CD Server container, replacing the defaults set by the operator.
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// TerminationGracePeriodSeconds is the time given to the Argo CD Server pods to shut down gracefully before they are killed.
+	// Defaults to the Kubernetes default of 30 seconds.
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// ServiceAccountAnnotations are the annotations set on the ServiceAccount of the Argo CD Server, e.g. to bind it
+	// to a cloud identity with eks.amazonaws.com/role-arn or iam.gke.io/gcp-service-account.
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+
+	// CustomStyles defines a CSS stylesheet customizing the Argo CD UI, which is served by the Argo CD Server and set
+	// as `ui.cssurl` in the argocd-cm ConfigMap.
+	CustomStyles *ArgoCDServerCustomStylesSpec `json:"customStyles,omitempty"`
+
+	// ContentSecurityPolicy is the value of the Content-Security-Policy header of the Argo CD Server responses, e.g. to
+	// allow the UI to load custom styles or fonts from another origin. Defaults to "frame-ancestors 'self';" in Argo CD.
+	ContentSecurityPolicy string `json:"contentSecurityPolicy,omitempty"`
+
+	// XFrameOptions is the value of the X-Frame-Options header of the Argo CD Server responses, e.g. to allow
+	// embedding the UI in another application. Defaults to "sameorigin" in Argo CD.
+	XFrameOptions string `json:"xFrameOptions,omitempty"`
+}
+
+// ArgoCDServerCustomStylesSpec defines the CSS stylesheet customizing the Argo CD UI. CSS is ignored when
+// ConfigMapRef is set.
+type ArgoCDServerCustomStylesSpec struct {
+	// ConfigMapRef references the key of a ConfigMap holding the CSS stylesheet.
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
+
+	// CSS is the CSS stylesheet, which the operator stores in a ConfigMap.
+	CSS string `json:"css,omitempty"`
+}
+
+// ArgoCDServerSessionSpec defines the options for the user sessions of the Argo CD Server component.
+type ArgoCDServerSessionSpec struct {
+	// MaxAge is the lifetime of the session tokens issued by the Argo CD Server on login, set as
+	// `users.session.duration` in the argocd-cm ConfigMap. Defaults to 24h in Argo CD.
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// MaxConcurrent is the maximum number of login requests processed concurrently by the Argo CD Server.
+	// Defaults to 50 in Argo CD, a value of 0 disables the limit.
+	//+kubebuilder:validation:Minimum=0
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
 }
 
 func (a *ArgoCDServerSpec) IsEnabled() bool {
 	return a.Enabled == nil || (a.Enabled != nil && *a.Enabled)
 }
 
+// ArgoCDServerRolloutStrategyType is the type of rollout of the Argo CD Server.
+type ArgoCDServerRolloutStrategyType string
+
+const (
+	// ArgoCDServerRolloutStrategyRollingUpdate replaces the Argo CD Server pods progressively. This is the default.
+	ArgoCDServerRolloutStrategyRollingUpdate ArgoCDServerRolloutStrategyType = "RollingUpdate"
+
+	// ArgoCDServerRolloutStrategyBlueGreen starts a full set of new Argo CD Server pods next to the previous ones,
+	// which keep serving until the new pods pass their /healthz check.
+	ArgoCDServerRolloutStrategyBlueGreen ArgoCDServerRolloutStrategyType = "BlueGreen"
+)
+
+// ArgoCDServerRolloutStrategy defines the rollout options for the Argo CD Server component.
+type ArgoCDServerRolloutStrategy struct {
+	// Type of the rollout, RollingUpdate or BlueGreen. Defaults to RollingUpdate.
+	// +kubebuilder:validation:Enum=RollingUpdate;BlueGreen
+	Type ArgoCDServerRolloutStrategyType `json:"type,omitempty"`
+
+	// MaxSurge is the maximum number of pods that can be created over the desired number of pods during a
+	// RollingUpdate. Defaults to 25%.
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable is the maximum number of pods that can be unavailable during a RollingUpdate. Defaults to 25%.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// MinReadySeconds is the minimum number of seconds a new pod must pass its /healthz check before it is
+	// considered available and previous pods are removed.
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+}
+
 // ArgoCDServerServiceSpec defines the Service options for Argo CD Server component.
 type ArgoCDServerServiceSpec struct {
 	// Type is the ServiceType to use for the Service resource.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Type'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:text"}
 	Type corev1.ServiceType `json:"type"`
+
+	// Annotations is the map of annotations to add to the Service resource, e.g. to configure a cloud load balancer.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels is the map of labels to add to the Service resource.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// LoadBalancerSourceRanges restricts the client IP ranges allowed to reach a LoadBalancer Service.
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
+	// LoadBalancerClass is the class of the load balancer implementation of a LoadBalancer Service. It can only be
+	// set when the Service is created.
+	LoadBalancerClass *string `json:"loadBalancerClass,omitempty"`
+
+	// ExternalTrafficPolicy defines how traffic from outside the cluster is routed by a NodePort or LoadBalancer
+	// Service, either Cluster or Local.
+	// +kubebuilder:validation:Enum=Cluster;Local
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+
+	// IPFamilyPolicy is the IP family policy of the Service, overriding the one set for the whole instance.
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// IPFamilies are the IP families of the Service, overriding the ones set for the whole instance.
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
 }
 
 // Resource Customization for custom health check
@@ -658,6 +1791,34 @@ type ResourceHealthCheck struct {
 	Check string `json:"check,omitempty"`
 }
 
+// ResourceStatusFieldIgnore defines which resources have their status field ignored when diffing.
+type ResourceStatusFieldIgnore string
+
+const (
+	// ResourceStatusFieldIgnoreCRD ignores the status field of custom resources only.
+	ResourceStatusFieldIgnoreCRD ResourceStatusFieldIgnore = "crd"
+
+	// ResourceStatusFieldIgnoreAll ignores the status field of all resources.
+	ResourceStatusFieldIgnoreAll ResourceStatusFieldIgnore = "all"
+
+	// ResourceStatusFieldIgnoreNone does not ignore the status field of any resource.
+	ResourceStatusFieldIgnoreNone ResourceStatusFieldIgnore = "none"
+)
+
+// ResourceCompareOptions defines the options of the `resource.compareoptions` key in the argocd-cm ConfigMap.
+type ResourceCompareOptions struct {
+	// IgnoreAggregatedRoles ignores the rules of aggregated ClusterRoles when diffing.
+	IgnoreAggregatedRoles bool `json:"ignoreAggregatedRoles,omitempty"`
+
+	// IgnoreResourceStatusField defines which resources have their status field ignored when diffing. Defaults to crd in Argo CD.
+	//+kubebuilder:validation:Enum=crd;all;none
+	IgnoreResourceStatusField ResourceStatusFieldIgnore `json:"ignoreResourceStatusField,omitempty"`
+
+	// IgnoreDifferencesOnResourceUpdates also applies the ignored differences when deciding whether a resource needs
+	// to be updated, not only when computing the sync status.
+	IgnoreDifferencesOnResourceUpdates bool `json:"ignoreDifferencesOnResourceUpdates,omitempty"`
+}
+
 // Resource Customization for ignore difference
 type ResourceIgnoreDifference struct {
 	All                 *IgnoreDifferenceCustomization `json:"all,omitempty"`
@@ -684,6 +1845,313 @@ type ResourceAction struct {
 	Action string `json:"action,omitempty"`
 }
 
+// ArgoCDProfileType string defines the sizing preset of an Argo CD instance.
+type ArgoCDProfileType string
+
+const (
+	// ArgoCDProfileSmall is suited for evaluation and instances managing a few dozen applications.
+	ArgoCDProfileSmall ArgoCDProfileType = "small"
+
+	// ArgoCDProfileMedium is suited for instances managing a few hundred applications.
+	ArgoCDProfileMedium ArgoCDProfileType = "medium"
+
+	// ArgoCDProfileLarge is suited for instances managing more than 500 applications.
+	ArgoCDProfileLarge ArgoCDProfileType = "large"
+)
+
+// ArgoCDControllerRBACMode defines how the permissions of the Application Controller are generated.
+type ArgoCDControllerRBACMode string
+
+const (
+	// ArgoCDControllerRBACModeFull grants the Application Controller access to all resources. This is the default.
+	ArgoCDControllerRBACModeFull ArgoCDControllerRBACMode = "full"
+
+	// ArgoCDControllerRBACModeAggregated builds the Application Controller ClusterRole from aggregated ClusterRoles.
+	ArgoCDControllerRBACModeAggregated ArgoCDControllerRBACMode = "aggregated"
+
+	// ArgoCDControllerRBACModeMinimal grants the Application Controller access to the resource types listed in the
+	// resource inclusions only.
+	ArgoCDControllerRBACModeMinimal ArgoCDControllerRBACMode = "minimal"
+)
+
+// ArgoCDAccountCapability is a capability of a local Argo CD account.
+type ArgoCDAccountCapability string
+
+const (
+	// ArgoCDAccountCapabilityLogin allows the account to log in to the UI and the CLI with a password.
+	ArgoCDAccountCapabilityLogin ArgoCDAccountCapability = "login"
+
+	// ArgoCDAccountCapabilityAPIKey allows API tokens to be generated for the account.
+	ArgoCDAccountCapabilityAPIKey ArgoCDAccountCapability = "apiKey"
+)
+
+// ArgoCDAccountSpec defines a local Argo CD account, e.g. for an automation accessing the API server.
+type ArgoCDAccountSpec struct {
+	// Name of the account.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Capabilities of the account.
+	// +kubebuilder:validation:MinItems=1
+	Capabilities []ArgoCDAccountCapability `json:"capabilities"`
+
+	// Enabled is false to disable the account. Accounts are enabled by default.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// TokensSecretRef references a Secret in the namespace of the Argo CD the operator stores an API token of the
+	// account in, under the token key, generating the token when the key is missing. It requires the apiKey
+	// capability. The Secret is created when missing.
+	TokensSecretRef *corev1.LocalObjectReference `json:"tokensSecretRef,omitempty"`
+}
+
+// ArgoCDCryptoPolicy defines the cryptography the components of an Argo CD instance are restricted to.
+type ArgoCDCryptoPolicy string
+
+const (
+	// ArgoCDCryptoPolicyDefault leaves the cryptography of the components unrestricted. This is the default.
+	ArgoCDCryptoPolicyDefault ArgoCDCryptoPolicy = "default"
+
+	// ArgoCDCryptoPolicyFIPS restricts the components to the FIPS 140 validated cryptography.
+	ArgoCDCryptoPolicyFIPS ArgoCDCryptoPolicy = "fips"
+)
+
+// ArgoCDAdoptionMode defines how the operator takes over the existing resources of an Argo CD installed without it,
+// e.g. with the Helm chart.
+type ArgoCDAdoptionMode string
+
+const (
+	// ArgoCDAdoptionModeReport records the existing resources that would be adopted, and the changes the operator
+	// would make, leaving these resources untouched while the other resources of the instance are reconciled.
+	ArgoCDAdoptionModeReport ArgoCDAdoptionMode = "Report"
+
+	// ArgoCDAdoptionModeAdopt sets the ArgoCD as the controller of the existing resources in batches, which are then
+	// reconciled.
+	ArgoCDAdoptionModeAdopt ArgoCDAdoptionMode = "Adopt"
+)
+
+// ArgoCDAdoptionSpec defines the adoption of the existing resources of an Argo CD installed without the operator.
+// The resources labeled app.kubernetes.io/part-of=argocd, without a controller, and written by the operator under
+// the same name are adopted.
+type ArgoCDAdoptionSpec struct {
+	// Mode is Report to review the resources that would be adopted, and Adopt to adopt them.
+	// +kubebuilder:validation:Enum=Report;Adopt
+	Mode ArgoCDAdoptionMode `json:"mode"`
+}
+
+// ArgoCDConfigManagementPolicy defines how the operator updates the argocd-cm ConfigMap.
+type ArgoCDConfigManagementPolicy string
+
+const (
+	// ArgoCDConfigManagementPolicyEnforce replaces the data of the ConfigMap with the keys generated by the operator.
+	// This is the default.
+	ArgoCDConfigManagementPolicyEnforce ArgoCDConfigManagementPolicy = "enforce"
+
+	// ArgoCDConfigManagementPolicyMerge enforces the keys generated by the operator, and preserves the other keys.
+	ArgoCDConfigManagementPolicyMerge ArgoCDConfigManagementPolicy = "merge"
+
+	// ArgoCDConfigManagementPolicyIgnore creates the ConfigMap, and leaves it untouched afterwards.
+	ArgoCDConfigManagementPolicyIgnore ArgoCDConfigManagementPolicy = "ignore"
+)
+
+// ArgoCDInstallationMode defines the set of components deployed for an Argo CD instance.
+type ArgoCDInstallationMode string
+
+const (
+	// ArgoCDInstallationModeFull deploys all the enabled components of Argo CD. This is the default.
+	ArgoCDInstallationModeFull ArgoCDInstallationMode = "full"
+
+	// ArgoCDInstallationModeCore deploys the headless Argo CD, managed with the CLI or CI only: the Argo CD Server,
+	// its Ingresses and Routes, and Dex are not deployed, whatever their options.
+	ArgoCDInstallationModeCore ArgoCDInstallationMode = "core"
+)
+
+const (
+	// ArgoCDConditionTypePaused indicates whether the reconciliation of the Argo CD instance is paused.
+	ArgoCDConditionTypePaused = "Paused"
+
+	// ArgoCDConditionReasonPaused is the reason of the Paused condition when spec.paused is set.
+	ArgoCDConditionReasonPaused = "ReconciliationPaused"
+
+	// ArgoCDConditionReasonResumed is the reason of the Paused condition once spec.paused has been unset.
+	ArgoCDConditionReasonResumed = "ReconciliationResumed"
+
+	// ArgoCDConditionTypeInitialResourcesApplied indicates whether the initial Applications and AppProjects have been created.
+	ArgoCDConditionTypeInitialResourcesApplied = "InitialResourcesApplied"
+
+	// ArgoCDConditionReasonInitialResourcesApplied is the reason of the InitialResourcesApplied condition once they have been created.
+	ArgoCDConditionReasonInitialResourcesApplied = "InitialResourcesApplied"
+
+	// ArgoCDConditionTypeDeleting reports the progress of the deletion of the Argo CD instance.
+	ArgoCDConditionTypeDeleting = "Deleting"
+
+	// ArgoCDConditionReasonDeletingClusterResources is the reason of the Deleting condition while the cluster scoped
+	// resources are deleted.
+	ArgoCDConditionReasonDeletingClusterResources = "DeletingClusterResources"
+
+	// ArgoCDConditionReasonDeletingApplications is the reason of the Deleting condition while the Applications of the
+	// instance are deleted.
+	ArgoCDConditionReasonDeletingApplications = "DeletingApplications"
+
+	// ArgoCDConditionReasonRemovingFinalizer is the reason of the Deleting condition once the remaining resources are
+	// cleaned up and the deletion finalizer is removed.
+	ArgoCDConditionReasonRemovingFinalizer = "RemovingFinalizer"
+
+	// ArgoCDConditionTypeDegraded indicates whether the Argo CD instance runs with a degraded configuration.
+	ArgoCDConditionTypeDegraded = "Degraded"
+
+	// ArgoCDConditionReasonInvalidConfiguration is the reason of the Degraded condition when the configuration rendered
+	// for the Argo CD ConfigMaps is invalid, and has not been applied.
+	ArgoCDConditionReasonInvalidConfiguration = "InvalidConfiguration"
+
+	// ArgoCDConditionReasonValidConfiguration is the reason of the Degraded condition once the configuration is valid again.
+	ArgoCDConditionReasonValidConfiguration = "ValidConfiguration"
+
+	// ArgoCDConditionTypeSSOReachable indicates whether the SSO provider of the Argo CD instance answers its livecheck.
+	ArgoCDConditionTypeSSOReachable = "SSOReachable"
+
+	// ArgoCDConditionReasonSSOProviderReachable is the reason of the SSOReachable condition when the livecheck succeeds.
+	ArgoCDConditionReasonSSOProviderReachable = "SSOProviderReachable"
+
+	// ArgoCDConditionReasonSSOProviderUnreachable is the reason of the SSOReachable condition when the livecheck fails.
+	ArgoCDConditionReasonSSOProviderUnreachable = "SSOProviderUnreachable"
+
+	// ArgoCDConditionReasonSSOProviderNotRunning is the reason of the SSOReachable condition while the SSO provider
+	// installed by the operator is not running yet.
+	ArgoCDConditionReasonSSOProviderNotRunning = "SSOProviderNotRunning"
+
+	// ArgoCDConditionTypeCryptoPolicyCompliant indicates whether the settings of the Argo CD instance comply with
+	// its fips crypto policy.
+	ArgoCDConditionTypeCryptoPolicyCompliant = "CryptoPolicyCompliant"
+
+	// ArgoCDConditionReasonCryptoPolicyCompliant is the reason of the CryptoPolicyCompliant condition when all the
+	// settings comply with the crypto policy.
+	ArgoCDConditionReasonCryptoPolicyCompliant = "Compliant"
+
+	// ArgoCDConditionReasonCryptoPolicyNonCompliant is the reason of the CryptoPolicyCompliant condition when some
+	// settings, listed in its message, do not comply with the crypto policy.
+	ArgoCDConditionReasonCryptoPolicyNonCompliant = "NonCompliant"
+
+	// ArgoCDConditionTypeRedisMigrating reports the progress of the migration of Redis between the standalone and the
+	// HA modes.
+	ArgoCDConditionTypeRedisMigrating = "RedisMigrating"
+
+	// ArgoCDConditionReasonWaitingForRedis is the reason of the RedisMigrating condition while the Redis of the new
+	// mode is not ready yet, the components keep using the Redis of the previous mode.
+	ArgoCDConditionReasonWaitingForRedis = "WaitingForRedis"
+
+	// ArgoCDConditionReasonSwitchingComponents is the reason of the RedisMigrating condition while the components are
+	// rolled out with the Redis of the new mode, the Redis of the previous mode is kept until they are.
+	ArgoCDConditionReasonSwitchingComponents = "SwitchingComponents"
+
+	// ArgoCDConditionTypeResourceBudgetExceeded indicates whether the components of the Argo CD instance request more
+	// resources than its resource budget.
+	ArgoCDConditionTypeResourceBudgetExceeded = "ResourceBudgetExceeded"
+
+	// ArgoCDConditionReasonResourceBudgetExceeded is the reason of the ResourceBudgetExceeded condition when the
+	// requests of the components exceed the resource budget.
+	ArgoCDConditionReasonResourceBudgetExceeded = "ResourceBudgetExceeded"
+
+	// ArgoCDConditionReasonWithinResourceBudget is the reason of the ResourceBudgetExceeded condition once the requests
+	// of the components fit in the resource budget again.
+	ArgoCDConditionReasonWithinResourceBudget = "WithinResourceBudget"
+
+	// ArgoCDConditionTypeServerCommandArgsConflict indicates whether ExtraCommandArgs of the Argo CD Server conflict
+	// with the arguments set by the operator.
+	ArgoCDConditionTypeServerCommandArgsConflict = "ServerCommandArgsConflict"
+
+	// ArgoCDConditionReasonCommandArgsDropped is the reason of the ServerCommandArgsConflict condition when the
+	// ExtraCommandArgs are dropped because of the conflict.
+	ArgoCDConditionReasonCommandArgsDropped = "CommandArgsDropped"
+
+	// ArgoCDConditionReasonCommandArgsOverridden is the reason of the ServerCommandArgsConflict condition when the
+	// conflicting arguments set by the operator are replaced, as ExtraCommandArgsForceOverride is set.
+	ArgoCDConditionReasonCommandArgsOverridden = "CommandArgsOverridden"
+
+	// ArgoCDConditionReasonNoConflictingCommandArgs is the reason of the ServerCommandArgsConflict condition once the
+	// ExtraCommandArgs no longer conflict with the arguments set by the operator.
+	ArgoCDConditionReasonNoConflictingCommandArgs = "NoConflictingCommandArgs"
+
+	// ArgoCDConditionTypeAdoption reports the adoption of the existing resources of an Argo CD installed without the
+	// operator.
+	ArgoCDConditionTypeAdoption = "Adoption"
+
+	// ArgoCDConditionReasonAdoptionReported is the reason of the Adoption condition once the resources that would be
+	// adopted, and the changes the operator would make, have been recorded.
+	ArgoCDConditionReasonAdoptionReported = "AdoptionReported"
+
+	// ArgoCDConditionReasonAdoptionFailed is the reason of the Adoption condition when an error interrupted the report.
+	ArgoCDConditionReasonAdoptionFailed = "AdoptionFailed"
+
+	// ArgoCDConditionReasonAdoptionInProgress is the reason of the Adoption condition while the existing resources are
+	// adopted in batches.
+	ArgoCDConditionReasonAdoptionInProgress = "AdoptionInProgress"
+
+	// ArgoCDConditionReasonResourcesAdopted is the reason of the Adoption condition once the existing resources have
+	// been adopted.
+	ArgoCDConditionReasonResourcesAdopted = "ResourcesAdopted"
+
+	// ArgoCDConditionTypeDryRun reports the result of the dry run requested through the argocd.argoproj.io/dry-run
+	// annotation.
+	ArgoCDConditionTypeDryRun = "DryRun"
+
+	// ArgoCDConditionReasonDryRunCompleted is the reason of the DryRun condition once the changes the operator would
+	// make have been recorded.
+	ArgoCDConditionReasonDryRunCompleted = "DryRunCompleted"
+
+	// ArgoCDConditionReasonDryRunFailed is the reason of the DryRun condition when an error interrupted the dry run,
+	// only the changes found before the error have been recorded.
+	ArgoCDConditionReasonDryRunFailed = "DryRunFailed"
+
+	// ArgoCDConditionReasonDryRunDisabled is the reason of the DryRun condition once the annotation has been removed
+	// and the changes are applied again.
+	ArgoCDConditionReasonDryRunDisabled = "DryRunDisabled"
+
+	// ArgoCDConditionTypeKeycloakDatabaseStorageDrifted indicates whether the size or the StorageClass of the
+	// PostgreSQL database managed for Keycloak differ from the volume of the existing database.
+	ArgoCDConditionTypeKeycloakDatabaseStorageDrifted = "KeycloakDatabaseStorageDrifted"
+
+	// ArgoCDConditionReasonStorageChangeIgnored is the reason of the KeycloakDatabaseStorageDrifted condition when the
+	// requested volume cannot be applied, as the volume claim templates of a StatefulSet are immutable.
+	ArgoCDConditionReasonStorageChangeIgnored = "StorageChangeIgnored"
+
+	// ArgoCDConditionReasonStorageInSync is the reason of the KeycloakDatabaseStorageDrifted condition once the
+	// requested volume matches the volume of the existing database again.
+	ArgoCDConditionReasonStorageInSync = "StorageInSync"
+)
+
+const (
+	// ArgoCDRedisModeStandalone means the Argo CD components use the single Redis instance.
+	ArgoCDRedisModeStandalone = "Standalone"
+
+	// ArgoCDRedisModeHA means the Argo CD components use Redis through the HA proxy.
+	ArgoCDRedisModeHA = "HA"
+)
+
+// ArgoCDResourceBudgetPolicy defines how an Argo CD instance exceeding its resource budget is handled.
+type ArgoCDResourceBudgetPolicy string
+
+const (
+	// ArgoCDResourceBudgetPolicyEnforce refuses an Argo CD instance exceeding its resource budget at admission, and
+	// does not scale its components up until it fits in the budget again.
+	ArgoCDResourceBudgetPolicyEnforce ArgoCDResourceBudgetPolicy = "Enforce"
+
+	// ArgoCDResourceBudgetPolicyWarn only warns about an Argo CD instance exceeding its resource budget.
+	ArgoCDResourceBudgetPolicyWarn ArgoCDResourceBudgetPolicy = "Warn"
+)
+
+// ArgoCDResourceBudgetSpec defines the resources the components of an Argo CD instance may request in total.
+type ArgoCDResourceBudgetSpec struct {
+	// CPU is the CPU the components may request in total.
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+
+	// Memory is the memory the components may request in total.
+	Memory *resource.Quantity `json:"memory,omitempty"`
+
+	// Policy defines how the instance is handled when the components exceed the budget. Defaults to Enforce.
+	//+kubebuilder:validation:Enum=Enforce;Warn
+	Policy ArgoCDResourceBudgetPolicy `json:"policy,omitempty"`
+}
+
 // SSOProviderType string defines the type of SSO provider.
 type SSOProviderType string
 
@@ -694,6 +2162,10 @@ const (
 
 	// SSOProviderTypeDex means dex will be Installed and Integrated with Argo CD.
 	SSOProviderTypeDex SSOProviderType = "dex"
+
+	// SSOProviderTypeExternal means Argo CD authenticates directly against the external OIDC provider configured in
+	// .spec.sso.oidc, without Dex.
+	SSOProviderTypeExternal SSOProviderType = "external"
 )
 
 // ArgoCDSSOSpec defines SSO provider.
@@ -706,6 +2178,43 @@ type ArgoCDSSOSpec struct {
 
 	// Keycloak contains the configuration for Argo CD keycloak authentication
 	Keycloak *ArgoCDKeycloakSpec `json:"keycloak,omitempty"`
+
+	// OIDC contains the configuration of the external OIDC provider, rendered into the oidc.config key of argocd-cm
+	OIDC *ArgoCDOIDCSpec `json:"oidc,omitempty"`
+}
+
+// ArgoCDOIDCSpec defines an external OIDC provider Argo CD authenticates against directly.
+type ArgoCDOIDCSpec struct {
+	// Name is the name of the provider displayed on the login button of the Argo CD UI. Defaults to OIDC.
+	Name string `json:"name,omitempty"`
+
+	// Issuer is the URL of the OIDC issuer.
+	Issuer string `json:"issuer"`
+
+	// ClientID is the ID of the Argo CD client at the provider.
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef references the key of a Secret, in the namespace of the ArgoCD, holding the secret of the Argo CD
+	// client. Not needed for public clients.
+	ClientSecretRef *corev1.SecretKeySelector `json:"clientSecretRef,omitempty"`
+
+	// Scopes are the scopes requested from the provider. Argo CD requests openid, profile and email when empty.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Claims are the claims requested in the ID token, keyed by claim name.
+	Claims map[string]ArgoCDOIDCClaimSpec `json:"claims,omitempty"`
+
+	// RootCA is the PEM encoded root certificate of the provider, when it is not trusted by the system.
+	RootCA string `json:"rootCA,omitempty"`
+}
+
+// ArgoCDOIDCClaimSpec defines a claim requested in the ID token.
+type ArgoCDOIDCClaimSpec struct {
+	// Essential requests the claim as essential for the authorization.
+	Essential bool `json:"essential,omitempty"`
+
+	// Values are the requested values of the claim.
+	Values []string `json:"values,omitempty"`
 }
 
 // KustomizeVersionSpec is used to specify information about a kustomize version to be used within ArgoCD.
@@ -725,6 +2234,23 @@ type ArgoCDMonitoringSpec struct {
 	Enabled bool `json:"enabled"`
 	// DisableMetrics field can be used to enable or disable the collection of Metrics on Openshift
 	DisableMetrics *bool `json:"disableMetrics,omitempty"`
+	// LogSidecar injects a log shipping sidecar into all the component pods of this instance
+	LogSidecar *ArgoCDLogSidecarSpec `json:"logSidecar,omitempty"`
+	// ResourceAttributes are the OpenTelemetry resource attributes set on the traces and metrics of all the
+	// components of this instance, through the OTEL_RESOURCE_ATTRIBUTES environment variable.
+	ResourceAttributes map[string]string `json:"resourceAttributes,omitempty"`
+}
+
+// ArgoCDLogSidecarSpec defines the log shipping sidecar injected into the Argo CD component pods.
+type ArgoCDLogSidecarSpec struct {
+	// Image is the container image of the log sidecar. Defaults to fluent-bit.
+	Image string `json:"image,omitempty"`
+	// Endpoint is the HTTP(S) URL the logs are shipped to by the generated fluent-bit configuration.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Config replaces the generated configuration of the log sidecar, e.g. to ship the logs to another output.
+	Config string `json:"config,omitempty"`
+	// Resources defines the Compute Resources required by the log sidecar container.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // ArgoCDNodePlacementSpec is used to specify NodeSelector and Tolerations for Argo CD workloads
@@ -739,6 +2265,16 @@ type ArgoCDNodePlacementSpec struct {
 // +k8s:openapi-gen=true
 type ArgoCDSpec struct {
 
+	// Accounts defines the local Argo CD accounts, rendered into the accounts keys of argocd-cm.
+	Accounts []ArgoCDAccountSpec `json:"accounts,omitempty"`
+
+	// AdminPasswordPolicy defines how the password of the admin user is rotated and disabled.
+	AdminPasswordPolicy *ArgoCDAdminPasswordPolicySpec `json:"adminPasswordPolicy,omitempty"`
+
+	// Adoption defines the adoption of the existing resources of an Argo CD installed without the operator, e.g.
+	// with the Helm chart, so that it can be migrated to the operator without downtime.
+	Adoption *ArgoCDAdoptionSpec `json:"adoption,omitempty"`
+
 	// ArgoCDApplicationSet defines whether the Argo CD ApplicationSet controller should be installed.
 	ApplicationSet *ArgoCDApplicationSet `json:"applicationSet,omitempty"`
 
@@ -746,13 +2282,29 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Application Instance Label Key'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ApplicationInstanceLabelKey string `json:"applicationInstanceLabelKey,omitempty"`
 
+	// BackupLabels labels the resources of the instance for Velero backups.
+	BackupLabels *ArgoCDBackupLabelsSpec `json:"backupLabels,omitempty"`
+
 	// ConfigManagementPlugins is used to specify additional config management plugins.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Config Management Plugins'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ConfigManagementPlugins string `json:"configManagementPlugins,omitempty"`
 
+	// ConfigManagementPolicy defines how the operator updates the argocd-cm ConfigMap. With enforce (default), the
+	// ConfigMap only holds the keys generated by the operator. With merge, the keys added out-of-band and the keys
+	// listed in the argocd.argoproj.io/unmanaged-keys annotation of the ConfigMap are preserved. With ignore, the
+	// ConfigMap is only created.
+	// +kubebuilder:validation:Enum=enforce;merge;ignore
+	ConfigManagementPolicy ArgoCDConfigManagementPolicy `json:"configManagementPolicy,omitempty"`
+
 	// Controller defines the Application Controller options for ArgoCD.
 	Controller ArgoCDApplicationControllerSpec `json:"controller,omitempty"`
 
+	// CryptoPolicy restricts the cryptography of the components of the Argo CD instance. The fips policy selects the
+	// FIPS-validated images of the components, runs them in FIPS mode, and reports the settings that do not comply,
+	// and the images set in the spec it cannot verify, with the CryptoPolicyCompliant condition.
+	// +kubebuilder:validation:Enum=default;fips
+	CryptoPolicy ArgoCDCryptoPolicy `json:"cryptoPolicy,omitempty"`
+
 	// DisableAdmin will disable the admin user.
 	DisableAdmin bool `json:"disableAdmin,omitempty"`
 
@@ -772,6 +2324,10 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Google Analytics Anonymize Users'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	GAAnonymizeUsers bool `json:"gaAnonymizeUsers,omitempty"`
 
+	// GPGKeys are the ASCII armored public GPG keys the Repo server verifies the signatures of commits with, keyed by
+	// their 16 hexadecimal digits key ID. When set, they replace the content of the argocd-gpg-keys-cm ConfigMap.
+	GPGKeys map[string]string `json:"gpgKeys,omitempty"`
+
 	// Deprecated: Grafana defines the Grafana server options for ArgoCD.
 	Grafana ArgoCDGrafanaSpec `json:"grafana,omitempty"`
 
@@ -790,16 +2346,55 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:ArgoCD","urn:alm:descriptor:com.tectonic.ui:text"}
 	Image string `json:"image,omitempty"`
 
+	// FIPSImages are the FIPS-validated images of the components selected by the fips crypto policy, overriding the
+	// images set with the ARGOCD_FIPS_IMAGES environment variable of the operator.
+	FIPSImages *ArgoCDImageOverridesSpec `json:"fipsImages,omitempty"`
+
+	// ImageOverrides are the images of the Argo CD components to use on nodes of a given architecture, keyed by
+	// architecture (e.g. arm64). They apply once the workloads are pinned to that architecture with the
+	// kubernetes.io/arch node selector of NodePlacement.
+	ImageOverrides map[string]ArgoCDImageOverridesSpec `json:"imageOverrides,omitempty"`
+
+	// ImageUpdater defines the options for the Argo CD Image Updater component.
+	ImageUpdater ArgoCDImageUpdaterSpec `json:"imageUpdater,omitempty"`
+
+	// InheritFrom references an ArgoCD resource, or a ConfigMap, whose spec is used for the fields left unset on
+	// this instance.
+	InheritFrom *ArgoCDInheritFromSpec `json:"inheritFrom,omitempty"`
+
 	// Import is the import/restore options for ArgoCD.
 	Import *ArgoCDImportSpec `json:"import,omitempty"`
 
+	// InitialApplications defines the Argo CD Applications to create once the Argo CD instance is Available for
+	// the first time, e.g. to bootstrap an app-of-apps. They are applied only once and not reconciled afterwards.
+	InitialApplications []InitialResourceSpec `json:"initialApplications,omitempty"`
+
+	// InitialProjects defines the Argo CD AppProjects to create once the Argo CD instance is Available for
+	// the first time. They are applied before the InitialApplications, only once and not reconciled afterwards.
+	InitialProjects []InitialResourceSpec `json:"initialProjects,omitempty"`
+
 	// InitialRepositories to configure Argo CD with upon creation of the cluster.
+	// Deprecated: use Repositories instead.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Initial Repositories'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	InitialRepositories string `json:"initialRepositories,omitempty"`
 
 	// InitialSSHKnownHosts defines the SSH known hosts data upon creation of the cluster for connecting Git repositories via SSH.
 	InitialSSHKnownHosts SSHHostsSpec `json:"initialSSHKnownHosts,omitempty"`
 
+	// InstallationMode is full to deploy all the enabled components, or core to deploy the headless Argo CD, without
+	// the Argo CD Server and Dex, as the core install of Argo CD. Defaults to full.
+	// +kubebuilder:validation:Enum=full;core
+	InstallationMode ArgoCDInstallationMode `json:"installationMode,omitempty"`
+
+	// IPFamilyPolicy is the IP family policy of the Services of this Argo CD instance, e.g. PreferDualStack on
+	// dual-stack clusters. Defaults to the cluster default, SingleStack.
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// IPFamilies are the IP families of the Services of this Argo CD instance, e.g. IPv6 on IPv6-only clusters. The
+	// first family is the primary family of the Services, which cannot be changed once they are created. Redis also
+	// listens on IPv6 addresses when IPv6 is listed.
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+
 	// KustomizeBuildOptions is used to specify build options/parameters to use with `kustomize build`.
 	KustomizeBuildOptions string `json:"kustomizeBuildOptions,omitempty"`
 
@@ -807,6 +2402,9 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kustomize Build Options'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	KustomizeVersions []KustomizeVersionSpec `json:"kustomizeVersions,omitempty"`
 
+	// LabelPolicy defines the default labels set on the resources of the instance.
+	LabelPolicy *ArgoCDLabelPolicySpec `json:"labelPolicy,omitempty"`
+
 	// OIDCConfig is the OIDC configuration as an alternative to dex.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OIDC Config'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	OIDCConfig string `json:"oidcConfig,omitempty"`
@@ -820,6 +2418,25 @@ type ArgoCDSpec struct {
 	// Notifications defines whether the Argo CD Notifications controller should be installed.
 	Notifications ArgoCDNotifications `json:"notifications,omitempty"`
 
+	// OpenShift defines the options for the integration of Argo CD with the OpenShift web console.
+	OpenShift *ArgoCDOpenShiftSpec `json:"openshift,omitempty"`
+
+	// Paused stops the operator from reconciling the resources of this Argo CD instance, without deleting them,
+	// so that they can be tuned by hand. A Paused condition is set on the status while reconciliation is paused.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Paused",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Paused bool `json:"paused,omitempty"`
+
+	// DeleteManagedApplications will delete the Applications of this Argo CD instance, and wait for them to be gone,
+	// before the deletion of the instance completes.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Delete Managed Applications",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	DeleteManagedApplications bool `json:"deleteManagedApplications,omitempty"`
+
+	// Profile is the sizing preset used as defaults for the processors, parallelism limit, repo server replicas and
+	// resource requirements of the Argo CD components when they are not set explicitly. (one of: small, medium, large)
+	// +kubebuilder:validation:Enum=small;medium;large
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Profile",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	Profile ArgoCDProfileType `json:"profile,omitempty"`
+
 	// Prometheus defines the Prometheus server options for ArgoCD.
 	Prometheus ArgoCDPrometheusSpec `json:"prometheus,omitempty"`
 
@@ -832,13 +2449,22 @@ type ArgoCDSpec struct {
 	// Repo defines the repo server options for Argo CD.
 	Repo ArgoCDRepoSpec `json:"repo,omitempty"`
 
+	// Repositories defines the repositories Argo CD connects to. Each repository is reconciled into a labeled
+	// repository Secret, which is removed again when the repository is removed from the list.
+	Repositories []RepositorySpec `json:"repositories,omitempty"`
+
 	// RepositoryCredentials are the Git pull credentials to configure Argo CD with upon creation of the cluster.
+	// Deprecated: use Repositories instead.
 	RepositoryCredentials string `json:"repositoryCredentials,omitempty"`
 
 	// ResourceHealthChecks customizes resource health check behavior.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Health Check Customizations'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ResourceHealthChecks []ResourceHealthCheck `json:"resourceHealthChecks,omitempty"`
 
+	// ResourceCompareOptions customizes how Argo CD compares the live and desired state of resources.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Compare Options",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ResourceCompareOptions *ResourceCompareOptions `json:"resourceCompareOptions,omitempty"`
+
 	// ResourceIgnoreDifferences customizes resource ignore difference behavior.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Ignore Difference Customizations'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ResourceIgnoreDifferences *ResourceIgnoreDifference `json:"resourceIgnoreDifferences,omitempty"`
@@ -859,6 +2485,20 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Tracking Method'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ResourceTrackingMethod string `json:"resourceTrackingMethod,omitempty"`
 
+	// ResourceBudget limits the resources requested in total by the components of the Argo CD instance.
+	ResourceBudget *ArgoCDResourceBudgetSpec `json:"resourceBudget,omitempty"`
+
+	// ResyncPeriod is the period after which the operator reconciles the Argo CD instance again, correcting the drift
+	// of its resources, e.g. 30s for a development instance. Must be at least 30s. It can only make the instance
+	// reconciled more often than the resync period of the operator, which applies when it is longer or not set.
+	//+kubebuilder:validation:XValidation:rule="duration(self) >= duration('30s')",message="resyncPeriod must be at least 30s"
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+
+	// SecretSeedRef references the key of a Secret holding the seed the admin password, the server signature key and
+	// the Redis password generated by the operator are derived from, instead of being random, so that an instance
+	// recreated with the same seed gets the same credentials.
+	SecretSeedRef *corev1.SecretKeySelector `json:"secretSeedRef,omitempty"`
+
 	// Server defines the options for the ArgoCD Server component.
 	Server ArgoCDServerSpec `json:"server,omitempty"`
 
@@ -951,6 +2591,10 @@ type ArgoCDStatus struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Redis",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Redis string `json:"redis,omitempty"`
 
+	// RedisMode is the Redis mode the Argo CD components are currently connected to, either Standalone or HA.
+	// It lags behind spec.ha.enabled while Redis is migrated between the modes.
+	RedisMode string `json:"redisMode,omitempty"`
+
 	// Repo is a simple, high-level summary of where the Argo CD Repo component is in its lifecycle.
 	// There are four possible repo values:
 	// Pending: The Argo CD Repo component has been accepted by the Kubernetes system, but one or more of the required resources have not been created.
@@ -969,6 +2613,14 @@ type ArgoCDStatus struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Server",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Server string `json:"server,omitempty"`
 
+	// ServerReplicas is the number of Argo CD server Pods targeted by the server Deployment.
+	// It backs the scale subresource of the ArgoCD resource.
+	ServerReplicas int32 `json:"serverReplicas,omitempty"`
+
+	// ServerSelector is the label selector of the Argo CD server Pods, in string form.
+	// It backs the scale subresource of the ArgoCD resource.
+	ServerSelector string `json:"serverSelector,omitempty"`
+
 	// RepoTLSChecksum contains the SHA256 checksum of the latest known state of tls.crt and tls.key in the argocd-repo-server-tls secret.
 	RepoTLSChecksum string `json:"repoTLSChecksum,omitempty"`
 
@@ -977,6 +2629,26 @@ type ArgoCDStatus struct {
 
 	// Host is the hostname of the Ingress.
 	Host string `json:"host,omitempty"`
+
+	// Endpoints holds the URLs of the Argo CD endpoints exposed outside of the cluster, discovered from the created
+	// Routes, Ingresses and LoadBalancer Services. They are keyed by endpoint: server, grpc, applicationSetWebhook,
+	// keycloak and grafana.
+	Endpoints map[string]string `json:"endpoints,omitempty"`
+
+	// ComponentMessages holds, for each component that is not running, the reason reported by its workload or Pods,
+	// e.g. an image pull back-off or a crash loop. Components are keyed by the name of their status field.
+	ComponentMessages map[string]string `json:"componentMessages,omitempty"`
+
+	// ControllerShards holds the clusters assigned to each application controller shard, as a comma separated list of
+	// cluster names keyed by shard number, to debug an imbalance between the shards. It is only reported when the
+	// application controller is sharded with the legacy or round-robin algorithm.
+	ControllerShards map[string]string `json:"controllerShards,omitempty"`
+
+	// Conditions describe the latest observations of the state of the Argo CD instance.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // Banner defines an additional banner message to be displayed in Argo CD UI
@@ -995,6 +2667,45 @@ type ArgoCDTLSSpec struct {
 
 	// InitialCerts defines custom TLS certificates upon creation of the cluster for connecting Git repositories via HTTPS.
 	InitialCerts map[string]string `json:"initialCerts,omitempty"`
+
+	// MinVersion is the minimum TLS version accepted by the server and the repo server, using the defaults of Argo CD
+	// when empty.
+	// +kubebuilder:validation:Enum="1.0";"1.1";"1.2";"1.3"
+	MinVersion string `json:"minVersion,omitempty"`
+
+	// CipherSuites are the TLS cipher suites accepted by the server and the repo server up to TLS 1.2, e.g.
+	// TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384, using the defaults of Argo CD when empty.
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+// InitialResourceSpec defines the source of Argo CD resources to create upon creation of the cluster.
+// Exactly one of Manifest or ConfigMapRef should be set.
+type InitialResourceSpec struct {
+	// Manifest is the inline YAML of the resources. Multiple resources can be separated with "---".
+	Manifest string `json:"manifest,omitempty"`
+
+	// ConfigMapRef references a key of a ConfigMap in the namespace of the Argo CD instance holding the YAML of the resources.
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
+}
+
+// RepositorySpec defines a repository Argo CD connects to.
+type RepositorySpec struct {
+	// URL is the URL of the repository.
+	URL string `json:"url"`
+
+	// Type is the type of the repository, either git or helm. Defaults to git. (optional)
+	// +kubebuilder:validation:Enum=git;helm
+	Type string `json:"type,omitempty"`
+
+	// Name is the name of the repository, required for Helm repositories. (optional)
+	Name string `json:"name,omitempty"`
+
+	// Project is the AppProject the repository is scoped to. (optional)
+	Project string `json:"project,omitempty"`
+
+	// SecretRef references a Secret in the namespace of the Argo CD instance holding the credentials of the
+	// repository, using the Argo CD repository Secret keys such as username, password or sshPrivateKey. (optional)
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
 }
 
 type SSHHostsSpec struct {
@@ -1005,8 +2716,27 @@ type SSHHostsSpec struct {
 	// Keys describes a custom set of SSH Known Hosts that you would like to
 	// have included in your ArgoCD server.
 	Keys string `json:"keys,omitempty"`
+
+	// RefreshInterval enables the periodic refresh of the default SSH Known Hosts from upstream Argo CD,
+	// and keeps the SSH Known Hosts ConfigMap in sync with this spec. When not set, the ConfigMap is
+	// only populated upon creation of the cluster.
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// MergeStrategy describes how the custom Keys are combined with the default SSH Known Hosts.
+	// With append (default), the custom keys are added after the default hosts. With merge, a custom
+	// key replaces the default entry for the same host and key type.
+	// +kubebuilder:validation:Enum=append;merge
+	MergeStrategy string `json:"mergeStrategy,omitempty"`
 }
 
+const (
+	// SSHKnownHostsMergeStrategyAppend adds the custom SSH Known Hosts after the default hosts.
+	SSHKnownHostsMergeStrategyAppend = "append"
+
+	// SSHKnownHostsMergeStrategyMerge replaces default SSH Known Hosts entries with custom entries for the same host and key type.
+	SSHKnownHostsMergeStrategyMerge = "merge"
+)
+
 // WebhookServerSpec defines the options for the ApplicationSet Webhook Server component.
 type WebhookServerSpec struct {
 
@@ -1038,6 +2768,12 @@ func (s *ArgoCDServerSpec) WantsAutoTLS() bool {
 	return s.Route.TLS == nil || s.Route.TLS.Termination == routev1.TLSTerminationReencrypt
 }
 
+// WantsAutoTLS returns true if the user has configured the webhook Route with reencrypt, so that the webhook server
+// serves TLS with a certificate of the OpenShift service CA.
+func (w *WebhookServerSpec) WantsAutoTLS() bool {
+	return w.Route.TLS != nil && w.Route.TLS.Termination == routev1.TLSTerminationReencrypt
+}
+
 // WantsAutoTLS returns true if the repository server configuration has set
 // the autoTLS toggle to a supported provider.
 func (r *ArgoCDRepoSpec) WantsAutoTLS() bool {
@@ -1047,7 +2783,13 @@ func (r *ArgoCDRepoSpec) WantsAutoTLS() bool {
 // WantsAutoTLS returns true if the redis server configuration has set
 // the autoTLS toggle to a supported provider.
 func (r *ArgoCDRedisSpec) WantsAutoTLS() bool {
-	return r.AutoTLS == "openshift"
+	return r.AutoTLS == "openshift" || r.WantsSelfSignedTLS()
+}
+
+// WantsSelfSignedTLS returns true if the redis server configuration requests
+// the operator to generate and rotate the TLS certificate itself.
+func (r *ArgoCDRedisSpec) WantsSelfSignedTLS() bool {
+	return r.AutoTLS == "self-signed"
 }
 
 // ApplicationInstanceLabelKey returns either the custom application instance
@@ -1060,6 +2802,11 @@ func (a *ArgoCD) ApplicationInstanceLabelKey() string {
 	}
 }
 
+// IsCoreInstallation returns true if the Argo CD is installed in core mode, without the Argo CD Server and Dex.
+func (a *ArgoCD) IsCoreInstallation() bool {
+	return a.Spec.InstallationMode == ArgoCDInstallationModeCore
+}
+
 // ResourceTrackingMethod represents the Argo CD resource tracking method to use
 type ResourceTrackingMethod int
 
@@ -0,0 +1,143 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	SchemeBuilder.Register(&ArgoCDFleet{}, &ArgoCDFleetList{})
+}
+
+//+kubebuilder:object:root=true
+
+// ArgoCDFleet is the Schema for the argocdfleets API. It aggregates the status of the ArgoCD instances it selects,
+// across all namespaces.
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Instances",type=integer,JSONPath=`.status.instances`
+// +kubebuilder:printcolumn:name="Degraded",type=integer,JSONPath=`.status.degraded`
+// +kubebuilder:printcolumn:name="Outdated",type=integer,JSONPath=`.status.outdated`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +operator-sdk:csv:customresourcedefinitions:resources={{ArgoCDFleet,v1beta1,""}}
+// +operator-sdk:csv:customresourcedefinitions:resources={{ArgoCD,v1beta1,""}}
+type ArgoCDFleet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ArgoCDFleetSpec   `json:"spec,omitempty"`
+	Status ArgoCDFleetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ArgoCDFleetList contains a list of ArgoCDFleet
+type ArgoCDFleetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArgoCDFleet `json:"items"`
+}
+
+// ArgoCDFleetSpec defines the ArgoCD instances of the fleet.
+type ArgoCDFleetSpec struct {
+	// Selector selects the ArgoCD instances of the fleet by their labels, in all namespaces. All the instances of the
+	// cluster are selected when empty.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Version is the Argo CD image tag or digest the instances are expected to run. Instances running another version
+	// are reported as outdated. Defaults to the version deployed by the operator when no image is set. (optional)
+	Version string `json:"version,omitempty"`
+}
+
+// ArgoCDFleetStatus defines the observed state of the ArgoCD instances of the fleet.
+type ArgoCDFleetStatus struct {
+	// ObservedGeneration is the generation of the fleet the status was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Instances is the number of ArgoCD instances of the fleet.
+	Instances int32 `json:"instances"`
+
+	// Available is the number of instances whose phase is Available.
+	Available int32 `json:"available"`
+
+	// Degraded is the number of instances which are not available, or report a Degraded condition.
+	Degraded int32 `json:"degraded"`
+
+	// Outdated is the number of instances running another version than the expected one.
+	Outdated int32 `json:"outdated"`
+
+	// Versions holds the number of instances running each Argo CD version.
+	Versions map[string]int32 `json:"versions,omitempty"`
+
+	// Members holds the status of the instances of the fleet which are degraded or outdated, sorted by namespace and
+	// name. Healthy instances are only counted, to keep the status small for large fleets.
+	Members []ArgoCDFleetMemberStatus `json:"members,omitempty"`
+
+	// Conditions describe the latest observations of the state of the fleet.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ArgoCDFleetMemberStatus defines the observed state of an ArgoCD instance of a fleet.
+type ArgoCDFleetMemberStatus struct {
+	// Name is the name of the ArgoCD instance.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the ArgoCD instance.
+	Namespace string `json:"namespace"`
+
+	// Phase is the phase of the ArgoCD instance.
+	Phase string `json:"phase,omitempty"`
+
+	// Version is the Argo CD version the instance runs, empty until its workloads are deployed.
+	Version string `json:"version,omitempty"`
+
+	// Degraded is true if the instance is not available, or reports a Degraded condition.
+	Degraded bool `json:"degraded,omitempty"`
+
+	// Outdated is true if the instance runs another version than the expected one.
+	Outdated bool `json:"outdated,omitempty"`
+
+	// Message explains why the instance is degraded, if known.
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	// ArgoCDFleetConditionTypeDegraded indicates whether some instances of the fleet are degraded.
+	ArgoCDFleetConditionTypeDegraded = "Degraded"
+
+	// ArgoCDFleetConditionTypeUpToDate indicates whether all the instances of the fleet run the expected version.
+	ArgoCDFleetConditionTypeUpToDate = "UpToDate"
+
+	// ArgoCDFleetConditionReasonInstancesDegraded is the reason of the Degraded condition when some instances are degraded.
+	ArgoCDFleetConditionReasonInstancesDegraded = "InstancesDegraded"
+
+	// ArgoCDFleetConditionReasonInstancesAvailable is the reason of the Degraded condition when no instance is degraded.
+	ArgoCDFleetConditionReasonInstancesAvailable = "InstancesAvailable"
+
+	// ArgoCDFleetConditionReasonInstancesOutdated is the reason of the UpToDate condition when some instances run
+	// another version than the expected one.
+	ArgoCDFleetConditionReasonInstancesOutdated = "InstancesOutdated"
+
+	// ArgoCDFleetConditionReasonExpectedVersion is the reason of the UpToDate condition when all the instances run the
+	// expected version.
+	ArgoCDFleetConditionReasonExpectedVersion = "ExpectedVersion"
+)
@@ -25,8 +25,10 @@ import (
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	"k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -35,7 +37,7 @@ func (in *ArgoCD) DeepCopyInto(out *ArgoCD) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCD.
@@ -56,6 +58,156 @@ func (in *ArgoCD) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAccountSpec) DeepCopyInto(out *ArgoCDAccountSpec) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]ArgoCDAccountCapability, len(*in))
+		copy(*out, *in)
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TokensSecretRef != nil {
+		in, out := &in.TokensSecretRef, &out.TokensSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAccountSpec.
+func (in *ArgoCDAccountSpec) DeepCopy() *ArgoCDAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAdminPasswordPolicySpec) DeepCopyInto(out *ArgoCDAdminPasswordPolicySpec) {
+	*out = *in
+	if in.RotateEvery != nil {
+		in, out := &in.RotateEvery, &out.RotateEvery
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAdminPasswordPolicySpec.
+func (in *ArgoCDAdminPasswordPolicySpec) DeepCopy() *ArgoCDAdminPasswordPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAdminPasswordPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAdoptionSpec) DeepCopyInto(out *ArgoCDAdoptionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAdoptionSpec.
+func (in *ArgoCDAdoptionSpec) DeepCopy() *ArgoCDAdoptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAdoptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDApplicationControllerClusterCacheSpec) DeepCopyInto(out *ArgoCDApplicationControllerClusterCacheSpec) {
+	*out = *in
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ListPageBufferSize != nil {
+		in, out := &in.ListPageBufferSize, &out.ListPageBufferSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ListPageSize != nil {
+		in, out := &in.ListPageSize, &out.ListPageSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ListSemaphore != nil {
+		in, out := &in.ListSemaphore, &out.ListSemaphore
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ResyncDuration != nil {
+		in, out := &in.ResyncDuration, &out.ResyncDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetryUseBackoff != nil {
+		in, out := &in.RetryUseBackoff, &out.RetryUseBackoff
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SyncRetryTimeout != nil {
+		in, out := &in.SyncRetryTimeout, &out.SyncRetryTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WatchResyncDuration != nil {
+		in, out := &in.WatchResyncDuration, &out.WatchResyncDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationControllerClusterCacheSpec.
+func (in *ArgoCDApplicationControllerClusterCacheSpec) DeepCopy() *ArgoCDApplicationControllerClusterCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDApplicationControllerClusterCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDApplicationControllerPersistenceSpec) DeepCopyInto(out *ArgoCDApplicationControllerPersistenceSpec) {
+	*out = *in
+	if in.StorageClass != nil {
+		in, out := &in.StorageClass, &out.StorageClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationControllerPersistenceSpec.
+func (in *ArgoCDApplicationControllerPersistenceSpec) DeepCopy() *ArgoCDApplicationControllerPersistenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDApplicationControllerPersistenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDApplicationControllerProcessorsSpec) DeepCopyInto(out *ArgoCDApplicationControllerProcessorsSpec) {
 	*out = *in
@@ -112,6 +264,41 @@ func (in *ArgoCDApplicationControllerSpec) DeepCopyInto(out *ArgoCDApplicationCo
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.AppHardResync != nil {
+		in, out := &in.AppHardResync, &out.AppHardResync
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SelfHealTimeout != nil {
+		in, out := &in.SelfHealTimeout, &out.SelfHealTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RepoServerTimeout != nil {
+		in, out := &in.RepoServerTimeout, &out.RepoServerTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RepoErrorGracePeriod != nil {
+		in, out := &in.RepoErrorGracePeriod, &out.RepoErrorGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.StatusCacheExpiration != nil {
+		in, out := &in.StatusCacheExpiration, &out.StatusCacheExpiration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.DefaultCacheExpiration != nil {
+		in, out := &in.DefaultCacheExpiration, &out.DefaultCacheExpiration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ClusterCache != nil {
+		in, out := &in.ClusterCache, &out.ClusterCache
+		*out = new(ArgoCDApplicationControllerClusterCacheSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Sharding.DeepCopyInto(&out.Sharding)
 	if in.SidecarContainers != nil {
 		in, out := &in.SidecarContainers, &out.SidecarContainers
@@ -151,6 +338,57 @@ func (in *ArgoCDApplicationControllerSpec) DeepCopyInto(out *ArgoCDApplicationCo
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(ArgoCDApplicationControllerPersistenceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreStopDelaySeconds != nil {
+		in, out := &in.PreStopDelaySeconds, &out.PreStopDelaySeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationControllerSpec.
@@ -183,6 +421,11 @@ func (in *ArgoCDApplicationSet) DeepCopyInto(out *ArgoCDApplicationSet) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
 	in.WebhookServer.DeepCopyInto(&out.WebhookServer)
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
@@ -199,6 +442,75 @@ func (in *ArgoCDApplicationSet) DeepCopyInto(out *ArgoCDApplicationSet) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarContainers != nil {
+		in, out := &in.SidecarContainers, &out.SidecarContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationSet.
@@ -211,6 +523,21 @@ func (in *ArgoCDApplicationSet) DeepCopy() *ArgoCDApplicationSet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDBackupLabelsSpec) DeepCopyInto(out *ArgoCDBackupLabelsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDBackupLabelsSpec.
+func (in *ArgoCDBackupLabelsSpec) DeepCopy() *ArgoCDBackupLabelsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDBackupLabelsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDCASpec) DeepCopyInto(out *ArgoCDCASpec) {
 	*out = *in
@@ -242,74 +569,412 @@ func (in *ArgoCDCertificateSpec) DeepCopy() *ArgoCDCertificateSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDDexSpec) DeepCopyInto(out *ArgoCDDexSpec) {
+func (in *ArgoCDConsoleLinkSpec) DeepCopyInto(out *ArgoCDConsoleLinkSpec) {
 	*out = *in
-	if in.Groups != nil {
-		in, out := &in.Groups, &out.Groups
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSpec.
-func (in *ArgoCDDexSpec) DeepCopy() *ArgoCDDexSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConsoleLinkSpec.
+func (in *ArgoCDConsoleLinkSpec) DeepCopy() *ArgoCDConsoleLinkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDDexSpec)
+	out := new(ArgoCDConsoleLinkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDGrafanaSpec) DeepCopyInto(out *ArgoCDGrafanaSpec) {
+func (in *ArgoCDDexStaticClient) DeepCopyInto(out *ArgoCDDexStaticClient) {
 	*out = *in
-	in.Ingress.DeepCopyInto(&out.Ingress)
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
-	in.Route.DeepCopyInto(&out.Route)
-	if in.Size != nil {
-		in, out := &in.Size, &out.Size
-		*out = new(int32)
-		**out = **in
+	if in.RedirectURIs != nil {
+		in, out := &in.RedirectURIs, &out.RedirectURIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGrafanaSpec.
-func (in *ArgoCDGrafanaSpec) DeepCopy() *ArgoCDGrafanaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexStaticClient.
+func (in *ArgoCDDexStaticClient) DeepCopy() *ArgoCDDexStaticClient {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDGrafanaSpec)
+	out := new(ArgoCDDexStaticClient)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDHASpec) DeepCopyInto(out *ArgoCDHASpec) {
+func (in *ArgoCDDexSpec) DeepCopyInto(out *ArgoCDDexSpec) {
 	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-}
-
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StaticClients != nil {
+		in, out := &in.StaticClients, &out.StaticClients
+		*out = make([]ArgoCDDexStaticClient, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RootCASecretRef != nil {
+		in, out := &in.RootCASecretRef, &out.RootCASecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSpec.
+func (in *ArgoCDDexSpec) DeepCopy() *ArgoCDDexSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDEphemeralStorageSpec) DeepCopyInto(out *ArgoCDEphemeralStorageSpec) {
+	*out = *in
+	if in.SizeLimits != nil {
+		in, out := &in.SizeLimits, &out.SizeLimits
+		*out = make(map[string]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDEphemeralStorageSpec.
+func (in *ArgoCDEphemeralStorageSpec) DeepCopy() *ArgoCDEphemeralStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDEphemeralStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDFleet) DeepCopyInto(out *ArgoCDFleet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDFleet.
+func (in *ArgoCDFleet) DeepCopy() *ArgoCDFleet {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDFleet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDFleet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDFleetList) DeepCopyInto(out *ArgoCDFleetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArgoCDFleet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDFleetList.
+func (in *ArgoCDFleetList) DeepCopy() *ArgoCDFleetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDFleetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDFleetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDFleetMemberStatus) DeepCopyInto(out *ArgoCDFleetMemberStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDFleetMemberStatus.
+func (in *ArgoCDFleetMemberStatus) DeepCopy() *ArgoCDFleetMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDFleetMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDFleetSpec) DeepCopyInto(out *ArgoCDFleetSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDFleetSpec.
+func (in *ArgoCDFleetSpec) DeepCopy() *ArgoCDFleetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDFleetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDFleetStatus) DeepCopyInto(out *ArgoCDFleetStatus) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]ArgoCDFleetMemberStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDFleetStatus.
+func (in *ArgoCDFleetStatus) DeepCopy() *ArgoCDFleetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDFleetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDGrafanaExternalSpec) DeepCopyInto(out *ArgoCDGrafanaExternalSpec) {
+	*out = *in
+	if in.DatasourceSecretRef != nil {
+		in, out := &in.DatasourceSecretRef, &out.DatasourceSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGrafanaExternalSpec.
+func (in *ArgoCDGrafanaExternalSpec) DeepCopy() *ArgoCDGrafanaExternalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDGrafanaExternalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDGrafanaSpec) DeepCopyInto(out *ArgoCDGrafanaSpec) {
+	*out = *in
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ArgoCDGrafanaExternalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Route.DeepCopyInto(&out.Route)
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGrafanaSpec.
+func (in *ArgoCDGrafanaSpec) DeepCopy() *ArgoCDGrafanaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDGrafanaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDHAProxyBackendSpec) DeepCopyInto(out *ArgoCDHAProxyBackendSpec) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHAProxyBackendSpec.
+func (in *ArgoCDHAProxyBackendSpec) DeepCopy() *ArgoCDHAProxyBackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDHAProxyBackendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDHAProxySpec) DeepCopyInto(out *ArgoCDHAProxySpec) {
+	*out = *in
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(ArgoCDHAProxyTimeoutsSpec)
+		**out = **in
+	}
+	if in.AdditionalBackends != nil {
+		in, out := &in.AdditionalBackends, &out.AdditionalBackends
+		*out = make([]ArgoCDHAProxyBackendSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHAProxySpec.
+func (in *ArgoCDHAProxySpec) DeepCopy() *ArgoCDHAProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDHAProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDHAProxyTimeoutsSpec) DeepCopyInto(out *ArgoCDHAProxyTimeoutsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHAProxyTimeoutsSpec.
+func (in *ArgoCDHAProxyTimeoutsSpec) DeepCopy() *ArgoCDHAProxyTimeoutsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDHAProxyTimeoutsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDHASpec) DeepCopyInto(out *ArgoCDHASpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HAProxy != nil {
+		in, out := &in.HAProxy, &out.HAProxy
+		*out = new(ArgoCDHAProxySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHASpec.
 func (in *ArgoCDHASpec) DeepCopy() *ArgoCDHASpec {
 	if in == nil {
@@ -320,6 +985,69 @@ func (in *ArgoCDHASpec) DeepCopy() *ArgoCDHASpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDImageOverridesSpec) DeepCopyInto(out *ArgoCDImageOverridesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDImageOverridesSpec.
+func (in *ArgoCDImageOverridesSpec) DeepCopy() *ArgoCDImageOverridesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDImageOverridesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDImageUpdaterConfigSpec) DeepCopyInto(out *ArgoCDImageUpdaterConfigSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDImageUpdaterConfigSpec.
+func (in *ArgoCDImageUpdaterConfigSpec) DeepCopy() *ArgoCDImageUpdaterConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDImageUpdaterConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDImageUpdaterSpec) DeepCopyInto(out *ArgoCDImageUpdaterSpec) {
+	*out = *in
+	in.Config.DeepCopyInto(&out.Config)
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDImageUpdaterSpec.
+func (in *ArgoCDImageUpdaterSpec) DeepCopy() *ArgoCDImageUpdaterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDImageUpdaterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDImportSpec) DeepCopyInto(out *ArgoCDImportSpec) {
 	*out = *in
@@ -340,6 +1068,21 @@ func (in *ArgoCDImportSpec) DeepCopy() *ArgoCDImportSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDInheritFromSpec) DeepCopyInto(out *ArgoCDInheritFromSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDInheritFromSpec.
+func (in *ArgoCDInheritFromSpec) DeepCopy() *ArgoCDInheritFromSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDInheritFromSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDIngressSpec) DeepCopyInto(out *ArgoCDIngressSpec) {
 	*out = *in
@@ -350,6 +1093,11 @@ func (in *ArgoCDIngressSpec) DeepCopyInto(out *ArgoCDIngressSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.IngressClassName != nil {
 		in, out := &in.IngressClassName, &out.IngressClassName
 		*out = new(string)
@@ -374,6 +1122,156 @@ func (in *ArgoCDIngressSpec) DeepCopy() *ArgoCDIngressSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKEDAAuthenticationRef) DeepCopyInto(out *ArgoCDKEDAAuthenticationRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKEDAAuthenticationRef.
+func (in *ArgoCDKEDAAuthenticationRef) DeepCopy() *ArgoCDKEDAAuthenticationRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKEDAAuthenticationRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKEDASpec) DeepCopyInto(out *ArgoCDKEDASpec) {
+	*out = *in
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]ArgoCDKEDATrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MinReplicaCount != nil {
+		in, out := &in.MinReplicaCount, &out.MinReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicaCount != nil {
+		in, out := &in.MaxReplicaCount, &out.MaxReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CooldownPeriod != nil {
+		in, out := &in.CooldownPeriod, &out.CooldownPeriod
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKEDASpec.
+func (in *ArgoCDKEDASpec) DeepCopy() *ArgoCDKEDASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKEDASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKEDATrigger) DeepCopyInto(out *ArgoCDKEDATrigger) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AuthenticationRef != nil {
+		in, out := &in.AuthenticationRef, &out.AuthenticationRef
+		*out = new(ArgoCDKEDAAuthenticationRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKEDATrigger.
+func (in *ArgoCDKEDATrigger) DeepCopy() *ArgoCDKEDATrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKEDATrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKeycloakDatabaseSpec) DeepCopyInto(out *ArgoCDKeycloakDatabaseSpec) {
+	*out = *in
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ArgoCDKeycloakExternalDatabaseSpec)
+		**out = **in
+	}
+	if in.ManagedPostgres != nil {
+		in, out := &in.ManagedPostgres, &out.ManagedPostgres
+		*out = new(ArgoCDKeycloakManagedPostgresSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakDatabaseSpec.
+func (in *ArgoCDKeycloakDatabaseSpec) DeepCopy() *ArgoCDKeycloakDatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakDatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKeycloakExternalDatabaseSpec) DeepCopyInto(out *ArgoCDKeycloakExternalDatabaseSpec) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakExternalDatabaseSpec.
+func (in *ArgoCDKeycloakExternalDatabaseSpec) DeepCopy() *ArgoCDKeycloakExternalDatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakExternalDatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDKeycloakManagedPostgresSpec) DeepCopyInto(out *ArgoCDKeycloakManagedPostgresSpec) {
+	*out = *in
+	if in.StorageClass != nil {
+		in, out := &in.StorageClass, &out.StorageClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakManagedPostgresSpec.
+func (in *ArgoCDKeycloakManagedPostgresSpec) DeepCopy() *ArgoCDKeycloakManagedPostgresSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakManagedPostgresSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDKeycloakSpec) DeepCopyInto(out *ArgoCDKeycloakSpec) {
 	*out = *in
@@ -387,14 +1285,56 @@ func (in *ArgoCDKeycloakSpec) DeepCopyInto(out *ArgoCDKeycloakSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(ArgoCDKeycloakDatabaseSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakSpec.
+func (in *ArgoCDKeycloakSpec) DeepCopy() *ArgoCDKeycloakSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDKeycloakSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDLabelPolicySpec) DeepCopyInto(out *ArgoCDLabelPolicySpec) {
+	*out = *in
+	if in.AdditionalLabels != nil {
+		in, out := &in.AdditionalLabels, &out.AdditionalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDKeycloakSpec.
-func (in *ArgoCDKeycloakSpec) DeepCopy() *ArgoCDKeycloakSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDLabelPolicySpec.
+func (in *ArgoCDLabelPolicySpec) DeepCopy() *ArgoCDLabelPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDKeycloakSpec)
+	out := new(ArgoCDLabelPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -431,6 +1371,26 @@ func (in *ArgoCDList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDLogSidecarSpec) DeepCopyInto(out *ArgoCDLogSidecarSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDLogSidecarSpec.
+func (in *ArgoCDLogSidecarSpec) DeepCopy() *ArgoCDLogSidecarSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDLogSidecarSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDMonitoringSpec) DeepCopyInto(out *ArgoCDMonitoringSpec) {
 	*out = *in
@@ -439,6 +1399,18 @@ func (in *ArgoCDMonitoringSpec) DeepCopyInto(out *ArgoCDMonitoringSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.LogSidecar != nil {
+		in, out := &in.LogSidecar, &out.LogSidecar
+		*out = new(ArgoCDLogSidecarSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceAttributes != nil {
+		in, out := &in.ResourceAttributes, &out.ResourceAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDMonitoringSpec.
@@ -500,6 +1472,53 @@ func (in *ArgoCDNotifications) DeepCopyInto(out *ArgoCDNotifications) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+
+	if in.RootCASecretRef != nil {
+		in, out := &in.RootCASecretRef, &out.RootCASecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Subscriptions != nil {
+		in, out := &in.Subscriptions, &out.Subscriptions
+		*out = make([]ArgoCDNotificationsSubscription, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotifications.
@@ -512,11 +1531,115 @@ func (in *ArgoCDNotifications) DeepCopy() *ArgoCDNotifications {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDOIDCClaimSpec) DeepCopyInto(out *ArgoCDOIDCClaimSpec) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOIDCClaimSpec.
+func (in *ArgoCDOIDCClaimSpec) DeepCopy() *ArgoCDOIDCClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDOIDCClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDOIDCSpec) DeepCopyInto(out *ArgoCDOIDCSpec) {
+	*out = *in
+	if in.ClientSecretRef != nil {
+		in, out := &in.ClientSecretRef, &out.ClientSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make(map[string]ArgoCDOIDCClaimSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOIDCSpec.
+func (in *ArgoCDOIDCSpec) DeepCopy() *ArgoCDOIDCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDOIDCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDOpenShiftSpec) DeepCopyInto(out *ArgoCDOpenShiftSpec) {
+	*out = *in
+	if in.ConsoleLink != nil {
+		in, out := &in.ConsoleLink, &out.ConsoleLink
+		*out = new(ArgoCDConsoleLinkSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDOpenShiftSpec.
+func (in *ArgoCDOpenShiftSpec) DeepCopy() *ArgoCDOpenShiftSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDOpenShiftSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotificationsSubscription) DeepCopyInto(out *ArgoCDNotificationsSubscription) {
+	*out = *in
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotificationsSubscription.
+func (in *ArgoCDNotificationsSubscription) DeepCopy() *ArgoCDNotificationsSubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotificationsSubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDPrometheusSpec) DeepCopyInto(out *ArgoCDPrometheusSpec) {
 	*out = *in
 	in.Ingress.DeepCopyInto(&out.Ingress)
 	in.Route.DeepCopyInto(&out.Route)
+	if in.ServiceMonitorLabels != nil {
+		in, out := &in.ServiceMonitorLabels, &out.ServiceMonitorLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Size != nil {
 		in, out := &in.Size, &out.Size
 		*out = new(int32)
@@ -534,6 +1657,21 @@ func (in *ArgoCDPrometheusSpec) DeepCopy() *ArgoCDPrometheusSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACPolicyChunkSpec) DeepCopyInto(out *ArgoCDRBACPolicyChunkSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACPolicyChunkSpec.
+func (in *ArgoCDRBACPolicyChunkSpec) DeepCopy() *ArgoCDRBACPolicyChunkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACPolicyChunkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDRBACSpec) DeepCopyInto(out *ArgoCDRBACSpec) {
 	*out = *in
@@ -557,6 +1695,11 @@ func (in *ArgoCDRBACSpec) DeepCopyInto(out *ArgoCDRBACSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PolicyChunks != nil {
+		in, out := &in.PolicyChunks, &out.PolicyChunks
+		*out = make([]ArgoCDRBACPolicyChunkSpec, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACSpec.
@@ -569,6 +1712,26 @@ func (in *ArgoCDRBACSpec) DeepCopy() *ArgoCDRBACSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisExporterSpec) DeepCopyInto(out *ArgoCDRedisExporterSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisExporterSpec.
+func (in *ArgoCDRedisExporterSpec) DeepCopy() *ArgoCDRedisExporterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisExporterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDRedisSpec) DeepCopyInto(out *ArgoCDRedisSpec) {
 	*out = *in
@@ -587,6 +1750,31 @@ func (in *ArgoCDRedisSpec) DeepCopyInto(out *ArgoCDRedisSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(ArgoCDRedisExporterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitResources != nil {
+		in, out := &in.InitResources, &out.InitResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisSpec.
@@ -599,14 +1787,85 @@ func (in *ArgoCDRedisSpec) DeepCopy() *ArgoCDRedisSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoAutoscaleSpec) DeepCopyInto(out *ArgoCDRepoAutoscaleSpec) {
+	*out = *in
+	if in.KEDA != nil {
+		in, out := &in.KEDA, &out.KEDA
+		*out = new(ArgoCDKEDASpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoAutoscaleSpec.
+func (in *ArgoCDRepoAutoscaleSpec) DeepCopy() *ArgoCDRepoAutoscaleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoAutoscaleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoCacheSpec) DeepCopyInto(out *ArgoCDRepoCacheSpec) {
+	*out = *in
+	if in.StorageClass != nil {
+		in, out := &in.StorageClass, &out.StorageClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoCacheSpec.
+func (in *ArgoCDRepoCacheSpec) DeepCopy() *ArgoCDRepoCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
 	*out = *in
+	in.Autoscale.DeepCopyInto(&out.Autoscale)
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(ArgoCDRepoCacheSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExtraRepoCommandArgs != nil {
 		in, out := &in.ExtraRepoCommandArgs, &out.ExtraRepoCommandArgs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.GRPCMaxMessageSizeMB != nil {
+		in, out := &in.GRPCMaxMessageSizeMB, &out.GRPCMaxMessageSizeMB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxCombinedDirectoryManifestsSize != nil {
+		in, out := &in.MaxCombinedDirectoryManifestsSize, &out.MaxCombinedDirectoryManifestsSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StreamedManifestMaxExtractedSize != nil {
+		in, out := &in.StreamedManifestMaxExtractedSize, &out.StreamedManifestMaxExtractedSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(int32)
@@ -636,6 +1895,11 @@ func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EphemeralStorage != nil {
+		in, out := &in.EphemeralStorage, &out.EphemeralStorage
+		*out = new(ArgoCDEphemeralStorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
 		*out = make([]v1.VolumeMount, len(*in))
@@ -657,6 +1921,11 @@ func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PluginResourceDefaults != nil {
+		in, out := &in.PluginResourceDefaults, &out.PluginResourceDefaults
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
@@ -667,14 +1936,80 @@ func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoSpec.
+func (in *ArgoCDRepoSpec) DeepCopy() *ArgoCDRepoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDResourceBudgetSpec) DeepCopyInto(out *ArgoCDResourceBudgetSpec) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoSpec.
-func (in *ArgoCDRepoSpec) DeepCopy() *ArgoCDRepoSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDResourceBudgetSpec.
+func (in *ArgoCDResourceBudgetSpec) DeepCopy() *ArgoCDResourceBudgetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDRepoSpec)
+	out := new(ArgoCDResourceBudgetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -701,6 +2036,11 @@ func (in *ArgoCDRouteSpec) DeepCopyInto(out *ArgoCDRouteSpec) {
 		*out = new(routev1.TLSConfig)
 		**out = **in
 	}
+	if in.TLSSecretRef != nil {
+		in, out := &in.TLSSecretRef, &out.TLSSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
 	if in.WildcardPolicy != nil {
 		in, out := &in.WildcardPolicy, &out.WildcardPolicy
 		*out = new(routev1.WildcardPolicyType)
@@ -731,6 +2071,11 @@ func (in *ArgoCDSSOSpec) DeepCopyInto(out *ArgoCDSSOSpec) {
 		*out = new(ArgoCDKeycloakSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(ArgoCDOIDCSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSSOSpec.
@@ -751,6 +2096,16 @@ func (in *ArgoCDServerAutoscaleSpec) DeepCopyInto(out *ArgoCDServerAutoscaleSpec
 		*out = new(autoscalingv1.HorizontalPodAutoscalerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.KEDA != nil {
+		in, out := &in.KEDA, &out.KEDA
+		*out = new(ArgoCDKEDASpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Operator != nil {
+		in, out := &in.Operator, &out.Operator
+		*out = new(ArgoCDServerOperatorAutoscaleSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerAutoscaleSpec.
@@ -763,6 +2118,26 @@ func (in *ArgoCDServerAutoscaleSpec) DeepCopy() *ArgoCDServerAutoscaleSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerCustomStylesSpec) DeepCopyInto(out *ArgoCDServerCustomStylesSpec) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerCustomStylesSpec.
+func (in *ArgoCDServerCustomStylesSpec) DeepCopy() *ArgoCDServerCustomStylesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerCustomStylesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDServerGRPCSpec) DeepCopyInto(out *ArgoCDServerGRPCSpec) {
 	*out = *in
@@ -779,9 +2154,113 @@ func (in *ArgoCDServerGRPCSpec) DeepCopy() *ArgoCDServerGRPCSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerOperatorAutoscaleSpec) DeepCopyInto(out *ArgoCDServerOperatorAutoscaleSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetRequestsPerReplica != nil {
+		in, out := &in.TargetRequestsPerReplica, &out.TargetRequestsPerReplica
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetLatency != nil {
+		in, out := &in.TargetLatency, &out.TargetLatency
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ScaleDownDelay != nil {
+		in, out := &in.ScaleDownDelay, &out.ScaleDownDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerOperatorAutoscaleSpec.
+func (in *ArgoCDServerOperatorAutoscaleSpec) DeepCopy() *ArgoCDServerOperatorAutoscaleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerOperatorAutoscaleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerRolloutStrategy) DeepCopyInto(out *ArgoCDServerRolloutStrategy) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerRolloutStrategy.
+func (in *ArgoCDServerRolloutStrategy) DeepCopy() *ArgoCDServerRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDServerServiceSpec) DeepCopyInto(out *ArgoCDServerServiceSpec) {
 	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LoadBalancerClass != nil {
+		in, out := &in.LoadBalancerClass, &out.LoadBalancerClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(v1.IPFamilyPolicy)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]v1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerServiceSpec.
@@ -794,6 +2273,31 @@ func (in *ArgoCDServerServiceSpec) DeepCopy() *ArgoCDServerServiceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerSessionSpec) DeepCopyInto(out *ArgoCDServerSessionSpec) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerSessionSpec.
+func (in *ArgoCDServerSessionSpec) DeepCopy() *ArgoCDServerSessionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerSessionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
 	*out = *in
@@ -817,8 +2321,18 @@ func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(ArgoCDServerRolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Route.DeepCopyInto(&out.Route)
-	out.Service = in.Service
+	in.Service.DeepCopyInto(&out.Service)
+	if in.Session != nil {
+		in, out := &in.Session, &out.Session
+		*out = new(ArgoCDServerSessionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SidecarContainers != nil {
 		in, out := &in.SidecarContainers, &out.SidecarContainers
 		*out = make([]v1.Container, len(*in))
@@ -850,6 +2364,11 @@ func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EphemeralStorage != nil {
+		in, out := &in.EphemeralStorage, &out.EphemeralStorage
+		*out = new(ArgoCDEphemeralStorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
 		*out = make([]v1.VolumeMount, len(*in))
@@ -857,6 +2376,52 @@ func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CustomStyles != nil {
+		in, out := &in.CustomStyles, &out.CustomStyles
+		*out = new(ArgoCDServerCustomStylesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerSpec.
@@ -872,11 +2437,33 @@ func (in *ArgoCDServerSpec) DeepCopy() *ArgoCDServerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 	*out = *in
+	if in.Accounts != nil {
+		in, out := &in.Accounts, &out.Accounts
+		*out = make([]ArgoCDAccountSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdminPasswordPolicy != nil {
+		in, out := &in.AdminPasswordPolicy, &out.AdminPasswordPolicy
+		*out = new(ArgoCDAdminPasswordPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(ArgoCDAdoptionSpec)
+		**out = **in
+	}
 	if in.ApplicationSet != nil {
 		in, out := &in.ApplicationSet, &out.ApplicationSet
 		*out = new(ArgoCDApplicationSet)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BackupLabels != nil {
+		in, out := &in.BackupLabels, &out.BackupLabels
+		*out = new(ArgoCDBackupLabelsSpec)
+		**out = **in
+	}
 	in.Controller.DeepCopyInto(&out.Controller)
 	if in.ExtraConfig != nil {
 		in, out := &in.ExtraConfig, &out.ExtraConfig
@@ -885,19 +2472,73 @@ func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.GPGKeys != nil {
+		in, out := &in.GPGKeys, &out.GPGKeys
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	in.Grafana.DeepCopyInto(&out.Grafana)
 	in.HA.DeepCopyInto(&out.HA)
+	if in.FIPSImages != nil {
+		in, out := &in.FIPSImages, &out.FIPSImages
+		*out = new(ArgoCDImageOverridesSpec)
+		**out = **in
+	}
+	if in.ImageOverrides != nil {
+		in, out := &in.ImageOverrides, &out.ImageOverrides
+		*out = make(map[string]ArgoCDImageOverridesSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.ImageUpdater.DeepCopyInto(&out.ImageUpdater)
+	if in.InheritFrom != nil {
+		in, out := &in.InheritFrom, &out.InheritFrom
+		*out = new(ArgoCDInheritFromSpec)
+		**out = **in
+	}
 	if in.Import != nil {
 		in, out := &in.Import, &out.Import
 		*out = new(ArgoCDImportSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	out.InitialSSHKnownHosts = in.InitialSSHKnownHosts
+	if in.InitialApplications != nil {
+		in, out := &in.InitialApplications, &out.InitialApplications
+		*out = make([]InitialResourceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitialProjects != nil {
+		in, out := &in.InitialProjects, &out.InitialProjects
+		*out = make([]InitialResourceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.InitialSSHKnownHosts.DeepCopyInto(&out.InitialSSHKnownHosts)
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(v1.IPFamilyPolicy)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]v1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
 	if in.KustomizeVersions != nil {
 		in, out := &in.KustomizeVersions, &out.KustomizeVersions
 		*out = make([]KustomizeVersionSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.LabelPolicy != nil {
+		in, out := &in.LabelPolicy, &out.LabelPolicy
+		*out = new(ArgoCDLabelPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Monitoring.DeepCopyInto(&out.Monitoring)
 	if in.NodePlacement != nil {
 		in, out := &in.NodePlacement, &out.NodePlacement
@@ -905,15 +2546,32 @@ func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Notifications.DeepCopyInto(&out.Notifications)
+	if in.OpenShift != nil {
+		in, out := &in.OpenShift, &out.OpenShift
+		*out = new(ArgoCDOpenShiftSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Prometheus.DeepCopyInto(&out.Prometheus)
 	in.RBAC.DeepCopyInto(&out.RBAC)
 	in.Redis.DeepCopyInto(&out.Redis)
 	in.Repo.DeepCopyInto(&out.Repo)
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]RepositorySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ResourceHealthChecks != nil {
 		in, out := &in.ResourceHealthChecks, &out.ResourceHealthChecks
 		*out = make([]ResourceHealthCheck, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResourceCompareOptions != nil {
+		in, out := &in.ResourceCompareOptions, &out.ResourceCompareOptions
+		*out = new(ResourceCompareOptions)
+		**out = **in
+	}
 	if in.ResourceIgnoreDifferences != nil {
 		in, out := &in.ResourceIgnoreDifferences, &out.ResourceIgnoreDifferences
 		*out = new(ResourceIgnoreDifference)
@@ -924,6 +2582,21 @@ func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
 		*out = make([]ResourceAction, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResourceBudget != nil {
+		in, out := &in.ResourceBudget, &out.ResourceBudget
+		*out = new(ArgoCDResourceBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResyncPeriod != nil {
+		in, out := &in.ResyncPeriod, &out.ResyncPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SecretSeedRef != nil {
+		in, out := &in.SecretSeedRef, &out.SecretSeedRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Server.DeepCopyInto(&out.Server)
 	if in.SourceNamespaces != nil {
 		in, out := &in.SourceNamespaces, &out.SourceNamespaces
@@ -956,6 +2629,34 @@ func (in *ArgoCDSpec) DeepCopy() *ArgoCDSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDStatus) DeepCopyInto(out *ArgoCDStatus) {
 	*out = *in
+	if in.ComponentMessages != nil {
+		in, out := &in.ComponentMessages, &out.ComponentMessages
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ControllerShards != nil {
+		in, out := &in.ControllerShards, &out.ControllerShards
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDStatus.
@@ -979,6 +2680,11 @@ func (in *ArgoCDTLSSpec) DeepCopyInto(out *ArgoCDTLSSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTLSSpec.
@@ -1036,6 +2742,26 @@ func (in *IgnoreDifferenceCustomization) DeepCopy() *IgnoreDifferenceCustomizati
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InitialResourceSpec) DeepCopyInto(out *InitialResourceSpec) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitialResourceSpec.
+func (in *InitialResourceSpec) DeepCopy() *InitialResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InitialResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KustomizeVersionSpec) DeepCopyInto(out *KustomizeVersionSpec) {
 	*out = *in
@@ -1051,6 +2777,26 @@ func (in *KustomizeVersionSpec) DeepCopy() *KustomizeVersionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositorySpec.
+func (in *RepositorySpec) DeepCopy() *RepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceAction) DeepCopyInto(out *ResourceAction) {
 	*out = *in
@@ -1066,6 +2812,21 @@ func (in *ResourceAction) DeepCopy() *ResourceAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceCompareOptions) DeepCopyInto(out *ResourceCompareOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceCompareOptions.
+func (in *ResourceCompareOptions) DeepCopy() *ResourceCompareOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceCompareOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceHealthCheck) DeepCopyInto(out *ResourceHealthCheck) {
 	*out = *in
@@ -1127,6 +2888,11 @@ func (in *ResourceIgnoreDifference) DeepCopy() *ResourceIgnoreDifference {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSHHostsSpec) DeepCopyInto(out *SSHHostsSpec) {
 	*out = *in
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHHostsSpec.
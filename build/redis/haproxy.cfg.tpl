@@ -1,20 +1,38 @@
-{{- if eq .UseTLS "true"}}
+{{- if or (eq .UseTLS "true") .MaxConn}}
 global
+{{- if eq .UseTLS "true"}}
     ca-base /app/config/redis/tls
 {{- end}}
+{{- if .MaxConn}}
+    maxconn {{.MaxConn}}
+{{- end}}
+{{- end}}
 
 defaults REDIS
     mode tcp
-    timeout connect 4s
-    timeout server 6m
-    timeout client 6m
-    timeout check 2s
+    timeout connect {{.TimeoutConnect}}
+    timeout server {{.TimeoutServer}}
+    timeout client {{.TimeoutClient}}
+    timeout check {{.TimeoutCheck}}
 
 listen health_check_http_url
     bind :8888
+{{- if eq .IPv6 "true"}}
+    bind :::8888 v6only
+{{- end}}
     mode http
     monitor-uri /healthz
     option      dontlognull
+{{- if eq .MetricsEnabled "true"}}
+
+frontend metrics
+    mode http
+    bind *:{{.MetricsPort}}
+{{- if eq .IPv6 "true"}}
+    bind :::{{.MetricsPort}} v6only
+{{- end}}
+    http-request use-service prometheus-exporter if { path /metrics }
+{{- end}}
 # Check Sentinel and whether they are nominated master
 backend check_if_redis_is_master_0
     mode tcp
@@ -33,13 +51,13 @@ backend check_if_redis_is_master_0
     tcp-check send QUIT\r\n
     tcp-check expect string +OK
 {{- if eq .UseTLS "false"}}
-    server R0 {{.ServiceName}}-announce-0:26379 check inter 3s
-    server R1 {{.ServiceName}}-announce-1:26379 check inter 3s
-    server R2 {{.ServiceName}}-announce-2:26379 check inter 3s
+    server R0 {{.ServiceName}}-announce-0:{{.SentinelPort}} check inter 3s
+    server R1 {{.ServiceName}}-announce-1:{{.SentinelPort}} check inter 3s
+    server R2 {{.ServiceName}}-announce-2:{{.SentinelPort}} check inter 3s
 {{- else}}
-    server R0 {{.ServiceName}}-announce-0:26379 verify required ca-file tls.crt check inter 3s
-    server R1 {{.ServiceName}}-announce-1:26379 verify required ca-file tls.crt check inter 3s
-    server R2 {{.ServiceName}}-announce-2:26379 verify required ca-file tls.crt check inter 3s
+    server R0 {{.ServiceName}}-announce-0:{{.SentinelPort}} verify required ca-file tls.crt check inter 3s
+    server R1 {{.ServiceName}}-announce-1:{{.SentinelPort}} verify required ca-file tls.crt check inter 3s
+    server R2 {{.ServiceName}}-announce-2:{{.SentinelPort}} verify required ca-file tls.crt check inter 3s
 {{- end}}
 # Check Sentinel and whether they are nominated master
 backend check_if_redis_is_master_1
@@ -59,13 +77,13 @@ backend check_if_redis_is_master_1
     tcp-check send QUIT\r\n
     tcp-check expect string +OK
 {{- if eq .UseTLS "false"}}
-    server R0 {{.ServiceName}}-announce-0:26379 check inter 3s
-    server R1 {{.ServiceName}}-announce-1:26379 check inter 3s
-    server R2 {{.ServiceName}}-announce-2:26379 check inter 3s
+    server R0 {{.ServiceName}}-announce-0:{{.SentinelPort}} check inter 3s
+    server R1 {{.ServiceName}}-announce-1:{{.SentinelPort}} check inter 3s
+    server R2 {{.ServiceName}}-announce-2:{{.SentinelPort}} check inter 3s
 {{- else}}
-    server R0 {{.ServiceName}}-announce-0:26379 verify required ca-file tls.crt check inter 3s
-    server R1 {{.ServiceName}}-announce-1:26379 verify required ca-file tls.crt check inter 3s
-    server R2 {{.ServiceName}}-announce-2:26379 verify required ca-file tls.crt check inter 3s
+    server R0 {{.ServiceName}}-announce-0:{{.SentinelPort}} verify required ca-file tls.crt check inter 3s
+    server R1 {{.ServiceName}}-announce-1:{{.SentinelPort}} verify required ca-file tls.crt check inter 3s
+    server R2 {{.ServiceName}}-announce-2:{{.SentinelPort}} verify required ca-file tls.crt check inter 3s
 {{- end}}
 # Check Sentinel and whether they are nominated master
 backend check_if_redis_is_master_2
@@ -85,19 +103,22 @@ backend check_if_redis_is_master_2
     tcp-check send QUIT\r\n
     tcp-check expect string +OK
 {{- if eq .UseTLS "false"}}
-    server R0 {{.ServiceName}}-announce-0:26379 check inter 3s
-    server R1 {{.ServiceName}}-announce-1:26379 check inter 3s
-    server R2 {{.ServiceName}}-announce-2:26379 check inter 3s
+    server R0 {{.ServiceName}}-announce-0:{{.SentinelPort}} check inter 3s
+    server R1 {{.ServiceName}}-announce-1:{{.SentinelPort}} check inter 3s
+    server R2 {{.ServiceName}}-announce-2:{{.SentinelPort}} check inter 3s
 {{- else}}
-    server R0 {{.ServiceName}}-announce-0:26379 verify required ca-file tls.crt check inter 3s
-    server R1 {{.ServiceName}}-announce-1:26379 verify required ca-file tls.crt check inter 3s
-    server R2 {{.ServiceName}}-announce-2:26379 verify required ca-file tls.crt check inter 3s
+    server R0 {{.ServiceName}}-announce-0:{{.SentinelPort}} verify required ca-file tls.crt check inter 3s
+    server R1 {{.ServiceName}}-announce-1:{{.SentinelPort}} verify required ca-file tls.crt check inter 3s
+    server R2 {{.ServiceName}}-announce-2:{{.SentinelPort}} verify required ca-file tls.crt check inter 3s
 {{- end}}
 
 # decide redis backend to use
 #master
 frontend ft_redis_master
-    bind *:6379
+    bind *:{{.RedisPort}}
+{{- if eq .IPv6 "true"}}
+    bind :::{{.RedisPort}} v6only
+{{- end}}
     use_backend bk_redis_master
 # Check all redis servers to see if they think they are master
 backend bk_redis_master
@@ -118,16 +139,24 @@ backend bk_redis_master
     tcp-check expect string +OK
 {{- if eq .UseTLS "false"}}
     use-server R0 if { srv_is_up(R0) } { nbsrv(check_if_redis_is_master_0) ge 2 }
-    server R0 {{.ServiceName}}-announce-0:6379 check inter 3s fall 1 rise 1
+    server R0 {{.ServiceName}}-announce-0:{{.RedisPort}} check inter 3s fall 1 rise 1
     use-server R1 if { srv_is_up(R1) } { nbsrv(check_if_redis_is_master_1) ge 2 }
-    server R1 {{.ServiceName}}-announce-1:6379 check inter 3s fall 1 rise 1
+    server R1 {{.ServiceName}}-announce-1:{{.RedisPort}} check inter 3s fall 1 rise 1
     use-server R2 if { srv_is_up(R2) } { nbsrv(check_if_redis_is_master_2) ge 2 }
-    server R2 {{.ServiceName}}-announce-2:6379 check inter 3s fall 1 rise 1
+    server R2 {{.ServiceName}}-announce-2:{{.RedisPort}} check inter 3s fall 1 rise 1
 {{- else}}
     use-server R0 if { srv_is_up(R0) } { nbsrv(check_if_redis_is_master_0) ge 2 }
-    server R0 {{.ServiceName}}-announce-0:6379 verify required ca-file tls.crt check inter 3s fall 1 rise 1
+    server R0 {{.ServiceName}}-announce-0:{{.RedisPort}} verify required ca-file tls.crt check inter 3s fall 1 rise 1
     use-server R1 if { srv_is_up(R1) } { nbsrv(check_if_redis_is_master_1) ge 2 }
-    server R1 {{.ServiceName}}-announce-1:6379 verify required ca-file tls.crt check inter 3s fall 1 rise 1
+    server R1 {{.ServiceName}}-announce-1:{{.RedisPort}} verify required ca-file tls.crt check inter 3s fall 1 rise 1
     use-server R2 if { srv_is_up(R2) } { nbsrv(check_if_redis_is_master_2) ge 2 }
-    server R2 {{.ServiceName}}-announce-2:6379 verify required ca-file tls.crt check inter 3s fall 1 rise 1
+    server R2 {{.ServiceName}}-announce-2:{{.RedisPort}} verify required ca-file tls.crt check inter 3s fall 1 rise 1
+{{- end}}
+{{- if .AdditionalBackends}}
+
+{{.AdditionalBackends}}
+{{- end}}
+{{- if .ConfigOverrides}}
+
+{{.ConfigOverrides}}
 {{- end}}
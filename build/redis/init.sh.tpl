@@ -1,20 +1,20 @@
 echo "$(date) Start..."
 HOSTNAME="$(cat /proc/sys/kernel/hostname)"
 INDEX="${HOSTNAME##*-}"
-SENTINEL_PORT={{- if eq .UseTLS "false" -}}26379{{- else -}}0{{- end }}
+SENTINEL_PORT={{- if eq .UseTLS "false" -}}{{.SentinelPort}}{{- else -}}0{{- end }}
 MASTER=''
 MASTER_GROUP="argocd"
 QUORUM="2"
 REDIS_CONF=/data/conf/redis.conf
 {{- if eq .UseTLS "false"}}
-REDIS_PORT=6379
+REDIS_PORT={{.RedisPort}}
 REDIS_TLS_PORT=
 {{- else}}
 REDIS_PORT=0
-REDIS_TLS_PORT=6379
+REDIS_TLS_PORT={{.RedisPort}}
 {{- end}}
 SENTINEL_CONF=/data/conf/sentinel.conf
-SENTINEL_TLS_PORT={{- if eq .UseTLS "true" -}}26379{{- end }}
+SENTINEL_TLS_PORT={{- if eq .UseTLS "true" -}}{{.SentinelPort}}{{- end }}
 SERVICE={{.ServiceName}}
 SENTINEL_TLS_REPLICATION_ENABLED={{.UseTLS}}
 REDIS_TLS_REPLICATION_ENABLED={{.UseTLS}}
@@ -2,7 +2,7 @@ response=$(
   redis-cli \
     -a "${AUTH}" --no-auth-warning \
     -h localhost \
-    -p 6379 \
+    -p {{.RedisPort}} \
 {{- if eq .UseTLS "true"}}
     --tls \
     --cacert /app/config/redis/tls/tls.crt \
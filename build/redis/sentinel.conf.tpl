@@ -1,16 +1,16 @@
 dir "/data"
 {{- if eq .UseTLS "false"}}
-port 26379
+port {{.SentinelPort}}
 {{- else}}
 port 0
-tls-port 26379
+tls-port {{.SentinelPort}}
 tls-cert-file /app/config/redis/tls/tls.crt
 tls-ca-cert-file /app/config/redis/tls/tls.crt
 tls-key-file /app/config/redis/tls/tls.key
 tls-replication yes
 tls-auth-clients no
 {{- end}}
-bind 0.0.0.0
+bind 0.0.0.0{{- if eq .IPv6 "true"}} ::{{- end}}
     sentinel down-after-milliseconds argocd 10000
     sentinel failover-timeout argocd 180000
     maxclients 10000
@@ -1,7 +1,7 @@
 response=$(
   redis-cli \
     -h localhost \
-    -p 26379 \
+    -p {{.SentinelPort}} \
 {{- if eq .UseTLS "true"}}
     --tls \
     --cacert /app/config/redis/tls/tls.crt \
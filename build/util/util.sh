@@ -21,37 +21,199 @@ BACKUP_FILENAME=argocd-backup.yaml
 BACKUP_EXPORT_LOCATION=/tmp/${BACKUP_FILENAME}
 BACKUP_ENCRYPT_LOCATION=/backups/${BACKUP_FILENAME}
 BACKUP_KEY_LOCATION=/secrets/backup.key
+BACKUP_INDEX_FILENAME=argocd-backup.index
+BACKUP_CHAIN_FILENAME=argocd-backup.chain
+BACKUP_TIME_FILENAME=argocd-backup.time
+BACKUP_DOCUMENTS_LOCATION=/tmp/argocd-backup-documents
 DEFAULT_BACKUP_BUCKET_REGION="us-east-1"
 
 export_argocd () {
     echo "exporting argo-cd"
-    create_backup
-    encrypt_backup
-    push_backup
+    if [[ "${BACKUP_INCREMENTAL}" == "true" ]]; then
+        export_incremental
+    else
+        create_backup
+        encrypt_backup
+        report_export ${BACKUP_ENCRYPT_LOCATION}
+        push_backup
+        reset_chain
+    fi
     echo "argo-cd export complete"
 }
 
+# reset_chain empties the chain left by previous incremental exports, so that they are not applied on top of a
+# newer full backup on import.
+reset_chain () {
+    if pull_file ${BACKUP_CHAIN_FILENAME}; then
+        prune_chain
+        : > /backups/${BACKUP_CHAIN_FILENAME}
+        push_file ${BACKUP_CHAIN_FILENAME}
+    fi
+}
+
+# retention_enabled succeeds when a retention of the exported archives is configured.
+retention_enabled () {
+    [[ -n "${BACKUP_RETENTION_MAX_BACKUPS}" || -n "${BACKUP_RETENTION_MAX_AGE}" ]]
+}
+
+# within_retention succeeds when a new incremental backup can be added to the pulled chain without exceeding the
+# maximum number of archives, counting the full backup, nor the maximum age of the full backup.
+within_retention () {
+    if [[ -n "${BACKUP_RETENTION_MAX_BACKUPS}" ]] && (( `grep -c . /backups/${BACKUP_CHAIN_FILENAME} || true` + 2 > BACKUP_RETENTION_MAX_BACKUPS )); then
+        echo "maximum number of argo-cd backups reached"
+        return 1
+    fi
+    if [[ -n "${BACKUP_RETENTION_MAX_AGE}" ]]; then
+        if ! pull_file ${BACKUP_TIME_FILENAME} || (( `date -u +%s` - `cat /backups/${BACKUP_TIME_FILENAME}` >= BACKUP_RETENTION_MAX_AGE )); then
+            echo "maximum age of argo-cd backup reached"
+            return 1
+        fi
+    fi
+}
+
+# prune_chain deletes the incremental backups listed in the pulled chain when a retention is configured, once a
+# newer full backup replaces them.
+prune_chain () {
+    if ! retention_enabled; then
+        return 0
+    fi
+    for increment in `cat /backups/${BACKUP_CHAIN_FILENAME}`; do
+        echo "pruning argo-cd incremental backup ${increment}"
+        delete_file ${increment}
+    done
+}
+
+# resources_deleted succeeds when resources listed in the given previous index are no longer exported, as an
+# incremental backup cannot remove them on import, or when the previous index does not identify its resources.
+resources_deleted () {
+    if awk 'NF < 2 { found = 1 } END { exit !found }' $1; then
+        echo "previous argo-cd export index does not identify its resources"
+        return 0
+    fi
+    BACKUP_DELETED_COUNT=`comm -23 <(cut -d ' ' -f 2 $1 | sort -u) <(cut -d ' ' -f 2 /tmp/${BACKUP_INDEX_FILENAME} | sort -u) | grep -c . || true`
+    if (( BACKUP_DELETED_COUNT > 0 )); then
+        echo "${BACKUP_DELETED_COUNT} resources deleted since the previous export"
+        return 0
+    fi
+    return 1
+}
+
+# export_incremental stores a full backup on the first run, then only the documents that changed since the
+# previous export. The index holds the checksum and the identity of every exported document and the chain lists the
+# incremental backups, in order, that must be applied on top of the full backup on import. A full backup is stored
+# again when resources were deleted since the previous export, as the import of an incremental backup only adds and
+# updates resources.
+export_incremental () {
+    create_backup
+    index_backup
+    if pull_file ${BACKUP_INDEX_FILENAME} && pull_file ${BACKUP_CHAIN_FILENAME} && within_retention \
+        && decrypt_file ${BACKUP_INDEX_FILENAME} /tmp/${BACKUP_INDEX_FILENAME}.previous \
+        && ! resources_deleted /tmp/${BACKUP_INDEX_FILENAME}.previous; then
+        BACKUP_INCREMENT_FILENAME=argocd-backup-`date -u +%Y%m%d%H%M%S`.yaml
+        create_increment /tmp/${BACKUP_INDEX_FILENAME}.previous /tmp/${BACKUP_INCREMENT_FILENAME}
+        encrypt_file /tmp/${BACKUP_INCREMENT_FILENAME} ${BACKUP_INCREMENT_FILENAME}
+        report_export /backups/${BACKUP_INCREMENT_FILENAME}
+        push_file ${BACKUP_INCREMENT_FILENAME}
+        echo ${BACKUP_INCREMENT_FILENAME} >> /backups/${BACKUP_CHAIN_FILENAME}
+        rm ${BACKUP_EXPORT_LOCATION}
+    else
+        echo "no previous argo-cd export to increment, creating a full backup"
+        encrypt_backup
+        report_export ${BACKUP_ENCRYPT_LOCATION}
+        push_backup
+        if [[ -f /backups/${BACKUP_CHAIN_FILENAME} ]]; then
+            prune_chain
+        fi
+        : > /backups/${BACKUP_CHAIN_FILENAME}
+        date -u +%s > /backups/${BACKUP_TIME_FILENAME}
+        push_file ${BACKUP_TIME_FILENAME}
+    fi
+    rm -f /tmp/${BACKUP_INDEX_FILENAME}.previous
+    encrypt_file /tmp/${BACKUP_INDEX_FILENAME} ${BACKUP_INDEX_FILENAME}
+    push_file ${BACKUP_INDEX_FILENAME}
+    push_file ${BACKUP_CHAIN_FILENAME}
+    rm -rf ${BACKUP_DOCUMENTS_LOCATION}
+}
+
 create_backup () {
     echo "creating argo-cd backup"
     argocd admin export > ${BACKUP_EXPORT_LOCATION}
 }
 
+index_backup () {
+    echo "indexing argo-cd backup"
+    rm -rf ${BACKUP_DOCUMENTS_LOCATION}
+    mkdir -p ${BACKUP_DOCUMENTS_LOCATION}
+    awk -v dir=${BACKUP_DOCUMENTS_LOCATION} '
+        BEGIN { n = 0; f = sprintf("%s/%08d.yaml", dir, n) }
+        /^---$/ { close(f); n++; f = sprintf("%s/%08d.yaml", dir, n); next }
+        { print > f }
+    ' ${BACKUP_EXPORT_LOCATION}
+    : > /tmp/${BACKUP_INDEX_FILENAME}
+    for document in ${BACKUP_DOCUMENTS_LOCATION}/*.yaml; do
+        if [[ -s "${document}" ]]; then
+            echo "`sha256sum "${document}" | cut -d ' ' -f 1` `resource_identity "${document}"`" >> /tmp/${BACKUP_INDEX_FILENAME}
+        fi
+    done
+}
+
+# resource_identity prints the kind, namespace and name of the resource in the given document.
+resource_identity () {
+    awk '
+        /^kind:/ { kind = $2 }
+        /^metadata:/ { metadata = 1; next }
+        /^[^ ]/ { metadata = 0 }
+        metadata && /^  name:/ { name = $2 }
+        metadata && /^  namespace:/ { namespace = $2 }
+        END { printf "%s/%s/%s\n", kind, namespace, name }
+    ' $1
+}
+
+create_increment () {
+    echo "creating argo-cd incremental backup"
+    : > $2
+    for document in ${BACKUP_DOCUMENTS_LOCATION}/*.yaml; do
+        if [[ -s "${document}" ]] && ! grep -q "^`sha256sum "${document}" | cut -d ' ' -f 1` " $1; then
+            echo "---" >> $2
+            cat "${document}" >> $2
+        fi
+    done
+    echo "`grep -c '^---$' $2 || true` resources changed since the previous export"
+}
+
 encrypt_backup () {
     echo "encrypting argo-cd backup"
     openssl enc -aes-256-cbc -pbkdf2 -pass file:${BACKUP_KEY_LOCATION} -in ${BACKUP_EXPORT_LOCATION} -out ${BACKUP_ENCRYPT_LOCATION}
     rm ${BACKUP_EXPORT_LOCATION}
 }
 
+encrypt_file () {
+    openssl enc -aes-256-cbc -pbkdf2 -pass file:${BACKUP_KEY_LOCATION} -in $1 -out /backups/$2
+    rm $1
+}
+
+# report_export writes the size and the checksum of the given encrypted file to the termination message of the
+# container, from which the operator reports them in the status of the ArgoCDExport.
+report_export () {
+    if [[ -w /dev/termination-log ]]; then
+        echo "{\"size\":`stat -c %s $1`,\"checksum\":\"`sha256sum $1 | cut -d ' ' -f 1`\"}" > /dev/termination-log
+    fi
+}
+
 push_backup () {
+    push_file ${BACKUP_FILENAME}
+}
+
+push_file () {
     case  ${BACKUP_LOCATION} in
         "aws")
-            push_aws
+            push_aws $1
             ;;
         "azure")
-            push_azure
+            push_azure $1
             ;;
         "gcp")
-            push_gcp
+            push_gcp $1
             ;;
         *)
         # local and unsupported backends
@@ -75,7 +237,7 @@ push_aws () {
         aws s3 mb ${BACKUP_BUCKET_URI} --region ${BACKUP_BUCKET_REGION}
         aws s3api put-public-access-block --bucket ${BACKUP_BUCKET_NAME} --public-access-block-configuration "BlockPublicAcls=true,IgnorePublicAcls=true,BlockPublicPolicy=true,RestrictPublicBuckets=true"
     fi
-    aws s3 cp ${BACKUP_ENCRYPT_LOCATION} ${BACKUP_BUCKET_URI}/${BACKUP_FILENAME}
+    aws s3 cp /backups/$1 ${BACKUP_BUCKET_URI}/$1
 }
 
 push_azure () {
@@ -87,7 +249,7 @@ push_azure () {
     BACKUP_CONTAINER_NAME=`cat /secrets/azure.container.name`
     az login --service-principal -u ${BACKUP_SERVICE_ID} -p ${BACKUP_CERT_PATH} --tenant ${BACKUP_TENANT_ID}
     az storage container create --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --name ${BACKUP_CONTAINER_NAME}
-    az storage blob upload --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --container-name ${BACKUP_CONTAINER_NAME} --file ${BACKUP_ENCRYPT_LOCATION} --name ${BACKUP_FILENAME}
+    az storage blob upload --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --container-name ${BACKUP_CONTAINER_NAME} --file /backups/$1 --name $1 --overwrite
 }
 
 push_gcp () {
@@ -98,7 +260,51 @@ push_gcp () {
     BACKUP_BUCKET_URI="gs://${BACKUP_BUCKET_NAME}"
     gcloud auth activate-service-account --key-file=${BACKUP_BUCKET_KEY}
     gsutil mb -b on -p ${BACKUP_PROJECT_ID} ${BACKUP_BUCKET_URI} || true
-    gsutil cp ${BACKUP_ENCRYPT_LOCATION} ${BACKUP_BUCKET_URI}/${BACKUP_FILENAME}
+    gsutil cp /backups/$1 ${BACKUP_BUCKET_URI}/$1
+}
+
+delete_file () {
+    case  ${BACKUP_LOCATION} in
+        "aws")
+            delete_aws $1
+            ;;
+        "azure")
+            delete_azure $1
+            ;;
+        "gcp")
+            delete_gcp $1
+            ;;
+        *)
+        # local and unsupported backends
+    esac
+    rm -f /backups/$1
+}
+
+delete_aws () {
+    echo "deleting argo-cd backup from aws"
+    BACKUP_BUCKET_NAME=`cat /secrets/aws.bucket.name`
+    BACKUP_BUCKET_URI="s3://${BACKUP_BUCKET_NAME}"
+    aws s3 rm ${BACKUP_BUCKET_URI}/$1
+}
+
+delete_azure () {
+    echo "deleting argo-cd backup from azure"
+    BACKUP_STORAGE_ACCOUNT=`cat /secrets/azure.storage.account`
+    BACKUP_SERVICE_ID=`cat /secrets/azure.service.id`
+    BACKUP_CERT_PATH="/secrets/azure.service.cert"
+    BACKUP_TENANT_ID=`cat /secrets/azure.tenant.id`
+    BACKUP_CONTAINER_NAME=`cat /secrets/azure.container.name`
+    az login --service-principal -u ${BACKUP_SERVICE_ID} -p ${BACKUP_CERT_PATH} --tenant ${BACKUP_TENANT_ID}
+    az storage blob delete --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --container-name ${BACKUP_CONTAINER_NAME} --name $1
+}
+
+delete_gcp () {
+    echo "deleting argo-cd backup from gcp"
+    BACKUP_BUCKET_KEY="/secrets/gcp.key.file"
+    BACKUP_BUCKET_NAME=`cat /secrets/gcp.bucket.name`
+    BACKUP_BUCKET_URI="gs://${BACKUP_BUCKET_NAME}"
+    gcloud auth activate-service-account --key-file=${BACKUP_BUCKET_KEY}
+    gsutil rm ${BACKUP_BUCKET_URI}/$1
 }
 
 import_argocd () {
@@ -106,22 +312,41 @@ import_argocd () {
     pull_backup
     decrypt_backup
     load_backup
+    import_increments
     echo "argo-cd import complete"
 }
 
+# import_increments applies the incremental backups listed in the chain, if any, in the order they were exported.
+import_increments () {
+    if ! pull_file ${BACKUP_CHAIN_FILENAME}; then
+        return 0
+    fi
+    for increment in `cat /backups/${BACKUP_CHAIN_FILENAME}`; do
+        echo "loading argo-cd incremental backup ${increment}"
+        pull_file ${increment}
+        decrypt_file ${increment} /tmp/${increment}
+        argocd admin import - < /tmp/${increment}
+    done
+}
+
 pull_backup () {
+    pull_file ${BACKUP_FILENAME}
+}
+
+pull_file () {
     case  ${BACKUP_LOCATION} in
         "aws")
-            pull_aws
+            pull_aws $1
             ;;
         "azure")
-            pull_azure
+            pull_azure $1
             ;;
         "gcp")
-            pull_gcp
+            pull_gcp $1
             ;;
         *)
         # local and unsupported backends
+            [[ -f /backups/$1 ]]
     esac
 }
 
@@ -129,7 +354,7 @@ pull_aws () {
     echo "pulling argo-cd backup from aws"
     BACKUP_BUCKET_NAME=`cat /secrets/aws.bucket.name`
     BACKUP_BUCKET_URI="s3://${BACKUP_BUCKET_NAME}"
-    aws s3 cp ${BACKUP_BUCKET_URI}/${BACKUP_FILENAME} ${BACKUP_ENCRYPT_LOCATION}
+    aws s3 cp ${BACKUP_BUCKET_URI}/$1 /backups/$1
 }
 
 pull_azure () {
@@ -140,7 +365,7 @@ pull_azure () {
     BACKUP_TENANT_ID=`cat /secrets/azure.tenant.id`
     BACKUP_CONTAINER_NAME=`cat /secrets/azure.container.name`
     az login --service-principal -u ${BACKUP_SERVICE_ID} -p ${BACKUP_CERT_PATH} --tenant ${BACKUP_TENANT_ID}
-    az storage blob download --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --container-name ${BACKUP_CONTAINER_NAME} --file ${BACKUP_ENCRYPT_LOCATION} --name ${BACKUP_FILENAME}
+    az storage blob download --auth-mode login --account-name ${BACKUP_STORAGE_ACCOUNT} --container-name ${BACKUP_CONTAINER_NAME} --file /backups/$1 --name $1
 }
 
 pull_gcp () {
@@ -150,7 +375,7 @@ pull_gcp () {
     BACKUP_BUCKET_NAME=`cat /secrets/gcp.bucket.name`
     BACKUP_BUCKET_URI="gs://${BACKUP_BUCKET_NAME}"
     gcloud auth activate-service-account --key-file=${BACKUP_BUCKET_KEY}
-    gsutil cp ${BACKUP_BUCKET_URI}/${BACKUP_FILENAME} ${BACKUP_ENCRYPT_LOCATION}
+    gsutil cp ${BACKUP_BUCKET_URI}/$1 /backups/$1
 }
 
 decrypt_backup () {
@@ -158,6 +383,10 @@ decrypt_backup () {
     openssl enc -aes-256-cbc -d -pbkdf2 -pass file:${BACKUP_KEY_LOCATION} -in ${BACKUP_ENCRYPT_LOCATION} -out ${BACKUP_EXPORT_LOCATION}
 }
 
+decrypt_file () {
+    openssl enc -aes-256-cbc -d -pbkdf2 -pass file:${BACKUP_KEY_LOCATION} -in /backups/$1 -out $2
+}
+
 load_backup () {
     echo "loading argo-cd backup"
     argocd admin import - < ${BACKUP_EXPORT_LOCATION}
@@ -0,0 +1,85 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command convert converts the v1alpha1 ArgoCD resources of a YAML manifest to v1beta1, migrating the deprecated
+// fields the same way the conversion webhook does.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func main() {
+	var input, output string
+	var failOnWarnings bool
+	flag.StringVar(&input, "f", "-", "The manifest to convert, - for the standard input.")
+	flag.StringVar(&output, "o", "-", "The file to write the converted manifest to, - for the standard output.")
+	flag.BoolVar(&failOnWarnings, "fail-on-warnings", false, "Exit with a non-zero status when deprecated fields were migrated.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-f manifest.yaml] [-o converted.yaml]\n\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "Converts the v1alpha1 ArgoCD resources of a manifest to v1beta1.")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// The conversion logs are only relevant to the conversion webhook
+	ctrl.SetLogger(logr.Discard())
+
+	manifest, err := readManifest(input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read %s: %v\n", input, err)
+		os.Exit(1)
+	}
+
+	converted, warnings, err := v1alpha1.ConvertManifest(manifest)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to convert %s: %v\n", input, err)
+		os.Exit(1)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+
+	if output == "-" {
+		_, err = os.Stdout.Write(converted)
+	} else {
+		err = os.WriteFile(output, converted, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", output, err)
+		os.Exit(1)
+	}
+
+	if failOnWarnings && len(warnings) > 0 {
+		os.Exit(2)
+	}
+}
+
+// readManifest will return the content of the given file, or of the standard input for -.
+func readManifest(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
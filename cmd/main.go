@@ -20,9 +20,11 @@ import (
 	"crypto/tls"
 	"flag"
 	"fmt"
+	"math"
 	"os"
 	goruntime "runtime"
 	"strings"
+	"time"
 
 	"github.com/argoproj/argo-cd/v2/util/env"
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
@@ -31,13 +33,17 @@ import (
 	oauthv1 "github.com/openshift/api/oauth/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	templatev1 "github.com/openshift/api/template/v1"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argocd"
 	"github.com/argoproj-labs/argocd-operator/controllers/argocdexport"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocdfleet"
 
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
@@ -90,14 +96,69 @@ func main() {
 	var secureMetrics = false
 	var enableHTTP2 = false
 
+	var argoCDMaxConcurrentReconciles int
+	var argoCDExportMaxConcurrentReconciles int
+	var notificationsConfigurationMaxConcurrentReconciles int
+	var rateLimiterBaseDelay time.Duration
+	var rateLimiterMaxDelay time.Duration
+	var rateLimiterQPS float64
+	var rateLimiterBurst int
+	var clusterResourceGCInterval time.Duration
+	var leaderElectionLeaseDuration time.Duration
+	var leaderElectionRenewDeadline time.Duration
+	var leaderElectionRetryPeriod time.Duration
+	var leaderElectionNamespace string
+	var logFormat string
+	var componentLogLevels string
+
 	flag.StringVar(&metricsAddr, "metrics-bind-address", fmt.Sprintf(":%d", common.OperatorMetricsPort), "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.StringVar(&labelSelectorFlag, "label-selector", env.StringFromEnv(common.ArgoCDLabelSelectorKey, common.ArgoCDDefaultLabelSelector), "The label selector is used to map to a subset of ArgoCD instances to reconcile")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&leaderElectionLeaseDuration, "leader-elect-lease-duration",
+		env.ParseDurationFromEnv(common.LeaderElectionLeaseDurationEnvName, common.DefaultLeaderElectionLeaseDuration, time.Second, math.MaxInt64),
+		"The duration the non-leader replicas wait before acquiring a leadership that has not been renewed.")
+	flag.DurationVar(&leaderElectionRenewDeadline, "leader-elect-renew-deadline",
+		env.ParseDurationFromEnv(common.LeaderElectionRenewDeadlineEnvName, common.DefaultLeaderElectionRenewDeadline, time.Second, math.MaxInt64),
+		"The duration the leader retries renewing its leadership before giving it up, shorter than the lease duration.")
+	flag.DurationVar(&leaderElectionRetryPeriod, "leader-elect-retry-period",
+		env.ParseDurationFromEnv(common.LeaderElectionRetryPeriodEnvName, common.DefaultLeaderElectionRetryPeriod, time.Second, math.MaxInt64),
+		"The duration the replicas wait between two attempts to acquire or renew the leadership.")
+	flag.StringVar(&leaderElectionNamespace, "leader-elect-namespace", env.StringFromEnv(common.LeaderElectionNamespaceEnvName, ""),
+		"The namespace of the leader election Lease, defaults to the namespace the operator runs in.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", enableHTTP2, "If HTTP/2 should be enabled for the metrics and webhook servers.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", secureMetrics, "If the metrics endpoint should be served securely.")
+	flag.IntVar(&argoCDMaxConcurrentReconciles, "argocd-max-concurrent-reconciles",
+		env.ParseNumFromEnv(common.ArgoCDMaxConcurrentReconcilesEnvName, common.DefaultMaxConcurrentReconciles, 1, math.MaxInt32),
+		"The number of ArgoCD instances reconciled concurrently.")
+	flag.IntVar(&argoCDExportMaxConcurrentReconciles, "argocdexport-max-concurrent-reconciles",
+		env.ParseNumFromEnv(common.ArgoCDExportMaxConcurrentReconcilesEnvName, common.DefaultMaxConcurrentReconciles, 1, math.MaxInt32),
+		"The number of ArgoCDExports reconciled concurrently.")
+	flag.IntVar(&notificationsConfigurationMaxConcurrentReconciles, "notificationsconfiguration-max-concurrent-reconciles",
+		env.ParseNumFromEnv(common.NotificationsConfigurationMaxConcurrentReconcilesEnvName, common.DefaultMaxConcurrentReconciles, 1, math.MaxInt32),
+		"The number of NotificationsConfigurations reconciled concurrently.")
+	flag.DurationVar(&rateLimiterBaseDelay, "rate-limiter-base-delay",
+		env.ParseDurationFromEnv(common.RateLimiterBaseDelayEnvName, common.DefaultRateLimiterBaseDelay, 0, math.MaxInt64),
+		"The initial requeue delay of a failing reconcile request, doubled on each failure.")
+	flag.DurationVar(&rateLimiterMaxDelay, "rate-limiter-max-delay",
+		env.ParseDurationFromEnv(common.RateLimiterMaxDelayEnvName, common.DefaultRateLimiterMaxDelay, 0, math.MaxInt64),
+		"The maximum requeue delay of a failing reconcile request.")
+	flag.Float64Var(&rateLimiterQPS, "rate-limiter-qps",
+		env.ParseFloat64FromEnv(common.RateLimiterQPSEnvName, common.DefaultRateLimiterQPS, 0, math.MaxFloat64),
+		"The overall rate of reconcile requests queued per second by each controller.")
+	flag.IntVar(&rateLimiterBurst, "rate-limiter-burst",
+		env.ParseNumFromEnv(common.RateLimiterBurstEnvName, common.DefaultRateLimiterBurst, 0, math.MaxInt32),
+		"The burst of reconcile requests queued over the overall rate by each controller.")
+	flag.DurationVar(&clusterResourceGCInterval, "cluster-resource-gc-interval",
+		env.ParseDurationFromEnv(common.ClusterResourceGCIntervalEnvName, common.DefaultClusterResourceGCInterval, 0, math.MaxInt64),
+		"The interval between two deletions of the ClusterRoles and ClusterRoleBindings left behind by deleted ArgoCD instances, 0 to disable.")
+
+	flag.StringVar(&logFormat, "log-format", env.StringFromEnv(common.LogFormatEnvName, "text"),
+		"The format of the logs, text or json.")
+	flag.StringVar(&componentLogLevels, "component-log-levels", env.StringFromEnv(common.ComponentLogLevelsEnvName, ""),
+		"The log levels of the applicationset, redis and reposerver reconcilers, e.g. redis=debug,reposerver=error. Defaults to the log level of the operator.")
 
 	//Configure log level
 	logLevelStr := strings.ToLower(os.Getenv("LOG_LEVEL"))
@@ -124,6 +185,17 @@ func main() {
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	// The encoder is set explicitly, as the default one depends on the development mode set with --zap-devel
+	switch logFormat {
+	case "text":
+		zap.ConsoleEncoder()(&opts)
+	case "json":
+		zap.JSONEncoder()(&opts)
+	default:
+		fmt.Fprintf(os.Stderr, "invalid log format %q, expected text or json\n", logFormat)
+		os.Exit(1)
+	}
+
 	disableHTTP2 := func(c *tls.Config) {
 		if enableHTTP2 {
 			return
@@ -144,6 +216,16 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	// The component reconcilers log through a logger enabling all the levels, their own level being applied on top
+	levels, err := argocd.ParseComponentLogLevels(componentLogLevels)
+	if err != nil {
+		setupLog.Error(err, "invalid component log levels")
+		os.Exit(1)
+	}
+	componentOpts := opts
+	componentOpts.Level = zapcore.DebugLevel
+	argocd.SetComponentLogging(zap.New(zap.UseFlagOptions(&componentOpts)), opts.Level, levels)
+
 	printVersion()
 
 	// Check the label selector format eg. "foo=bar"
@@ -164,6 +246,12 @@ func main() {
 	}
 	setupLog.Info(fmt.Sprintf("Watching namespace \"%s\"", namespace))
 
+	if enableLeaderElection && leaderElectionRenewDeadline >= leaderElectionLeaseDuration {
+		setupLog.Error(fmt.Errorf("renew deadline %s must be shorter than lease duration %s", leaderElectionRenewDeadline, leaderElectionLeaseDuration),
+			"invalid leader election configuration")
+		os.Exit(1)
+	}
+
 	// Set default manager options
 	options := manager.Options{
 		Metrics:                metricsServerOptions,
@@ -172,6 +260,13 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "b674928d.argoproj.io",
+		// The leader gives up the Lease when it stops, e.g. during an upgrade, so that another replica takes over
+		// right away instead of waiting for the Lease to expire. The process exits as soon as the manager stops.
+		LeaderElectionReleaseOnCancel: true,
+		LeaderElectionNamespace:       leaderElectionNamespace,
+		LeaseDuration:                 &leaderElectionLeaseDuration,
+		RenewDeadline:                 &leaderElectionRenewDeadline,
+		RetryPeriod:                   &leaderElectionRetryPeriod,
 	}
 
 	if watchedNsCache := getDefaultWatchedNamespacesCacheOptions(); watchedNsCache != nil {
@@ -188,6 +283,17 @@ func main() {
 
 	setupLog.Info("Registering Components.")
 
+	// Each controller gets its own rate limiter, the failures are tracked per request.
+	controllerOptions := func(maxConcurrentReconciles int) controller.Options {
+		return controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter: workqueue.NewMaxOfRateLimiter(
+				workqueue.NewItemExponentialFailureRateLimiter(rateLimiterBaseDelay, rateLimiterMaxDelay),
+				&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(rateLimiterQPS), rateLimiterBurst)},
+			),
+		}
+	}
+
 	// Setup Scheme for all resources
 	if err := v1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
 		setupLog.Error(err, "")
@@ -243,27 +349,46 @@ func main() {
 	}
 
 	if err = (&argocd.ReconcileArgoCD{
-		Client:        mgr.GetClient(),
-		Scheme:        mgr.GetScheme(),
-		LabelSelector: labelSelectorFlag,
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		LabelSelector:     labelSelectorFlag,
+		ControllerOptions: controllerOptions(argoCDMaxConcurrentReconciles),
+		Recorder:          mgr.GetEventRecorderFor("argocd-operator"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ArgoCD")
 		os.Exit(1)
 	}
 	if err = (&argocdexport.ReconcileArgoCDExport{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		ControllerOptions: controllerOptions(argoCDExportMaxConcurrentReconciles),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ArgoCDExport")
 		os.Exit(1)
 	}
 	if err = (&notificationsConfig.NotificationsConfigurationReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		ControllerOptions: controllerOptions(notificationsConfigurationMaxConcurrentReconciles),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NotificationsConfiguration")
 		os.Exit(1)
 	}
+	if err = (&argocdfleet.ArgoCDFleetReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		ControllerOptions: controllerOptions(common.DefaultMaxConcurrentReconciles),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ArgoCDFleet")
+		os.Exit(1)
+	}
+	if err = (&argocd.ClusterResourceGarbageCollector{
+		Client:   mgr.GetClient(),
+		Interval: clusterResourceGCInterval,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create the cluster resource garbage collector")
+		os.Exit(1)
+	}
 
 	// Start webhook only if ENABLE_CONVERSION_WEBHOOK is set
 	if strings.EqualFold(os.Getenv("ENABLE_CONVERSION_WEBHOOK"), "true") {
@@ -272,6 +397,22 @@ func main() {
 			os.Exit(1)
 		}
 	}
+
+	// Start the defaulting webhook only if ENABLE_DEFAULTING_WEBHOOK is set
+	if strings.EqualFold(os.Getenv("ENABLE_DEFAULTING_WEBHOOK"), "true") {
+		if err = argocd.SetupDefaultingWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create defaulting webhook", "webhook", "ArgoCD")
+			os.Exit(1)
+		}
+	}
+
+	// Start the validating webhook only if ENABLE_VALIDATING_WEBHOOK is set
+	if strings.EqualFold(os.Getenv("ENABLE_VALIDATING_WEBHOOK"), "true") {
+		if err = argocd.SetupValidatingWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create validating webhook", "webhook", "ArgoCD")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
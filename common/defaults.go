@@ -14,6 +14,8 @@
 
 package common
 
+import "time"
+
 const (
 	// ArgoCDApplicationControllerComponent is the name of the application controller control plane component
 	ArgoCDApplicationControllerComponent = "argocd-application-controller"
@@ -27,6 +29,10 @@ const (
 	// ArgoCDApplicationControllerDefaultShardReplicas is the default number of replicas that the ArgoCD Application Controller Should Use
 	ArgocdApplicationControllerDefaultReplicas = 1
 
+	// ArgoCDDefaultControllerShardingAlgorithm is the algorithm distributing the clusters across the application
+	// controller shards when not specified, the default of Argo CD.
+	ArgoCDDefaultControllerShardingAlgorithm = "legacy"
+
 	// ArgoCDDefaultLogLevel is the default log level to be used by all ArgoCD components.
 	ArgoCDDefaultLogLevel = "info"
 
@@ -36,6 +42,9 @@ const (
 	// ArgoCDServerComponent is the name of the Dex server control plane component
 	ArgoCDServerComponent = "argocd-server"
 
+	// ArgoCDRepoServerComponent is the name of the Repo Server control plane component
+	ArgoCDRepoServerComponent = "argocd-repo-server"
+
 	// ArgoCDRedisComponent is the name of the Redis control plane component
 	ArgoCDRedisComponent = "argocd-redis"
 
@@ -48,6 +57,9 @@ const (
 	// ArgoCDNotificationsControllerComponent is the name of the Notifications controller control plane component
 	ArgoCDNotificationsControllerComponent = "argocd-notifications-controller"
 
+	// ArgoCDImageUpdaterComponent is the name of the Image Updater control plane component
+	ArgoCDImageUpdaterComponent = "argocd-image-updater"
+
 	// ArgoCDApplicationSetControllerComponent is the name of the ApplictionSet controller control plane component
 	ArgoCDApplicationSetControllerComponent = "argocd-applicationset-controller"
 
@@ -63,6 +75,13 @@ const (
 	// ArgoCDDefaultAdminPasswordNumSymbols is the number of symbols to use for the generated default admin password.
 	ArgoCDDefaultAdminPasswordNumSymbols = 0
 
+	// ArgoCDDefaultAdoptionBatchSize is the number of existing resources adopted by each reconciliation of an ArgoCD,
+	// so that a large installation is taken over gradually.
+	ArgoCDDefaultAdoptionBatchSize = 10
+
+	// ArgoCDDefaultAdoptionInterval is the interval between two batches of the adoption of the existing resources.
+	ArgoCDDefaultAdoptionInterval = 10 * time.Second
+
 	// ArgoCDDefaultApplicationInstanceLabelKey is the default app name as a tracking label.
 	ArgoCDDefaultApplicationInstanceLabelKey = "app.kubernetes.io/instance"
 
@@ -115,6 +134,13 @@ const (
 	// ArgoCDDefaultDexHTTPPort is the default HTTP listen port for Dex.
 	ArgoCDDefaultDexHTTPPort = 5556
 
+	// ArgoCDDexRootCAPath is the path of the directory holding the root CA trusted by Dex.
+	ArgoCDDexRootCAPath = "/app/config/dex/tls"
+
+	// ArgoCDNotificationsRootCAPath is the path of the directory holding the root CA trusted by the Notifications
+	// Controller.
+	ArgoCDNotificationsRootCAPath = "/app/config/notifications/tls"
+
 	// ArgoCDDefaultDexMetricsPort is the default Metrics listen port for Dex.
 	ArgoCDDefaultDexMetricsPort = 5558
 
@@ -130,6 +156,19 @@ const (
 	// ArgoCDDefaultExportJobVersion is the export job container image tag to use when not specified.
 	ArgoCDDefaultExportJobVersion = "sha256:463185c62f35e73e287a859c42926fea7c23cecd2db7f94675f4d46629d4b4d8" // 0.11.0
 
+	// ArgoCDDefaultExportMaxBackups is the maximum number of archives kept by an export, counting the full export and
+	// its incremental exports, when its retention does not set one.
+	ArgoCDDefaultExportMaxBackups = 30
+
+	// ArgoCDDefaultImageUpdaterImage is the Image Updater container image to use when not specified.
+	ArgoCDDefaultImageUpdaterImage = "quay.io/argoprojlabs/argocd-image-updater"
+
+	// ArgoCDDefaultImageUpdaterVersion is the Image Updater container image tag to use when not specified.
+	ArgoCDDefaultImageUpdaterVersion = "v0.12.2"
+
+	// ArgoCDDefaultImageUpdaterInterval is the default interval between two image update checks.
+	ArgoCDDefaultImageUpdaterInterval = "2m"
+
 	// ArgoCDDefaultExportLocalCapicity is the default capacity to use for local export.
 	ArgoCDDefaultExportLocalCapicity = "2Gi"
 
@@ -157,6 +196,9 @@ const (
 	// ArgoCDDefaultLabelSelector is the default Label Selector which will reconcile all ArgoCD instances.
 	ArgoCDDefaultLabelSelector = ""
 
+	// ArgoCDDefaultLogSidecarImage is the container image of the log sidecar when not specified.
+	ArgoCDDefaultLogSidecarImage = "fluent/fluent-bit:2.2.2"
+
 	// ArgoCDKeycloakVersion is the default Keycloak version used for the non-openshift platform when not specified.
 	// Version: 15.0.2
 	ArgoCDKeycloakVersion = "sha256:64fb81886fde61dee55091e6033481fa5ccdac62ae30a4fd29b54eb5e97df6a9"
@@ -168,6 +210,15 @@ const (
 	// Version: 7.6-32
 	ArgoCDKeycloakVersionForOpenShift = "sha256:ec9f60018694dcc5d431ba47d5536b761b71cb3f66684978fe6bb74c157679ac"
 
+	// ArgoCDKeycloakPostgresImage is the default PostgreSQL image of the database managed for Keycloak.
+	ArgoCDKeycloakPostgresImage = "postgres"
+
+	// ArgoCDKeycloakPostgresVersion is the default PostgreSQL version of the database managed for Keycloak.
+	ArgoCDKeycloakPostgresVersion = "15-alpine"
+
+	// ArgoCDKeycloakPostgresCapacity is the default capacity of the volume of the database managed for Keycloak.
+	ArgoCDKeycloakPostgresCapacity = "1Gi"
+
 	// ArgoCDDefaultOIDCConfig is the default OIDC configuration.
 	ArgoCDDefaultOIDCConfig = ""
 
@@ -183,6 +234,10 @@ const (
 	// ArgoCDDefaultRBACScopes is the default Argo CD RBAC scopes.
 	ArgoCDDefaultRBACScopes = "[groups]"
 
+	// ArgoCDRBACPolicySizeWarningThreshold is the size, in bytes, of the RBAC policy over which a warning is reported,
+	// as the RBAC ConfigMap gets close to the 1MiB size limit of the Kubernetes objects.
+	ArgoCDRBACPolicySizeWarningThreshold = 800 * 1024
+
 	// ArgoCDDefaultRedisConfigPath is the default Redis configuration directory when not specified.
 	ArgoCDDefaultRedisConfigPath = "/var/lib/redis"
 
@@ -195,15 +250,52 @@ const (
 	// ArgoCDDefaultRedisHAProxyVersion is the default Redis HAProxy image tag to use when not specified.
 	ArgoCDDefaultRedisHAProxyVersion = "sha256:7392fbbbb53e9e063ca94891da6656e6062f9d021c0e514888a91535b9f73231" // 2.0.25-alpine
 
+	// ArgoCDDefaultRedisExporterImage is the Redis exporter container image to use when not specified.
+	ArgoCDDefaultRedisExporterImage = "quay.io/oliver006/redis_exporter"
+
+	// ArgoCDDefaultRedisExporterPort is the default listen port for the Redis exporter metrics.
+	ArgoCDDefaultRedisExporterPort = 9121
+
+	// ArgoCDDefaultRedisExporterVersion is the Redis exporter image tag to use when not specified.
+	ArgoCDDefaultRedisExporterVersion = "v1.58.0"
+
 	// ArgoCDDefaultRedisImage is the Redis container image to use when not specified.
 	ArgoCDDefaultRedisImage = "redis"
 
+	// ArgoCDDefaultRedisHAProxyMetricsPort is the default listen port for the Redis HAProxy metrics.
+	ArgoCDDefaultRedisHAProxyMetricsPort = 9101
+
+	// ArgoCDDefaultRedisHAProxyTimeoutConnect is the default timeout of the connections of the Redis HAProxy.
+	ArgoCDDefaultRedisHAProxyTimeoutConnect = "4s"
+
+	// ArgoCDDefaultRedisHAProxyTimeoutServer is the default server inactivity timeout of the Redis HAProxy.
+	ArgoCDDefaultRedisHAProxyTimeoutServer = "6m"
+
+	// ArgoCDDefaultRedisHAProxyTimeoutClient is the default client inactivity timeout of the Redis HAProxy.
+	ArgoCDDefaultRedisHAProxyTimeoutClient = "6m"
+
+	// ArgoCDDefaultRedisHAProxyTimeoutCheck is the default health check timeout of the Redis HAProxy.
+	ArgoCDDefaultRedisHAProxyTimeoutCheck = "2s"
+
+	// ArgoCDDefaultRedisHAMaxMemoryPolicy is the default eviction policy of the Redis HA servers.
+	ArgoCDDefaultRedisHAMaxMemoryPolicy = "volatile-lru"
+
+	// ArgoCDDefaultRedisMaxMemory is the default memory limit of the Redis data set, no limit.
+	ArgoCDDefaultRedisMaxMemory = "0"
+
 	// ArgoCDDefaultRedisPort is the default listen port for Redis.
 	ArgoCDDefaultRedisPort = 6379
 
 	// ArgoCDDefaultRedisSentinelPort is the default listen port for Redis sentinel.
 	ArgoCDDefaultRedisSentinelPort = 26379
 
+	// ArgoCDServerIngressTLSSecretSuffix is the suffix of the secret holding the certificate of the Argo CD Server
+	// Ingress issued by cert-manager.
+	ArgoCDServerIngressTLSSecretSuffix = "server-ingress-tls"
+
+	// ArgoCDCertManagerClusterIssuerKind is the kind of the cert-manager cluster-scoped issuers.
+	ArgoCDCertManagerClusterIssuerKind = "ClusterIssuer"
+
 	//ArgoCDDefaultRedisSuffix is the default suffix to use for Redis resources.
 	ArgoCDDefaultRedisSuffix = "redis"
 
@@ -213,6 +305,17 @@ const (
 	// ArgoCDDefaultRedisVersionHA is the Redis container image tag to use when not specified in HA mode.
 	ArgoCDDefaultRedisVersionHA = "sha256:8061ca607db2a0c80010aeb5fc9bed0253448bc68711eaa14253a392f6c48280" // 6.2.4-alpine
 
+	// ArgoCDDefaultControllerPersistenceCapacity is the default capacity of the Argo CD application controller
+	// persistent volume.
+	ArgoCDDefaultControllerPersistenceCapacity = "1Gi"
+
+	// ArgoCDDefaultControllerPersistenceMountPath is the default path of the Argo CD application controller
+	// persistent volume.
+	ArgoCDDefaultControllerPersistenceMountPath = "/home/argocd/cache"
+
+	// ArgoCDDefaultRepoCacheCapacity is the default capacity of the persistent Argo CD repo server cache volume.
+	ArgoCDDefaultRepoCacheCapacity = "10Gi"
+
 	// ArgoCDDefaultRepoMetricsPort is the default listen port for the Argo CD repo server metrics.
 	ArgoCDDefaultRepoMetricsPort = 8084
 
@@ -231,6 +334,10 @@ const (
 	// ArgoCDDefaultResourceInclusions is the default resource inclusions.
 	ArgoCDDefaultResourceInclusions = ""
 
+	// ArgoCDDefaultResyncPeriodMinimum is the shortest resync period of an Argo CD instance, so that a tiny period does
+	// not reconcile the instance in a loop.
+	ArgoCDDefaultResyncPeriodMinimum = 30 * time.Second
+
 	// ArgoCDDefaultRSAKeySize is the default RSA key size when not specified.
 	ArgoCDDefaultRSAKeySize = 2048
 
@@ -243,6 +350,18 @@ const (
 	// ArgoCDDefaultControllerParellelismLimit is the default parallelism limit for application controller
 	ArgoCDDefaultControllerParallelismLimit = int32(10)
 
+	// ArgoCDDefaultServerAutoscaleInterval is the interval between two evaluations of the operator autoscaler of the
+	// Argo CD server.
+	ArgoCDDefaultServerAutoscaleInterval = time.Minute
+
+	// ArgoCDDefaultServerAutoscaleScaleDownDelay is the time the operator autoscaler of the Argo CD server waits after a
+	// scaling before removing a replica.
+	ArgoCDDefaultServerAutoscaleScaleDownDelay = 5 * time.Minute
+
+	// ArgoCDDefaultServerAutoscaleTargetRequestsPerReplica is the rate of gRPC requests per second each replica of the
+	// Argo CD server should serve with the operator autoscaler.
+	ArgoCDDefaultServerAutoscaleTargetRequestsPerReplica = int32(50)
+
 	// ArgoCDDefaultServerResourceLimitCPU is the default CPU limit when not specified for the Argo CD server contianer.
 	ArgoCDDefaultServerResourceLimitCPU = "1000m"
 
@@ -264,6 +383,13 @@ const (
 	// ArgoCDDefaultServerSessionKeyNumSymbols is the number of symbols to use for the generated default server signature key.
 	ArgoCDDefaultServerSessionKeyNumSymbols = 0
 
+	// ArgoCDDefaultSSOLivecheckInterval is the interval between two checks of the connectivity to the SSO provider.
+	ArgoCDDefaultSSOLivecheckInterval = 5 * time.Minute
+
+	// ArgoCDDefaultSSHKnownHostsURL is the location of the upstream SSH Known hosts used to refresh the default SSH Known hosts.
+	// It can be overridden with the ARGOCD_SSH_KNOWN_HOSTS_URL environment variable.
+	ArgoCDDefaultSSHKnownHostsURL = "https://raw.githubusercontent.com/argoproj/argo-cd/stable/manifests/base/config/argocd-ssh-known-hosts-cm.yaml"
+
 	// ArgoCDDefaultSSHKnownHosts is the default SSH Known hosts data.
 	ArgoCDDefaultSSHKnownHosts = `[ssh.github.com]:443 ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBEmKSENjQEezOmxkZMy7opKgwFB9nkt5YRrYMjNuG5N87uRgg6CLrbo5wAdT/y6v0mKV0U2w0WZ2YB/++Tpockg=
 [ssh.github.com]:443 ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl
@@ -294,6 +420,38 @@ vs-ssh.visualstudio.com ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQC7Hr1oTWqNqOlzGJOf
 
 	// NotificationsControllerMetricsPort is the port that is used to expose notifications controller metrics.
 	NotificationsControllerMetricsPort = 9001
+
+	// DefaultMaxConcurrentReconciles is the default number of reconcile requests processed concurrently by each
+	// controller of the operator.
+	DefaultMaxConcurrentReconciles = 1
+
+	// DefaultRateLimiterBaseDelay is the default initial requeue delay of a failing reconcile request.
+	DefaultRateLimiterBaseDelay = 5 * time.Millisecond
+
+	// DefaultRateLimiterMaxDelay is the default maximum requeue delay of a failing reconcile request.
+	DefaultRateLimiterMaxDelay = 1000 * time.Second
+
+	// DefaultRateLimiterQPS is the default overall rate of reconcile requests queued per second.
+	DefaultRateLimiterQPS = 10
+
+	// DefaultRateLimiterBurst is the default burst of reconcile requests queued over the overall rate.
+	DefaultRateLimiterBurst = 100
+
+	// DefaultClusterResourceGCInterval is the default interval between two collections of the cluster-scoped resources
+	// left behind by deleted ArgoCD instances.
+	DefaultClusterResourceGCInterval = 10 * time.Minute
+
+	// DefaultLeaderElectionLeaseDuration is the default duration the non-leader operator replicas wait before
+	// acquiring a leadership that has not been renewed.
+	DefaultLeaderElectionLeaseDuration = 15 * time.Second
+
+	// DefaultLeaderElectionRenewDeadline is the default duration the leader operator replica retries renewing its
+	// leadership before giving it up.
+	DefaultLeaderElectionRenewDeadline = 10 * time.Second
+
+	// DefaultLeaderElectionRetryPeriod is the default duration the operator replicas wait between two attempts to
+	// acquire or renew the leadership.
+	DefaultLeaderElectionRetryPeriod = 2 * time.Second
 )
 
 // DefaultLabels returns the default set of labels for controllers.
@@ -76,6 +76,21 @@ const (
 	// ArgoCDKeyIngressSSLPassthrough is the ssl passthrough key for labels.
 	ArgoCDKeyIngressSSLPassthrough = "nginx.ingress.kubernetes.io/ssl-passthrough"
 
+	// ArgoCDKeyIngressRewriteTarget is the rewrite-target key for labels.
+	ArgoCDKeyIngressRewriteTarget = "nginx.ingress.kubernetes.io/rewrite-target"
+
+	// ArgoCDKeyIngressUseRegex is the use-regex key for labels.
+	ArgoCDKeyIngressUseRegex = "nginx.ingress.kubernetes.io/use-regex"
+
+	// ArgoCDKeyIngressCertManagerIssuer is the cert-manager issuer key for annotations.
+	ArgoCDKeyIngressCertManagerIssuer = "cert-manager.io/issuer"
+
+	// ArgoCDKeyIngressCertManagerClusterIssuer is the cert-manager cluster issuer key for annotations.
+	ArgoCDKeyIngressCertManagerClusterIssuer = "cert-manager.io/cluster-issuer"
+
+	// ArgoCDKeyIngressCertManagerHTTP01IngressClass is the ingress class of the cert-manager HTTP-01 solver key for annotations.
+	ArgoCDKeyIngressCertManagerHTTP01IngressClass = "acme.cert-manager.io/http01-ingress-class"
+
 	// ArgoCDKeyKustomizeBuildOptions is the configuration key for the kustomize build options.
 	ArgoCDKeyKustomizeBuildOptions = "kustomize.buildOptions"
 
@@ -103,6 +118,10 @@ const (
 	// ArgoCDKeyRBACPolicyCSV is the configuration key for the Argo CD RBAC policy CSV.
 	ArgoCDKeyRBACPolicyCSV = "policy.csv"
 
+	// ArgoCDKeyRBACPolicyChunkCSVFormat is the format of the configuration keys for the Argo CD RBAC policy chunks,
+	// numbered from 1 in the order of the chunks.
+	ArgoCDKeyRBACPolicyChunkCSVFormat = "policy.%03d.csv"
+
 	// ArgoCDKeyRBACPolicyDefault is the configuration key for the Argo CD RBAC default policy.
 	ArgoCDKeyRBACPolicyDefault = "policy.default"
 
@@ -112,6 +131,9 @@ const (
 	// ArgoCDKeyRelease is the prometheus release key for labels.
 	ArgoCDKeyRelease = "release"
 
+	// ArgoCDKeyResourceCompareOptions is the configuration key for resource compare options.
+	ArgoCDKeyResourceCompareOptions = "resource.compareoptions"
+
 	// ArgoCDKeyResourceExclusions is the configuration key for resource exclusions.
 	ArgoCDKeyResourceExclusions = "resource.exclusions"
 
@@ -145,6 +167,9 @@ const (
 	// ArgoCDKeyBannerURL is the configuration key for a banner message URL.
 	ArgoCDKeyBannerURL = "ui.bannerurl"
 
+	// ArgoCDKeyUICSSURL is the configuration key for the URL of the custom styles of the UI.
+	ArgoCDKeyUICSSURL = "ui.cssurl"
+
 	// ArgoCDKeyTLSCACert is the key for TLS CA certificates.
 	ArgoCDKeyTLSCACert = "ca.crt"
 
@@ -164,14 +189,29 @@ const (
 	// ArgoCDKeyUsersAnonymousEnabled is the configuration key for anonymous user access.
 	ArgoCDKeyUsersAnonymousEnabled = "users.anonymous.enabled"
 
+	// ArgoCDKeyUsersSessionDuration is the configuration key for the lifetime of the user sessions.
+	ArgoCDKeyUsersSessionDuration = "users.session.duration"
+
+	// ArgoCDMaxConcurrentLoginRequestsEnvName is the environment variable used to limit the number of
+	// login requests processed concurrently by the Argo CD Server.
+	ArgoCDMaxConcurrentLoginRequestsEnvName = "ARGOCD_MAX_CONCURRENT_LOGIN_REQUESTS_COUNT"
+
 	// ArgoCDDexImageEnvName is the environment variable used to get the image
 	// to used for the Dex container.
 	ArgoCDDexImageEnvName = "ARGOCD_DEX_IMAGE"
 
+	// ArgoCDFIPSImagesEnvName is the environment variable used to get the FIPS-validated images of the components
+	// selected by the fips crypto policy, as a comma-separated list of <component>=<image>.
+	ArgoCDFIPSImagesEnvName = "ARGOCD_FIPS_IMAGES"
+
 	// ArgoCDImageEnvName is the environment variable used to get the image
 	// to used for the argocd container.
 	ArgoCDImageEnvName = "ARGOCD_IMAGE"
 
+	// ArgoCDImageUpdaterImageEnvName is the environment variable used to get the image
+	// to used for the Image Updater container.
+	ArgoCDImageUpdaterImageEnvName = "ARGOCD_IMAGE_UPDATER_IMAGE"
+
 	// ArgoCDKeycloakImageEnvName is the environment variable used to get the image
 	// to used for the Keycloak container.
 	ArgoCDKeycloakImageEnvName = "ARGOCD_KEYCLOAK_IMAGE"
@@ -197,6 +237,97 @@ const (
 	// ArgoCDSecretTypeLabel is needed for cluster secrets
 	ArgoCDSecretTypeLabel = "argocd.argoproj.io/secret-type"
 
+	// ArgoCDSecretTypeRepository is the secret type label value of repository secrets.
+	ArgoCDSecretTypeRepository = "repository"
+
+	// GrafanaDatasourceLabel is the label selecting the Secrets and ConfigMaps provisioned as datasources by the
+	// Grafana sidecar.
+	GrafanaDatasourceLabel = "grafana_datasource"
+
+	// ArgoCDBackupLabel is the label selecting the resources of an Argo CD instance for Velero backups.
+	ArgoCDBackupLabel = "argocd.argoproj.io/backup"
+
+	// ArgoCDBackupStorageLocationAnnotation is the annotation naming the Velero BackupStorageLocation the resources of
+	// an Argo CD instance are backed up to.
+	ArgoCDBackupStorageLocationAnnotation = "argocd.argoproj.io/backup-storage-location"
+
+	// ArgoCDAdditionalLabelsAnnotation lists the keys of the additional labels of the label policy set on a resource,
+	// so that the labels removed from the policy are removed from the resource.
+	ArgoCDAdditionalLabelsAnnotation = "argocd.argoproj.io/additional-labels"
+
+	// ArgoCDServiceAccountAnnotationsAnnotation lists the keys of the annotations of a component set on its
+	// ServiceAccount, so that the annotations removed from the component are removed from the ServiceAccount.
+	ArgoCDServiceAccountAnnotationsAnnotation = "argocd.argoproj.io/service-account-annotations"
+
+	// ArgoCDPodLabelsAnnotation lists the keys of the pod labels of a component set on its pod template, so that the
+	// labels removed from the component are removed from the pods.
+	ArgoCDPodLabelsAnnotation = "argocd.argoproj.io/pod-labels"
+
+	// ArgoCDPodAnnotationsAnnotation lists the keys of the pod annotations of a component set on its pod template, so
+	// that the annotations removed from the component are removed from the pods.
+	ArgoCDPodAnnotationsAnnotation = "argocd.argoproj.io/pod-annotations"
+
+	// ArgoCDDefaultedFieldsAnnotation records the fields of an ArgoCD resource that have been filled with their default
+	// value by the defaulting webhook, as a JSON object of their values by path.
+	ArgoCDDefaultedFieldsAnnotation = "argocd.argoproj.io/defaulted-fields"
+
+	// ArgoCDTemplateLabel marks the ArgoCD resources and ConfigMaps that instances may inherit their spec from.
+	ArgoCDTemplateLabel = "argocd.argoproj.io/template"
+
+	// ArgoCDKeyInheritFromSpec is the key of the ArgoCD spec in a ConfigMap instances inherit their spec from.
+	ArgoCDKeyInheritFromSpec = "spec"
+
+	// ArgoCDManagedKeysAnnotation lists the keys of the argocd-cm ConfigMap set by the operator on its last update.
+	ArgoCDManagedKeysAnnotation = "argocd.argoproj.io/managed-keys"
+
+	// ArgoCDServiceMonitorLabelsAnnotation lists the labels of a ServiceMonitor set from the ServiceMonitor labels of the
+	// ArgoCD by the operator on its last update, so that the labels removed from the ArgoCD are removed too.
+	ArgoCDServiceMonitorLabelsAnnotation = "argocd.argoproj.io/service-monitor-labels"
+
+	// ArgoCDServerServiceAnnotationsAnnotation lists the annotations of the Argo CD Server Service set from the ArgoCD
+	// by the operator on its last update, so that the annotations removed from the ArgoCD are removed too.
+	ArgoCDServerServiceAnnotationsAnnotation = "argocd.argoproj.io/server-service-annotations"
+
+	// ArgoCDServerServiceLabelsAnnotation lists the labels of the Argo CD Server Service set from the ArgoCD by the
+	// operator on its last update, so that the labels removed from the ArgoCD are removed too.
+	ArgoCDServerServiceLabelsAnnotation = "argocd.argoproj.io/server-service-labels"
+
+	// ArgoCDManagedSubscriptionsAnnotation marks the NotificationsConfiguration whose subscriptions key is set from the
+	// default subscriptions of the ArgoCD by the operator.
+	ArgoCDManagedSubscriptionsAnnotation = "argocd.argoproj.io/managed-subscriptions"
+
+	// ArgoCDUnmanagedKeysAnnotation lists the keys of the argocd-cm ConfigMap the operator must leave untouched when
+	// its configuration management policy is merge.
+	ArgoCDUnmanagedKeysAnnotation = "argocd.argoproj.io/unmanaged-keys"
+
+	// ArgoCDMigrateSSOAnnotation requests the migration of the SSO configuration of an ArgoCD to the given provider.
+	// Only the external provider is supported, migrating .spec.oidcConfig or the OIDC connector of Dex to .spec.sso.oidc.
+	ArgoCDMigrateSSOAnnotation = "argocd.argoproj.io/migrate-sso"
+
+	// ArgoCDDryRunAnnotation requests a dry run of the reconciliation of an ArgoCD when set to true. The changes the
+	// operator would make are recorded in a ConfigMap instead of being applied.
+	ArgoCDDryRunAnnotation = "argocd.argoproj.io/dry-run"
+
+	// ArgoCDKeyDryRunChanges is the key of the changes recorded by a dry run in the dry run ConfigMap.
+	ArgoCDKeyDryRunChanges = "changes"
+
+	// ArgoCDKeyDryRunError is the key of the error that interrupted a dry run in the dry run ConfigMap.
+	ArgoCDKeyDryRunError = "error"
+
+	// ArgoCDKeyDryRunPendingNamespaces is the key of the namespaces no longer managed by an ArgoCD in dry run in the dry
+	// run ConfigMap. Their RBACs and cluster secret entry are removed once the dry run ends.
+	ArgoCDKeyDryRunPendingNamespaces = "pendingNamespaces"
+
+	// ArgoCDLogLevelsAnnotation sets the log levels of the component reconcilers of an ArgoCD, overriding the levels of
+	// the operator, e.g. redis=debug,reposerver=error.
+	ArgoCDLogLevelsAnnotation = "argocd.argoproj.io/log-levels"
+
+	// ArgoCDKeyNotificationsSubscriptions is the key of the default subscriptions in the notifications ConfigMap.
+	ArgoCDKeyNotificationsSubscriptions = "subscriptions"
+
+	// ArgoCDKeyAdoptionResources is the key of the existing resources that would be adopted in the adoption ConfigMap.
+	ArgoCDKeyAdoptionResources = "resources"
+
 	// ArgoCDManagedByLabel is needed to identify namespace managed by an instance on ArgoCD
 	ArgoCDManagedByLabel = "argocd.argoproj.io/managed-by"
 
@@ -215,6 +346,65 @@ const (
 	// ArgoCDDexSecretKey is used to reference Dex secret from Argo CD secret into Argo CD configmap
 	ArgoCDDexSecretKey = "oidc.dex.clientSecret"
 
+	// ArgoCDExternalOIDCSecretKey is the key of the Argo CD secret holding the client secret of the external OIDC provider.
+	ArgoCDExternalOIDCSecretKey = "oidc.external.clientSecret"
+
 	// Label Selector is an env variable for ArgoCD instance reconcilliation.
 	ArgoCDLabelSelectorKey = "ARGOCD_LABEL_SELECTOR"
+
+	// ArgoCDMaxConcurrentReconcilesEnvName is an env variable for the number of ArgoCD instances reconciled concurrently.
+	ArgoCDMaxConcurrentReconcilesEnvName = "ARGOCD_MAX_CONCURRENT_RECONCILES"
+
+	// ArgoCDExportMaxConcurrentReconcilesEnvName is an env variable for the number of ArgoCDExports reconciled concurrently.
+	ArgoCDExportMaxConcurrentReconcilesEnvName = "ARGOCDEXPORT_MAX_CONCURRENT_RECONCILES"
+
+	// NotificationsConfigurationMaxConcurrentReconcilesEnvName is an env variable for the number of
+	// NotificationsConfigurations reconciled concurrently.
+	NotificationsConfigurationMaxConcurrentReconcilesEnvName = "NOTIFICATIONSCONFIGURATION_MAX_CONCURRENT_RECONCILES"
+
+	// RateLimiterBaseDelayEnvName is an env variable for the initial requeue delay of a failing reconcile request.
+	RateLimiterBaseDelayEnvName = "RATE_LIMITER_BASE_DELAY"
+
+	// RateLimiterMaxDelayEnvName is an env variable for the maximum requeue delay of a failing reconcile request.
+	RateLimiterMaxDelayEnvName = "RATE_LIMITER_MAX_DELAY"
+
+	// RateLimiterQPSEnvName is an env variable for the overall rate of reconcile requests queued per second.
+	RateLimiterQPSEnvName = "RATE_LIMITER_QPS"
+
+	// RateLimiterBurstEnvName is an env variable for the burst of reconcile requests queued over the overall rate.
+	RateLimiterBurstEnvName = "RATE_LIMITER_BURST"
+
+	// ClusterResourceGCIntervalEnvName is an env variable for the interval between two collections of the
+	// cluster-scoped resources left behind by deleted ArgoCD instances.
+	ClusterResourceGCIntervalEnvName = "CLUSTER_RESOURCE_GC_INTERVAL"
+
+	// LeaderElectionLeaseDurationEnvName is an env variable for the duration the non-leader operator replicas wait
+	// before acquiring a leadership that has not been renewed.
+	LeaderElectionLeaseDurationEnvName = "LEADER_ELECTION_LEASE_DURATION"
+
+	// LeaderElectionRenewDeadlineEnvName is an env variable for the duration the leader operator replica retries
+	// renewing its leadership before giving it up.
+	LeaderElectionRenewDeadlineEnvName = "LEADER_ELECTION_RENEW_DEADLINE"
+
+	// LeaderElectionRetryPeriodEnvName is an env variable for the duration the operator replicas wait between two
+	// attempts to acquire or renew the leadership.
+	LeaderElectionRetryPeriodEnvName = "LEADER_ELECTION_RETRY_PERIOD"
+
+	// LeaderElectionNamespaceEnvName is an env variable for the namespace of the Lease used for the leader election.
+	LeaderElectionNamespaceEnvName = "LEADER_ELECTION_NAMESPACE"
+
+	// LogFormatEnvName is an env variable for the format of the logs of the operator, text or json.
+	LogFormatEnvName = "LOG_FORMAT"
+
+	// ComponentLogLevelsEnvName is an env variable for the log levels of the component reconcilers of the operator,
+	// e.g. redis=debug,reposerver=error.
+	ComponentLogLevelsEnvName = "COMPONENT_LOG_LEVELS"
+
+	// ArgoCDDefaultLabelsEnvName is an env variable for the labels, as comma separated key=value pairs, added to the
+	// default labels of the resources of all the ArgoCD instances.
+	ArgoCDDefaultLabelsEnvName = "ARGOCD_DEFAULT_LABELS"
+
+	// ArgoCDManagedByLabelValueEnvName is an env variable for the value of the app.kubernetes.io/managed-by label of
+	// the resources of all the ArgoCD instances, instead of the name of the instance.
+	ArgoCDManagedByLabelValueEnvName = "ARGOCD_MANAGED_BY_LABEL_VALUE"
 )
@@ -32,6 +32,9 @@ const (
 	// ArgoCDDuration365Days is a duration representing 365 days.
 	ArgoCDDuration365Days = time.Hour * 24 * 365
 
+	// ArgoCDSelfSignedCertRenewBefore is the remaining validity at which operator generated certificates are rotated.
+	ArgoCDSelfSignedCertRenewBefore = time.Hour * 24 * 30
+
 	// ArgoCDExportName is the export name for labels.
 	ArgoCDExportName = "argocd.export"
 
@@ -83,6 +86,9 @@ const (
 	// ArgoCDServerTLSSecretName is the name of the TLS secret for the argocd-server
 	ArgoCDServerTLSSecretName = "argocd-server-tls"
 
+	// ArgoCDApplicationSetWebhookTLSSecretName is the name of the TLS secret for the applicationset webhook server
+	ArgoCDApplicationSetWebhookTLSSecretName = "argocd-applicationset-webhook-tls"
+
 	//ApplicationSetServiceNameSuffix is the suffix for Apllication Set Controller Service
 	ApplicationSetServiceNameSuffix = "applicationset-controller"
 
@@ -0,0 +1,176 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// accountTokenKey is the key of the API token in the Secret referenced by the tokensSecretRef of an account.
+	accountTokenKey = "token"
+
+	// accountTokenIssuer is the issuer of the tokens signed by the Argo CD API server.
+	accountTokenIssuer = "argocd"
+)
+
+// accountToken is an API token of a local account, as recorded by Argo CD in the accounts.<name>.tokens key of
+// argocd-secret. A token is only accepted by the API server while it is recorded there.
+type accountToken struct {
+	ID        string `json:"id"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+// hasAccountCapability returns true if the given account has the given capability.
+func hasAccountCapability(account argoproj.ArgoCDAccountSpec, capability argoproj.ArgoCDAccountCapability) bool {
+	for _, c := range account.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// getAccountsConfig will return the argocd-cm keys declaring the accounts of the given ArgoCD.
+func getAccountsConfig(cr *argoproj.ArgoCD) map[string]string {
+	config := make(map[string]string, len(cr.Spec.Accounts))
+	for _, account := range cr.Spec.Accounts {
+		capabilities := make([]string, 0, len(account.Capabilities))
+		for _, c := range account.Capabilities {
+			capabilities = append(capabilities, string(c))
+		}
+		config["accounts."+account.Name] = strings.Join(capabilities, ", ")
+		if account.Enabled != nil {
+			config[fmt.Sprintf("accounts.%s.enabled", account.Name)] = fmt.Sprint(*account.Enabled)
+		}
+	}
+	return config
+}
+
+// signAccountToken will return an API token of the given account for the given token, signed with the given server
+// signature key as done by the Argo CD API server: a JWT signed with HS256, whose subject is <account>:apiKey.
+func signAccountToken(account string, token accountToken, key []byte) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": token.IssuedAt,
+		"iss": accountTokenIssuer,
+		"jti": token.ID,
+		"nbf": token.IssuedAt,
+		"sub": fmt.Sprintf("%s:%s", account, argoproj.ArgoCDAccountCapabilityAPIKey),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// reconcileAccountTokens will ensure that the Secrets referenced by the tokensSecretRef of the accounts of the given
+// ArgoCD hold an API token of their account. A token is only generated when the Secret has none, and recorded in
+// argocd-secret so that the API server accepts it. Removing the token from the Secret generates a new one, the
+// previous one being revoked with the argocd CLI.
+func (r *ReconcileArgoCD) reconcileAccountTokens(cr *argoproj.ArgoCD) error {
+	argoSecret := argoutil.NewSecretWithName(cr, common.ArgoCDSecretName)
+	for _, account := range cr.Spec.Accounts {
+		if account.TokensSecretRef == nil {
+			continue
+		}
+		if !hasAccountCapability(account, argoproj.ArgoCDAccountCapabilityAPIKey) {
+			return fmt.Errorf("account %s of ArgoCD %s/%s requires the %s capability to store its tokens", account.Name, cr.Namespace, cr.Name, argoproj.ArgoCDAccountCapabilityAPIKey)
+		}
+
+		secret := argoutil.NewSecretWithName(cr, account.TokensSecretRef.Name)
+		found := argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret)
+		if found && len(secret.Data[accountTokenKey]) > 0 {
+			continue // Token found, move along...
+		}
+
+		// The tokens are signed with the signature key of the API server
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, argoSecret.Name, argoSecret) {
+			instanceLog(cr).Info(fmt.Sprintf("argo secret [%s] not found, waiting to generate the token of account [%s]", argoSecret.Name, account.Name))
+			return nil
+		}
+		key := argoSecret.Data[common.ArgoCDKeyServerSecretKey]
+		if len(key) == 0 {
+			return fmt.Errorf("argo secret %s of ArgoCD %s/%s has no %s key", argoSecret.Name, cr.Namespace, cr.Name, common.ArgoCDKeyServerSecretKey)
+		}
+
+		tokensKey := fmt.Sprintf("accounts.%s.tokens", account.Name)
+		tokens := []accountToken{}
+		if data := argoSecret.Data[tokensKey]; len(data) > 0 {
+			if err := json.Unmarshal(data, &tokens); err != nil {
+				return fmt.Errorf("failed to parse the tokens of account %s of ArgoCD %s/%s: %w", account.Name, cr.Namespace, cr.Name, err)
+			}
+		}
+		token := accountToken{ID: string(uuid.NewUUID()), IssuedAt: time.Now().Unix()}
+		signed, err := signAccountToken(account.Name, token, key)
+		if err != nil {
+			return err
+		}
+
+		// Record the token before handing it out, a token that is not recorded being rejected by the API server
+		data, err := json.Marshal(append(tokens, token))
+		if err != nil {
+			return err
+		}
+		argoSecret.Data[tokensKey] = data
+		instanceLog(cr).Info(fmt.Sprintf("Recording a new token of account %s in secret %s", account.Name, argoSecret.Name))
+		if err := r.Client.Update(context.TODO(), argoSecret); err != nil {
+			return err
+		}
+
+		if !found {
+			secret.Data = map[string][]byte{accountTokenKey: []byte(signed)}
+			if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+				return err
+			}
+			instanceLog(cr).Info(fmt.Sprintf("Creating secret %s with the token of account %s", secret.Name, account.Name))
+			if err := r.Client.Create(context.TODO(), secret); err != nil {
+				return err
+			}
+			continue
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[accountTokenKey] = []byte(signed)
+		instanceLog(cr).Info(fmt.Sprintf("Updating secret %s with the token of account %s", secret.Name, account.Name))
+		if err := r.Client.Update(context.TODO(), secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func TestReconcileArgoCD_reconcileArgoConfigMap_accounts(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Accounts = []argoproj.ArgoCDAccountSpec{
+			{
+				Name:         "ci",
+				Capabilities: []argoproj.ArgoCDAccountCapability{argoproj.ArgoCDAccountCapabilityAPIKey},
+			},
+			{
+				Name:         "alice",
+				Capabilities: []argoproj.ArgoCDAccountCapability{argoproj.ArgoCDAccountCapabilityAPIKey, argoproj.ArgoCDAccountCapabilityLogin},
+				Enabled:      boolPtr(false),
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, "apiKey", cm.Data["accounts.ci"])
+	assert.NotContains(t, cm.Data, "accounts.ci.enabled")
+	assert.Equal(t, "apiKey, login", cm.Data["accounts.alice"])
+	assert.Equal(t, "false", cm.Data["accounts.alice.enabled"])
+}
+
+func TestReconcileArgoCD_reconcileAccountTokens(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Accounts = []argoproj.ArgoCDAccountSpec{{
+			Name:            "ci",
+			Capabilities:    []argoproj.ArgoCDAccountCapability{argoproj.ArgoCDAccountCapabilityAPIKey},
+			TokensSecretRef: &corev1.LocalObjectReference{Name: "argocd-ci-token"},
+		}}
+	})
+	argoSecret := argoutil.NewSecretWithName(a, common.ArgoCDSecretName)
+	argoSecret.Data = map[string][]byte{common.ArgoCDKeyServerSecretKey: []byte("signature-key")}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// No token is generated until the signature key of the API server exists
+	assert.NoError(t, r.reconcileAccountTokens(a))
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Name: "argocd-ci-token", Namespace: a.Namespace}
+	assert.Error(t, r.Client.Get(context.TODO(), key, secret))
+	assert.NoError(t, r.Client.Create(context.TODO(), argoSecret))
+
+	// The token is signed with the signature key, and recorded in argocd-secret
+	assert.NoError(t, r.reconcileAccountTokens(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	assert.True(t, metav1.IsControlledBy(secret, a))
+	token := string(secret.Data[accountTokenKey])
+	parts := strings.Split(token, ".")
+	assert.Len(t, parts, 3)
+	claims := map[string]interface{}{}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(payload, &claims))
+	assert.Equal(t, "ci:apiKey", claims["sub"])
+	assert.Equal(t, "argocd", claims["iss"])
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, argoSecret))
+	tokens := []accountToken{}
+	assert.NoError(t, json.Unmarshal(argoSecret.Data["accounts.ci.tokens"], &tokens))
+	assert.Len(t, tokens, 1)
+	assert.Equal(t, claims["jti"], tokens[0].ID)
+	expected, err := signAccountToken("ci", tokens[0], []byte("signature-key"))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, token)
+
+	// The token is kept, and a new one is generated once removed from the Secret
+	assert.NoError(t, r.reconcileAccountTokens(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	assert.Equal(t, token, string(secret.Data[accountTokenKey]))
+
+	delete(secret.Data, accountTokenKey)
+	assert.NoError(t, r.Client.Update(context.TODO(), secret))
+	assert.NoError(t, r.reconcileAccountTokens(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	assert.NotEqual(t, token, string(secret.Data[accountTokenKey]))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, argoSecret))
+	assert.NoError(t, json.Unmarshal(argoSecret.Data["accounts.ci.tokens"], &tokens))
+	assert.Len(t, tokens, 2)
+
+	// The tokens of an account require the apiKey capability
+	a.Spec.Accounts[0].Capabilities = []argoproj.ArgoCDAccountCapability{argoproj.ArgoCDAccountCapabilityLogin}
+	assert.Error(t, r.reconcileAccountTokens(a))
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	argopass "github.com/argoproj/argo-cd/v2/util/password"
+	corev1 "k8s.io/api/core/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// newAdminPasswordSecret will return the Secret holding the admin password of the given ArgoCD, either the one
+// referenced by its admin password policy or the <name>-cluster Secret.
+func newAdminPasswordSecret(cr *argoproj.ArgoCD) *corev1.Secret {
+	if cr.Spec.AdminPasswordPolicy != nil && cr.Spec.AdminPasswordPolicy.SecretRef != nil {
+		return argoutil.NewSecretWithName(cr, cr.Spec.AdminPasswordPolicy.SecretRef.Name)
+	}
+	return argoutil.NewSecretWithSuffix(cr, "cluster")
+}
+
+// getAdminPasswordRotationInterval will return the interval at which the admin password of the given ArgoCD is
+// rotated, zero if it is not.
+func getAdminPasswordRotationInterval(cr *argoproj.ArgoCD) time.Duration {
+	if cr.Spec.AdminPasswordPolicy == nil || cr.Spec.AdminPasswordPolicy.RotateEvery == nil {
+		return 0
+	}
+	return cr.Spec.AdminPasswordPolicy.RotateEvery.Duration
+}
+
+// isAdminDisabled returns true if the admin user of the given ArgoCD must be disabled, either explicitly or because
+// its SSO provider is running and the admin password policy disables the admin user after SSO.
+func isAdminDisabled(cr *argoproj.ArgoCD) bool {
+	if cr.Spec.DisableAdmin {
+		return true
+	}
+	return cr.Spec.AdminPasswordPolicy != nil && cr.Spec.AdminPasswordPolicy.DisableAfterSSO &&
+		cr.Spec.SSO != nil && cr.Status.SSO == "Running"
+}
+
+// getAdminPasswordRotationDelay will return the time left until the next rotation of the admin password of the
+// given ArgoCD, zero if the password is not rotated.
+func (r *ReconcileArgoCD) getAdminPasswordRotationDelay(cr *argoproj.ArgoCD) time.Duration {
+	interval := getAdminPasswordRotationInterval(cr)
+	if interval <= 0 {
+		return 0
+	}
+
+	secret := argoutil.NewSecretWithName(cr, common.ArgoCDSecretName)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		return interval
+	}
+	mtime, err := time.Parse(time.RFC3339, string(secret.Data[common.ArgoCDKeyAdminPasswordMTime]))
+	if err != nil {
+		return interval
+	}
+	if delay := time.Until(mtime.Add(interval)); delay > 0 {
+		return delay
+	}
+	// Overdue, e.g. as the previous rotation failed
+	return time.Second
+}
+
+// reconcileAdminPasswordRotation will generate a new admin password for the given ArgoCD once the rotation interval
+// of its admin password policy has elapsed since the last change of the password. The password is published to the
+// admin password Secret before it is set in the Argo CD Secret, so that it is never set without being readable.
+func (r *ReconcileArgoCD) reconcileAdminPasswordRotation(cr *argoproj.ArgoCD) error {
+	interval := getAdminPasswordRotationInterval(cr)
+	if interval <= 0 {
+		return nil
+	}
+
+	secret := argoutil.NewSecretWithName(cr, common.ArgoCDSecretName)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		return nil // Argo CD Secret not created yet, the password is as new as it gets
+	}
+	mtime, err := time.Parse(time.RFC3339, string(secret.Data[common.ArgoCDKeyAdminPasswordMTime]))
+	if err == nil && time.Now().Before(mtime.Add(interval)) {
+		return nil
+	}
+
+	passwordSecret := newAdminPasswordSecret(cr)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, passwordSecret.Name, passwordSecret) {
+		instanceLog(cr).Info(fmt.Sprintf("admin password secret [%s] not found, waiting to rotate the admin password", passwordSecret.Name))
+		return nil
+	}
+
+	adminPassword, err := generateArgoAdminPassword()
+	if err != nil {
+		return err
+	}
+	hashedPassword, err := argopass.HashPassword(string(adminPassword))
+	if err != nil {
+		return err
+	}
+
+	if passwordSecret.Data == nil {
+		passwordSecret.Data = map[string][]byte{}
+	}
+	passwordSecret.Data[common.ArgoCDKeyAdminPassword] = adminPassword
+	if err := r.Client.Update(context.TODO(), passwordSecret); err != nil {
+		return err
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[common.ArgoCDKeyAdminPassword] = []byte(hashedPassword)
+	secret.Data[common.ArgoCDKeyAdminPasswordMTime] = nowBytes()
+	instanceLog(cr).Info(fmt.Sprintf("rotating the admin password of ArgoCD %s, published to secret [%s]", cr.Name, passwordSecret.Name))
+	return r.Client.Update(context.TODO(), secret)
+}
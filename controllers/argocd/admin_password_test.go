@@ -0,0 +1,105 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	argopass "github.com/argoproj/argo-cd/v2/util/password"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func TestReconcileArgoCD_reconcileAdminPasswordRotation(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.AdminPasswordPolicy = &argoproj.ArgoCDAdminPasswordPolicySpec{
+			RotateEvery: &metav1.Duration{Duration: 24 * time.Hour},
+			SecretRef:   &corev1.LocalObjectReference{Name: "argocd-admin"},
+		}
+	})
+	tlsSecret := argoutil.NewSecretWithSuffix(a, "tls")
+
+	resObjs := []client.Object{a, tlsSecret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// The admin password is generated in the referenced Secret
+	assert.NoError(t, r.reconcileClusterMainSecret(a))
+	assert.NoError(t, r.reconcileArgoSecret(a))
+	passwordSecret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-admin", Namespace: a.Namespace}, passwordSecret))
+	initialPassword := string(passwordSecret.Data[common.ArgoCDKeyAdminPassword])
+	assert.NotEmpty(t, initialPassword)
+
+	// The password is kept until the rotation interval elapses
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-admin", Namespace: a.Namespace}, passwordSecret))
+	assert.Equal(t, initialPassword, string(passwordSecret.Data[common.ArgoCDKeyAdminPassword]))
+	delay := r.getAdminPasswordRotationDelay(a)
+	assert.True(t, delay > 23*time.Hour && delay <= 24*time.Hour)
+
+	argoSecret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, argoSecret))
+	argoSecret.Data[common.ArgoCDKeyAdminPasswordMTime] = []byte(time.Now().Add(-25 * time.Hour).UTC().Format(time.RFC3339))
+	assert.NoError(t, r.Client.Update(context.TODO(), argoSecret))
+	assert.Equal(t, time.Second, r.getAdminPasswordRotationDelay(a))
+
+	// Once it elapsed, a new password is published and set in the Argo CD Secret
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-admin", Namespace: a.Namespace}, passwordSecret))
+	rotatedPassword := string(passwordSecret.Data[common.ArgoCDKeyAdminPassword])
+	assert.NotEqual(t, initialPassword, rotatedPassword)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, argoSecret))
+	valid, _ := argopass.VerifyPassword(rotatedPassword, string(argoSecret.Data[common.ArgoCDKeyAdminPassword]))
+	assert.True(t, valid)
+	mtime, err := time.Parse(time.RFC3339, string(argoSecret.Data[common.ArgoCDKeyAdminPasswordMTime]))
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), mtime, time.Minute)
+}
+
+func TestIsAdminDisabled(t *testing.T) {
+	a := makeTestArgoCD()
+	assert.False(t, isAdminDisabled(a))
+
+	a.Spec.DisableAdmin = true
+	assert.True(t, isAdminDisabled(a))
+
+	// The admin user is only disabled while SSO is running
+	a.Spec.DisableAdmin = false
+	a.Spec.AdminPasswordPolicy = &argoproj.ArgoCDAdminPasswordPolicySpec{DisableAfterSSO: true}
+	a.Spec.SSO = &argoproj.ArgoCDSSOSpec{Provider: argoproj.SSOProviderTypeDex}
+	a.Status.SSO = "Pending"
+	assert.False(t, isAdminDisabled(a))
+
+	a.Status.SSO = "Running"
+	assert.True(t, isAdminDisabled(a))
+
+	a.Spec.SSO = nil
+	assert.False(t, isAdminDisabled(a))
+}
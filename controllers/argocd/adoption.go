@@ -0,0 +1,334 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// newAdoptionConfigMap returns the ConfigMap holding the report of the adoption of the existing resources of the
+// given ArgoCD.
+func newAdoptionConfigMap(cr *argoproj.ArgoCD) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nameWithSuffix("adoption", cr),
+			Namespace: cr.Namespace,
+			Labels:    argoutil.LabelsForCluster(cr),
+		},
+	}
+}
+
+// getUnownedResources will return the resources labeled as part of Argo CD in the namespace of the given ArgoCD that
+// have no controller, e.g. the resources of an Argo CD installed with Helm or plain manifests.
+func (r *ReconcileArgoCD) getUnownedResources(cr *argoproj.ArgoCD) ([]client.Object, error) {
+	unowned := []client.Object{}
+	for _, list := range newBackupLabeledLists() {
+		if err := r.Client.List(context.TODO(), list, client.InNamespace(cr.Namespace), client.MatchingLabels{common.ArgoCDKeyPartOf: common.ArgoCDAppName}); err != nil {
+			return nil, fmt.Errorf("failed to list the existing resources of ArgoCD %s/%s to adopt: %w", cr.Namespace, cr.Name, err)
+		}
+		objs, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range objs {
+			if obj, ok := o.(client.Object); ok && metav1.GetControllerOf(obj) == nil {
+				unowned = append(unowned, obj)
+			}
+		}
+	}
+	return unowned, nil
+}
+
+// adoptionGuardClient is a client skipping the writes to the existing resources of an ArgoCD that are not adopted yet,
+// so that the other resources of the instance are reconciled while they are reported or adopted.
+type adoptionGuardClient struct {
+	client.Client
+	pending map[string]bool
+}
+
+// newAdoptionGuardClient returns an adoptionGuardClient leaving the given pending resources of the given client
+// untouched.
+func newAdoptionGuardClient(c client.Client, pending map[string]bool) *adoptionGuardClient {
+	return &adoptionGuardClient{Client: c, pending: pending}
+}
+
+// skip returns true if the given object is not adopted yet, logging the write that is skipped.
+func (c *adoptionGuardClient) skip(action string, obj client.Object) bool {
+	id := describeObject(obj, client.ObjectKeyFromObject(obj), c.Scheme())
+	if !c.pending[id] {
+		return false
+	}
+	log.V(1).Info(fmt.Sprintf("Skipping the %s of %s, not adopted yet", action, id))
+	return true
+}
+
+func (c *adoptionGuardClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if c.skip("creation", obj) {
+		return nil
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *adoptionGuardClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if c.skip("update", obj) {
+		return nil
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *adoptionGuardClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if c.skip("update", obj) {
+		return nil
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *adoptionGuardClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if c.skip("deletion", obj) {
+		return nil
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+// getAdoptionCandidates will run the reconciliation of the resources of the given ArgoCD as a dry run, and return
+// the given unowned resources it would create or update, i.e. the resources the operator manages under the same
+// name, with the changes it would make to them and the other resources.
+func (r *ReconcileArgoCD) getAdoptionCandidates(cr *argoproj.ArgoCD, unowned []client.Object) ([]string, []string, error) {
+	recorder := newDryRunRecorder(r.Client)
+	dryRunErr := r.newDryRunReconciler(recorder).reconcileResources(cr.DeepCopy())
+
+	candidates := []string{}
+	for _, obj := range unowned {
+		id := recorder.describe(obj, client.ObjectKeyFromObject(obj))
+		if written := recorder.objects[id]; written != nil {
+			candidates = append(candidates, id)
+		}
+	}
+	sort.Strings(candidates)
+	return candidates, recorder.changes, dryRunErr
+}
+
+// getAdoptionReport will return the adoption report of the given ArgoCD if it was recorded for its current generation,
+// nil if the report has to be recorded again.
+func (r *ReconcileArgoCD) getAdoptionReport(cr *argoproj.ArgoCD) (*corev1.ConfigMap, error) {
+	condition := meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionTypeAdoption)
+	if condition == nil || condition.ObservedGeneration != cr.Generation || condition.Reason == argoproj.ArgoCDConditionReasonAdoptionFailed {
+		return nil, nil
+	}
+
+	report := newAdoptionConfigMap(cr)
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, report.Name, report); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+// reconcileAdoption will find the existing resources of an Argo CD installed without the operator that the given
+// ArgoCD manages, once per generation of the instance, and either report them with the changes the operator would
+// make, or set the ArgoCD as their controller in batches, depending on the adoption mode. It returns the resources
+// that are not adopted yet, which must be left untouched by the reconciliation of the other resources of the instance.
+func (r *ReconcileArgoCD) reconcileAdoption(cr *argoproj.ArgoCD) (map[string]bool, error) {
+	if cr.Spec.Adoption == nil {
+		return nil, r.reconcileAdoptionDisabled(cr)
+	}
+
+	condition := meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionTypeAdoption)
+	if condition != nil && condition.ObservedGeneration == cr.Generation && condition.Reason == argoproj.ArgoCDConditionReasonResourcesAdopted {
+		return nil, nil // Adopted for this generation, nothing left to do
+	}
+
+	report, err := r.getAdoptionReport(cr)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		unowned, err := r.getUnownedResources(cr)
+		if err != nil {
+			return nil, err
+		}
+		if report, err = r.reconcileAdoptionReport(cr, unowned); err != nil {
+			return nil, err
+		}
+	}
+
+	pending := map[string]bool{}
+	for _, id := range strings.Split(report.Data[common.ArgoCDKeyAdoptionResources], "\n") {
+		if id != "" {
+			pending[id] = true
+		}
+	}
+
+	if cr.Spec.Adoption.Mode != argoproj.ArgoCDAdoptionModeAdopt {
+		return pending, nil
+	}
+	if reportErr := report.Data[common.ArgoCDKeyDryRunError]; reportErr != "" {
+		return nil, fmt.Errorf("failed to find the existing resources of ArgoCD %s/%s to adopt: %s", cr.Namespace, cr.Name, reportErr)
+	}
+	return r.reconcileAdoptionBatch(cr, report, pending)
+}
+
+// reconcileAdoptionBatch will set the given ArgoCD as the controller of the next batch of the given pending resources
+// recorded in the given adoption report, and return the resources left to adopt. The report is removed once all the
+// resources have been adopted.
+func (r *ReconcileArgoCD) reconcileAdoptionBatch(cr *argoproj.ArgoCD, report *corev1.ConfigMap, pending map[string]bool) (map[string]bool, error) {
+	unowned, err := r.getUnownedResources(cr)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(unowned, func(i, j int) bool {
+		return describeObject(unowned[i], client.ObjectKeyFromObject(unowned[i]), r.Scheme) < describeObject(unowned[j], client.ObjectKeyFromObject(unowned[j]), r.Scheme)
+	})
+
+	remaining := map[string]bool{}
+	adopted := 0
+	for _, obj := range unowned {
+		id := describeObject(obj, client.ObjectKeyFromObject(obj), r.Scheme)
+		if !pending[id] {
+			continue
+		}
+		if adopted == common.ArgoCDDefaultAdoptionBatchSize {
+			remaining[id] = true
+			continue
+		}
+		if err := controllerutil.SetControllerReference(cr, obj, r.Scheme); err != nil {
+			return nil, err
+		}
+		instanceLog(cr).Info(fmt.Sprintf("Adopting %s for ArgoCD %s", id, cr.Name))
+		if err := r.Client.Update(context.TODO(), obj); err != nil {
+			return nil, fmt.Errorf("failed to adopt %s: %w", id, err)
+		}
+		adopted++
+	}
+
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeAdoption,
+		Status:             metav1.ConditionFalse,
+		Reason:             argoproj.ArgoCDConditionReasonAdoptionInProgress,
+		Message:            fmt.Sprintf("%d of %d existing resources adopted, the next ones are adopted in batches of %d", len(pending)-len(remaining), len(pending), common.ArgoCDDefaultAdoptionBatchSize),
+		ObservedGeneration: cr.Generation,
+	}
+	if len(remaining) == 0 {
+		if err := r.Client.Delete(context.TODO(), report); err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = argoproj.ArgoCDConditionReasonResourcesAdopted
+		condition.Message = fmt.Sprintf("%d existing resources adopted, their differences are reconciled", len(pending))
+	}
+	return remaining, r.updateAdoptionCondition(cr, condition)
+}
+
+// reconcileAdoptionReport will run the reconciliation of the given ArgoCD as a dry run, and record the given unowned
+// resources it would adopt, and the changes the operator would make, in the adoption ConfigMap, leaving the resources
+// untouched. It returns the adoption ConfigMap.
+func (r *ReconcileArgoCD) reconcileAdoptionReport(cr *argoproj.ArgoCD, unowned []client.Object) (*corev1.ConfigMap, error) {
+	candidates, changes, reportErr := r.getAdoptionCandidates(cr, unowned)
+
+	cm := newAdoptionConfigMap(cr)
+	cm.Data = map[string]string{
+		common.ArgoCDKeyAdoptionResources: strings.Join(candidates, "\n"),
+		common.ArgoCDKeyDryRunChanges:     strings.Join(changes, "\n"),
+	}
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeAdoption,
+		Status:             metav1.ConditionFalse,
+		Reason:             argoproj.ArgoCDConditionReasonAdoptionReported,
+		Message:            fmt.Sprintf("%d existing resources to adopt and %d changes recorded in ConfigMap %s, the resources are adopted once the adoption mode is set to %s", len(candidates), len(changes), cm.Name, argoproj.ArgoCDAdoptionModeAdopt),
+		ObservedGeneration: cr.Generation,
+	}
+	if reportErr != nil {
+		cm.Data[common.ArgoCDKeyDryRunError] = reportErr.Error()
+		condition.Reason = argoproj.ArgoCDConditionReasonAdoptionFailed
+		condition.Message = fmt.Sprintf("adoption report interrupted after %d changes recorded in ConfigMap %s: %v", len(changes), cm.Name, reportErr)
+	}
+
+	existing := &corev1.ConfigMap{}
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, cm.Name, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+			return nil, err
+		}
+		instanceLog(cr).Info(fmt.Sprintf("Creating ConfigMap %s with the adoption report of ArgoCD %s", cm.Name, cr.Name))
+		if err := r.Client.Create(context.TODO(), cm); err != nil {
+			return nil, err
+		}
+	} else {
+		if !reflect.DeepEqual(existing.Data, cm.Data) {
+			existing.Data = cm.Data
+			if err := r.Client.Update(context.TODO(), existing); err != nil {
+				return nil, err
+			}
+		}
+		cm = existing
+	}
+
+	return cm, r.updateAdoptionCondition(cr, condition)
+}
+
+// getAdoptionDelay will return the time until the next batch of the adoption of the existing resources of the given
+// ArgoCD, zero if no adoption is in progress.
+func getAdoptionDelay(cr *argoproj.ArgoCD) time.Duration {
+	condition := meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionTypeAdoption)
+	if condition == nil || condition.Reason != argoproj.ArgoCDConditionReasonAdoptionInProgress {
+		return 0
+	}
+	return common.ArgoCDDefaultAdoptionInterval
+}
+
+// reconcileAdoptionDisabled will remove the adoption report of the given ArgoCD, and its Adoption condition, once the
+// adoption is no longer requested.
+func (r *ReconcileArgoCD) reconcileAdoptionDisabled(cr *argoproj.ArgoCD) error {
+	if meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionTypeAdoption) == nil {
+		return nil // No adoption requested, nothing to clean up
+	}
+
+	if err := r.Client.Delete(context.TODO(), newAdoptionConfigMap(cr)); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	meta.RemoveStatusCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionTypeAdoption)
+	return r.Client.Status().Update(context.TODO(), cr)
+}
+
+// updateAdoptionCondition will set the given Adoption condition on the status of the given ArgoCD, if changed.
+func (r *ReconcileArgoCD) updateAdoptionCondition(cr *argoproj.ArgoCD, condition metav1.Condition) error {
+	conditions := make([]metav1.Condition, len(cr.Status.Conditions))
+	copy(conditions, cr.Status.Conditions)
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	if !reflect.DeepEqual(conditions, cr.Status.Conditions) {
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+	return nil
+}
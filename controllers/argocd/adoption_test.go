@@ -0,0 +1,166 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestUnownedConfigMap(name string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{common.ArgoCDKeyPartOf: common.ArgoCDAppName},
+		},
+		Data: map[string]string{"url": "https://argocd.example.com"},
+	}
+}
+
+func TestReconcileArgoCD_reconcileAdoption(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Adoption = &argoproj.ArgoCDAdoptionSpec{Mode: argoproj.ArgoCDAdoptionModeReport}
+	})
+	managed := makeTestUnownedConfigMap(common.ArgoCDConfigMapName)
+	other := makeTestUnownedConfigMap("argocd-custom")
+
+	resObjs := []client.Object{a, managed, other}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	assert.NoError(t, r.setManagedNamespaces(a))
+
+	// The existing resources managed by the operator are only reported, and left untouched by the reconciliation
+	pending, err := r.reconcileAdoption(a)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"ConfigMap argocd/argocd-cm": true}, pending)
+
+	report := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-adoption", Namespace: a.Namespace}, report))
+	assert.Equal(t, "ConfigMap argocd/argocd-cm", report.Data[common.ArgoCDKeyAdoptionResources])
+	assert.Contains(t, report.Data[common.ArgoCDKeyDryRunChanges], "update ConfigMap argocd/argocd-cm")
+
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeAdoption)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonAdoptionReported, condition.Reason)
+
+	guarded := newAdoptionGuardClient(r.Client, pending)
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, guarded.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	cm.Data["url"] = "https://changed.example.com"
+	assert.NoError(t, guarded.Update(context.TODO(), cm))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Nil(t, metav1.GetControllerOf(cm))
+	assert.Equal(t, "https://argocd.example.com", cm.Data["url"])
+
+	// The report is only recorded once per generation
+	assert.NoError(t, r.Client.Delete(context.TODO(), other))
+	report.Data[common.ArgoCDKeyDryRunChanges] = "recorded"
+	assert.NoError(t, r.Client.Update(context.TODO(), report))
+	_, err = r.reconcileAdoption(a)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-adoption", Namespace: a.Namespace}, report))
+	assert.Equal(t, "recorded", report.Data[common.ArgoCDKeyDryRunChanges])
+	assert.NoError(t, r.Client.Create(context.TODO(), makeTestUnownedConfigMap(other.Name)))
+
+	// The resources are adopted once requested, leaving the other resources untouched
+	a.Spec.Adoption.Mode = argoproj.ArgoCDAdoptionModeAdopt
+	a.Generation++
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	pending, err = r.reconcileAdoption(a)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.True(t, metav1.IsControlledBy(cm, a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: other.Name, Namespace: a.Namespace}, cm))
+	assert.Nil(t, metav1.GetControllerOf(cm))
+
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-adoption", Namespace: a.Namespace}, report)
+	assert.True(t, errors.IsNotFound(err))
+	assert.True(t, meta.IsStatusConditionTrue(a.Status.Conditions, argoproj.ArgoCDConditionTypeAdoption))
+
+	// The condition is removed once the adoption is no longer requested
+	a.Spec.Adoption = nil
+	pending, err = r.reconcileAdoption(a)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeAdoption))
+}
+
+func TestReconcileArgoCD_reconcileAdoption_batches(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Adoption = &argoproj.ArgoCDAdoptionSpec{Mode: argoproj.ArgoCDAdoptionModeAdopt}
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// A report recorded for the current generation is adopted in batches
+	resources := []string{}
+	for i := 0; i < common.ArgoCDDefaultAdoptionBatchSize+2; i++ {
+		cm := makeTestUnownedConfigMap(fmt.Sprintf("argocd-adopted-%02d", i))
+		assert.NoError(t, r.Client.Create(context.TODO(), cm))
+		resources = append(resources, fmt.Sprintf("ConfigMap argocd/%s", cm.Name))
+	}
+	report := newAdoptionConfigMap(a)
+	report.Data = map[string]string{common.ArgoCDKeyAdoptionResources: strings.Join(resources, "\n")}
+	assert.NoError(t, r.Client.Create(context.TODO(), report))
+	meta.SetStatusCondition(&a.Status.Conditions, metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeAdoption,
+		Status:             metav1.ConditionFalse,
+		Reason:             argoproj.ArgoCDConditionReasonAdoptionReported,
+		ObservedGeneration: a.Generation,
+	})
+
+	remaining, err := r.reconcileAdoption(a)
+	assert.NoError(t, err)
+	assert.Len(t, remaining, 2)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonAdoptionInProgress, meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeAdoption).Reason)
+	assert.Equal(t, common.ArgoCDDefaultAdoptionInterval, getAdoptionDelay(a))
+
+	remaining, err = r.reconcileAdoption(a)
+	assert.NoError(t, err)
+	assert.Empty(t, remaining)
+	assert.True(t, meta.IsStatusConditionTrue(a.Status.Conditions, argoproj.ArgoCDConditionTypeAdoption))
+	assert.Zero(t, getAdoptionDelay(a))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), client.ObjectKeyFromObject(report), report)))
+}
@@ -52,12 +52,17 @@ func (r *ReconcileArgoCD) getArgoApplicationSetCommand(cr *argoproj.ArgoCD) []st
 	if cr.Spec.Repo.IsEnabled() {
 		cmd = append(cmd, "--argocd-repo-server", getRepoServerAddress(cr))
 	} else {
-		log.Info("Repo Server is disabled. This would affect the functioning of ApplicationSet Controller.")
+		componentLog(cr, logComponentApplicationSet).Info("Repo Server is disabled. This would affect the functioning of ApplicationSet Controller.")
 	}
 
 	cmd = append(cmd, "--loglevel")
 	cmd = append(cmd, getLogLevel(cr.Spec.ApplicationSet.LogLevel))
 
+	// multiple replicas elect a leader, the others stand by for failover
+	if replicas := getApplicationSetReplicas(cr); replicas != nil && *replicas > 1 {
+		cmd = append(cmd, "--enable-leader-election")
+	}
+
 	if cr.Spec.ApplicationSet.SCMRootCAConfigMap != "" {
 		cmd = append(cmd, "--scm-root-ca-path")
 		cmd = append(cmd, ApplicationSetGitlabSCMTlsCertPath)
@@ -71,7 +76,7 @@ func (r *ReconcileArgoCD) getArgoApplicationSetCommand(cr *argoproj.ArgoCD) []st
 			if contains(appsNamespaces, ns) {
 				appsetsSourceNamespaces = append(appsetsSourceNamespaces, ns)
 			} else {
-				log.V(1).Info(fmt.Sprintf("Apps in target sourceNamespace %s is not enabled, thus skipping the namespace in deployment command.", ns))
+				componentLog(cr, logComponentApplicationSet).V(1).Info(fmt.Sprintf("Apps in target sourceNamespace %s is not enabled, thus skipping the namespace in deployment command.", ns))
 			}
 		}
 	}
@@ -91,6 +96,18 @@ func (r *ReconcileArgoCD) getArgoApplicationSetCommand(cr *argoproj.ArgoCD) []st
 		cmd = append(cmd, "--enable-scm-providers=false")
 	}
 
+	if cr.Spec.ApplicationSet.Policy != "" {
+		cmd = append(cmd, "--policy", string(cr.Spec.ApplicationSet.Policy))
+	}
+
+	if cr.Spec.ApplicationSet.EnableProgressiveSyncs {
+		cmd = append(cmd, "--enable-progressive-syncs")
+	}
+
+	if cr.Spec.ApplicationSet.DryRun {
+		cmd = append(cmd, "--dry-run")
+	}
+
 	// ApplicationSet command arguments provided by the user
 	extraArgs := cr.Spec.ApplicationSet.ExtraCommandArgs
 	err = isMergable(extraArgs, cmd)
@@ -105,53 +122,53 @@ func (r *ReconcileArgoCD) getArgoApplicationSetCommand(cr *argoproj.ArgoCD) []st
 
 func (r *ReconcileArgoCD) reconcileApplicationSetController(cr *argoproj.ArgoCD) error {
 
-	log.Info("reconciling applicationset serviceaccounts")
+	componentLog(cr, logComponentApplicationSet).Info("reconciling applicationset serviceaccounts")
 	sa, err := r.reconcileApplicationSetServiceAccount(cr)
 	if err != nil {
 		return err
 	}
 
-	log.Info("reconciling applicationset roles")
+	componentLog(cr, logComponentApplicationSet).Info("reconciling applicationset roles")
 	role, err := r.reconcileApplicationSetRole(cr)
 	if err != nil {
 		return err
 	}
 
-	log.Info("reconciling applicationset role bindings")
+	componentLog(cr, logComponentApplicationSet).Info("reconciling applicationset role bindings")
 	if err := r.reconcileApplicationSetRoleBinding(cr, role, sa); err != nil {
 		return err
 	}
 
-	log.Info("reconciling applicationset deployments")
+	componentLog(cr, logComponentApplicationSet).Info("reconciling applicationset deployments")
 	if err := r.reconcileApplicationSetDeployment(cr, sa); err != nil {
 		return err
 	}
 
-	log.Info("reconciling applicationset service")
+	componentLog(cr, logComponentApplicationSet).Info("reconciling applicationset service")
 	if err := r.reconcileApplicationSetService(cr); err != nil {
 		return err
 	}
 
 	// create clusterrole & clusterrolebinding if cluster-scoped ArgoCD
-	log.Info("reconciling applicationset clusterroles")
+	componentLog(cr, logComponentApplicationSet).Info("reconciling applicationset clusterroles")
 	clusterrole, err := r.reconcileApplicationSetClusterRole(cr)
 	if err != nil {
 		return err
 	}
 
-	log.Info("reconciling applicationset clusterrolebindings")
+	componentLog(cr, logComponentApplicationSet).Info("reconciling applicationset clusterrolebindings")
 	if err := r.reconcileApplicationSetClusterRoleBinding(cr, clusterrole, sa); err != nil {
 		return err
 	}
 
 	// reconcile source namespace roles & rolebindings
-	log.Info("reconciling applicationset roles & rolebindings in source namespaces")
+	componentLog(cr, logComponentApplicationSet).Info("reconciling applicationset roles & rolebindings in source namespaces")
 	if err := r.reconcileApplicationSetSourceNamespacesResources(cr); err != nil {
 		return err
 	}
 
 	// remove resources for namespaces not part of SourceNamespaces
-	log.Info("performing cleanup for applicationset source namespaces")
+	componentLog(cr, logComponentApplicationSet).Info("performing cleanup for applicationset source namespaces")
 	if err := r.removeUnmanagedApplicationSetSourceNamespaceResources(cr); err != nil {
 		return err
 	}
@@ -246,34 +263,61 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoproj.ArgoCD,
 		}
 	}
 
+	podSpec.Volumes = append(podSpec.Volumes, cr.Spec.ApplicationSet.Volumes...)
+	podSpec.InitContainers = cr.Spec.ApplicationSet.InitContainers
 	podSpec.Containers = []corev1.Container{
 		r.applicationSetContainer(cr, addSCMGitlabVolumeMount),
 	}
+	podSpec.Containers = append(podSpec.Containers, cr.Spec.ApplicationSet.SidecarContainers...)
+	applyApplicationSetWebhookTLS(cr, podSpec)
 	AddSeccompProfileForOpenShift(r.Client, podSpec)
+	applySecurityContext(podSpec, cr.Spec.ApplicationSet.PodSecurityContext, cr.Spec.ApplicationSet.SecurityContext, "argocd-applicationset-controller")
+	applyTerminationGracePeriod(podSpec, cr.Spec.ApplicationSet.TerminationGracePeriodSeconds)
+	applyDNSConfig(podSpec, cr.Spec.ApplicationSet.DNSPolicy, cr.Spec.ApplicationSet.DNSConfig)
+	applyLogSidecar(cr, podSpec, "argocd-applicationset-controller")
+	applyPodMetadata(&deploy.Spec.Template, cr.Spec.ApplicationSet.PodLabels, cr.Spec.ApplicationSet.PodAnnotations)
+
+	if replicas := getApplicationSetReplicas(cr); replicas != nil {
+		deploy.Spec.Replicas = replicas
+	}
 
 	if exists {
 
 		existingSpec := existing.Spec.Template.Spec
+		if deploy.Spec.Replicas != nil {
+			deploy.Spec.Replicas = getBudgetedReplicas(cr, existing.Spec.Replicas, deploy.Spec.Replicas)
+		}
 
 		deploymentsDifferent := !reflect.DeepEqual(existingSpec.Containers[0], podSpec.Containers) ||
 			!reflect.DeepEqual(existingSpec.Volumes, podSpec.Volumes) ||
+			!reflect.DeepEqual(existingSpec.InitContainers, podSpec.InitContainers) ||
 			existingSpec.ServiceAccountName != podSpec.ServiceAccountName ||
 			!reflect.DeepEqual(existing.Labels, deploy.Labels) ||
 			!reflect.DeepEqual(existing.Spec.Template.Labels, deploy.Spec.Template.Labels) ||
 			!reflect.DeepEqual(existing.Spec.Selector, deploy.Spec.Selector) ||
 			!reflect.DeepEqual(existing.Spec.Template.Spec.NodeSelector, deploy.Spec.Template.Spec.NodeSelector) ||
-			!reflect.DeepEqual(existing.Spec.Template.Spec.Tolerations, deploy.Spec.Template.Spec.Tolerations)
+			!reflect.DeepEqual(existing.Spec.Template.Spec.Tolerations, deploy.Spec.Template.Spec.Tolerations) ||
+			!reflect.DeepEqual(existingSpec.SecurityContext, podSpec.SecurityContext) ||
+			(deploy.Spec.Replicas != nil && !reflect.DeepEqual(existing.Spec.Replicas, deploy.Spec.Replicas))
+		updateTerminationGracePeriod(&existing.Spec.Template.Spec, podSpec, &deploymentsDifferent)
+		updateDNSConfig(&existing.Spec.Template.Spec, podSpec, &deploymentsDifferent)
+		updatePodMetadata(&existing.Spec.Template, &deploy.Spec.Template, &deploymentsDifferent)
 
 		// If the Deployment already exists, make sure the values we care about are up-to-date
 		if deploymentsDifferent {
 			existing.Spec.Template.Spec.Containers = podSpec.Containers
 			existing.Spec.Template.Spec.Volumes = podSpec.Volumes
+			existing.Spec.Template.Spec.InitContainers = podSpec.InitContainers
 			existing.Spec.Template.Spec.ServiceAccountName = podSpec.ServiceAccountName
 			existing.Labels = deploy.Labels
 			existing.Spec.Template.Labels = deploy.Spec.Template.Labels
 			existing.Spec.Selector = deploy.Spec.Selector
 			existing.Spec.Template.Spec.NodeSelector = deploy.Spec.Template.Spec.NodeSelector
 			existing.Spec.Template.Spec.Tolerations = deploy.Spec.Template.Spec.Tolerations
+			existing.Spec.Template.Spec.SecurityContext = podSpec.SecurityContext
+			if deploy.Spec.Replicas != nil {
+				existing.Spec.Replicas = deploy.Spec.Replicas
+			}
 			return r.Client.Update(context.TODO(), existing)
 		}
 		return nil // Deployment found with nothing to do, move along...
@@ -306,6 +350,8 @@ func (r *ReconcileArgoCD) applicationSetContainer(cr *argoproj.ArgoCD, addSCMGit
 	appSetEnv = argoutil.EnvMerge(cr.Spec.ApplicationSet.Env, appSetEnv, true)
 	// Environment specified in the CR take precedence over everything else
 	appSetEnv = argoutil.EnvMerge(appSetEnv, proxyEnvVars(), false)
+	appSetEnv = argoutil.EnvMerge(appSetEnv, getOTelResourceAttributesEnv(cr), false)
+	appSetEnv = argoutil.EnvMerge(appSetEnv, getCryptoPolicyEnv(cr), false)
 
 	container := corev1.Container{
 		Command:         r.getArgoApplicationSetCommand(cr),
@@ -363,6 +409,7 @@ func (r *ReconcileArgoCD) applicationSetContainer(cr *argoproj.ArgoCD, addSCMGit
 			MountPath: ApplicationSetGitlabSCMTlsMountPath,
 		})
 	}
+	container.VolumeMounts = append(container.VolumeMounts, cr.Spec.ApplicationSet.VolumeMounts...)
 	return container
 }
 
@@ -391,6 +438,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetServiceAccount(cr *argoproj.Arg
 		return sa, nil
 	}
 
+	changed := applyServiceAccountAnnotations(sa, getServiceAccountAnnotations(cr, common.ArgoCDApplicationSetControllerComponent))
 	if !exists {
 		if err := controllerutil.SetControllerReference(cr, sa, r.Scheme); err != nil {
 			return sa, err
@@ -400,6 +448,10 @@ func (r *ReconcileArgoCD) reconcileApplicationSetServiceAccount(cr *argoproj.Arg
 		if err != nil {
 			return sa, err
 		}
+	} else if changed {
+		if err := r.Client.Update(context.TODO(), sa); err != nil {
+			return sa, err
+		}
 	}
 
 	return sa, nil
@@ -539,6 +591,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetClusterRoleBinding(cr *argoproj
 
 	// if subj differ, update the rolebinding
 	if !reflect.DeepEqual(existingClusterRB.Subjects, clusterRB.Subjects) {
+		logStaleSubjects(existingClusterRB.Name, existingClusterRB.Subjects, cr.Namespace)
 		existingClusterRB.Subjects = clusterRB.Subjects
 		if err := r.Client.Update(context.TODO(), existingClusterRB); err != nil {
 			return err
@@ -572,7 +625,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetSourceNamespacesResources(cr *a
 			continue
 		}
 		if !contains(appsNamespaces, sourceNamespace) {
-			log.Error(fmt.Errorf("skipping reconciliation of resources for sourceNamespace %s as Apps in target sourceNamespace is not enabled", sourceNamespace), "Warning")
+			componentLog(cr, logComponentApplicationSet).Error(fmt.Errorf("skipping reconciliation of resources for sourceNamespace %s as Apps in target sourceNamespace is not enabled", sourceNamespace), "Warning")
 			continue
 		}
 
@@ -588,18 +641,18 @@ func (r *ReconcileArgoCD) reconcileApplicationSetSourceNamespacesResources(cr *a
 		// i.e, only one of either managed-by or applicationset-managed-by-cluster-argocd labels can be applied to a given namespace.
 		// Since appset-in-any-ns is in beta, we prioritize managed-by label in case of a conflict.
 		if value, ok := namespace.Labels[common.ArgoCDManagedByLabel]; ok && value != "" {
-			log.Info(fmt.Sprintf("Skipping reconciling resources for namespace %s as it is already managed-by namespace %s.", namespace.Name, value))
+			componentLog(cr, logComponentApplicationSet).Info(fmt.Sprintf("Skipping reconciling resources for namespace %s as it is already managed-by namespace %s.", namespace.Name, value))
 			// remove any source namespace resources
 			if val, ok1 := namespace.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel]; ok1 && val != cr.Namespace {
 				delete(r.ManagedApplicationSetSourceNamespaces, namespace.Name)
 				if err := r.cleanupUnmanagedApplicationSetSourceNamespaceResources(cr, namespace.Name); err != nil {
-					log.Error(err, fmt.Sprintf("error cleaning up resources for namespace %s", namespace.Name))
+					componentLog(cr, logComponentApplicationSet).Error(err, fmt.Sprintf("error cleaning up resources for namespace %s", namespace.Name))
 				}
 			}
 			continue
 		}
 
-		log.Info(fmt.Sprintf("Reconciling applicationset resources for %s", namespace.Name))
+		componentLog(cr, logComponentApplicationSet).Info(fmt.Sprintf("Reconciling applicationset resources for %s", namespace.Name))
 		// add applicationset-managed-by-cluster-argocd label on namespace
 		if _, ok := namespace.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel]; !ok {
 			// Get the latest value of namespace before updating it
@@ -612,7 +665,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetSourceNamespacesResources(cr *a
 			}
 			namespace.Labels[common.ArgoCDApplicationSetManagedByClusterArgoCDLabel] = cr.Namespace
 			if err := r.Client.Update(context.TODO(), namespace); err != nil {
-				log.Error(err, fmt.Sprintf("failed to add label from namespace [%s]", namespace.Name))
+				componentLog(cr, logComponentApplicationSet).Error(err, fmt.Sprintf("failed to add label from namespace [%s]", namespace.Name))
 			}
 		}
 
@@ -758,6 +811,10 @@ func (r *ReconcileArgoCD) reconcileApplicationSetRoleBinding(cr *argoproj.ArgoCD
 }
 
 func getApplicationSetContainerImage(cr *argoproj.ArgoCD) string {
+	if img := getImageOverride(cr, argoImageComponent); img != "" && (cr.Spec.ApplicationSet == nil || cr.Spec.ApplicationSet.Image == "") {
+		return img
+	}
+
 	defaultImg, defaultTag := false, false
 
 	img := ""
@@ -786,6 +843,15 @@ func getApplicationSetContainerImage(cr *argoproj.ArgoCD) string {
 	return argoutil.CombineImageTag(img, tag)
 }
 
+// getApplicationSetReplicas will return the replica count for the ApplicationSet controller if it has
+// been set in the ArgoCD CR. Otherwise, nil is returned.
+func getApplicationSetReplicas(cr *argoproj.ArgoCD) *int32 {
+	if cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.Replicas != nil && *cr.Spec.ApplicationSet.Replicas >= 0 {
+		return cr.Spec.ApplicationSet.Replicas
+	}
+	return nil
+}
+
 // getApplicationSetResources will return the ResourceRequirements for the Application Sets container.
 func getApplicationSetResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
 	resources := corev1.ResourceRequirements{}
@@ -806,7 +872,7 @@ func setAppSetLabels(obj *metav1.ObjectMeta) {
 
 // reconcileApplicationSetService will ensure that the Service is present for the ApplicationSet webhook and metrics component.
 func (r *ReconcileArgoCD) reconcileApplicationSetService(cr *argoproj.ArgoCD) error {
-	log.Info("reconciling applicationset service")
+	componentLog(cr, logComponentApplicationSet).Info("reconciling applicationset service")
 
 	svc := newServiceWithSuffix(common.ApplicationSetServiceNameSuffix, common.ApplicationSetServiceNameSuffix, cr)
 	if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.IsEnabled() {
@@ -816,7 +882,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetService(cr *argoproj.ArgoCD) er
 			if err != nil {
 				return err
 			}
-			log.Info(fmt.Sprintf("Deleting applicationset controller service %s as applicationset is disabled", svc.Name))
+			componentLog(cr, logComponentApplicationSet).Info(fmt.Sprintf("Deleting applicationset controller service %s as applicationset is disabled", svc.Name))
 			err = r.Delete(context.TODO(), svc)
 			if err != nil {
 				return err
@@ -825,15 +891,26 @@ func (r *ReconcileArgoCD) reconcileApplicationSetService(cr *argoproj.ArgoCD) er
 		return nil
 	} else {
 		if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-			return nil // Service found, do nothing
+			changed := ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDApplicationSetWebhookTLSSecretName, wantsApplicationSetWebhookAutoTLS(cr))
+			for i := range svc.Spec.Ports {
+				if svc.Spec.Ports[i].Name == "webhook" && svc.Spec.Ports[i].TargetPort != getApplicationSetWebhookTargetPort(cr) {
+					svc.Spec.Ports[i].TargetPort = getApplicationSetWebhookTargetPort(cr)
+					changed = true
+				}
+			}
+			if changed {
+				return r.Client.Update(context.TODO(), svc)
+			}
+			return nil // Service found with nothing to do, move along...
 		}
 	}
+	ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDApplicationSetWebhookTLSSecretName, wantsApplicationSetWebhookAutoTLS(cr))
 	svc.Spec.Ports = []corev1.ServicePort{
 		{
 			Name:       "webhook",
-			Port:       7000,
+			Port:       applicationSetWebhookPort,
 			Protocol:   corev1.ProtocolTCP,
-			TargetPort: intstr.FromInt(7000),
+			TargetPort: getApplicationSetWebhookTargetPort(cr),
 		}, {
 			Name:       "metrics",
 			Port:       8080,
@@ -879,7 +956,7 @@ func (r *ReconcileArgoCD) removeUnmanagedApplicationSetSourceNamespaceResources(
 
 		if !managedNamespace {
 			if err := r.cleanupUnmanagedApplicationSetSourceNamespaceResources(cr, ns); err != nil {
-				log.Error(err, fmt.Sprintf("error cleaning up applicationset resources for namespace %s", ns))
+				componentLog(cr, logComponentApplicationSet).Error(err, fmt.Sprintf("error cleaning up applicationset resources for namespace %s", ns))
 				continue
 			}
 			delete(r.ManagedApplicationSetSourceNamespaces, ns)
@@ -980,7 +1057,7 @@ func (r *ReconcileArgoCD) reconcileSourceNamespaceRole(role v1.Role, cr *argopro
 			return errors.Join(errMsg, err)
 		}
 
-		log.Info(fmt.Sprintf("role %s created successfully for Argo CD instance %s in namespace %s", role.Name, cr.Name, role.Namespace))
+		componentLog(cr, logComponentApplicationSet).Info(fmt.Sprintf("role %s created successfully for Argo CD instance %s in namespace %s", role.Name, cr.Name, role.Namespace))
 		return nil
 	}
 
@@ -991,7 +1068,7 @@ func (r *ReconcileArgoCD) reconcileSourceNamespaceRole(role v1.Role, cr *argopro
 			errMsg := fmt.Errorf("failed to update role %s in namespace %s", role.Name, role.Namespace)
 			return errors.Join(errMsg, err)
 		}
-		log.Info(fmt.Sprintf("role %s update successfully for Argo CD instance %s in namespace %s", role.Name, cr.Name, role.Namespace))
+		componentLog(cr, logComponentApplicationSet).Info(fmt.Sprintf("role %s update successfully for Argo CD instance %s in namespace %s", role.Name, cr.Name, role.Namespace))
 	}
 
 	return nil
@@ -1017,7 +1094,7 @@ func (r *ReconcileArgoCD) reconcileSourceNamespaceRoleBinding(roleBinding v1.Rol
 			return errors.Join(errMsg, err)
 		}
 
-		log.Info(fmt.Sprintf("rolebinding %s created successfully for Argo CD instance %s in namespace %s", roleBinding.Name, cr.Name, roleBinding.Namespace))
+		componentLog(cr, logComponentApplicationSet).Info(fmt.Sprintf("rolebinding %s created successfully for Argo CD instance %s in namespace %s", roleBinding.Name, cr.Name, roleBinding.Namespace))
 		return nil
 	}
 
@@ -1033,7 +1110,7 @@ func (r *ReconcileArgoCD) reconcileSourceNamespaceRoleBinding(roleBinding v1.Rol
 			if err = r.Client.Update(context.TODO(), &existingRoleBinding); err != nil {
 				return err
 			}
-			log.Info(fmt.Sprintf("rolebinding %s update successfully for Argo CD instance %s in namespace %s", roleBinding.Name, cr.Name, roleBinding.Namespace))
+			componentLog(cr, logComponentApplicationSet).Info(fmt.Sprintf("rolebinding %s update successfully for Argo CD instance %s in namespace %s", roleBinding.Name, cr.Name, roleBinding.Namespace))
 		}
 	}
 
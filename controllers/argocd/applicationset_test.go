@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	routev1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -29,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -338,6 +340,86 @@ func TestReconcileApplicationSet_Deployments_resourceRequirements(t *testing.T)
 	}
 }
 
+func TestReconcileApplicationSet_Deployments_extraContainers(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+			InitContainers:    []corev1.Container{{Name: "plugin-init", Image: "plugin:latest"}},
+			SidecarContainers: []corev1.Container{{Name: "plugin", Image: "plugin:latest"}},
+			Volumes:           []corev1.Volume{{Name: "plugin-socket", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+			VolumeMounts:      []corev1.VolumeMount{{Name: "plugin-socket", MountPath: "/plugin"}},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	sa := corev1.ServiceAccount{}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, a.Spec.ApplicationSet.InitContainers, podSpec.InitContainers)
+	assert.Len(t, podSpec.Containers, 2)
+	assert.Equal(t, "argocd-applicationset-controller", podSpec.Containers[0].Name)
+	assert.Contains(t, podSpec.Containers[0].VolumeMounts, a.Spec.ApplicationSet.VolumeMounts[0])
+	assert.Equal(t, a.Spec.ApplicationSet.SidecarContainers[0], podSpec.Containers[1])
+	assert.Equal(t, append(applicationSetDefaultVolumes(), a.Spec.ApplicationSet.Volumes...), podSpec.Volumes)
+
+	// The extra containers are removed from an existing Deployment
+	a.Spec.ApplicationSet.InitContainers = nil
+	a.Spec.ApplicationSet.SidecarContainers = nil
+	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Empty(t, deployment.Spec.Template.Spec.InitContainers)
+	assert.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+}
+
+func TestReconcileApplicationSet_Deployments_replicas(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	sa := corev1.ServiceAccount{}
+	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Nil(t, deployment.Spec.Replicas)
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].Command, "--enable-leader-election")
+
+	// Multiple replicas enable leader election
+	replicas := int32(2)
+	a.Spec.ApplicationSet.Replicas = &replicas
+	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, replicas, *deployment.Spec.Replicas)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Command, "--enable-leader-election")
+
+	// A single replica does not need leader election
+	replicas = int32(1)
+	a.Spec.ApplicationSet.Replicas = &replicas
+	assert.NoError(t, r.reconcileApplicationSetDeployment(a, &sa))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, replicas, *deployment.Spec.Replicas)
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].Command, "--enable-leader-election")
+}
+
 func TestReconcileApplicationSet_Deployments_SpecOverride(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 
@@ -888,6 +970,51 @@ func TestReconcileApplicationSet_Service(t *testing.T) {
 	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, s))
 }
 
+func TestReconcileApplicationSet_WebhookAutoTLS(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	routeAPIFound = true
+	defer func() { routeAPIFound = false }()
+
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	s := newServiceWithSuffix(common.ApplicationSetServiceNameSuffix, common.ApplicationSetServiceNameSuffix, a)
+	assert.NoError(t, r.reconcileApplicationSetService(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, s))
+	assert.NotContains(t, s.Annotations, common.AnnotationOpenShiftServiceCA)
+	assert.Equal(t, intstr.FromInt(applicationSetWebhookPort), s.Spec.Ports[0].TargetPort)
+
+	// A reencrypt Route makes the webhook server serve a certificate of the service CA through the TLS proxy
+	a.Spec.ApplicationSet.WebhookServer.Route = argoproj.ArgoCDRouteSpec{
+		Enabled: true,
+		TLS:     &routev1.TLSConfig{Termination: routev1.TLSTerminationReencrypt},
+	}
+	assert.NoError(t, r.reconcileApplicationSetService(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, s))
+	assert.Equal(t, common.ArgoCDApplicationSetWebhookTLSSecretName, s.Annotations[common.AnnotationOpenShiftServiceCA])
+	assert.Equal(t, intstr.FromInt(applicationSetWebhookTLSPort), s.Spec.Ports[0].TargetPort)
+
+	podSpec := &corev1.PodSpec{}
+	applyApplicationSetWebhookTLS(a, podSpec)
+	assert.Len(t, podSpec.Containers, 1)
+	assert.Equal(t, applicationSetWebhookTLSName, podSpec.Containers[0].Name)
+	assert.Equal(t, common.ArgoCDApplicationSetWebhookTLSSecretName, podSpec.Volumes[0].Secret.SecretName)
+
+	// Back to edge termination, the webhook server serves plain HTTP again
+	a.Spec.ApplicationSet.WebhookServer.Route.TLS = nil
+	assert.NoError(t, r.reconcileApplicationSetService(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, s))
+	assert.NotContains(t, s.Annotations, common.AnnotationOpenShiftServiceCA)
+	assert.Equal(t, intstr.FromInt(applicationSetWebhookPort), s.Spec.Ports[0].TargetPort)
+}
+
 func TestArgoCDApplicationSetCommand(t *testing.T) {
 	a := makeTestArgoCD()
 	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
@@ -981,6 +1108,40 @@ func TestArgoCDApplicationSetCommand(t *testing.T) {
 	assert.Equal(t, baseCommand, deployment.Spec.Template.Spec.Containers[0].Command)
 }
 
+func TestArgoCDApplicationSetCommand_policyFlags(t *testing.T) {
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+		Policy:                 argoproj.ArgoCDApplicationSetPolicyCreateUpdate,
+		EnableProgressiveSyncs: true,
+		DryRun:                 true,
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	cmd := r.getArgoApplicationSetCommand(a)
+	assert.Equal(t, []string{
+		"entrypoint.sh",
+		"argocd-applicationset-controller",
+		"--argocd-repo-server",
+		"argocd-repo-server.argocd.svc.cluster.local:8081",
+		"--loglevel",
+		"info",
+		"--policy",
+		"create-update",
+		"--enable-progressive-syncs",
+		"--dry-run",
+	}, cmd)
+
+	// The typed fields take precedence over the same flags in the extra arguments
+	a.Spec.ApplicationSet.ExtraCommandArgs = []string{"--policy", "sync"}
+	assert.Equal(t, cmd, r.getArgoApplicationSetCommand(a))
+}
+
 func TestArgoCDApplicationSetEnv(t *testing.T) {
 	a := makeTestArgoCD()
 	a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
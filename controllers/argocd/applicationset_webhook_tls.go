@@ -0,0 +1,127 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	// applicationSetWebhookPort is the port of the plain HTTP webhook server of the ApplicationSet controller.
+	applicationSetWebhookPort = 7000
+
+	// applicationSetWebhookTLSPort is the port the TLS proxy of the ApplicationSet webhook listens on.
+	applicationSetWebhookTLSPort = 7443
+
+	// applicationSetWebhookTLSName is the name of the TLS proxy container of the ApplicationSet webhook and of the
+	// volume holding its serving certificate.
+	applicationSetWebhookTLSName = "webhook-tls"
+
+	// applicationSetWebhookTLSPath is the path the serving certificate of the ApplicationSet webhook is mounted on.
+	applicationSetWebhookTLSPath = "/app/config/applicationset/webhook-tls"
+)
+
+// applicationSetWebhookTLSScript writes the configuration of the TLS proxy of the ApplicationSet webhook and runs
+// HAProxy with it. The key is loaded from the file named after the certificate with the ".key" suffix.
+var applicationSetWebhookTLSScript = fmt.Sprintf(`cat > /tmp/webhook-tls-haproxy.cfg <<EOF
+global
+  maxconn 1024
+defaults
+  mode http
+  timeout connect 5s
+  timeout client 60s
+  timeout server 60s
+frontend webhook
+  bind :%d ssl crt %s/tls.crt
+  default_backend applicationset
+backend applicationset
+  server applicationset 127.0.0.1:%d
+EOF
+exec haproxy -W -db -f /tmp/webhook-tls-haproxy.cfg`, applicationSetWebhookTLSPort, applicationSetWebhookTLSPath, applicationSetWebhookPort)
+
+// wantsApplicationSetWebhookAutoTLS returns true if the webhook server of the ApplicationSet controller of the given
+// ArgoCD must serve TLS with a certificate of the OpenShift service CA.
+func wantsApplicationSetWebhookAutoTLS(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.WebhookServer.WantsAutoTLS() && IsRouteAPIAvailable()
+}
+
+// getApplicationSetWebhookTargetPort will return the port of the ApplicationSet controller pods the webhook Service
+// of the given ArgoCD targets, the one of the TLS proxy when the webhook server serves TLS.
+func getApplicationSetWebhookTargetPort(cr *argoproj.ArgoCD) intstr.IntOrString {
+	if wantsApplicationSetWebhookAutoTLS(cr) {
+		return intstr.FromInt(applicationSetWebhookTLSPort)
+	}
+	return intstr.FromInt(applicationSetWebhookPort)
+}
+
+// applyApplicationSetWebhookTLS will add the TLS proxy of the ApplicationSet webhook and its serving certificate to the
+// given pod spec, when the webhook server of the given ArgoCD serves TLS. The proxy terminates TLS with the
+// certificate issued by the OpenShift service CA, which the router trusts when reencrypting the webhook Route.
+func applyApplicationSetWebhookTLS(cr *argoproj.ArgoCD, podSpec *corev1.PodSpec) {
+	if !wantsApplicationSetWebhookAutoTLS(cr) {
+		return
+	}
+	podSpec.Containers = append(podSpec.Containers, corev1.Container{
+		Name:            applicationSetWebhookTLSName,
+		Image:           getRedisHAProxyContainerImage(cr),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"sh", "-c", applicationSetWebhookTLSScript},
+		Ports: []corev1.ContainerPort{
+			{
+				ContainerPort: applicationSetWebhookTLSPort,
+				Name:          applicationSetWebhookTLSName,
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{
+					"ALL",
+				},
+			},
+			ReadOnlyRootFilesystem: boolPtr(true),
+			RunAsNonRoot:           boolPtr(true),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      applicationSetWebhookTLSName,
+				MountPath: applicationSetWebhookTLSPath,
+				ReadOnly:  true,
+			},
+			{
+				Name:      "tmp",
+				MountPath: "/tmp",
+			},
+		},
+	})
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: applicationSetWebhookTLSName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: common.ArgoCDApplicationSetWebhookTLSSecretName,
+				Items: []corev1.KeyToPath{
+					{Key: corev1.TLSCertKey, Path: "tls.crt"},
+					{Key: corev1.TLSPrivateKeyKey, Path: "tls.crt.key"},
+				},
+			},
+		},
+	})
+}
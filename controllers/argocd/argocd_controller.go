@@ -19,19 +19,23 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	logr "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -51,6 +55,10 @@ type ReconcileArgoCD struct {
 	ManagedApplicationSetSourceNamespaces map[string]string
 	// Stores label selector used to reconcile a subset of ArgoCD
 	LabelSelector string
+	// Options of the controller, e.g. the number of concurrent reconciles and the rate limiter
+	ControllerOptions controller.Options
+	// Records the Events reporting the resources created, updated and deleted on the ArgoCD instances
+	Recorder record.EventRecorder
 }
 
 var log = logr.Log.WithName("controller_argocd")
@@ -61,6 +69,9 @@ var log = logr.Log.WithName("controller_argocd")
 // as, having multiple Argo CD instances in the same namespace is considered an anti-pattern
 var ActiveInstanceMap = make(map[string]string)
 
+// activeInstanceMapLock guards ActiveInstanceMap, as ArgoCD instances may be reconciled concurrently.
+var activeInstanceMapLock sync.Mutex
+
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=*
 //+kubebuilder:rbac:groups="",resources=configmaps;endpoints;events;persistentvolumeclaims;pods;namespaces;secrets;serviceaccounts;services;services/finalizers,verbs=*
 //+kubebuilder:rbac:groups=apps.openshift.io,resources=deploymentconfigs,verbs=*
@@ -68,6 +79,8 @@ var ActiveInstanceMap = make(map[string]string)
 //+kubebuilder:rbac:groups=apps,resourceNames=argocd-operator,resources=deployments/finalizers,verbs=update
 //+kubebuilder:rbac:groups=argoproj.io,resources=argocds;argocds/finalizers;argocds/status,verbs=*
 //+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=*
+//+kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=*
+//+kubebuilder:rbac:groups=console.openshift.io,resources=consolelinks,verbs=*
 //+kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=*
 //+kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=*
@@ -97,6 +110,16 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		ReconcileTime.WithLabelValues(request.Namespace).Observe(time.Since(reconcileStartTS).Seconds())
 	}()
 
+	// The managed namespaces are tracked per ArgoCD instance, work on a copy of the reconciler so that they are not
+	// shared between concurrent reconciles.
+	r = &ReconcileArgoCD{
+		Client:            r.Client,
+		Scheme:            r.Scheme,
+		LabelSelector:     r.LabelSelector,
+		ControllerOptions: r.ControllerOptions,
+		Recorder:          r.Recorder,
+	}
+
 	reqLogger := logr.FromContext(ctx, "namespace", request.Namespace, "name", request.Name)
 	reqLogger.Info("Reconciling ArgoCD")
 
@@ -107,12 +130,19 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			forgetInstanceLog(request.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
 
+	// Name the instance in every line logged while reconciling it
+	setInstanceLog(argocd)
+
+	// Report the changes made to the resources of the instance as Events on the instance
+	r.Client = newEventClient(r.Client, r.Recorder, argocd)
+
 	// Fetch labelSelector from r.LabelSelector (command-line option)
 	labelSelector, err := labels.Parse(r.LabelSelector)
 	if err != nil {
@@ -126,6 +156,7 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 	}
 
 	newPhase := argocd.Status.Phase
+	activeInstanceMapLock.Lock()
 	// If we discover a new Argo CD instance in a previously un-seen namespace
 	// we add it to the map and increment active instance count by phase
 	// as well as total active instance count
@@ -146,45 +177,36 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 			ActiveInstancesByPhase.WithLabelValues(oldPhase).Dec()
 		}
 	}
+	activeInstanceMapLock.Unlock()
 
 	ActiveInstanceReconciliationCount.WithLabelValues(argocd.Namespace).Inc()
 
+	if err = r.setManagedNamespaces(argocd); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err = r.setManagedSourceNamespaces(argocd); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err = r.setManagedApplicationSetSourceNamespaces(argocd); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if argocd.GetDeletionTimestamp() != nil {
 
 		// Argo CD instance marked for deletion; remove entry from activeInstances map and decrement active instance count
 		// by phase as well as total
+		activeInstanceMapLock.Lock()
 		delete(ActiveInstanceMap, argocd.Namespace)
+		activeInstanceMapLock.Unlock()
 		ActiveInstancesByPhase.WithLabelValues(newPhase).Dec()
 		ActiveInstancesTotal.Dec()
 		ActiveInstanceReconciliationCount.DeleteLabelValues(argocd.Namespace)
 		ReconcileTime.DeletePartialMatch(prometheus.Labels{"namespace": argocd.Namespace})
 
 		if argocd.IsDeletionFinalizerPresent() {
-			if err := r.deleteClusterResources(argocd); err != nil {
-				return reconcile.Result{}, fmt.Errorf("failed to delete ClusterResources: %w", err)
-			}
-
-			if isRemoveManagedByLabelOnArgoCDDeletion() {
-				if err := r.removeManagedByLabelFromNamespaces(argocd.Namespace); err != nil {
-					return reconcile.Result{}, fmt.Errorf("failed to remove label from namespace[%v], error: %w", argocd.Namespace, err)
-				}
-			}
-
-			if err := r.removeUnmanagedSourceNamespaceResources(argocd); err != nil {
-				return reconcile.Result{}, fmt.Errorf("failed to remove resources from sourceNamespaces, error: %w", err)
-			}
-
-			if err := r.removeUnmanagedApplicationSetSourceNamespaceResources(argocd); err != nil {
-				return reconcile.Result{}, fmt.Errorf("failed to remove resources from applicationSetSourceNamespaces, error: %w", err)
-			}
-
-			if err := r.removeDeletionFinalizer(argocd); err != nil {
-				return reconcile.Result{}, err
-			}
-
-			// remove namespace of deleted Argo CD instance from deprecationEventEmissionTracker (if exists) so that if another instance
-			// is created in the same namespace in the future, that instance is appropriately tracked
-			delete(DeprecationEventEmissionTracker, argocd.Namespace)
+			return r.finalizeArgoCD(argocd)
 		}
 		return reconcile.Result{}, nil
 	}
@@ -200,16 +222,52 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		return reconcile.Result{}, err
 	}
 
-	if err = r.setManagedNamespaces(argocd); err != nil {
+	if err = r.migrateSSOToExternalOIDC(argocd); err != nil {
 		return reconcile.Result{}, err
 	}
 
-	if err = r.setManagedSourceNamespaces(argocd); err != nil {
+	// reconcile with the defaults of this operator rather than the ones recorded by the defaulting webhook
+	clearDefaultedFields(argocd)
+
+	if err = r.applyInheritedSpec(argocd); err != nil {
+		reqLogger.Error(err, "failed to inherit the spec of the ArgoCD instance")
 		return reconcile.Result{}, err
 	}
 
-	if err = r.setManagedApplicationSetSourceNamespaces(argocd); err != nil {
+	if err = r.reconcileStatusPaused(argocd); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if argocd.Spec.Paused {
+		// Reconciliation paused by the user, leave the resources of the instance untouched
+		reqLogger.Info("reconciliation of the ArgoCD instance is paused, skipping")
+		return reconcile.Result{}, nil
+	}
+
+	if err = r.reconcileDeprecatedFields(argocd); err != nil {
+		reqLogger.Error(err, "failed to report the deprecated fields of the ArgoCD instance")
+	}
+
+	if err = r.reconcileResourceBudget(argocd); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if isDryRunRequested(argocd) {
+		// Record the changes the reconciliation would make for review, leaving the resources of the instance untouched
+		reqLogger.Info("dry run of the ArgoCD instance requested, recording the changes instead of applying them")
+		return reconcile.Result{}, r.reconcileDryRun(argocd)
+	}
+
+	if err = r.reconcileDryRunDisabled(argocd); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if pending, err := r.reconcileAdoption(argocd); err != nil {
 		return reconcile.Result{}, err
+	} else if len(pending) > 0 {
+		// Leave the existing resources that are not adopted yet untouched, reconciling the other resources
+		reqLogger.Info(fmt.Sprintf("%d existing resources of the ArgoCD instance not adopted yet, leaving them untouched", len(pending)))
+		r.Client = newAdoptionGuardClient(r.Client, pending)
 	}
 
 	if err := r.reconcileResources(argocd); err != nil {
@@ -217,13 +275,36 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		return reconcile.Result{}, err
 	}
 
-	// Return and don't requeue
-	return reconcile.Result{}, nil
+	// Requeue to resync the instance, refresh the SSH known hosts, rotate the admin password, autoscale the server and
+	// adopt the next existing resources periodically, if requested
+	var requeueAfter time.Duration
+	if argocd.Spec.ResyncPeriod != nil && argocd.Spec.ResyncPeriod.Duration > 0 {
+		requeueAfter = argocd.Spec.ResyncPeriod.Duration
+		if requeueAfter < common.ArgoCDDefaultResyncPeriodMinimum {
+			requeueAfter = common.ArgoCDDefaultResyncPeriodMinimum
+		}
+	}
+	if interval := argocd.Spec.InitialSSHKnownHosts.RefreshInterval; interval != nil && interval.Duration > 0 && (requeueAfter == 0 || interval.Duration < requeueAfter) {
+		requeueAfter = interval.Duration
+	}
+	if delay := r.getAdminPasswordRotationDelay(argocd); delay > 0 && (requeueAfter == 0 || delay < requeueAfter) {
+		requeueAfter = delay
+	}
+	if delay := getSSOLivecheckDelay(argocd); delay > 0 && (requeueAfter == 0 || delay < requeueAfter) {
+		requeueAfter = delay
+	}
+	if delay := getServerAutoscaleDelay(argocd); delay > 0 && (requeueAfter == 0 || delay < requeueAfter) {
+		requeueAfter = delay
+	}
+	if delay := getAdoptionDelay(argocd); delay > 0 && (requeueAfter == 0 || delay < requeueAfter) {
+		requeueAfter = delay
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReconcileArgoCD) SetupWithManager(mgr ctrl.Manager) error {
-	bldr := ctrl.NewControllerManagedBy(mgr)
-	r.setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper, r.clusterSecretResourceMapper, r.applicationSetSCMTLSConfigMapMapper)
+	bldr := ctrl.NewControllerManagedBy(mgr).WithOptions(r.ControllerOptions)
+	r.setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper, r.clusterSecretResourceMapper, r.applicationSetSCMTLSConfigMapMapper, r.inheritFromMapper)
 	return bldr.Complete(r)
 }
@@ -17,6 +17,8 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -27,6 +29,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -114,6 +117,251 @@ func TestReconcileArgoCD_Reconcile(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_Reconcile_resyncPeriod(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ResyncPeriod = &metav1.Duration{Duration: 30 * time.Second}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      a.Name,
+			Namespace: a.Namespace,
+		},
+	}
+
+	// The instance is requeued after its resync period
+	res, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, res.RequeueAfter)
+
+	// A period shorter than the minimum is raised to the minimum
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	a.Spec.ResyncPeriod = &metav1.Duration{Duration: time.Second}
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	res, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, res.RequeueAfter)
+
+	// A shorter interval of another periodic task takes precedence
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	a.Spec.InitialSSHKnownHosts.RefreshInterval = &metav1.Duration{Duration: 10 * time.Second}
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+
+	res, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, res.RequeueAfter)
+}
+
+func TestReconcileArgoCD_Reconcile_concurrent(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	b := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Namespace = "argocd-2"
+	})
+
+	resObjs := []client.Object{a, b}
+	subresObjs := []client.Object{a, b}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	assert.NoError(t, createNamespace(r, b.Namespace, ""))
+	managedNamespaces := r.ManagedNamespaces.DeepCopy()
+
+	var wg sync.WaitGroup
+	for _, cr := range []*argoproj.ArgoCD{a, b} {
+		wg.Add(1)
+		go func(cr *argoproj.ArgoCD) {
+			defer wg.Done()
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace},
+			})
+			assert.NoError(t, err)
+		}(cr)
+	}
+	wg.Wait()
+
+	// The managed namespaces of each instance are tracked per reconcile, not on the shared reconciler
+	assert.Equal(t, managedNamespaces, r.ManagedNamespaces)
+
+	for _, cr := range []*argoproj.ArgoCD{a, b} {
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+			Name:      "argocd-redis",
+			Namespace: cr.Namespace,
+		}, deployment))
+	}
+}
+
+func TestReconcileArgoCD_Reconcile_paused(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Paused = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      a.Name,
+			Namespace: a.Namespace,
+		},
+	}
+
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	// No resources are reconciled while paused
+	deployment := &appsv1.Deployment{}
+	assert.True(t, apierrors.IsNotFound(r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-redis",
+		Namespace: testNamespace,
+	}, deployment)))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypePaused)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonPaused, condition.Reason)
+
+	// Resuming reconciles the resources again
+	a.Spec.Paused = false
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-redis",
+		Namespace: testNamespace,
+	}, deployment))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypePaused)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonResumed, condition.Reason)
+}
+
+func TestReconcileArgoCD_Reconcile_dryRun(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Annotations = map[string]string{common.ArgoCDDryRunAnnotation: "true"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      a.Name,
+			Namespace: a.Namespace,
+		},
+	}
+
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	// The resources are not created, only recorded
+	deployment := &appsv1.Deployment{}
+	assert.True(t, apierrors.IsNotFound(r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-redis",
+		Namespace: testNamespace,
+	}, deployment)))
+
+	cm := &corev1.ConfigMap{}
+	cmKey := types.NamespacedName{Name: "argocd-dry-run", Namespace: testNamespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), cmKey, cm))
+	assert.NotContains(t, cm.Data, common.ArgoCDKeyDryRunError)
+	assert.Contains(t, strings.Split(cm.Data[common.ArgoCDKeyDryRunChanges], "\n"), "create Deployment argocd/argocd-redis")
+
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeDryRun)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+	// Removing the annotation applies the changes and removes the recorded ones
+	a.Annotations = nil
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-redis",
+		Namespace: testNamespace,
+	}, deployment))
+	assert.True(t, apierrors.IsNotFound(r.Client.Get(context.TODO(), cmKey, cm)))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), req.NamespacedName, a))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeDryRun)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonDryRunDisabled, condition.Reason)
+}
+
+func TestReconcileArgoCD_deferNamespaceCleanup(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Annotations = map[string]string{common.ArgoCDDryRunAnnotation: "true"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	// The cleanup of a namespace no longer managed by an instance in dry run is postponed
+	assert.False(t, r.deferNamespaceCleanup("other", "source"))
+	assert.True(t, r.deferNamespaceCleanup(a.Namespace, "source"))
+	assert.True(t, r.deferNamespaceCleanup(a.Namespace, "source"))
+
+	cm := &corev1.ConfigMap{}
+	cmKey := types.NamespacedName{Name: "argocd-dry-run", Namespace: testNamespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), cmKey, cm))
+	assert.Equal(t, "source", cm.Data[common.ArgoCDKeyDryRunPendingNamespaces])
+
+	// The postponed namespaces are kept by the dry run, which leaves the state of the reconciler untouched
+	r.ManagedSourceNamespaces = map[string]string{"source": ""}
+	dryRun := r.newDryRunReconciler(newDryRunRecorder(r.Client))
+	delete(dryRun.ManagedSourceNamespaces, "source")
+	assert.Contains(t, r.ManagedSourceNamespaces, "source")
+
+	assert.NoError(t, r.reconcileDryRun(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), cmKey, cm))
+	assert.Equal(t, "source", cm.Data[common.ArgoCDKeyDryRunPendingNamespaces])
+	assert.NotEmpty(t, cm.Data[common.ArgoCDKeyDryRunChanges])
+}
+
 func TestReconcileArgoCD_LabelSelector(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	//ctx := context.Background()
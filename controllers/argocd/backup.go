@@ -0,0 +1,142 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// isBackupLabelsEnabled returns true if the resources of the given ArgoCD are labeled for Velero backups.
+func isBackupLabelsEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.BackupLabels != nil && cr.Spec.BackupLabels.Enabled
+}
+
+// newBackupLabeledLists returns the lists of the kinds of namespaced resources created by the operator that are
+// labeled for Velero backups.
+func newBackupLabeledLists() []client.ObjectList {
+	return []client.ObjectList{
+		&corev1.ConfigMapList{},
+		&corev1.SecretList{},
+		&corev1.ServiceList{},
+		&corev1.ServiceAccountList{},
+		&appsv1.DeploymentList{},
+		&appsv1.StatefulSetList{},
+		&rbacv1.RoleList{},
+		&rbacv1.RoleBindingList{},
+		&networkingv1.IngressList{},
+	}
+}
+
+// applyBackupMetadata will add or remove the backup label and storage location annotation of the given object to
+// match the backup labels of the given ArgoCD. It returns true if the object was changed.
+func applyBackupMetadata(cr *argoproj.ArgoCD, obj client.Object) bool {
+	labels, annotations := obj.GetLabels(), obj.GetAnnotations()
+	changed := false
+
+	if isBackupLabelsEnabled(cr) {
+		if labels[common.ArgoCDBackupLabel] != "true" {
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels[common.ArgoCDBackupLabel] = "true"
+			changed = true
+		}
+	} else if _, ok := labels[common.ArgoCDBackupLabel]; ok {
+		delete(labels, common.ArgoCDBackupLabel)
+		changed = true
+	}
+
+	location := ""
+	if isBackupLabelsEnabled(cr) {
+		location = cr.Spec.BackupLabels.StorageLocation
+	}
+	if location != "" {
+		if annotations[common.ArgoCDBackupStorageLocationAnnotation] != location {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[common.ArgoCDBackupStorageLocationAnnotation] = location
+			changed = true
+		}
+	} else if _, ok := annotations[common.ArgoCDBackupStorageLocationAnnotation]; ok {
+		delete(annotations, common.ArgoCDBackupStorageLocationAnnotation)
+		changed = true
+	}
+
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+	return changed
+}
+
+// reconcileBackupLabels will label the resources of the given ArgoCD for Velero backups when its backup labels are
+// enabled, and remove the labels otherwise. Besides the resources created by the operator, the Secrets holding the
+// repositories and clusters added through Argo CD are labeled, as they are part of the state of the instance, and
+// so is the ArgoCD itself, as the restored resources are otherwise garbage collected for lack of their owner.
+func (r *ReconcileArgoCD) reconcileBackupLabels(cr *argoproj.ArgoCD) error {
+	selector, err := argocdInstanceSelector(argoutil.ManagedByLabelValue(cr))
+	if err != nil {
+		return err
+	}
+
+	lists := newBackupLabeledLists()
+	listOptions := make([][]client.ListOption, len(lists))
+	for i := range lists {
+		listOptions[i] = []client.ListOption{client.InNamespace(cr.Namespace), client.MatchingLabelsSelector{Selector: selector}}
+	}
+	lists = append(lists, &corev1.SecretList{})
+	listOptions = append(listOptions, []client.ListOption{client.InNamespace(cr.Namespace), client.HasLabels{common.ArgoCDSecretTypeLabel}})
+
+	for i, list := range lists {
+		if err := r.Client.List(context.TODO(), list, listOptions[i]...); err != nil {
+			return fmt.Errorf("failed to list the resources of ArgoCD %s/%s to label for backups: %w", cr.Namespace, cr.Name, err)
+		}
+		objs, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, o := range objs {
+			obj, ok := o.(client.Object)
+			if !ok || !applyBackupMetadata(cr, obj) {
+				continue
+			}
+			if err := r.Client.Update(context.TODO(), obj); err != nil {
+				return fmt.Errorf("failed to update the backup labels of %T %s/%s: %w", obj, obj.GetNamespace(), obj.GetName(), err)
+			}
+		}
+	}
+
+	// Only the metadata of the ArgoCD is patched, leaving the spec to its owner
+	labeled := cr.DeepCopy()
+	if !applyBackupMetadata(cr, labeled) {
+		return nil
+	}
+	if err := r.Client.Patch(context.TODO(), labeled, client.MergeFrom(cr)); err != nil {
+		return fmt.Errorf("failed to update the backup labels of ArgoCD %s/%s: %w", cr.Namespace, cr.Name, err)
+	}
+	cr.ObjectMeta = labeled.ObjectMeta
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileArgoCD_reconcileBackupLabels(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD()
+
+	// A repository added through Argo CD, and a Secret unrelated to the instance
+	repoSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "repo-example",
+			Namespace: a.Namespace,
+			Labels:    map[string]string{common.ArgoCDSecretTypeLabel: "repository"},
+		},
+	}
+	otherSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: a.Namespace},
+	}
+
+	resObjs := []client.Object{a, repoSecret, otherSecret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRBAC(a))
+
+	a.Spec.BackupLabels = &argoproj.ArgoCDBackupLabelsSpec{Enabled: true, StorageLocation: "default"}
+	assert.NoError(t, r.reconcileBackupLabels(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRBACConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, "true", cm.Labels[common.ArgoCDBackupLabel])
+	assert.Equal(t, "default", cm.Annotations[common.ArgoCDBackupStorageLocationAnnotation])
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: repoSecret.Name, Namespace: a.Namespace}, secret))
+	assert.Equal(t, "true", secret.Labels[common.ArgoCDBackupLabel])
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: otherSecret.Name, Namespace: a.Namespace}, secret))
+	assert.NotContains(t, secret.Labels, common.ArgoCDBackupLabel)
+
+	// The ArgoCD is labeled too, so that it is restored with its resources
+	argocd := &argoproj.ArgoCD{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, argocd))
+	assert.Equal(t, "true", argocd.Labels[common.ArgoCDBackupLabel])
+	assert.Equal(t, "default", argocd.Annotations[common.ArgoCDBackupStorageLocationAnnotation])
+	assert.Equal(t, argocd.ResourceVersion, a.ResourceVersion)
+
+	// Resources created while enabled are labeled from the start
+	assert.Equal(t, "true", newConfigMapWithName("example", a).Labels[common.ArgoCDBackupLabel])
+
+	a.Spec.BackupLabels = nil
+	assert.NoError(t, r.reconcileBackupLabels(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRBACConfigMapName, Namespace: a.Namespace}, cm))
+	assert.NotContains(t, cm.Labels, common.ArgoCDBackupLabel)
+	assert.NotContains(t, cm.Annotations, common.ArgoCDBackupStorageLocationAnnotation)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: repoSecret.Name, Namespace: a.Namespace}, secret))
+	assert.NotContains(t, secret.Labels, common.ArgoCDBackupLabel)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, argocd))
+	assert.NotContains(t, argocd.Labels, common.ArgoCDBackupLabel)
+	assert.NotContains(t, argocd.Annotations, common.ArgoCDBackupStorageLocationAnnotation)
+}
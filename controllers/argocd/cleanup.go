@@ -0,0 +1,168 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	amerr "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	componentDex           = "dex"
+	componentGrafana       = "grafana"
+	componentImageUpdater  = "image-updater"
+	componentNotifications = "notifications"
+	componentPrometheus    = "prometheus"
+)
+
+// namedObject returns the given object with the name and namespace set.
+func namedObject(obj client.Object, name string, cr *argoproj.ArgoCD) client.Object {
+	obj.SetName(name)
+	obj.SetNamespace(cr.Namespace)
+	return obj
+}
+
+// getComponentResources will return the resources created for the given optional component of the ArgoCD
+// instance, ordered so that workloads are removed before the RBAC and configuration they depend on.
+func getComponentResources(cr *argoproj.ArgoCD, component string) []client.Object {
+	var resources []client.Object
+
+	switch component {
+	case componentDex:
+		resources = []client.Object{
+			namedObject(&appsv1.Deployment{}, nameWithSuffix("dex-server", cr), cr),
+			namedObject(&corev1.Service{}, nameWithSuffix("dex-server", cr), cr),
+			namedObject(&corev1.Service{}, nameWithSuffix("dex-server-metrics", cr), cr),
+			namedObject(&rbacv1.RoleBinding{}, generateResourceName(common.ArgoCDDexServerComponent, cr), cr),
+			namedObject(&rbacv1.Role{}, generateResourceName(common.ArgoCDDexServerComponent, cr), cr),
+			namedObject(&corev1.ServiceAccount{}, getServiceAccountName(cr.Name, common.ArgoCDDefaultDexServiceAccountName), cr),
+		}
+		if IsPrometheusAPIAvailable() {
+			resources = append(resources, namedObject(&monitoringv1.ServiceMonitor{}, nameWithSuffix("dex-server-metrics", cr), cr))
+		}
+	case componentGrafana:
+		resources = []client.Object{
+			namedObject(&appsv1.Deployment{}, nameWithSuffix("grafana", cr), cr),
+			namedObject(&corev1.Service{}, nameWithSuffix("grafana", cr), cr),
+			namedObject(&networkingv1.Ingress{}, nameWithSuffix("grafana", cr), cr),
+			namedObject(&corev1.Secret{}, nameWithSuffix("grafana", cr), cr),
+			namedObject(&corev1.ConfigMap{}, nameWithSuffix("grafana-config", cr), cr),
+			namedObject(&corev1.ConfigMap{}, nameWithSuffix("grafana-dashboards", cr), cr),
+		}
+		if IsRouteAPIAvailable() {
+			resources = append(resources, namedObject(&routev1.Route{}, nameWithSuffix("grafana", cr), cr))
+		}
+	case componentImageUpdater:
+		resources = []client.Object{
+			namedObject(&appsv1.Deployment{}, nameWithSuffix("image-updater", cr), cr),
+			namedObject(&rbacv1.RoleBinding{}, generateResourceName(common.ArgoCDImageUpdaterComponent, cr), cr),
+			namedObject(&rbacv1.Role{}, generateResourceName(common.ArgoCDImageUpdaterComponent, cr), cr),
+			namedObject(&corev1.ServiceAccount{}, getServiceAccountName(cr.Name, common.ArgoCDImageUpdaterComponent), cr),
+			namedObject(&corev1.ConfigMap{}, imageUpdaterConfigMapName, cr),
+			namedObject(&corev1.Secret{}, imageUpdaterSecretName, cr),
+		}
+	case componentNotifications:
+		resources = []client.Object{
+			namedObject(&appsv1.Deployment{}, nameWithSuffix("notifications-controller", cr), cr),
+			namedObject(&corev1.Service{}, nameWithSuffix("notifications-controller-metrics", cr), cr),
+			namedObject(&rbacv1.RoleBinding{}, generateResourceName(common.ArgoCDNotificationsControllerComponent, cr), cr),
+			namedObject(&rbacv1.Role{}, generateResourceName(common.ArgoCDNotificationsControllerComponent, cr), cr),
+			namedObject(&corev1.ServiceAccount{}, getServiceAccountName(cr.Name, common.ArgoCDNotificationsControllerComponent), cr),
+			namedObject(&corev1.Secret{}, "argocd-notifications-secret", cr),
+		}
+		if IsPrometheusAPIAvailable() {
+			resources = append(resources, namedObject(&monitoringv1.ServiceMonitor{}, nameWithSuffix("notifications-controller-metrics", cr), cr))
+		}
+	case componentPrometheus:
+		resources = []client.Object{
+			namedObject(&networkingv1.Ingress{}, nameWithSuffix("prometheus", cr), cr),
+		}
+		if IsRouteAPIAvailable() {
+			resources = append(resources, namedObject(&routev1.Route{}, nameWithSuffix("prometheus", cr), cr))
+		}
+		if IsPrometheusAPIAvailable() {
+			resources = append(resources,
+				namedObject(&monitoringv1.Prometheus{}, cr.Name, cr),
+				namedObject(&monitoringv1.ServiceMonitor{}, nameWithSuffix(common.ArgoCDKeyMetrics, cr), cr),
+				namedObject(&monitoringv1.ServiceMonitor{}, nameWithSuffix("repo-server-metrics", cr), cr),
+				namedObject(&monitoringv1.ServiceMonitor{}, nameWithSuffix("server-metrics", cr), cr),
+				namedObject(&monitoringv1.ServiceMonitor{}, nameWithSuffix("dex-server-metrics", cr), cr),
+			)
+		}
+	}
+
+	return resources
+}
+
+// deleteComponentResources will delete the resources of the given component of the ArgoCD instance. Only
+// resources controlled by the ArgoCD instance are deleted, so that user created resources are left alone.
+func (r *ReconcileArgoCD) deleteComponentResources(cr *argoproj.ArgoCD, component string) error {
+	var deletionErrors []error
+
+	for _, obj := range getComponentResources(cr, component) {
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, obj.GetName(), obj) {
+			continue
+		}
+		if !metav1.IsControlledBy(obj, cr) {
+			continue
+		}
+
+		instanceLog(cr).Info(fmt.Sprintf("deleting %T %s of disabled component %s", obj, obj.GetName(), component))
+		if err := r.Client.Delete(context.TODO(), obj); err != nil && !apierrors.IsNotFound(err) {
+			deletionErrors = append(deletionErrors, fmt.Errorf("failed to delete %s of component %s: %w", obj.GetName(), component, err))
+		}
+	}
+
+	return amerr.NewAggregate(deletionErrors)
+}
+
+// deleteDisabledComponentResources will delete the resources of the optional components that are disabled for
+// the ArgoCD instance.
+func (r *ReconcileArgoCD) deleteDisabledComponentResources(cr *argoproj.ArgoCD) error {
+	disabled := map[string]bool{
+		componentDex:           !UseDex(cr),
+		componentGrafana:       !cr.Spec.Grafana.Enabled,
+		componentImageUpdater:  !cr.Spec.ImageUpdater.Enabled,
+		componentNotifications: !cr.Spec.Notifications.Enabled,
+		componentPrometheus:    !cr.Spec.Prometheus.Enabled,
+	}
+
+	var deletionErrors []error
+	for _, component := range []string{componentDex, componentGrafana, componentImageUpdater, componentNotifications, componentPrometheus} {
+		if !disabled[component] {
+			continue
+		}
+		if err := r.deleteComponentResources(cr, component); err != nil {
+			deletionErrors = append(deletionErrors, err)
+		}
+	}
+
+	return amerr.NewAggregate(deletionErrors)
+}
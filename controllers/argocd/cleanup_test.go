@@ -0,0 +1,98 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestReconcileArgoCD_deleteDisabledComponentResources(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD()
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+
+	owned := func(obj client.Object) client.Object {
+		assert.NoError(t, controllerutil.SetControllerReference(a, obj, sch))
+		return obj
+	}
+
+	dexRole := owned(namedObject(&rbacv1.Role{}, "argocd-argocd-dex-server", a))
+	dexSA := owned(namedObject(&corev1.ServiceAccount{}, "argocd-argocd-dex-server", a))
+	grafanaDeployment := owned(namedObject(&appsv1.Deployment{}, "argocd-grafana", a))
+	notificationsService := owned(namedObject(&corev1.Service{}, "argocd-notifications-controller-metrics", a))
+	// Not owned by the ArgoCD instance, must be left alone
+	grafanaConfig := namedObject(&corev1.ConfigMap{}, "argocd-grafana-config", a)
+
+	resObjs := []client.Object{a, dexRole, dexSA, grafanaDeployment, notificationsService, grafanaConfig}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.deleteDisabledComponentResources(a))
+
+	for _, obj := range []client.Object{dexRole, dexSA, grafanaDeployment, notificationsService} {
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: obj.GetName(), Namespace: a.Namespace}, obj)
+		assert.True(t, apierrors.IsNotFound(err), "expected %T %s to be deleted", obj, obj.GetName())
+	}
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: grafanaConfig.GetName(), Namespace: a.Namespace}, grafanaConfig))
+}
+
+func TestReconcileArgoCD_deleteDisabledComponentResources_enabledComponents(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex: &argoproj.ArgoCDDexSpec{
+				OpenShiftOAuth: true,
+			},
+		}
+		a.Spec.Notifications.Enabled = true
+	})
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+
+	dexSA := namedObject(&corev1.ServiceAccount{}, "argocd-argocd-dex-server", a)
+	assert.NoError(t, controllerutil.SetControllerReference(a, dexSA, sch))
+	notificationsService := namedObject(&corev1.Service{}, "argocd-notifications-controller-metrics", a)
+	assert.NoError(t, controllerutil.SetControllerReference(a, notificationsService, sch))
+
+	resObjs := []client.Object{a, dexSA, notificationsService}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.deleteDisabledComponentResources(a))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: dexSA.GetName(), Namespace: a.Namespace}, dexSA))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: notificationsService.GetName(), Namespace: a.Namespace}, notificationsService))
+}
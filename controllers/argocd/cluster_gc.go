@@ -0,0 +1,128 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	amerr "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// ClusterResourceGarbageCollector periodically deletes the ClusterRoles and ClusterRoleBindings created for ArgoCD
+// instances that no longer exist. Cluster-scoped resources cannot be owned by a namespaced ArgoCD, so they are not
+// garbage collected by Kubernetes, and are left behind when an instance is deleted while the operator is not running
+// or without its finalizer. The instance of a resource is found from its DefaultAnnotations.
+type ClusterResourceGarbageCollector struct {
+	Client client.Client
+	// Interval is the time between two collections, 0 disables the collector.
+	Interval time.Duration
+}
+
+// SetupWithManager adds the collector to the Manager, so that it runs on the leader.
+func (gc *ClusterResourceGarbageCollector) SetupWithManager(mgr ctrl.Manager) error {
+	if gc.Interval <= 0 {
+		return nil
+	}
+	return mgr.Add(gc)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (gc *ClusterResourceGarbageCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs a collection at each interval until the given context is done.
+func (gc *ClusterResourceGarbageCollector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(gc.Interval)
+	defer ticker.Stop()
+	for {
+		if err := gc.Collect(ctx); err != nil {
+			log.Error(err, "failed to garbage collect the cluster resources of deleted ArgoCD instances")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect will delete the ClusterRoles and ClusterRoleBindings annotated with the name and namespace of an ArgoCD
+// that does not exist. The resources of a namespace whose instances cannot be read are skipped, e.g. a namespace
+// outside the cache of the operator, and the errors are returned once the other namespaces are collected.
+func (gc *ClusterResourceGarbageCollector) Collect(ctx context.Context) error {
+	exists := map[types.NamespacedName]bool{}
+	failed := map[string]bool{}
+	collectErrors := []error{}
+	instanceExists := func(key types.NamespacedName) (bool, error) {
+		if found, ok := exists[key]; ok {
+			return found, nil
+		}
+		err := gc.Client.Get(ctx, key, &argoproj.ArgoCD{})
+		if err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+		exists[key] = err == nil
+		return exists[key], nil
+	}
+
+	for _, list := range []client.ObjectList{&v1.ClusterRoleList{}, &v1.ClusterRoleBindingList{}} {
+		if err := gc.Client.List(ctx, list); err != nil {
+			return fmt.Errorf("failed to list %T: %w", list, err)
+		}
+		objs, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, o := range objs {
+			obj, ok := o.(client.Object)
+			if !ok {
+				continue
+			}
+			name, namespace := obj.GetAnnotations()[common.AnnotationName], obj.GetAnnotations()[common.AnnotationNamespace]
+			if name == "" || namespace == "" {
+				continue // Not created for an ArgoCD instance
+			}
+			if failed[namespace] {
+				continue
+			}
+			found, err := instanceExists(types.NamespacedName{Name: name, Namespace: namespace})
+			if err != nil {
+				failed[namespace] = true
+				collectErrors = append(collectErrors, fmt.Errorf("failed to get ArgoCD %s/%s, skipping namespace %s: %w", namespace, name, namespace, err))
+				continue
+			}
+			if found {
+				continue
+			}
+			log.Info(fmt.Sprintf("Deleting %T %s of the deleted ArgoCD %s/%s", obj, obj.GetName(), namespace, name))
+			if err := gc.Client.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+				collectErrors = append(collectErrors, fmt.Errorf("failed to delete %T %s: %w", obj, obj.GetName(), err))
+			}
+		}
+	}
+	return amerr.NewAggregate(collectErrors)
+}
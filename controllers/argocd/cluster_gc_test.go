@@ -0,0 +1,103 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestClusterResourceGarbageCollector_Collect(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	deleted := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Name = "deleted"
+	})
+
+	kept := newClusterRole("argocd-server", nil, a)
+	keptBinding := newClusterRoleBindingWithname("argocd-server", a)
+	orphan := newClusterRole("argocd-server", nil, deleted)
+	orphanBinding := newClusterRoleBindingWithname("argocd-server", deleted)
+	unrelated := &v1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"}}
+
+	resObjs := []client.Object{a, kept, keptBinding, orphan, orphanBinding, unrelated}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+
+	gc := &ClusterResourceGarbageCollector{Client: cl}
+	assert.NoError(t, gc.Collect(context.TODO()))
+
+	for _, obj := range []client.Object{kept, keptBinding, unrelated} {
+		assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: obj.GetName()}, obj))
+	}
+	assert.True(t, errors.IsNotFound(cl.Get(context.TODO(), types.NamespacedName{Name: orphan.Name}, &v1.ClusterRole{})))
+	assert.True(t, errors.IsNotFound(cl.Get(context.TODO(), types.NamespacedName{Name: orphanBinding.Name}, &v1.ClusterRoleBinding{})))
+}
+
+// forbiddenNamespaceClient is a client failing to get the objects of a namespace, as for a namespace outside the
+// cache of the operator.
+type forbiddenNamespaceClient struct {
+	client.Client
+	namespace string
+}
+
+func (c *forbiddenNamespaceClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if key.Namespace == c.namespace {
+		return errors.NewForbidden(argoproj.GroupVersion.WithResource("argocds").GroupResource(), key.Name, fmt.Errorf("namespace not cached"))
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func TestClusterResourceGarbageCollector_Collect_namespaceError(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	deleted := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Name = "deleted"
+	})
+	unreadable := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Namespace = "unreadable"
+	})
+
+	orphan := newClusterRole("argocd-server", nil, deleted)
+	unreadableRole := newClusterRole("argocd-server", nil, unreadable)
+	unreadableBinding := newClusterRoleBindingWithname("argocd-server", unreadable)
+
+	resObjs := []client.Object{orphan, unreadableRole, unreadableBinding}
+	subresObjs := []client.Object{}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+
+	// The resources of the unreadable namespace are kept, and the other namespaces are still collected
+	gc := &ClusterResourceGarbageCollector{Client: &forbiddenNamespaceClient{Client: cl, namespace: "unreadable"}}
+	err := gc.Collect(context.TODO())
+	assert.ErrorContains(t, err, "skipping namespace unreadable")
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: unreadableRole.Name}, &v1.ClusterRole{}))
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: unreadableBinding.Name}, &v1.ClusterRoleBinding{}))
+	assert.True(t, errors.IsNotFound(cl.Get(context.TODO(), types.NamespacedName{Name: orphan.Name}, &v1.ClusterRole{})))
+}
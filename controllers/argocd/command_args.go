@@ -0,0 +1,98 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+// getConflictingArgs returns the flags of the given extra arguments that are already part of the given command, see
+// isMergable.
+func getConflictingArgs(extraArgs []string, cmd []string) []string {
+	var conflicts []string
+	for _, arg := range extraArgs {
+		if len(arg) > 2 && arg[:2] == "--" && contains(cmd, arg) && !contains(conflicts, arg) {
+			conflicts = append(conflicts, arg)
+		}
+	}
+	return conflicts
+}
+
+// removeArgs returns the given command without the given flags, nor the values following them.
+func removeArgs(cmd []string, flags []string) []string {
+	result := make([]string, 0, len(cmd))
+	for i := 0; i < len(cmd); i++ {
+		if !contains(flags, cmd[i]) {
+			result = append(result, cmd[i])
+			continue
+		}
+		if i+1 < len(cmd) && !strings.HasPrefix(cmd[i+1], "--") {
+			i++ // Skip the value of the flag
+		}
+	}
+	return result
+}
+
+// reconcileServerCommandArgs will ensure that the ServerCommandArgsConflict condition reports the extra command
+// arguments of the Argo CD Server that conflict with the arguments set by the operator, and whether they are dropped
+// or replace the arguments of the operator. The condition is only added once a conflict has been found.
+func (r *ReconcileArgoCD) reconcileServerCommandArgs(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
+	conflicts := getConflictingArgs(cr.Spec.Server.ExtraCommandArgs, getArgoServerDefaultCommand(cr, useTLSForRedis))
+	existing := meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionTypeServerCommandArgsConflict)
+	if len(conflicts) == 0 && existing == nil {
+		return nil // Never conflicted, no need for the condition
+	}
+
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeServerCommandArgsConflict,
+		Status:             metav1.ConditionFalse,
+		Reason:             argoproj.ArgoCDConditionReasonNoConflictingCommandArgs,
+		Message:            "The extra command arguments of the Argo CD Server do not conflict with the arguments set by the operator",
+		ObservedGeneration: cr.Generation,
+	}
+	if len(conflicts) > 0 {
+		condition.Status = metav1.ConditionTrue
+		if cr.Spec.Server.ExtraCommandArgsForceOverride {
+			condition.Reason = argoproj.ArgoCDConditionReasonCommandArgsOverridden
+			condition.Message = fmt.Sprintf("The extra command arguments %s of the Argo CD Server replace the arguments set by the operator",
+				strings.Join(conflicts, ", "))
+		} else {
+			condition.Reason = argoproj.ArgoCDConditionReasonCommandArgsDropped
+			condition.Message = fmt.Sprintf("The extra command arguments %s of the Argo CD Server conflict with the arguments set by the operator, "+
+				"all the extra command arguments are dropped, set extraCommandArgsForceOverride to replace the arguments of the operator",
+				strings.Join(conflicts, ", "))
+		}
+	}
+
+	if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+		return nil // Nothing changed, move along...
+	}
+
+	if len(conflicts) > 0 {
+		instanceLog(cr).Info(fmt.Sprintf("ArgoCD %s/%s: %s", cr.Namespace, cr.Name, condition.Message))
+		r.recordEvent(cr, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	return r.Client.Status().Update(context.TODO(), cr)
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestGetConflictingArgs(t *testing.T) {
+	cmd := []string{"argocd-server", "--insecure", "--loglevel", "info"}
+	assert.Empty(t, getConflictingArgs([]string{"--rootpath", "/argocd"}, cmd))
+	assert.Equal(t, []string{"--loglevel", "--insecure"}, getConflictingArgs([]string{"--loglevel", "debug", "--insecure", "--loglevel", "warn"}, cmd))
+}
+
+func TestRemoveArgs(t *testing.T) {
+	cmd := []string{"argocd-server", "--insecure", "--staticassets", "/shared/app", "--loglevel", "info"}
+	assert.Equal(t, []string{"argocd-server", "--staticassets", "/shared/app"}, removeArgs(cmd, []string{"--insecure", "--loglevel"}))
+	assert.Equal(t, []string{"argocd-server", "--insecure", "--loglevel", "info"}, removeArgs(cmd, []string{"--staticassets"}))
+}
+
+func TestArgoCDServerCommand_forceOverride(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.ExtraCommandArgs = []string{"--loglevel", "debug", "--enable-gzip"}
+	})
+
+	// The conflicting extra arguments are dropped by default
+	cmd := getArgoServerCommand(a, false)
+	assert.NotContains(t, cmd, "--enable-gzip")
+	assert.Contains(t, cmd, "info")
+
+	a.Spec.Server.ExtraCommandArgsForceOverride = true
+	cmd = getArgoServerCommand(a, false)
+	assert.Equal(t, []string{"--loglevel", "debug", "--enable-gzip"}, cmd[len(cmd)-3:])
+	assert.NotContains(t, cmd, "info")
+}
+
+func TestReconcileArgoCD_reconcileServerCommandArgs(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// No condition without conflicts
+	assert.NoError(t, r.reconcileServerCommandArgs(a, false))
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeServerCommandArgsConflict))
+
+	a.Spec.Server.ExtraCommandArgs = []string{"--insecure", "--enable-gzip"}
+	a.Spec.Server.Insecure = true
+	assert.NoError(t, r.reconcileServerCommandArgs(a, false))
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeServerCommandArgsConflict)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonCommandArgsDropped, condition.Reason)
+	assert.Contains(t, condition.Message, "--insecure")
+
+	// The status update reads back the stored spec
+	a.Spec.Server.ExtraCommandArgs = []string{"--insecure", "--enable-gzip"}
+	a.Spec.Server.Insecure = true
+	a.Spec.Server.ExtraCommandArgsForceOverride = true
+	assert.NoError(t, r.reconcileServerCommandArgs(a, false))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeServerCommandArgsConflict)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonCommandArgsOverridden, condition.Reason)
+
+	a.Spec.Server.ExtraCommandArgs = []string{"--enable-gzip"}
+	assert.NoError(t, r.reconcileServerCommandArgs(a, false))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeServerCommandArgsConflict)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonNoConflictingCommandArgs, condition.Reason)
+}
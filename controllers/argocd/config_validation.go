@@ -0,0 +1,326 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// invalidConfigError is returned when the configuration rendered for a ConfigMap of Argo CD is invalid. The ConfigMap
+// is left untouched, so that Argo CD keeps running with the last valid configuration.
+type invalidConfigError struct {
+	configMap string
+	err       error
+}
+
+func (e *invalidConfigError) Error() string {
+	return fmt.Sprintf("invalid configuration for ConfigMap %s: %v", e.configMap, e.err)
+}
+
+func (e *invalidConfigError) Unwrap() error {
+	return e.err
+}
+
+// isInvalidConfigError returns true if the given error is an invalidConfigError.
+func isInvalidConfigError(err error) bool {
+	var invalid *invalidConfigError
+	return errors.As(err, &invalid)
+}
+
+// argoConfigYAMLKeys are the keys of the argocd-cm ConfigMap holding YAML documents.
+var argoConfigYAMLKeys = []string{
+	common.ArgoCDKeyDexConfig,
+	common.ArgoCDKeyOIDCConfig,
+	common.ArgoCDKeyRepositories,
+	common.ArgoCDKeyRepositoryCredentials,
+	common.ArgoCDKeyResourceCompareOptions,
+	common.ArgoCDKeyResourceExclusions,
+	common.ArgoCDKeyResourceInclusions,
+	"resource.customizations",
+}
+
+// argoConfigYAMLPrefixes are the prefixes of the keys of the argocd-cm ConfigMap holding YAML documents.
+var argoConfigYAMLPrefixes = []string{
+	"resource.customizations.ignoreDifferences.",
+	"resource.customizations.knownTypeFields.",
+}
+
+// resourceActionsConfig is the configuration of the custom actions of a resource kind.
+type resourceActionsConfig struct {
+	DiscoveryLua string `yaml:"discovery.lua"`
+	Definitions  []struct {
+		Name      string `yaml:"name"`
+		ActionLua string `yaml:"action.lua"`
+	} `yaml:"definitions"`
+}
+
+// validateArgoConfig will validate the given argocd-cm ConfigMap data, returning an error for the first invalid key.
+func validateArgoConfig(data map[string]string) error {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := validateArgoConfigKey(k, data[k]); err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// validateArgoConfigKey will validate the value of the given argocd-cm ConfigMap key.
+func validateArgoConfigKey(key string, value string) error {
+	switch {
+	case strings.HasPrefix(key, "resource.customizations.health."):
+		return validateLuaScript(value)
+	case strings.HasPrefix(key, "resource.customizations.actions."):
+		actions := resourceActionsConfig{}
+		if err := yaml.Unmarshal([]byte(value), &actions); err != nil {
+			return err
+		}
+		if err := validateLuaScript(actions.DiscoveryLua); err != nil {
+			return fmt.Errorf("discovery.lua: %w", err)
+		}
+		for _, d := range actions.Definitions {
+			if err := validateLuaScript(d.ActionLua); err != nil {
+				return fmt.Errorf("action %s: %w", d.Name, err)
+			}
+		}
+		return nil
+	case contains(argoConfigYAMLKeys, key) || hasAnyPrefix(key, argoConfigYAMLPrefixes):
+		var v interface{}
+		return yaml.Unmarshal([]byte(value), &v)
+	}
+	return nil
+}
+
+// hasAnyPrefix returns true if the given string starts with any of the given prefixes.
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRBACPolicy will validate the given RBAC policy CSV. Policy lines must have six fields, and role
+// assignments three fields.
+func validateRBACPolicy(policy string) error {
+	reader := csv.NewReader(strings.NewReader(policy))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		line, _ := reader.FieldPos(0)
+		switch strings.TrimSpace(record[0]) {
+		case "p":
+			if len(record) != 6 {
+				return fmt.Errorf("line %d: policy must have 6 fields, got %d", line, len(record))
+			}
+		case "g":
+			if len(record) != 3 {
+				return fmt.Errorf("line %d: role assignment must have 3 fields, got %d", line, len(record))
+			}
+		default:
+			return fmt.Errorf("line %d: unknown policy type %q", line, record[0])
+		}
+	}
+}
+
+// validateRBACPolicyScope will validate that the policy rules of the given RBAC policy CSV only apply to the given
+// AppProject, or to its objects, and that its role assignments only bind to the roles of the AppProject, named
+// proj:<project>:<role>. The policy is expected to be valid already, see validateRBACPolicy.
+func validateRBACPolicyScope(policy string, project string) error {
+	reader := csv.NewReader(strings.NewReader(policy))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := reader.FieldPos(0)
+		if strings.TrimSpace(record[0]) == "g" {
+			if role := strings.TrimSpace(record[2]); !strings.HasPrefix(role, "proj:"+project+":") {
+				return fmt.Errorf("line %d: role assignment binds to role %q outside of project %q", line, role, project)
+			}
+			continue
+		}
+
+		resource, object := strings.TrimSpace(record[2]), strings.TrimSpace(record[4])
+		if resource == "projects" {
+			if object != project {
+				return fmt.Errorf("line %d: policy applies to project %q outside of project %q", line, object, project)
+			}
+		} else if !strings.HasPrefix(object, project+"/") {
+			return fmt.Errorf("line %d: policy applies to %s %q outside of project %q", line, resource, object, project)
+		}
+	}
+}
+
+// validateLuaScript will perform a structural check of the given Lua script: strings, comments and brackets must be
+// closed, and every block must be terminated. It does not check the full Lua grammar, but catches the usual typos
+// that would break the resource customizations of Argo CD.
+func validateLuaScript(script string) error {
+	type opener struct {
+		token string
+		line  int
+	}
+	var stack []opener
+	line := 1
+
+	pop := func(closing string, expected ...string) error {
+		if len(stack) == 0 || !contains(expected, stack[len(stack)-1].token) {
+			return fmt.Errorf("line %d: unexpected '%s'", line, closing)
+		}
+		stack = stack[:len(stack)-1]
+		return nil
+	}
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '\n':
+			line++
+		case c == '-' && strings.HasPrefix(script[i:], "--"):
+			if level, ok := luaLongBracketLevel(script, i+2); ok {
+				end, err := skipLuaLongBracket(script, i+2, level)
+				if err != nil {
+					return fmt.Errorf("line %d: unfinished long comment", line)
+				}
+				line += strings.Count(script[i:end], "\n")
+				i = end - 1
+				continue
+			}
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+			i-- // let the newline be counted
+		case c == '"' || c == '\'':
+			j := i + 1
+			for ; j < len(script) && script[j] != c; j++ {
+				if script[j] == '\\' {
+					j++
+				} else if script[j] == '\n' {
+					break
+				}
+			}
+			if j >= len(script) || script[j] != c {
+				return fmt.Errorf("line %d: unfinished string", line)
+			}
+			i = j
+		case c == '[':
+			if level, ok := luaLongBracketLevel(script, i); ok {
+				end, err := skipLuaLongBracket(script, i, level)
+				if err != nil {
+					return fmt.Errorf("line %d: unfinished long string", line)
+				}
+				line += strings.Count(script[i:end], "\n")
+				i = end - 1
+				continue
+			}
+			stack = append(stack, opener{token: "[", line: line})
+		case c == '(' || c == '{':
+			stack = append(stack, opener{token: string(c), line: line})
+		case c == ')':
+			if err := pop(")", "("); err != nil {
+				return err
+			}
+		case c == ']':
+			if err := pop("]", "["); err != nil {
+				return err
+			}
+		case c == '}':
+			if err := pop("}", "{"); err != nil {
+				return err
+			}
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			j := i
+			for j < len(script) && (script[j] == '_' || (script[j] >= 'a' && script[j] <= 'z') ||
+				(script[j] >= 'A' && script[j] <= 'Z') || (script[j] >= '0' && script[j] <= '9')) {
+				j++
+			}
+			switch word := script[i:j]; word {
+			case "function", "if", "do", "repeat":
+				stack = append(stack, opener{token: word, line: line})
+			case "end":
+				if err := pop(word, "function", "if", "do"); err != nil {
+					return err
+				}
+			case "until":
+				if err := pop(word, "repeat"); err != nil {
+					return err
+				}
+			}
+			i = j - 1
+		}
+	}
+
+	if len(stack) > 0 {
+		o := stack[len(stack)-1]
+		return fmt.Errorf("line %d: '%s' is not closed", o.line, o.token)
+	}
+	return nil
+}
+
+// luaLongBracketLevel returns the level of the Lua long bracket opening at the given position, e.g. 0 for "[[" and
+// 1 for "[=[".
+func luaLongBracketLevel(script string, pos int) (int, bool) {
+	if pos >= len(script) || script[pos] != '[' {
+		return 0, false
+	}
+	level := 0
+	for pos+1+level < len(script) && script[pos+1+level] == '=' {
+		level++
+	}
+	return level, pos+1+level < len(script) && script[pos+1+level] == '['
+}
+
+// skipLuaLongBracket returns the position following the Lua long bracket of the given level opening at the given
+// position.
+func skipLuaLongBracket(script string, pos int, level int) (int, error) {
+	closing := "]" + strings.Repeat("=", level) + "]"
+	end := strings.Index(script[pos+level+2:], closing)
+	if end < 0 {
+		return 0, errors.New("unfinished long bracket")
+	}
+	return pos + level + 2 + end + len(closing), nil
+}
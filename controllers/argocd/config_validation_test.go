@@ -0,0 +1,191 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const testHealthCheckLua = `hs = {}
+if obj.status ~= nil then
+  for i, condition in ipairs(obj.status.conditions) do
+    if condition.type == "Ready" and condition.status == "False" then
+      hs.status = "Degraded"
+      hs.message = condition.message -- "the [message" is reported as is
+      return hs
+    end
+  end
+end
+--[[ long
+comment with end ]]
+hs.status = [==[Healthy]==]
+return hs
+`
+
+func TestValidateLuaScript(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		err    string
+	}{
+		{name: "valid script", script: testHealthCheckLua},
+		{name: "empty script", script: ""},
+		{name: "repeat until", script: "repeat\n  i = i + 1\nuntil i > 10"},
+		{name: "missing end", script: "if obj.status then\n  return {}\n", err: "line 1: 'if' is not closed"},
+		{name: "extra end", script: "return {}\nend", err: "line 2: unexpected 'end'"},
+		{name: "unbalanced bracket", script: "hs = {\nreturn hs", err: "line 1: '{' is not closed"},
+		{name: "mismatched bracket", script: "f(a]", err: "line 1: unexpected ']'"},
+		{name: "unfinished string", script: "hs.status = \"Healthy\nreturn hs", err: "line 1: unfinished string"},
+		{name: "unfinished long string", script: "\nhs.message = [[Healthy", err: "line 2: unfinished long string"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateLuaScript(test.script)
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestValidateRBACPolicy(t *testing.T) {
+	assert.NoError(t, validateRBACPolicy(""))
+	assert.NoError(t, validateRBACPolicy(common.ArgoCDDefaultRBACPolicy))
+	assert.NoError(t, validateRBACPolicy("# admins\np, role:org-admin, applications, *, */*, allow\ng, \"my-org:team\", role:org-admin\n"))
+
+	assert.EqualError(t, validateRBACPolicy("p, role:org-admin, applications, *, */*, allow\np, role:org-admin, clusters, get, allow"),
+		"line 2: policy must have 6 fields, got 5")
+	assert.EqualError(t, validateRBACPolicy("g, my-org:team"), "line 1: role assignment must have 3 fields, got 2")
+	assert.EqualError(t, validateRBACPolicy("x, role:org-admin, role:admin"), "line 1: unknown policy type \"x\"")
+}
+
+func TestValidateRBACPolicyScope(t *testing.T) {
+	assert.NoError(t, validateRBACPolicyScope("p, proj:team-a:dev, applications, *, team-a/*, allow\n"+
+		"p, proj:team-a:dev, projects, get, team-a, allow\ng, team-a-devs, proj:team-a:dev\n", "team-a"))
+
+	assert.EqualError(t, validateRBACPolicyScope("p, role:team-a, applications, get, team-a/*, allow\np, role:team-a, clusters, get, *, allow", "team-a"),
+		"line 2: policy applies to clusters \"*\" outside of project \"team-a\"")
+	assert.EqualError(t, validateRBACPolicyScope("p, role:team-a, projects, get, team-b, allow", "team-a"),
+		"line 1: policy applies to project \"team-b\" outside of project \"team-a\"")
+	assert.EqualError(t, validateRBACPolicyScope("p, role:team-a, applications, get, team-ab/app, allow", "team-a"),
+		"line 1: policy applies to applications \"team-ab/app\" outside of project \"team-a\"")
+	assert.EqualError(t, validateRBACPolicyScope("p, proj:team-a:dev, applications, get, team-a/*, allow\ng, team-a-devs, role:admin", "team-a"),
+		"line 2: role assignment binds to role \"role:admin\" outside of project \"team-a\"")
+	assert.EqualError(t, validateRBACPolicyScope("g, proj:team-a:dev, proj:team-ab:admin", "team-a"),
+		"line 1: role assignment binds to role \"proj:team-ab:admin\" outside of project \"team-a\"")
+}
+
+func TestValidateArgoConfig(t *testing.T) {
+	assert.NoError(t, validateArgoConfig(map[string]string{
+		common.ArgoCDKeyDexConfig:                               "connectors: []\n",
+		common.ArgoCDKeyAdminEnabled:                            "true",
+		"resource.customizations.health.argoproj.io_Rollout":    testHealthCheckLua,
+		"resource.customizations.actions.apps_Deployment":       "discovery.lua: |\n  return {}\ndefinitions:\n- name: restart\n  action.lua: |\n    return obj\n",
+		"resource.customizations.ignoreDifferences.apps_Deploy": "jsonPointers:\n- /spec/replicas\n",
+	}))
+
+	err := validateArgoConfig(map[string]string{
+		common.ArgoCDKeyDexConfig: "connectors:\n- type: github\n  config: {",
+	})
+	assert.ErrorContains(t, err, "dex.config: ")
+
+	err = validateArgoConfig(map[string]string{
+		"resource.customizations.actions.apps_Deployment": "definitions:\n- name: restart\n  action.lua: |\n    if obj then\n",
+	})
+	assert.EqualError(t, err, "resource.customizations.actions.apps_Deployment: action restart: line 1: 'if' is not closed")
+}
+
+func TestReconcileArgoCD_reconcileConfigMaps_invalidConfiguration(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	t.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	assert.NoError(t, r.reconcileConfigMaps(a, false))
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeDegraded))
+
+	// A broken health check is refused, the last valid configuration is kept
+	a.Spec.ExtraConfig = map[string]string{"resource.customizations.health.apps_Deployment": "if obj then"}
+	assert.NoError(t, r.reconcileConfigMaps(a, false))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.NotContains(t, cm.Data, "resource.customizations.health.apps_Deployment")
+
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeDegraded)
+	assert.NotNil(t, condition)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonInvalidConfiguration, condition.Reason)
+	assert.Contains(t, condition.Message, "resource.customizations.health.apps_Deployment: line 1: 'if' is not closed")
+
+	// The Event is emitted once for the same invalid configuration
+	a.Spec.ExtraConfig = map[string]string{"resource.customizations.health.apps_Deployment": "if obj then"}
+	assert.NoError(t, r.reconcileConfigMaps(a, false))
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, corev1.EventTypeWarning+" "+argoproj.ArgoCDConditionReasonInvalidConfiguration)
+
+	// A fixed configuration is applied, and clears the condition
+	a.Spec.ExtraConfig = map[string]string{"resource.customizations.health.apps_Deployment": "if obj then return {} end"}
+	assert.NoError(t, r.reconcileConfigMaps(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Contains(t, cm.Data, "resource.customizations.health.apps_Deployment")
+	assert.True(t, meta.IsStatusConditionFalse(a.Status.Conditions, argoproj.ArgoCDConditionTypeDegraded))
+}
+
+func TestReconcileArgoCD_reconcileRBAC_invalidPolicy(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	policy := "p, role:org-admin, applications, *, allow"
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RBAC.Policy = &policy
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	err := r.reconcileRBAC(a)
+	assert.True(t, isInvalidConfigError(err))
+	assert.EqualError(t, err, "invalid configuration for ConfigMap argocd-rbac-cm: policy.csv: line 1: policy must have 6 fields, got 5")
+
+	cm := &corev1.ConfigMap{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRBACConfigMapName, Namespace: a.Namespace}, cm)
+	assert.Error(t, err)
+}
@@ -18,6 +18,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
@@ -35,12 +38,18 @@ func (r *ReconcileArgoCD) createRBACConfigMap(cm *corev1.ConfigMap, cr *argoproj
 	data[common.ArgoCDKeyRBACPolicyCSV] = getRBACPolicy(cr)
 	data[common.ArgoCDKeyRBACPolicyDefault] = getRBACDefaultPolicy(cr)
 	data[common.ArgoCDKeyRBACScopes] = getRBACScopes(cr)
+	for key, chunk := range getRBACPolicyChunks(cr) {
+		data[key] = chunk
+	}
 	cm.Data = data
 
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
 		return err
 	}
-	return r.Client.Create(context.TODO(), cm)
+	if err := r.Client.Create(context.TODO(), cm); err != nil {
+		return err
+	}
+	return r.reportRBACPolicySize(cr)
 }
 
 // getApplicationInstanceLabelKey will return the application instance label key  for the given ArgoCD.
@@ -115,6 +124,10 @@ func getKustomizeBuildOptions(cr *argoproj.ArgoCD) string {
 
 // getOIDCConfig will return the OIDC configuration for the given ArgoCD.
 func getOIDCConfig(cr *argoproj.ArgoCD) string {
+	if UseExternalOIDC(cr) {
+		return getExternalOIDCConfig(cr)
+	}
+
 	config := common.ArgoCDDefaultOIDCConfig
 	if len(cr.Spec.OIDCConfig) > 0 {
 		config = cr.Spec.OIDCConfig
@@ -131,6 +144,33 @@ func getRBACPolicy(cr *argoproj.ArgoCD) string {
 	return policy
 }
 
+// rbacPolicyChunkKeyPattern matches the keys of the RBAC ConfigMap holding the policy chunks of an ArgoCD.
+var rbacPolicyChunkKeyPattern = regexp.MustCompile(`^policy\.[0-9]{3}\.csv$`)
+
+// getRBACPolicyChunks will return the RBAC policy chunks for the given ArgoCD, by key of the RBAC ConfigMap. The keys
+// are numbered in the order of the chunks, as Argo CD appends them to the policy in the order of their keys.
+func getRBACPolicyChunks(cr *argoproj.ArgoCD) map[string]string {
+	chunks := make(map[string]string, len(cr.Spec.RBAC.PolicyChunks))
+	for i, chunk := range cr.Spec.RBAC.PolicyChunks {
+		chunks[fmt.Sprintf(common.ArgoCDKeyRBACPolicyChunkCSVFormat, i+1)] = chunk.Policy
+	}
+	return chunks
+}
+
+// getRBACPolicySizeWarning will return a warning when the RBAC policy of the given ArgoCD, including its chunks, gets
+// close to the size limit of the RBAC ConfigMap, or an empty string otherwise.
+func getRBACPolicySizeWarning(cr *argoproj.ArgoCD) string {
+	size := len(getRBACPolicy(cr))
+	for _, chunk := range cr.Spec.RBAC.PolicyChunks {
+		size += len(chunk.Policy)
+	}
+	if size <= common.ArgoCDRBACPolicySizeWarningThreshold {
+		return ""
+	}
+	return fmt.Sprintf("the RBAC policy is %d bytes, close to the 1MiB size limit of the %s ConfigMap, consider grouping its rules into roles",
+		size, common.ArgoCDRBACConfigMapName)
+}
+
 // getRBACDefaultPolicy will retun the RBAC default policy for the given ArgoCD.
 func getRBACDefaultPolicy(cr *argoproj.ArgoCD) string {
 	dp := common.ArgoCDDefaultRBACDefaultPolicy
@@ -196,6 +236,32 @@ func getResourceIgnoreDifferences(cr *argoproj.ArgoCD) (map[string]string, error
 	return ignoreDiff, nil
 }
 
+// getResourceCompareOptions will return the `resource.compareoptions` configuration for the given ArgoCD, or an empty
+// string to use the Argo CD defaults.
+func getResourceCompareOptions(cr *argoproj.ArgoCD) (string, error) {
+	opts := cr.Spec.ResourceCompareOptions
+	if opts == nil {
+		return "", nil
+	}
+
+	compareOptions := make(map[string]interface{})
+	if opts.IgnoreAggregatedRoles {
+		compareOptions["ignoreAggregatedRoles"] = true
+	}
+	if opts.IgnoreResourceStatusField != "" {
+		compareOptions["ignoreResourceStatusField"] = string(opts.IgnoreResourceStatusField)
+	}
+	if opts.IgnoreDifferencesOnResourceUpdates {
+		compareOptions["ignoreDifferencesOnResourceUpdates"] = true
+	}
+	if len(compareOptions) == 0 {
+		return "", nil
+	}
+
+	bytes, err := yaml.Marshal(compareOptions)
+	return string(bytes), err
+}
+
 // getResourceActions loads custom actions to `resource.customizations.actions` from argocd-cm ConfigMap
 func getResourceActions(cr *argoproj.ArgoCD) map[string]string {
 	action := make(map[string]string)
@@ -235,11 +301,11 @@ func getResourceInclusions(cr *argoproj.ArgoCD) string {
 func getResourceTrackingMethod(cr *argoproj.ArgoCD) string {
 	rtm := argoproj.ParseResourceTrackingMethod(cr.Spec.ResourceTrackingMethod)
 	if rtm == argoproj.ResourceTrackingMethodInvalid {
-		log.Info(fmt.Sprintf("Found '%s' as resource tracking method, which is invalid. Using default 'label' method.", cr.Spec.ResourceTrackingMethod))
+		instanceLog(cr).Info(fmt.Sprintf("Found '%s' as resource tracking method, which is invalid. Using default 'label' method.", cr.Spec.ResourceTrackingMethod))
 	} else if cr.Spec.ResourceTrackingMethod != "" {
-		log.Info(fmt.Sprintf("Found '%s' as tracking method", cr.Spec.ResourceTrackingMethod))
+		instanceLog(cr).Info(fmt.Sprintf("Found '%s' as tracking method", cr.Spec.ResourceTrackingMethod))
 	} else {
-		log.Info("Using default resource tracking method 'label'")
+		instanceLog(cr).Info("Using default resource tracking method 'label'")
 	}
 	return rtm.String()
 }
@@ -267,11 +333,10 @@ func getInitialSSHKnownHosts(cr *argoproj.ArgoCD) string {
 	skh := common.ArgoCDDefaultSSHKnownHosts
 	if cr.Spec.InitialSSHKnownHosts.ExcludeDefaultHosts {
 		skh = ""
+	} else if cr.Spec.InitialSSHKnownHosts.RefreshInterval != nil && cr.Spec.InitialSSHKnownHosts.RefreshInterval.Duration > 0 {
+		skh = getDefaultSSHKnownHosts(cr.Spec.InitialSSHKnownHosts.RefreshInterval.Duration)
 	}
-	if len(cr.Spec.InitialSSHKnownHosts.Keys) > 0 {
-		skh += cr.Spec.InitialSSHKnownHosts.Keys
-	}
-	return skh
+	return mergeSSHKnownHosts(skh, cr.Spec.InitialSSHKnownHosts.Keys, cr.Spec.InitialSSHKnownHosts.MergeStrategy)
 }
 
 // getTLSCerts will return the TLS certs for the given ArgoCD.
@@ -308,8 +373,13 @@ func newConfigMapWithName(name string, cr *argoproj.ArgoCD) *corev1.ConfigMap {
 
 // reconcileConfigMaps will ensure that all ArgoCD ConfigMaps are present.
 func (r *ReconcileArgoCD) reconcileConfigMaps(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
+	// Invalid configurations are not applied, and reported on the ArgoCD instead of failing the reconciliation.
+	var invalid []error
 	if err := r.reconcileArgoConfigMap(cr); err != nil {
-		return err
+		if !isInvalidConfigError(err) {
+			return err
+		}
+		invalid = append(invalid, err)
 	}
 
 	if err := r.reconcileRedisConfiguration(cr, useTLSForRedis); err != nil {
@@ -317,6 +387,13 @@ func (r *ReconcileArgoCD) reconcileConfigMaps(cr *argoproj.ArgoCD, useTLSForRedi
 	}
 
 	if err := r.reconcileRBAC(cr); err != nil {
+		if !isInvalidConfigError(err) {
+			return err
+		}
+		invalid = append(invalid, err)
+	}
+
+	if err := r.reconcileStatusConfigValidation(cr, invalid); err != nil {
 		return err
 	}
 
@@ -336,6 +413,14 @@ func (r *ReconcileArgoCD) reconcileConfigMaps(cr *argoproj.ArgoCD, useTLSForRedi
 		return err
 	}
 
+	if err := r.reconcileLogSidecarConfigMap(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileCustomStylesConfigMap(cr); err != nil {
+		return err
+	}
+
 	return r.reconcileGPGKeysConfigMap(cr)
 }
 
@@ -349,7 +434,7 @@ func (r *ReconcileArgoCD) reconcileCAConfigMap(cr *argoproj.ArgoCD) error {
 
 	caSecret := argoutil.NewSecretWithSuffix(cr, common.ArgoCDCASuffix)
 	if !argoutil.IsObjectFound(r.Client, cr.Namespace, caSecret.Name, caSecret) {
-		log.Info(fmt.Sprintf("ca secret [%s] not found, waiting to reconcile ca configmap [%s]", caSecret.Name, cm.Name))
+		instanceLog(cr).Info(fmt.Sprintf("ca secret [%s] not found, waiting to reconcile ca configmap [%s]", caSecret.Name, cm.Name))
 		return nil
 	}
 
@@ -371,7 +456,7 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 
 	cm.Data[common.ArgoCDKeyApplicationInstanceLabelKey] = getApplicationInstanceLabelKey(cr)
 	cm.Data[common.ArgoCDKeyConfigManagementPlugins] = getConfigManagementPlugins(cr)
-	cm.Data[common.ArgoCDKeyAdminEnabled] = fmt.Sprintf("%t", !cr.Spec.DisableAdmin)
+	cm.Data[common.ArgoCDKeyAdminEnabled] = fmt.Sprintf("%t", !isAdminDisabled(cr))
 	cm.Data[common.ArgoCDKeyGATrackingID] = getGATrackingID(cr)
 	cm.Data[common.ArgoCDKeyGAAnonymizeUsers] = fmt.Sprint(cr.Spec.GAAnonymizeUsers)
 	cm.Data[common.ArgoCDKeyHelpChatURL] = getHelpChatURL(cr)
@@ -400,6 +485,14 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	compareOptions, err := getResourceCompareOptions(cr)
+	if err != nil {
+		return err
+	}
+	if compareOptions != "" {
+		cm.Data[common.ArgoCDKeyResourceCompareOptions] = compareOptions
+	}
+
 	if c := getResourceActions(cr); c != nil {
 		for k, v := range c {
 			cm.Data[k] = v
@@ -415,6 +508,19 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 	cm.Data[common.ArgoCDKeyServerURL] = r.getArgoServerURI(cr)
 	cm.Data[common.ArgoCDKeyUsersAnonymousEnabled] = fmt.Sprint(cr.Spec.UsersAnonymousEnabled)
 
+	if duration := getArgoServerSessionDuration(cr); duration != "" {
+		cm.Data[common.ArgoCDKeyUsersSessionDuration] = duration
+	}
+
+	// The Image Updater authenticates to the API server with a token of its own account when using the argocd API.
+	if usesImageUpdaterAccount(cr) {
+		cm.Data["accounts."+imageUpdaterAccountName] = "apiKey"
+	}
+
+	for key, value := range getAccountsConfig(cr) {
+		cm.Data[key] = value
+	}
+
 	// create dex config if dex is enabled through `.spec.sso`
 	if UseDex(cr) {
 		dexConfig := getDexConfig(cr)
@@ -427,6 +533,11 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 			}
 			dexConfig = cfg
 		}
+
+		dexConfig, err := addDexStaticClients(cr, dexConfig)
+		if err != nil {
+			return err
+		}
 		cm.Data[common.ArgoCDKeyDexConfig] = dexConfig
 	}
 
@@ -439,18 +550,29 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 		}
 	}
 
+	if hasCustomStyles(cr) {
+		cm.Data[common.ArgoCDKeyUICSSURL] = customStylesURL
+	}
+
 	if len(cr.Spec.ExtraConfig) > 0 {
 		for k, v := range cr.Spec.ExtraConfig {
 			cm.Data[k] = v
 		}
 	}
 
+	if err := validateArgoConfig(cm.Data); err != nil {
+		return &invalidConfigError{configMap: cm.Name, err: err}
+	}
+
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
 		return err
 	}
 
 	existingCM := &corev1.ConfigMap{}
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, existingCM) {
+		if cr.Spec.ConfigManagementPolicy == argoproj.ArgoCDConfigManagementPolicyIgnore {
+			return nil // The ConfigMap is left to the user once created.
+		}
 
 		// reconcile dex configuration if dex is enabled `.spec.sso.dex.provider` or there is
 		// existing dex configuration
@@ -464,23 +586,89 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 			cm.Data[common.ArgoCDKeyOIDCConfig] = existingCM.Data[common.ArgoCDKeyOIDCConfig]
 		}
 
-		if !reflect.DeepEqual(cm.Data, existingCM.Data) {
-			existingCM.Data = cm.Data
+		data := cm.Data
+		if cr.Spec.ConfigManagementPolicy == argoproj.ArgoCDConfigManagementPolicyMerge {
+			data = mergeArgoConfigMapData(existingCM, cm.Data)
+		}
+		managedKeys := getManagedKeys(existingCM, cm.Data, cr.Spec.ConfigManagementPolicy)
+
+		if !reflect.DeepEqual(data, existingCM.Data) || existingCM.Annotations[common.ArgoCDManagedKeysAnnotation] != managedKeys {
+			existingCM.Data = data
+			if existingCM.Annotations == nil {
+				existingCM.Annotations = map[string]string{}
+			}
+			existingCM.Annotations[common.ArgoCDManagedKeysAnnotation] = managedKeys
 			return r.Client.Update(context.TODO(), existingCM)
 		}
 		return nil // Do nothing as there is no change in the configmap.
 	}
+	if cm.Annotations == nil {
+		cm.Annotations = map[string]string{}
+	}
+	cm.Annotations[common.ArgoCDManagedKeysAnnotation] = getManagedKeys(cm, cm.Data, cr.Spec.ConfigManagementPolicy)
 	return r.Client.Create(context.TODO(), cm)
 
 }
 
+// getAnnotatedKeys will return the set of ConfigMap keys listed in the given annotation of the given ConfigMap.
+func getAnnotatedKeys(obj metav1.Object, annotation string) map[string]bool {
+	keys := map[string]bool{}
+	for _, k := range strings.Split(obj.GetAnnotations()[annotation], ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys[k] = true
+		}
+	}
+	return keys
+}
+
+// getManagedKeys will return the sorted, comma separated keys of the given data generated by the operator, which it
+// manages. With the merge policy, the keys listed as unmanaged in the given ConfigMap are excluded.
+func getManagedKeys(cm *corev1.ConfigMap, data map[string]string, policy argoproj.ArgoCDConfigManagementPolicy) string {
+	unmanaged := map[string]bool{}
+	if policy == argoproj.ArgoCDConfigManagementPolicyMerge {
+		unmanaged = getAnnotatedKeys(cm, common.ArgoCDUnmanagedKeysAnnotation)
+	}
+	managed := make([]string, 0, len(data))
+	for k := range data {
+		if !unmanaged[k] {
+			managed = append(managed, k)
+		}
+	}
+	sort.Strings(managed)
+	return strings.Join(managed, ",")
+}
+
+// mergeArgoConfigMapData will return the data of the given existing ConfigMap merged with the given desired data of
+// the operator. Desired keys replace existing ones, unless they are listed as unmanaged in the annotations of the
+// ConfigMap. Keys the operator set on its last update but no longer desires are removed, while the keys added
+// out-of-band are kept.
+func mergeArgoConfigMapData(existing *corev1.ConfigMap, desired map[string]string) map[string]string {
+	unmanaged := getAnnotatedKeys(existing, common.ArgoCDUnmanagedKeysAnnotation)
+	previouslyManaged := getAnnotatedKeys(existing, common.ArgoCDManagedKeysAnnotation)
+
+	data := make(map[string]string, len(existing.Data)+len(desired))
+	for k, v := range existing.Data {
+		if _, ok := desired[k]; !ok && previouslyManaged[k] && !unmanaged[k] {
+			continue
+		}
+		data[k] = v
+	}
+	for k, v := range desired {
+		if _, ok := existing.Data[k]; ok && unmanaged[k] {
+			continue
+		}
+		data[k] = v
+	}
+	return data
+}
+
 // reconcileGrafanaConfiguration will ensure that the Grafana configuration ConfigMap is present.
 func (r *ReconcileArgoCD) reconcileGrafanaConfiguration(cr *argoproj.ArgoCD) error {
 	if !cr.Spec.Grafana.Enabled {
 		return nil // Grafana not enabled, do nothing.
 	}
 
-	log.Info(grafanaDeprecatedWarning)
+	instanceLog(cr).Info(grafanaDeprecatedWarning)
 
 	return nil
 }
@@ -491,7 +679,7 @@ func (r *ReconcileArgoCD) reconcileGrafanaDashboards(cr *argoproj.ArgoCD) error
 		return nil // Grafana not enabled, do nothing.
 	}
 
-	log.Info(grafanaDeprecatedWarning)
+	instanceLog(cr).Info(grafanaDeprecatedWarning)
 
 	return nil
 }
@@ -499,6 +687,21 @@ func (r *ReconcileArgoCD) reconcileGrafanaDashboards(cr *argoproj.ArgoCD) error
 // reconcileRBAC will ensure that the ArgoCD RBAC ConfigMap is present.
 func (r *ReconcileArgoCD) reconcileRBAC(cr *argoproj.ArgoCD) error {
 	cm := newConfigMapWithName(common.ArgoCDRBACConfigMapName, cr)
+	if err := validateRBACPolicy(getRBACPolicy(cr)); err != nil {
+		return &invalidConfigError{configMap: cm.Name, err: fmt.Errorf("%s: %w", common.ArgoCDKeyRBACPolicyCSV, err)}
+	}
+	for i, chunk := range cr.Spec.RBAC.PolicyChunks {
+		key := fmt.Sprintf(common.ArgoCDKeyRBACPolicyChunkCSVFormat, i+1)
+		if err := validateRBACPolicy(chunk.Policy); err != nil {
+			return &invalidConfigError{configMap: cm.Name, err: fmt.Errorf("%s: %w", key, err)}
+		}
+		if chunk.Project == "" {
+			continue
+		}
+		if err := validateRBACPolicyScope(chunk.Policy, chunk.Project); err != nil {
+			return &invalidConfigError{configMap: cm.Name, err: fmt.Errorf("%s: %w", key, err)}
+		}
+	}
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
 		return r.reconcileRBACConfigMap(cm, cr)
 	}
@@ -532,13 +735,47 @@ func (r *ReconcileArgoCD) reconcileRBACConfigMap(cm *corev1.ConfigMap, cr *argop
 		changed = true
 	}
 
+	// Policy Chunks
+	chunks := getRBACPolicyChunks(cr)
+	for key := range cm.Data {
+		if _, ok := chunks[key]; !ok && rbacPolicyChunkKeyPattern.MatchString(key) {
+			delete(cm.Data, key)
+			changed = true
+		}
+	}
+	for key, chunk := range chunks {
+		if value, ok := cm.Data[key]; !ok || value != chunk {
+			if cm.Data == nil {
+				cm.Data = make(map[string]string)
+			}
+			cm.Data[key] = chunk
+			changed = true
+		}
+	}
+
 	if changed {
 		// TODO: Reload server (and dex?) if RBAC settings change?
-		return r.Client.Update(context.TODO(), cm)
+		if err := r.Client.Update(context.TODO(), cm); err != nil {
+			return err
+		}
+		return r.reportRBACPolicySize(cr)
 	}
 	return nil // ConfigMap exists and nothing to do, move along...
 }
 
+// reportRBACPolicySize will emit a Warning Event on the given ArgoCD when its RBAC policy gets close to the size limit
+// of the RBAC ConfigMap. It is called when the ConfigMap is written, so that the warning is not repeated on each
+// reconciliation.
+func (r *ReconcileArgoCD) reportRBACPolicySize(cr *argoproj.ArgoCD) error {
+	message := getRBACPolicySizeWarning(cr)
+	if message == "" {
+		return nil
+	}
+	instanceLog(cr).Info(fmt.Sprintf("ArgoCD %s/%s: %s", cr.Namespace, cr.Name, message))
+	r.recordEvent(cr, corev1.EventTypeWarning, "RBACPolicySize", message)
+	return nil
+}
+
 // reconcileRedisConfiguration will ensure that all of the Redis ConfigMaps are present for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileRedisConfiguration(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
 	if err := r.reconcileRedisHAConfigMap(cr, useTLSForRedis); err != nil {
@@ -554,21 +791,39 @@ func (r *ReconcileArgoCD) reconcileRedisConfiguration(cr *argoproj.ArgoCD, useTL
 func (r *ReconcileArgoCD) reconcileRedisHAHealthConfigMap(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
 	cm := newConfigMapWithName(common.ArgoCDRedisHAHealthConfigMapName, cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
-		if !cr.Spec.HA.Enabled {
+		if !wantsRedisHA(cr) {
 			// ConfigMap exists but HA enabled flag has been set to false, delete the ConfigMap
 			return r.Client.Delete(context.TODO(), cm)
 		}
+		// Changing the ports changes the scripts
+		changed := false
+		for key, script := range map[string]string{
+			"redis_liveness.sh":    getRedisLivenessScript(cr, useTLSForRedis),
+			"redis_readiness.sh":   getRedisReadinessScript(cr, useTLSForRedis),
+			"sentinel_liveness.sh": getSentinelLivenessScript(cr, useTLSForRedis),
+		} {
+			if script != "" && cm.Data[key] != script {
+				if cm.Data == nil {
+					cm.Data = make(map[string]string)
+				}
+				cm.Data[key] = script
+				changed = true
+			}
+		}
+		if changed {
+			return r.Client.Update(context.TODO(), cm)
+		}
 		return nil // ConfigMap found with nothing changed, move along...
 	}
 
-	if !cr.Spec.HA.Enabled {
+	if !wantsRedisHA(cr) {
 		return nil // HA not enabled, do nothing.
 	}
 
 	cm.Data = map[string]string{
-		"redis_liveness.sh":    getRedisLivenessScript(useTLSForRedis),
-		"redis_readiness.sh":   getRedisReadinessScript(useTLSForRedis),
-		"sentinel_liveness.sh": getSentinelLivenessScript(useTLSForRedis),
+		"redis_liveness.sh":    getRedisLivenessScript(cr, useTLSForRedis),
+		"redis_readiness.sh":   getRedisReadinessScript(cr, useTLSForRedis),
+		"sentinel_liveness.sh": getSentinelLivenessScript(cr, useTLSForRedis),
 	}
 
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
@@ -581,14 +836,33 @@ func (r *ReconcileArgoCD) reconcileRedisHAHealthConfigMap(cr *argoproj.ArgoCD, u
 func (r *ReconcileArgoCD) reconcileRedisHAConfigMap(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
 	cm := newConfigMapWithName(common.ArgoCDRedisHAConfigMapName, cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
-		if !cr.Spec.HA.Enabled {
+		if !wantsRedisHA(cr) {
 			// ConfigMap exists but HA enabled flag has been set to false, delete the ConfigMap
 			return r.Client.Delete(context.TODO(), cm)
 		}
+		// Toggling the HAProxy metrics endpoint or IPv6, or changing the ports or the HAProxy settings, changes the configuration
+		changed := false
+		for key, conf := range map[string]string{
+			"haproxy.cfg":   getRedisHAProxyConfig(cr, useTLSForRedis),
+			"init.sh":       getRedisInitScript(cr, useTLSForRedis),
+			"redis.conf":    getRedisConf(cr, useTLSForRedis),
+			"sentinel.conf": getRedisSentinelConf(cr, useTLSForRedis),
+		} {
+			if conf != "" && cm.Data[key] != conf {
+				if cm.Data == nil {
+					cm.Data = make(map[string]string)
+				}
+				cm.Data[key] = conf
+				changed = true
+			}
+		}
+		if changed {
+			return r.Client.Update(context.TODO(), cm)
+		}
 		return nil // ConfigMap found with nothing changed, move along...
 	}
 
-	if !cr.Spec.HA.Enabled {
+	if !wantsRedisHA(cr) {
 		return nil // HA not enabled, do nothing.
 	}
 
@@ -596,8 +870,8 @@ func (r *ReconcileArgoCD) reconcileRedisHAConfigMap(cr *argoproj.ArgoCD, useTLSF
 		"haproxy.cfg":     getRedisHAProxyConfig(cr, useTLSForRedis),
 		"haproxy_init.sh": getRedisHAProxyScript(cr),
 		"init.sh":         getRedisInitScript(cr, useTLSForRedis),
-		"redis.conf":      getRedisConf(useTLSForRedis),
-		"sentinel.conf":   getRedisSentinelConf(useTLSForRedis),
+		"redis.conf":      getRedisConf(cr, useTLSForRedis),
+		"sentinel.conf":   getRedisSentinelConf(cr, useTLSForRedis),
 	}
 
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
@@ -630,7 +904,19 @@ func (r *ReconcileArgoCD) recreateRedisHAHealthConfigMap(cr *argoproj.ArgoCD, us
 func (r *ReconcileArgoCD) reconcileSSHKnownHosts(cr *argoproj.ArgoCD) error {
 	cm := newConfigMapWithName(common.ArgoCDKnownHostsConfigMapName, cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
-		return nil // ConfigMap found, move along...
+		if cr.Spec.InitialSSHKnownHosts.RefreshInterval == nil {
+			return nil // ConfigMap found and not managed after creation, move along...
+		}
+		skh := getInitialSSHKnownHosts(cr)
+		if cm.Data[common.ArgoCDKeySSHKnownHosts] != skh {
+			if cm.Data == nil {
+				cm.Data = make(map[string]string)
+			}
+			cm.Data[common.ArgoCDKeySSHKnownHosts] = skh
+			instanceLog(cr).Info(fmt.Sprintf("updating SSH known hosts ConfigMap %s", cm.Name))
+			return r.Client.Update(context.TODO(), cm)
+		}
+		return nil
 	}
 
 	cm.Data = map[string]string{
@@ -658,12 +944,27 @@ func (r *ReconcileArgoCD) reconcileTLSCerts(cr *argoproj.ArgoCD) error {
 	return r.Client.Create(context.TODO(), cm)
 }
 
-// reconcileGPGKeysConfigMap creates a gpg-keys config map
+// gpgKeyIDPattern matches the 16 hexadecimal digits ID of a GPG key.
+var gpgKeyIDPattern = regexp.MustCompile(`^[0-9A-Fa-f]{16}$`)
+
+// reconcileGPGKeysConfigMap creates a gpg-keys config map. When GPG keys are set in the spec, they are kept in sync
+// with the ConfigMap, otherwise the keys added through Argo CD are left untouched.
 func (r *ReconcileArgoCD) reconcileGPGKeysConfigMap(cr *argoproj.ArgoCD) error {
+	for id := range cr.Spec.GPGKeys {
+		if !gpgKeyIDPattern.MatchString(id) {
+			return fmt.Errorf("invalid GPG key ID %q in spec.gpgKeys, expected 16 hexadecimal digits", id)
+		}
+	}
+
 	cm := newConfigMapWithName(common.ArgoCDGPGKeysConfigMapName, cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
-		return nil
+		if cr.Spec.GPGKeys == nil || (len(cm.Data) == 0 && len(cr.Spec.GPGKeys) == 0) || reflect.DeepEqual(cm.Data, cr.Spec.GPGKeys) {
+			return nil
+		}
+		cm.Data = cr.Spec.GPGKeys
+		return r.Client.Update(context.TODO(), cm)
 	}
+	cm.Data = cr.Spec.GPGKeys
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
 		return err
 	}
@@ -18,7 +18,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
@@ -339,6 +341,40 @@ func TestReconcileArgoCD_reconcileArgoConfigMap_withDisableAdmin(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_reconcileArgoConfigMap_withSessionDuration(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Session = &argoproj.ArgoCDServerSessionSpec{
+			MaxAge: &metav1.Duration{Duration: 8 * time.Hour},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDConfigMapName,
+		Namespace: testNamespace,
+	}, cm))
+	assert.Equal(t, "8h0m0s", cm.Data[common.ArgoCDKeyUsersSessionDuration])
+
+	// Removing the option restores the Argo CD default
+	a.Spec.Server.Session = nil
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDConfigMapName,
+		Namespace: testNamespace,
+	}, cm))
+	assert.NotContains(t, cm.Data, common.ArgoCDKeyUsersSessionDuration)
+}
+
 func TestReconcileArgoCD_reconcileArgoConfigMap_withDexConnector(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 
@@ -464,6 +500,67 @@ func TestReconcileArgoCD_reconcileArgoConfigMap_withDexConnector(t *testing.T) {
 
 }
 
+func TestReconcileArgoCD_reconcileArgoConfigMap_withDexStaticClients(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex: &argoproj.ArgoCDDexSpec{
+				Config: "staticClients:\n- id: existing\n  secret: existing-secret\n",
+				StaticClients: []argoproj.ArgoCDDexStaticClient{
+					{
+						ID:           "cli",
+						RedirectURIs: []string{"http://localhost:8085/auth/callback"},
+						Public:       true,
+					},
+					{
+						ID:   "dashboard",
+						Name: "Dashboard",
+						SecretRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "dashboard-oidc"},
+							Key:                  "clientSecret",
+						},
+						RedirectURIs: []string{"https://dashboard.example.com/callback"},
+					},
+				},
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDConfigMapName,
+		Namespace: testNamespace,
+	}, cm))
+
+	m := make(map[string]interface{})
+	assert.NoError(t, yaml.Unmarshal([]byte(cm.Data[common.ArgoCDKeyDexConfig]), &m))
+
+	clients := m["staticClients"].([]interface{})
+	assert.Len(t, clients, 3)
+	assert.Equal(t, "existing", clients[0].(map[interface{}]interface{})["id"])
+
+	cli := clients[1].(map[interface{}]interface{})
+	assert.Equal(t, "cli", cli["id"])
+	assert.Equal(t, true, cli["public"])
+	assert.NotContains(t, cli, "secret")
+
+	dashboard := clients[2].(map[interface{}]interface{})
+	assert.Equal(t, "Dashboard", dashboard["name"])
+	assert.Equal(t, "$dashboard-oidc:clientSecret", dashboard["secret"])
+	assert.Equal(t, []interface{}{"https://dashboard.example.com/callback"}, dashboard["redirectURIs"])
+}
+
 func TestReconcileArgoCD_reconcileArgoConfigMap_withDexDisabled(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 
@@ -671,6 +768,37 @@ func TestReconcileArgoCD_reconcileGPGKeysConfigMap(t *testing.T) {
 	// Currently the gpg keys configmap is empty
 }
 
+func TestReconcileArgoCD_reconcileGPGKeysConfigMap_withGPGKeys(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// Keys added through Argo CD are left untouched when no key is set in the spec
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: common.ArgoCDGPGKeysConfigMapName, Namespace: testNamespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	cm.Data = map[string]string{"4AEE18F83AFDEB23": "-----BEGIN PGP PUBLIC KEY BLOCK-----"}
+	assert.NoError(t, r.Client.Update(context.TODO(), cm))
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Len(t, cm.Data, 1)
+
+	a.Spec.GPGKeys = map[string]string{"6D3D1B7E0E23FB5A": "-----BEGIN PGP PUBLIC KEY BLOCK-----"}
+	assert.NoError(t, r.reconcileGPGKeysConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Equal(t, a.Spec.GPGKeys, cm.Data)
+
+	a.Spec.GPGKeys = map[string]string{"signing-key": "-----BEGIN PGP PUBLIC KEY BLOCK-----"}
+	assert.ErrorContains(t, r.reconcileGPGKeysConfigMap(a), "invalid GPG key ID")
+}
+
 func TestReconcileArgoCD_reconcileArgoConfigMap_withResourceTrackingMethod(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
@@ -844,17 +972,17 @@ managedfieldsmanagers:
 		{
 			Group:  "actionsFoo",
 			Kind:   "actionsFoo",
-			Action: "actionsFoo",
+			Action: "discovery.lua: actionsFoo",
 		},
 		{
 			Group:  "actionsBar",
 			Kind:   "actionsBar",
-			Action: "actionsBar",
+			Action: "discovery.lua: actionsBar",
 		},
 		{
 			Group:  "",
 			Kind:   "actionsFooBar",
-			Action: "actionsFooBar",
+			Action: "discovery.lua: actionsFooBar",
 		},
 	}
 	ignoreDifferences := argoproj.ResourceIgnoreDifference{
@@ -912,9 +1040,9 @@ managedfieldsmanagers:
 	desiredCM["resource.customizations.health.healthFoo_healthFoo"] = "healthFoo"
 	desiredCM["resource.customizations.health.healthBar_healthBar"] = "healthBar"
 	desiredCM["resource.customizations.health.healthFooBar"] = "healthFooBar"
-	desiredCM["resource.customizations.actions.actionsFoo_actionsFoo"] = "actionsFoo"
-	desiredCM["resource.customizations.actions.actionsBar_actionsBar"] = "actionsBar"
-	desiredCM["resource.customizations.actions.actionsFooBar"] = "actionsFooBar"
+	desiredCM["resource.customizations.actions.actionsFoo_actionsFoo"] = "discovery.lua: actionsFoo"
+	desiredCM["resource.customizations.actions.actionsBar_actionsBar"] = "discovery.lua: actionsBar"
+	desiredCM["resource.customizations.actions.actionsFooBar"] = "discovery.lua: actionsFooBar"
 	desiredCM["resource.customizations.ignoreDifferences.all"] = desiredIgnoreDifferenceCustomization
 	desiredCM["resource.customizations.ignoreDifferences.ignoreDiffBar_ignoreDiffBar"] = desiredIgnoreDifferenceCustomization
 	desiredCM["resource.customizations.ignoreDifferences.ignoreDiffFoo"] = desiredIgnoreDifferenceCustomization
@@ -926,6 +1054,41 @@ managedfieldsmanagers:
 	}
 }
 
+func TestReconcileArgoCD_reconcileArgoConfigMap_withResourceCompareOptions(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ResourceCompareOptions = &argoproj.ResourceCompareOptions{
+			IgnoreAggregatedRoles:     true,
+			IgnoreResourceStatusField: argoproj.ResourceStatusFieldIgnoreAll,
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDConfigMapName,
+		Namespace: testNamespace,
+	}, cm))
+	assert.Equal(t, "ignoreAggregatedRoles: true\nignoreResourceStatusField: all\n", cm.Data[common.ArgoCDKeyResourceCompareOptions])
+
+	// Removing the options restores the Argo CD defaults
+	a.Spec.ResourceCompareOptions = &argoproj.ResourceCompareOptions{}
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDConfigMapName,
+		Namespace: testNamespace,
+	}, cm))
+	assert.NotContains(t, cm.Data, common.ArgoCDKeyResourceCompareOptions)
+}
+
 func TestReconcileArgoCD_reconcileArgoConfigMap_withExtraConfig(t *testing.T) {
 	a := makeTestArgoCD()
 
@@ -1040,3 +1203,115 @@ func Test_reconcileRBAC(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, cm.Data["policy.matchMode"], matcherMode)
 }
+
+func Test_reconcileRBAC_policyChunks(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RBAC.PolicyChunks = []argoproj.ArgoCDRBACPolicyChunkSpec{
+			{Policy: "g, platform, role:admin"},
+			{Project: "team-a", Policy: "p, role:team-a, applications, *, team-a/*, allow\np, role:team-a, projects, get, team-a, allow"},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRBAC(a))
+
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: common.ArgoCDRBACConfigMapName, Namespace: testNamespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Equal(t, "g, platform, role:admin", cm.Data["policy.001.csv"])
+	assert.Contains(t, cm.Data["policy.002.csv"], "role:team-a")
+
+	// Chunks removed from the instance are removed from the ConfigMap, other policy keys are kept
+	cm.Data["policy.extra.csv"] = "g, auditors, role:readonly"
+	assert.NoError(t, r.Client.Update(context.TODO(), cm))
+	a.Spec.RBAC.PolicyChunks = a.Spec.RBAC.PolicyChunks[1:]
+	assert.NoError(t, r.reconcileRBAC(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Contains(t, cm.Data["policy.001.csv"], "role:team-a")
+	assert.NotContains(t, cm.Data, "policy.002.csv")
+	assert.Equal(t, "g, auditors, role:readonly", cm.Data["policy.extra.csv"])
+
+	// A chunk granting access outside of its project is refused, the ConfigMap is left untouched
+	a.Spec.RBAC.PolicyChunks[0].Policy = "p, role:team-a, applications, *, */*, allow"
+	err := r.reconcileRBAC(a)
+	assert.True(t, isInvalidConfigError(err))
+	assert.ErrorContains(t, err, "policy.001.csv: line 1")
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Contains(t, cm.Data["policy.001.csv"], "team-a/*")
+}
+
+func TestGetRBACPolicySizeWarning(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RBAC.PolicyChunks = []argoproj.ArgoCDRBACPolicyChunkSpec{
+			{Policy: strings.Repeat("g, team, role:readonly\n", 20000)},
+		}
+	})
+	assert.Empty(t, getRBACPolicySizeWarning(a))
+
+	a.Spec.RBAC.PolicyChunks = append(a.Spec.RBAC.PolicyChunks, a.Spec.RBAC.PolicyChunks[0], a.Spec.RBAC.PolicyChunks[0])
+	assert.Contains(t, getRBACPolicySizeWarning(a), "close to the 1MiB size limit of the argocd-rbac-cm ConfigMap")
+}
+
+func TestReconcileArgoCD_reconcileArgoConfigMap_configManagementPolicy(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ConfigManagementPolicy = argoproj.ArgoCDConfigManagementPolicyMerge
+		a.Spec.ExtraConfig = map[string]string{"ping": "pong"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Contains(t, strings.Split(cm.Annotations[common.ArgoCDManagedKeysAnnotation], ","), "ping")
+
+	// Keys added out-of-band and keys annotated as unmanaged are preserved, operator keys are enforced
+	cm.Annotations[common.ArgoCDUnmanagedKeysAnnotation] = "users.anonymous.enabled"
+	cm.Data["accounts.alice"] = "login"
+	cm.Data["users.anonymous.enabled"] = "true"
+	cm.Data["admin.enabled"] = "false"
+	assert.NoError(t, r.Client.Update(context.TODO(), cm))
+
+	// Keys the operator no longer generates are removed
+	a.Spec.ExtraConfig = nil
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Equal(t, "login", cm.Data["accounts.alice"])
+	assert.Equal(t, "true", cm.Data["users.anonymous.enabled"])
+	assert.Equal(t, "true", cm.Data["admin.enabled"])
+	assert.NotContains(t, cm.Data, "ping")
+	managed := strings.Split(cm.Annotations[common.ArgoCDManagedKeysAnnotation], ",")
+	assert.Contains(t, managed, "admin.enabled")
+	assert.NotContains(t, managed, "users.anonymous.enabled")
+	assert.NotContains(t, managed, "accounts.alice")
+
+	// With ignore, the ConfigMap is left untouched
+	a.Spec.ConfigManagementPolicy = argoproj.ArgoCDConfigManagementPolicyIgnore
+	a.Spec.DisableAdmin = true
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Equal(t, "true", cm.Data["admin.enabled"])
+
+	// With enforce, only the keys generated by the operator are kept
+	a.Spec.ConfigManagementPolicy = argoproj.ArgoCDConfigManagementPolicyEnforce
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Equal(t, "false", cm.Data["admin.enabled"])
+	assert.Equal(t, "false", cm.Data["users.anonymous.enabled"])
+	assert.NotContains(t, cm.Data, "accounts.alice")
+}
@@ -0,0 +1,185 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+var consoleLinkAPIFound = false
+
+// consoleLinkGVK is the GroupVersionKind of the OpenShift ConsoleLink resource.
+var consoleLinkGVK = schema.GroupVersionKind{Group: "console.openshift.io", Version: "v1", Kind: "ConsoleLink"}
+
+// defaultConsoleLinkSection is the ApplicationMenu section of the ConsoleLinks created by the operator.
+const defaultConsoleLinkSection = "Argo CD"
+
+// IsConsoleLinkAPIAvailable returns true if the OpenShift ConsoleLink API is present.
+func IsConsoleLinkAPIAvailable() bool {
+	return consoleLinkAPIFound
+}
+
+// verifyConsoleLinkAPI will verify that the OpenShift ConsoleLink API is present.
+func verifyConsoleLinkAPI() error {
+	found, err := argoutil.VerifyAPI(consoleLinkGVK.Group, consoleLinkGVK.Version)
+	if err != nil {
+		return err
+	}
+	consoleLinkAPIFound = found
+	return nil
+}
+
+// isConsoleLinkEnabled returns true if a ConsoleLink to the Argo CD Server Route is requested for the given ArgoCD.
+func isConsoleLinkEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.OpenShift != nil && cr.Spec.OpenShift.ConsoleLink != nil && cr.Spec.OpenShift.ConsoleLink.Enabled &&
+		cr.Spec.Server.Route.Enabled && isServerEnabled(cr)
+}
+
+// newConsoleLink returns a new, empty ConsoleLink for the given ArgoCD. ConsoleLinks are cluster scoped, so they
+// cannot be owned by the ArgoCD and are removed with the other cluster resources on deletion.
+func newConsoleLink(cr *argoproj.ArgoCD) *unstructured.Unstructured {
+	cl := &unstructured.Unstructured{}
+	cl.SetGroupVersionKind(consoleLinkGVK)
+	cl.SetName(GenerateUniqueResourceName("console-link", cr))
+	cl.SetLabels(argoutil.LabelsForCluster(cr))
+	cl.SetAnnotations(argoutil.AnnotationsForCluster(cr))
+	return cl
+}
+
+// getConsoleLinkSpec returns the ConsoleLink spec pointing at the given Argo CD Server URL.
+func getConsoleLinkSpec(cr *argoproj.ArgoCD, href string) map[string]interface{} {
+	opts := cr.Spec.OpenShift.ConsoleLink
+
+	text := opts.Text
+	if text == "" {
+		text = fmt.Sprintf("Argo CD - %s/%s", cr.Namespace, cr.Name)
+	}
+
+	location := opts.Location
+	if location == "" {
+		location = argoproj.ArgoCDConsoleLinkLocationApplicationMenu
+	}
+
+	spec := map[string]interface{}{
+		"href":     href,
+		"text":     text,
+		"location": string(location),
+	}
+
+	switch location {
+	case argoproj.ArgoCDConsoleLinkLocationApplicationMenu:
+		section := opts.Section
+		if section == "" {
+			section = defaultConsoleLinkSection
+		}
+		menu := map[string]interface{}{"section": section}
+		if opts.ImageURL != "" {
+			menu["imageURL"] = opts.ImageURL
+		}
+		spec["applicationMenu"] = menu
+	case argoproj.ArgoCDConsoleLinkLocationNamespaceDashboard:
+		spec["namespaceDashboard"] = map[string]interface{}{
+			"namespaces": []interface{}{cr.Namespace},
+		}
+	}
+
+	return spec
+}
+
+// getArgoServerRouteURL will return the URL of the Argo CD Server Route, or an empty string if the Route has no host.
+func (r *ReconcileArgoCD) getArgoServerRouteURL(cr *argoproj.ArgoCD) string {
+	route := newRouteWithSuffix("server", cr)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, route.Name, route) || route.Spec.Host == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://%s", route.Spec.Host)
+}
+
+// reconcileConsoleLink will ensure that the ConsoleLink to the Argo CD Server Route is present when requested, and
+// removed otherwise.
+func (r *ReconcileArgoCD) reconcileConsoleLink(cr *argoproj.ArgoCD) error {
+	if !IsConsoleLinkAPIAvailable() {
+		return nil
+	}
+
+	href := ""
+	if isConsoleLinkEnabled(cr) {
+		href = r.getArgoServerRouteURL(cr)
+	}
+
+	existing := newConsoleLink(cr)
+	if argoutil.IsObjectFound(r.Client, "", existing.GetName(), existing) {
+		if href == "" {
+			// ConsoleLink found but disabled, or the Server Route is gone, delete it.
+			instanceLog(cr).Info(fmt.Sprintf("deleting ConsoleLink %s", existing.GetName()))
+			return r.Client.Delete(context.TODO(), existing)
+		}
+
+		desired := getConsoleLinkSpec(cr, href)
+		spec, _, err := unstructured.NestedMap(existing.Object, "spec")
+		if err != nil {
+			return err
+		}
+		if reflect.DeepEqual(spec, desired) {
+			return nil
+		}
+		if err := unstructured.SetNestedMap(existing.Object, desired, "spec"); err != nil {
+			return err
+		}
+		instanceLog(cr).Info(fmt.Sprintf("updating ConsoleLink %s", existing.GetName()))
+		return r.Client.Update(context.TODO(), existing)
+	}
+
+	if href == "" {
+		return nil // ConsoleLink not enabled, or the Server Route has no host yet, move along...
+	}
+
+	cl := newConsoleLink(cr)
+	if err := unstructured.SetNestedMap(cl.Object, getConsoleLinkSpec(cr, href), "spec"); err != nil {
+		return err
+	}
+	instanceLog(cr).Info(fmt.Sprintf("creating ConsoleLink %s", cl.GetName()))
+	return r.Client.Create(context.TODO(), cl)
+}
+
+// deleteConsoleLinks will delete the ConsoleLinks of the ArgoCD instance matching the given selector.
+func (r *ReconcileArgoCD) deleteConsoleLinks(selector labels.Selector) error {
+	if !IsConsoleLinkAPIAvailable() {
+		return nil
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(consoleLinkGVK.GroupVersion().WithKind(consoleLinkGVK.Kind + "List"))
+	if err := r.Client.List(context.TODO(), list, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("failed to list ConsoleLinks: %w", err)
+	}
+	for i := range list.Items {
+		if err := r.Client.Delete(context.TODO(), &list.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete ConsoleLink %s: %w", list.Items[i].GetName(), err)
+		}
+	}
+	return nil
+}
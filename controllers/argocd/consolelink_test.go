@@ -0,0 +1,118 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestReconcileArgoCD_reconcileConsoleLink(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	consoleLinkAPIFound = true
+	defer func() {
+		consoleLinkAPIFound = false
+	}()
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Route.Enabled = true
+		a.Spec.OpenShift = &argoproj.ArgoCDOpenShiftSpec{
+			ConsoleLink: &argoproj.ArgoCDConsoleLinkSpec{Enabled: true},
+		}
+	})
+	route := newRouteWithSuffix("server", a)
+	route.Spec.Host = "argocd.apps.example.com"
+
+	resObjs := []client.Object{a, route}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, routev1.Install)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	getConsoleLink := func() (*unstructured.Unstructured, error) {
+		link := newConsoleLink(a)
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: link.GetName()}, link)
+		return link, err
+	}
+
+	assert.NoError(t, r.reconcileConsoleLink(a))
+
+	link, err := getConsoleLink()
+	assert.NoError(t, err)
+	href, _, _ := unstructured.NestedString(link.Object, "spec", "href")
+	assert.Equal(t, "https://argocd.apps.example.com", href)
+	location, _, _ := unstructured.NestedString(link.Object, "spec", "location")
+	assert.Equal(t, "ApplicationMenu", location)
+	section, _, _ := unstructured.NestedString(link.Object, "spec", "applicationMenu", "section")
+	assert.Equal(t, defaultConsoleLinkSection, section)
+
+	// Changes to the options are synced to the ConsoleLink
+	a.Spec.OpenShift.ConsoleLink.Location = argoproj.ArgoCDConsoleLinkLocationNamespaceDashboard
+	a.Spec.OpenShift.ConsoleLink.Text = "GitOps"
+	assert.NoError(t, r.reconcileConsoleLink(a))
+	link, err = getConsoleLink()
+	assert.NoError(t, err)
+	text, _, _ := unstructured.NestedString(link.Object, "spec", "text")
+	assert.Equal(t, "GitOps", text)
+	namespaces, _, _ := unstructured.NestedStringSlice(link.Object, "spec", "namespaceDashboard", "namespaces")
+	assert.Equal(t, []string{a.Namespace}, namespaces)
+	_, found, _ := unstructured.NestedMap(link.Object, "spec", "applicationMenu")
+	assert.False(t, found)
+
+	// Disabling the Server Route removes the ConsoleLink
+	a.Spec.Server.Route.Enabled = false
+	assert.NoError(t, r.reconcileConsoleLink(a))
+	_, err = getConsoleLink()
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileArgoCD_reconcileConsoleLink_noConsoleLinkAPI(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	consoleLinkAPIFound = false
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Route.Enabled = true
+		a.Spec.OpenShift = &argoproj.ArgoCDOpenShiftSpec{
+			ConsoleLink: &argoproj.ArgoCDConsoleLinkSpec{Enabled: true},
+		}
+	})
+	route := newRouteWithSuffix("server", a)
+	route.Spec.Host = "argocd.apps.example.com"
+
+	resObjs := []client.Object{a, route}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, routev1.Install)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileConsoleLink(a))
+
+	link := newConsoleLink(a)
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: link.GetName()}, link)
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// applicationControllerPersistenceVolumeName is the name of the volume persisting the Application Controller state.
+const applicationControllerPersistenceVolumeName = "persistence"
+
+// isApplicationControllerPersistenceEnabled returns true if the Application Controller state is persisted.
+func isApplicationControllerPersistenceEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Controller.Persistence != nil && cr.Spec.Controller.Persistence.Enabled
+}
+
+// getApplicationControllerPersistenceVolumeMount will return the mount of the volume persisting the Application
+// Controller state.
+func getApplicationControllerPersistenceVolumeMount(cr *argoproj.ArgoCD) corev1.VolumeMount {
+	mountPath := common.ArgoCDDefaultControllerPersistenceMountPath
+	if cr.Spec.Controller.Persistence.MountPath != "" {
+		mountPath = cr.Spec.Controller.Persistence.MountPath
+	}
+	return corev1.VolumeMount{
+		Name:      applicationControllerPersistenceVolumeName,
+		MountPath: mountPath,
+	}
+}
+
+// getApplicationControllerVolumeClaimTemplates will return the volume claim templates of the Application Controller
+// StatefulSet, which give each replica its own volume, kept across restarts.
+func getApplicationControllerVolumeClaimTemplates(cr *argoproj.ArgoCD) []corev1.PersistentVolumeClaim {
+	if !isApplicationControllerPersistenceEnabled(cr) {
+		return nil
+	}
+	opts := cr.Spec.Controller.Persistence
+
+	size := resource.MustParse(common.ArgoCDDefaultControllerPersistenceCapacity)
+	if opts.Size != nil {
+		size = *opts.Size
+	}
+
+	volumeMode := corev1.PersistentVolumeFilesystem
+	return []corev1.PersistentVolumeClaim{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   applicationControllerPersistenceVolumeName,
+			Labels: argoutil.DefaultLabelsForCluster(cr, nameWithSuffix("application-controller", cr)),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: opts.StorageClass,
+			VolumeMode:       &volumeMode,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+		},
+	}}
+}
+
+// volumeClaimTemplatesChanged returns true if the given existing volume claim templates of a StatefulSet differ from
+// the desired ones. Only the fields set by the operator are compared, as the API server defaults the others.
+func volumeClaimTemplatesChanged(existing, desired []corev1.PersistentVolumeClaim) bool {
+	if len(existing) != len(desired) {
+		return true
+	}
+	for i := range desired {
+		e, d := existing[i], desired[i]
+		if e.Name != d.Name {
+			return true
+		}
+		if (e.Spec.StorageClassName == nil) != (d.Spec.StorageClassName == nil) ||
+			(d.Spec.StorageClassName != nil && *e.Spec.StorageClassName != *d.Spec.StorageClassName) {
+			return true
+		}
+		if e.Spec.Resources.Requests.Storage().Cmp(*d.Spec.Resources.Requests.Storage()) != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"hash/fnv"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	// inClusterName is the name of the cluster Argo CD runs in, when it is not declared by a cluster Secret.
+	inClusterName = "in-cluster"
+
+	// inClusterServer is the API server URL of the cluster Argo CD runs in.
+	inClusterServer = "https://kubernetes.default.svc"
+)
+
+// shardedCluster is a cluster managed by an Argo CD instance, as seen by the sharding of its application controller.
+type shardedCluster struct {
+	// id is the UID of the cluster Secret, empty for the in-cluster cluster not declared by a Secret.
+	id   string
+	name string
+	// shard is the shard the cluster is pinned to in its Secret, nil if not pinned.
+	shard *int
+}
+
+// getShardedClusters will return the clusters managed by the given ArgoCD, from its cluster Secrets and the cluster it
+// runs in, unless declared by a Secret or disabled in argocd-cm.
+func (r *ReconcileArgoCD) getShardedClusters(cr *argoproj.ArgoCD) ([]shardedCluster, error) {
+	secrets, err := r.getClusterSecrets(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]shardedCluster, 0, len(secrets.Items)+1)
+	inCluster := cr.Spec.ExtraConfig["cluster.inClusterEnabled"] != "false"
+	for _, secret := range secrets.Items {
+		cluster := shardedCluster{id: string(secret.UID), name: string(secret.Data["name"])}
+		if cluster.name == "" {
+			cluster.name = string(secret.Data["server"])
+		}
+		if shard, err := strconv.Atoi(strings.TrimSpace(string(secret.Data["shard"]))); err == nil && shard >= 0 {
+			cluster.shard = &shard
+		}
+		if strings.TrimSuffix(string(secret.Data["server"]), "/") == inClusterServer {
+			inCluster = false
+		}
+		clusters = append(clusters, cluster)
+	}
+	if inCluster {
+		clusters = append(clusters, shardedCluster{name: inClusterName})
+	}
+	return clusters, nil
+}
+
+// getClusterShard will return the shard of the given cluster among the given number of replicas with the legacy
+// algorithm of Argo CD: the FNV-1a hash of the cluster ID, unless the cluster is pinned to a shard.
+func getClusterShard(cluster shardedCluster, replicas int) int {
+	if cluster.shard != nil && *cluster.shard < replicas {
+		return *cluster.shard
+	}
+	if cluster.id == "" {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(cluster.id))
+	return int(h.Sum32() % uint32(replicas))
+}
+
+// getControllerShards will return the names of the given clusters assigned to each of the given number of shards,
+// as computed by the application controller with the given sharding algorithm. It returns nil for the
+// consistent-hashing algorithm, whose assignment depends on the state of the controller.
+func getControllerShards(clusters []shardedCluster, replicas int, algorithm string) map[string]string {
+	if replicas < 1 || algorithm == argoproj.ShardingAlgorithmConsistentHashing {
+		return nil
+	}
+
+	sorted := make([]shardedCluster, len(clusters))
+	copy(sorted, clusters)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].id < sorted[j].id })
+
+	names := map[int][]string{}
+	for i, cluster := range sorted {
+		shard := getClusterShard(cluster, replicas)
+		if algorithm == argoproj.ShardingAlgorithmRoundRobin && (cluster.shard == nil || *cluster.shard >= replicas) {
+			shard = i % replicas
+		}
+		names[shard] = append(names[shard], cluster.name)
+	}
+
+	shards := make(map[string]string, replicas)
+	for shard := 0; shard < replicas; shard++ {
+		sort.Strings(names[shard])
+		shards[strconv.Itoa(shard)] = strings.Join(names[shard], ",")
+	}
+	return shards
+}
+
+// reconcileStatusControllerShards will ensure that the controller shards status lists the clusters assigned to each
+// shard of the application controller of the given ArgoCD, when it is sharded.
+func (r *ReconcileArgoCD) reconcileStatusControllerShards(cr *argoproj.ArgoCD) error {
+	var shards map[string]string
+	if isControllerShardingEnabled(cr) {
+		clusters, err := r.getShardedClusters(cr)
+		if err != nil {
+			return err
+		}
+		algorithm := cr.Spec.Controller.Sharding.Algorithm
+		if algorithm == "" {
+			algorithm = common.ArgoCDDefaultControllerShardingAlgorithm
+		}
+		shards = getControllerShards(clusters, int(r.getApplicationControllerReplicaCount(cr)), algorithm)
+	}
+
+	// Compare with the stored status, where an empty map is omitted
+	if len(shards) == 0 && len(cr.Status.ControllerShards) == 0 || reflect.DeepEqual(shards, cr.Status.ControllerShards) {
+		return nil
+	}
+	cr.Status.ControllerShards = shards
+	return r.Client.Status().Update(context.TODO(), cr)
+}
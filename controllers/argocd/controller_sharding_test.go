@@ -0,0 +1,115 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestClusterSecret(name, uid, server, shard string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			UID:       types.UID(uid),
+			Labels:    map[string]string{common.ArgoCDSecretTypeLabel: "cluster"},
+		},
+		Data: map[string][]byte{"name": []byte(name), "server": []byte(server)},
+	}
+	if shard != "" {
+		secret.Data["shard"] = []byte(shard)
+	}
+	return secret
+}
+
+func TestGetControllerShards(t *testing.T) {
+	pinned := 1
+	clusters := []shardedCluster{
+		{id: "c", name: "staging"},
+		{name: inClusterName},
+		{id: "a", name: "prod"},
+		{id: "b", name: "dev", shard: &pinned},
+		{id: "d", name: "qa"},
+	}
+
+	// Round robin assigns the clusters sorted by ID in turn, except the pinned ones
+	assert.Equal(t, map[string]string{"0": "in-cluster,qa", "1": "dev,prod,staging"},
+		getControllerShards(clusters, 2, argoproj.ShardingAlgorithmRoundRobin))
+
+	// Legacy hashes the cluster ID, the in-cluster cluster always runs on the first shard
+	legacy := getControllerShards(clusters, 3, argoproj.ShardingAlgorithmLegacy)
+	assert.Len(t, legacy, 3)
+	assert.Contains(t, legacy["0"], inClusterName)
+	assert.Contains(t, legacy["1"], "dev")
+
+	assert.Nil(t, getControllerShards(clusters, 3, argoproj.ShardingAlgorithmConsistentHashing))
+}
+
+func TestReconcileArgoCD_reconcileStatusControllerShards(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Controller.Sharding = argoproj.ArgoCDApplicationControllerShardSpec{
+			Enabled:   true,
+			Replicas:  2,
+			Algorithm: argoproj.ShardingAlgorithmRoundRobin,
+		}
+	})
+
+	resObjs := []client.Object{
+		a,
+		makeTestClusterSecret("prod", "a", "https://prod.example.com", ""),
+		makeTestClusterSecret("local", "b", "https://kubernetes.default.svc", ""),
+		makeTestClusterSecret("staging", "c", "https://staging.example.com", "0"),
+	}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// The in-cluster cluster is declared by a Secret, and not reported twice
+	assert.NoError(t, r.reconcileStatusControllerShards(a))
+	assert.Equal(t, map[string]string{"0": "prod,staging", "1": "local"}, a.Status.ControllerShards)
+
+	// The status is left untouched while the shards do not change
+	resourceVersion := a.ResourceVersion
+	assert.NoError(t, r.reconcileStatusControllerShards(a))
+	assert.Equal(t, resourceVersion, a.ResourceVersion)
+
+	env := getArgoControllerContainerEnv(a)
+	assert.Contains(t, env, corev1.EnvVar{Name: "ARGOCD_CONTROLLER_SHARDING_ALGORITHM", Value: "round-robin"})
+
+	a.Spec.Controller.Sharding = argoproj.ArgoCDApplicationControllerShardSpec{}
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileStatusControllerShards(a))
+	assert.Nil(t, a.Status.ControllerShards)
+
+	resourceVersion = a.ResourceVersion
+	a.Status.ControllerShards = map[string]string{}
+	assert.NoError(t, r.reconcileStatusControllerShards(a))
+	assert.Equal(t, resourceVersion, a.ResourceVersion)
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// fipsTLSVersions are the minimum TLS versions allowed by the fips crypto policy.
+var fipsTLSVersions = map[string]bool{
+	"1.2": true,
+	"1.3": true,
+}
+
+// fipsTLSCipherSuites are the TLS cipher suites allowed by the fips crypto policy.
+var fipsTLSCipherSuites = map[string]bool{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256": true,
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384": true,
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":   true,
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":   true,
+	"TLS_AES_128_GCM_SHA256":                  true,
+	"TLS_AES_256_GCM_SHA384":                  true,
+}
+
+// isFIPSEnabled returns true if the components of the given ArgoCD are restricted to the FIPS cryptography.
+func isFIPSEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.CryptoPolicy == argoproj.ArgoCDCryptoPolicyFIPS
+}
+
+// getFIPSImage will return the FIPS-validated image of the given component: the image set in the fipsImages of the
+// given ArgoCD, or the image set for the component with the ARGOCD_FIPS_IMAGES environment variable.
+func getFIPSImage(cr *argoproj.ArgoCD, component imageOverrideComponent) string {
+	if cr.Spec.FIPSImages != nil {
+		if img := component.override(*cr.Spec.FIPSImages); img != "" {
+			return img
+		}
+	}
+	for _, item := range strings.Split(os.Getenv(common.ArgoCDFIPSImagesEnvName), ",") {
+		name, img, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if ok && strings.TrimSpace(name) == component.name {
+			return strings.TrimSpace(img)
+		}
+	}
+	return ""
+}
+
+// getCryptoPolicyEnv will return the environment variables running the Go and OpenSSL cryptography of the components
+// of the given ArgoCD in FIPS mode, or nil when the fips crypto policy is not set.
+func getCryptoPolicyEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
+	if !isFIPSEnabled(cr) {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{Name: "GODEBUG", Value: "fips140=on"},
+		{Name: "GOLANG_FIPS", Value: "1"},
+		{Name: "OPENSSL_FORCE_FIPS_MODE", Value: "1"},
+	}
+}
+
+// getCommandArgValue will return the value of the given flag in the given command arguments, set either as
+// --flag=value or as --flag value.
+func getCommandArgValue(args []string, flag string) (string, bool) {
+	for i, arg := range args {
+		if value, ok := strings.CutPrefix(arg, flag+"="); ok {
+			return value, true
+		}
+		if arg == flag && i+1 < len(args) {
+			return args[i+1], true
+		}
+	}
+	return "", false
+}
+
+// getTLSViolations will return the TLS settings of the given component command arguments that the fips crypto
+// policy does not allow.
+func getTLSViolations(component string, args []string) []string {
+	var violations []string
+	if version, ok := getCommandArgValue(args, "--tlsminversion"); ok && !fipsTLSVersions[version] {
+		violations = append(violations, fmt.Sprintf("%s minimum TLS version %s is below 1.2", component, version))
+	}
+	if ciphers, ok := getCommandArgValue(args, "--tlsciphers"); ok {
+		for _, cipher := range strings.Split(ciphers, ":") {
+			if !fipsTLSCipherSuites[cipher] {
+				violations = append(violations, fmt.Sprintf("%s TLS cipher suite %s is not FIPS-approved", component, cipher))
+			}
+		}
+	}
+	return violations
+}
+
+// getCryptoPolicyViolations will return the settings of the given ArgoCD that its fips crypto policy does not
+// allow: components without a FIPS-validated image, and TLS settings below the FIPS requirements, set in the tls
+// spec or with the extra command arguments of the server and repo server. Images set explicitly in the spec are not
+// violations, they are reported as unverified instead.
+func getCryptoPolicyViolations(cr *argoproj.ArgoCD) []string {
+	if !isFIPSEnabled(cr) {
+		return nil
+	}
+
+	var violations []string
+	for _, component := range imageOverrideComponents {
+		if !component.explicit(cr) && getFIPSImage(cr, component) == "" {
+			violations = append(violations, fmt.Sprintf("%s has no FIPS-validated image", component.name))
+		}
+	}
+	violations = append(violations, getTLSViolations("tls", getTLSCommandArgs(cr))...)
+	violations = append(violations, getTLSViolations("server", cr.Spec.Server.ExtraCommandArgs)...)
+	violations = append(violations, getTLSViolations("repo server", cr.Spec.Repo.ExtraRepoCommandArgs)...)
+	return violations
+}
+
+// getCryptoPolicyUnverifiedImages will return the components of the given ArgoCD running an image set explicitly in
+// the spec, which the fips crypto policy cannot verify to be FIPS-validated.
+func getCryptoPolicyUnverifiedImages(cr *argoproj.ArgoCD) []string {
+	if !isFIPSEnabled(cr) {
+		return nil
+	}
+
+	var unverified []string
+	for _, component := range imageOverrideComponents {
+		if component.explicit(cr) {
+			unverified = append(unverified, component.name)
+		}
+	}
+	return unverified
+}
+
+// reconcileStatusCryptoPolicy will ensure that the CryptoPolicyCompliant condition of the given ArgoCD reports the
+// settings that do not comply with its fips crypto policy, the condition being removed without the policy.
+func (r *ReconcileArgoCD) reconcileStatusCryptoPolicy(cr *argoproj.ArgoCD) error {
+	existing := meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionTypeCryptoPolicyCompliant)
+	if !isFIPSEnabled(cr) {
+		if existing == nil {
+			return nil
+		}
+		meta.RemoveStatusCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionTypeCryptoPolicyCompliant)
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeCryptoPolicyCompliant,
+		Status:             metav1.ConditionTrue,
+		Reason:             argoproj.ArgoCDConditionReasonCryptoPolicyCompliant,
+		Message:            "All the settings comply with the fips crypto policy",
+		ObservedGeneration: cr.Generation,
+	}
+	if violations := getCryptoPolicyViolations(cr); len(violations) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = argoproj.ArgoCDConditionReasonCryptoPolicyNonCompliant
+		condition.Message = fmt.Sprintf("The settings do not comply with the fips crypto policy: %s", strings.Join(violations, "; "))
+	}
+	if unverified := getCryptoPolicyUnverifiedImages(cr); len(unverified) > 0 {
+		condition.Message += fmt.Sprintf(". The images set in the spec for %s are unverified, make sure they are FIPS-validated", strings.Join(unverified, ", "))
+	}
+
+	if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason &&
+		existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration {
+		return nil // Nothing changed, move along...
+	}
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	return r.Client.Status().Update(context.TODO(), cr)
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func enableTestFIPS(a *argoproj.ArgoCD) {
+	a.Spec.CryptoPolicy = argoproj.ArgoCDCryptoPolicyFIPS
+}
+
+func TestGetImageOverride_fips(t *testing.T) {
+	t.Setenv(common.ArgoCDFIPSImagesEnvName, "argo=registry.example.com/argocd-fips:v1, redis=registry.example.com/redis-fips:v1")
+
+	// The FIPS images of the operator are only selected by the fips crypto policy
+	a := makeTestArgoCD()
+	assert.NotContains(t, getArgoContainerImage(a), "registry.example.com")
+
+	a = makeTestArgoCD(enableTestFIPS, func(a *argoproj.ArgoCD) {
+		a.Spec.FIPSImages = &argoproj.ArgoCDImageOverridesSpec{Redis: "registry.example.com/redis-fips:v2"}
+	})
+	assert.Equal(t, "registry.example.com/argocd-fips:v1", getArgoContainerImage(a))
+	assert.Equal(t, "registry.example.com/argocd-fips:v1", getApplicationSetContainerImage(a))
+	assert.Equal(t, "registry.example.com/redis-fips:v2", getRedisContainerImage(a))
+	assert.NotContains(t, getDexContainerImage(a), "registry.example.com")
+
+	// Images set explicitly for a component win over the FIPS images
+	a.Spec.Repo.Image = "registry.example.com/repo"
+	a.Spec.Repo.Version = "v1"
+	assert.Equal(t, "registry.example.com/repo:v1", getRepoServerContainerImage(a))
+}
+
+func TestGetCryptoPolicyEnv(t *testing.T) {
+	assert.Nil(t, getCryptoPolicyEnv(makeTestArgoCD()))
+	env := getCryptoPolicyEnv(makeTestArgoCD(enableTestFIPS))
+	assert.Contains(t, env, corev1.EnvVar{Name: "GODEBUG", Value: "fips140=on"})
+	assert.Contains(t, env, corev1.EnvVar{Name: "OPENSSL_FORCE_FIPS_MODE", Value: "1"})
+}
+
+func TestGetCryptoPolicyViolations(t *testing.T) {
+	t.Setenv(common.ArgoCDFIPSImagesEnvName, "argo=a,dex=d,redis=r,redisHAProxy=p")
+
+	assert.Empty(t, getCryptoPolicyViolations(makeTestArgoCD()))
+	a := makeTestArgoCD(enableTestFIPS)
+	assert.Empty(t, getCryptoPolicyViolations(a))
+
+	a.Spec.Server.ExtraCommandArgs = []string{"--tlsminversion=1.1", "--tlsciphers", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256:TLS_RSA_WITH_RC4_128_SHA"}
+	a.Spec.Repo.ExtraRepoCommandArgs = []string{"--tlsminversion", "1.3"}
+	assert.Equal(t, []string{
+		"server minimum TLS version 1.1 is below 1.2",
+		"server TLS cipher suite TLS_RSA_WITH_RC4_128_SHA is not FIPS-approved",
+	}, getCryptoPolicyViolations(a))
+
+	a.Spec.Server.ExtraCommandArgs = nil
+	a.Spec.Repo.ExtraRepoCommandArgs = nil
+	a.Spec.TLS.MinVersion = "1.0"
+	a.Spec.TLS.CipherSuites = []string{"TLS_AES_128_GCM_SHA256"}
+	assert.Equal(t, []string{"tls minimum TLS version 1.0 is below 1.2"}, getCryptoPolicyViolations(a))
+
+	t.Setenv(common.ArgoCDFIPSImagesEnvName, "")
+	a = makeTestArgoCD(enableTestFIPS, func(a *argoproj.ArgoCD) {
+		a.Spec.Image = "registry.example.com/argocd"
+		a.Spec.FIPSImages = &argoproj.ArgoCDImageOverridesSpec{Dex: "d", Redis: "r"}
+	})
+	assert.Equal(t, []string{"redisHAProxy has no FIPS-validated image"}, getCryptoPolicyViolations(a))
+	assert.Equal(t, []string{"argo"}, getCryptoPolicyUnverifiedImages(a))
+}
+
+func TestReconcileArgoCD_reconcileStatusCryptoPolicy(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	t.Setenv(common.ArgoCDFIPSImagesEnvName, "argo=a,dex=d,redis=r")
+	a := makeTestArgoCD(enableTestFIPS)
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileStatusCryptoPolicy(a))
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeCryptoPolicyCompliant)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonCryptoPolicyNonCompliant, condition.Reason)
+	assert.Contains(t, condition.Message, "redisHAProxy has no FIPS-validated image")
+
+	a.Spec.FIPSImages = &argoproj.ArgoCDImageOverridesSpec{RedisHAProxy: "p"}
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileStatusCryptoPolicy(a))
+	assert.True(t, meta.IsStatusConditionTrue(a.Status.Conditions, argoproj.ArgoCDConditionTypeCryptoPolicyCompliant))
+
+	// The status is left untouched while the settings do not change
+	resourceVersion := a.ResourceVersion
+	assert.NoError(t, r.reconcileStatusCryptoPolicy(a))
+	assert.Equal(t, resourceVersion, a.ResourceVersion)
+
+	// An image set explicitly in the spec is reported as unverified
+	a.Spec.Redis.Image = "registry.example.com/redis"
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileStatusCryptoPolicy(a))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeCryptoPolicyCompliant)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Contains(t, condition.Message, "The images set in the spec for redis are unverified")
+
+	// The condition is removed with the policy
+	a.Spec.CryptoPolicy = ""
+	assert.NoError(t, r.reconcileStatusCryptoPolicy(a))
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeCryptoPolicyCompliant))
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// customStylesName is the suffix of the ConfigMap holding the inline custom styles, and the name of their volume.
+	customStylesName = "server-custom-styles"
+
+	// customStylesKey is the key of the custom styles in the ConfigMap and the name of the file served.
+	customStylesKey = "custom.css"
+
+	// customStylesPath is the directory of the custom styles, under the static assets of the Argo CD Server.
+	customStylesPath = "/shared/app/custom"
+
+	// customStylesURL is the URL of the custom styles, relative to the UI.
+	customStylesURL = "./custom/" + customStylesKey
+)
+
+// hasCustomStyles returns true if custom styles are set for the UI of the given ArgoCD.
+func hasCustomStyles(cr *argoproj.ArgoCD) bool {
+	styles := cr.Spec.Server.CustomStyles
+	return styles != nil && (styles.ConfigMapRef != nil || styles.CSS != "")
+}
+
+// hasInlineCustomStyles returns true if the custom styles of the given ArgoCD are stored in a ConfigMap managed by the
+// operator.
+func hasInlineCustomStyles(cr *argoproj.ArgoCD) bool {
+	return hasCustomStyles(cr) && cr.Spec.Server.CustomStyles.ConfigMapRef == nil
+}
+
+// getCustomStylesVolume will return the volume holding the custom styles of the given ArgoCD, from the referenced
+// ConfigMap or the one managed by the operator.
+func getCustomStylesVolume(cr *argoproj.ArgoCD) corev1.Volume {
+	source := &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{Name: nameWithSuffix(customStylesName, cr)},
+		Items:                []corev1.KeyToPath{{Key: customStylesKey, Path: customStylesKey}},
+	}
+	if ref := cr.Spec.Server.CustomStyles.ConfigMapRef; ref != nil {
+		source.LocalObjectReference = ref.LocalObjectReference
+		source.Items[0].Key = ref.Key
+		source.Optional = ref.Optional
+	}
+	return corev1.Volume{
+		Name:         customStylesName,
+		VolumeSource: corev1.VolumeSource{ConfigMap: source},
+	}
+}
+
+// getCustomStylesVolumeMount will return the mount of the custom styles volume among the static assets of the Argo CD
+// Server. The directory is mounted rather than the file, so that changes to the ConfigMap are served without a
+// restart.
+func getCustomStylesVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      customStylesName,
+		MountPath: customStylesPath,
+		ReadOnly:  true,
+	}
+}
+
+// reconcileCustomStylesConfigMap will ensure that the ConfigMap holding the inline custom styles of the given ArgoCD
+// is present when they are set, and removed otherwise.
+func (r *ReconcileArgoCD) reconcileCustomStylesConfigMap(cr *argoproj.ArgoCD) error {
+	cm := newConfigMapWithName(nameWithSuffix(customStylesName, cr), cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
+		if !hasInlineCustomStyles(cr) {
+			// ConfigMap exists but the inline custom styles have been removed, delete the ConfigMap
+			return r.Client.Delete(context.TODO(), cm)
+		}
+		if cm.Data[customStylesKey] != cr.Spec.Server.CustomStyles.CSS {
+			cm.Data = map[string]string{customStylesKey: cr.Spec.Server.CustomStyles.CSS}
+			return r.Client.Update(context.TODO(), cm)
+		}
+		return nil // ConfigMap found with nothing changed, move along...
+	}
+
+	if !hasInlineCustomStyles(cr) {
+		return nil // No inline custom styles, do nothing.
+	}
+
+	cm.Data = map[string]string{customStylesKey: cr.Spec.Server.CustomStyles.CSS}
+	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), cm)
+}
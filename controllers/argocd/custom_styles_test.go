@@ -0,0 +1,124 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileArgoCD_reconcileCustomStylesConfigMap(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.CustomStyles = &argoproj.ArgoCDServerCustomStylesSpec{CSS: ".sidebar { background: #1d3557; }"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileCustomStylesConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: "argocd-server-custom-styles", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Equal(t, ".sidebar { background: #1d3557; }", cm.Data["custom.css"])
+
+	a.Spec.Server.CustomStyles.CSS = ".sidebar { background: #e63946; }"
+	assert.NoError(t, r.reconcileCustomStylesConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Equal(t, ".sidebar { background: #e63946; }", cm.Data["custom.css"])
+
+	// The ConfigMap is removed once the styles come from a ConfigMap of the user
+	a.Spec.Server.CustomStyles.ConfigMapRef = &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "argocd-styles"},
+		Key:                  "argocd.css",
+	}
+	assert.NoError(t, r.reconcileCustomStylesConfigMap(a))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, cm)))
+}
+
+func TestReconcileArgoCD_customStyles(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.CustomStyles = &argoproj.ArgoCDServerCustomStylesSpec{
+			ConfigMapRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "argocd-styles"},
+				Key:                  "argocd.css",
+			},
+		}
+		a.Spec.Server.ContentSecurityPolicy = "frame-ancestors 'self'; style-src 'self' https://fonts.example.com;"
+		a.Spec.Server.XFrameOptions = "deny"
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, "./custom/custom.css", cm.Data[common.ArgoCDKeyUICSSURL])
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, deployment))
+	podSpec := deployment.Spec.Template.Spec
+	assert.Contains(t, podSpec.Volumes, corev1.Volume{
+		Name: "server-custom-styles",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "argocd-styles"},
+				Items:                []corev1.KeyToPath{{Key: "argocd.css", Path: "custom.css"}},
+			},
+		},
+	})
+	assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "server-custom-styles",
+		MountPath: "/shared/app/custom",
+		ReadOnly:  true,
+	})
+	cmd := podSpec.Containers[0].Command
+	assert.Subset(t, cmd, []string{"--content-security-policy", "frame-ancestors 'self'; style-src 'self' https://fonts.example.com;"})
+	assert.Subset(t, cmd, []string{"--x-frame-options", "deny"})
+
+	// Removing the styles removes the key and the volume
+	a.Spec.Server.CustomStyles = nil
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.NotContains(t, cm.Data, common.ArgoCDKeyUICSSURL)
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, deployment))
+	for _, volume := range deployment.Spec.Template.Spec.Volumes {
+		assert.NotEqual(t, "server-custom-styles", volume.Name)
+	}
+}
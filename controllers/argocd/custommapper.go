@@ -3,6 +3,7 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/argoproj/argo-cd/v2/util/glob"
@@ -66,8 +67,22 @@ func isOwnerOfInterest(owner v1.OwnerReference) bool {
 }
 
 // tlsSecretMapper maps a watch event on a secret of type TLS back to the
-// ArgoCD object that we want to reconcile.
+// ArgoCD objects that we want to reconcile: the instances using it as the TLS
+// secret of a Route, and the instance whose components it secures.
 func (r *ReconcileArgoCD) tlsSecretMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	result := r.routeTLSSecretRequests(o)
+	for _, request := range r.componentTLSSecretRequests(o) {
+		if !slices.Contains(result, request) {
+			result = append(result, request)
+		}
+	}
+	return result
+}
+
+// componentTLSSecretRequests returns the request of the ArgoCD instance whose
+// components are secured by the given secret, if it is one of the well-known
+// tls secrets used amongst the Argo CD components.
+func (r *ReconcileArgoCD) componentTLSSecretRequests(o client.Object) []reconcile.Request {
 	var result = []reconcile.Request{}
 
 	if !isSecretOfInterest(o) {
@@ -127,6 +142,24 @@ func (r *ReconcileArgoCD) tlsSecretMapper(ctx context.Context, o client.Object)
 	return result
 }
 
+// routeTLSSecretRequests returns the requests of the ArgoCD instances in the namespace of the given secret that
+// reference it as the TLS secret of one of their Routes, so that the Routes follow the renewals of the certificate.
+func (r *ReconcileArgoCD) routeTLSSecretRequests(o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, client.InNamespace(o.GetNamespace())); err != nil {
+		log.Error(err, fmt.Sprintf("failed to list the ArgoCD instances referencing secret %s", o.GetName()))
+		return result
+	}
+	for i := range argocds.Items {
+		if contains(getRouteTLSSecretRefs(&argocds.Items[i]), o.GetName()) {
+			result = append(result, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&argocds.Items[i])})
+		}
+	}
+	return result
+}
+
 // namespaceResourceMapper maps a watch event on a namespace, back to the
 // ArgoCD object that we want to reconcile.
 func (r *ReconcileArgoCD) namespaceResourceMapper(ctx context.Context, o client.Object) []reconcile.Request {
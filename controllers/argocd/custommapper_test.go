@@ -569,6 +569,50 @@ func TestReconcileArgoCD_tlsSecretMapperRedis(t *testing.T) {
 
 }
 
+func TestReconcileArgoCD_tlsSecretMapperRouteAndComponent(t *testing.T) {
+	argocd := &argoproj.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: "argocd-operator"},
+	}
+	other := &argoproj.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "argocd-operator"},
+		Spec: argoproj.ArgoCDSpec{
+			Server: argoproj.ArgoCDServerSpec{
+				Route: argoproj.ArgoCDRouteSpec{
+					Enabled:      true,
+					TLSSecretRef: &corev1.LocalObjectReference{Name: "argocd-operator-redis-tls"},
+				},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "argocd-operator-redis-tls",
+			Namespace:   "argocd-operator",
+			Annotations: map[string]string{common.AnnotationName: "argocd"},
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+
+	resObjs := []client.Object{argocd, other, secret}
+	subresObjs := []client.Object{argocd, other}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, configv1.Install, routev1.Install)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// A secret both used by a Route and securing the components reconciles both instances
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "argocd", Namespace: "argocd-operator"}},
+		{NamespacedName: types.NamespacedName{Name: "other", Namespace: "argocd-operator"}},
+	}, r.tlsSecretMapper(context.TODO(), secret))
+
+	// An instance matched both ways is reconciled once
+	secret.Annotations[common.AnnotationName] = "other"
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "other", Namespace: "argocd-operator"}},
+	}, r.tlsSecretMapper(context.TODO(), secret))
+}
+
 func TestReconcileArgoCD_namespaceResourceMapperWithManagedByLabel(t *testing.T) {
 	a := makeTestArgoCD()
 
@@ -0,0 +1,298 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+//+kubebuilder:webhook:path=/mutate-argoproj-io-v1beta1-argocd,mutating=true,failurePolicy=ignore,sideEffects=None,groups=argoproj.io,resources=argocds,verbs=create;update,versions=v1beta1,name=margocd.kb.io,admissionReviewVersions=v1
+
+// argoCDDefault is a field of the ArgoCD resource filled by the defaulting webhook. Field returns a pointer to the
+// field, and value its default value for the given ArgoCD, or nil if it has none.
+type argoCDDefault struct {
+	path  string
+	field func(cr *argoproj.ArgoCD) interface{}
+	value func(cr *argoproj.ArgoCD) interface{}
+}
+
+// argoCDDefaults are the fields of the ArgoCD resource filled by the defaulting webhook. The values are the ones the
+// operator falls back to when reconciling the instance.
+var argoCDDefaults = []argoCDDefault{
+	{
+		path:  "spec.version",
+		field: func(cr *argoproj.ArgoCD) interface{} { return &cr.Spec.Version },
+		value: func(cr *argoproj.ArgoCD) interface{} {
+			// The image set in the environment of the operator wins over the default version
+			if cr.Spec.Image != "" || os.Getenv(common.ArgoCDImageEnvName) != "" {
+				return nil
+			}
+			return common.ArgoCDDefaultArgoVersion
+		},
+	},
+	{
+		path:  "spec.controller.processors.operation",
+		field: func(cr *argoproj.ArgoCD) interface{} { return &cr.Spec.Controller.Processors.Operation },
+		value: func(cr *argoproj.ArgoCD) interface{} { return getArgoServerOperationProcessors(cr) },
+	},
+	{
+		path:  "spec.controller.processors.status",
+		field: func(cr *argoproj.ArgoCD) interface{} { return &cr.Spec.Controller.Processors.Status },
+		value: func(cr *argoproj.ArgoCD) interface{} { return getArgoServerStatusProcessors(cr) },
+	},
+	{
+		path:  "spec.controller.resources",
+		field: func(cr *argoproj.ArgoCD) interface{} { return &cr.Spec.Controller.Resources },
+		value: func(cr *argoproj.ArgoCD) interface{} {
+			if preset, ok := getProfilePreset(cr); ok {
+				return preset.controllerResources.DeepCopy()
+			}
+			return nil
+		},
+	},
+	{
+		path:  "spec.repo.resources",
+		field: func(cr *argoproj.ArgoCD) interface{} { return &cr.Spec.Repo.Resources },
+		value: func(cr *argoproj.ArgoCD) interface{} {
+			if preset, ok := getProfilePreset(cr); ok {
+				return preset.repoResources.DeepCopy()
+			}
+			return nil
+		},
+	},
+	{
+		path:  "spec.redis.resources",
+		field: func(cr *argoproj.ArgoCD) interface{} { return &cr.Spec.Redis.Resources },
+		value: func(cr *argoproj.ArgoCD) interface{} {
+			if preset, ok := getProfilePreset(cr); ok {
+				return preset.redisResources.DeepCopy()
+			}
+			return nil
+		},
+	},
+	{
+		path:  "spec.controller.logLevel",
+		field: func(cr *argoproj.ArgoCD) interface{} { return &cr.Spec.Controller.LogLevel },
+		value: func(cr *argoproj.ArgoCD) interface{} { return common.ArgoCDDefaultLogLevel },
+	},
+	{
+		path:  "spec.controller.logFormat",
+		field: func(cr *argoproj.ArgoCD) interface{} { return &cr.Spec.Controller.LogFormat },
+		value: func(cr *argoproj.ArgoCD) interface{} { return common.ArgoCDDefaultLogFormat },
+	},
+	{
+		path:  "spec.repo.logLevel",
+		field: func(cr *argoproj.ArgoCD) interface{} { return &cr.Spec.Repo.LogLevel },
+		value: func(cr *argoproj.ArgoCD) interface{} { return common.ArgoCDDefaultLogLevel },
+	},
+	{
+		path:  "spec.repo.logFormat",
+		field: func(cr *argoproj.ArgoCD) interface{} { return &cr.Spec.Repo.LogFormat },
+		value: func(cr *argoproj.ArgoCD) interface{} { return common.ArgoCDDefaultLogFormat },
+	},
+	{
+		path:  "spec.server.logLevel",
+		field: func(cr *argoproj.ArgoCD) interface{} { return &cr.Spec.Server.LogLevel },
+		value: func(cr *argoproj.ArgoCD) interface{} { return common.ArgoCDDefaultLogLevel },
+	},
+	{
+		path:  "spec.server.logFormat",
+		field: func(cr *argoproj.ArgoCD) interface{} { return &cr.Spec.Server.LogFormat },
+		value: func(cr *argoproj.ArgoCD) interface{} { return common.ArgoCDDefaultLogFormat },
+	},
+}
+
+// argoCDDefaulter fills the defaults of the ArgoCD resources at admission time, so that the effective configuration
+// of an instance shows in its spec.
+type argoCDDefaulter struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &argoCDDefaulter{}
+
+// SetupDefaultingWebhookWithManager will register the defaulting webhook of the ArgoCD resources with the given manager.
+func SetupDefaultingWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register("/mutate-argoproj-io-v1beta1-argocd", &webhook.Admission{
+		Handler: &argoCDDefaulter{decoder: admission.NewDecoder(mgr.GetScheme())},
+	})
+	return nil
+}
+
+// Handle will fill the defaults of the ArgoCD of the given request. The patch only sets the defaulted fields and
+// their annotation, the fields left unset by the user stay absent from the resource.
+func (d *argoCDDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	cr := &argoproj.ArgoCD{}
+	if err := d.decoder.Decode(req, cr); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	applyArgoCDDefaults(cr)
+
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := setArgoCDDefaults(obj, cr); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	marshalled, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshalled)
+}
+
+// setArgoCDDefaults will copy the defaulted fields of the given ArgoCD, and their annotation, to the given unstructured
+// object. The fields no longer defaulted are only removed when empty, the other fields are left untouched.
+func setArgoCDDefaults(obj map[string]interface{}, cr *argoproj.ArgoCD) error {
+	defaulted := getDefaultedFields(cr)
+	for _, d := range argoCDDefaults {
+		path := strings.Split(d.path, ".")
+		field := reflect.ValueOf(d.field(cr)).Elem()
+		if _, ok := defaulted[d.path]; !ok {
+			if field.IsZero() {
+				unstructured.RemoveNestedField(obj, path...)
+			}
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(defaulted[d.path], &value); err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedField(obj, value, path...); err != nil {
+			return err
+		}
+	}
+
+	if annotation, ok := cr.Annotations[common.ArgoCDDefaultedFieldsAnnotation]; ok {
+		return unstructured.SetNestedField(obj, annotation, "metadata", "annotations", common.ArgoCDDefaultedFieldsAnnotation)
+	}
+	unstructured.RemoveNestedField(obj, "metadata", "annotations", common.ArgoCDDefaultedFieldsAnnotation)
+	return nil
+}
+
+// applyArgoCDDefaults will fill the defaults of the given ArgoCD, and record their values in its defaulted fields
+// annotation. Fields still holding the recorded value are recomputed, so that they keep following e.g. the profile of
+// the instance. Once changed, a field is owned by the user and left as is.
+func applyArgoCDDefaults(cr *argoproj.ArgoCD) {
+	defaulted := getDefaultedFields(cr)
+
+	for _, d := range argoCDDefaults {
+		if _, ok := defaulted[d.path]; !ok {
+			continue
+		}
+		field := reflect.ValueOf(d.field(cr)).Elem()
+		if isDefaultedField(cr, d, defaulted) {
+			field.Set(reflect.Zero(field.Type()))
+		} else if !field.IsZero() {
+			delete(defaulted, d.path) // Set by the user
+		}
+	}
+
+	for _, d := range argoCDDefaults {
+		field := reflect.ValueOf(d.field(cr)).Elem()
+		if !field.IsZero() {
+			continue
+		}
+		value := d.value(cr)
+		if value == nil {
+			delete(defaulted, d.path)
+			continue
+		}
+		field.Set(reflect.ValueOf(value))
+		raw, err := json.Marshal(field.Interface())
+		if err != nil {
+			continue
+		}
+		defaulted[d.path] = raw
+	}
+
+	setDefaultedFields(cr, defaulted)
+}
+
+// clearDefaultedFields will reset the fields of the given ArgoCD filled by the defaulting webhook, so that the instance
+// is reconciled with the defaults of the running operator rather than the ones recorded at admission time. Only the
+// fields still holding the value recorded by the webhook are reset, a field changed since, e.g. while the webhook was
+// unavailable, is owned by the user. The paths of the fields reset are returned.
+func clearDefaultedFields(cr *argoproj.ArgoCD) []string {
+	var cleared []string
+	defaulted := getDefaultedFields(cr)
+	for _, d := range argoCDDefaults {
+		if isDefaultedField(cr, d, defaulted) {
+			field := reflect.ValueOf(d.field(cr)).Elem()
+			field.Set(reflect.Zero(field.Type()))
+			cleared = append(cleared, d.path)
+		}
+	}
+	return cleared
+}
+
+// isDefaultedField returns true if the field of the given default still holds the value recorded for it in the
+// given defaulted fields.
+func isDefaultedField(cr *argoproj.ArgoCD, d argoCDDefault, defaulted map[string]json.RawMessage) bool {
+	recorded, ok := defaulted[d.path]
+	if !ok {
+		return false
+	}
+	value, err := json.Marshal(reflect.ValueOf(d.field(cr)).Elem().Interface())
+	return err == nil && bytes.Equal(value, recorded)
+}
+
+// getDefaultedFields will return the values of the fields of the given ArgoCD filled by the defaulting webhook, in
+// JSON, by path.
+func getDefaultedFields(cr *argoproj.ArgoCD) map[string]json.RawMessage {
+	defaulted := make(map[string]json.RawMessage)
+	if annotation := cr.Annotations[common.ArgoCDDefaultedFieldsAnnotation]; annotation != "" {
+		if err := json.Unmarshal([]byte(annotation), &defaulted); err != nil {
+			return make(map[string]json.RawMessage) // Not written by the webhook, nothing is defaulted
+		}
+	}
+	return defaulted
+}
+
+// setDefaultedFields will record the given defaulted fields in the annotations of the given ArgoCD.
+func setDefaultedFields(cr *argoproj.ArgoCD, defaulted map[string]json.RawMessage) {
+	if len(defaulted) == 0 {
+		delete(cr.Annotations, common.ArgoCDDefaultedFieldsAnnotation)
+		return
+	}
+
+	raw, err := json.Marshal(defaulted)
+	if err != nil {
+		return
+	}
+	if cr.Annotations == nil {
+		cr.Annotations = make(map[string]string)
+	}
+	cr.Annotations[common.ArgoCDDefaultedFieldsAnnotation] = string(raw)
+}
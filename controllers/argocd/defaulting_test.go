@@ -0,0 +1,117 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestApplyArgoCDDefaults(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.LogLevel = "debug"
+	})
+
+	applyArgoCDDefaults(a)
+
+	assert.Equal(t, common.ArgoCDDefaultArgoVersion, a.Spec.Version)
+	assert.Equal(t, common.ArgoCDDefaultServerOperationProcessors, a.Spec.Controller.Processors.Operation)
+	assert.Equal(t, common.ArgoCDDefaultServerStatusProcessors, a.Spec.Controller.Processors.Status)
+	assert.Equal(t, common.ArgoCDDefaultLogLevel, a.Spec.Controller.LogLevel)
+	assert.Equal(t, common.ArgoCDDefaultLogFormat, a.Spec.Repo.LogFormat)
+	assert.Equal(t, "debug", a.Spec.Server.LogLevel)
+	assert.Nil(t, a.Spec.Controller.Resources)
+
+	defaulted := getDefaultedFields(a)
+	assert.Equal(t, `"`+common.ArgoCDDefaultArgoVersion+`"`, string(defaulted["spec.version"]))
+	assert.Contains(t, defaulted, "spec.controller.processors.operation")
+	assert.NotContains(t, defaulted, "spec.server.logLevel")
+	assert.NotContains(t, defaulted, "spec.controller.resources")
+
+	// The reconciler ignores the defaulted fields
+	reconciled := a.DeepCopy()
+	assert.Contains(t, clearDefaultedFields(reconciled), "spec.version")
+	assert.Empty(t, reconciled.Spec.Version)
+	assert.Zero(t, reconciled.Spec.Controller.Processors.Operation)
+	assert.Equal(t, "debug", reconciled.Spec.Server.LogLevel)
+
+	// A field changed without going through the webhook is owned by the user
+	reconciled = a.DeepCopy()
+	reconciled.Spec.Controller.LogLevel = "warn"
+	assert.NotContains(t, clearDefaultedFields(reconciled), "spec.controller.logLevel")
+	assert.Equal(t, "warn", reconciled.Spec.Controller.LogLevel)
+}
+
+func TestApplyArgoCDDefaults_update(t *testing.T) {
+	a := makeTestArgoCD()
+	applyArgoCDDefaults(a)
+
+	// Defaulted fields follow the profile of the instance
+	a.Spec.Profile = argoproj.ArgoCDProfileLarge
+	applyArgoCDDefaults(a)
+	assert.Equal(t, profilePresets[argoproj.ArgoCDProfileLarge].operationProcessors, a.Spec.Controller.Processors.Operation)
+	assert.Equal(t, profilePresets[argoproj.ArgoCDProfileLarge].repoResources, *a.Spec.Repo.Resources)
+	assert.Contains(t, getDefaultedFields(a), "spec.repo.resources")
+
+	// Fields changed by the user are owned by the user
+	a.Spec.Controller.Processors.Operation = 7
+	a.Spec.Profile = argoproj.ArgoCDProfileSmall
+	applyArgoCDDefaults(a)
+	assert.Equal(t, int32(7), a.Spec.Controller.Processors.Operation)
+	assert.NotContains(t, getDefaultedFields(a), "spec.controller.processors.operation")
+	assert.Equal(t, profilePresets[argoproj.ArgoCDProfileSmall].statusProcessors, a.Spec.Controller.Processors.Status)
+
+	// Removing the profile removes the resources it defaulted
+	a.Spec.Profile = ""
+	applyArgoCDDefaults(a)
+	assert.Nil(t, a.Spec.Repo.Resources)
+	assert.NotContains(t, getDefaultedFields(a), "spec.repo.resources")
+	assert.Equal(t, common.ArgoCDDefaultServerStatusProcessors, a.Spec.Controller.Processors.Status)
+}
+
+func TestArgoCDDefaulter_Handle(t *testing.T) {
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	d := &argoCDDefaulter{decoder: admission.NewDecoder(sch)}
+	raw := `{"apiVersion":"argoproj.io/v1beta1","kind":"ArgoCD","metadata":{"name":"argocd","namespace":"argocd"},` +
+		`"spec":{"server":{"logLevel":"debug"}}}`
+
+	resp := d.Handle(context.TODO(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: []byte(raw)},
+		},
+	})
+	assert.True(t, resp.Allowed)
+	assert.NotEmpty(t, resp.Patches)
+
+	// Only the defaulted fields and their annotation are patched, the unset fields stay absent
+	for _, patch := range resp.Patches {
+		assert.True(t, strings.HasPrefix(patch.Path, "/metadata/annotations") ||
+			strings.HasPrefix(patch.Path, "/spec/version") ||
+			strings.HasPrefix(patch.Path, "/spec/controller") ||
+			strings.HasPrefix(patch.Path, "/spec/repo") ||
+			strings.HasPrefix(patch.Path, "/spec/server/log"), patch.Path)
+		assert.NotContains(t, patch.Json(), `"enabled"`)
+	}
+}
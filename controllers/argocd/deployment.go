@@ -16,10 +16,13 @@ package argocd
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,13 +40,22 @@ import (
 )
 
 // getArgoCDRepoServerReplicas will return the size value for the argocd-repo-server replica count if it
-// has been set in argocd CR. Otherwise, nil is returned if the replicas is not set in the argocd CR or
-// replicas value is < 0.
+// has been set in argocd CR, or the replica count of the profile if one is set. Otherwise, nil is returned
+// if the replicas is not set in the argocd CR or replicas value is < 0. If the repo server is autoscaled by
+// KEDA, the value for replicas in the argocd CR will be ignored.
 func getArgoCDRepoServerReplicas(cr *argoproj.ArgoCD) *int32 {
+	if isRepoKEDAEnabled(cr) {
+		return nil
+	}
+
 	if cr.Spec.Repo.Replicas != nil && *cr.Spec.Repo.Replicas >= 0 {
 		return cr.Spec.Repo.Replicas
 	}
 
+	if preset, ok := getProfilePreset(cr); ok {
+		return &preset.repoReplicas
+	}
+
 	return nil
 }
 
@@ -57,6 +69,44 @@ func getArgoCDServerReplicas(cr *argoproj.ArgoCD) *int32 {
 	return nil
 }
 
+// getArgoCDServerStrategy will return the Deployment strategy of the Argo CD Server for the given ArgoCD. The
+// defaults match the ones of Kubernetes, so that Deployments are left untouched when no rollout strategy is set.
+func getArgoCDServerStrategy(cr *argoproj.ArgoCD) appsv1.DeploymentStrategy {
+	defaultValue := intstr.FromString("25%")
+	maxSurge, maxUnavailable := defaultValue, defaultValue
+
+	if rs := cr.Spec.Server.RolloutStrategy; rs != nil {
+		if rs.Type == argoproj.ArgoCDServerRolloutStrategyBlueGreen {
+			// Bring up a full set of new pods, the previous ones are only removed as the new ones become ready
+			maxSurge, maxUnavailable = intstr.FromString("100%"), intstr.FromInt(0)
+		} else {
+			if rs.MaxSurge != nil {
+				maxSurge = *rs.MaxSurge
+			}
+			if rs.MaxUnavailable != nil {
+				maxUnavailable = *rs.MaxUnavailable
+			}
+		}
+	}
+
+	return appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxSurge:       &maxSurge,
+			MaxUnavailable: &maxUnavailable,
+		},
+	}
+}
+
+// getArgoCDServerMinReadySeconds will return the minimum number of seconds new Argo CD Server pods must be ready
+// before they are considered available.
+func getArgoCDServerMinReadySeconds(cr *argoproj.ArgoCD) int32 {
+	if cr.Spec.Server.RolloutStrategy == nil {
+		return 0
+	}
+	return cr.Spec.Server.RolloutStrategy.MinReadySeconds
+}
+
 func (r *ReconcileArgoCD) getArgoCDExport(cr *argoproj.ArgoCD) *argoprojv1alpha1.ArgoCDExport {
 	if cr.Spec.Import == nil {
 		return nil
@@ -207,20 +257,39 @@ func getArgoImportVolumes(cr *argoprojv1alpha1.ArgoCDExport) []corev1.Volume {
 	return volumes
 }
 
-func getArgoRedisArgs(useTLS bool) []string {
+func getArgoRedisArgs(cr *argoproj.ArgoCD, useTLS bool) []string {
 	args := make([]string, 0)
+	port := strconv.Itoa(int(getRedisPort(cr)))
 
-	args = append(args, "--save", "")
+	if points := getRedisSavePoints(cr); len(points) > 0 {
+		for _, point := range points {
+			args = append(args, append([]string{"--save"}, strings.Fields(point)...)...)
+		}
+	} else {
+		args = append(args, "--save", "")
+	}
 	args = append(args, "--appendonly", "no")
+	if cr.Spec.Redis.MaxMemory != "" {
+		args = append(args, "--maxmemory", cr.Spec.Redis.MaxMemory)
+	}
+	if cr.Spec.Redis.MaxMemoryPolicy != "" {
+		args = append(args, "--maxmemory-policy", cr.Spec.Redis.MaxMemoryPolicy)
+	}
 	args = append(args, "--requirepass $(REDIS_PASSWORD)")
+	if isLogSidecarEnabled(cr) {
+		// Redis runs the entrypoint of its image, so it writes its log file for the log sidecar itself
+		args = append(args, "--logfile", getLogSidecarLogFile("redis"))
+	}
 
 	if useTLS {
-		args = append(args, "--tls-port", "6379")
+		args = append(args, "--tls-port", port)
 		args = append(args, "--port", "0")
 
 		args = append(args, "--tls-cert-file", "/app/config/redis/tls/tls.crt")
 		args = append(args, "--tls-key-file", "/app/config/redis/tls/tls.key")
 		args = append(args, "--tls-auth-clients", "no")
+	} else if getRedisPort(cr) != common.ArgoCDDefaultRedisPort {
+		args = append(args, "--port", port)
 	}
 
 	return args
@@ -236,7 +305,7 @@ func getArgoRepoCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 	if cr.Spec.Redis.IsEnabled() {
 		cmd = append(cmd, "--redis", getRedisServerAddress(cr))
 	} else {
-		log.Info("Redis is Disabled. Skipping adding Redis configuration to Repo Server.")
+		componentLog(cr, logComponentRepoServer).Info("Redis is Disabled. Skipping adding Redis configuration to Repo Server.")
 	}
 	if useTLSForRedis {
 		cmd = append(cmd, "--redis-use-tls")
@@ -253,6 +322,16 @@ func getArgoRepoCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 	cmd = append(cmd, "--logformat")
 	cmd = append(cmd, getLogFormat(cr.Spec.Repo.LogFormat))
 
+	if size := cr.Spec.Repo.MaxCombinedDirectoryManifestsSize; size != nil {
+		cmd = append(cmd, "--max-combined-directory-manifests-size", size.String())
+	}
+
+	if size := cr.Spec.Repo.StreamedManifestMaxExtractedSize; size != nil {
+		cmd = append(cmd, "--streamed-manifest-max-extracted-size", size.String())
+	}
+
+	cmd = append(cmd, getTLSCommandArgs(cr)...)
+
 	// *** NOTE ***
 	// Do Not add any new default command line arguments below this.
 	extraArgs := cr.Spec.Repo.ExtraRepoCommandArgs
@@ -265,6 +344,38 @@ func getArgoRepoCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 	return cmd
 }
 
+// getTLSCommandArgs will return the command arguments setting the minimum TLS version and the TLS cipher suites
+// of the given ArgoCD, which the server and the repo server accept alike.
+func getTLSCommandArgs(cr *argoproj.ArgoCD) []string {
+	args := make([]string, 0)
+	if cr.Spec.TLS.MinVersion != "" {
+		args = append(args, "--tlsminversion", cr.Spec.TLS.MinVersion)
+	}
+	if len(cr.Spec.TLS.CipherSuites) > 0 {
+		args = append(args, "--tlsciphers", strings.Join(cr.Spec.TLS.CipherSuites, ":"))
+	}
+	return args
+}
+
+// getTLSCipherSuitesWarning returns a warning about the TLS cipher suites of the given ArgoCD that Go does not know,
+// the server and the repo server failing to start with them.
+func getTLSCipherSuitesWarning(cr *argoproj.ArgoCD) string {
+	known := map[string]bool{}
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		known[suite.Name] = true
+	}
+	var unknown []string
+	for _, name := range cr.Spec.TLS.CipherSuites {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Sprintf("unknown TLS cipher suites in tls.cipherSuites: %s", strings.Join(unknown, ", "))
+	}
+	return ""
+}
+
 // getArgoCmpServerInitCommand will return the command for the ArgoCD CMP Server init container
 func getArgoCmpServerInitCommand() []string {
 	cmd := make([]string, 0)
@@ -277,6 +388,23 @@ func getArgoCmpServerInitCommand() []string {
 
 // getArgoServerCommand will return the command for the ArgoCD server component.
 func getArgoServerCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
+	cmd := getArgoServerDefaultCommand(cr, useTLSForRedis)
+
+	extraArgs := cr.Spec.Server.ExtraCommandArgs
+	if conflicts := getConflictingArgs(extraArgs, cmd); len(conflicts) > 0 {
+		if !cr.Spec.Server.ExtraCommandArgsForceOverride {
+			return cmd // Reported through the ServerCommandArgsConflict condition
+		}
+		cmd = removeArgs(cmd, conflicts)
+	}
+
+	cmd = append(cmd, extraArgs...)
+	return cmd
+}
+
+// getArgoServerDefaultCommand will return the command for the ArgoCD server component, without the extra command
+// arguments of the given ArgoCD.
+func getArgoServerDefaultCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 	cmd := make([]string, 0)
 	cmd = append(cmd, "argocd-server")
 
@@ -297,13 +425,13 @@ func getArgoServerCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 	if cr.Spec.Repo.IsEnabled() {
 		cmd = append(cmd, "--repo-server", getRepoServerAddress(cr))
 	} else {
-		log.Info("Repo Server is disabled. This would affect the functioning of ArgoCD Server.")
+		instanceLog(cr).Info("Repo Server is disabled. This would affect the functioning of ArgoCD Server.")
 	}
 
 	if cr.Spec.Redis.IsEnabled() {
 		cmd = append(cmd, "--redis", getRedisServerAddress(cr))
 	} else {
-		log.Info("Redis is Disabled. Skipping adding Redis configuration to ArgoCD Server.")
+		instanceLog(cr).Info("Redis is Disabled. Skipping adding Redis configuration to ArgoCD Server.")
 	}
 
 	if useTLSForRedis {
@@ -315,22 +443,35 @@ func getArgoServerCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 		}
 	}
 
+	if rootPath := getArgoServerRootPath(cr); rootPath != "" {
+		cmd = append(cmd, "--rootpath", rootPath)
+		cmd = append(cmd, "--basehref", rootPath)
+	}
+
+	if cr.Spec.Server.GRPCWeb {
+		cmd = append(cmd, "--enable-grpc-web")
+	}
+
+	if cr.Spec.Server.ContentSecurityPolicy != "" {
+		cmd = append(cmd, "--content-security-policy", cr.Spec.Server.ContentSecurityPolicy)
+	}
+
+	if cr.Spec.Server.XFrameOptions != "" {
+		cmd = append(cmd, "--x-frame-options", cr.Spec.Server.XFrameOptions)
+	}
+
+	cmd = append(cmd, getTLSCommandArgs(cr)...)
+
 	cmd = append(cmd, "--loglevel")
 	cmd = append(cmd, getLogLevel(cr.Spec.Server.LogLevel))
 
 	cmd = append(cmd, "--logformat")
 	cmd = append(cmd, getLogFormat(cr.Spec.Server.LogFormat))
 
-	extraArgs := cr.Spec.Server.ExtraCommandArgs
-	err := isMergable(extraArgs, cmd)
-	if err != nil {
-		return cmd
-	}
 	if cr.Spec.SourceNamespaces != nil && len(cr.Spec.SourceNamespaces) > 0 {
 		cmd = append(cmd, "--application-namespaces", fmt.Sprint(strings.Join(cr.Spec.SourceNamespaces, ",")))
 	}
 
-	cmd = append(cmd, extraArgs...)
 	return cmd
 }
 
@@ -418,7 +559,7 @@ func newDeploymentWithSuffix(suffix string, component string, cr *argoproj.ArgoC
 func (r *ReconcileArgoCD) reconcileDeployments(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
 
 	if err := r.reconcileDexDeployment(cr); err != nil {
-		log.Error(err, "error reconciling dex deployment")
+		instanceLog(cr).Error(err, "error reconciling dex deployment")
 	}
 
 	err := r.reconcileRedisDeployment(cr, useTLSForRedis)
@@ -431,6 +572,11 @@ func (r *ReconcileArgoCD) reconcileDeployments(cr *argoproj.ArgoCD, useTLSForRed
 		return err
 	}
 
+	err = r.reconcileRepoServerCache(cr)
+	if err != nil {
+		return err
+	}
+
 	err = r.reconcileRepoDeployment(cr, useTLSForRedis)
 	if err != nil {
 		return err
@@ -454,7 +600,7 @@ func (r *ReconcileArgoCD) reconcileGrafanaDeployment(cr *argoproj.ArgoCD) error
 	if !cr.Spec.Grafana.Enabled {
 		return nil // Grafana not enabled, do nothing.
 	}
-	log.Info(grafanaDeprecatedWarning)
+	instanceLog(cr).Info(grafanaDeprecatedWarning)
 	return nil
 }
 
@@ -477,13 +623,14 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
 
 	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
-		Args:            getArgoRedisArgs(useTLS),
+		Args:            getArgoRedisArgs(cr, useTLS),
 		Image:           getRedisContainerImage(cr),
 		ImagePullPolicy: corev1.PullAlways,
 		Name:            "redis",
 		Ports: []corev1.ContainerPort{
 			{
-				ContainerPort: common.ArgoCDDefaultRedisPort,
+				ContainerPort: getRedisPort(cr),
+				Protocol:      corev1.ProtocolTCP,
 			},
 		},
 		Resources: getRedisResources(cr),
@@ -509,6 +656,9 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 		},
 	}}
 
+	applySecurityContext(&deploy.Spec.Template.Spec, cr.Spec.Redis.PodSecurityContext, cr.Spec.Redis.SecurityContext, "redis")
+	applyTerminationGracePeriod(&deploy.Spec.Template.Spec, cr.Spec.Redis.TerminationGracePeriodSeconds)
+
 	deploy.Spec.Template.Spec.ServiceAccountName = fmt.Sprintf("%s-%s", cr.Name, "argocd-redis")
 	deploy.Spec.Template.Spec.Volumes = []corev1.Volume{
 		{
@@ -521,6 +671,7 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 			},
 		},
 	}
+	applyLogSidecar(cr, &deploy.Spec.Template.Spec, "redis")
 
 	if err := applyReconcilerHook(cr, deploy, ""); err != nil {
 		return err
@@ -530,11 +681,12 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 		if !cr.Spec.Redis.IsEnabled() {
 			// Deployment exists but component enabled flag has been set to false, delete the Deployment
-			log.Info("Redis exists but should be disabled. Deleting existing redis.")
+			componentLog(cr, logComponentRedis).Info("Redis exists but should be disabled. Deleting existing redis.")
 			return r.Client.Delete(context.TODO(), deploy)
 		}
-		if cr.Spec.HA.Enabled {
-			// Deployment exists but HA enabled flag has been set to true, delete the Deployment
+		if !wantsRedisStandalone(cr) {
+			// Deployment exists but HA enabled flag has been set to true, and the components have been switched
+			// over to Redis HA, delete the Deployment
 			return r.Client.Delete(context.TODO(), deploy)
 		}
 		changed := false
@@ -546,12 +698,20 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 			changed = true
 		}
 		updateNodePlacement(existing, deploy, &changed)
+		updateSecurityContext(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
+		updateTerminationGracePeriod(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
+		updateLogSidecar(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
 
 		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Args, existing.Spec.Template.Spec.Containers[0].Args) {
 			existing.Spec.Template.Spec.Containers[0].Args = deploy.Spec.Template.Spec.Containers[0].Args
 			changed = true
 		}
 
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Ports, existing.Spec.Template.Spec.Containers[0].Ports) {
+			existing.Spec.Template.Spec.Containers[0].Ports = deploy.Spec.Template.Spec.Containers[0].Ports
+			changed = true
+		}
+
 		if !reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Env,
 			deploy.Spec.Template.Spec.Containers[0].Env) {
 			existing.Spec.Template.Spec.Containers[0].Env = deploy.Spec.Template.Spec.Containers[0].Env
@@ -570,16 +730,16 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 	}
 
 	if cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "" {
-		log.Info("Custom Redis Endpoint. Skipping starting redis.")
+		componentLog(cr, logComponentRedis).Info("Custom Redis Endpoint. Skipping starting redis.")
 		return nil
 	}
 
 	if !cr.Spec.Redis.IsEnabled() {
-		log.Info("Redis disabled. Skipping starting redis.")
+		componentLog(cr, logComponentRedis).Info("Redis disabled. Skipping starting redis.")
 		return nil
 	}
 
-	if cr.Spec.HA.Enabled {
+	if !wantsRedisStandalone(cr) {
 		return nil // HA enabled, do nothing.
 	}
 	if err := controllerutil.SetControllerReference(cr, deploy, r.Scheme); err != nil {
@@ -588,6 +748,25 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 	return r.Client.Create(context.TODO(), deploy)
 }
 
+// getRedisHAProxyContainerPorts will return the container ports of the Redis HA Proxy for the given ArgoCD.
+func getRedisHAProxyContainerPorts(cr *argoproj.ArgoCD) []corev1.ContainerPort {
+	ports := []corev1.ContainerPort{
+		{
+			ContainerPort: getRedisPort(cr),
+			Name:          "redis",
+			Protocol:      corev1.ProtocolTCP,
+		},
+	}
+	if cr.Spec.HA.RedisProxyMetrics {
+		ports = append(ports, corev1.ContainerPort{
+			ContainerPort: common.ArgoCDDefaultRedisHAProxyMetricsPort,
+			Name:          common.ArgoCDKeyMetrics,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+	return ports
+}
+
 // reconcileRedisHAProxyDeployment will ensure the Deployment resource is present for the Redis HA Proxy component.
 func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) error {
 	deploy := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
@@ -647,12 +826,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 			InitialDelaySeconds: int32(5),
 			PeriodSeconds:       int32(3),
 		},
-		Ports: []corev1.ContainerPort{
-			{
-				ContainerPort: common.ArgoCDDefaultRedisPort,
-				Name:          "redis",
-			},
-		},
+		Ports:     getRedisHAProxyContainerPorts(cr),
 		Resources: getRedisHAResources(cr),
 		SecurityContext: &corev1.SecurityContext{
 			AllowPrivilegeEscalation: boolPtr(false),
@@ -780,7 +954,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 
 	version, err := getClusterVersion(r.Client)
 	if err != nil {
-		log.Error(err, "error getting cluster version")
+		componentLog(cr, logComponentRedis).Error(err, "error getting cluster version")
 	}
 	if err := applyReconcilerHook(cr, deploy, version); err != nil {
 		return err
@@ -788,8 +962,9 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 
 	existing := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		if !cr.Spec.HA.Enabled {
-			// Deployment exists but HA enabled flag has been set to false, delete the Deployment
+		if !wantsRedisHA(cr) {
+			// Deployment exists but HA enabled flag has been set to false, and the components have been switched
+			// over to the standalone Redis, delete the Deployment
 			return r.Client.Delete(context.TODO(), existing)
 		}
 		changed := false
@@ -813,13 +988,18 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) e
 			changed = true
 		}
 
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Ports, existing.Spec.Template.Spec.Containers[0].Ports) {
+			existing.Spec.Template.Spec.Containers[0].Ports = deploy.Spec.Template.Spec.Containers[0].Ports
+			changed = true
+		}
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
 		return nil // Deployment found, do nothing
 	}
 
-	if !cr.Spec.HA.Enabled {
+	if !wantsRedisHA(cr) {
 		return nil // HA not enabled, do nothing.
 	}
 
@@ -839,9 +1019,7 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 
 	deploy.Spec.Template.Spec.AutomountServiceAccountToken = &automountToken
 
-	if cr.Spec.Repo.ServiceAccount != "" {
-		deploy.Spec.Template.Spec.ServiceAccountName = cr.Spec.Repo.ServiceAccount
-	}
+	deploy.Spec.Template.Spec.ServiceAccountName = getRepoServiceAccountName(cr)
 
 	// Global proxy env vars go first
 	repoEnv := cr.Spec.Repo.Env
@@ -858,9 +1036,15 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 	})
 	// Environment specified in the CR take precedence over everything else
 	repoEnv = argoutil.EnvMerge(repoEnv, proxyEnvVars(), false)
+	repoEnv = argoutil.EnvMerge(repoEnv, getOTelResourceAttributesEnv(cr), false)
+	repoEnv = argoutil.EnvMerge(repoEnv, getCryptoPolicyEnv(cr), false)
+	repoEnv = argoutil.EnvMerge(repoEnv, getRepoGRPCMaxMessageSizeEnv(cr), false)
 	if cr.Spec.Repo.ExecTimeout != nil {
 		repoEnv = argoutil.EnvMerge(repoEnv, []corev1.EnvVar{{Name: "ARGOCD_EXEC_TIMEOUT", Value: fmt.Sprintf("%ds", *cr.Spec.Repo.ExecTimeout)}}, true)
 	}
+	if cr.Spec.Repo.ReadOnlyRootFilesystem {
+		repoEnv = argoutil.EnvMerge(repoEnv, getRepoServerHelmEnv(), false)
+	}
 
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
 
@@ -997,8 +1181,14 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 		VolumeMounts: repoServerVolumeMounts,
 	}}
 
-	if cr.Spec.Repo.SidecarContainers != nil {
-		deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, cr.Spec.Repo.SidecarContainers...)
+	for _, sidecar := range cr.Spec.Repo.SidecarContainers {
+		sidecar = *sidecar.DeepCopy()
+		// The plugins served by the sidecars exchange the manifests they generate with the Repo server over gRPC
+		if cr.Spec.Repo.GRPCMaxMessageSizeMB != nil {
+			sidecar.Env = argoutil.EnvMerge(sidecar.Env, getRepoGRPCMaxMessageSizeEnv(cr), false)
+		}
+		sidecar.Resources = withResourceDefaults(sidecar.Resources, cr.Spec.Repo.PluginResourceDefaults)
+		deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, sidecar)
 	}
 
 	repoServerVolumes := []corev1.Volume{
@@ -1072,17 +1262,13 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 
 	// If the user is not used a custom /tmp mount, then just use the default
 	if !volumeMountOverridesTmpVolume {
-		repoServerVolumes = append(repoServerVolumes, corev1.Volume{
-			Name: "tmp",
-			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{},
-			},
-		})
+		repoServerVolumes = append(repoServerVolumes, getRepoServerTmpVolume(cr))
 	}
 
 	if cr.Spec.Repo.Volumes != nil {
 		repoServerVolumes = append(repoServerVolumes, cr.Spec.Repo.Volumes...)
 	}
+	applyEmptyDirSizeLimits(repoServerVolumes, cr.Spec.Repo.EphemeralStorage)
 
 	deploy.Spec.Template.Spec.Volumes = repoServerVolumes
 
@@ -1090,11 +1276,23 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 		deploy.Spec.Replicas = replicas
 	}
 
+	applySecurityContext(&deploy.Spec.Template.Spec, cr.Spec.Repo.PodSecurityContext, cr.Spec.Repo.SecurityContext, "argocd-repo-server")
+	applyTerminationGracePeriod(&deploy.Spec.Template.Spec, cr.Spec.Repo.TerminationGracePeriodSeconds)
+	applyDNSConfig(&deploy.Spec.Template.Spec, cr.Spec.Repo.DNSPolicy, cr.Spec.Repo.DNSConfig)
+	applyLogSidecar(cr, &deploy.Spec.Template.Spec, "argocd-repo-server")
+	applyPodMetadata(&deploy.Spec.Template, cr.Spec.Repo.PodLabels, cr.Spec.Repo.PodAnnotations)
+	if cr.Spec.Repo.ReadOnlyRootFilesystem {
+		if err := applyReadOnlyRootFilesystem(&deploy.Spec.Template.Spec, getRepoServerWritablePaths(cr)); err != nil {
+			return fmt.Errorf("invalid read-only root filesystem for the Repo server: %w", err)
+		}
+		applyEmptyDirSizeLimits(deploy.Spec.Template.Spec.Volumes, cr.Spec.Repo.EphemeralStorage)
+	}
+
 	existing := newDeploymentWithSuffix("repo-server", "repo-server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 
 		if !cr.Spec.Repo.IsEnabled() {
-			log.Info("Existing ArgoCD Repo Server found but should be disabled. Deleting Repo Server")
+			componentLog(cr, logComponentRepoServer).Info("Existing ArgoCD Repo Server found but should be disabled. Deleting Repo Server")
 			// Delete existing deployment for ArgoCD Repo Server, if any ..
 			return r.Client.Delete(context.TODO(), existing)
 		}
@@ -1113,6 +1311,11 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 			changed = true
 		}
 		updateNodePlacement(existing, deploy, &changed)
+		updateSecurityContext(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
+		updateTerminationGracePeriod(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
+		updateDNSConfig(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
+		updateLogSidecar(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
+		updatePodMetadata(&existing.Spec.Template, &deploy.Spec.Template, &changed)
 		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Volumes, existing.Spec.Template.Spec.Volumes) {
 			existing.Spec.Template.Spec.Volumes = deploy.Spec.Template.Spec.Volumes
 			changed = true
@@ -1145,9 +1348,12 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 			existing.Spec.Template.Spec.InitContainers = deploy.Spec.Template.Spec.InitContainers
 			changed = true
 		}
+		deploy.Spec.Replicas = getBudgetedReplicas(cr, existing.Spec.Replicas, deploy.Spec.Replicas)
 		if !reflect.DeepEqual(deploy.Spec.Replicas, existing.Spec.Replicas) {
-			existing.Spec.Replicas = deploy.Spec.Replicas
-			changed = true
+			if !isRepoKEDAEnabled(cr) {
+				existing.Spec.Replicas = deploy.Spec.Replicas
+				changed = true
+			}
 		}
 
 		if deploy.Spec.Template.Spec.AutomountServiceAccountToken != existing.Spec.Template.Spec.AutomountServiceAccountToken {
@@ -1168,7 +1374,7 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 	}
 
 	if !cr.Spec.Repo.IsEnabled() {
-		log.Info("ArgoCD Repo Server disabled. Skipping starting ArgoCD Repo Server.")
+		componentLog(cr, logComponentRepoServer).Info("ArgoCD Repo Server disabled. Skipping starting ArgoCD Repo Server.")
 		return nil
 	}
 
@@ -1193,7 +1399,11 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 			},
 		},
 	})
+	serverEnv = argoutil.EnvMerge(serverEnv, getArgoServerSessionEnv(cr), false)
 	serverEnv = argoutil.EnvMerge(serverEnv, proxyEnvVars(), false)
+	serverEnv = argoutil.EnvMerge(serverEnv, getOTelResourceAttributesEnv(cr), false)
+	serverEnv = argoutil.EnvMerge(serverEnv, getCryptoPolicyEnv(cr), false)
+	serverEnv = argoutil.EnvMerge(serverEnv, getRepoGRPCMaxMessageSizeEnv(cr), false)
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
 
 	if cr.Spec.Server.InitContainers != nil {
@@ -1218,6 +1428,10 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 		},
 	}
 
+	if hasCustomStyles(cr) {
+		serverVolumeMounts = append(serverVolumeMounts, getCustomStylesVolumeMount())
+	}
+
 	if cr.Spec.Server.VolumeMounts != nil {
 		serverVolumeMounts = append(serverVolumeMounts, cr.Spec.Server.VolumeMounts...)
 	}
@@ -1313,9 +1527,14 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 		},
 	}
 
+	if hasCustomStyles(cr) {
+		serverVolumes = append(serverVolumes, getCustomStylesVolume(cr))
+	}
+
 	if cr.Spec.Server.Volumes != nil {
 		serverVolumes = append(serverVolumes, cr.Spec.Server.Volumes...)
 	}
+	applyEmptyDirSizeLimits(serverVolumes, cr.Spec.Server.EphemeralStorage)
 
 	deploy.Spec.Template.Spec.Volumes = serverVolumes
 
@@ -1323,14 +1542,23 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 		deploy.Spec.Replicas = replicas
 	}
 
+	deploy.Spec.Strategy = getArgoCDServerStrategy(cr)
+	deploy.Spec.MinReadySeconds = getArgoCDServerMinReadySeconds(cr)
+
 	if cr.Spec.Server.SidecarContainers != nil {
 		deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, cr.Spec.Server.SidecarContainers...)
 	}
 
+	applySecurityContext(&deploy.Spec.Template.Spec, cr.Spec.Server.PodSecurityContext, cr.Spec.Server.SecurityContext, "argocd-server")
+	applyTerminationGracePeriod(&deploy.Spec.Template.Spec, cr.Spec.Server.TerminationGracePeriodSeconds)
+	applyDNSConfig(&deploy.Spec.Template.Spec, cr.Spec.Server.DNSPolicy, cr.Spec.Server.DNSConfig)
+	applyLogSidecar(cr, &deploy.Spec.Template.Spec, "argocd-server")
+	applyPodMetadata(&deploy.Spec.Template, cr.Spec.Server.PodLabels, cr.Spec.Server.PodAnnotations)
+
 	existing := newDeploymentWithSuffix("server", "server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		if !cr.Spec.Server.IsEnabled() {
-			log.Info("Existing ArgoCD Server found but should be disabled. Deleting ArgoCD Server")
+		if !isServerEnabled(cr) {
+			instanceLog(cr).Info("Existing ArgoCD Server found but should be disabled. Deleting ArgoCD Server")
 			// Delete existing deployment for ArgoCD Server, if any ..
 			return r.Client.Delete(context.TODO(), existing)
 		}
@@ -1343,6 +1571,11 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 			changed = true
 		}
 		updateNodePlacement(existing, deploy, &changed)
+		updateSecurityContext(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
+		updateTerminationGracePeriod(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
+		updateDNSConfig(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
+		updateLogSidecar(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
+		updatePodMetadata(&existing.Spec.Template, &deploy.Spec.Template, &changed)
 		if !reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Env,
 			deploy.Spec.Template.Spec.Containers[0].Env) {
 			existing.Spec.Template.Spec.Containers[0].Env = deploy.Spec.Template.Spec.Containers[0].Env
@@ -1377,20 +1610,29 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSF
 				deploy.Spec.Template.Spec.Containers[1:]...)
 			changed = true
 		}
+		deploy.Spec.Replicas = getBudgetedReplicas(cr, existing.Spec.Replicas, deploy.Spec.Replicas)
 		if !reflect.DeepEqual(deploy.Spec.Replicas, existing.Spec.Replicas) {
 			if !cr.Spec.Server.Autoscale.Enabled {
 				existing.Spec.Replicas = deploy.Spec.Replicas
 				changed = true
 			}
 		}
+		if !reflect.DeepEqual(deploy.Spec.Strategy, existing.Spec.Strategy) {
+			existing.Spec.Strategy = deploy.Spec.Strategy
+			changed = true
+		}
+		if deploy.Spec.MinReadySeconds != existing.Spec.MinReadySeconds {
+			existing.Spec.MinReadySeconds = deploy.Spec.MinReadySeconds
+			changed = true
+		}
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
 		return nil // Deployment found with nothing to do, move along...
 	}
 
-	if !cr.Spec.Server.IsEnabled() {
-		log.Info("ArgoCD Server disabled. Skipping starting argocd server.")
+	if !isServerEnabled(cr) {
+		instanceLog(cr).Info("ArgoCD Server disabled. Skipping starting argocd server.")
 		return nil
 	}
 
@@ -1423,6 +1665,37 @@ func proxyEnvVars(vars ...corev1.EnvVar) []corev1.EnvVar {
 	return result
 }
 
+// getRepoGRPCMaxMessageSizeEnv will return the ARGOCD_GRPC_MAX_SIZE_MB environment variable setting the maximum size
+// of the gRPC messages exchanged with the Repo server of the given ArgoCD, or nil when not set.
+func getRepoGRPCMaxMessageSizeEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
+	if cr.Spec.Repo.GRPCMaxMessageSizeMB == nil {
+		return nil
+	}
+	return []corev1.EnvVar{{Name: "ARGOCD_GRPC_MAX_SIZE_MB", Value: strconv.Itoa(int(*cr.Spec.Repo.GRPCMaxMessageSizeMB))}}
+}
+
+// otelResourceAttributeEscaper percent-encodes the characters delimiting the OpenTelemetry resource attributes.
+var otelResourceAttributeEscaper = strings.NewReplacer("%", "%25", ",", "%2C", "=", "%3D")
+
+// getOTelResourceAttributesEnv will return the OTEL_RESOURCE_ATTRIBUTES environment variable holding the
+// OpenTelemetry resource attributes of the given ArgoCD, or nil when none is set.
+func getOTelResourceAttributesEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
+	attributes := cr.Spec.Monitoring.ResourceAttributes
+	if len(attributes) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, otelResourceAttributeEscaper.Replace(attributes[key])))
+	}
+	return []corev1.EnvVar{{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: strings.Join(pairs, ",")}}
+}
+
 func caseInsensitiveGetenv(s string) (string, string) {
 	if v := os.Getenv(s); v != "" {
 		return s, v
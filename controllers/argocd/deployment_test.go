@@ -9,6 +9,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	resourcev1 "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -223,6 +224,50 @@ func TestReconcileArgoCD_reconcileRepoDeployment_loglevel(t *testing.T) {
 // TODO: This needs more testing for the rest of the RepoDeployment container
 // fields.
 
+func TestReconcileArgoCD_reconcileRepoDeployment_securityContext(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, &corev1.PodSecurityContext{
+		RunAsNonRoot: boolPtr(true),
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}, deployment.Spec.Template.Spec.SecurityContext)
+
+	// Overrides replace the defaults on the existing deployment
+	podSecurityContext := &corev1.PodSecurityContext{
+		RunAsNonRoot: boolPtr(true),
+		RunAsUser:    int64Ptr(2000),
+		FSGroup:      int64Ptr(2000),
+	}
+	securityContext := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: boolPtr(false),
+		ReadOnlyRootFilesystem:   boolPtr(true),
+	}
+	a.Spec.Repo.PodSecurityContext = podSecurityContext
+	a.Spec.Repo.SecurityContext = securityContext
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, podSecurityContext, deployment.Spec.Template.Spec.SecurityContext)
+	assert.Equal(t, securityContext, deployment.Spec.Template.Spec.Containers[0].SecurityContext)
+	// The init container keeps its defaults
+	assert.NotEqual(t, securityContext, deployment.Spec.Template.Spec.InitContainers[0].SecurityContext)
+}
+
 // reconcileRepoDeployment creates a Deployment with the correct volumes for the
 // repo-server.
 func TestReconcileArgoCD_reconcileRepoDeployment_volumes(t *testing.T) {
@@ -319,6 +364,224 @@ func TestReconcileArgoCD_reconcile_ServerDeployment_env(t *testing.T) {
 		assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "BAR", Value: "FOO"})
 	})
 
+	t.Run("Test session env set in argocd-server", func(t *testing.T) {
+		logf.SetLogger(ZapLogger(true))
+		a := makeTestArgoCD()
+		maxConcurrent := int32(10)
+		a.Spec.Server.Session = &argoproj.ArgoCDServerSessionSpec{MaxConcurrent: &maxConcurrent}
+
+		resObjs := []client.Object{a}
+		subresObjs := []client.Object{a}
+		runtimeObjs := []runtime.Object{}
+		sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+		cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+		r := makeTestReconciler(cl, sch)
+
+		assert.NoError(t, r.reconcileServerDeployment(a, false))
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+			Name:      "argocd-server",
+			Namespace: testNamespace,
+		}, deployment))
+		assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env,
+			corev1.EnvVar{Name: common.ArgoCDMaxConcurrentLoginRequestsEnvName, Value: "10"})
+
+		// Changes to the option are synced to the deployment
+		maxConcurrent = 0
+		assert.NoError(t, r.reconcileServerDeployment(a, false))
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+			Name:      "argocd-server",
+			Namespace: testNamespace,
+		}, deployment))
+		assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env,
+			corev1.EnvVar{Name: common.ArgoCDMaxConcurrentLoginRequestsEnvName, Value: "0"})
+	})
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_persistentCache(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	size := resourcev1.MustParse("50Gi")
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Cache = &argoproj.ArgoCDRepoCacheSpec{Persistent: true, Size: &size}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	getTmpVolume := func() *corev1.Volume {
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+		for i, v := range deployment.Spec.Template.Spec.Volumes {
+			if v.Name == "tmp" {
+				return &deployment.Spec.Template.Spec.Volumes[i]
+			}
+		}
+		return nil
+	}
+
+	assert.NoError(t, r.reconcileRepoServerCache(a))
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-cache", Namespace: testNamespace}, pvc))
+	assert.Equal(t, size, pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, pvc.Spec.AccessModes)
+
+	volume := getTmpVolume()
+	assert.NotNil(t, volume.PersistentVolumeClaim)
+	assert.Equal(t, "argocd-repo-server-cache", volume.PersistentVolumeClaim.ClaimName)
+
+	// Ephemeral volumes replace the shared claim
+	a.Spec.Repo.Cache.Ephemeral = true
+	assert.NoError(t, r.reconcileRepoServerCache(a))
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-cache", Namespace: testNamespace}, pvc)
+	assert.True(t, apierrors.IsNotFound(err))
+	volume = getTmpVolume()
+	assert.NotNil(t, volume.Ephemeral)
+	assert.Equal(t, size, volume.Ephemeral.VolumeClaimTemplate.Spec.Resources.Requests[corev1.ResourceStorage])
+
+	// Several replicas cannot share a ReadWriteOnce claim, each pod gets its own volume
+	replicas := int32(2)
+	a.Spec.Repo.Cache.Ephemeral = false
+	a.Spec.Repo.Replicas = &replicas
+	assert.NoError(t, r.reconcileRepoServerCache(a))
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-cache", Namespace: testNamespace}, pvc)
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.NotNil(t, getTmpVolume().Ephemeral)
+
+	// A ReadWriteMany claim is shared by the replicas
+	a.Spec.Repo.Cache.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+	assert.NoError(t, r.reconcileRepoServerCache(a))
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-cache", Namespace: testNamespace}, pvc))
+	assert.Equal(t, "argocd-repo-server-cache", getTmpVolume().PersistentVolumeClaim.ClaimName)
+
+	// Disabling the persistent cache brings back the emptyDir volume
+	a.Spec.Repo.Cache = nil
+	assert.NoError(t, r.reconcileRepoServerCache(a))
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	volume = getTmpVolume()
+	assert.NotNil(t, volume.EmptyDir)
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_ephemeralStorage(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	tmpLimit := resourcev1.MustParse("5Gi")
+	pluginsLimit := resourcev1.MustParse("100Mi")
+	request := resourcev1.MustParse("2Gi")
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.EphemeralStorage = &argoproj.ArgoCDEphemeralStorageSpec{
+			SizeLimits: map[string]resourcev1.Quantity{"tmp": tmpLimit, "plugins": pluginsLimit},
+			Request:    &request,
+		}
+		a.Spec.Repo.Resources = &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resourcev1.MustParse("250m")},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+
+	limits := map[string]*resourcev1.Quantity{}
+	for _, v := range deployment.Spec.Template.Spec.Volumes {
+		if v.EmptyDir != nil {
+			limits[v.Name] = v.EmptyDir.SizeLimit
+		}
+	}
+	assert.Equal(t, &tmpLimit, limits["tmp"])
+	assert.Equal(t, &pluginsLimit, limits["plugins"])
+	assert.Nil(t, limits["var-files"])
+
+	requests := deployment.Spec.Template.Spec.Containers[0].Resources.Requests
+	assert.Equal(t, request, requests[corev1.ResourceEphemeralStorage])
+	assert.Equal(t, resourcev1.MustParse("250m"), requests[corev1.ResourceCPU])
+	assert.NotContains(t, a.Spec.Repo.Resources.Requests, corev1.ResourceEphemeralStorage)
+
+	// An ephemeral-storage request set in the resources takes precedence
+	a.Spec.Repo.Resources.Requests[corev1.ResourceEphemeralStorage] = resourcev1.MustParse("1Gi")
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Equal(t, resourcev1.MustParse("1Gi"), deployment.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceEphemeralStorage])
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_readOnlyRootFilesystem(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	helmLimit := resourcev1.MustParse("1Gi")
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.ReadOnlyRootFilesystem = true
+		a.Spec.Repo.SecurityContext = &corev1.SecurityContext{RunAsNonRoot: boolPtr(true)}
+		a.Spec.Repo.EphemeralStorage = &argoproj.ArgoCDEphemeralStorageSpec{
+			SizeLimits: map[string]resourcev1.Quantity{"helm-working-dir": helmLimit},
+		}
+		a.Spec.Repo.SidecarContainers = []corev1.Container{{Name: "cmp", Image: "example.com/cmp:latest"}}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+
+	podSpec := deployment.Spec.Template.Spec
+	for _, c := range append(podSpec.InitContainers, podSpec.Containers...) {
+		assert.Equal(t, boolPtr(true), c.SecurityContext.ReadOnlyRootFilesystem, c.Name)
+	}
+	assert.Nil(t, a.Spec.Repo.SecurityContext.ReadOnlyRootFilesystem)
+	assert.Nil(t, a.Spec.Repo.SidecarContainers[0].SecurityContext)
+
+	// The helm working directory is backed by an emptyDir volume, next to the existing writable volumes
+	assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "helm-working-dir", MountPath: "/helm-working-dir"})
+	assert.Contains(t, podSpec.Volumes, corev1.Volume{
+		Name:         "helm-working-dir",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &helmLimit}},
+	})
+	assert.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{Name: "HELM_CACHE_HOME", Value: "/helm-working-dir"})
+
+	// The plugin sidecar is given its own writable /tmp
+	cmp := podSpec.Containers[1]
+	assert.Equal(t, "cmp", cmp.Name)
+	assert.Contains(t, cmp.VolumeMounts, corev1.VolumeMount{Name: "cmp-tmp-0", MountPath: "/tmp"})
+	assert.Contains(t, podSpec.Volumes, corev1.Volume{
+		Name:         "cmp-tmp-0",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+
+	// Writable paths mounted from read-only volumes are rejected
+	a.Spec.Repo.Volumes = []corev1.Volume{{
+		Name:         "custom-tmp",
+		VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}},
+	}}
+	a.Spec.Repo.VolumeMounts = []corev1.VolumeMount{{Name: "custom-tmp", MountPath: "/tmp"}}
+	assert.ErrorContains(t, r.reconcileRepoDeployment(a, false), "volume custom-tmp is read-only")
+
+	// Disabling the option restores the root filesystem
+	a.Spec.Repo.ReadOnlyRootFilesystem = false
+	a.Spec.Repo.Volumes = nil
+	a.Spec.Repo.VolumeMounts = nil
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Nil(t, deployment.Spec.Template.Spec.Containers[0].SecurityContext.ReadOnlyRootFilesystem)
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "helm-working-dir", MountPath: "/helm-working-dir"})
 }
 
 func TestReconcileArgoCD_reconcileRepoDeployment_env(t *testing.T) {
@@ -442,6 +705,44 @@ func TestReconcileArgoCD_reconcileRepoDeployment_env(t *testing.T) {
 	})
 }
 
+func TestReconcileArgoCD_reconcileRepoDeployment_manifestSizes(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		maxSize := int32(500)
+		combinedSize := resourcev1.MustParse("50M")
+		extractedSize := resourcev1.MustParse("2G")
+		a.Spec.Repo.GRPCMaxMessageSizeMB = &maxSize
+		a.Spec.Repo.MaxCombinedDirectoryManifestsSize = &combinedSize
+		a.Spec.Repo.StreamedManifestMaxExtractedSize = &extractedSize
+		a.Spec.Repo.SidecarContainers = []corev1.Container{{Name: "cmp", Image: "cmp:latest"}}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+
+	// The sizes are passed to the Repo server, and the gRPC message size to its plugins
+	sizeEnv := corev1.EnvVar{Name: "ARGOCD_GRPC_MAX_SIZE_MB", Value: "500"}
+	command := strings.Join(deployment.Spec.Template.Spec.Containers[0].Command, " ")
+	assert.Contains(t, command, "--max-combined-directory-manifests-size 50M")
+	assert.Contains(t, command, "--streamed-manifest-max-extracted-size 2G")
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, sizeEnv)
+	assert.Equal(t, []corev1.EnvVar{sizeEnv}, deployment.Spec.Template.Spec.Containers[1].Env)
+	assert.Nil(t, a.Spec.Repo.SidecarContainers[0].Env)
+
+	// The gRPC message size is also set on the clients of the Repo server
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, sizeEnv)
+}
+
 // reconcileRepoDeployment creates a Deployment with the correct mounts for the
 // repo-server.
 func TestReconcileArgoCD_reconcileRepoDeployment_mounts(t *testing.T) {
@@ -1125,6 +1426,12 @@ func TestReconcileArgoCD_reconcileServerDeployment(t *testing.T) {
 		},
 		deployment))
 	want := corev1.PodSpec{
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsNonRoot: boolPtr(true),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
 		Containers: []corev1.Container{
 			{
 				Name:            "argocd-server",
@@ -1233,6 +1540,135 @@ func TestReconcileArgoCD_reconcileServerDeployment(t *testing.T) {
 	assert.Equal(t, wantCmd, deployment.Spec.Template.Spec.Containers[0].Command)
 }
 
+func TestReconcileArgoCD_reconcileServerDeployment_rolloutStrategy(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	getStrategy := func() (*appsv1.RollingUpdateDeployment, int32) {
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, deployment))
+		assert.Equal(t, appsv1.RollingUpdateDeploymentStrategyType, deployment.Spec.Strategy.Type)
+		return deployment.Spec.Strategy.RollingUpdate, deployment.Spec.MinReadySeconds
+	}
+
+	// Kubernetes defaults
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	rollingUpdate, minReadySeconds := getStrategy()
+	assert.Equal(t, intstr.FromString("25%"), *rollingUpdate.MaxSurge)
+	assert.Equal(t, intstr.FromString("25%"), *rollingUpdate.MaxUnavailable)
+	assert.Equal(t, int32(0), minReadySeconds)
+
+	maxSurge, maxUnavailable := intstr.FromInt(2), intstr.FromInt(0)
+	a.Spec.Server.RolloutStrategy = &argoproj.ArgoCDServerRolloutStrategy{
+		Type:           argoproj.ArgoCDServerRolloutStrategyRollingUpdate,
+		MaxSurge:       &maxSurge,
+		MaxUnavailable: &maxUnavailable,
+	}
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	rollingUpdate, _ = getStrategy()
+	assert.Equal(t, maxSurge, *rollingUpdate.MaxSurge)
+	assert.Equal(t, maxUnavailable, *rollingUpdate.MaxUnavailable)
+
+	a.Spec.Server.RolloutStrategy = &argoproj.ArgoCDServerRolloutStrategy{
+		Type:            argoproj.ArgoCDServerRolloutStrategyBlueGreen,
+		MinReadySeconds: 30,
+	}
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	rollingUpdate, minReadySeconds = getStrategy()
+	assert.Equal(t, intstr.FromString("100%"), *rollingUpdate.MaxSurge)
+	assert.Equal(t, intstr.FromInt(0), *rollingUpdate.MaxUnavailable)
+	assert.Equal(t, int32(30), minReadySeconds)
+}
+
+func TestReconcileArgoCD_reconcileServerDeployment_podMetadata(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.PodLabels = map[string]string{
+			"sidecar.istio.io/inject": "true",
+			common.ArgoCDKeyName:      "other",
+		}
+		a.Spec.Server.PodAnnotations = map[string]string{"prometheus.io/scrape": "true"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, deployment))
+	assert.Equal(t, "true", deployment.Spec.Template.Labels["sidecar.istio.io/inject"])
+	assert.Equal(t, "argocd-server", deployment.Spec.Template.Labels[common.ArgoCDKeyName])
+	assert.Equal(t, "true", deployment.Spec.Template.Annotations["prometheus.io/scrape"])
+	assert.NotContains(t, deployment.Labels, "sidecar.istio.io/inject")
+	assert.NotContains(t, deployment.Annotations, "prometheus.io/scrape")
+
+	// The pod metadata removed from the component is removed from the pods, other annotations are left untouched
+	deployment.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"] = "2024-01-01T00:00:00Z"
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	a.Spec.Server.PodLabels = map[string]string{"linkerd.io/inject": "enabled"}
+	a.Spec.Server.PodAnnotations = nil
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, deployment))
+	assert.NotContains(t, deployment.Spec.Template.Labels, "sidecar.istio.io/inject")
+	assert.Equal(t, "enabled", deployment.Spec.Template.Labels["linkerd.io/inject"])
+	assert.Equal(t, "argocd-server", deployment.Spec.Template.Labels[common.ArgoCDKeyName])
+	assert.NotContains(t, deployment.Spec.Template.Annotations, "prometheus.io/scrape")
+	assert.NotContains(t, deployment.Spec.Template.Annotations, common.ArgoCDPodAnnotationsAnnotation)
+	assert.Equal(t, "linkerd.io/inject", deployment.Spec.Template.Annotations[common.ArgoCDPodLabelsAnnotation])
+	assert.Equal(t, "2024-01-01T00:00:00Z", deployment.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"])
+}
+
+func TestReconcileArgoCD_reconcileServerDeployment_coreInstallation(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Ingress.Enabled = true
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex:      &argoproj.ArgoCDDexSpec{OpenShiftOAuth: true},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+	assert.True(t, UseDex(a))
+
+	// The Argo CD Server and its Ingress are removed once the instance is installed in core mode, and Dex is not used
+	a.Spec.InstallationMode = argoproj.ArgoCDInstallationModeCore
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+	assert.False(t, UseDex(a))
+
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, &appsv1.Deployment{})
+	assert.True(t, apierrors.IsNotFound(err))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, &networkingv1.Ingress{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// Nor are they created again
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, &appsv1.Deployment{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
 func TestArgoCDServerDeploymentCommand(t *testing.T) {
 	a := makeTestArgoCD()
 
@@ -1437,6 +1873,39 @@ func TestArgoCDServerCommand_isMergable(t *testing.T) {
 	assert.Error(t, isMergable(extraCMDArgs, cmd))
 }
 
+func TestArgoCDServerCommand_rootPathAndGRPCWeb(t *testing.T) {
+	a := makeTestArgoCD()
+	cmd := getArgoServerCommand(a, false)
+	assert.NotContains(t, cmd, "--rootpath")
+	assert.NotContains(t, cmd, "--basehref")
+	assert.NotContains(t, cmd, "--enable-grpc-web")
+
+	a = makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.RootPath = "argocd/"
+		a.Spec.Server.GRPCWeb = true
+	})
+	cmd = getArgoServerCommand(a, false)
+	assert.Contains(t, strings.Join(cmd, " "), "--rootpath /argocd --basehref /argocd --enable-grpc-web")
+}
+
+func TestArgoCDCommand_tls(t *testing.T) {
+	a := makeTestArgoCD()
+	assert.NotContains(t, getArgoServerCommand(a, false), "--tlsminversion")
+	assert.NotContains(t, getArgoRepoCommand(a, false), "--tlsciphers")
+
+	a = makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.TLS.MinVersion = "1.2"
+		a.Spec.TLS.CipherSuites = []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}
+	})
+	args := "--tlsminversion 1.2 --tlsciphers TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256:TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"
+	assert.Contains(t, strings.Join(getArgoServerCommand(a, false), " "), args)
+	assert.Contains(t, strings.Join(getArgoRepoCommand(a, false), " "), args)
+	assert.Empty(t, getTLSCipherSuitesWarning(a))
+
+	a.Spec.TLS.CipherSuites = append(a.Spec.TLS.CipherSuites, "TLS_WEAK")
+	assert.Contains(t, getTLSCipherSuitesWarning(a), "TLS_WEAK")
+}
+
 func TestReconcileArgoCD_reconcileServerDeploymentWithInsecure(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
@@ -1461,6 +1930,12 @@ func TestReconcileArgoCD_reconcileServerDeploymentWithInsecure(t *testing.T) {
 		},
 		deployment))
 	want := corev1.PodSpec{
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsNonRoot: boolPtr(true),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
 		Containers: []corev1.Container{
 			{
 				Name:            "argocd-server",
@@ -1568,6 +2043,12 @@ func TestReconcileArgoCD_reconcileServerDeploymentChangedToInsecure(t *testing.T
 		},
 		deployment))
 	want := corev1.PodSpec{
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsNonRoot: boolPtr(true),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
 		Containers: []corev1.Container{
 			{
 				Name:            "argocd-server",
@@ -2291,3 +2772,114 @@ func TestReconcileArgoCD_reconcileRepoDeployment_serviceAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileArgoCD_reconcileRepoDeployment_terminationGracePeriod(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.TerminationGracePeriodSeconds = int64Ptr(90)
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	key := types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, int64(90), *deployment.Spec.Template.Spec.TerminationGracePeriodSeconds)
+
+	a.Spec.Repo.TerminationGracePeriodSeconds = nil
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Nil(t, deployment.Spec.Template.Spec.TerminationGracePeriodSeconds)
+}
+
+func TestUpdateTerminationGracePeriod(t *testing.T) {
+	// The default filled in by the API server is not a change
+	existing := &corev1.PodSpec{TerminationGracePeriodSeconds: int64Ptr(corev1.DefaultTerminationGracePeriodSeconds)}
+	changed := false
+	updateTerminationGracePeriod(existing, &corev1.PodSpec{}, &changed)
+	assert.False(t, changed)
+
+	updateTerminationGracePeriod(existing, &corev1.PodSpec{TerminationGracePeriodSeconds: int64Ptr(60)}, &changed)
+	assert.True(t, changed)
+	assert.Equal(t, int64(60), *existing.TerminationGracePeriodSeconds)
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_dnsConfig(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.DNSPolicy = corev1.DNSNone
+		a.Spec.Repo.DNSConfig = &corev1.PodDNSConfig{
+			Nameservers: []string{"10.0.0.53"},
+			Searches:    []string{"git.corp.example.com"},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	key := types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, corev1.DNSNone, deployment.Spec.Template.Spec.DNSPolicy)
+	assert.Equal(t, a.Spec.Repo.DNSConfig, deployment.Spec.Template.Spec.DNSConfig)
+
+	a.Spec.Repo.DNSPolicy = ""
+	a.Spec.Repo.DNSConfig = nil
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Empty(t, deployment.Spec.Template.Spec.DNSPolicy)
+	assert.Nil(t, deployment.Spec.Template.Spec.DNSConfig)
+}
+
+func TestReconcileArgoCD_reconcileDeployments_otelResourceAttributes(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Monitoring.ResourceAttributes = map[string]string{
+			"service.namespace":      "team-a",
+			"deployment.environment": "prod,eu=1",
+		}
+		a.Spec.Repo.Env = []corev1.EnvVar{{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: "team=repo"}}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	getEnv := func(name string) []corev1.EnvVar {
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, deployment))
+		return deployment.Spec.Template.Spec.Containers[0].Env
+	}
+
+	assert.Contains(t, getEnv("argocd-server"), corev1.EnvVar{
+		Name:  "OTEL_RESOURCE_ATTRIBUTES",
+		Value: "deployment.environment=prod%2Ceu%3D1,service.namespace=team-a",
+	})
+	// The environment set by the user takes precedence
+	assert.Contains(t, getEnv("argocd-repo-server"), corev1.EnvVar{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: "team=repo"})
+
+	a.Spec.Monitoring.ResourceAttributes = nil
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	for _, env := range getEnv("argocd-server") {
+		assert.NotEqual(t, "OTEL_RESOURCE_ATTRIBUTES", env.Name)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+// deprecatedFieldsEventReason is the reason of the Events reporting the deprecated fields set on an ArgoCD.
+const deprecatedFieldsEventReason = "DeprecatedFields"
+
+// deprecatedField is a deprecated field of the ArgoCD API set on an instance, with the field replacing it.
+type deprecatedField struct {
+	path        string
+	replacement string
+}
+
+// warning returns the message warning about the usage of the deprecated field.
+func (f deprecatedField) warning() string {
+	return fmt.Sprintf("%s is deprecated and will be removed in a future release, use %s instead", f.path, f.replacement)
+}
+
+// getDeprecatedFields will return the deprecated fields set on the given ArgoCD.
+func getDeprecatedFields(cr *argoproj.ArgoCD) []deprecatedField {
+	fields := []deprecatedField{}
+	if !reflect.DeepEqual(cr.Spec.Grafana, argoproj.ArgoCDGrafanaSpec{}) {
+		fields = append(fields, deprecatedField{path: "spec.grafana", replacement: "a Grafana instance managed outside of the operator"})
+	}
+	if len(cr.Spec.InitialRepositories) > 0 {
+		fields = append(fields, deprecatedField{path: "spec.initialRepositories", replacement: "spec.repositories"})
+	}
+	if len(cr.Spec.RepositoryCredentials) > 0 {
+		fields = append(fields, deprecatedField{path: "spec.repositoryCredentials", replacement: "spec.repositories"})
+	}
+	return fields
+}
+
+// getAlphaDeprecatedFields will return the deprecated fields set on the given v1alpha1 ArgoCD that are dropped when it
+// is converted to v1beta1. The deprecated fields kept by the conversion are reported by getDeprecatedFields.
+func getAlphaDeprecatedFields(cr *argoprojv1alpha1.ArgoCD) []deprecatedField {
+	fields := []deprecatedField{}
+	if cr.Spec.Dex != nil {
+		fields = append(fields, deprecatedField{path: "spec.dex", replacement: "spec.sso.dex with spec.sso.provider set to dex"})
+	}
+	if len(cr.Spec.ResourceCustomizations) > 0 {
+		fields = append(fields, deprecatedField{path: "spec.resourceCustomizations", replacement: "spec.resourceHealthChecks, spec.resourceIgnoreDifferences and spec.resourceActions"})
+	}
+	if cr.Spec.SSO != nil {
+		if len(cr.Spec.SSO.Image) > 0 {
+			fields = append(fields, deprecatedField{path: "spec.sso.image", replacement: "spec.sso.keycloak.image"})
+		}
+		if len(cr.Spec.SSO.Version) > 0 {
+			fields = append(fields, deprecatedField{path: "spec.sso.version", replacement: "spec.sso.keycloak.version"})
+		}
+		if cr.Spec.SSO.Resources != nil {
+			fields = append(fields, deprecatedField{path: "spec.sso.resources", replacement: "spec.sso.keycloak.resources"})
+		}
+		if cr.Spec.SSO.VerifyTLS != nil {
+			fields = append(fields, deprecatedField{path: "spec.sso.verifyTLS", replacement: "spec.sso.keycloak.verifyTLS"})
+		}
+	}
+	return fields
+}
+
+// getDeprecationWarnings will return the warnings about the given deprecated fields.
+func getDeprecationWarnings(fields []deprecatedField) []string {
+	warnings := make([]string, 0, len(fields))
+	for _, field := range fields {
+		warnings = append(warnings, field.warning())
+	}
+	return warnings
+}
+
+// reconcileDeprecatedFields will emit a Warning Event on the given ArgoCD listing the deprecated fields it sets, with
+// their replacement, so that they can be migrated before they are removed. The Event is emitted again only when the
+// deprecated fields set on the instance change.
+func (r *ReconcileArgoCD) reconcileDeprecatedFields(cr *argoproj.ArgoCD) error {
+	message := strings.Join(getDeprecationWarnings(getDeprecatedFields(cr)), "; ")
+	key := types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}
+	status := DeprecationEventEmissionTracker[key]
+	if status.DeprecatedFieldsWarning == message {
+		return nil // Already reported, move along...
+	}
+	status.DeprecatedFieldsWarning = message
+	DeprecationEventEmissionTracker[key] = status
+	if len(message) <= 0 {
+		return nil // No deprecated field set anymore
+	}
+
+	instanceLog(cr).Info(fmt.Sprintf("ArgoCD %s/%s: %s", cr.Namespace, cr.Name, message))
+	r.recordEvent(cr, corev1.EventTypeWarning, deprecatedFieldsEventReason, message)
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestReconcileArgoCD_reconcileDeprecatedFields(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Grafana.Enabled = true
+		a.Spec.InitialRepositories = "- url: https://github.com/argoproj/argocd-example-apps"
+	})
+	key := types.NamespacedName{Name: a.Name, Namespace: a.Namespace}
+	delete(DeprecationEventEmissionTracker, key)
+	t.Cleanup(func() { delete(DeprecationEventEmissionTracker, key) })
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	assert.NoError(t, r.reconcileDeprecatedFields(a))
+	assert.Len(t, recorder.Events, 1)
+	event := <-recorder.Events
+	assert.Contains(t, event, corev1.EventTypeWarning+" "+deprecatedFieldsEventReason)
+	assert.Contains(t, event, "spec.grafana is deprecated")
+	assert.Contains(t, event, "spec.initialRepositories is deprecated and will be removed in a future release, use spec.repositories instead")
+
+	// The same deprecated fields are only reported once
+	assert.NoError(t, r.reconcileDeprecatedFields(a))
+	assert.Empty(t, recorder.Events)
+
+	// Another instance in the same namespace is reported on its own
+	b := a.DeepCopy()
+	b.Name = "other"
+	t.Cleanup(func() {
+		delete(DeprecationEventEmissionTracker, types.NamespacedName{Name: b.Name, Namespace: b.Namespace})
+	})
+	assert.NoError(t, r.reconcileDeprecatedFields(b))
+	assert.Len(t, recorder.Events, 1)
+	<-recorder.Events
+
+	// Migrating a field reports the remaining ones
+	a.Spec.InitialRepositories = ""
+	assert.NoError(t, r.reconcileDeprecatedFields(a))
+	assert.Len(t, recorder.Events, 1)
+	assert.NotContains(t, <-recorder.Events, "spec.initialRepositories")
+
+	// Nothing is reported once all the fields are migrated
+	a.Spec.Grafana = argoproj.ArgoCDGrafanaSpec{}
+	assert.NoError(t, r.reconcileDeprecatedFields(a))
+	assert.Empty(t, recorder.Events)
+}
+
+func TestGetAlphaDeprecatedFields(t *testing.T) {
+	cr := &argoprojv1alpha1.ArgoCD{}
+	assert.Empty(t, getAlphaDeprecatedFields(cr))
+
+	cr.Spec.Dex = &argoprojv1alpha1.ArgoCDDexSpec{OpenShiftOAuth: true}
+	cr.Spec.ResourceCustomizations = "apps/Deployment: {}"
+	cr.Spec.SSO = &argoprojv1alpha1.ArgoCDSSOSpec{Image: "quay.io/keycloak/keycloak"}
+	assert.Equal(t, []string{
+		"spec.dex is deprecated and will be removed in a future release, use spec.sso.dex with spec.sso.provider set to dex instead",
+		"spec.resourceCustomizations is deprecated and will be removed in a future release, use spec.resourceHealthChecks, spec.resourceIgnoreDifferences and spec.resourceActions instead",
+		"spec.sso.image is deprecated and will be removed in a future release, use spec.sso.keycloak.image instead",
+	}, getDeprecationWarnings(getAlphaDeprecatedFields(cr)))
+}
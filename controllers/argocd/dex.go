@@ -30,8 +30,20 @@ type DexConnector struct {
 	Type   string                 `yaml:"type"`
 }
 
+// DexStaticClient represents an OAuth2 client registered in Dex.
+type DexStaticClient struct {
+	ID           string   `yaml:"id"`
+	Name         string   `yaml:"name,omitempty"`
+	Secret       string   `yaml:"secret,omitempty"`
+	RedirectURIs []string `yaml:"redirectURIs,omitempty"`
+	Public       bool     `yaml:"public,omitempty"`
+}
+
 // UseDex determines whether Dex resources should be created and configured or not
 func UseDex(cr *argoproj.ArgoCD) bool {
+	if cr.IsCoreInstallation() {
+		return false // Dex only serves the logins to the Argo CD Server
+	}
 	if cr.Spec.SSO != nil {
 		return cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeDex
 	}
@@ -110,6 +122,11 @@ func (r *ReconcileArgoCD) reconcileDexConfiguration(cm *corev1.ConfigMap, cr *ar
 		desired = cfg
 	}
 
+	desired, err := addDexStaticClients(cr, desired)
+	if err != nil {
+		return err
+	}
+
 	if actual != desired {
 		// Update ConfigMap with desired configuration.
 		cm.Data[common.ArgoCDKeyDexConfig] = desired
@@ -120,7 +137,7 @@ func (r *ReconcileArgoCD) reconcileDexConfiguration(cm *corev1.ConfigMap, cr *ar
 		// Trigger rollout of Dex Deployment to pick up changes.
 		deploy := newDeploymentWithSuffix("dex-server", "dex-server", cr)
 		if !argoutil.IsObjectFound(r.Client, deploy.Namespace, deploy.Name, deploy) {
-			log.Info("unable to locate dex deployment")
+			instanceLog(cr).Info("unable to locate dex deployment")
 			return nil
 		}
 
@@ -168,6 +185,52 @@ func (r *ReconcileArgoCD) getOpenShiftDexConfig(cr *argoproj.ArgoCD) (string, er
 	return string(bytes), err
 }
 
+// getDexStaticClients will return the static clients of the Dex server for the given ArgoCD. Client secrets are
+// referenced with the $<secret>:<key> syntax, and resolved by Argo CD when generating the Dex configuration.
+func getDexStaticClients(cr *argoproj.ArgoCD) []DexStaticClient {
+	if cr.Spec.SSO == nil || cr.Spec.SSO.Dex == nil {
+		return nil
+	}
+
+	var clients []DexStaticClient
+	for _, c := range cr.Spec.SSO.Dex.StaticClients {
+		cl := DexStaticClient{
+			ID:           c.ID,
+			Name:         c.Name,
+			RedirectURIs: c.RedirectURIs,
+			Public:       c.Public,
+		}
+		if c.SecretRef != nil {
+			cl.Secret = fmt.Sprintf("$%s:%s", c.SecretRef.Name, c.SecretRef.Key)
+		}
+		clients = append(clients, cl)
+	}
+	return clients
+}
+
+// addDexStaticClients will append the static clients of the given ArgoCD to the given Dex configuration, after the
+// static clients already defined in the configuration.
+func addDexStaticClients(cr *argoproj.ArgoCD, config string) (string, error) {
+	clients := getDexStaticClients(cr)
+	if len(clients) == 0 {
+		return config, nil
+	}
+
+	dex := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(config), dex); err != nil {
+		return "", err
+	}
+
+	staticClients, _ := dex["staticClients"].([]interface{})
+	for _, c := range clients {
+		staticClients = append(staticClients, c)
+	}
+	dex["staticClients"] = staticClients
+
+	bytes, err := yaml.Marshal(dex)
+	return string(bytes), err
+}
+
 func addDexConfigFromCR(cr *argoproj.ArgoCD, dex map[string]interface{}) error {
 	dexCfgStr := getDexConfig(cr)
 	if dexCfgStr == "" {
@@ -193,7 +256,7 @@ func (r *ReconcileArgoCD) reconcileDexServiceAccount(cr *argoproj.ArgoCD) error
 		return nil // OpenShift OAuth not enabled, move along...
 	}
 
-	log.Info("oauth enabled, configuring dex service account")
+	instanceLog(cr).Info("oauth enabled, configuring dex service account")
 	sa := newServiceAccountWithName(common.ArgoCDDefaultDexServiceAccountName, cr)
 	if err := argoutil.FetchObject(r.Client, cr.Namespace, sa.Name, sa); err != nil {
 		return err
@@ -201,7 +264,7 @@ func (r *ReconcileArgoCD) reconcileDexServiceAccount(cr *argoproj.ArgoCD) error
 
 	// Get the OAuth redirect URI that should be used.
 	uri := r.getDexOAuthRedirectURI(cr)
-	log.Info(fmt.Sprintf("URI: %s", uri))
+	instanceLog(cr).Info(fmt.Sprintf("URI: %s", uri))
 
 	// Get the current redirect URI
 	ann := sa.ObjectMeta.Annotations
@@ -210,7 +273,7 @@ func (r *ReconcileArgoCD) reconcileDexServiceAccount(cr *argoproj.ArgoCD) error
 		return nil // Redirect URI annotation found and correct, move along...
 	}
 
-	log.Info(fmt.Sprintf("current URI: %s is not correct, should be: %s", currentURI, uri))
+	instanceLog(cr).Info(fmt.Sprintf("current URI: %s is not correct, should be: %s", currentURI, uri))
 	if len(ann) <= 0 {
 		ann = make(map[string]string)
 	}
@@ -227,9 +290,62 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
 
-	dexEnv := proxyEnvVars()
+	var dexSpec *argoproj.ArgoCDDexSpec
 	if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil {
-		dexEnv = append(dexEnv, cr.Spec.SSO.Dex.Env...)
+		dexSpec = cr.Spec.SSO.Dex
+	}
+
+	dexEnv := proxyEnvVars()
+	dexVolumeMounts := []corev1.VolumeMount{{
+		Name:      "static-files",
+		MountPath: "/shared",
+	}}
+	dexVolumes := []corev1.Volume{{
+		Name: "static-files",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}}
+	if dexSpec != nil && dexSpec.RootCASecretRef != nil {
+		// Trust the root CA in addition to the system CAs, read from the directories listed in SSL_CERT_DIR
+		dexEnv = append(dexEnv, corev1.EnvVar{
+			Name:  "SSL_CERT_DIR",
+			Value: "/etc/ssl/certs:" + common.ArgoCDDexRootCAPath,
+		})
+		dexVolumeMounts = append(dexVolumeMounts, corev1.VolumeMount{
+			Name:      "dex-root-ca",
+			MountPath: common.ArgoCDDexRootCAPath,
+			ReadOnly:  true,
+		})
+		dexVolumes = append(dexVolumes, corev1.Volume{
+			Name: "dex-root-ca",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: dexSpec.RootCASecretRef.Name,
+					Items: []corev1.KeyToPath{{
+						Key:  dexSpec.RootCASecretRef.Key,
+						Path: "ca.crt",
+					}},
+					Optional: dexSpec.RootCASecretRef.Optional,
+				},
+			},
+		})
+	}
+	if dexSpec != nil {
+		dexEnv = append(dexEnv, dexSpec.Env...)
+		dexVolumeMounts = append(dexVolumeMounts, dexSpec.VolumeMounts...)
+		dexVolumes = append(dexVolumes, dexSpec.Volumes...)
+	}
+	if otelEnv := getOTelResourceAttributesEnv(cr); otelEnv != nil {
+		// Let the user specify their own resource attributes first
+		dexEnv = argoutil.EnvMerge(dexEnv, otelEnv, false)
+	}
+	if cryptoEnv := getCryptoPolicyEnv(cr); cryptoEnv != nil {
+		dexEnv = argoutil.EnvMerge(dexEnv, cryptoEnv, false)
+	}
+	var startupProbe *corev1.Probe
+	if dexSpec != nil {
+		startupProbe = dexSpec.StartupProbe
 	}
 
 	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
@@ -250,6 +366,17 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 			InitialDelaySeconds: 60,
 			PeriodSeconds:       30,
 		},
+		// Allow Dex up to five minutes to start before the liveness probe kicks in
+		StartupProbe: getSSOStartupProbe(corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/healthz/live",
+					Port: intstr.FromInt(common.ArgoCDDefaultDexMetricsPort),
+				},
+			},
+			PeriodSeconds:    10,
+			FailureThreshold: 30,
+		}, startupProbe),
 		Ports: []corev1.ContainerPort{
 			{
 				ContainerPort: common.ArgoCDDefaultDexHTTPPort,
@@ -272,10 +399,7 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 			},
 			RunAsNonRoot: boolPtr(true),
 		},
-		VolumeMounts: []corev1.VolumeMount{{
-			Name:      "static-files",
-			MountPath: "/shared",
-		}},
+		VolumeMounts: dexVolumeMounts,
 	}}
 
 	deploy.Spec.Template.Spec.InitContainers = []corev1.Container{{
@@ -306,19 +430,31 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 	}}
 
 	deploy.Spec.Template.Spec.ServiceAccountName = fmt.Sprintf("%s-%s", cr.Name, common.ArgoCDDefaultDexServiceAccountName)
-	deploy.Spec.Template.Spec.Volumes = []corev1.Volume{{
-		Name: "static-files",
-		VolumeSource: corev1.VolumeSource{
-			EmptyDir: &corev1.EmptyDirVolumeSource{},
-		},
-	}}
+	deploy.Spec.Template.Spec.Volumes = dexVolumes
+
+	var podSecurityContext *corev1.PodSecurityContext
+	var securityContext *corev1.SecurityContext
+	var terminationGracePeriodSeconds *int64
+	var dnsPolicy corev1.DNSPolicy
+	var dnsConfig *corev1.PodDNSConfig
+	if dexSpec != nil {
+		podSecurityContext = dexSpec.PodSecurityContext
+		securityContext = dexSpec.SecurityContext
+		terminationGracePeriodSeconds = dexSpec.TerminationGracePeriodSeconds
+		dnsPolicy = dexSpec.DNSPolicy
+		dnsConfig = dexSpec.DNSConfig
+	}
+	applySecurityContext(&deploy.Spec.Template.Spec, podSecurityContext, securityContext, "dex")
+	applyTerminationGracePeriod(&deploy.Spec.Template.Spec, terminationGracePeriodSeconds)
+	applyDNSConfig(&deploy.Spec.Template.Spec, dnsPolicy, dnsConfig)
+	applyLogSidecar(cr, &deploy.Spec.Template.Spec, "dex")
 
 	existing := newDeploymentWithSuffix("dex-server", "dex-server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 
 		// dex uninstallation requested
 		if !UseDex(cr) {
-			log.Info("deleting the existing dex deployment because dex uninstallation has been requested")
+			instanceLog(cr).Info("deleting the existing dex deployment because dex uninstallation has been requested")
 			return r.Client.Delete(context.TODO(), existing)
 		}
 		changed := false
@@ -339,12 +475,22 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 			changed = true
 		}
 		updateNodePlacement(existing, deploy, &changed)
+		updateSecurityContext(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
+		updateTerminationGracePeriod(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
+		updateDNSConfig(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
+		updateLogSidecar(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &changed)
 		if !reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Env,
 			deploy.Spec.Template.Spec.Containers[0].Env) {
 			existing.Spec.Template.Spec.Containers[0].Env = deploy.Spec.Template.Spec.Containers[0].Env
 			changed = true
 		}
 
+		if !reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].StartupProbe,
+			deploy.Spec.Template.Spec.Containers[0].StartupProbe) {
+			existing.Spec.Template.Spec.Containers[0].StartupProbe = deploy.Spec.Template.Spec.Containers[0].StartupProbe
+			changed = true
+		}
+
 		if !reflect.DeepEqual(existing.Spec.Template.Spec.InitContainers[0].Env,
 			deploy.Spec.Template.Spec.InitContainers[0].Env) {
 			existing.Spec.Template.Spec.InitContainers[0].Env = deploy.Spec.Template.Spec.InitContainers[0].Env
@@ -356,6 +502,16 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 			changed = true
 		}
 
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, existing.Spec.Template.Spec.Containers[0].VolumeMounts) {
+			existing.Spec.Template.Spec.Containers[0].VolumeMounts = deploy.Spec.Template.Spec.Containers[0].VolumeMounts
+			changed = true
+		}
+
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Volumes, existing.Spec.Template.Spec.Volumes) {
+			existing.Spec.Template.Spec.Volumes = deploy.Spec.Template.Spec.Volumes
+			changed = true
+		}
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -371,7 +527,7 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
-	log.Info(fmt.Sprintf("creating deployment %s for Argo CD instance %s in namespace %s", deploy.Name, cr.Name, cr.Namespace))
+	instanceLog(cr).Info(fmt.Sprintf("creating deployment %s for Argo CD instance %s in namespace %s", deploy.Name, cr.Name, cr.Namespace))
 	return r.Client.Create(context.TODO(), deploy)
 }
 
@@ -382,7 +538,7 @@ func (r *ReconcileArgoCD) reconcileDexService(cr *argoproj.ArgoCD) error {
 
 		// dex uninstallation requested
 		if !UseDex(cr) {
-			log.Info("deleting the existing Dex service because dex uninstallation has been requested")
+			instanceLog(cr).Info("deleting the existing Dex service because dex uninstallation has been requested")
 			return r.Client.Delete(context.TODO(), svc)
 		}
 		return nil
@@ -415,7 +571,43 @@ func (r *ReconcileArgoCD) reconcileDexService(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
-	log.Info(fmt.Sprintf("creating service %s for Argo CD instance %s in namespace %s", svc.Name, cr.Name, cr.Namespace))
+	instanceLog(cr).Info(fmt.Sprintf("creating service %s for Argo CD instance %s in namespace %s", svc.Name, cr.Name, cr.Namespace))
+	return r.Client.Create(context.TODO(), svc)
+}
+
+// reconcileDexMetricsService will ensure that the Service exposing the telemetry port of Dex is present.
+func (r *ReconcileArgoCD) reconcileDexMetricsService(cr *argoproj.ArgoCD) error {
+	svc := newServiceWithSuffix("dex-server-metrics", "dex-server", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
+		if !UseDex(cr) {
+			instanceLog(cr).Info("deleting the existing Dex metrics service because dex uninstallation has been requested")
+			return r.Client.Delete(context.TODO(), svc)
+		}
+		return nil // Service found, do nothing
+	}
+
+	if !UseDex(cr) {
+		return nil // Dex is disabled, do nothing
+	}
+
+	svc.Spec.Selector = map[string]string{
+		common.ArgoCDKeyName: nameWithSuffix("dex-server", cr),
+	}
+
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       common.ArgoCDKeyMetrics,
+			Port:       common.ArgoCDDefaultDexMetricsPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexMetricsPort),
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info(fmt.Sprintf("creating service %s for Argo CD instance %s in namespace %s", svc.Name, cr.Name, cr.Namespace))
 	return r.Client.Create(context.TODO(), svc)
 }
 
@@ -423,11 +615,11 @@ func (r *ReconcileArgoCD) reconcileDexService(cr *argoproj.ArgoCD) error {
 // and deletion of dex resources based on the specified configuration of dex
 func (r *ReconcileArgoCD) reconcileDexResources(cr *argoproj.ArgoCD) error {
 	if _, err := r.reconcileRole(common.ArgoCDDexServerComponent, policyRuleForDexServer(), cr); err != nil {
-		log.Error(err, "error reconciling dex role")
+		instanceLog(cr).Error(err, "error reconciling dex role")
 	}
 
 	if err := r.reconcileRoleBinding(common.ArgoCDDexServerComponent, policyRuleForDexServer(), cr); err != nil {
-		log.Error(err, "error reconciling dex rolebinding")
+		instanceLog(cr).Error(err, "error reconciling dex rolebinding")
 	}
 
 	if err := r.reconcileServiceAccountPermissions(common.ArgoCDDexServerComponent, policyRuleForDexServer(), cr); err != nil {
@@ -436,24 +628,28 @@ func (r *ReconcileArgoCD) reconcileDexResources(cr *argoproj.ArgoCD) error {
 
 	// specialized handling for dex
 	if err := r.reconcileDexServiceAccount(cr); err != nil {
-		log.Error(err, "error reconciling dex serviceaccount")
+		instanceLog(cr).Error(err, "error reconciling dex serviceaccount")
 	}
 
 	// Reconcile dex config in argocd-cm, create dex config in argocd-cm if required (right after dex is enabled)
 	if err := r.reconcileArgoConfigMap(cr); err != nil {
-		log.Error(err, "error reconciling argocd-cm configmap")
+		instanceLog(cr).Error(err, "error reconciling argocd-cm configmap")
 	}
 
 	if err := r.reconcileDexService(cr); err != nil {
-		log.Error(err, "error reconciling dex service")
+		instanceLog(cr).Error(err, "error reconciling dex service")
+	}
+
+	if err := r.reconcileDexMetricsService(cr); err != nil {
+		instanceLog(cr).Error(err, "error reconciling dex metrics service")
 	}
 
 	if err := r.reconcileDexDeployment(cr); err != nil {
-		log.Error(err, "error reconciling dex deployment")
+		instanceLog(cr).Error(err, "error reconciling dex deployment")
 	}
 
 	if err := r.reconcileStatusSSO(cr); err != nil {
-		log.Error(err, "error reconciling dex status")
+		instanceLog(cr).Error(err, "error reconciling dex status")
 	}
 
 	return nil
@@ -480,11 +676,15 @@ func (r *ReconcileArgoCD) deleteDexResources(cr *argoproj.ArgoCD) error {
 	}
 
 	if err := r.reconcileDexDeployment(cr); err != nil {
-		log.Error(err, "error reconciling dex deployment")
+		instanceLog(cr).Error(err, "error reconciling dex deployment")
 	}
 
 	if err := r.reconcileDexService(cr); err != nil {
-		log.Error(err, "error reconciling dex service")
+		instanceLog(cr).Error(err, "error reconciling dex service")
+	}
+
+	if err := r.reconcileDexMetricsService(cr); err != nil {
+		instanceLog(cr).Error(err, "error reconciling dex metrics service")
 	}
 
 	// Reconcile dex config in argocd-cm (right after dex is disabled)
@@ -494,16 +694,23 @@ func (r *ReconcileArgoCD) deleteDexResources(cr *argoproj.ArgoCD) error {
 	cm := newConfigMapWithName(common.ArgoCDConfigMapName, cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
 		if err := r.reconcileDexConfiguration(cm, cr); err != nil {
-			log.Error(err, "error reconciling dex configuration in configmap")
+			instanceLog(cr).Error(err, "error reconciling dex configuration in configmap")
 		}
 	}
 
 	if err := r.reconcileRoleBinding(common.ArgoCDDexServerComponent, policyRuleForDexServer(), cr); err != nil {
-		log.Error(err, "error reconciling dex rolebinding")
+		instanceLog(cr).Error(err, "error reconciling dex rolebinding")
+	}
+
+	// Sweep the remaining dex resources, such as the role and service account
+	if !UseDex(cr) {
+		if err := r.deleteComponentResources(cr, componentDex); err != nil {
+			instanceLog(cr).Error(err, "error deleting dex resources")
+		}
 	}
 
 	if err := r.reconcileStatusSSO(cr); err != nil {
-		log.Error(err, "error reconciling dex status")
+		instanceLog(cr).Error(err, "error reconciling dex status")
 	}
 
 	return nil
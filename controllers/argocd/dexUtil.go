@@ -23,6 +23,10 @@ import (
 // 3. the default is configured in common.ArgoCDDefaultDexVersion and
 // common.ArgoCDDefaultDexImage.
 func getDexContainerImage(cr *argoproj.ArgoCD) string {
+	if img := getImageOverride(cr, dexImageComponent); img != "" {
+		return img
+	}
+
 	defaultImg, defaultTag := false, false
 
 	img := ""
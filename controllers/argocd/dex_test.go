@@ -254,6 +254,12 @@ func TestReconcileArgoCD_reconcileDexDeployment(t *testing.T) {
 		},
 		deployment))
 	want := corev1.PodSpec{
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsNonRoot: boolPtr(true),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
 		Volumes: []corev1.Volume{
 			{
 				Name: "static-files",
@@ -308,6 +314,19 @@ func TestReconcileArgoCD_reconcileDexDeployment(t *testing.T) {
 					InitialDelaySeconds: 60,
 					PeriodSeconds:       30,
 				},
+				StartupProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Path:   "/healthz/live",
+							Port:   intstr.FromInt(5558),
+							Scheme: corev1.URISchemeHTTP,
+						},
+					},
+					TimeoutSeconds:   1,
+					PeriodSeconds:    10,
+					SuccessThreshold: 1,
+					FailureThreshold: 30,
+				},
 				Ports: []corev1.ContainerPort{
 					{
 						Name:          "http",
@@ -375,6 +394,12 @@ func TestReconcileArgoCD_reconcileDexDeployment_withUpdate(t *testing.T) {
 				}
 			}),
 			wantPodSpec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsNonRoot: boolPtr(true),
+					SeccompProfile: &corev1.SeccompProfile{
+						Type: corev1.SeccompProfileTypeRuntimeDefault,
+					},
+				},
 				Volumes: []corev1.Volume{
 					{
 						Name: "static-files",
@@ -429,6 +454,19 @@ func TestReconcileArgoCD_reconcileDexDeployment_withUpdate(t *testing.T) {
 							InitialDelaySeconds: 60,
 							PeriodSeconds:       30,
 						},
+						StartupProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{
+									Path:   "/healthz/live",
+									Port:   intstr.FromInt(5558),
+									Scheme: corev1.URISchemeHTTP,
+								},
+							},
+							TimeoutSeconds:   1,
+							PeriodSeconds:    10,
+							SuccessThreshold: 1,
+							FailureThreshold: 30,
+						},
 						Ports: []corev1.ContainerPort{
 							{
 								Name:          "http",
@@ -488,6 +526,12 @@ func TestReconcileArgoCD_reconcileDexDeployment_withUpdate(t *testing.T) {
 				}
 			}),
 			wantPodSpec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsNonRoot: boolPtr(true),
+					SeccompProfile: &corev1.SeccompProfile{
+						Type: corev1.SeccompProfileTypeRuntimeDefault,
+					},
+				},
 				Volumes: []corev1.Volume{
 					{
 						Name: "static-files",
@@ -542,6 +586,19 @@ func TestReconcileArgoCD_reconcileDexDeployment_withUpdate(t *testing.T) {
 							InitialDelaySeconds: 60,
 							PeriodSeconds:       30,
 						},
+						StartupProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{
+									Path:   "/healthz/live",
+									Port:   intstr.FromInt(5558),
+									Scheme: corev1.URISchemeHTTP,
+								},
+							},
+							TimeoutSeconds:   1,
+							PeriodSeconds:    10,
+							SuccessThreshold: 1,
+							FailureThreshold: 30,
+						},
 						Ports: []corev1.ContainerPort{
 							{
 								Name:          "http",
@@ -994,3 +1051,119 @@ func TestReconcileArgoCD_reconcileRoleBinding_dex_disabled(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileArgoCD_reconcileDexDeployment_withRootCAAndVolumes(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+		Provider: argoproj.SSOProviderTypeDex,
+		Dex: &argoproj.ArgoCDDexSpec{
+			RootCASecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "ldap-ca"},
+				Key:                  "ca.pem",
+			},
+		},
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileDexDeployment(a))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-dex-server", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Contains(t, podSpec.Volumes, corev1.Volume{
+		Name: "dex-root-ca",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "ldap-ca",
+				Items:      []corev1.KeyToPath{{Key: "ca.pem", Path: "ca.crt"}},
+			},
+		},
+	})
+	assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "dex-root-ca",
+		MountPath: common.ArgoCDDexRootCAPath,
+		ReadOnly:  true,
+	})
+	assert.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{
+		Name:  "SSL_CERT_DIR",
+		Value: "/etc/ssl/certs:" + common.ArgoCDDexRootCAPath,
+	})
+
+	// Volumes added by the user are appended to the ones of the operator
+	a.Spec.SSO.Dex.RootCASecretRef = nil
+	a.Spec.SSO.Dex.Volumes = []corev1.Volume{{
+		Name: "connector-config",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "connector-config"},
+			},
+		},
+	}}
+	a.Spec.SSO.Dex.VolumeMounts = []corev1.VolumeMount{{
+		Name:      "connector-config",
+		MountPath: "/etc/dex/connectors",
+	}}
+	assert.NoError(t, r.reconcileDexDeployment(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+
+	podSpec = deployment.Spec.Template.Spec
+	assert.Len(t, podSpec.Volumes, 2)
+	assert.Equal(t, "static-files", podSpec.Volumes[0].Name)
+	assert.Equal(t, "connector-config", podSpec.Volumes[1].Name)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "static-files", MountPath: "/shared"},
+		{Name: "connector-config", MountPath: "/etc/dex/connectors"},
+	}, podSpec.Containers[0].VolumeMounts)
+	for _, env := range podSpec.Containers[0].Env {
+		assert.NotEqual(t, "SSL_CERT_DIR", env.Name)
+	}
+}
+
+func TestReconcileArgoCD_reconcileDexDeployment_startupProbe(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+		Provider: argoproj.SSOProviderTypeDex,
+		Dex: &argoproj.ArgoCDDexSpec{
+			OpenShiftOAuth: true,
+		},
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileDexDeployment(a))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-dex-server", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	probe := deployment.Spec.Template.Spec.Containers[0].StartupProbe
+	assert.Equal(t, "/healthz/live", probe.HTTPGet.Path)
+	assert.Equal(t, int32(30), probe.FailureThreshold)
+
+	// The override is applied to the existing deployment, with the default handler
+	a.Spec.SSO.Dex.StartupProbe = &corev1.Probe{
+		PeriodSeconds:    5,
+		FailureThreshold: 120,
+	}
+	assert.NoError(t, r.reconcileDexDeployment(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	probe = deployment.Spec.Template.Spec.Containers[0].StartupProbe
+	assert.Equal(t, "/healthz/live", probe.HTTPGet.Path)
+	assert.Equal(t, int32(5), probe.PeriodSeconds)
+	assert.Equal(t, int32(120), probe.FailureThreshold)
+	assert.Equal(t, int32(1), probe.SuccessThreshold)
+}
@@ -0,0 +1,365 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// dryRunRecorder is a client sending all its writes as server-side dry runs, which records the resources that would
+// be created, updated or deleted. The objects written are kept, so that the reconciliation reads them back as if they
+// had been applied.
+type dryRunRecorder struct {
+	client.Client
+	changes []string
+	objects map[string]client.Object
+}
+
+// newDryRunRecorder returns a dryRunRecorder reading from, and sending the dry runs through, the given client.
+func newDryRunRecorder(c client.Client) *dryRunRecorder {
+	return &dryRunRecorder{Client: client.NewDryRunClient(c), objects: map[string]client.Object{}}
+}
+
+// describeObject returns the kind and the namespaced name of the given object.
+func describeObject(obj client.Object, key client.ObjectKey, scheme *runtime.Scheme) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := apiutil.GVKForObject(obj, scheme); err == nil {
+		kind = gvk.Kind
+	}
+	if key.Namespace != "" {
+		return fmt.Sprintf("%s %s/%s", kind, key.Namespace, key.Name)
+	}
+	return fmt.Sprintf("%s %s", kind, key.Name)
+}
+
+// describe returns the kind and the namespaced name of the given object.
+func (c *dryRunRecorder) describe(obj client.Object, key client.ObjectKey) string {
+	return describeObject(obj, key, c.Scheme())
+}
+
+// record will add the given change of the given object to the recorded changes, unless already recorded, and keep
+// the object as written, nil for a deletion.
+func (c *dryRunRecorder) record(action string, obj client.Object) {
+	id := c.describe(obj, client.ObjectKeyFromObject(obj))
+	if action == "delete" {
+		c.objects[id] = nil
+	} else {
+		c.objects[id] = obj.DeepCopyObject().(client.Object)
+	}
+
+	change := fmt.Sprintf("%s %s", action, id)
+	for _, recorded := range c.changes {
+		if recorded == change {
+			return
+		}
+	}
+	c.changes = append(c.changes, change)
+}
+
+func (c *dryRunRecorder) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	id := c.describe(obj, key)
+	written, ok := c.objects[id]
+	if !ok {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+	if written == nil {
+		gvk, _ := apiutil.GVKForObject(obj, c.Scheme())
+		return errors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: strings.ToLower(gvk.Kind)}, key.Name)
+	}
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(written.DeepCopyObject()).Elem())
+	return nil
+}
+
+func (c *dryRunRecorder) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record("create", obj)
+	return nil
+}
+
+func (c *dryRunRecorder) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record("update", obj)
+	return nil
+}
+
+func (c *dryRunRecorder) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	c.record("update", obj)
+	return nil
+}
+
+func (c *dryRunRecorder) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record("delete", obj)
+	return nil
+}
+
+func (c *dryRunRecorder) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if err := c.Client.DeleteAllOf(ctx, obj, opts...); err != nil {
+		return err
+	}
+	deleteOpts := &client.DeleteAllOfOptions{}
+	deleteOpts.ApplyOptions(opts)
+	kind := strings.SplitN(c.describe(obj, client.ObjectKey{}), " ", 2)[0]
+	change := fmt.Sprintf("delete all %s", kind)
+	if deleteOpts.Namespace != "" {
+		change = fmt.Sprintf("%s in namespace %s", change, deleteOpts.Namespace)
+	}
+	c.changes = append(c.changes, change)
+	return nil
+}
+
+// isDryRun returns true if the reconciler sends its writes as dry runs.
+func (r *ReconcileArgoCD) isDryRun() bool {
+	_, ok := r.Client.(*dryRunRecorder)
+	return ok
+}
+
+// newDryRunReconciler returns a copy of the reconciler sending all its writes through the given recorder. The managed
+// namespaces are copied as well, so that the dry run leaves the state of the reconciler untouched.
+func (r *ReconcileArgoCD) newDryRunReconciler(recorder *dryRunRecorder) *ReconcileArgoCD {
+	dryRun := *r
+	dryRun.Client = recorder
+	if r.ManagedNamespaces != nil {
+		dryRun.ManagedNamespaces = r.ManagedNamespaces.DeepCopy()
+	}
+	dryRun.ManagedSourceNamespaces = maps.Clone(r.ManagedSourceNamespaces)
+	dryRun.ManagedApplicationSetSourceNamespaces = maps.Clone(r.ManagedApplicationSetSourceNamespaces)
+	return &dryRun
+}
+
+// isDryRunRequested returns true if a dry run of the reconciliation of the given ArgoCD is requested.
+func isDryRunRequested(cr *argoproj.ArgoCD) bool {
+	return strings.EqualFold(cr.Annotations[common.ArgoCDDryRunAnnotation], "true")
+}
+
+// newDryRunConfigMap returns the ConfigMap holding the changes recorded by the dry runs of the given ArgoCD.
+func newDryRunConfigMap(cr *argoproj.ArgoCD) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nameWithSuffix("dry-run", cr),
+			Namespace: cr.Namespace,
+			Labels:    argoutil.LabelsForCluster(cr),
+		},
+	}
+}
+
+// getPendingNamespaces returns the namespaces recorded in the given dry run ConfigMap, whose cleanup is postponed
+// until the end of the dry run.
+func getPendingNamespaces(cm *corev1.ConfigMap) []string {
+	if cm.Data[common.ArgoCDKeyDryRunPendingNamespaces] == "" {
+		return nil
+	}
+	return strings.Split(cm.Data[common.ArgoCDKeyDryRunPendingNamespaces], "\n")
+}
+
+// deferNamespaceCleanup will record the given source namespace, no longer managed by the ArgoCD instances of the given
+// owner namespace, in the dry run ConfigMap of the instances in dry run, so that its RBACs and cluster secret entry
+// are removed once the dry run ends. It returns false when no instance is in dry run, in which case the namespace
+// must be cleaned up right away.
+func (r *ReconcileArgoCD) deferNamespaceCleanup(ownerNS, sourceNS string) bool {
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, client.InNamespace(ownerNS)); err != nil {
+		log.Error(err, fmt.Sprintf("unable to list the ArgoCD instances in namespace %s", ownerNS))
+		return false
+	}
+
+	deferred := false
+	for i := range argocds.Items {
+		cr := &argocds.Items[i]
+		if !isDryRunRequested(cr) {
+			continue
+		}
+		deferred = true
+
+		cm := newDryRunConfigMap(cr)
+		exists := true
+		if err := argoutil.FetchObject(r.Client, cr.Namespace, cm.Name, cm); err != nil {
+			if !errors.IsNotFound(err) {
+				log.Error(err, fmt.Sprintf("unable to postpone the cleanup of namespace %s", sourceNS))
+				continue
+			}
+			if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+				log.Error(err, fmt.Sprintf("unable to postpone the cleanup of namespace %s", sourceNS))
+				continue
+			}
+			exists = false
+		}
+
+		pending := getPendingNamespaces(cm)
+		if slices.Contains(pending, sourceNS) {
+			continue
+		}
+		pending = append(pending, sourceNS)
+		sort.Strings(pending)
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[common.ArgoCDKeyDryRunPendingNamespaces] = strings.Join(pending, "\n")
+
+		var err error
+		if exists {
+			err = r.Client.Update(context.TODO(), cm)
+		} else {
+			err = r.Client.Create(context.TODO(), cm)
+		}
+		if err != nil {
+			log.Error(err, fmt.Sprintf("unable to postpone the cleanup of namespace %s", sourceNS))
+			continue
+		}
+		log.Info(fmt.Sprintf("ArgoCD %s/%s is in dry run, the cleanup of namespace %s is postponed until the dry run ends", cr.Namespace, cr.Name, sourceNS))
+	}
+	return deferred
+}
+
+// cleanupPendingNamespaces will remove the RBACs and the cluster secret entry of the namespaces recorded in the given
+// dry run ConfigMap of the given ArgoCD, unless the instance manages them again.
+func (r *ReconcileArgoCD) cleanupPendingNamespaces(cr *argoproj.ArgoCD, cm *corev1.ConfigMap) error {
+	pending := getPendingNamespaces(cm)
+	if len(pending) == 0 {
+		return nil
+	}
+
+	k8sClient, err := initK8sClient()
+	if err != nil {
+		return err
+	}
+	for _, sourceNS := range pending {
+		ns := &corev1.Namespace{}
+		if err := r.Client.Get(context.TODO(), client.ObjectKey{Name: sourceNS}, ns); err == nil && ns.Labels[common.ArgoCDManagedByLabel] == cr.Namespace {
+			continue // Managed again since the dry run started
+		}
+		if err := deleteRBACsForNamespace(sourceNS, k8sClient); err != nil {
+			return fmt.Errorf("failed to delete RBACs for namespace %s: %w", sourceNS, err)
+		}
+		if err := deleteManagedNamespaceFromClusterSecret(cr.Namespace, sourceNS, k8sClient); err != nil {
+			return fmt.Errorf("unable to delete namespace %s from cluster secret: %w", sourceNS, err)
+		}
+	}
+	return nil
+}
+
+// reconcileDryRun will run the reconciliation of the resources of the given ArgoCD with all the writes sent as
+// server-side dry runs, and record the resources that would be created, updated or deleted in the dry run ConfigMap.
+// Only the changes to Kubernetes resources are recorded, requests to other services, e.g. Keycloak, are still sent.
+// The operator caches, e.g. the state of the server autoscaler, are left untouched.
+func (r *ReconcileArgoCD) reconcileDryRun(cr *argoproj.ArgoCD) error {
+	recorder := newDryRunRecorder(r.Client)
+	dryRunErr := r.newDryRunReconciler(recorder).reconcileResources(cr.DeepCopy())
+
+	cm := newDryRunConfigMap(cr)
+	cm.Data = map[string]string{common.ArgoCDKeyDryRunChanges: strings.Join(recorder.changes, "\n")}
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeDryRun,
+		Status:             metav1.ConditionTrue,
+		Reason:             argoproj.ArgoCDConditionReasonDryRunCompleted,
+		Message:            fmt.Sprintf("%d changes recorded in ConfigMap %s, they are applied once the annotation %s is removed", len(recorder.changes), cm.Name, common.ArgoCDDryRunAnnotation),
+		ObservedGeneration: cr.Generation,
+	}
+	if dryRunErr != nil {
+		cm.Data[common.ArgoCDKeyDryRunError] = dryRunErr.Error()
+		condition.Reason = argoproj.ArgoCDConditionReasonDryRunFailed
+		condition.Message = fmt.Sprintf("dry run interrupted after %d changes recorded in ConfigMap %s: %v", len(recorder.changes), cm.Name, dryRunErr)
+	}
+
+	existing := &corev1.ConfigMap{}
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, cm.Name, existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+			return err
+		}
+		instanceLog(cr).Info(fmt.Sprintf("Creating ConfigMap %s with the changes of the dry run of ArgoCD %s", cm.Name, cr.Name))
+		if err := r.Client.Create(context.TODO(), cm); err != nil {
+			return err
+		}
+	} else {
+		if pending := existing.Data[common.ArgoCDKeyDryRunPendingNamespaces]; pending != "" {
+			cm.Data[common.ArgoCDKeyDryRunPendingNamespaces] = pending
+		}
+		if !reflect.DeepEqual(existing.Data, cm.Data) {
+			existing.Data = cm.Data
+			if err := r.Client.Update(context.TODO(), existing); err != nil {
+				return err
+			}
+		}
+	}
+
+	conditions := make([]metav1.Condition, len(cr.Status.Conditions))
+	copy(conditions, cr.Status.Conditions)
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	if !reflect.DeepEqual(conditions, cr.Status.Conditions) {
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+	return nil
+}
+
+// reconcileDryRunDisabled will clean up the namespaces the given ArgoCD stopped managing during its dry run, remove
+// its dry run ConfigMap, and report that the changes are applied again, once its dry run annotation has been removed.
+func (r *ReconcileArgoCD) reconcileDryRunDisabled(cr *argoproj.ArgoCD) error {
+	cm := newDryRunConfigMap(cr)
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, cm.Name, cm); err == nil {
+		if err := r.cleanupPendingNamespaces(cr, cm); err != nil {
+			return err
+		}
+		if err := r.Client.Delete(context.TODO(), cm); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	if !meta.IsStatusConditionTrue(cr.Status.Conditions, argoproj.ArgoCDConditionTypeDryRun) {
+		return nil // Not in a dry run, no change to report
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeDryRun,
+		Status:             metav1.ConditionFalse,
+		Reason:             argoproj.ArgoCDConditionReasonDryRunDisabled,
+		Message:            "The dry run annotation has been removed, changes are applied",
+		ObservedGeneration: cr.Generation,
+	})
+	return r.Client.Status().Update(context.TODO(), cr)
+}
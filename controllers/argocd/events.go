@@ -0,0 +1,254 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+const (
+	// resourceCreatedEventReason is the reason of the Events reporting a resource created by the operator.
+	resourceCreatedEventReason = "ResourceCreated"
+
+	// resourceUpdatedEventReason is the reason of the Events reporting a resource updated by the operator.
+	resourceUpdatedEventReason = "ResourceUpdated"
+
+	// resourceDeletedEventReason is the reason of the Events reporting a resource deleted by the operator.
+	resourceDeletedEventReason = "ResourceDeleted"
+)
+
+// eventClient is a client emitting a Normal Event on an ArgoCD for each resource it creates, updates or deletes, so
+// that the changes made by the operator to the resources of the instance can be audited. Dry runs, and the changes to
+// the ArgoCD itself and to Events, are not reported.
+type eventClient struct {
+	client.Client
+	recorder record.EventRecorder
+	cr       *argoproj.ArgoCD
+
+	// reads holds the objects last read or written through the client, from which the fields changed by an update
+	// are found.
+	reads map[string]client.Object
+}
+
+// newEventClient returns a client emitting the Events of the changes made through the given client on the given
+// ArgoCD with the given recorder. The given client is returned as is when there is no recorder.
+func newEventClient(c client.Client, recorder record.EventRecorder, cr *argoproj.ArgoCD) client.Client {
+	if recorder == nil {
+		return c
+	}
+	return &eventClient{Client: c, recorder: recorder, cr: cr, reads: map[string]client.Object{}}
+}
+
+// isReported returns true if the changes to the given object are reported on the ArgoCD.
+func (c *eventClient) isReported(obj client.Object) bool {
+	switch o := obj.(type) {
+	case *corev1.Event:
+		return false
+	case *argoproj.ArgoCD:
+		return o.Name != c.cr.Name || o.Namespace != c.cr.Namespace
+	}
+	return true
+}
+
+// describe returns the kind and the namespaced name of the given object.
+func (c *eventClient) describe(obj client.Object) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	if obj.GetNamespace() != "" {
+		return fmt.Sprintf("%s %s/%s", kind, obj.GetNamespace(), obj.GetName())
+	}
+	return fmt.Sprintf("%s %s", kind, obj.GetName())
+}
+
+// getChangedFields will return the fields changed between the given objects, down to the fields of the top level
+// sections, e.g. spec.template or data. The metadata is limited to the fields set by the operator, and the status is
+// ignored.
+func getChangedFields(before, after client.Object) []string {
+	beforeFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(before)
+	if err != nil {
+		return nil
+	}
+	afterFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(after)
+	if err != nil {
+		return nil
+	}
+
+	changed := []string{}
+	for _, section := range unionKeys(beforeFields, afterFields) {
+		switch section {
+		case "apiVersion", "kind", "status":
+			continue
+		}
+		beforeSection, beforeIsMap := beforeFields[section].(map[string]interface{})
+		afterSection, afterIsMap := afterFields[section].(map[string]interface{})
+		if !beforeIsMap && !afterIsMap {
+			if !reflect.DeepEqual(beforeFields[section], afterFields[section]) {
+				changed = append(changed, section)
+			}
+			continue
+		}
+		for _, field := range unionKeys(beforeSection, afterSection) {
+			if section == "metadata" && field != "labels" && field != "annotations" && field != "ownerReferences" && field != "finalizers" {
+				continue
+			}
+			if !reflect.DeepEqual(beforeSection[field], afterSection[field]) {
+				changed = append(changed, section+"."+field)
+			}
+		}
+	}
+	return changed
+}
+
+// unionKeys returns the sorted keys set in any of the given maps.
+func unionKeys(maps ...map[string]interface{}) []string {
+	set := map[string]bool{}
+	for _, m := range maps {
+		for key := range m {
+			set[key] = true
+		}
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// remember will keep a copy of the given object as its last known version.
+func (c *eventClient) remember(obj client.Object) {
+	if known, ok := obj.DeepCopyObject().(client.Object); ok {
+		c.reads[c.describe(obj)] = known
+	}
+}
+
+// reportUpdate will emit the Event reporting the update of the given object, written over the given resource version,
+// with the fields changed from its last known version. The API server keeps the resource version of an update
+// changing nothing, which is then not reported.
+func (c *eventClient) reportUpdate(resourceVersion string, obj client.Object) {
+	if obj.GetResourceVersion() == resourceVersion {
+		return // Nothing changed, move along...
+	}
+	message := fmt.Sprintf("Updated %s", c.describe(obj))
+	if previous, ok := c.reads[c.describe(obj)]; ok {
+		if changed := getChangedFields(previous, obj); len(changed) > 0 {
+			message = fmt.Sprintf("%s: %s changed", message, strings.Join(changed, ", "))
+		}
+	}
+	c.remember(obj)
+	c.recorder.Event(c.cr, corev1.EventTypeNormal, resourceUpdatedEventReason, message)
+}
+
+// recordEvent will emit an Event of the given type and reason on the given ArgoCD with the recorder of the reconciler.
+// No Event is emitted by a dry run, or without a recorder.
+func (r *ReconcileArgoCD) recordEvent(cr *argoproj.ArgoCD, eventType, reason, message string) {
+	if r.Recorder == nil || r.isDryRun() {
+		return
+	}
+	r.Recorder.Event(cr, eventType, reason, message)
+}
+
+func (c *eventClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := c.Client.Get(ctx, key, obj, opts...); err != nil {
+		return err
+	}
+	if c.isReported(obj) {
+		c.remember(obj)
+	}
+	return nil
+}
+
+func (c *eventClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	createOpts := &client.CreateOptions{}
+	createOpts.ApplyOptions(opts)
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	if len(createOpts.DryRun) == 0 && c.isReported(obj) {
+		c.remember(obj)
+		c.recorder.Event(c.cr, corev1.EventTypeNormal, resourceCreatedEventReason, fmt.Sprintf("Created %s", c.describe(obj)))
+	}
+	return nil
+}
+
+func (c *eventClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	updateOpts := &client.UpdateOptions{}
+	updateOpts.ApplyOptions(opts)
+	resourceVersion := obj.GetResourceVersion()
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	if len(updateOpts.DryRun) == 0 && c.isReported(obj) {
+		c.reportUpdate(resourceVersion, obj)
+	}
+	return nil
+}
+
+func (c *eventClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	patchOpts := &client.PatchOptions{}
+	patchOpts.ApplyOptions(opts)
+	resourceVersion := obj.GetResourceVersion()
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	if len(patchOpts.DryRun) == 0 && c.isReported(obj) {
+		c.reportUpdate(resourceVersion, obj)
+	}
+	return nil
+}
+
+func (c *eventClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	deleteOpts := &client.DeleteOptions{}
+	deleteOpts.ApplyOptions(opts)
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	if len(deleteOpts.DryRun) == 0 && c.isReported(obj) {
+		delete(c.reads, c.describe(obj))
+		c.recorder.Event(c.cr, corev1.EventTypeNormal, resourceDeletedEventReason, fmt.Sprintf("Deleted %s", c.describe(obj)))
+	}
+	return nil
+}
+
+func (c *eventClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	deleteOpts := &client.DeleteAllOfOptions{}
+	deleteOpts.ApplyOptions(opts)
+	if err := c.Client.DeleteAllOf(ctx, obj, opts...); err != nil {
+		return err
+	}
+	if len(deleteOpts.DryRun) == 0 && c.isReported(obj) {
+		kind := strings.SplitN(c.describe(obj), " ", 2)[0]
+		message := fmt.Sprintf("Deleted all %s", kind)
+		if deleteOpts.Namespace != "" {
+			message = fmt.Sprintf("%s in namespace %s", message, deleteOpts.Namespace)
+		}
+		c.recorder.Event(c.cr, corev1.EventTypeNormal, resourceDeletedEventReason, message)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestEventClient(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	recorder := record.NewFakeRecorder(10)
+	cl := newEventClient(makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs), recorder, a)
+
+	cm := newConfigMapWithName("argocd-example", a)
+	cm.Data = map[string]string{"key": "value"}
+	assert.NoError(t, cl.Create(context.TODO(), cm))
+	assert.Equal(t, "Normal ResourceCreated Created ConfigMap argocd/argocd-example", <-recorder.Events)
+
+	// The fields changed are the ones changed since the object was last read
+	cm.Data["key"] = "changed"
+	assert.NoError(t, cl.Update(context.TODO(), cm))
+	assert.Equal(t, "Normal ResourceUpdated Updated ConfigMap argocd/argocd-example: data.key changed", <-recorder.Events)
+
+	read := &corev1.ConfigMap{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(cm), read))
+	read.Labels["team"] = "platform"
+	assert.NoError(t, cl.Update(context.TODO(), read))
+	assert.Equal(t, "Normal ResourceUpdated Updated ConfigMap argocd/argocd-example: metadata.labels changed", <-recorder.Events)
+
+	// Dry runs and the changes to the ArgoCD itself are not reported
+	assert.NoError(t, cl.Delete(context.TODO(), cm, client.DryRunAll))
+	a.Spec.Version = "v2.10.0"
+	assert.NoError(t, cl.Update(context.TODO(), a))
+	assert.Empty(t, recorder.Events)
+
+	assert.NoError(t, cl.Delete(context.TODO(), cm))
+	assert.Equal(t, "Normal ResourceDeleted Deleted ConfigMap argocd/argocd-example", <-recorder.Events)
+
+	// An update changing nothing keeps the resource version and is not reported
+	unchanged := newEventClient(noopUpdateClient{makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)}, recorder, a)
+	assert.NoError(t, unchanged.Update(context.TODO(), cm))
+	assert.Empty(t, recorder.Events)
+
+	// Without a recorder, the client is used as is
+	plain := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	assert.Equal(t, plain, newEventClient(plain, nil, a))
+}
+
+// noopUpdateClient is a client whose updates change nothing, as done by the API server for an update of an object
+// with its current content.
+type noopUpdateClient struct {
+	client.Client
+}
+
+func (c noopUpdateClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// applicationDeletionRequeueInterval is the interval at which the deletion of the Applications is checked.
+const applicationDeletionRequeueInterval = 10 * time.Second
+
+// finalizeArgoCD will clean up after the given ArgoCD marked for deletion. The Applications of the instance are deleted
+// first when requested, while the Application Controller still has the permissions to process their finalizers, then
+// the cluster scoped resources, and the deletion finalizer is removed last. The progress is reported through the
+// Deleting condition, and the request is requeued while Applications remain.
+func (r *ReconcileArgoCD) finalizeArgoCD(cr *argoproj.ArgoCD) (reconcile.Result, error) {
+	if cr.Spec.DeleteManagedApplications {
+		remaining, err := r.deleteManagedApplications(cr)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to delete Applications: %w", err)
+		}
+		if remaining > 0 {
+			msg := fmt.Sprintf("Waiting for %d Applications to be deleted", remaining)
+			if err := r.setDeletingCondition(cr, argoproj.ArgoCDConditionReasonDeletingApplications, msg); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{RequeueAfter: applicationDeletionRequeueInterval}, nil
+		}
+	}
+
+	if err := r.setDeletingCondition(cr, argoproj.ArgoCDConditionReasonDeletingClusterResources, "Deleting the cluster scoped resources"); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.deleteClusterResources(cr); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to delete ClusterResources: %w", err)
+	}
+
+	if err := r.setDeletingCondition(cr, argoproj.ArgoCDConditionReasonRemovingFinalizer, "Cleaning up the managed namespaces"); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if isRemoveManagedByLabelOnArgoCDDeletion() {
+		if err := r.removeManagedByLabelFromNamespaces(cr.Namespace); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to remove label from namespace[%v], error: %w", cr.Namespace, err)
+		}
+	}
+
+	if err := r.removeUnmanagedSourceNamespaceResources(cr); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to remove resources from sourceNamespaces, error: %w", err)
+	}
+
+	if err := r.removeUnmanagedApplicationSetSourceNamespaceResources(cr); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to remove resources from applicationSetSourceNamespaces, error: %w", err)
+	}
+
+	if err := r.removeDeletionFinalizer(cr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// remove the deleted Argo CD instance from deprecationEventEmissionTracker (if exists) so that if another instance
+	// is created with the same name in the future, that instance is appropriately tracked
+	delete(DeprecationEventEmissionTracker, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace})
+	forgetInstanceLog(types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace})
+
+	return reconcile.Result{}, nil
+}
+
+// setDeletingCondition will update the Deleting condition of the given ArgoCD, if changed.
+func (r *ReconcileArgoCD) setDeletingCondition(cr *argoproj.ArgoCD, reason string, message string) error {
+	existing := meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionTypeDeleting)
+	if existing != nil && existing.Reason == reason && existing.Message == message {
+		return nil // Nothing changed, move along...
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeDeleting,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cr.Generation,
+	})
+	return r.Client.Status().Update(context.TODO(), cr)
+}
+
+// getApplicationNamespaces will return the namespaces holding the Applications of the given ArgoCD, that is the
+// namespace of the instance and the source namespaces it manages.
+func (r *ReconcileArgoCD) getApplicationNamespaces(cr *argoproj.ArgoCD) ([]string, error) {
+	namespaces := &corev1.NamespaceList{}
+	listOption := client.MatchingLabels{
+		common.ArgoCDManagedByClusterArgoCDLabel: cr.Namespace,
+	}
+	if err := r.Client.List(context.TODO(), namespaces, listOption); err != nil {
+		return nil, err
+	}
+
+	result := []string{cr.Namespace}
+	for _, ns := range namespaces.Items {
+		if ns.Name != cr.Namespace {
+			result = append(result, ns.Name)
+		}
+	}
+	return result, nil
+}
+
+// deleteManagedApplications will request the deletion of the Applications of the given ArgoCD, and return the number
+// of Applications still present. Applications with finalizers are only gone once the Application Controller has
+// processed them, which is why the instance must not be removed before.
+func (r *ReconcileArgoCD) deleteManagedApplications(cr *argoproj.ArgoCD) (int, error) {
+	namespaces, err := r.getApplicationNamespaces(cr)
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := 0
+	for _, ns := range namespaces {
+		apps := &unstructured.UnstructuredList{}
+		apps.SetGroupVersionKind(argoCDApplicationGVK.GroupVersion().WithKind(argoCDApplicationGVK.Kind + "List"))
+		if err := r.Client.List(context.TODO(), apps, client.InNamespace(ns)); err != nil {
+			if meta.IsNoMatchError(err) {
+				return 0, nil // Application CRD not installed, nothing to delete
+			}
+			return remaining, err
+		}
+
+		for i := range apps.Items {
+			app := &apps.Items[i]
+			remaining++
+			if app.GetDeletionTimestamp() != nil {
+				continue // Deletion in progress
+			}
+			instanceLog(cr).Info(fmt.Sprintf("deleting Application %s in namespace %s", app.GetName(), app.GetNamespace()))
+			if err := r.Client.Delete(context.TODO(), app); client.IgnoreNotFound(err) != nil {
+				return remaining, err
+			}
+		}
+	}
+	return remaining, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileArgoCD_finalizeArgoCD(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(deletedAt(time.Now()), addFinalizer(common.ArgoCDDeletionFinalizer), func(a *argoproj.ArgoCD) {
+		a.Spec.DeleteManagedApplications = true
+	})
+
+	clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{
+		Name:   "argocd-argocd-argocd-application-controller",
+		Labels: map[string]string{common.ArgoCDKeyManagedBy: a.Name},
+	}}
+	sourceNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "team-a",
+		Labels: map[string]string{common.ArgoCDManagedByClusterArgoCDLabel: a.Namespace},
+	}}
+	newApplication := func(name, namespace string, finalizers ...string) *unstructured.Unstructured {
+		app := &unstructured.Unstructured{}
+		app.SetGroupVersionKind(argoCDApplicationGVK)
+		app.SetName(name)
+		app.SetNamespace(namespace)
+		app.SetFinalizers(finalizers)
+		return app
+	}
+
+	resObjs := []client.Object{a, clusterRole, sourceNamespace}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.Client.Create(context.TODO(), newApplication("guestbook", a.Namespace)))
+	assert.NoError(t, r.Client.Create(context.TODO(), newApplication("team-a-app", "team-a", "resources-finalizer.argocd.argoproj.io")))
+
+	// The instance waits for the Applications, and keeps the cluster scoped resources the Application Controller needs
+	result, err := r.finalizeArgoCD(a)
+	assert.NoError(t, err)
+	assert.Equal(t, applicationDeletionRequeueInterval, result.RequeueAfter)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRole.Name}, clusterRole))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "guestbook", Namespace: a.Namespace}, newApplication("", ""))
+	assert.True(t, errors.IsNotFound(err))
+
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeDeleting)
+	assert.NotNil(t, condition)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonDeletingApplications, condition.Reason)
+	assert.True(t, a.IsDeletionFinalizerPresent())
+
+	// The Application Controller processed the remaining Application
+	app := newApplication("", "")
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "team-a-app", Namespace: "team-a"}, app))
+	app.SetFinalizers(nil)
+	assert.NoError(t, r.Client.Update(context.TODO(), app))
+
+	result, err = r.finalizeArgoCD(a)
+	assert.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+	assert.False(t, a.IsDeletionFinalizerPresent())
+
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRole.Name}, clusterRole)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileArgoCD_finalizeArgoCD_keepApplications(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(deletedAt(time.Now()), addFinalizer(common.ArgoCDDeletionFinalizer))
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	app := &unstructured.Unstructured{}
+	app.SetGroupVersionKind(argoCDApplicationGVK)
+	app.SetName("guestbook")
+	app.SetNamespace(a.Namespace)
+	assert.NoError(t, r.Client.Create(context.TODO(), app))
+
+	result, err := r.finalizeArgoCD(a)
+	assert.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+	assert.False(t, a.IsDeletionFinalizerPresent())
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: app.GetName(), Namespace: a.Namespace}, app))
+}
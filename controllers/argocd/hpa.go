@@ -72,9 +72,12 @@ func (r *ReconcileArgoCD) reconcileServerHPA(cr *argoproj.ArgoCD) error {
 		},
 	}
 
+	// The HorizontalPodAutoscaler is replaced by a KEDA ScaledObject when KEDA options are set, or by the operator
+	enabled := cr.Spec.Server.Autoscale.Enabled && !isServerKEDAEnabled(cr) && !isServerOperatorAutoscaleEnabled(cr) && isServerEnabled(cr)
+
 	existingHPA := newHorizontalPodAutoscalerWithSuffix("server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existingHPA.Name, existingHPA) {
-		if !cr.Spec.Server.Autoscale.Enabled {
+		if !enabled {
 			return r.Client.Delete(context.TODO(), existingHPA) // HorizontalPodAutoscaler found but globally disabled, delete it.
 		}
 
@@ -95,7 +98,7 @@ func (r *ReconcileArgoCD) reconcileServerHPA(cr *argoproj.ArgoCD) error {
 		return nil
 	}
 
-	if !cr.Spec.Server.Autoscale.Enabled {
+	if !enabled {
 		return nil // AutoScale not enabled, move along...
 	}
 
@@ -107,10 +110,17 @@ func (r *ReconcileArgoCD) reconcileServerHPA(cr *argoproj.ArgoCD) error {
 	return r.Client.Create(context.TODO(), defaultHPA)
 }
 
-// reconcileAutoscalers will ensure that all HorizontalPodAutoscalers are present for the given ArgoCD.
+// reconcileAutoscalers will ensure that all HorizontalPodAutoscalers and KEDA ScaledObjects are present for the given
+// ArgoCD, and scale the components autoscaled by the operator.
 func (r *ReconcileArgoCD) reconcileAutoscalers(cr *argoproj.ArgoCD) error {
 	if err := r.reconcileServerHPA(cr); err != nil {
 		return err
 	}
+	if err := r.reconcileScaledObjects(cr); err != nil {
+		return err
+	}
+	if err := r.reconcileServerOperatorAutoscaler(cr); err != nil {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,129 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+// defaultImageArchitectures are the architectures supported by all the default images of the Argo CD components.
+var defaultImageArchitectures = map[string]bool{
+	"amd64":   true,
+	"arm64":   true,
+	"ppc64le": true,
+	"s390x":   true,
+}
+
+// imageOverrideComponent is a component whose image can be overridden per architecture.
+type imageOverrideComponent struct {
+	name     string
+	override func(o argoproj.ArgoCDImageOverridesSpec) string
+	// explicit returns true if the image of the component is set explicitly in the spec of the given ArgoCD.
+	explicit func(cr *argoproj.ArgoCD) bool
+}
+
+var (
+	argoImageComponent = imageOverrideComponent{
+		name:     "argo",
+		override: func(o argoproj.ArgoCDImageOverridesSpec) string { return o.Argo },
+		explicit: func(cr *argoproj.ArgoCD) bool { return cr.Spec.Image != "" },
+	}
+	dexImageComponent = imageOverrideComponent{
+		name:     "dex",
+		override: func(o argoproj.ArgoCDImageOverridesSpec) string { return o.Dex },
+		explicit: func(cr *argoproj.ArgoCD) bool {
+			return cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.Image != ""
+		},
+	}
+	redisImageComponent = imageOverrideComponent{
+		name:     "redis",
+		override: func(o argoproj.ArgoCDImageOverridesSpec) string { return o.Redis },
+		explicit: func(cr *argoproj.ArgoCD) bool { return cr.Spec.Redis.Image != "" },
+	}
+	redisHAProxyImageComponent = imageOverrideComponent{
+		name:     "redisHAProxy",
+		override: func(o argoproj.ArgoCDImageOverridesSpec) string { return o.RedisHAProxy },
+		explicit: func(cr *argoproj.ArgoCD) bool { return cr.Spec.HA.RedisProxyImage != "" },
+	}
+)
+
+// imageOverrideComponents are the components whose image can be overridden per architecture.
+var imageOverrideComponents = []imageOverrideComponent{
+	argoImageComponent,
+	dexImageComponent,
+	redisImageComponent,
+	redisHAProxyImageComponent,
+}
+
+// getImageArchitecture will return the architecture the workloads of the given ArgoCD are pinned to through the
+// node selector of its node placement, if any.
+func getImageArchitecture(cr *argoproj.ArgoCD) string {
+	if cr.Spec.NodePlacement == nil {
+		return ""
+	}
+	return cr.Spec.NodePlacement.NodeSelector[corev1.LabelArchStable]
+}
+
+// getImageOverride will return the FIPS-validated image of the given component when the fips crypto policy of the
+// given ArgoCD is set, or its image for the architecture the workloads are pinned to, if any. Images set explicitly
+// for the component in the spec win over the overrides.
+func getImageOverride(cr *argoproj.ArgoCD, component imageOverrideComponent) string {
+	if component.explicit(cr) {
+		return ""
+	}
+	if isFIPSEnabled(cr) {
+		if img := getFIPSImage(cr, component); img != "" {
+			return img
+		}
+	}
+	arch := getImageArchitecture(cr)
+	if arch == "" {
+		return ""
+	}
+	return component.override(cr.Spec.ImageOverrides[arch])
+}
+
+// validateImageArchitecture will return an error if the images of the given ArgoCD cannot be selected for the nodes
+// its workloads run on. Overrides only apply to workloads pinned to an architecture, and components using their
+// default image must run on an architecture supported by all the default images. Images set explicitly in the
+// spec are trusted to support the architecture of the nodes.
+func validateImageArchitecture(cr *argoproj.ArgoCD) error {
+	arch := getImageArchitecture(cr)
+	if arch == "" {
+		if len(cr.Spec.ImageOverrides) > 0 {
+			return fmt.Errorf("imageOverrides require the workloads to be pinned to an architecture with the %s node selector of nodePlacement", corev1.LabelArchStable)
+		}
+		return nil
+	}
+	if defaultImageArchitectures[arch] {
+		return nil
+	}
+
+	var missing []string
+	for _, component := range imageOverrideComponents {
+		if !component.explicit(cr) && component.override(cr.Spec.ImageOverrides[arch]) == "" {
+			missing = append(missing, component.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the default images do not support architecture %s, set the images of %s in imageOverrides.%s", arch, strings.Join(missing, ", "), arch)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func pinTestArchitecture(arch string) argoCDOpt {
+	return func(a *argoproj.ArgoCD) {
+		a.Spec.NodePlacement = &argoproj.ArgoCDNodePlacementSpec{
+			NodeSelector: map[string]string{corev1.LabelArchStable: arch},
+		}
+	}
+}
+
+func TestGetImageOverride(t *testing.T) {
+	overrides := func(a *argoproj.ArgoCD) {
+		a.Spec.ImageOverrides = map[string]argoproj.ArgoCDImageOverridesSpec{
+			"arm64": {
+				Argo:  "registry.example.com/argocd@sha256:arm64",
+				Redis: "registry.example.com/redis@sha256:arm64",
+			},
+		}
+	}
+
+	// Overrides apply to the architecture the workloads are pinned to
+	a := makeTestArgoCD(overrides, pinTestArchitecture("arm64"))
+	assert.Equal(t, "registry.example.com/argocd@sha256:arm64", getArgoContainerImage(a))
+	assert.Equal(t, "registry.example.com/argocd@sha256:arm64", getRepoServerContainerImage(a))
+	assert.Equal(t, "registry.example.com/argocd@sha256:arm64", getApplicationSetContainerImage(a))
+	assert.Equal(t, "registry.example.com/redis@sha256:arm64", getRedisContainerImage(a))
+	assert.Equal(t, "registry.example.com/redis@sha256:arm64", getRedisHAContainerImage(a))
+	assert.NotContains(t, getRedisHAProxyContainerImage(a), "registry.example.com")
+	assert.NotContains(t, getDexContainerImage(a), "registry.example.com")
+
+	// Images set explicitly for a component win over the overrides
+	a.Spec.Repo.Image = "registry.example.com/repo"
+	a.Spec.Repo.Version = "v1"
+	assert.Equal(t, "registry.example.com/repo:v1", getRepoServerContainerImage(a))
+	assert.Equal(t, "registry.example.com/argocd@sha256:arm64", getArgoContainerImage(a))
+
+	// Overrides of other architectures are ignored
+	a = makeTestArgoCD(overrides, pinTestArchitecture("amd64"))
+	assert.NotContains(t, getArgoContainerImage(a), "registry.example.com")
+
+	a = makeTestArgoCD(overrides)
+	assert.NotContains(t, getArgoContainerImage(a), "registry.example.com")
+}
+
+func TestValidateImageArchitecture(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []argoCDOpt
+		wantErr string
+	}{
+		{
+			name: "no architecture",
+		},
+		{
+			name: "architecture supported by the default images",
+			opts: []argoCDOpt{pinTestArchitecture("arm64")},
+		},
+		{
+			name: "overrides without architecture",
+			opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+				a.Spec.ImageOverrides = map[string]argoproj.ArgoCDImageOverridesSpec{"arm64": {Argo: "argocd"}}
+			}},
+			wantErr: "pinned to an architecture",
+		},
+		{
+			name:    "architecture unsupported by the default images",
+			opts:    []argoCDOpt{pinTestArchitecture("riscv64")},
+			wantErr: "set the images of argo, dex, redis, redisHAProxy in imageOverrides.riscv64",
+		},
+		{
+			name: "architecture unsupported by some of the default images",
+			opts: []argoCDOpt{pinTestArchitecture("riscv64"), func(a *argoproj.ArgoCD) {
+				a.Spec.Image = "registry.example.com/argocd"
+				a.Spec.ImageOverrides = map[string]argoproj.ArgoCDImageOverridesSpec{
+					"riscv64": {Dex: "dex", Redis: "redis"},
+				}
+			}},
+			wantErr: "set the images of redisHAProxy in imageOverrides.riscv64",
+		},
+		{
+			name: "all images overridden",
+			opts: []argoCDOpt{pinTestArchitecture("riscv64"), func(a *argoproj.ArgoCD) {
+				a.Spec.ImageOverrides = map[string]argoproj.ArgoCDImageOverridesSpec{
+					"riscv64": {Argo: "argocd", Dex: "dex", Redis: "redis", RedisHAProxy: "haproxy"},
+				}
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateImageArchitecture(makeTestArgoCD(test.opts...))
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,406 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// imageUpdaterConfigMapName is the name of the ConfigMap holding the registries configuration of the Image Updater.
+	imageUpdaterConfigMapName = "argocd-image-updater-config"
+
+	// imageUpdaterSecretName is the name of the Secret holding the Argo CD API token of the Image Updater.
+	imageUpdaterSecretName = "argocd-image-updater-secret"
+
+	// imageUpdaterAccountName is the name of the local Argo CD account used by the Image Updater with the argocd API.
+	imageUpdaterAccountName = "image-updater"
+)
+
+// getImageUpdaterContainerImage will return the container image for the Image Updater.
+func getImageUpdaterContainerImage(cr *argoproj.ArgoCD) string {
+	defaultImg, defaultTag := false, false
+
+	img := cr.Spec.ImageUpdater.Image
+	if img == "" {
+		img = common.ArgoCDDefaultImageUpdaterImage
+		defaultImg = true
+	}
+
+	tag := cr.Spec.ImageUpdater.Version
+	if tag == "" {
+		tag = common.ArgoCDDefaultImageUpdaterVersion
+		defaultTag = true
+	}
+
+	if e := os.Getenv(common.ArgoCDImageUpdaterImageEnvName); e != "" && (defaultTag && defaultImg) {
+		return e
+	}
+	return argoutil.CombineImageTag(img, tag)
+}
+
+// getImageUpdaterApplicationsAPI will return the API used by the Image Updater to access the Applications.
+func getImageUpdaterApplicationsAPI(cr *argoproj.ArgoCD) argoproj.ArgoCDImageUpdaterApplicationsAPI {
+	if cr.Spec.ImageUpdater.Config.ApplicationsAPI == "" {
+		return argoproj.ArgoCDImageUpdaterApplicationsAPIKubernetes
+	}
+	return cr.Spec.ImageUpdater.Config.ApplicationsAPI
+}
+
+// usesImageUpdaterAccount returns true if the Image Updater accesses the Applications through the Argo CD API
+// server, with a local Argo CD account.
+func usesImageUpdaterAccount(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.ImageUpdater.Enabled && getImageUpdaterApplicationsAPI(cr) == argoproj.ArgoCDImageUpdaterApplicationsAPIArgoCD
+}
+
+// getImageUpdaterCommand will return the command for the Image Updater, pointing it at the Argo CD API server of the
+// given ArgoCD instance.
+func getImageUpdaterCommand(cr *argoproj.ArgoCD) []string {
+	cfg := cr.Spec.ImageUpdater.Config
+
+	interval := common.ArgoCDDefaultImageUpdaterInterval
+	if cfg.Interval != nil {
+		interval = cfg.Interval.Duration.String()
+	}
+
+	cmd := []string{
+		"/usr/local/bin/argocd-image-updater",
+		"run",
+		"--loglevel", getLogLevel(cr.Spec.ImageUpdater.LogLevel),
+		"--interval", interval,
+		"--applications-api", string(getImageUpdaterApplicationsAPI(cr)),
+		"--registries-conf-path", "/app/config/registries.conf",
+		"--argocd-server-addr", fqdnServiceRef("server", 443, cr),
+	}
+
+	// The API server uses a self-signed certificate by default, and serves plain text when running insecure.
+	if cr.Spec.Server.Insecure {
+		cmd = append(cmd, "--argocd-plaintext")
+	} else {
+		cmd = append(cmd, "--argocd-insecure")
+	}
+
+	if cfg.GitCommitUser != "" {
+		cmd = append(cmd, "--git-commit-user", cfg.GitCommitUser)
+	}
+	if cfg.GitCommitEmail != "" {
+		cmd = append(cmd, "--git-commit-email", cfg.GitCommitEmail)
+	}
+
+	return cmd
+}
+
+// getImageUpdaterEnv will return the environment of the Image Updater container.
+func getImageUpdaterEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
+	env := []corev1.EnvVar{
+		{
+			Name: "ARGOCD_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: imageUpdaterSecretName},
+					Key:                  "argocd.token",
+					Optional:             boolPtr(true),
+				},
+			},
+		},
+	}
+
+	// Let user specify their own environment first
+	env = argoutil.EnvMerge(cr.Spec.ImageUpdater.Env, env, false)
+	return argoutil.EnvMerge(env, proxyEnvVars(), false)
+}
+
+// getImageUpdaterResources will return the ResourceRequirements for the Image Updater container.
+func getImageUpdaterResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
+	resources := corev1.ResourceRequirements{}
+
+	// Allow override of resource requirements from CR
+	if cr.Spec.ImageUpdater.Resources != nil {
+		resources = *cr.Spec.ImageUpdater.Resources
+	}
+
+	return resources
+}
+
+// reconcileImageUpdater will ensure that the Argo CD Image Updater resources are present for the given ArgoCD.
+// The resources of a disabled Image Updater are removed with the other disabled components.
+func (r *ReconcileArgoCD) reconcileImageUpdater(cr *argoproj.ArgoCD) error {
+	instanceLog(cr).Info("reconciling image updater serviceaccount")
+	sa, err := r.reconcileImageUpdaterServiceAccount(cr)
+	if err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info("reconciling image updater role")
+	if err := r.reconcileImageUpdaterRole(cr); err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info("reconciling image updater role binding")
+	if err := r.reconcileImageUpdaterRoleBinding(cr, sa); err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info("reconciling image updater configmap")
+	if err := r.reconcileImageUpdaterConfigMap(cr); err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info("reconciling image updater secret")
+	if err := r.reconcileImageUpdaterSecret(cr); err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info("reconciling image updater deployment")
+	return r.reconcileImageUpdaterDeployment(cr, sa)
+}
+
+// reconcileImageUpdaterServiceAccount will ensure that the ServiceAccount of the Image Updater is present.
+func (r *ReconcileArgoCD) reconcileImageUpdaterServiceAccount(cr *argoproj.ArgoCD) (*corev1.ServiceAccount, error) {
+	sa := newServiceAccountWithName(common.ArgoCDImageUpdaterComponent, cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, sa.Name, sa) {
+		return sa, nil // ServiceAccount found, move along...
+	}
+
+	if err := controllerutil.SetControllerReference(cr, sa, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	instanceLog(cr).Info(fmt.Sprintf("Creating serviceaccount %s", sa.Name))
+	return sa, r.Client.Create(context.TODO(), sa)
+}
+
+// reconcileImageUpdaterRole will ensure that the Role of the Image Updater is present and up to date.
+func (r *ReconcileArgoCD) reconcileImageUpdaterRole(cr *argoproj.ArgoCD) error {
+	desired := newRole(common.ArgoCDImageUpdaterComponent, policyRuleForImageUpdater(), cr)
+
+	existing := &rbacv1.Role{}
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, desired.Name, existing) {
+		if reflect.DeepEqual(existing.Rules, desired.Rules) {
+			return nil
+		}
+		existing.Rules = desired.Rules
+		instanceLog(cr).Info(fmt.Sprintf("Updating role %s", existing.Name))
+		return r.Client.Update(context.TODO(), existing)
+	}
+
+	if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info(fmt.Sprintf("Creating role %s", desired.Name))
+	return r.Client.Create(context.TODO(), desired)
+}
+
+// reconcileImageUpdaterRoleBinding will ensure that the RoleBinding of the Image Updater is present.
+func (r *ReconcileArgoCD) reconcileImageUpdaterRoleBinding(cr *argoproj.ArgoCD, sa *corev1.ServiceAccount) error {
+	desired := newRoleBindingWithname(common.ArgoCDImageUpdaterComponent, cr)
+	desired.RoleRef = rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "Role",
+		Name:     generateResourceName(common.ArgoCDImageUpdaterComponent, cr),
+	}
+	desired.Subjects = []rbacv1.Subject{
+		{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      sa.Name,
+			Namespace: sa.Namespace,
+		},
+	}
+
+	existing := &rbacv1.RoleBinding{}
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, desired.Name, existing) {
+		if reflect.DeepEqual(existing.Subjects, desired.Subjects) {
+			return nil
+		}
+		existing.Subjects = desired.Subjects
+		instanceLog(cr).Info(fmt.Sprintf("Updating roleBinding %s", existing.Name))
+		return r.Client.Update(context.TODO(), existing)
+	}
+
+	if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info(fmt.Sprintf("Creating roleBinding %s", desired.Name))
+	return r.Client.Create(context.TODO(), desired)
+}
+
+// reconcileImageUpdaterConfigMap will ensure that the ConfigMap holding the registries configuration of the Image
+// Updater is present and up to date.
+func (r *ReconcileArgoCD) reconcileImageUpdaterConfigMap(cr *argoproj.ArgoCD) error {
+	cm := newConfigMapWithName(imageUpdaterConfigMapName, cr)
+	cm.Data = map[string]string{
+		"registries.conf": cr.Spec.ImageUpdater.Config.Registries,
+	}
+
+	existing := &corev1.ConfigMap{}
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, existing) {
+		if reflect.DeepEqual(existing.Data, cm.Data) {
+			return nil
+		}
+		existing.Data = cm.Data
+		instanceLog(cr).Info(fmt.Sprintf("Updating configmap %s", existing.Name))
+		return r.Client.Update(context.TODO(), existing)
+	}
+
+	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info(fmt.Sprintf("Creating configmap %s", cm.Name))
+	return r.Client.Create(context.TODO(), cm)
+}
+
+// reconcileImageUpdaterSecret only creates the Secret holding the Argo CD API token of the Image Updater. The token
+// is generated by the user for the image-updater account, so the content of the Secret is never overwritten.
+func (r *ReconcileArgoCD) reconcileImageUpdaterSecret(cr *argoproj.ArgoCD) error {
+	secret := argoutil.NewSecretWithName(cr, imageUpdaterSecretName)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		return nil // Secret found, move along...
+	}
+
+	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info(fmt.Sprintf("Creating secret %s", secret.Name))
+	return r.Client.Create(context.TODO(), secret)
+}
+
+// reconcileImageUpdaterDeployment will ensure that the Deployment of the Image Updater is present and up to date.
+func (r *ReconcileArgoCD) reconcileImageUpdaterDeployment(cr *argoproj.ArgoCD, sa *corev1.ServiceAccount) error {
+	deploy := newDeploymentWithSuffix("image-updater", "image-updater", cr)
+	deploy.Spec.Strategy = appsv1.DeploymentStrategy{
+		Type: appsv1.RecreateDeploymentStrategyType,
+	}
+
+	podSpec := &deploy.Spec.Template.Spec
+	podSpec.ServiceAccountName = sa.Name
+	podSpec.SecurityContext = &corev1.PodSecurityContext{
+		RunAsNonRoot: boolPtr(true),
+	}
+	AddSeccompProfileForOpenShift(r.Client, podSpec)
+	podSpec.Volumes = []corev1.Volume{
+		{
+			Name: "image-updater-conf",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: imageUpdaterConfigMapName,
+					},
+				},
+			},
+		},
+		{
+			Name: "tmp",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	podSpec.Containers = []corev1.Container{{
+		Command:         getImageUpdaterCommand(cr),
+		Image:           getImageUpdaterContainerImage(cr),
+		ImagePullPolicy: corev1.PullAlways,
+		Name:            common.ArgoCDImageUpdaterComponent,
+		Env:             getImageUpdaterEnv(cr),
+		Resources:       getImageUpdaterResources(cr),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolPtr(false),
+			ReadOnlyRootFilesystem:   boolPtr(true),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{
+					"ALL",
+				},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "image-updater-conf",
+				MountPath: "/app/config",
+			},
+			{
+				Name:      "tmp",
+				MountPath: "/tmp",
+			},
+		},
+	}}
+
+	existing := newDeploymentWithSuffix("image-updater", "image-updater", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		changed := false
+		existingSpec := &existing.Spec.Template.Spec
+
+		if existingSpec.Containers[0].Image != podSpec.Containers[0].Image {
+			existingSpec.Containers[0].Image = podSpec.Containers[0].Image
+			if existing.Spec.Template.ObjectMeta.Labels == nil {
+				existing.Spec.Template.ObjectMeta.Labels = map[string]string{}
+			}
+			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
+			changed = true
+		}
+		if !reflect.DeepEqual(existingSpec.Containers[0].Command, podSpec.Containers[0].Command) {
+			existingSpec.Containers[0].Command = podSpec.Containers[0].Command
+			changed = true
+		}
+		if !reflect.DeepEqual(existingSpec.Containers[0].Env, podSpec.Containers[0].Env) {
+			existingSpec.Containers[0].Env = podSpec.Containers[0].Env
+			changed = true
+		}
+		if !reflect.DeepEqual(existingSpec.Containers[0].Resources, podSpec.Containers[0].Resources) {
+			existingSpec.Containers[0].Resources = podSpec.Containers[0].Resources
+			changed = true
+		}
+		if !reflect.DeepEqual(existingSpec.Containers[0].VolumeMounts, podSpec.Containers[0].VolumeMounts) {
+			existingSpec.Containers[0].VolumeMounts = podSpec.Containers[0].VolumeMounts
+			changed = true
+		}
+		if !reflect.DeepEqual(existingSpec.Volumes, podSpec.Volumes) {
+			existingSpec.Volumes = podSpec.Volumes
+			changed = true
+		}
+		if existingSpec.ServiceAccountName != podSpec.ServiceAccountName {
+			existingSpec.ServiceAccountName = podSpec.ServiceAccountName
+			changed = true
+		}
+		updateNodePlacement(existing, deploy, &changed)
+
+		if changed {
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // Deployment found with nothing to do, move along...
+	}
+
+	if err := controllerutil.SetControllerReference(cr, deploy, r.Scheme); err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info(fmt.Sprintf("Creating deployment %s", deploy.Name))
+	return r.Client.Create(context.TODO(), deploy)
+}
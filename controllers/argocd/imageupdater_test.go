@@ -0,0 +1,136 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileArgoCD_reconcileImageUpdater(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ImageUpdater = argoproj.ArgoCDImageUpdaterSpec{
+			Enabled: true,
+			Config: argoproj.ArgoCDImageUpdaterConfigSpec{
+				Registries:    "registries: []\n",
+				GitCommitUser: "argocd-image-updater",
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileImageUpdater(a))
+
+	sa := &corev1.ServiceAccount{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-image-updater", Namespace: a.Namespace}, sa))
+	role := &rbacv1.Role{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-image-updater", Namespace: a.Namespace}, role))
+	assert.Equal(t, policyRuleForImageUpdater(), role.Rules)
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: imageUpdaterSecretName, Namespace: a.Namespace}, secret))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: imageUpdaterConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, "registries: []\n", cm.Data["registries.conf"])
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-image-updater", Namespace: a.Namespace}, deployment))
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "quay.io/argoprojlabs/argocd-image-updater:v0.12.2", container.Image)
+	assert.Equal(t, sa.Name, deployment.Spec.Template.Spec.ServiceAccountName)
+	assert.Equal(t, []string{
+		"/usr/local/bin/argocd-image-updater",
+		"run",
+		"--loglevel", "info",
+		"--interval", "2m",
+		"--applications-api", "kubernetes",
+		"--registries-conf-path", "/app/config/registries.conf",
+		"--argocd-server-addr", "argocd-server.argocd.svc.cluster.local:443",
+		"--argocd-insecure",
+		"--git-commit-user", "argocd-image-updater",
+	}, container.Command)
+	assert.Equal(t, imageUpdaterSecretName, container.Env[0].ValueFrom.SecretKeyRef.Name)
+
+	// Changes to the options are synced to the deployment and the configuration
+	a.Spec.ImageUpdater.Version = "v0.13.0"
+	a.Spec.ImageUpdater.Config.ApplicationsAPI = argoproj.ArgoCDImageUpdaterApplicationsAPIArgoCD
+	a.Spec.ImageUpdater.Config.Registries = ""
+	assert.NoError(t, r.reconcileImageUpdater(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-image-updater", Namespace: a.Namespace}, deployment))
+	assert.Equal(t, "quay.io/argoprojlabs/argocd-image-updater:v0.13.0", deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Command, "argocd")
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: imageUpdaterConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, "", cm.Data["registries.conf"])
+
+	// Disabling the Image Updater removes its resources
+	a.Spec.ImageUpdater.Enabled = false
+	assert.NoError(t, r.deleteDisabledComponentResources(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-image-updater", Namespace: a.Namespace}, deployment)
+	assert.True(t, errors.IsNotFound(err))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: getServiceAccountName(a.Name, common.ArgoCDImageUpdaterComponent), Namespace: a.Namespace}, sa)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileArgoCD_reconcileArgoConfigMap_imageUpdaterAccount(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ImageUpdater = argoproj.ArgoCDImageUpdaterSpec{
+			Enabled: true,
+			Config: argoproj.ArgoCDImageUpdaterConfigSpec{
+				ApplicationsAPI: argoproj.ArgoCDImageUpdaterApplicationsAPIArgoCD,
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, "apiKey", cm.Data["accounts.image-updater"])
+
+	// The account is not needed with the kubernetes API
+	a.Spec.ImageUpdater.Config.ApplicationsAPI = argoproj.ArgoCDImageUpdaterApplicationsAPIKubernetes
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.NotContains(t, cm.Data, "accounts.image-updater")
+}
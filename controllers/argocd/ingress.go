@@ -17,6 +17,8 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,6 +38,116 @@ func getPathOrDefault(path string) string {
 	return result
 }
 
+// getArgoServerIngressPath will return the Ingress Path for the Argo CD Server, along with the target that
+// requests should be rewritten to, if any. The path follows the server root path unless a different path
+// has been set explicitly for the Ingress, in which case requests are rewritten to the root path.
+func getArgoServerIngressPath(cr *argoproj.ArgoCD) (string, string) {
+	rootPath := getArgoServerRootPath(cr)
+	if rootPath == "" {
+		return getPathOrDefault(cr.Spec.Server.Ingress.Path), ""
+	}
+
+	prefix := strings.TrimSuffix(cr.Spec.Server.Ingress.Path, "/")
+	switch {
+	case len(cr.Spec.Server.Ingress.Path) == 0 || prefix == rootPath:
+		return rootPath, ""
+	case prefix == "":
+		return "/(.*)", rootPath + "/$1"
+	default:
+		return prefix + "(/|$)(.*)", rootPath + "/$2"
+	}
+}
+
+// certManagerIngressKeys are the annotations of an Ingress managed from its cert-manager TLS issuer.
+var certManagerIngressKeys = []string{
+	common.ArgoCDKeyIngressCertManagerIssuer,
+	common.ArgoCDKeyIngressCertManagerClusterIssuer,
+	common.ArgoCDKeyIngressCertManagerHTTP01IngressClass,
+}
+
+// getIngressTLSIssuerAnnotations will return the cert-manager annotations requesting the certificate of an Ingress
+// from its TLS issuer, if any. HTTP-01 challenges are solved through the class of the Ingress, so that the solver is
+// reachable through the same ingress controller. The class is ignored by DNS-01 solvers.
+func getIngressTLSIssuerAnnotations(spec argoproj.ArgoCDIngressSpec) map[string]string {
+	atns := make(map[string]string)
+	if spec.TLSIssuer == "" {
+		return atns
+	}
+
+	if spec.TLSIssuerKind == common.ArgoCDCertManagerClusterIssuerKind {
+		atns[common.ArgoCDKeyIngressCertManagerClusterIssuer] = spec.TLSIssuer
+	} else {
+		atns[common.ArgoCDKeyIngressCertManagerIssuer] = spec.TLSIssuer
+	}
+	if spec.IngressClassName != nil && *spec.IngressClassName != "" {
+		atns[common.ArgoCDKeyIngressCertManagerHTTP01IngressClass] = *spec.IngressClassName
+	}
+	return atns
+}
+
+// getArgoServerIngressHosts will return the hostnames of the Argo CD Server Ingress, the host of the server first
+// followed by the additional hosts of the Ingress.
+func getArgoServerIngressHosts(cr *argoproj.ArgoCD) []string {
+	hosts := []string{getArgoServerHost(cr)}
+	for _, host := range cr.Spec.Server.Ingress.Hosts {
+		if host != "" && !containsString(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// getArgoServerIngressRules will return the rules of the Argo CD Server Ingress, routing the given path of each of
+// its hostnames to the Argo CD Server.
+func getArgoServerIngressRules(cr *argoproj.ArgoCD, path string) []networkingv1.IngressRule {
+	pathType := networkingv1.PathTypeImplementationSpecific
+	rules := []networkingv1.IngressRule{}
+	for _, host := range getArgoServerIngressHosts(cr) {
+		rules = append(rules, networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{
+							Path: path,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: nameWithSuffix("server", cr),
+									Port: networkingv1.ServiceBackendPort{
+										Name: "http",
+									},
+								},
+							},
+							PathType: &pathType,
+						},
+					},
+				},
+			},
+		})
+	}
+	return rules
+}
+
+// getArgoServerIngressTLS will return the TLS configuration of the Argo CD Server Ingress. The certificate is stored
+// in a dedicated secret when requested from a cert-manager issuer.
+func getArgoServerIngressTLS(cr *argoproj.ArgoCD) []networkingv1.IngressTLS {
+	// Allow override of TLS options if specified
+	if len(cr.Spec.Server.Ingress.TLS) > 0 {
+		return cr.Spec.Server.Ingress.TLS
+	}
+
+	secretName := common.ArgoCDSecretName
+	if cr.Spec.Server.Ingress.TLSIssuer != "" {
+		secretName = nameWithSuffix(common.ArgoCDServerIngressTLSSecretSuffix, cr)
+	}
+	return []networkingv1.IngressTLS{
+		{
+			Hosts:      getArgoServerIngressHosts(cr),
+			SecretName: secretName,
+		},
+	}
+}
+
 // newIngress returns a new Ingress instance for the given ArgoCD.
 func newIngress(cr *argoproj.ArgoCD) *networkingv1.Ingress {
 	return &networkingv1.Ingress{
@@ -93,20 +205,89 @@ func (r *ReconcileArgoCD) reconcileIngresses(cr *argoproj.ArgoCD) error {
 func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoproj.ArgoCD) error {
 	ingress := newIngressWithSuffix("server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if !cr.Spec.Server.Ingress.Enabled {
+		if !cr.Spec.Server.Ingress.Enabled || !isServerEnabled(cr) {
 			// Ingress exists but enabled flag has been set to false, delete the Ingress
 			return r.Client.Delete(context.TODO(), ingress)
 		}
 
+		changed := false
 		// If Ingress found and enabled, make sure the ingressClassName is up-to-date
 		if ingress.Spec.IngressClassName != cr.Spec.Server.Ingress.IngressClassName {
 			ingress.Spec.IngressClassName = cr.Spec.Server.Ingress.IngressClassName
+			changed = true
+		}
+
+		// Make sure the path and rewrite target follow the server root path
+		path, rewriteTarget := getArgoServerIngressPath(cr)
+		if len(cr.Spec.Server.Ingress.Annotations) == 0 && ingress.Annotations[common.ArgoCDKeyIngressRewriteTarget] != rewriteTarget {
+			if ingress.Annotations == nil {
+				ingress.Annotations = make(map[string]string)
+			}
+			if rewriteTarget != "" {
+				ingress.Annotations[common.ArgoCDKeyIngressUseRegex] = "true"
+				ingress.Annotations[common.ArgoCDKeyIngressRewriteTarget] = rewriteTarget
+			} else {
+				delete(ingress.Annotations, common.ArgoCDKeyIngressUseRegex)
+				delete(ingress.Annotations, common.ArgoCDKeyIngressRewriteTarget)
+			}
+			changed = true
+		}
+		// Make sure the certificate is requested from the configured issuer, unless annotated explicitly
+		issuerAtns := getIngressTLSIssuerAnnotations(cr.Spec.Server.Ingress)
+		for _, key := range certManagerIngressKeys {
+			if _, ok := cr.Spec.Server.Ingress.Annotations[key]; ok {
+				continue
+			}
+			value, ok := issuerAtns[key]
+			if current, exists := ingress.Annotations[key]; exists == ok && current == value {
+				continue
+			}
+			if ok {
+				if ingress.Annotations == nil {
+					ingress.Annotations = make(map[string]string)
+				}
+				ingress.Annotations[key] = value
+			} else {
+				delete(ingress.Annotations, key)
+			}
+			changed = true
+		}
+		// Make sure the Ingress serves all the hostnames of the server, with their certificate
+		hosts := []string{}
+		for _, rule := range ingress.Spec.Rules {
+			hosts = append(hosts, rule.Host)
+		}
+		hostsChanged := !reflect.DeepEqual(hosts, getArgoServerIngressHosts(cr))
+		if hostsChanged {
+			ingress.Spec.Rules = getArgoServerIngressRules(cr, path)
+			changed = true
+		}
+		if cr.Spec.Server.Ingress.TLSIssuer != "" || hostsChanged {
+			if tls := getArgoServerIngressTLS(cr); !reflect.DeepEqual(ingress.Spec.TLS, tls) {
+				ingress.Spec.TLS = tls
+				changed = true
+			}
+		}
+
+		for i := range ingress.Spec.Rules {
+			if ingress.Spec.Rules[i].HTTP == nil {
+				continue
+			}
+			for j := range ingress.Spec.Rules[i].HTTP.Paths {
+				if ingress.Spec.Rules[i].HTTP.Paths[j].Path != path {
+					ingress.Spec.Rules[i].HTTP.Paths[j].Path = path
+					changed = true
+				}
+			}
+		}
+
+		if changed {
 			return r.Client.Update(context.TODO(), ingress)
 		}
 		return nil // Ingress found and enabled, do nothing
 	}
 
-	if !cr.Spec.Server.Ingress.Enabled {
+	if !cr.Spec.Server.Ingress.Enabled || !isServerEnabled(cr) {
 		return nil // Ingress not enabled, move along...
 	}
 
@@ -114,56 +295,36 @@ func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoproj.ArgoCD) error
 	atns := make(map[string]string)
 	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
 	atns[common.ArgoCDKeyIngressBackendProtocol] = "HTTP"
+	path, rewriteTarget := getArgoServerIngressPath(cr)
+	if rewriteTarget != "" {
+		atns[common.ArgoCDKeyIngressUseRegex] = "true"
+		atns[common.ArgoCDKeyIngressRewriteTarget] = rewriteTarget
+	}
 
 	// Override default annotations if specified
 	if len(cr.Spec.Server.Ingress.Annotations) > 0 {
-		atns = cr.Spec.Server.Ingress.Annotations
+		atns = make(map[string]string)
+		for k, v := range cr.Spec.Server.Ingress.Annotations {
+			atns[k] = v
+		}
+	}
+
+	// Request the certificate from the configured issuer, unless annotated explicitly
+	for k, v := range getIngressTLSIssuerAnnotations(cr.Spec.Server.Ingress) {
+		if _, ok := atns[k]; !ok {
+			atns[k] = v
+		}
 	}
 
 	ingress.ObjectMeta.Annotations = atns
 
 	ingress.Spec.IngressClassName = cr.Spec.Server.Ingress.IngressClassName
 
-	pathType := networkingv1.PathTypeImplementationSpecific
 	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: getArgoServerHost(cr),
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path: getPathOrDefault(cr.Spec.Server.Ingress.Path),
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: nameWithSuffix("server", cr),
-									Port: networkingv1.ServiceBackendPort{
-										Name: "http",
-									},
-								},
-							},
-							PathType: &pathType,
-						},
-					},
-				},
-			},
-		},
-	}
+	ingress.Spec.Rules = getArgoServerIngressRules(cr, path)
 
-	// Add default TLS options
-	ingress.Spec.TLS = []networkingv1.IngressTLS{
-		{
-			Hosts: []string{
-				getArgoServerHost(cr),
-			},
-			SecretName: common.ArgoCDSecretName,
-		},
-	}
-
-	// Allow override of TLS options if specified
-	if len(cr.Spec.Server.Ingress.TLS) > 0 {
-		ingress.Spec.TLS = cr.Spec.Server.Ingress.TLS
-	}
+	// Add TLS options
+	ingress.Spec.TLS = getArgoServerIngressTLS(cr)
 
 	if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
 		return err
@@ -175,14 +336,14 @@ func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoproj.ArgoCD) error
 func (r *ReconcileArgoCD) reconcileArgoServerGRPCIngress(cr *argoproj.ArgoCD) error {
 	ingress := newIngressWithSuffix("grpc", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if !cr.Spec.Server.GRPC.Ingress.Enabled {
+		if !cr.Spec.Server.GRPC.Ingress.Enabled || !isServerEnabled(cr) {
 			// Ingress exists but enabled flag has been set to false, delete the Ingress
 			return r.Client.Delete(context.TODO(), ingress)
 		}
 		return nil // Ingress found and enabled, do nothing
 	}
 
-	if !cr.Spec.Server.GRPC.Ingress.Enabled {
+	if !cr.Spec.Server.GRPC.Ingress.Enabled || !isServerEnabled(cr) {
 		return nil // Ingress not enabled, move along...
 	}
 
@@ -254,7 +415,7 @@ func (r *ReconcileArgoCD) reconcileGrafanaIngress(cr *argoproj.ArgoCD) error {
 			// Ingress exists but enabled flag has been set to false, delete the Ingress
 			return r.Client.Delete(context.TODO(), ingress)
 		}
-		log.Info(grafanaDeprecatedWarning)
+		instanceLog(cr).Info(grafanaDeprecatedWarning)
 		return nil // Ingress found and enabled, do nothing
 	}
 
@@ -262,7 +423,7 @@ func (r *ReconcileArgoCD) reconcileGrafanaIngress(cr *argoproj.ArgoCD) error {
 		return nil // Grafana itself or Ingress not enabled, move along...
 	}
 
-	log.Info(grafanaDeprecatedWarning)
+	instanceLog(cr).Info(grafanaDeprecatedWarning)
 
 	return nil
 }
@@ -271,14 +432,14 @@ func (r *ReconcileArgoCD) reconcileGrafanaIngress(cr *argoproj.ArgoCD) error {
 func (r *ReconcileArgoCD) reconcilePrometheusIngress(cr *argoproj.ArgoCD) error {
 	ingress := newIngressWithSuffix("prometheus", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if !cr.Spec.Prometheus.Enabled || !cr.Spec.Prometheus.Ingress.Enabled {
+		if !isPrometheusManaged(cr) || !cr.Spec.Prometheus.Ingress.Enabled {
 			// Ingress exists but enabled flag has been set to false, delete the Ingress
 			return r.Client.Delete(context.TODO(), ingress)
 		}
 		return nil // Ingress found and enabled, do nothing
 	}
 
-	if !cr.Spec.Prometheus.Enabled || !cr.Spec.Prometheus.Ingress.Enabled {
+	if !isPrometheusManaged(cr) || !cr.Spec.Prometheus.Ingress.Enabled {
 		return nil // Prometheus itself or Ingress not enabled, move along...
 	}
 
@@ -352,7 +513,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetControllerIngress(cr *argoproj.
 	}
 
 	if cr.Spec.ApplicationSet == nil || !cr.Spec.ApplicationSet.WebhookServer.Ingress.Enabled {
-		log.Info("not enabled")
+		instanceLog(cr).Info("not enabled")
 		return nil // Ingress not enabled, move along...
 	}
 
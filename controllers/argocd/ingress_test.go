@@ -106,6 +106,179 @@ func TestReconcileArgoCD_reconcile_ServerIngress_ingressClassName_update(t *test
 
 }
 
+func TestReconcileArgoCD_reconcile_ServerIngress_rootPath(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	tests := []struct {
+		name         string
+		rootPath     string
+		ingressPath  string
+		wantPath     string
+		wantRewrite  string
+		wantUseRegex bool
+	}{
+		{
+			name:     "no root path",
+			wantPath: "/",
+		},
+		{
+			name:     "root path is used as ingress path",
+			rootPath: "/argocd",
+			wantPath: "/argocd",
+		},
+		{
+			name:        "matching ingress path",
+			rootPath:    "/argocd",
+			ingressPath: "/argocd/",
+			wantPath:    "/argocd",
+		},
+		{
+			name:         "ingress path is rewritten to root path",
+			rootPath:     "/argocd",
+			ingressPath:  "/cd",
+			wantPath:     "/cd(/|$)(.*)",
+			wantRewrite:  "/argocd/$2",
+			wantUseRegex: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Spec.Server.Ingress.Enabled = true
+				a.Spec.Server.Ingress.Path = test.ingressPath
+				a.Spec.Server.RootPath = test.rootPath
+			})
+
+			resObjs := []client.Object{a}
+			subresObjs := []client.Object{a}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+
+			assert.NoError(t, r.reconcileArgoServerIngress(a))
+
+			ingress := &networkingv1.Ingress{}
+			assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+				Name:      "argocd-server",
+				Namespace: testNamespace,
+			}, ingress))
+			assert.Equal(t, test.wantPath, ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+			assert.Equal(t, test.wantRewrite, ingress.Annotations[common.ArgoCDKeyIngressRewriteTarget])
+			_, useRegex := ingress.Annotations[common.ArgoCDKeyIngressUseRegex]
+			assert.Equal(t, test.wantUseRegex, useRegex)
+
+			// Changing the root path updates the existing ingress
+			a.Spec.Server.RootPath = "/other"
+			a.Spec.Server.Ingress.Path = ""
+			assert.NoError(t, r.reconcileArgoServerIngress(a))
+			assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+				Name:      "argocd-server",
+				Namespace: testNamespace,
+			}, ingress))
+			assert.Equal(t, "/other", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+			assert.NotContains(t, ingress.Annotations, common.ArgoCDKeyIngressRewriteTarget)
+		})
+	}
+}
+
+func TestReconcileArgoCD_reconcile_ServerIngress_tlsIssuer(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	nginx := "nginx"
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Ingress.Enabled = true
+		a.Spec.Server.Ingress.IngressClassName = &nginx
+		a.Spec.Server.Ingress.TLSIssuer = "letsencrypt"
+		a.Spec.Server.Ingress.TLSIssuerKind = common.ArgoCDCertManagerClusterIssuerKind
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+
+	ingress := &networkingv1.Ingress{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-server",
+		Namespace: testNamespace,
+	}, ingress))
+	assert.Equal(t, "letsencrypt", ingress.Annotations[common.ArgoCDKeyIngressCertManagerClusterIssuer])
+	assert.Equal(t, nginx, ingress.Annotations[common.ArgoCDKeyIngressCertManagerHTTP01IngressClass])
+	assert.Equal(t, "true", ingress.Annotations[common.ArgoCDKeyIngressSSLRedirect])
+	assert.Equal(t, "argocd-server-ingress-tls", ingress.Spec.TLS[0].SecretName)
+	assert.Equal(t, []string{getArgoServerHost(a)}, ingress.Spec.TLS[0].Hosts)
+
+	// Switching to a namespaced issuer updates the existing ingress
+	a.Spec.Server.Ingress.TLSIssuerKind = ""
+	a.Spec.Server.Ingress.IngressClassName = nil
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-server",
+		Namespace: testNamespace,
+	}, ingress))
+	assert.Equal(t, "letsencrypt", ingress.Annotations[common.ArgoCDKeyIngressCertManagerIssuer])
+	assert.NotContains(t, ingress.Annotations, common.ArgoCDKeyIngressCertManagerClusterIssuer)
+	assert.NotContains(t, ingress.Annotations, common.ArgoCDKeyIngressCertManagerHTTP01IngressClass)
+}
+
+func TestReconcileArgoCD_reconcile_ServerIngress_hosts(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Host = "argocd.example.com"
+		a.Spec.Server.Ingress.Enabled = true
+		a.Spec.Server.Ingress.Hosts = []string{"*.argocd.example.com", "argocd.example.com"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+
+	ingress := &networkingv1.Ingress{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-server",
+		Namespace: testNamespace,
+	}, ingress))
+	assert.Len(t, ingress.Spec.Rules, 2)
+	assert.Equal(t, "argocd.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "*.argocd.example.com", ingress.Spec.Rules[1].Host)
+	assert.Equal(t, "argocd-server", ingress.Spec.Rules[1].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, []string{"argocd.example.com", "*.argocd.example.com"}, ingress.Spec.TLS[0].Hosts)
+
+	// Changing the hosts updates the rules and the TLS of the existing ingress
+	a.Spec.Server.Ingress.Hosts = []string{"cd.example.com"}
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-server",
+		Namespace: testNamespace,
+	}, ingress))
+	assert.Len(t, ingress.Spec.Rules, 2)
+	assert.Equal(t, "cd.example.com", ingress.Spec.Rules[1].Host)
+	assert.Equal(t, []string{"argocd.example.com", "cd.example.com"}, ingress.Spec.TLS[0].Hosts)
+
+	// Custom TLS options are kept as is
+	a.Spec.Server.Ingress.Hosts = nil
+	a.Spec.Server.Ingress.TLS = []networkingv1.IngressTLS{{Hosts: []string{"argocd.example.com"}, SecretName: "custom-tls"}}
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      "argocd-server",
+		Namespace: testNamespace,
+	}, ingress))
+	assert.Len(t, ingress.Spec.Rules, 1)
+	assert.Equal(t, a.Spec.Server.Ingress.TLS, ingress.Spec.TLS)
+}
+
 func TestReconcileArgoCD_reconcile_ServerGRPCIngress_ingressClassName(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 
@@ -0,0 +1,197 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// getInheritFromKey will return the key of the resource the given ArgoCD inherits its spec from.
+func getInheritFromKey(cr *argoproj.ArgoCD) (argoproj.ArgoCDInheritFromKind, types.NamespacedName) {
+	ref := cr.Spec.InheritFrom
+	kind := ref.Kind
+	if kind == "" {
+		kind = argoproj.ArgoCDInheritFromKindArgoCD
+	}
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = cr.Namespace
+	}
+	return kind, types.NamespacedName{Name: ref.Name, Namespace: namespace}
+}
+
+// getInheritedValues will return the fields set in the spec the given ArgoCD inherits from. The referenced resource
+// must be labeled as a template, so that instances cannot copy the configuration of any other instance of the cluster.
+func (r *ReconcileArgoCD) getInheritedValues(cr *argoproj.ArgoCD) (map[string]interface{}, error) {
+	kind, key := getInheritFromKey(cr)
+
+	if kind == argoproj.ArgoCDInheritFromKindConfigMap {
+		cm := &corev1.ConfigMap{}
+		if err := r.Client.Get(context.TODO(), key, cm); err != nil {
+			return nil, fmt.Errorf("failed to get %s %s to inherit from: %w", kind, key, err)
+		}
+		if cm.Labels[common.ArgoCDTemplateLabel] != "true" {
+			return nil, fmt.Errorf("%s %s to inherit from is not labeled %s=true", kind, key, common.ArgoCDTemplateLabel)
+		}
+		data := []byte(cm.Data[common.ArgoCDKeyInheritFromSpec])
+		if err := yaml.UnmarshalStrict(data, &argoproj.ArgoCDSpec{}); err != nil {
+			return nil, fmt.Errorf("failed to parse the spec of ConfigMap %s to inherit from: %w", key, err)
+		}
+		values := map[string]interface{}{}
+		if err := yaml.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse the spec of ConfigMap %s to inherit from: %w", key, err)
+		}
+		return values, nil
+	}
+
+	if key.Name == cr.Name && key.Namespace == cr.Namespace {
+		return nil, fmt.Errorf("ArgoCD %s cannot inherit from itself", cr.Name)
+	}
+	template, values, err := r.getArgoCDSpecValues(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s to inherit from: %w", kind, key, err)
+	}
+	if template.GetLabels()[common.ArgoCDTemplateLabel] != "true" {
+		return nil, fmt.Errorf("%s %s to inherit from is not labeled %s=true", kind, key, common.ArgoCDTemplateLabel)
+	}
+	return values, nil
+}
+
+// getArgoCDSpecValues will return the ArgoCD with the given key as stored in the cluster, along with the fields set in
+// its spec. The fields filled by the defaulting webhook are left out, see clearDefaultedFields.
+func (r *ReconcileArgoCD) getArgoCDSpecValues(key types.NamespacedName) (*unstructured.Unstructured, map[string]interface{}, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(argoproj.GroupVersion.WithKind("ArgoCD"))
+	if err := r.Client.Get(context.TODO(), key, obj); err != nil {
+		return nil, nil, err
+	}
+
+	values, _, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return nil, nil, err
+	}
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+
+	cr := &argoproj.ArgoCD{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, cr); err != nil {
+		return nil, nil, err
+	}
+	for _, path := range clearDefaultedFields(cr) {
+		unstructured.RemoveNestedField(values, strings.Split(strings.TrimPrefix(path, "spec."), ".")...)
+	}
+	return obj, values, nil
+}
+
+// applyInheritedSpec will fill the fields left unset in the spec of the given ArgoCD with the ones of the spec it
+// inherits from, if any. The spec is only changed in memory, the ArgoCD resource is left untouched.
+func (r *ReconcileArgoCD) applyInheritedSpec(cr *argoproj.ArgoCD) error {
+	if cr.Spec.InheritFrom == nil {
+		return nil
+	}
+
+	base, err := r.getInheritedValues(cr)
+	if err != nil {
+		return err
+	}
+	_, values, err := r.getArgoCDSpecValues(client.ObjectKeyFromObject(cr))
+	if err != nil {
+		return err
+	}
+
+	spec, err := mergeInheritedSpec(values, base)
+	if err != nil {
+		return err
+	}
+	cr.Spec = spec
+	return nil
+}
+
+// mergeInheritedSpec will return the spec with the given fields, with its unset fields taken from the given base
+// fields. Objects are merged field by field, while lists and scalar values set in the spec replace the ones of the
+// base spec. References are not followed further, the base spec does not inherit from its own template.
+func mergeInheritedSpec(values, base map[string]interface{}) (argoproj.ArgoCDSpec, error) {
+	delete(base, "inheritFrom")
+	mergeInheritedValues(values, base)
+
+	merged := argoproj.ArgoCDSpec{}
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return merged, err
+	}
+	return merged, json.Unmarshal(raw, &merged)
+}
+
+// mergeInheritedValues will add the values of src missing from dst to dst, merging nested objects recursively. Only
+// the fields absent from dst are missing, a field explicitly set to its zero value is kept.
+func mergeInheritedValues(dst, src map[string]interface{}) {
+	for k, v := range src {
+		existing, ok := dst[k]
+		if !ok {
+			dst[k] = v
+			continue
+		}
+		dstMap, dstIsMap := existing.(map[string]interface{})
+		srcMap, srcIsMap := v.(map[string]interface{})
+		if dstIsMap && srcIsMap {
+			mergeInheritedValues(dstMap, srcMap)
+		}
+	}
+}
+
+// inheritFromMapper maps a watch event on a template ArgoCD resource or ConfigMap back to the ArgoCD instances
+// inheriting from it.
+func (r *ReconcileArgoCD) inheritFromMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	if o.GetLabels()[common.ArgoCDTemplateLabel] != "true" {
+		return result
+	}
+	kind := argoproj.ArgoCDInheritFromKindArgoCD
+	if _, ok := o.(*corev1.ConfigMap); ok {
+		kind = argoproj.ArgoCDInheritFromKindConfigMap
+	}
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(ctx, argocds); err != nil {
+		return result
+	}
+	for i := range argocds.Items {
+		cr := &argocds.Items[i]
+		if cr.Spec.InheritFrom == nil {
+			continue
+		}
+		refKind, key := getInheritFromKey(cr)
+		if refKind == kind && key.Name == o.GetName() && key.Namespace == o.GetNamespace() {
+			result = append(result, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)})
+		}
+	}
+	return result
+}
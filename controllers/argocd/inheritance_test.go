@@ -0,0 +1,182 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestArgoCDTemplate(opts ...argoCDOpt) *argoproj.ArgoCD {
+	a := makeTestArgoCD(opts...)
+	a.Name = "argocd-template"
+	a.Labels = map[string]string{common.ArgoCDTemplateLabel: "true"}
+	return a
+}
+
+func TestReconcileArgoCD_applyInheritedSpec(t *testing.T) {
+	template := makeTestArgoCDTemplate(func(a *argoproj.ArgoCD) {
+		templateReplicas := int32(2)
+		a.Spec.Server.Replicas = &templateReplicas
+		a.Spec.Server.Host = "argocd.example.com"
+		a.Spec.Controller.LogLevel = "debug"
+		a.Spec.SourceNamespaces = []string{"team-a", "team-b"}
+	})
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.InheritFrom = &argoproj.ArgoCDInheritFromSpec{Name: template.Name}
+		replicas := int32(3)
+		a.Spec.Server.Replicas = &replicas
+		a.Spec.SourceNamespaces = []string{"team-c"}
+	})
+
+	resObjs := []client.Object{template, a}
+	subresObjs := []client.Object{template, a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.applyInheritedSpec(a))
+	assert.Equal(t, int32(3), *a.Spec.Server.Replicas)
+	assert.Equal(t, "argocd.example.com", a.Spec.Server.Host)
+	assert.Equal(t, "debug", a.Spec.Controller.LogLevel)
+	assert.Equal(t, []string{"team-c"}, a.Spec.SourceNamespaces)
+	assert.Equal(t, template.Name, a.Spec.InheritFrom.Name)
+}
+
+func TestReconcileArgoCD_applyInheritedSpec_configMap(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "argocd-template",
+			Namespace: "platform",
+			Labels:    map[string]string{common.ArgoCDTemplateLabel: "true"},
+		},
+		Data: map[string]string{
+			common.ArgoCDKeyInheritFromSpec: "server:\n  replicas: 2\ncontroller:\n  logLevel: debug\n",
+		},
+	}
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.InheritFrom = &argoproj.ArgoCDInheritFromSpec{
+			Kind:      argoproj.ArgoCDInheritFromKindConfigMap,
+			Name:      cm.Name,
+			Namespace: cm.Namespace,
+		}
+		a.Spec.Controller.LogLevel = "info"
+	})
+
+	resObjs := []client.Object{cm, a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.applyInheritedSpec(a))
+	assert.Equal(t, int32(2), *a.Spec.Server.Replicas)
+	assert.Equal(t, "info", a.Spec.Controller.LogLevel)
+
+	// Unknown fields are rejected rather than silently ignored
+	cm.Data[common.ArgoCDKeyInheritFromSpec] = "server:\n  replica: 2\n"
+	assert.NoError(t, cl.Update(context.TODO(), cm))
+	assert.Error(t, r.applyInheritedSpec(a))
+}
+
+func TestMergeInheritedValues(t *testing.T) {
+	dst := map[string]interface{}{
+		"server": map[string]interface{}{"route": map[string]interface{}{"enabled": false}},
+		"repo":   map[string]interface{}{"replicas": int64(0)},
+	}
+	src := map[string]interface{}{
+		"server": map[string]interface{}{
+			"route":    map[string]interface{}{"enabled": true, "path": "/argocd"},
+			"insecure": true,
+		},
+		"repo": map[string]interface{}{"replicas": int64(2)},
+		"ha":   map[string]interface{}{"enabled": true},
+	}
+
+	// Fields explicitly set to their zero value are kept, absent ones are inherited
+	mergeInheritedValues(dst, src)
+	assert.Equal(t, map[string]interface{}{
+		"server": map[string]interface{}{
+			"route":    map[string]interface{}{"enabled": false, "path": "/argocd"},
+			"insecure": true,
+		},
+		"repo": map[string]interface{}{"replicas": int64(0)},
+		"ha":   map[string]interface{}{"enabled": true},
+	}, dst)
+}
+
+func TestReconcileArgoCD_applyInheritedSpec_notTemplate(t *testing.T) {
+	source := makeTestArgoCDTemplate()
+	source.Labels = nil
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.InheritFrom = &argoproj.ArgoCDInheritFromSpec{Name: source.Name}
+	})
+
+	resObjs := []client.Object{source, a}
+	subresObjs := []client.Object{source, a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.ErrorContains(t, r.applyInheritedSpec(a), common.ArgoCDTemplateLabel)
+
+	// An instance cannot inherit from itself
+	a.Spec.InheritFrom.Name = a.Name
+	assert.ErrorContains(t, r.applyInheritedSpec(a), "itself")
+}
+
+func TestReconcileArgoCD_inheritFromMapper(t *testing.T) {
+	template := makeTestArgoCDTemplate()
+	inheriting := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Name = "inheriting"
+		a.Spec.InheritFrom = &argoproj.ArgoCDInheritFromSpec{Name: template.Name}
+	})
+	other := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Name = "other"
+		a.Namespace = "other"
+		a.Spec.InheritFrom = &argoproj.ArgoCDInheritFromSpec{Name: template.Name}
+	})
+
+	resObjs := []client.Object{template, inheriting, other}
+	subresObjs := []client.Object{template, inheriting, other}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: inheriting.Name, Namespace: testNamespace}}}
+	assert.Equal(t, want, r.inheritFromMapper(context.TODO(), template))
+
+	// A ConfigMap of the same name is not the template of the instances
+	cm := &corev1.ConfigMap{ObjectMeta: template.ObjectMeta}
+	assert.Empty(t, r.inheritFromMapper(context.TODO(), cm))
+
+	template.Labels = nil
+	assert.Empty(t, r.inheritFromMapper(context.TODO(), template))
+}
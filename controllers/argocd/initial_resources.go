@@ -0,0 +1,164 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+var (
+	// argoCDApplicationGVK is the GroupVersionKind of the Argo CD Application resource.
+	argoCDApplicationGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Application"}
+
+	// argoCDAppProjectGVK is the GroupVersionKind of the Argo CD AppProject resource.
+	argoCDAppProjectGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "AppProject"}
+)
+
+// getInitialResourceManifest will return the YAML of the given initial resource source.
+func (r *ReconcileArgoCD) getInitialResourceManifest(cr *argoproj.ArgoCD, src argoproj.InitialResourceSpec) (string, error) {
+	if src.ConfigMapRef == nil {
+		return src.Manifest, nil
+	}
+
+	optional := src.ConfigMapRef.Optional != nil && *src.ConfigMapRef.Optional
+
+	cm := &corev1.ConfigMap{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: src.ConfigMapRef.Name, Namespace: cr.Namespace}, cm); err != nil {
+		if apierrors.IsNotFound(err) && optional {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get ConfigMap %s: %w", src.ConfigMapRef.Name, err)
+	}
+
+	manifest, ok := cm.Data[src.ConfigMapRef.Key]
+	if !ok && !optional {
+		return "", fmt.Errorf("key %s not found in ConfigMap %s", src.ConfigMapRef.Key, src.ConfigMapRef.Name)
+	}
+	return manifest, nil
+}
+
+// parseInitialResources will return the resources of the given kind found in the YAML manifest. Resources without
+// a namespace are placed in the given default namespace, and resources outside of the allowed namespaces are rejected.
+func parseInitialResources(manifest string, gvk schema.GroupVersionKind, defaultNamespace string, allowedNamespaces []string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+
+	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+	for {
+		data := map[string]interface{}{}
+		if err := decoder.Decode(&data); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to decode %s manifest: %w", gvk.Kind, err)
+		}
+		if len(data) == 0 {
+			continue // empty document
+		}
+
+		obj := &unstructured.Unstructured{Object: data}
+		if obj.GroupVersionKind() != gvk {
+			return nil, fmt.Errorf("expected %s, found %s %q", gvk.Kind, obj.GroupVersionKind().String(), obj.GetName())
+		}
+		if obj.GetName() == "" {
+			return nil, fmt.Errorf("%s without a name", gvk.Kind)
+		}
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(defaultNamespace)
+		}
+		if obj.GetNamespace() != defaultNamespace && !containsString(allowedNamespaces, obj.GetNamespace()) {
+			return nil, fmt.Errorf("%s %s cannot be created in namespace %s", gvk.Kind, obj.GetName(), obj.GetNamespace())
+		}
+		objs = append(objs, obj)
+	}
+
+	return objs, nil
+}
+
+// getInitialResources will return the initial resources of the given kind from the given sources.
+func (r *ReconcileArgoCD) getInitialResources(cr *argoproj.ArgoCD, sources []argoproj.InitialResourceSpec, gvk schema.GroupVersionKind, allowedNamespaces []string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for _, src := range sources {
+		manifest, err := r.getInitialResourceManifest(cr, src)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parseInitialResources(manifest, gvk, cr.Namespace, allowedNamespaces)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, parsed...)
+	}
+	return objs, nil
+}
+
+// reconcileInitialResources will create the initial AppProjects and Applications of the given ArgoCD once the
+// instance is Available for the first time. Resources that already exist are left untouched, and nothing is
+// applied again once the InitialResourcesApplied condition is set.
+func (r *ReconcileArgoCD) reconcileInitialResources(cr *argoproj.ArgoCD) error {
+	if len(cr.Spec.InitialProjects) == 0 && len(cr.Spec.InitialApplications) == 0 {
+		return nil
+	}
+
+	if meta.IsStatusConditionTrue(cr.Status.Conditions, argoproj.ArgoCDConditionTypeInitialResourcesApplied) {
+		return nil // Already applied, move along...
+	}
+
+	if cr.Status.Phase != "Available" {
+		instanceLog(cr).Info("waiting for the Argo CD instance to become Available before creating the initial resources")
+		return nil
+	}
+
+	projects, err := r.getInitialResources(cr, cr.Spec.InitialProjects, argoCDAppProjectGVK, nil)
+	if err != nil {
+		return fmt.Errorf("invalid initial projects: %w", err)
+	}
+
+	applications, err := r.getInitialResources(cr, cr.Spec.InitialApplications, argoCDApplicationGVK, cr.Spec.SourceNamespaces)
+	if err != nil {
+		return fmt.Errorf("invalid initial applications: %w", err)
+	}
+
+	// AppProjects are created first, so that the Applications referencing them are valid right away
+	for _, obj := range append(projects, applications...) {
+		instanceLog(cr).Info(fmt.Sprintf("creating initial %s %s in namespace %s", obj.GetKind(), obj.GetName(), obj.GetNamespace()))
+		if err := r.Client.Create(context.TODO(), obj); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create initial %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeInitialResourcesApplied,
+		Status:             metav1.ConditionTrue,
+		Reason:             argoproj.ArgoCDConditionReasonInitialResourcesApplied,
+		Message:            fmt.Sprintf("Created %d initial AppProjects and %d initial Applications", len(projects), len(applications)),
+		ObservedGeneration: cr.Generation,
+	})
+	return r.Client.Status().Update(context.TODO(), cr)
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+const testInitialApplications = `apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: app-of-apps
+spec:
+  project: bootstrap
+  source:
+    repoURL: https://github.com/argoproj/argocd-example-apps
+    path: apps
+  destination:
+    server: https://kubernetes.default.svc
+    namespace: argocd
+---
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: guestbook
+  namespace: team-a
+spec:
+  project: default
+`
+
+const testInitialProjects = `apiVersion: argoproj.io/v1alpha1
+kind: AppProject
+metadata:
+  name: bootstrap
+spec:
+  sourceRepos:
+  - '*'
+`
+
+func TestParseInitialResources(t *testing.T) {
+	objs, err := parseInitialResources(testInitialApplications, argoCDApplicationGVK, "argocd", []string{"team-a"})
+	assert.NoError(t, err)
+	assert.Len(t, objs, 2)
+	assert.Equal(t, "argocd", objs[0].GetNamespace())
+	assert.Equal(t, "team-a", objs[1].GetNamespace())
+
+	_, err = parseInitialResources(testInitialApplications, argoCDApplicationGVK, "argocd", nil)
+	assert.Error(t, err)
+
+	_, err = parseInitialResources(testInitialProjects, argoCDApplicationGVK, "argocd", nil)
+	assert.Error(t, err)
+
+	objs, err = parseInitialResources("---\n", argoCDAppProjectGVK, "argocd", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, objs)
+}
+
+func TestReconcileArgoCD_reconcileInitialResources(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SourceNamespaces = []string{"team-a"}
+		a.Spec.InitialApplications = []argoproj.InitialResourceSpec{{Manifest: testInitialApplications}}
+		a.Spec.InitialProjects = []argoproj.InitialResourceSpec{
+			{
+				ConfigMapRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "bootstrap"},
+					Key:                  "projects.yaml",
+				},
+			},
+		}
+	})
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: a.Namespace},
+		Data:       map[string]string{"projects.yaml": testInitialProjects},
+	}
+
+	resObjs := []client.Object{a, cm}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	getResource := func(gvkObj *unstructured.Unstructured, name, namespace string) error {
+		return r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, gvkObj)
+	}
+	newApplication := func() *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(argoCDApplicationGVK)
+		return obj
+	}
+
+	// Nothing is created before the instance is Available
+	assert.NoError(t, r.reconcileInitialResources(a))
+	assert.True(t, errors.IsNotFound(getResource(newApplication(), "app-of-apps", a.Namespace)))
+
+	a.Status.Phase = "Available"
+	assert.NoError(t, r.reconcileInitialResources(a))
+
+	project := &unstructured.Unstructured{}
+	project.SetGroupVersionKind(argoCDAppProjectGVK)
+	assert.NoError(t, getResource(project, "bootstrap", a.Namespace))
+	assert.NoError(t, getResource(newApplication(), "app-of-apps", a.Namespace))
+	assert.NoError(t, getResource(newApplication(), "guestbook", "team-a"))
+	assert.True(t, meta.IsStatusConditionTrue(a.Status.Conditions, argoproj.ArgoCDConditionTypeInitialResourcesApplied))
+
+	// The initial resources are applied only once
+	app := newApplication()
+	assert.NoError(t, getResource(app, "app-of-apps", a.Namespace))
+	assert.NoError(t, r.Client.Delete(context.TODO(), app))
+	assert.NoError(t, r.reconcileInitialResources(a))
+	assert.True(t, errors.IsNotFound(getResource(newApplication(), "app-of-apps", a.Namespace)))
+}
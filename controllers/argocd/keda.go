@@ -0,0 +1,203 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+var kedaAPIFound = false
+
+// kedaScaledObjectGVK is the GroupVersionKind of the KEDA ScaledObject resource.
+var kedaScaledObjectGVK = schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"}
+
+// kedaManagedSpecKeys are the ScaledObject spec fields managed by the operator.
+var kedaManagedSpecKeys = []string{"scaleTargetRef", "triggers", "minReplicaCount", "maxReplicaCount", "pollingInterval", "cooldownPeriod"}
+
+// IsKEDAAPIAvailable returns true if the KEDA API is present.
+func IsKEDAAPIAvailable() bool {
+	return kedaAPIFound
+}
+
+// verifyKEDAAPI will verify that the KEDA API is present.
+func verifyKEDAAPI() error {
+	found, err := argoutil.VerifyAPI(kedaScaledObjectGVK.Group, kedaScaledObjectGVK.Version)
+	if err != nil {
+		return err
+	}
+	kedaAPIFound = found
+	return nil
+}
+
+// isServerKEDAEnabled returns true if the Argo CD Server is autoscaled by a KEDA ScaledObject.
+func isServerKEDAEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Server.Autoscale.Enabled && cr.Spec.Server.Autoscale.KEDA != nil && !isServerOperatorAutoscaleEnabled(cr) && isServerEnabled(cr)
+}
+
+// isRepoKEDAEnabled returns true if the Argo CD Repo server is autoscaled by a KEDA ScaledObject.
+func isRepoKEDAEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Repo.Autoscale.Enabled && cr.Spec.Repo.Autoscale.KEDA != nil
+}
+
+// newScaledObjectWithSuffix returns a new, empty KEDA ScaledObject for the given ArgoCD.
+func newScaledObjectWithSuffix(suffix string, cr *argoproj.ArgoCD) *unstructured.Unstructured {
+	name := nameWithSuffix(suffix, cr)
+
+	lbls := argoutil.LabelsForCluster(cr)
+	lbls[common.ArgoCDKeyName] = name
+
+	so := &unstructured.Unstructured{}
+	so.SetGroupVersionKind(kedaScaledObjectGVK)
+	so.SetName(name)
+	so.SetNamespace(cr.Namespace)
+	so.SetLabels(lbls)
+	return so
+}
+
+// getScaledObjectSpec returns the ScaledObject spec scaling the given Deployment using the given KEDA options.
+func getScaledObjectSpec(deployment string, keda *argoproj.ArgoCDKEDASpec) map[string]interface{} {
+	triggers := make([]interface{}, 0, len(keda.Triggers))
+	for _, t := range keda.Triggers {
+		metadata := make(map[string]interface{}, len(t.Metadata))
+		for k, v := range t.Metadata {
+			metadata[k] = v
+		}
+		trigger := map[string]interface{}{
+			"type":     t.Type,
+			"metadata": metadata,
+		}
+		if t.Name != "" {
+			trigger["name"] = t.Name
+		}
+		if t.MetricType != "" {
+			trigger["metricType"] = t.MetricType
+		}
+		if t.AuthenticationRef != nil {
+			ref := map[string]interface{}{"name": t.AuthenticationRef.Name}
+			if t.AuthenticationRef.Kind != "" {
+				ref["kind"] = t.AuthenticationRef.Kind
+			}
+			trigger["authenticationRef"] = ref
+		}
+		triggers = append(triggers, trigger)
+	}
+
+	spec := map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"name":       deployment,
+		},
+		"triggers": triggers,
+	}
+	for key, value := range map[string]*int32{
+		"minReplicaCount": keda.MinReplicaCount,
+		"maxReplicaCount": keda.MaxReplicaCount,
+		"pollingInterval": keda.PollingInterval,
+		"cooldownPeriod":  keda.CooldownPeriod,
+	} {
+		if value != nil {
+			spec[key] = int64(*value)
+		}
+	}
+	return spec
+}
+
+// hasScaledObjectSpecChanged will return true if the operator managed fields of the existing ScaledObject spec
+// differ from the desired spec.
+func hasScaledObjectSpecChanged(existing map[string]interface{}, desired map[string]interface{}) bool {
+	for _, key := range kedaManagedSpecKeys {
+		if !reflect.DeepEqual(existing[key], desired[key]) {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileScaledObject will ensure that the KEDA ScaledObject of the given component is present when KEDA
+// autoscaling is requested, and removed otherwise.
+func (r *ReconcileArgoCD) reconcileScaledObject(cr *argoproj.ArgoCD, suffix string, enabled bool, keda *argoproj.ArgoCDKEDASpec) error {
+	if !IsKEDAAPIAvailable() {
+		if enabled {
+			instanceLog(cr).Info(fmt.Sprintf("KEDA API is not available, skipping ScaledObject for %s", nameWithSuffix(suffix, cr)))
+		}
+		return nil
+	}
+
+	existing := newScaledObjectWithSuffix(suffix, cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.GetName(), existing) {
+		if !enabled {
+			// ScaledObject found but KEDA autoscaling disabled, delete it.
+			instanceLog(cr).Info(fmt.Sprintf("deleting ScaledObject %s as KEDA autoscaling is disabled", existing.GetName()))
+			return r.Client.Delete(context.TODO(), existing)
+		}
+
+		desired := getScaledObjectSpec(nameWithSuffix(suffix, cr), keda)
+		spec, _, err := unstructured.NestedMap(existing.Object, "spec")
+		if err != nil {
+			return err
+		}
+		if !hasScaledObjectSpecChanged(spec, desired) {
+			return nil
+		}
+		if spec == nil {
+			spec = map[string]interface{}{}
+		}
+		for _, key := range kedaManagedSpecKeys {
+			if value, ok := desired[key]; ok {
+				spec[key] = value
+			} else {
+				delete(spec, key)
+			}
+		}
+		if err := unstructured.SetNestedMap(existing.Object, spec, "spec"); err != nil {
+			return err
+		}
+		return r.Client.Update(context.TODO(), existing)
+	}
+
+	if !enabled {
+		return nil // KEDA autoscaling not enabled, move along...
+	}
+
+	so := newScaledObjectWithSuffix(suffix, cr)
+	if err := unstructured.SetNestedMap(so.Object, getScaledObjectSpec(nameWithSuffix(suffix, cr), keda), "spec"); err != nil {
+		return err
+	}
+	if err := controllerutil.SetControllerReference(cr, so, r.Scheme); err != nil {
+		return err
+	}
+	instanceLog(cr).Info(fmt.Sprintf("creating ScaledObject %s", so.GetName()))
+	return r.Client.Create(context.TODO(), so)
+}
+
+// reconcileScaledObjects will ensure that the KEDA ScaledObjects of the Argo CD Server and Repo server are
+// present when requested for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileScaledObjects(cr *argoproj.ArgoCD) error {
+	if err := r.reconcileScaledObject(cr, "server", isServerKEDAEnabled(cr), cr.Spec.Server.Autoscale.KEDA); err != nil {
+		return err
+	}
+	return r.reconcileScaledObject(cr, "repo-server", isRepoKEDAEnabled(cr), cr.Spec.Repo.Autoscale.KEDA)
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscaling "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func makeTestKEDASpec(maxReplicas int32) *argoproj.ArgoCDKEDASpec {
+	return &argoproj.ArgoCDKEDASpec{
+		MaxReplicaCount: &maxReplicas,
+		Triggers: []argoproj.ArgoCDKEDATrigger{
+			{
+				Type: "prometheus",
+				Metadata: map[string]string{
+					"serverAddress": "http://prometheus-operated:9090",
+					"query":         "sum(rate(grpc_server_handled_total[2m]))",
+					"threshold":     "100",
+				},
+			},
+		},
+	}
+}
+
+func TestReconcileArgoCD_reconcileScaledObjects(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	kedaAPIFound = true
+	defer func() {
+		kedaAPIFound = false
+	}()
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Autoscale = argoproj.ArgoCDServerAutoscaleSpec{Enabled: true, KEDA: makeTestKEDASpec(5)}
+		a.Spec.Repo.Autoscale = argoproj.ArgoCDRepoAutoscaleSpec{Enabled: true, KEDA: makeTestKEDASpec(3)}
+		a.Spec.Repo.Replicas = &min
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileAutoscalers(a))
+
+	// The ScaledObject replaces the HorizontalPodAutoscaler of the server
+	hpa := &autoscaling.HorizontalPodAutoscaler{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: nameWithSuffix("server", a), Namespace: a.Namespace}, hpa)
+	assert.True(t, errors.IsNotFound(err))
+
+	for suffix, maxReplicas := range map[string]int64{"server": 5, "repo-server": 3} {
+		so := newScaledObjectWithSuffix(suffix, a)
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: so.GetName(), Namespace: a.Namespace}, so))
+
+		target, _, _ := unstructured.NestedString(so.Object, "spec", "scaleTargetRef", "name")
+		assert.Equal(t, nameWithSuffix(suffix, a), target)
+		count, _, _ := unstructured.NestedInt64(so.Object, "spec", "maxReplicaCount")
+		assert.Equal(t, maxReplicas, count)
+		triggers, _, _ := unstructured.NestedSlice(so.Object, "spec", "triggers")
+		assert.Len(t, triggers, 1)
+	}
+
+	// The repo server replicas are left to KEDA
+	assert.Nil(t, getArgoCDRepoServerReplicas(a))
+
+	// Changes to the KEDA options are synced to the ScaledObject
+	a.Spec.Server.Autoscale.KEDA = makeTestKEDASpec(10)
+	assert.NoError(t, r.reconcileAutoscalers(a))
+	so := newScaledObjectWithSuffix("server", a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: so.GetName(), Namespace: a.Namespace}, so))
+	count, _, _ := unstructured.NestedInt64(so.Object, "spec", "maxReplicaCount")
+	assert.Equal(t, int64(10), count)
+
+	// Disabling KEDA removes the ScaledObject and brings back the HorizontalPodAutoscaler
+	a.Spec.Server.Autoscale.KEDA = nil
+	a.Spec.Repo.Autoscale.Enabled = false
+	assert.NoError(t, r.reconcileAutoscalers(a))
+	for _, suffix := range []string{"server", "repo-server"} {
+		so := newScaledObjectWithSuffix(suffix, a)
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: so.GetName(), Namespace: a.Namespace}, so)
+		assert.True(t, errors.IsNotFound(err))
+	}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: nameWithSuffix("server", a), Namespace: a.Namespace}, hpa))
+	assert.Equal(t, &min, getArgoCDRepoServerReplicas(a))
+}
+
+func TestReconcileArgoCD_reconcileScaledObjects_noKEDAAPI(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	kedaAPIFound = false
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Autoscale = argoproj.ArgoCDServerAutoscaleSpec{Enabled: true, KEDA: makeTestKEDASpec(5)}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileAutoscalers(a))
+
+	so := newScaledObjectWithSuffix("server", a)
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: so.GetName(), Namespace: a.Namespace}, so)
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -21,6 +21,7 @@ import (
 	json "encoding/json"
 	"fmt"
 	"os"
+	"reflect"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -32,8 +33,6 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	template "github.com/openshift/api/template/v1"
 	oappsv1client "github.com/openshift/client-go/apps/clientset/versioned/typed/apps/v1"
-	oauthclient "github.com/openshift/client-go/oauth/clientset/versioned/typed/oauth/v1"
-	templatev1client "github.com/openshift/client-go/template/clientset/versioned/typed/template/v1"
 	"gopkg.in/yaml.v2"
 	k8sappsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -44,8 +43,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -53,6 +52,8 @@ import (
 const (
 	// SuccessResonse is returned when a realm is created in keycloak.
 	successResponse = "201 Created"
+	// ConflictResponse is returned when the realm already exists in the database of keycloak.
+	conflictResponse = "409 Conflict"
 	// ExpectedReplicas is used to identify the keycloak running status.
 	expectedReplicas int32 = 1
 	// ServingCertSecretName is a secret that holds the service certificate.
@@ -196,6 +197,21 @@ func getKeycloakResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
 	return resources
 }
 
+// getKeycloakStartupProbe will return the startup probe of the Keycloak container for the given ArgoCD, which allows
+// for ten minutes of startup by default, using the given handler unless overridden.
+func getKeycloakStartupProbe(cr *argoproj.ArgoCD, handler corev1.ProbeHandler, timeoutSeconds int32) *corev1.Probe {
+	var override *corev1.Probe
+	if cr.Spec.SSO != nil && cr.Spec.SSO.Keycloak != nil {
+		override = cr.Spec.SSO.Keycloak.StartupProbe
+	}
+	return getSSOStartupProbe(corev1.Probe{
+		ProbeHandler:     handler,
+		TimeoutSeconds:   timeoutSeconds,
+		PeriodSeconds:    10,
+		FailureThreshold: 60,
+	}, override)
+}
+
 func getKeycloakContainer(cr *argoproj.ArgoCD) corev1.Container {
 	envVars := []corev1.EnvVar{
 		{Name: "SSO_HOSTNAME", Value: "${SSO_HOSTNAME}"},
@@ -250,6 +266,15 @@ func getKeycloakContainer(cr *argoproj.ArgoCD) corev1.Container {
 			},
 		},
 		Resources: getKeycloakResources(cr),
+		StartupProbe: getKeycloakStartupProbe(cr, corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{
+					"/bin/bash",
+					"-c",
+					"/opt/eap/bin/livenessProbe.sh",
+				},
+			},
+		}, 240),
 		VolumeMounts: []corev1.VolumeMount{
 			{
 				MountPath: "/etc/x509/https",
@@ -593,7 +618,7 @@ func getKeycloakContainerEnv() []corev1.EnvVar {
 func newKeycloakDeployment(cr *argoproj.ArgoCD) *k8sappsv1.Deployment {
 
 	var replicas int32 = 1
-	return &k8sappsv1.Deployment{
+	deploy := &k8sappsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      defaultKeycloakIdentifier,
 			Namespace: cr.Namespace,
@@ -622,7 +647,7 @@ func newKeycloakDeployment(cr *argoproj.ArgoCD) *k8sappsv1.Deployment {
 						{
 							Name:  defaultKeycloakIdentifier,
 							Image: getKeycloakContainerImage(cr),
-							Env:   proxyEnvVars(getKeycloakContainerEnv()...),
+							Env:   proxyEnvVars(append(getKeycloakContainerEnv(), getKeycloakDatabaseEnv(cr)...)...),
 							Ports: []corev1.ContainerPort{
 								{Name: "http", ContainerPort: httpPort},
 								{Name: "https", ContainerPort: portTLS},
@@ -635,12 +660,36 @@ func newKeycloakDeployment(cr *argoproj.ArgoCD) *k8sappsv1.Deployment {
 									},
 								},
 							},
+							StartupProbe: getKeycloakStartupProbe(cr, corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{
+									Path: "/auth/realms/master",
+									Port: intstr.FromInt(int(httpPort)),
+								},
+							}, 5),
+							SecurityContext: &corev1.SecurityContext{
+								AllowPrivilegeEscalation: boolPtr(false),
+								Capabilities: &corev1.Capabilities{
+									Drop: []corev1.Capability{
+										"ALL",
+									},
+								},
+								RunAsNonRoot: boolPtr(true),
+							},
 						},
 					},
 				},
 			},
 		},
 	}
+
+	var podSecurityContext *corev1.PodSecurityContext
+	var securityContext *corev1.SecurityContext
+	if cr.Spec.SSO != nil && cr.Spec.SSO.Keycloak != nil {
+		podSecurityContext = cr.Spec.SSO.Keycloak.PodSecurityContext
+		securityContext = cr.Spec.SSO.Keycloak.SecurityContext
+	}
+	applySecurityContext(&deploy.Spec.Template.Spec, podSecurityContext, securityContext, defaultKeycloakIdentifier)
+	return deploy
 }
 
 func (r *ReconcileArgoCD) newKeycloakInstance(cr *argoproj.ArgoCD) error {
@@ -992,7 +1041,7 @@ func (r *ReconcileArgoCD) updateArgoCDConfiguration(cr *argoproj.ArgoCD, kRouteU
 
 	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: argoCDSecret.Name, Namespace: argoCDSecret.Namespace}, argoCDSecret)
 	if err != nil {
-		log.Error(err, fmt.Sprintf("ArgoCD secret not found for ArgoCD %s in namespace %s",
+		instanceLog(cr).Error(err, fmt.Sprintf("ArgoCD secret not found for ArgoCD %s in namespace %s",
 			cr.Name, cr.Namespace))
 		return err
 	}
@@ -1000,7 +1049,7 @@ func (r *ReconcileArgoCD) updateArgoCDConfiguration(cr *argoproj.ArgoCD, kRouteU
 	argoCDSecret.Data["oidc.keycloak.clientSecret"] = []byte(oAuthClientSecret)
 	err = r.Client.Update(context.TODO(), argoCDSecret)
 	if err != nil {
-		log.Error(err, fmt.Sprintf("Error updating ArgoCD Secret for ArgoCD %s in namespace %s",
+		instanceLog(cr).Error(err, fmt.Sprintf("Error updating ArgoCD Secret for ArgoCD %s in namespace %s",
 			cr.Name, cr.Namespace))
 		return err
 	}
@@ -1060,7 +1109,7 @@ func (r *ReconcileArgoCD) updateArgoCDConfiguration(cr *argoproj.ArgoCD, kRouteU
 	argoCDCM := newConfigMapWithName(common.ArgoCDConfigMapName, cr)
 	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: argoCDCM.Name, Namespace: argoCDCM.Namespace}, argoCDCM)
 	if err != nil {
-		log.Error(err, fmt.Sprintf("ArgoCD configmap not found for ArgoCD %s in namespace %s",
+		instanceLog(cr).Error(err, fmt.Sprintf("ArgoCD configmap not found for ArgoCD %s in namespace %s",
 			cr.Name, cr.Namespace))
 
 		return err
@@ -1069,7 +1118,7 @@ func (r *ReconcileArgoCD) updateArgoCDConfiguration(cr *argoproj.ArgoCD, kRouteU
 	argoCDCM.Data[common.ArgoCDKeyOIDCConfig] = string(o)
 	err = r.Client.Update(context.TODO(), argoCDCM)
 	if err != nil {
-		log.Error(err, fmt.Sprintf("Error updating OIDC Configuration for ArgoCD %s in namespace %s",
+		instanceLog(cr).Error(err, fmt.Sprintf("Error updating OIDC Configuration for ArgoCD %s in namespace %s",
 			cr.Name, cr.Namespace))
 		return err
 	}
@@ -1078,7 +1127,7 @@ func (r *ReconcileArgoCD) updateArgoCDConfiguration(cr *argoproj.ArgoCD, kRouteU
 	argoRBACCM := newConfigMapWithName(common.ArgoCDRBACConfigMapName, cr)
 	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: argoRBACCM.Name, Namespace: argoRBACCM.Namespace}, argoRBACCM)
 	if err != nil {
-		log.Error(err, fmt.Sprintf("ArgoCD RBAC configmap not found for ArgoCD %s in namespace %s",
+		instanceLog(cr).Error(err, fmt.Sprintf("ArgoCD RBAC configmap not found for ArgoCD %s in namespace %s",
 			cr.Name, cr.Namespace))
 
 		return err
@@ -1087,7 +1136,7 @@ func (r *ReconcileArgoCD) updateArgoCDConfiguration(cr *argoproj.ArgoCD, kRouteU
 	argoRBACCM.Data["scopes"] = "[groups,email]"
 	err = r.Client.Update(context.TODO(), argoRBACCM)
 	if err != nil {
-		log.Error(err, fmt.Sprintf("Error updating ArgoCD RBAC configmap %s in namespace %s",
+		instanceLog(cr).Error(err, fmt.Sprintf("Error updating ArgoCD RBAC configmap %s in namespace %s",
 			cr.Name, cr.Namespace))
 		return err
 	}
@@ -1144,16 +1193,16 @@ func (r *ReconcileArgoCD) reconcileKeycloakConfiguration(cr *argoproj.ArgoCD) er
 	return nil
 }
 
-func deleteKeycloakConfiguration(cr *argoproj.ArgoCD) error {
+func (r *ReconcileArgoCD) deleteKeycloakConfiguration(cr *argoproj.ArgoCD) error {
 
 	// If SSO is installed using OpenShift templates.
 	if CanUseKeycloakWithTemplate() {
-		err := deleteKeycloakConfigForOpenShift(cr)
+		err := r.deleteKeycloakConfigForOpenShift(cr)
 		if err != nil {
 			return err
 		}
 	} else {
-		err := deleteKeycloakConfigForK8s(cr)
+		err := r.deleteKeycloakConfigForK8s(cr)
 		if err != nil {
 			return err
 		}
@@ -1163,36 +1212,22 @@ func deleteKeycloakConfiguration(cr *argoproj.ArgoCD) error {
 }
 
 // Delete Keycloak configuration for OpenShift
-func deleteKeycloakConfigForOpenShift(cr *argoproj.ArgoCD) error {
-	cfg, err := config.GetConfig()
-	if err != nil {
-		log.Error(err, fmt.Sprintf("unable to get k8s config for ArgoCD %s in namespace %s",
-			cr.Name, cr.Namespace))
-		return err
-	}
-
-	// Initialize template client.
-	templateclient, err := templatev1client.NewForConfig(cfg)
-	if err != nil {
-		log.Error(err, fmt.Sprintf("unable to create Template client for ArgoCD %s in namespace %s",
-			cr.Name, cr.Namespace))
-		return err
-	}
-
-	log.Info(fmt.Sprintf("Delete Template Instance for ArgoCD %s in namespace %s",
+func (r *ReconcileArgoCD) deleteKeycloakConfigForOpenShift(cr *argoproj.ArgoCD) error {
+	instanceLog(cr).Info(fmt.Sprintf("Delete Template Instance for ArgoCD %s in namespace %s",
 		cr.Name, cr.Namespace))
 
 	// We use the foreground propagation policy to ensure that the garbage
 	// collector removes all instantiated objects before the TemplateInstance
 	// itself disappears.
-	foreground := metav1.DeletePropagationForeground
-	deleteOptions := metav1.DeleteOptions{PropagationPolicy: &foreground}
-	err = templateclient.TemplateInstances(cr.Namespace).Delete(context.TODO(), defaultTemplateIdentifier, deleteOptions)
+	templateInstance := &template.TemplateInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: defaultTemplateIdentifier, Namespace: cr.Namespace},
+	}
+	err := r.Client.Delete(context.TODO(), templateInstance, client.PropagationPolicy(metav1.DeletePropagationForeground))
 	if err != nil {
 		return err
 	}
 
-	err = deleteOAuthClient(cr)
+	err = r.deleteOAuthClient(cr)
 	if err != nil {
 		return err
 	}
@@ -1201,88 +1236,59 @@ func deleteKeycloakConfigForOpenShift(cr *argoproj.ArgoCD) error {
 }
 
 // Delete OpenShift OAuthClient
-func deleteOAuthClient(cr *argoproj.ArgoCD) error {
-
-	cfg, err := config.GetConfig()
-	if err != nil {
-		log.Error(err, fmt.Sprintf("unable to get k8s config for ArgoCD %s in namespace %s",
-			cr.Name, cr.Namespace))
-		return err
-	}
-
-	// We use the foreground propagation policy to ensure that the garbage
-	// collector removes all instantiated objects before the TemplateInstance
-	// itself disappears.
-	foreground := metav1.DeletePropagationForeground
-	deleteOptions := metav1.DeleteOptions{PropagationPolicy: &foreground}
+func (r *ReconcileArgoCD) deleteOAuthClient(cr *argoproj.ArgoCD) error {
+	instanceLog(cr).Info(fmt.Sprintf("Delete OAuthClient for ArgoCD %s in namespace %s",
+		cr.Name, cr.Namespace))
 
 	// Delete OAuthClient created for keycloak.
-	oauth, err := oauthclient.NewForConfig(cfg)
-	if err != nil {
-		log.Error(err, fmt.Sprintf("unable to create oAuth client for ArgoCD %s in namespace %s",
-			cr.Name, cr.Namespace))
+	oAuthClient := &oauthv1.OAuthClient{ObjectMeta: metav1.ObjectMeta{Name: getOAuthClient(cr.Namespace)}}
+	err := r.Client.Delete(context.TODO(), oAuthClient, client.PropagationPolicy(metav1.DeletePropagationForeground))
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
-	log.Info(fmt.Sprintf("Delete OAuthClient for ArgoCD %s in namespace %s",
-		cr.Name, cr.Namespace))
-
-	oa := getOAuthClient(cr.Namespace)
-
-	// TODO: Remove the oauth.OAuthClients().Get and proceed with delete once the issue is resolved.
-	// OAuthClient configuration does not get deleted from previous instances occasionally.
-	// It is safe to verify if OAuthClient exists and perform delete.
-	// https://github.com/openshift/client-go/issues/209
-	_, err = oauth.OAuthClients().Get(context.TODO(), oa, metav1.GetOptions{})
-	if err == nil {
-		err = oauth.OAuthClients().Delete(context.TODO(), oa, deleteOptions)
-		if err != nil {
-			return err
-		}
-	}
 
 	return nil
 }
 
 // Delete Keycloak configuration for Kubernetes
-func deleteKeycloakConfigForK8s(cr *argoproj.ArgoCD) error {
+func (r *ReconcileArgoCD) deleteKeycloakConfigForK8s(cr *argoproj.ArgoCD) error {
+	instanceLog(cr).Info(fmt.Sprintf("Delete Keycloak deployment for ArgoCD %s in namespace %s",
+		cr.Name, cr.Namespace))
 
-	cfg, err := config.GetConfig()
+	// We use the foreground propagation policy to ensure that the garbage
+	// collector removes all instantiated objects before the TemplateInstance
+	// itself disappears.
+	foreground := client.PropagationPolicy(metav1.DeletePropagationForeground)
+	objectMeta := metav1.ObjectMeta{Name: defaultKeycloakIdentifier, Namespace: cr.Namespace}
+	err := r.Client.Delete(context.TODO(), &k8sappsv1.Deployment{ObjectMeta: objectMeta}, foreground)
 	if err != nil {
-		log.Error(err, fmt.Sprintf("unable to get k8s config for ArgoCD %s in namespace %s",
-			cr.Name, cr.Namespace))
 		return err
 	}
 
-	clientset, err := kubernetes.NewForConfig(cfg)
-	if err != nil {
+	// The Secret and the PersistentVolumeClaim of the database managed for Keycloak are kept, so that its data is
+	// not lost.
+	databaseMeta := metav1.ObjectMeta{Name: keycloakPostgresIdentifier, Namespace: cr.Namespace}
+	err = r.Client.Delete(context.TODO(), &k8sappsv1.StatefulSet{ObjectMeta: databaseMeta}, foreground)
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
-
-	log.Info(fmt.Sprintf("Delete Keycloak deployment for ArgoCD %s in namespace %s",
-		cr.Name, cr.Namespace))
-
-	// We use the foreground propagation policy to ensure that the garbage
-	// collector removes all instantiated objects before the TemplateInstance
-	// itself disappears.
-	foreground := metav1.DeletePropagationForeground
-	deleteOptions := metav1.DeleteOptions{PropagationPolicy: &foreground}
-	err = clientset.AppsV1().Deployments(cr.Namespace).Delete(context.TODO(), defaultKeycloakIdentifier, deleteOptions)
-	if err != nil {
+	err = r.Client.Delete(context.TODO(), &corev1.Service{ObjectMeta: databaseMeta}, foreground)
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
-	log.Info(fmt.Sprintf("Delete Keycloak Service for ArgoCD %s in namespace %s",
+	instanceLog(cr).Info(fmt.Sprintf("Delete Keycloak Service for ArgoCD %s in namespace %s",
 		cr.Name, cr.Namespace))
 
-	err = clientset.CoreV1().Services(cr.Namespace).Delete(context.TODO(), defaultKeycloakIdentifier, deleteOptions)
+	err = r.Client.Delete(context.TODO(), &corev1.Service{ObjectMeta: objectMeta}, foreground)
 	if err != nil {
 		return err
 	}
 
-	log.Info(fmt.Sprintf("Delete Keycloak Ingress for ArgoCD %s in namespace %s",
+	instanceLog(cr).Info(fmt.Sprintf("Delete Keycloak Ingress for ArgoCD %s in namespace %s",
 		cr.Name, cr.Namespace))
 
-	err = clientset.ExtensionsV1beta1().Ingresses(cr.Namespace).Delete(context.TODO(), defaultKeycloakIdentifier, deleteOptions)
+	err = r.Client.Delete(context.TODO(), &networkingv1.Ingress{ObjectMeta: objectMeta}, foreground)
 	if err != nil {
 		return err
 	}
@@ -1293,6 +1299,11 @@ func deleteKeycloakConfigForK8s(cr *argoproj.ArgoCD) error {
 // Installs and configures Keycloak for OpenShift
 func (r *ReconcileArgoCD) reconcileKeycloakForOpenShift(cr *argoproj.ArgoCD) error {
 
+	if getKeycloakDatabaseSpec(cr) != nil {
+		instanceLog(cr).Info(fmt.Sprintf(".spec.sso.keycloak.database is not supported when Keycloak is installed from a Template, ignoring it for ArgoCD %s in namespace %s",
+			cr.Name, cr.Namespace))
+	}
+
 	templateInstanceRef, err := newKeycloakTemplateInstance(cr)
 	if err != nil {
 		return err
@@ -1301,7 +1312,7 @@ func (r *ReconcileArgoCD) reconcileKeycloakForOpenShift(cr *argoproj.ArgoCD) err
 		Namespace: templateInstanceRef.Namespace}, &template.TemplateInstance{})
 	if err != nil {
 		if errors.IsNotFound(err) {
-			log.Info(fmt.Sprintf("Template API found, Installing keycloak using openshift templates for ArgoCD %s in namespace %s",
+			instanceLog(cr).Info(fmt.Sprintf("Template API found, Installing keycloak using openshift templates for ArgoCD %s in namespace %s",
 				cr.Name, cr.Namespace))
 
 			if err := controllerutil.SetControllerReference(cr, templateInstanceRef, r.Scheme); err != nil {
@@ -1325,14 +1336,21 @@ func (r *ReconcileArgoCD) reconcileKeycloakForOpenShift(cr *argoproj.ArgoCD) err
 	}
 	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: existingDC.Name, Namespace: existingDC.Namespace}, existingDC)
 	if err != nil {
-		log.Error(err, fmt.Sprintf("Keycloak Deployment not found or being created for ArgoCD %s in namespace %s",
+		instanceLog(cr).Error(err, fmt.Sprintf("Keycloak Deployment not found or being created for ArgoCD %s in namespace %s",
 			cr.Name, cr.Namespace))
 	} else {
-		// Handle Image upgrades
-		desiredImage := getKeycloakContainerImage(cr)
-		if existingDC.Spec.Template.Spec.Containers[0].Image != desiredImage {
-			existingDC.Spec.Template.Spec.Containers[0].Image = desiredImage
-
+		// Handle Image upgrades and startup probe changes
+		changed := false
+		desiredContainer := getKeycloakContainer(cr)
+		if existingDC.Spec.Template.Spec.Containers[0].Image != desiredContainer.Image {
+			existingDC.Spec.Template.Spec.Containers[0].Image = desiredContainer.Image
+			changed = true
+		}
+		if !reflect.DeepEqual(existingDC.Spec.Template.Spec.Containers[0].StartupProbe, desiredContainer.StartupProbe) {
+			existingDC.Spec.Template.Spec.Containers[0].StartupProbe = desiredContainer.StartupProbe
+			changed = true
+		}
+		if changed {
 			err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 				return r.Client.Update(context.TODO(), existingDC)
 			})
@@ -1360,20 +1378,20 @@ func (r *ReconcileArgoCD) reconcileKeycloakForOpenShift(cr *argoproj.ArgoCD) err
 			// Create a keycloak realm and publish.
 			response, err := createRealm(cfg)
 			if err != nil {
-				log.Error(err, fmt.Sprintf("Failed posting keycloak realm configuration for ArgoCD %s in namespace %s",
+				instanceLog(cr).Error(err, fmt.Sprintf("Failed posting keycloak realm configuration for ArgoCD %s in namespace %s",
 					cr.Name, cr.Namespace))
 				return err
 			}
 
 			if response == successResponse {
-				log.Info(fmt.Sprintf("Successfully created keycloak realm for ArgoCD %s in namespace %s",
+				instanceLog(cr).Info(fmt.Sprintf("Successfully created keycloak realm for ArgoCD %s in namespace %s",
 					cr.Name, cr.Namespace))
 
 				// TODO: Remove the deleteOAuthClient invocation once the issue is resolved.
 				// OAuthClient configuration does not get deleted from previous instances occasionally.
 				// It is safe to delete before updating the OIDC config.
 				// https://github.com/openshift/client-go/issues/209
-				err = deleteOAuthClient(cr)
+				err = r.deleteOAuthClient(cr)
 				if err != nil {
 					return err
 				}
@@ -1400,7 +1418,7 @@ func (r *ReconcileArgoCD) reconcileKeycloakForOpenShift(cr *argoproj.ArgoCD) err
 		// or when user requests to update the OIDC configuration through `.spec.sso.keycloak.rootCA`.
 		err = r.updateArgoCDConfiguration(cr, keycloakRouteURL)
 		if err != nil {
-			log.Error(err, fmt.Sprintf("Failed to update OIDC Configuration for ArgoCD %s in namespace %s",
+			instanceLog(cr).Error(err, fmt.Sprintf("Failed to update OIDC Configuration for ArgoCD %s in namespace %s",
 				cr.Name, cr.Namespace))
 			return err
 		}
@@ -1412,9 +1430,16 @@ func (r *ReconcileArgoCD) reconcileKeycloakForOpenShift(cr *argoproj.ArgoCD) err
 // Installs and configures Keycloak for Kubernetes
 func (r *ReconcileArgoCD) reconcileKeycloak(cr *argoproj.ArgoCD) error {
 
-	err := r.newKeycloakInstance(cr)
+	err := r.reconcileKeycloakPostgres(cr)
+	if err != nil {
+		instanceLog(cr).Error(err, fmt.Sprintf("Failed reconciling the keycloak database for ArgoCD %s in Namespace %s",
+			cr.Name, cr.Namespace))
+		return err
+	}
+
+	err = r.newKeycloakInstance(cr)
 	if err != nil {
-		log.Error(err, fmt.Sprintf("Failed creating keycloak instance for ArgoCD %s in Namespace %s",
+		instanceLog(cr).Error(err, fmt.Sprintf("Failed creating keycloak instance for ArgoCD %s in Namespace %s",
 			cr.Name, cr.Namespace))
 		return err
 	}
@@ -1428,14 +1453,30 @@ func (r *ReconcileArgoCD) reconcileKeycloak(cr *argoproj.ArgoCD) error {
 
 	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: existingDeployment.Name, Namespace: existingDeployment.Namespace}, existingDeployment)
 	if err != nil {
-		log.Error(err, fmt.Sprintf("Keycloak Deployment not found or being created for ArgoCD %s in namespace %s",
+		instanceLog(cr).Error(err, fmt.Sprintf("Keycloak Deployment not found or being created for ArgoCD %s in namespace %s",
 			cr.Name, cr.Namespace))
 	} else {
-		// Handle Image upgrades
-		desiredImage := getKeycloakContainerImage(cr)
-		if existingDeployment.Spec.Template.Spec.Containers[0].Image != desiredImage {
-			existingDeployment.Spec.Template.Spec.Containers[0].Image = desiredImage
-
+		// Handle Image upgrades, database, startup probe and security context changes
+		desired := newKeycloakDeployment(cr)
+		desiredContainer := desired.Spec.Template.Spec.Containers[0]
+		existingContainer := &existingDeployment.Spec.Template.Spec.Containers[0]
+		changed := false
+		if existingContainer.Image != desiredContainer.Image {
+			existingContainer.Image = desiredContainer.Image
+			changed = true
+		}
+		if !reflect.DeepEqual(existingContainer.Env, desiredContainer.Env) {
+			existingContainer.Env = desiredContainer.Env
+			// The realm is created again in the new database
+			existingDeployment.Annotations["argocd.argoproj.io/realm-created"] = "false"
+			changed = true
+		}
+		if !reflect.DeepEqual(existingContainer.StartupProbe, desiredContainer.StartupProbe) {
+			existingContainer.StartupProbe = desiredContainer.StartupProbe
+			changed = true
+		}
+		updateSecurityContext(&existingDeployment.Spec.Template.Spec, &desired.Spec.Template.Spec, &changed)
+		if changed {
 			err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 				return r.Client.Update(context.TODO(), existingDeployment)
 			})
@@ -1462,13 +1503,15 @@ func (r *ReconcileArgoCD) reconcileKeycloak(cr *argoproj.ArgoCD) error {
 			// Create a keycloak realm and publish.
 			response, err := createRealm(cfg)
 			if err != nil {
-				log.Error(err, fmt.Sprintf("Failed posting keycloak realm configuration for ArgoCD %s in namespace %s",
+				instanceLog(cr).Error(err, fmt.Sprintf("Failed posting keycloak realm configuration for ArgoCD %s in namespace %s",
 					cr.Name, cr.Namespace))
 				return err
 			}
 
-			if response == successResponse {
-				log.Info("Successfully created keycloak realm for ArgoCD %s in namespace %s")
+			// The realm already exists when it is kept in the database of keycloak
+			if response == successResponse || response == conflictResponse {
+				instanceLog(cr).Info(fmt.Sprintf("Successfully created keycloak realm for ArgoCD %s in namespace %s",
+					cr.Name, cr.Namespace))
 
 				// Update Realm creation. This will avoid posting of realm configuration on further reconciliations.
 				existingDeployment.Annotations["argocd.argoproj.io/realm-created"] = "true"
@@ -1483,7 +1526,7 @@ func (r *ReconcileArgoCD) reconcileKeycloak(cr *argoproj.ArgoCD) error {
 		// or when user requests to update the OIDC configuration through `.spec.sso.keycloak.rootCA`.
 		err = r.updateArgoCDConfiguration(cr, kIngURL)
 		if err != nil {
-			log.Error(err, fmt.Sprintf("Failed to update OIDC Configuration for ArgoCD %s in namespace %s",
+			instanceLog(cr).Error(err, fmt.Sprintf("Failed to update OIDC Configuration for ArgoCD %s in namespace %s",
 				cr.Name, cr.Namespace))
 			return err
 		}
@@ -0,0 +1,387 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// Identifier for the PostgreSQL database managed for Keycloak.
+	keycloakPostgresIdentifier = "keycloak-postgresql"
+	// Default name of the database of Keycloak.
+	keycloakDatabaseName = "keycloak"
+	// Default port of the database of Keycloak.
+	keycloakPostgresPort int32 = 5432
+	// Name of the volume holding the data of the PostgreSQL database managed for Keycloak.
+	keycloakPostgresVolumeName = "data"
+	// User and group of the postgres user of the alpine PostgreSQL image, owning the data of the database.
+	keycloakPostgresUser int64 = 70
+)
+
+// getKeycloakDatabaseSpec will return the database options of Keycloak, nil if Keycloak uses its embedded database.
+func getKeycloakDatabaseSpec(cr *argoproj.ArgoCD) *argoproj.ArgoCDKeycloakDatabaseSpec {
+	if cr.Spec.SSO == nil || cr.Spec.SSO.Keycloak == nil {
+		return nil
+	}
+	return cr.Spec.SSO.Keycloak.Database
+}
+
+// isKeycloakManagedPostgresEnabled returns true if a PostgreSQL database is deployed for Keycloak.
+func isKeycloakManagedPostgresEnabled(cr *argoproj.ArgoCD) bool {
+	db := getKeycloakDatabaseSpec(cr)
+	return db != nil && db.ManagedPostgres != nil
+}
+
+// validateKeycloakDatabase will return an error if the database options of Keycloak are not consistent.
+func validateKeycloakDatabase(cr *argoproj.ArgoCD) error {
+	db := getKeycloakDatabaseSpec(cr)
+	if db == nil {
+		return nil
+	}
+	if db.External != nil && db.ManagedPostgres != nil {
+		return errors.New("cannot supply both external and managedPostgres in .spec.sso.keycloak.database")
+	}
+	if db.External == nil && db.ManagedPostgres == nil {
+		return errors.New("must supply external or managedPostgres in .spec.sso.keycloak.database")
+	}
+	if db.External != nil && (db.External.Host == "" || db.External.SecretRef.Name == "") {
+		return errors.New("must supply a host and a secretRef in .spec.sso.keycloak.database.external")
+	}
+	return nil
+}
+
+// getKeycloakDatabaseEnv will return the environment pointing the Keycloak container at its database, nil if
+// Keycloak uses its embedded database.
+func getKeycloakDatabaseEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
+	db := getKeycloakDatabaseSpec(cr)
+	if db == nil || validateKeycloakDatabase(cr) != nil {
+		return nil
+	}
+
+	host := fmt.Sprintf("%s.%s.svc.cluster.local", keycloakPostgresIdentifier, cr.Namespace)
+	port := keycloakPostgresPort
+	database := keycloakDatabaseName
+	secretName := keycloakPostgresIdentifier
+	if db.External != nil {
+		host = db.External.Host
+		if db.External.Port > 0 {
+			port = db.External.Port
+		}
+		if db.External.Database != "" {
+			database = db.External.Database
+		}
+		secretName = db.External.SecretRef.Name
+	}
+
+	secretKeyRef := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		}
+	}
+	return []corev1.EnvVar{
+		{Name: "DB_VENDOR", Value: "postgres"},
+		{Name: "DB_ADDR", Value: host},
+		{Name: "DB_PORT", Value: strconv.Itoa(int(port))},
+		{Name: "DB_DATABASE", Value: database},
+		{Name: "DB_USER", ValueFrom: secretKeyRef("username")},
+		{Name: "DB_PASSWORD", ValueFrom: secretKeyRef("password")},
+	}
+}
+
+// newKeycloakPostgresSecret will return the Secret holding the credentials of the PostgreSQL database managed for
+// Keycloak, with a random password.
+func newKeycloakPostgresSecret(cr *argoproj.ArgoCD) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      keycloakPostgresIdentifier,
+			Namespace: cr.Namespace,
+			Labels:    map[string]string{"app": keycloakPostgresIdentifier},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"username": []byte(keycloakDatabaseName),
+			"password": []byte(generateRandomString(24)),
+		},
+	}
+}
+
+// newKeycloakPostgresService will return the Service of the PostgreSQL database managed for Keycloak.
+func newKeycloakPostgresService(cr *argoproj.ArgoCD) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      keycloakPostgresIdentifier,
+			Namespace: cr.Namespace,
+			Labels:    map[string]string{"app": keycloakPostgresIdentifier},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "postgresql", Port: keycloakPostgresPort, TargetPort: intstr.FromInt(int(keycloakPostgresPort))},
+			},
+			Selector: map[string]string{"app": keycloakPostgresIdentifier},
+		},
+	}
+}
+
+// newKeycloakPostgresStatefulSet will return the StatefulSet of the PostgreSQL database managed for Keycloak, which
+// keeps its data on a PersistentVolumeClaim.
+func newKeycloakPostgresStatefulSet(cr *argoproj.ArgoCD) *appsv1.StatefulSet {
+	opts := getKeycloakDatabaseSpec(cr).ManagedPostgres
+
+	size := resource.MustParse(common.ArgoCDKeycloakPostgresCapacity)
+	if opts.Size != nil {
+		size = *opts.Size
+	}
+
+	secretKeyRef := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: keycloakPostgresIdentifier},
+				Key:                  key,
+			},
+		}
+	}
+
+	var replicas int32 = 1
+	volumeMode := corev1.PersistentVolumeFilesystem
+	ss := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      keycloakPostgresIdentifier,
+			Namespace: cr.Namespace,
+			Labels:    map[string]string{"app": keycloakPostgresIdentifier},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &replicas,
+			ServiceName: keycloakPostgresIdentifier,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": keycloakPostgresIdentifier},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": keycloakPostgresIdentifier},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "postgresql",
+						Image: argoutil.CombineImageTag(common.ArgoCDKeycloakPostgresImage, common.ArgoCDKeycloakPostgresVersion),
+						Env: []corev1.EnvVar{
+							{Name: "POSTGRES_DB", Value: keycloakDatabaseName},
+							{Name: "POSTGRES_USER", ValueFrom: secretKeyRef("username")},
+							{Name: "POSTGRES_PASSWORD", ValueFrom: secretKeyRef("password")},
+							{Name: "PGDATA", Value: "/var/lib/postgresql/data/pgdata"},
+						},
+						Ports: []corev1.ContainerPort{
+							{Name: "postgresql", ContainerPort: keycloakPostgresPort},
+						},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								Exec: &corev1.ExecAction{
+									Command: []string{"sh", "-c", "pg_isready -U \"$POSTGRES_USER\" -d \"$POSTGRES_DB\""},
+								},
+							},
+						},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: boolPtr(false),
+							Capabilities: &corev1.Capabilities{
+								Drop: []corev1.Capability{
+									"ALL",
+								},
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: keycloakPostgresVolumeName, MountPath: "/var/lib/postgresql/data"},
+						},
+					}},
+					// The image runs the database as the postgres user, the volume is made writable to its group
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: boolPtr(true),
+						RunAsUser:    int64Ptr(keycloakPostgresUser),
+						RunAsGroup:   int64Ptr(keycloakPostgresUser),
+						FSGroup:      int64Ptr(keycloakPostgresUser),
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					NodeSelector: common.DefaultNodeSelector(),
+				},
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   keycloakPostgresVolumeName,
+					Labels: map[string]string{"app": keycloakPostgresIdentifier},
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					StorageClassName: opts.StorageClass,
+					VolumeMode:       &volumeMode,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: size,
+						},
+					},
+				},
+			}},
+		},
+	}
+
+	if cr.Spec.NodePlacement != nil {
+		ss.Spec.Template.Spec.NodeSelector = argoutil.AppendStringMap(ss.Spec.Template.Spec.NodeSelector, cr.Spec.NodePlacement.NodeSelector)
+		ss.Spec.Template.Spec.Tolerations = cr.Spec.NodePlacement.Tolerations
+	}
+	return ss
+}
+
+// reconcileKeycloakPostgres will deploy the PostgreSQL database of Keycloak when it is managed by the operator, and
+// remove it otherwise. The PersistentVolumeClaim of the database is kept on removal, so that the data is not lost.
+func (r *ReconcileArgoCD) reconcileKeycloakPostgres(cr *argoproj.ArgoCD) error {
+	if !isKeycloakManagedPostgresEnabled(cr) {
+		for _, obj := range []client.Object{newKeycloakPostgresService(cr), &appsv1.StatefulSet{}} {
+			if err := argoutil.FetchObject(r.Client, cr.Namespace, keycloakPostgresIdentifier, obj); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return err
+			}
+			if err := r.Client.Delete(context.TODO(), obj); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		if meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionTypeKeycloakDatabaseStorageDrifted) != nil {
+			meta.RemoveStatusCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionTypeKeycloakDatabaseStorageDrifted)
+			return r.Client.Status().Update(context.TODO(), cr)
+		}
+		return nil
+	}
+
+	for _, obj := range []client.Object{newKeycloakPostgresSecret(cr), newKeycloakPostgresService(cr), newKeycloakPostgresStatefulSet(cr)} {
+		existing := obj.DeepCopyObject().(client.Object)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, obj.GetName(), existing) {
+			// The credentials and the volume of the database are kept once created, only the security context of
+			// the database follows the operator
+			ss, ok := existing.(*appsv1.StatefulSet)
+			if !ok {
+				continue
+			}
+			if err := r.reconcileKeycloakPostgresStorage(cr, ss, obj.(*appsv1.StatefulSet)); err != nil {
+				return err
+			}
+			changed := false
+			updateSecurityContext(&ss.Spec.Template.Spec, &obj.(*appsv1.StatefulSet).Spec.Template.Spec, &changed)
+			if changed {
+				if err := r.Client.Update(context.TODO(), ss); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := controllerutil.SetControllerReference(cr, obj, r.Scheme); err != nil {
+			return err
+		}
+		instanceLog(cr).Info(fmt.Sprintf("Creating %T %s for the Keycloak database of ArgoCD %s in namespace %s",
+			obj, obj.GetName(), cr.Name, cr.Namespace))
+		if err := r.Client.Create(context.TODO(), obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getKeycloakPostgresStorageDrift returns the changes of the volume of the given desired StatefulSet of the Keycloak
+// database that cannot be applied to the existing one, as the volume claim templates of a StatefulSet are immutable.
+func getKeycloakPostgresStorageDrift(existing *appsv1.StatefulSet, desired *appsv1.StatefulSet) []string {
+	if len(existing.Spec.VolumeClaimTemplates) == 0 || len(desired.Spec.VolumeClaimTemplates) == 0 {
+		return nil
+	}
+	existingClaim := existing.Spec.VolumeClaimTemplates[0].Spec
+	desiredClaim := desired.Spec.VolumeClaimTemplates[0].Spec
+
+	var drift []string
+	existingSize := existingClaim.Resources.Requests[corev1.ResourceStorage]
+	desiredSize := desiredClaim.Resources.Requests[corev1.ResourceStorage]
+	if existingSize.Cmp(desiredSize) != 0 {
+		drift = append(drift, fmt.Sprintf("size %s (existing %s)", desiredSize.String(), existingSize.String()))
+	}
+
+	existingClass, desiredClass := "[default]", "[default]"
+	if existingClaim.StorageClassName != nil {
+		existingClass = *existingClaim.StorageClassName
+	}
+	if desiredClaim.StorageClassName != nil {
+		desiredClass = *desiredClaim.StorageClassName
+	}
+	if existingClass != desiredClass {
+		drift = append(drift, fmt.Sprintf("storageClass %s (existing %s)", desiredClass, existingClass))
+	}
+	return drift
+}
+
+// reconcileKeycloakPostgresStorage will ensure that the KeycloakDatabaseStorageDrifted condition reports the size and
+// the StorageClass of the Keycloak database that are not applied to the existing StatefulSet. The condition is only
+// added once a drift has been found.
+func (r *ReconcileArgoCD) reconcileKeycloakPostgresStorage(cr *argoproj.ArgoCD, existing *appsv1.StatefulSet, desired *appsv1.StatefulSet) error {
+	drift := getKeycloakPostgresStorageDrift(existing, desired)
+	current := meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionTypeKeycloakDatabaseStorageDrifted)
+	if len(drift) == 0 && current == nil {
+		return nil // Never drifted, no need for the condition
+	}
+
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeKeycloakDatabaseStorageDrifted,
+		Status:             metav1.ConditionFalse,
+		Reason:             argoproj.ArgoCDConditionReasonStorageInSync,
+		Message:            "The volume of the Keycloak database matches .spec.sso.keycloak.database.managedPostgres",
+		ObservedGeneration: cr.Generation,
+	}
+	if len(drift) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = argoproj.ArgoCDConditionReasonStorageChangeIgnored
+		condition.Message = fmt.Sprintf("The %s of .spec.sso.keycloak.database.managedPostgres are not applied to the existing "+
+			"StatefulSet %s, as its volume claim templates are immutable; delete the StatefulSet and migrate the data to apply them",
+			strings.Join(drift, " and "), keycloakPostgresIdentifier)
+	}
+
+	if current != nil && current.Status == condition.Status && current.Reason == condition.Reason && current.Message == condition.Message {
+		return nil // Nothing changed, move along...
+	}
+
+	if len(drift) > 0 {
+		instanceLog(cr).Info(fmt.Sprintf("ArgoCD %s/%s: %s", cr.Namespace, cr.Name, condition.Message))
+		r.recordEvent(cr, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	return r.Client.Status().Update(context.TODO(), cr)
+}
@@ -0,0 +1,210 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func makeTestKeycloakDatabaseArgoCD(db *argoproj.ArgoCDKeycloakDatabaseSpec) *argoproj.ArgoCD {
+	return makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeKeycloak,
+			Keycloak: &argoproj.ArgoCDKeycloakSpec{Database: db},
+		}
+	})
+}
+
+func TestValidateKeycloakDatabase(t *testing.T) {
+	assert.NoError(t, validateKeycloakDatabase(makeTestArgoCD()))
+	assert.NoError(t, validateKeycloakDatabase(makeTestKeycloakDatabaseArgoCD(nil)))
+
+	assert.EqualError(t, validateKeycloakDatabase(makeTestKeycloakDatabaseArgoCD(&argoproj.ArgoCDKeycloakDatabaseSpec{})),
+		"must supply external or managedPostgres in .spec.sso.keycloak.database")
+
+	assert.EqualError(t, validateKeycloakDatabase(makeTestKeycloakDatabaseArgoCD(&argoproj.ArgoCDKeycloakDatabaseSpec{
+		External:        &argoproj.ArgoCDKeycloakExternalDatabaseSpec{Host: "db.example.com"},
+		ManagedPostgres: &argoproj.ArgoCDKeycloakManagedPostgresSpec{},
+	})), "cannot supply both external and managedPostgres in .spec.sso.keycloak.database")
+
+	assert.EqualError(t, validateKeycloakDatabase(makeTestKeycloakDatabaseArgoCD(&argoproj.ArgoCDKeycloakDatabaseSpec{
+		External: &argoproj.ArgoCDKeycloakExternalDatabaseSpec{Host: "db.example.com"},
+	})), "must supply a host and a secretRef in .spec.sso.keycloak.database.external")
+}
+
+func TestGetKeycloakDatabaseEnv(t *testing.T) {
+	assert.Nil(t, getKeycloakDatabaseEnv(makeTestArgoCD()))
+
+	a := makeTestKeycloakDatabaseArgoCD(&argoproj.ArgoCDKeycloakDatabaseSpec{
+		External: &argoproj.ArgoCDKeycloakExternalDatabaseSpec{
+			Host:      "db.example.com",
+			Port:      6432,
+			SecretRef: corev1.LocalObjectReference{Name: "keycloak-db"},
+		},
+	})
+	env := getKeycloakDatabaseEnv(a)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "DB_VENDOR", Value: "postgres"},
+		{Name: "DB_ADDR", Value: "db.example.com"},
+		{Name: "DB_PORT", Value: "6432"},
+		{Name: "DB_DATABASE", Value: "keycloak"},
+	}, env[:4])
+	assert.Equal(t, "keycloak-db", env[4].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "username", env[4].ValueFrom.SecretKeyRef.Key)
+	assert.Equal(t, "password", env[5].ValueFrom.SecretKeyRef.Key)
+
+	a = makeTestKeycloakDatabaseArgoCD(&argoproj.ArgoCDKeycloakDatabaseSpec{
+		ManagedPostgres: &argoproj.ArgoCDKeycloakManagedPostgresSpec{},
+	})
+	env = getKeycloakDatabaseEnv(a)
+	assert.Equal(t, "keycloak-postgresql.argocd.svc.cluster.local", env[1].Value)
+	assert.Equal(t, "5432", env[2].Value)
+	assert.Equal(t, keycloakPostgresIdentifier, env[4].ValueFrom.SecretKeyRef.Name)
+
+	// The Keycloak container connects to the database
+	assert.Subset(t, newKeycloakDeployment(a).Spec.Template.Spec.Containers[0].Env, env)
+}
+
+func TestReconcileArgoCD_reconcileKeycloakPostgres(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	size := resource.MustParse("5Gi")
+	storageClass := "fast"
+	a := makeTestKeycloakDatabaseArgoCD(&argoproj.ArgoCDKeycloakDatabaseSpec{
+		ManagedPostgres: &argoproj.ArgoCDKeycloakManagedPostgresSpec{Size: &size, StorageClass: &storageClass},
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileKeycloakPostgres(a))
+
+	key := types.NamespacedName{Name: keycloakPostgresIdentifier, Namespace: a.Namespace}
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	assert.Equal(t, "keycloak", string(secret.Data["username"]))
+	assert.NotEmpty(t, secret.Data["password"])
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+	claim := ss.Spec.VolumeClaimTemplates[0]
+	assert.Equal(t, "fast", *claim.Spec.StorageClassName)
+	assert.Equal(t, "5Gi", claim.Spec.Resources.Requests.Storage().String())
+	assert.NoError(t, r.Client.Get(context.TODO(), key, &corev1.Service{}))
+
+	// The database runs as non-root, with its volume owned by the postgres group
+	podSecurityContext := ss.Spec.Template.Spec.SecurityContext
+	assert.True(t, *podSecurityContext.RunAsNonRoot)
+	assert.Equal(t, keycloakPostgresUser, *podSecurityContext.RunAsUser)
+	assert.Equal(t, keycloakPostgresUser, *podSecurityContext.FSGroup)
+	assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, podSecurityContext.SeccompProfile.Type)
+	assert.False(t, *ss.Spec.Template.Spec.Containers[0].SecurityContext.AllowPrivilegeEscalation)
+
+	// The security context of an existing database is updated
+	ss.Spec.Template.Spec.SecurityContext = nil
+	assert.NoError(t, r.Client.Update(context.TODO(), ss))
+	assert.NoError(t, r.reconcileKeycloakPostgres(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+	assert.Equal(t, podSecurityContext, ss.Spec.Template.Spec.SecurityContext)
+
+	// The credentials are kept across reconciliations
+	password := secret.Data["password"]
+	assert.NoError(t, r.reconcileKeycloakPostgres(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	assert.Equal(t, password, secret.Data["password"])
+
+	// The database is removed, its credentials are kept
+	a.Spec.SSO.Keycloak.Database = nil
+	assert.NoError(t, r.reconcileKeycloakPostgres(a))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, ss)))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, &corev1.Service{})))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+}
+
+func TestReconcileArgoCD_reconcileKeycloakPostgres_storageDrift(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestKeycloakDatabaseArgoCD(&argoproj.ArgoCDKeycloakDatabaseSpec{
+		ManagedPostgres: &argoproj.ArgoCDKeycloakManagedPostgresSpec{},
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	assert.NoError(t, r.reconcileKeycloakPostgres(a))
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeKeycloakDatabaseStorageDrifted))
+
+	// A new size and StorageClass are not applied to the existing database, the drift is reported
+	size := resource.MustParse("5Gi")
+	storageClass := "fast"
+	a.Spec.SSO.Keycloak.Database.ManagedPostgres.Size = &size
+	a.Spec.SSO.Keycloak.Database.ManagedPostgres.StorageClass = &storageClass
+	assert.NoError(t, r.reconcileKeycloakPostgres(a))
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: keycloakPostgresIdentifier, Namespace: a.Namespace}, ss))
+	assert.Equal(t, "1Gi", ss.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String())
+
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeKeycloakDatabaseStorageDrifted)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonStorageChangeIgnored, condition.Reason)
+	assert.Contains(t, condition.Message, "size 5Gi (existing 1Gi) and storageClass fast (existing [default])")
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, corev1.EventTypeWarning+" StorageChangeIgnored")
+
+	// The drift is reported once
+	assert.NoError(t, r.reconcileKeycloakPostgres(a))
+	assert.Len(t, recorder.Events, 0)
+
+	// Reverting the options clears the condition
+	a.Spec.SSO.Keycloak.Database.ManagedPostgres.Size = nil
+	a.Spec.SSO.Keycloak.Database.ManagedPostgres.StorageClass = nil
+	assert.NoError(t, r.reconcileKeycloakPostgres(a))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeKeycloakDatabaseStorageDrifted)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonStorageInSync, condition.Reason)
+
+	// The condition is removed with the database
+	a.Spec.SSO.Keycloak.Database = nil
+	assert.NoError(t, r.reconcileKeycloakPostgres(a))
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeKeycloakDatabaseStorageDrifted))
+}
@@ -27,6 +27,7 @@ import (
 	resourcev1 "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
@@ -316,6 +317,103 @@ func TestKeycloakResources(t *testing.T) {
 	}
 }
 
+func TestKeycloakStartupProbe(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeKeycloak,
+		}
+	})
+
+	// The default probe allows ten minutes for Keycloak to start
+	probe := newKeycloakDeployment(a).Spec.Template.Spec.Containers[0].StartupProbe
+	assert.Equal(t, "/auth/realms/master", probe.HTTPGet.Path)
+	assert.Equal(t, int32(10), probe.PeriodSeconds)
+	assert.Equal(t, int32(60), probe.FailureThreshold)
+	probe = getKeycloakContainer(a).StartupProbe
+	assert.Equal(t, []string{"/bin/bash", "-c", "/opt/eap/bin/livenessProbe.sh"}, probe.Exec.Command)
+
+	assert.Equal(t, int32(240), probe.TimeoutSeconds)
+
+	// The override keeps the default handler and the fields it does not set
+	a.Spec.SSO.Keycloak = &argoproj.ArgoCDKeycloakSpec{
+		StartupProbe: &corev1.Probe{FailureThreshold: 120},
+	}
+	probe = newKeycloakDeployment(a).Spec.Template.Spec.Containers[0].StartupProbe
+	assert.Equal(t, "/auth/realms/master", probe.HTTPGet.Path)
+	assert.Equal(t, int32(10), probe.PeriodSeconds)
+	assert.Equal(t, int32(120), probe.FailureThreshold)
+	probe = getKeycloakContainer(a).StartupProbe
+	assert.Equal(t, []string{"/bin/bash", "-c", "/opt/eap/bin/livenessProbe.sh"}, probe.Exec.Command)
+	assert.Equal(t, int32(120), probe.FailureThreshold)
+	assert.Equal(t, int32(240), probe.TimeoutSeconds)
+}
+
+func TestGetSSOStartupProbe(t *testing.T) {
+	defaultProbe := corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8080)},
+		},
+		TimeoutSeconds:   240,
+		PeriodSeconds:    10,
+		FailureThreshold: 60,
+	}
+
+	probe := getSSOStartupProbe(defaultProbe, nil)
+	assert.Equal(t, int32(240), probe.TimeoutSeconds)
+	assert.Equal(t, corev1.URISchemeHTTP, probe.HTTPGet.Scheme)
+	assert.Equal(t, int32(1), probe.SuccessThreshold)
+
+	// The fields set override the default ones, the others are kept
+	probe = getSSOStartupProbe(defaultProbe, &corev1.Probe{FailureThreshold: 120, InitialDelaySeconds: 30})
+	assert.Equal(t, "/healthz", probe.HTTPGet.Path)
+	assert.Equal(t, int32(240), probe.TimeoutSeconds)
+	assert.Equal(t, int32(10), probe.PeriodSeconds)
+	assert.Equal(t, int32(120), probe.FailureThreshold)
+	assert.Equal(t, int32(30), probe.InitialDelaySeconds)
+
+	probe = getSSOStartupProbe(defaultProbe, &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"true"}},
+		},
+	})
+	assert.Nil(t, probe.HTTPGet)
+	assert.Equal(t, []string{"true"}, probe.Exec.Command)
+	assert.Equal(t, int32(240), probe.TimeoutSeconds)
+
+	// The default probe is left untouched
+	assert.Equal(t, int32(60), defaultProbe.FailureThreshold)
+	assert.Equal(t, corev1.URIScheme(""), defaultProbe.HTTPGet.Scheme)
+}
+
+func TestKeycloakSecurityContext(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeKeycloak,
+		}
+	})
+
+	// The default security context passes the restricted Pod Security Standard
+	podSpec := newKeycloakDeployment(a).Spec.Template.Spec
+	assert.Equal(t, &corev1.PodSecurityContext{
+		RunAsNonRoot: boolPtr(true),
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}, podSpec.SecurityContext)
+	assert.Equal(t, boolPtr(false), podSpec.Containers[0].SecurityContext.AllowPrivilegeEscalation)
+	assert.Equal(t, []corev1.Capability{"ALL"}, podSpec.Containers[0].SecurityContext.Capabilities.Drop)
+
+	podSecurityContext := &corev1.PodSecurityContext{FSGroup: int64Ptr(1000)}
+	securityContext := &corev1.SecurityContext{RunAsUser: int64Ptr(1000)}
+	a.Spec.SSO.Keycloak = &argoproj.ArgoCDKeycloakSpec{
+		PodSecurityContext: podSecurityContext,
+		SecurityContext:    securityContext,
+	}
+	podSpec = newKeycloakDeployment(a).Spec.Template.Spec
+	assert.Equal(t, podSecurityContext, podSpec.SecurityContext)
+	assert.Equal(t, securityContext, podSpec.Containers[0].SecurityContext)
+}
+
 func TestNewKeycloakTemplate_testConfigmap(t *testing.T) {
 	cm := getKeycloakConfigMapTemplate(fakeNs)
 	assert.Equal(t, cm.Name, "${APPLICATION_NAME}-service-ca")
@@ -0,0 +1,165 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// knownHostsCache holds the default SSH known hosts last fetched from upstream.
+var knownHostsCache = struct {
+	sync.Mutex
+	hosts     string
+	checkedAt time.Time
+}{}
+
+// fetchSSHKnownHosts retrieves the SSH known hosts from the given URL.
+// It is a variable so that it can be replaced in tests.
+var fetchSSHKnownHosts = func(url string) (string, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d fetching SSH known hosts from %s", resp.StatusCode, url)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	return parseSSHKnownHosts(data)
+}
+
+// getSSHKnownHostsURL will return the location of the upstream SSH known hosts.
+func getSSHKnownHostsURL() string {
+	if url := os.Getenv("ARGOCD_SSH_KNOWN_HOSTS_URL"); url != "" {
+		return url
+	}
+	return common.ArgoCDDefaultSSHKnownHostsURL
+}
+
+// parseSSHKnownHosts returns the SSH known hosts from the given data, which is either
+// the upstream SSH known hosts ConfigMap manifest or a plain known hosts file.
+func parseSSHKnownHosts(data []byte) (string, error) {
+	hosts := string(data)
+
+	cm := struct {
+		Data map[string]string `yaml:"data"`
+	}{}
+	if err := yaml.Unmarshal(data, &cm); err == nil {
+		if h, ok := cm.Data[common.ArgoCDKeySSHKnownHosts]; ok {
+			hosts = h
+		}
+	}
+
+	for _, line := range strings.Split(hosts, "\n") {
+		if _, ok := knownHostsEntryKey(line); ok {
+			return hosts, nil
+		}
+	}
+	return "", errors.New("no SSH known hosts entries found")
+}
+
+// knownHostsEntryKey returns the host pattern and key type of the given known hosts line, including the
+// marker if there is one. The second return value is false for comments, empty and malformed lines.
+func knownHostsEntryKey(line string) (string, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return "", false
+	}
+	if strings.HasPrefix(fields[0], "@") {
+		if len(fields) < 4 {
+			return "", false
+		}
+		return strings.Join(fields[:3], " "), true
+	}
+	if len(fields) < 3 {
+		return "", false
+	}
+	return strings.Join(fields[:2], " "), true
+}
+
+// getDefaultSSHKnownHosts will return the default SSH known hosts, refreshed from upstream once the given
+// interval has elapsed since the last attempt. The compiled-in defaults are used until a refresh succeeds. The hosts
+// are fetched without holding the cache, the attempt being recorded beforehand so that a single refresh runs at once.
+func getDefaultSSHKnownHosts(interval time.Duration) string {
+	knownHostsCache.Lock()
+	refresh := time.Since(knownHostsCache.checkedAt) >= interval
+	if refresh {
+		knownHostsCache.checkedAt = time.Now()
+	}
+	knownHostsCache.Unlock()
+
+	if refresh {
+		hosts, err := fetchSSHKnownHosts(getSSHKnownHostsURL())
+		if err != nil {
+			log.Error(err, "failed to refresh the default SSH known hosts, using the last known defaults")
+		} else {
+			knownHostsCache.Lock()
+			knownHostsCache.hosts = hosts
+			knownHostsCache.Unlock()
+		}
+	}
+
+	knownHostsCache.Lock()
+	defer knownHostsCache.Unlock()
+	if knownHostsCache.hosts != "" {
+		return knownHostsCache.hosts
+	}
+	return common.ArgoCDDefaultSSHKnownHosts
+}
+
+// mergeSSHKnownHosts combines the default SSH known hosts with the custom keys using the given strategy.
+func mergeSSHKnownHosts(defaults string, keys string, strategy string) string {
+	if strategy != argoproj.SSHKnownHostsMergeStrategyMerge || keys == "" {
+		return defaults + keys
+	}
+
+	overridden := make(map[string]bool)
+	for _, line := range strings.Split(keys, "\n") {
+		if k, ok := knownHostsEntryKey(line); ok {
+			overridden[k] = true
+		}
+	}
+
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(defaults, "\n"), "\n") {
+		if k, ok := knownHostsEntryKey(line); ok && overridden[k] {
+			continue
+		}
+		if line != "" {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString(keys)
+	return b.String()
+}
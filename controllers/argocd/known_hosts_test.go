@@ -0,0 +1,143 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	testUpstreamKnownHosts = "github.com ssh-ed25519 AAAAupstream\nbitbucket.org ssh-rsa AAAAupstream\n"
+	testCustomKnownHosts   = "github.com ssh-ed25519 AAAAcustom\n"
+)
+
+// stubFetchSSHKnownHosts replaces the upstream fetch for the duration of the test and resets the cache.
+func stubFetchSSHKnownHosts(t *testing.T, hosts string, err error) *int {
+	calls := 0
+	orig := fetchSSHKnownHosts
+	fetchSSHKnownHosts = func(url string) (string, error) {
+		calls++
+		return hosts, err
+	}
+	resetCache := func() {
+		knownHostsCache.hosts = ""
+		knownHostsCache.checkedAt = time.Time{}
+	}
+	resetCache()
+	t.Cleanup(func() {
+		fetchSSHKnownHosts = orig
+		resetCache()
+	})
+	return &calls
+}
+
+func TestParseSSHKnownHosts(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: argocd-ssh-known-hosts-cm
+data:
+  ssh_known_hosts: |
+    github.com ssh-ed25519 AAAAupstream
+    bitbucket.org ssh-rsa AAAAupstream
+`
+	hosts, err := parseSSHKnownHosts([]byte(manifest))
+	assert.NoError(t, err)
+	assert.Equal(t, testUpstreamKnownHosts, hosts)
+
+	hosts, err = parseSSHKnownHosts([]byte(testUpstreamKnownHosts))
+	assert.NoError(t, err)
+	assert.Equal(t, testUpstreamKnownHosts, hosts)
+
+	_, err = parseSSHKnownHosts([]byte("<html>not found</html>"))
+	assert.Error(t, err)
+}
+
+func TestMergeSSHKnownHosts(t *testing.T) {
+	assert.Equal(t, testUpstreamKnownHosts+testCustomKnownHosts,
+		mergeSSHKnownHosts(testUpstreamKnownHosts, testCustomKnownHosts, ""))
+	assert.Equal(t, testUpstreamKnownHosts+testCustomKnownHosts,
+		mergeSSHKnownHosts(testUpstreamKnownHosts, testCustomKnownHosts, argoproj.SSHKnownHostsMergeStrategyAppend))
+	assert.Equal(t, "bitbucket.org ssh-rsa AAAAupstream\n"+testCustomKnownHosts,
+		mergeSSHKnownHosts(testUpstreamKnownHosts, testCustomKnownHosts, argoproj.SSHKnownHostsMergeStrategyMerge))
+	assert.Equal(t, testUpstreamKnownHosts,
+		mergeSSHKnownHosts(testUpstreamKnownHosts, "", argoproj.SSHKnownHostsMergeStrategyMerge))
+}
+
+func TestGetDefaultSSHKnownHosts(t *testing.T) {
+	t.Run("upstream known hosts are cached for the refresh interval", func(t *testing.T) {
+		calls := stubFetchSSHKnownHosts(t, testUpstreamKnownHosts, nil)
+
+		assert.Equal(t, testUpstreamKnownHosts, getDefaultSSHKnownHosts(time.Hour))
+		assert.Equal(t, testUpstreamKnownHosts, getDefaultSSHKnownHosts(time.Hour))
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("compiled-in defaults are used when the refresh fails", func(t *testing.T) {
+		stubFetchSSHKnownHosts(t, "", errors.New("connection refused"))
+
+		assert.Equal(t, common.ArgoCDDefaultSSHKnownHosts, getDefaultSSHKnownHosts(time.Hour))
+	})
+}
+
+func TestReconcileArgoCD_reconcileSSHKnownHosts_withRefreshInterval(t *testing.T) {
+	stubFetchSSHKnownHosts(t, testUpstreamKnownHosts, nil)
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.InitialSSHKnownHosts = argoproj.SSHHostsSpec{
+			Keys:            testCustomKnownHosts,
+			MergeStrategy:   argoproj.SSHKnownHostsMergeStrategyMerge,
+			RefreshInterval: &metav1.Duration{Duration: time.Hour},
+		}
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileSSHKnownHosts(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDKnownHostsConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, "bitbucket.org ssh-rsa AAAAupstream\n"+testCustomKnownHosts, cm.Data[common.ArgoCDKeySSHKnownHosts])
+
+	// Changes to the custom keys are synced to the existing ConfigMap
+	a.Spec.InitialSSHKnownHosts.MergeStrategy = argoproj.SSHKnownHostsMergeStrategyAppend
+	assert.NoError(t, r.reconcileSSHKnownHosts(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDKnownHostsConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, testUpstreamKnownHosts+testCustomKnownHosts, cm.Data[common.ArgoCDKeySSHKnownHosts])
+
+	// Without a refresh interval the existing ConfigMap is left alone
+	a.Spec.InitialSSHKnownHosts.RefreshInterval = nil
+	a.Spec.InitialSSHKnownHosts.Keys = ""
+	assert.NoError(t, r.reconcileSSHKnownHosts(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDKnownHostsConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, testUpstreamKnownHosts+testCustomKnownHosts, cm.Data[common.ArgoCDKeySSHKnownHosts])
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// applyLabelPolicy will set the additional labels and the managed-by label of the label policy of the given ArgoCD
+// on the given object, adding the managed-by label when missing, and remove the additional labels no longer part of
+// the policy. It returns true if the object was changed.
+func applyLabelPolicy(cr *argoproj.ArgoCD, obj client.Object) bool {
+	labels, annotations := obj.GetLabels(), obj.GetAnnotations()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	changed := false
+
+	if labels[common.ArgoCDKeyManagedBy] != argoutil.ManagedByLabelValue(cr) {
+		labels[common.ArgoCDKeyManagedBy] = argoutil.ManagedByLabelValue(cr)
+		changed = true
+	}
+
+	additional := argoutil.AdditionalLabelsForCluster(cr)
+	if previous := annotations[common.ArgoCDAdditionalLabelsAnnotation]; previous != "" {
+		for _, key := range strings.Split(previous, ",") {
+			if _, ok := additional[key]; !ok {
+				delete(labels, key)
+				changed = true
+			}
+		}
+	}
+	keys := make([]string, 0, len(additional))
+	for key, val := range additional {
+		keys = append(keys, key)
+		if labels[key] != val {
+			labels[key] = val
+			changed = true
+		}
+	}
+	sort.Strings(keys)
+
+	if len(keys) > 0 {
+		if annotations[common.ArgoCDAdditionalLabelsAnnotation] != strings.Join(keys, ",") {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[common.ArgoCDAdditionalLabelsAnnotation] = strings.Join(keys, ",")
+			changed = true
+		}
+	} else if _, ok := annotations[common.ArgoCDAdditionalLabelsAnnotation]; ok {
+		delete(annotations, common.ArgoCDAdditionalLabelsAnnotation)
+		changed = true
+	}
+
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+	return changed
+}
+
+// reconcileLabelPolicy will ensure that the existing resources of the given ArgoCD carry the labels of its label
+// policy, which are otherwise only set on the resources created after the policy changed. The namespaced resources
+// are found through their controller reference, and the cluster-scoped ones through the annotations naming their
+// instance, as their managed-by label may be the one being changed.
+func (r *ReconcileArgoCD) reconcileLabelPolicy(cr *argoproj.ArgoCD) error {
+	for _, list := range newBackupLabeledLists() {
+		if err := r.applyLabelPolicyToList(cr, list, metav1.IsControlledBy, client.InNamespace(cr.Namespace)); err != nil {
+			return err
+		}
+	}
+	for _, list := range []client.ObjectList{&rbacv1.ClusterRoleList{}, &rbacv1.ClusterRoleBindingList{}} {
+		if err := r.applyLabelPolicyToList(cr, list, isClusterResourceCreatedForInstance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyLabelPolicyToList will apply the label policy of the given ArgoCD to the resources of the given list which
+// belong to the instance according to the given function.
+func (r *ReconcileArgoCD) applyLabelPolicyToList(cr *argoproj.ArgoCD, list client.ObjectList, belongs func(metav1.Object, metav1.Object) bool, opts ...client.ListOption) error {
+	opts = append(opts, client.MatchingLabels{common.ArgoCDKeyPartOf: common.ArgoCDAppName})
+	if err := r.Client.List(context.TODO(), list, opts...); err != nil {
+		return fmt.Errorf("failed to list the resources of ArgoCD %s/%s to apply its label policy: %w", cr.Namespace, cr.Name, err)
+	}
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	for _, o := range objs {
+		obj, ok := o.(client.Object)
+		if !ok || !belongs(obj, cr) || !applyLabelPolicy(cr, obj) {
+			continue
+		}
+		if err := r.Client.Update(context.TODO(), obj); err != nil {
+			return fmt.Errorf("failed to update the labels of %T %s: %w", obj, client.ObjectKeyFromObject(obj), err)
+		}
+	}
+	return nil
+}
+
+// isClusterResourceCreatedForInstance returns true if the given cluster-scoped object is annotated as created for the
+// given ArgoCD.
+func isClusterResourceCreatedForInstance(obj metav1.Object, cr metav1.Object) bool {
+	annotations := obj.GetAnnotations()
+	return annotations[common.AnnotationName] == cr.GetName() && annotations[common.AnnotationNamespace] == cr.GetNamespace()
+}
+
+// getLegacyAggregationManagedBy will return the name of the given ArgoCD when ClusterRoles aggregated into its
+// ClusterRoles still carry it as their managed-by label, while its label policy sets another value. The aggregation
+// selectors keep matching the name of the instance until these ClusterRoles are migrated to the new value.
+func (r *ReconcileArgoCD) getLegacyAggregationManagedBy(cr *argoproj.ArgoCD) (string, error) {
+	if argoutil.ManagedByLabelValue(cr) == cr.Name {
+		return "", nil
+	}
+	for _, key := range []string{common.ArgoCDAggregateToControllerLabelKey, common.ArgoCDAggregateToAdminLabelKey} {
+		list := &rbacv1.ClusterRoleList{}
+		if err := r.Client.List(context.TODO(), list, client.MatchingLabels{key: "true", common.ArgoCDKeyManagedBy: cr.Name}); err != nil {
+			return "", err
+		}
+		if len(list.Items) > 0 {
+			return cr.Name, nil
+		}
+	}
+	return "", nil
+}
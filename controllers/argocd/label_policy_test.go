@@ -0,0 +1,185 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileArgoCD_reconcileLabelPolicy(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD()
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRBAC(a))
+
+	a.Spec.LabelPolicy = &argoproj.ArgoCDLabelPolicySpec{
+		AdditionalLabels: map[string]string{"team": "platform", "env": "prod"},
+		ManagedBy:        "gitops",
+	}
+	assert.NoError(t, r.reconcileLabelPolicy(a))
+
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: common.ArgoCDRBACConfigMapName, Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Equal(t, "platform", cm.Labels["team"])
+	assert.Equal(t, "prod", cm.Labels["env"])
+	assert.Equal(t, "gitops", cm.Labels[common.ArgoCDKeyManagedBy])
+	assert.Equal(t, "env,team", cm.Annotations[common.ArgoCDAdditionalLabelsAnnotation])
+
+	// Resources created with the policy are labeled from the start
+	assert.Equal(t, "platform", newConfigMapWithName("example", a).Labels["team"])
+	assert.Equal(t, "gitops", newConfigMapWithName("example", a).Labels[common.ArgoCDKeyManagedBy])
+
+	// The labels removed from the policy are removed from the resources
+	a.Spec.LabelPolicy = &argoproj.ArgoCDLabelPolicySpec{AdditionalLabels: map[string]string{"team": "platform"}}
+	assert.NoError(t, r.reconcileLabelPolicy(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Equal(t, "platform", cm.Labels["team"])
+	assert.NotContains(t, cm.Labels, "env")
+	assert.Equal(t, a.Name, cm.Labels[common.ArgoCDKeyManagedBy])
+	assert.Equal(t, "team", cm.Annotations[common.ArgoCDAdditionalLabelsAnnotation])
+
+	a.Spec.LabelPolicy = nil
+	assert.NoError(t, r.reconcileLabelPolicy(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.NotContains(t, cm.Labels, "team")
+	assert.NotContains(t, cm.Annotations, common.ArgoCDAdditionalLabelsAnnotation)
+}
+
+func TestReconcileArgoCD_reconcileLabelPolicy_clusterResources(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD()
+	clusterRole := newClusterRole(common.ArgoCDApplicationControllerComponent, nil, a)
+	clusterRoleBinding := newClusterRoleBinding(a)
+	// Created before the managed-by label was set on cluster-scoped resources
+	delete(clusterRoleBinding.Labels, common.ArgoCDKeyManagedBy)
+	other := newClusterRole("other", nil, a)
+	other.Annotations = map[string]string{common.AnnotationName: a.Name, common.AnnotationNamespace: "other"}
+
+	resObjs := []client.Object{a, clusterRole, clusterRoleBinding, other}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	a.Spec.LabelPolicy = &argoproj.ArgoCDLabelPolicySpec{AdditionalLabels: map[string]string{"team": "platform"}, ManagedBy: "gitops"}
+	assert.NoError(t, r.reconcileLabelPolicy(a))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRole.Name}, clusterRole))
+	assert.Equal(t, "gitops", clusterRole.Labels[common.ArgoCDKeyManagedBy])
+	assert.Equal(t, "platform", clusterRole.Labels["team"])
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRoleBinding.Name}, clusterRoleBinding))
+	assert.Equal(t, "gitops", clusterRoleBinding.Labels[common.ArgoCDKeyManagedBy])
+
+	// The resources of another instance are left untouched
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: other.Name}, other))
+	assert.Equal(t, a.Name, other.Labels[common.ArgoCDKeyManagedBy])
+	assert.NotContains(t, other.Labels, "team")
+}
+
+func TestReconcileArgoCD_getLegacyAggregationManagedBy(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD()
+	aggregated := &rbacv1.ClusterRole{}
+	aggregated.Name = "custom-controller-permissions"
+	aggregated.Labels = map[string]string{
+		common.ArgoCDAggregateToControllerLabelKey: "true",
+		common.ArgoCDKeyManagedBy:                  a.Name,
+	}
+
+	resObjs := []client.Object{a, aggregated}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// The selector matches the name of the instance already
+	legacy, err := r.getLegacyAggregationManagedBy(a)
+	assert.NoError(t, err)
+	assert.Empty(t, legacy)
+
+	a.Spec.LabelPolicy = &argoproj.ArgoCDLabelPolicySpec{ManagedBy: "gitops"}
+	legacy, err = r.getLegacyAggregationManagedBy(a)
+	assert.NoError(t, err)
+	assert.Equal(t, a.Name, legacy)
+
+	clusterRole := newClusterRole(common.ArgoCDApplicationControllerComponent, nil, a)
+	configureAggregatedClusterRole(a, clusterRole, common.ArgoCDApplicationControllerComponent, legacy)
+	assert.Len(t, clusterRole.AggregationRule.ClusterRoleSelectors, 2)
+	assert.Equal(t, "gitops", clusterRole.AggregationRule.ClusterRoleSelectors[0].MatchLabels[common.ArgoCDKeyManagedBy])
+	assert.Equal(t, a.Name, clusterRole.AggregationRule.ClusterRoleSelectors[1].MatchLabels[common.ArgoCDKeyManagedBy])
+
+	// Once migrated, only the new value is selected
+	aggregated.Labels[common.ArgoCDKeyManagedBy] = "gitops"
+	assert.NoError(t, r.Client.Update(context.TODO(), aggregated))
+	legacy, err = r.getLegacyAggregationManagedBy(a)
+	assert.NoError(t, err)
+	assert.Empty(t, legacy)
+}
+
+func TestReconcileArgoCD_reconcileLabelPolicy_clusterRolesStable(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.LabelPolicy = &argoproj.ArgoCDLabelPolicySpec{AdditionalLabels: map[string]string{"team": "platform"}}
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	t.Setenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES", a.Namespace)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	assert.NoError(t, r.reconcileRoles(a))
+	assert.NoError(t, r.reconcileLabelPolicy(a))
+
+	clusterRole := &rbacv1.ClusterRole{}
+	key := types.NamespacedName{Name: GenerateUniqueResourceName(common.ArgoCDApplicationControllerComponent, a)}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, clusterRole))
+	assert.Equal(t, "team", clusterRole.Annotations[common.ArgoCDAdditionalLabelsAnnotation])
+	resourceVersion := clusterRole.ResourceVersion
+
+	// The annotation set by the label policy does not make the ClusterRole differ from the expected one
+	assert.NoError(t, r.reconcileRoles(a))
+	assert.NoError(t, r.reconcileLabelPolicy(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, clusterRole))
+	assert.Equal(t, resourceVersion, clusterRole.ResourceVersion)
+}
@@ -0,0 +1,400 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// logSidecarName is the name of the log sidecar container.
+	logSidecarName = "log-sidecar"
+
+	// logSidecarConfigKey is the key of the log sidecar configuration in its ConfigMap.
+	logSidecarConfigKey = "log-sidecar.conf"
+
+	// logSidecarConfigVolume is the name of the volume holding the log sidecar configuration.
+	logSidecarConfigVolume = "log-sidecar-config"
+
+	// logSidecarLogsVolume is the name of the volume shared by the containers of the pod to hand their logs to the
+	// log sidecar.
+	logSidecarLogsVolume = "log-sidecar-logs"
+
+	// logSidecarConfigPath is the directory where the log sidecar configuration is mounted.
+	logSidecarConfigPath = "/log-sidecar"
+
+	// logSidecarLogsPath is the directory where the containers of the pod write their logs for the log sidecar.
+	logSidecarLogsPath = "/var/log/argocd"
+
+	// logSidecarLogFileSize is the size, in bytes, over which the log file of a container is rotated. The previous
+	// file is kept until the next rotation, so that the log sidecar can finish reading it.
+	logSidecarLogFileSize = 10 * 1024 * 1024
+
+	// logSidecarUser is the user the log sidecar runs as, nobody.
+	logSidecarUser = 65534
+)
+
+// logSidecarConfigMode is the file mode of the log sidecar configuration, set explicitly to match the default.
+var logSidecarConfigMode = corev1.ConfigMapVolumeSourceDefaultMode
+
+// logSidecarWrapperScript runs the command of a container with its output copied to the container log and to a log
+// file in the volume shared with the log sidecar. The command replaces the shell, so that it still receives the
+// signals sent to the container, and writes to a FIFO read by a background shell that rotates the log file. Besides
+// the command, the image only needs to provide sh, rm, mkfifo and mv.
+const logSidecarWrapperScript = `log="%[1]s/$0.log"
+fifo="%[1]s/.$0.fifo"
+rm -f "$fifo" && mkfifo "$fifo" || exit 1
+[ -f "$log" ] && mv -f "$log" "$log.1"
+(
+  size=0
+  while IFS= read -r line || [ -n "$line" ]; do
+    printf '%%s\n' "$line"
+    printf '%%s\n' "$line" >> "$log"
+    size=$((size + ${#line} + 1))
+    if [ "$size" -ge %[2]d ]; then
+      mv -f "$log" "$log.1"
+      size=0
+    fi
+  done < "$fifo"
+) &
+exec "$@" > "$fifo" 2>&1
+`
+
+// logSidecarConfigTemplate is the fluent-bit configuration tailing the log files of the containers of the pod the
+// sidecar runs in.
+const logSidecarConfigTemplate = `[SERVICE]
+    Flush        5
+    Log_Level    warn
+
+[INPUT]
+    Name             tail
+    Path             %[1]s/*.log
+    Path_Key         log_file
+    Tag              argocd.*
+    Refresh_Interval 5
+
+[FILTER]
+    Name   record_modifier
+    Match  *
+    Record argocd_instance %[2]s
+    Record namespace ${POD_NAMESPACE}
+    Record pod ${POD_NAME}
+
+[OUTPUT]
+    Name   http
+    Match  *
+    Host   %[3]s
+    Port   %[4]s
+    URI    %[5]s
+    Format json
+    tls    %[6]s
+`
+
+// isLogSidecarEnabled returns true if the log sidecar should be injected into the component pods of the given ArgoCD.
+func isLogSidecarEnabled(cr *argoproj.ArgoCD) bool {
+	ls := cr.Spec.Monitoring.LogSidecar
+	return ls != nil && (ls.Endpoint != "" || ls.Config != "")
+}
+
+// getLogSidecarImage will return the container image of the log sidecar for the given ArgoCD.
+func getLogSidecarImage(cr *argoproj.ArgoCD) string {
+	if cr.Spec.Monitoring.LogSidecar != nil && cr.Spec.Monitoring.LogSidecar.Image != "" {
+		return cr.Spec.Monitoring.LogSidecar.Image
+	}
+	return common.ArgoCDDefaultLogSidecarImage
+}
+
+// getLogSidecarConfig will return the configuration of the log sidecar for the given ArgoCD. The configuration set
+// by the user takes precedence over the generated one.
+func getLogSidecarConfig(cr *argoproj.ArgoCD) (string, error) {
+	ls := cr.Spec.Monitoring.LogSidecar
+	if ls.Config != "" {
+		return ls.Config, nil
+	}
+
+	endpoint, err := url.Parse(ls.Endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid log sidecar endpoint %s: %w", ls.Endpoint, err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return "", fmt.Errorf("invalid log sidecar endpoint %s: scheme must be http or https", ls.Endpoint)
+	}
+
+	tls, port := "Off", "80"
+	if endpoint.Scheme == "https" {
+		tls, port = "On", "443"
+	}
+	if endpoint.Port() != "" {
+		port = endpoint.Port()
+	}
+	uri := endpoint.RequestURI()
+
+	return fmt.Sprintf(logSidecarConfigTemplate, logSidecarLogsPath, cr.Name, endpoint.Hostname(), port, uri, tls), nil
+}
+
+// reconcileLogSidecarConfigMap will ensure that the ConfigMap holding the log sidecar configuration is present when
+// the log sidecar is enabled for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileLogSidecarConfigMap(cr *argoproj.ArgoCD) error {
+	cm := newConfigMapWithName(nameWithSuffix(logSidecarName, cr), cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
+		if !isLogSidecarEnabled(cr) {
+			// ConfigMap exists but the log sidecar has been disabled, delete the ConfigMap
+			return r.Client.Delete(context.TODO(), cm)
+		}
+		config, err := getLogSidecarConfig(cr)
+		if err != nil {
+			return err
+		}
+		if cm.Data[logSidecarConfigKey] != config {
+			cm.Data = map[string]string{logSidecarConfigKey: config}
+			return r.Client.Update(context.TODO(), cm)
+		}
+		return nil // ConfigMap found with nothing changed, move along...
+	}
+
+	if !isLogSidecarEnabled(cr) {
+		return nil // Log sidecar not enabled, do nothing.
+	}
+
+	config, err := getLogSidecarConfig(cr)
+	if err != nil {
+		return err
+	}
+	cm.Data = map[string]string{logSidecarConfigKey: config}
+
+	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), cm)
+}
+
+// getLogSidecarContainer will return the log sidecar container for the given ArgoCD. The sidecar tails the log files
+// written by the other containers of its pod to the shared volume, so it runs as non-root without any capabilities.
+func getLogSidecarContainer(cr *argoproj.ArgoCD) corev1.Container {
+	container := corev1.Container{
+		Name:            logSidecarName,
+		Image:           getLogSidecarImage(cr),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Args:            []string{"-c", fmt.Sprintf("%s/%s", logSidecarConfigPath, logSidecarConfigKey)},
+		Env: []corev1.EnvVar{
+			{
+				Name:      "POD_NAME",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.name"}},
+			},
+			{
+				Name:      "POD_NAMESPACE",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.namespace"}},
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{
+					"ALL",
+				},
+			},
+			ReadOnlyRootFilesystem: boolPtr(true),
+			RunAsNonRoot:           boolPtr(true),
+			RunAsUser:              int64Ptr(logSidecarUser),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      logSidecarConfigVolume,
+				MountPath: logSidecarConfigPath,
+			},
+			{
+				Name:      logSidecarLogsVolume,
+				MountPath: logSidecarLogsPath,
+				ReadOnly:  true,
+			},
+		},
+	}
+	if cr.Spec.Monitoring.LogSidecar.Resources != nil {
+		container.Resources = *cr.Spec.Monitoring.LogSidecar.Resources
+	}
+	return container
+}
+
+// getLogSidecarVolumes will return the volumes used by the log sidecar of the given ArgoCD.
+func getLogSidecarVolumes(cr *argoproj.ArgoCD) []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: logSidecarConfigVolume,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: nameWithSuffix(logSidecarName, cr),
+					},
+					DefaultMode: &logSidecarConfigMode,
+				},
+			},
+		},
+		{
+			Name: logSidecarLogsVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+}
+
+// getLogSidecarCommand will return the given command of the container with the given name, wrapped so that its
+// output is also written to the volume shared with the log sidecar when the log sidecar is enabled for the given
+// ArgoCD. Containers without a command run the entrypoint of their image, which is left untouched.
+func getLogSidecarCommand(cr *argoproj.ArgoCD, name string, command []string) []string {
+	if !isLogSidecarEnabled(cr) || len(command) == 0 {
+		return command
+	}
+	script := fmt.Sprintf(logSidecarWrapperScript, logSidecarLogsPath, logSidecarLogFileSize)
+	return append([]string{"sh", "-c", script, name}, command...)
+}
+
+// applyLogSidecar will add the log sidecar container and its volumes to the given pod spec, when the log sidecar
+// is enabled for the given ArgoCD. Only the given containers, built by the operator, hand their logs to the sidecar
+// through the shared volume: their commands are wrapped to write their output to it, while the ones running the
+// entrypoint of their image must write their log files to it themselves. The sidecars set by the user are left
+// untouched.
+func applyLogSidecar(cr *argoproj.ArgoCD, podSpec *corev1.PodSpec, containers ...string) {
+	if !isLogSidecarEnabled(cr) {
+		return
+	}
+	for i := range podSpec.Containers {
+		c := &podSpec.Containers[i]
+		if !slices.Contains(containers, c.Name) {
+			continue
+		}
+		c.Command = getLogSidecarCommand(cr, c.Name, c.Command)
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      logSidecarLogsVolume,
+			MountPath: logSidecarLogsPath,
+		})
+	}
+	podSpec.Containers = append(podSpec.Containers, getLogSidecarContainer(cr))
+	podSpec.Volumes = append(podSpec.Volumes, getLogSidecarVolumes(cr)...)
+}
+
+// getLogSidecarLogFile will return the path of the log file in the volume shared with the log sidecar of the
+// container with the given name, for the containers that write it themselves.
+func getLogSidecarLogFile(name string) string {
+	return fmt.Sprintf("%s/%s.log", logSidecarLogsPath, name)
+}
+
+// isLogSidecarVolume returns true if the given volume name belongs to the log sidecar.
+func isLogSidecarVolume(name string) bool {
+	return strings.HasPrefix(name, logSidecarName+"-")
+}
+
+// updateLogSidecar will update the log sidecar container and its volumes of the existing pod spec to match the
+// desired pod spec, adding or removing them as needed.
+func updateLogSidecar(existing *corev1.PodSpec, desired *corev1.PodSpec, changed *bool) {
+	var desiredContainer *corev1.Container
+	for i := range desired.Containers {
+		if desired.Containers[i].Name == logSidecarName {
+			desiredContainer = &desired.Containers[i]
+		}
+	}
+
+	containers := make([]corev1.Container, 0, len(existing.Containers))
+	found := false
+	for _, c := range existing.Containers {
+		if c.Name != logSidecarName {
+			updateLogSidecarWrapper(&c, desired, changed)
+			containers = append(containers, c)
+			continue
+		}
+		if desiredContainer == nil {
+			*changed = true // Log sidecar disabled, drop it
+			continue
+		}
+		found = true
+		if c.Image != desiredContainer.Image ||
+			!reflect.DeepEqual(c.Args, desiredContainer.Args) ||
+			!reflect.DeepEqual(c.Env, desiredContainer.Env) ||
+			!reflect.DeepEqual(c.Resources, desiredContainer.Resources) ||
+			!reflect.DeepEqual(c.SecurityContext, desiredContainer.SecurityContext) ||
+			!reflect.DeepEqual(c.VolumeMounts, desiredContainer.VolumeMounts) {
+			c = *desiredContainer
+			*changed = true
+		}
+		containers = append(containers, c)
+	}
+	if !found && desiredContainer != nil {
+		containers = append(containers, *desiredContainer)
+		*changed = true
+	}
+	existing.Containers = containers
+
+	volumes := make([]corev1.Volume, 0, len(existing.Volumes))
+	for _, v := range existing.Volumes {
+		if !isLogSidecarVolume(v.Name) {
+			volumes = append(volumes, v)
+		}
+	}
+	for _, v := range desired.Volumes {
+		if isLogSidecarVolume(v.Name) {
+			volumes = append(volumes, v)
+		}
+	}
+	if !reflect.DeepEqual(volumes, existing.Volumes) {
+		existing.Volumes = volumes
+		*changed = true
+	}
+}
+
+// updateLogSidecarWrapper will update the command of the given existing container, and its mount of the volume shared
+// with the log sidecar, to match the container with the same name in the desired pod spec.
+func updateLogSidecarWrapper(existing *corev1.Container, desired *corev1.PodSpec, changed *bool) {
+	for _, c := range desired.Containers {
+		if c.Name != existing.Name {
+			continue
+		}
+		if !reflect.DeepEqual(existing.Command, c.Command) {
+			existing.Command = c.Command
+			*changed = true
+		}
+
+		var mounts []corev1.VolumeMount
+		for _, m := range existing.VolumeMounts {
+			if m.Name != logSidecarLogsVolume {
+				mounts = append(mounts, m)
+			}
+		}
+		for _, m := range c.VolumeMounts {
+			if m.Name == logSidecarLogsVolume {
+				mounts = append(mounts, m)
+			}
+		}
+		if !reflect.DeepEqual(mounts, existing.VolumeMounts) {
+			existing.VolumeMounts = mounts
+			*changed = true
+		}
+		return
+	}
+}
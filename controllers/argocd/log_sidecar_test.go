@@ -0,0 +1,199 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestGetLogSidecarConfig(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Monitoring.LogSidecar = &argoproj.ArgoCDLogSidecarSpec{Endpoint: "https://logs.example.com:8443/ingest?tenant=argocd"}
+	})
+
+	config, err := getLogSidecarConfig(a)
+	assert.NoError(t, err)
+	assert.Contains(t, config, "Host   logs.example.com\n")
+	assert.Contains(t, config, "Port   8443\n")
+	assert.Contains(t, config, "URI    /ingest?tenant=argocd\n")
+	assert.Contains(t, config, "tls    On\n")
+	assert.Contains(t, config, "Record argocd_instance argocd\n")
+	assert.Contains(t, config, "Path             /var/log/argocd/*.log\n")
+
+	a.Spec.Monitoring.LogSidecar.Endpoint = "http://logs.example.com"
+	config, err = getLogSidecarConfig(a)
+	assert.NoError(t, err)
+	assert.Contains(t, config, "Port   80\n")
+	assert.Contains(t, config, "URI    /\n")
+	assert.Contains(t, config, "tls    Off\n")
+
+	a.Spec.Monitoring.LogSidecar.Endpoint = "logs.example.com"
+	_, err = getLogSidecarConfig(a)
+	assert.Error(t, err)
+
+	a.Spec.Monitoring.LogSidecar.Config = "[OUTPUT]\n    Name stdout\n"
+	config, err = getLogSidecarConfig(a)
+	assert.NoError(t, err)
+	assert.Equal(t, a.Spec.Monitoring.LogSidecar.Config, config)
+}
+
+func TestReconcileArgoCD_logSidecar(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Monitoring.LogSidecar = &argoproj.ArgoCDLogSidecarSpec{Endpoint: "https://logs.example.com"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	getContainerNames := func() []string {
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, deployment))
+		var names []string
+		for _, c := range deployment.Spec.Template.Spec.Containers {
+			names = append(names, c.Name)
+		}
+		for _, v := range deployment.Spec.Template.Spec.Volumes {
+			if isLogSidecarVolume(v.Name) {
+				names = append(names, v.Name)
+			}
+		}
+		return names
+	}
+
+	assert.NoError(t, r.reconcileLogSidecarConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-log-sidecar", Namespace: a.Namespace}, cm))
+	assert.Contains(t, cm.Data[logSidecarConfigKey], "Host   logs.example.com\n")
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.Equal(t, []string{"argocd-server", logSidecarName, logSidecarConfigVolume, logSidecarLogsVolume}, getContainerNames())
+
+	// The server writes its output to the volume shared with the sidecar, which runs as non-root
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, deployment))
+	server, sidecar := deployment.Spec.Template.Spec.Containers[0], deployment.Spec.Template.Spec.Containers[1]
+	assert.Equal(t, getLogSidecarCommand(a, "argocd-server", getArgoServerCommand(a, false)), server.Command)
+	assert.Equal(t, []string{"sh", "-c"}, server.Command[:2])
+	assert.Equal(t, "argocd-server", server.Command[3])
+	assert.Contains(t, server.VolumeMounts, corev1.VolumeMount{Name: logSidecarLogsVolume, MountPath: logSidecarLogsPath})
+	assert.True(t, *sidecar.SecurityContext.RunAsNonRoot)
+	assert.Equal(t, int64(logSidecarUser), *sidecar.SecurityContext.RunAsUser)
+	for _, v := range deployment.Spec.Template.Spec.Volumes {
+		assert.Nil(t, v.HostPath)
+	}
+
+	// Reconciling again leaves the wrapped command untouched
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, deployment))
+	assert.Equal(t, server.Command, deployment.Spec.Template.Spec.Containers[0].Command)
+
+	// Disabling the log sidecar removes it from the existing pods
+	a.Spec.Monitoring.LogSidecar = nil
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.Equal(t, []string{"argocd-server"}, getContainerNames())
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}, deployment))
+	assert.Equal(t, getArgoServerCommand(a, false), deployment.Spec.Template.Spec.Containers[0].Command)
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts,
+		corev1.VolumeMount{Name: logSidecarLogsVolume, MountPath: logSidecarLogsPath})
+
+	assert.NoError(t, r.reconcileLogSidecarConfigMap(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: cm.Name, Namespace: a.Namespace}, cm)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestUpdateLogSidecar(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Monitoring.LogSidecar = &argoproj.ArgoCDLogSidecarSpec{Endpoint: "https://logs.example.com"}
+	})
+
+	existing := &corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "redis"}},
+		Volumes:    []corev1.Volume{{Name: "data"}},
+	}
+	desired := existing.DeepCopy()
+	applyLogSidecar(a, desired, "redis")
+
+	changed := false
+	updateLogSidecar(existing, desired, &changed)
+	assert.True(t, changed)
+	assert.Equal(t, desired, existing)
+
+	changed = false
+	updateLogSidecar(existing, desired, &changed)
+	assert.False(t, changed)
+
+	a.Spec.Monitoring.LogSidecar.Image = "timberio/vector:0.35.0-alpine"
+	desired = &corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "redis"}},
+		Volumes:    []corev1.Volume{{Name: "data"}},
+	}
+	applyLogSidecar(a, desired, "redis")
+	updateLogSidecar(existing, desired, &changed)
+	assert.True(t, changed)
+	assert.Equal(t, "timberio/vector:0.35.0-alpine", existing.Containers[1].Image)
+}
+
+func TestApplyLogSidecar(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Monitoring.LogSidecar = &argoproj.ArgoCDLogSidecarSpec{Endpoint: "https://logs.example.com"}
+	})
+	logsMount := corev1.VolumeMount{Name: logSidecarLogsVolume, MountPath: logSidecarLogsPath}
+
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{Name: "argocd-repo-server", Command: []string{"uid_entrypoint.sh", "argocd-repo-server"}},
+			{Name: "redis", Args: []string{"--save", ""}},
+			{Name: "cmp-plugin", Command: []string{"/var/run/argocd/argocd-cmp-server"}},
+		},
+	}
+	applyLogSidecar(a, podSpec, "argocd-repo-server", "redis")
+
+	// The commands of the given containers are wrapped
+	repo := podSpec.Containers[0]
+	assert.Equal(t, getLogSidecarCommand(a, repo.Name, []string{"uid_entrypoint.sh", "argocd-repo-server"}), repo.Command)
+	assert.Contains(t, repo.VolumeMounts, logsMount)
+
+	// The entrypoint of the image is left untouched, the container writes its log file to the shared volume itself
+	redis := podSpec.Containers[1]
+	assert.Empty(t, redis.Command)
+	assert.Equal(t, []string{"--save", ""}, redis.Args)
+	assert.Contains(t, redis.VolumeMounts, logsMount)
+
+	// The sidecars set by the user are left untouched
+	plugin := podSpec.Containers[2]
+	assert.Equal(t, []string{"/var/run/argocd/argocd-cmp-server"}, plugin.Command)
+	assert.Empty(t, plugin.VolumeMounts)
+
+	assert.Equal(t, logSidecarName, podSpec.Containers[3].Name)
+	assert.Contains(t, getArgoRedisArgs(a, false), getLogSidecarLogFile("redis"))
+}
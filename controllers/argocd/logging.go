@@ -0,0 +1,231 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const (
+	// logComponentApplicationSet is the component of the logs of the ApplicationSet controller reconciler.
+	logComponentApplicationSet = "applicationset"
+
+	// logComponentRedis is the component of the logs of the Redis and Redis HA reconcilers.
+	logComponentRedis = "redis"
+
+	// logComponentRepoServer is the component of the logs of the repo server reconciler.
+	logComponentRepoServer = "reposerver"
+)
+
+var (
+	// componentLogBase is the logger the component logs are written to. It must enable all the levels, the level of
+	// each component being applied on top of it.
+	componentLogBase = log
+
+	// defaultComponentLogLevel is the level of the components without a level of their own.
+	defaultComponentLogLevel zapcore.LevelEnabler = zapcore.InfoLevel
+
+	// componentLogLevels are the levels of the components set on the operator.
+	componentLogLevels = map[string]zapcore.Level{}
+
+	// logComponents are the components with a logger and a log level of their own.
+	logComponents = []string{logComponentApplicationSet, logComponentRedis, logComponentRepoServer}
+)
+
+// instanceLogger holds the loggers of an ArgoCD instance, which name the instance in every line.
+type instanceLogger struct {
+	// logLevels is the value of the log levels annotation the component loggers were built with.
+	logLevels  string
+	log        logr.Logger
+	components map[string]logr.Logger
+}
+
+// instanceLogs holds the loggers of the ArgoCD instances, built once at the start of each reconciliation rather than
+// on each log line.
+var instanceLogs = struct {
+	sync.Mutex
+	loggers map[types.NamespacedName]*instanceLogger
+}{loggers: map[types.NamespacedName]*instanceLogger{}}
+
+// levelSink is a LogSink only writing the logs enabled at the given level to the given LogSink.
+type levelSink struct {
+	sink  logr.LogSink
+	level zapcore.LevelEnabler
+}
+
+// newLevelSink returns a LogSink writing the logs of the given LogSink enabled at the given level.
+func newLevelSink(sink logr.LogSink, level zapcore.LevelEnabler) logr.LogSink {
+	// Skip the frame of the levelSink, so that the logs point at their caller
+	if s, ok := sink.(logr.CallDepthLogSink); ok {
+		sink = s.WithCallDepth(1)
+	}
+	return &levelSink{sink: sink, level: level}
+}
+
+// Init does nothing, the wrapped LogSink being initialized by its own Logger.
+func (s *levelSink) Init(info logr.RuntimeInfo) {}
+
+// Enabled returns true if the logs of the given verbosity are enabled, V(1) being the debug level.
+func (s *levelSink) Enabled(level int) bool {
+	return s.level.Enabled(zapcore.Level(-level)) && s.sink.Enabled(level)
+}
+
+func (s *levelSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	s.sink.Info(level, msg, keysAndValues...)
+}
+
+func (s *levelSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	if s.level.Enabled(zapcore.ErrorLevel) {
+		s.sink.Error(err, msg, keysAndValues...)
+	}
+}
+
+func (s *levelSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &levelSink{sink: s.sink.WithValues(keysAndValues...), level: s.level}
+}
+
+func (s *levelSink) WithName(name string) logr.LogSink {
+	return &levelSink{sink: s.sink.WithName(name), level: s.level}
+}
+
+// ParseComponentLogLevels will return the log levels of the components listed in the given value, e.g.
+// redis=debug,reposerver=error.
+func ParseComponentLogLevels(value string) (map[string]zapcore.Level, error) {
+	levels := map[string]zapcore.Level{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		component, levelName, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid component log level %q, expected <component>=<level>", item)
+		}
+		component = strings.TrimSpace(component)
+		if !contains(logComponents, component) {
+			return nil, fmt.Errorf("unknown log component %q, expected one of %s", component, strings.Join(logComponents, ", "))
+		}
+		level, err := zapcore.ParseLevel(strings.TrimSpace(levelName))
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level of component %s: %w", component, err)
+		}
+		levels[component] = level
+	}
+	return levels, nil
+}
+
+// SetComponentLogging sets the logger the component logs are written to, which must enable all the levels, the
+// default level of the components and the levels of the given components. It must be called before the
+// reconcilers are started.
+func SetComponentLogging(base logr.Logger, defaultLevel zapcore.LevelEnabler, levels map[string]zapcore.Level) {
+	componentLogBase = base.WithName("controller_argocd")
+	defaultComponentLogLevel = defaultLevel
+	componentLogLevels = levels
+
+	instanceLogs.Lock()
+	instanceLogs.loggers = map[types.NamespacedName]*instanceLogger{}
+	instanceLogs.Unlock()
+}
+
+// getComponentLogLevel will return the log level of the given component for the given ArgoCD: the level set with
+// the log levels annotation of the instance, the level of the component set on the operator, or the default level.
+func getComponentLogLevel(cr *argoproj.ArgoCD, component string) zapcore.LevelEnabler {
+	if value, ok := cr.Annotations[common.ArgoCDLogLevelsAnnotation]; ok {
+		// An invalid annotation is ignored, and reported by the validating webhook
+		if levels, err := ParseComponentLogLevels(value); err == nil {
+			if level, ok := levels[component]; ok {
+				return level
+			}
+		}
+	}
+	if level, ok := componentLogLevels[component]; ok {
+		return level
+	}
+	return defaultComponentLogLevel
+}
+
+// newInstanceLogger returns the loggers of the given ArgoCD, with the level of each component resolved once.
+func newInstanceLogger(cr *argoproj.ArgoCD) *instanceLogger {
+	logger := &instanceLogger{
+		logLevels:  cr.Annotations[common.ArgoCDLogLevelsAnnotation],
+		log:        log.WithValues("namespace", cr.Namespace, "name", cr.Name),
+		components: map[string]logr.Logger{},
+	}
+	for _, component := range logComponents {
+		sink := newLevelSink(componentLogBase.GetSink(), getComponentLogLevel(cr, component))
+		logger.components[component] = logr.New(sink).WithName(component).WithValues("namespace", cr.Namespace, "name", cr.Name)
+	}
+	return logger
+}
+
+// setInstanceLog will build the loggers of the given ArgoCD for the reconciliation starting.
+func setInstanceLog(cr *argoproj.ArgoCD) {
+	logger := newInstanceLogger(cr)
+
+	instanceLogs.Lock()
+	defer instanceLogs.Unlock()
+	instanceLogs.loggers[types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}] = logger
+}
+
+// forgetInstanceLog will drop the loggers of the ArgoCD with the given name, once it is gone.
+func forgetInstanceLog(key types.NamespacedName) {
+	instanceLogs.Lock()
+	defer instanceLogs.Unlock()
+	delete(instanceLogs.loggers, key)
+}
+
+// getInstanceLogger will return the loggers of the given ArgoCD built for the reconciliation, or new ones when the
+// instance is not being reconciled or its log levels annotation changed since.
+func getInstanceLogger(cr *argoproj.ArgoCD) *instanceLogger {
+	instanceLogs.Lock()
+	logger, ok := instanceLogs.loggers[types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}]
+	instanceLogs.Unlock()
+	if ok && logger.logLevels == cr.Annotations[common.ArgoCDLogLevelsAnnotation] {
+		return logger
+	}
+	return newInstanceLogger(cr)
+}
+
+// instanceLog returns the logger of the given ArgoCD, naming the instance in every line.
+func instanceLog(cr *argoproj.ArgoCD) logr.Logger {
+	return getInstanceLogger(cr).log
+}
+
+// componentLog returns the logger of the given component reconciler for the given ArgoCD, naming the component and
+// the instance in every line.
+func componentLog(cr *argoproj.ArgoCD, component string) logr.Logger {
+	return getInstanceLogger(cr).components[component]
+}
+
+// getLogLevelsWarning returns a warning about the log levels annotation of the given ArgoCD when it is invalid.
+func getLogLevelsWarning(cr *argoproj.ArgoCD) string {
+	value, ok := cr.Annotations[common.ArgoCDLogLevelsAnnotation]
+	if !ok {
+		return ""
+	}
+	if _, err := ParseComponentLogLevels(value); err != nil {
+		return fmt.Sprintf("the %s annotation is ignored: %s", common.ArgoCDLogLevelsAnnotation, err)
+	}
+	return ""
+}
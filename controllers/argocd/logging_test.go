@@ -0,0 +1,97 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestParseComponentLogLevels(t *testing.T) {
+	levels, err := ParseComponentLogLevels("redis=debug, reposerver=error,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]zapcore.Level{logComponentRedis: zapcore.DebugLevel, logComponentRepoServer: zapcore.ErrorLevel}, levels)
+
+	levels, err = ParseComponentLogLevels("")
+	assert.NoError(t, err)
+	assert.Empty(t, levels)
+
+	for _, value := range []string{"redis", "dex=debug", "redis=verbose"} {
+		_, err = ParseComponentLogLevels(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestComponentLog(t *testing.T) {
+	lines := []string{}
+	base := funcr.New(func(prefix, args string) {
+		lines = append(lines, prefix+" "+args)
+	}, funcr.Options{Verbosity: 1})
+	previousBase, previousDefault, previousLevels := componentLogBase, defaultComponentLogLevel, componentLogLevels
+	defer func() {
+		componentLogBase, defaultComponentLogLevel, componentLogLevels = previousBase, previousDefault, previousLevels
+	}()
+	SetComponentLogging(base, zapcore.InfoLevel, map[string]zapcore.Level{logComponentRepoServer: zapcore.ErrorLevel})
+
+	a := makeTestArgoCD()
+
+	// The default level applies to the components without a level of their own
+	componentLog(a, logComponentRedis).Info("reconciling")
+	componentLog(a, logComponentRedis).V(1).Info("details")
+	assert.Len(t, lines, 1)
+	assert.Contains(t, lines[0], "controller_argocd/redis")
+	assert.Contains(t, lines[0], `"namespace"="argocd" "name"="argocd"`)
+
+	// The level of the operator applies to the component, errors being still logged at the error level
+	componentLog(a, logComponentRepoServer).Info("reconciling")
+	componentLog(a, logComponentRepoServer).Error(errors.New("failed"), "reconciling")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[1], "controller_argocd/reposerver")
+
+	// The level of the instance overrides the level of the operator
+	a.Annotations = map[string]string{common.ArgoCDLogLevelsAnnotation: "redis=debug,reposerver=info"}
+	componentLog(a, logComponentRedis).V(1).Info("details")
+	componentLog(a, logComponentRepoServer).Info("reconciling")
+	assert.Len(t, lines, 4)
+	assert.Empty(t, getLogLevelsWarning(a))
+
+	// An invalid annotation is ignored, and reported by the validating webhook
+	a.Annotations[common.ArgoCDLogLevelsAnnotation] = "redis=verbose"
+	componentLog(a, logComponentRedis).V(1).Info("details")
+	assert.Len(t, lines, 4)
+	assert.NotEmpty(t, getLogLevelsWarning(a))
+	assert.Equal(t, zapcore.InfoLevel, getComponentLogLevel(a, logComponentRedis))
+	assert.Equal(t, zapcore.ErrorLevel, getComponentLogLevel(&argoproj.ArgoCD{}, logComponentRepoServer))
+
+	// The loggers built for a reconciliation are reused until the levels of the instance change
+	a.Annotations[common.ArgoCDLogLevelsAnnotation] = "redis=debug"
+	setInstanceLog(a)
+	defer forgetInstanceLog(types.NamespacedName{Name: a.Name, Namespace: a.Namespace})
+	assert.Equal(t, componentLog(a, logComponentRedis), componentLog(a, logComponentRedis))
+	componentLog(a, logComponentRedis).V(1).Info("details")
+	assert.Len(t, lines, 5)
+
+	a.Annotations[common.ArgoCDLogLevelsAnnotation] = "redis=info"
+	componentLog(a, logComponentRedis).V(1).Info("details")
+	assert.Len(t, lines, 5)
+}
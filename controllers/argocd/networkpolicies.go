@@ -88,7 +88,7 @@ func (r *ReconcileArgoCD) ReconcileRedisNetworkPolicy(cr *argoproj.ArgoCD) error
 					Ports: []networkingv1.NetworkPolicyPort{
 						{
 							Protocol: TCPProtocol,
-							Port:     &intstr.IntOrString{Type: intstr.Int, IntVal: 6379},
+							Port:     &intstr.IntOrString{Type: intstr.Int, IntVal: getRedisPort(cr)},
 						},
 					},
 				},
@@ -121,10 +121,10 @@ func (r *ReconcileArgoCD) ReconcileRedisNetworkPolicy(cr *argoproj.ArgoCD) error
 		}
 
 		if modified {
-			log.Info("Updating redis network policy", "namespace", networkPolicy.Namespace, "name", networkPolicy.Name)
+			componentLog(cr, logComponentRedis).Info("Updating redis network policy", "namespace", networkPolicy.Namespace, "name", networkPolicy.Name)
 			err := r.Client.Update(context.TODO(), existing)
 			if err != nil {
-				log.Error(err, "Failed to update redis network policy")
+				componentLog(cr, logComponentRedis).Error(err, "Failed to update redis network policy")
 				return err
 			}
 		}
@@ -136,14 +136,14 @@ func (r *ReconcileArgoCD) ReconcileRedisNetworkPolicy(cr *argoproj.ArgoCD) error
 
 	// Set the ArgoCD instance as the owner and controller
 	if err := controllerutil.SetControllerReference(cr, networkPolicy, r.Scheme); err != nil {
-		log.Error(err, "Failed to set controller reference on redis network policy")
+		componentLog(cr, logComponentRedis).Error(err, "Failed to set controller reference on redis network policy")
 		return err
 	}
 
-	log.Info("Creating redis network policy", "namespace", networkPolicy.Namespace, "name", networkPolicy.Name)
+	componentLog(cr, logComponentRedis).Info("Creating redis network policy", "namespace", networkPolicy.Namespace, "name", networkPolicy.Name)
 	err := r.Client.Create(context.TODO(), networkPolicy)
 	if err != nil {
-		log.Error(err, "Failed to create redis network policy")
+		componentLog(cr, logComponentRedis).Error(err, "Failed to create redis network policy")
 		return err
 	}
 
@@ -196,11 +196,11 @@ func (r *ReconcileArgoCD) ReconcileRedisHANetworkPolicy(cr *argoproj.ArgoCD) err
 					Ports: []networkingv1.NetworkPolicyPort{
 						{
 							Protocol: TCPProtocol,
-							Port:     &intstr.IntOrString{Type: intstr.Int, IntVal: 6379},
+							Port:     &intstr.IntOrString{Type: intstr.Int, IntVal: getRedisPort(cr)},
 						},
 						{
 							Protocol: TCPProtocol,
-							Port:     &intstr.IntOrString{Type: intstr.Int, IntVal: 26379},
+							Port:     &intstr.IntOrString{Type: intstr.Int, IntVal: getRedisSentinelPort(cr)},
 						},
 					},
 				},
@@ -233,10 +233,10 @@ func (r *ReconcileArgoCD) ReconcileRedisHANetworkPolicy(cr *argoproj.ArgoCD) err
 		}
 
 		if modified {
-			log.Info("Updating redis ha network policy", "namespace", networkPolicy.Namespace, "name", networkPolicy.Name)
+			componentLog(cr, logComponentRedis).Info("Updating redis ha network policy", "namespace", networkPolicy.Namespace, "name", networkPolicy.Name)
 			err := r.Client.Update(context.TODO(), existing)
 			if err != nil {
-				log.Error(err, "Failed to update redis ha network policy")
+				componentLog(cr, logComponentRedis).Error(err, "Failed to update redis ha network policy")
 				return err
 			}
 		}
@@ -248,14 +248,14 @@ func (r *ReconcileArgoCD) ReconcileRedisHANetworkPolicy(cr *argoproj.ArgoCD) err
 
 	// Set the ArgoCD instance as the owner and controller
 	if err := controllerutil.SetControllerReference(cr, networkPolicy, r.Scheme); err != nil {
-		log.Error(err, "Failed to set controller reference on redis ha network policy")
+		componentLog(cr, logComponentRedis).Error(err, "Failed to set controller reference on redis ha network policy")
 		return err
 	}
 
-	log.Info("Creating redis ha network policy", "namespace", networkPolicy.Namespace, "name", networkPolicy.Name)
+	componentLog(cr, logComponentRedis).Info("Creating redis ha network policy", "namespace", networkPolicy.Namespace, "name", networkPolicy.Name)
 	err := r.Client.Create(context.TODO(), networkPolicy)
 	if err != nil {
-		log.Error(err, "Failed to create redis ha network policy")
+		componentLog(cr, logComponentRedis).Error(err, "Failed to create redis ha network policy")
 		return err
 	}
 
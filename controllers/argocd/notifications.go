@@ -28,45 +28,45 @@ const (
 
 func (r *ReconcileArgoCD) reconcileNotificationsController(cr *argoproj.ArgoCD) error {
 
-	log.Info("reconciling notifications serviceaccount")
+	instanceLog(cr).Info("reconciling notifications serviceaccount")
 	sa, err := r.reconcileNotificationsServiceAccount(cr)
 	if err != nil {
 		return err
 	}
 
-	log.Info("reconciling notifications role")
+	instanceLog(cr).Info("reconciling notifications role")
 	role, err := r.reconcileNotificationsRole(cr)
 	if err != nil {
 		return err
 	}
 
-	log.Info("reconciling notifications role binding")
+	instanceLog(cr).Info("reconciling notifications role binding")
 	if err := r.reconcileNotificationsRoleBinding(cr, role, sa); err != nil {
 		return err
 	}
 
-	log.Info("reconciling NotificationsConfiguration")
+	instanceLog(cr).Info("reconciling NotificationsConfiguration")
 	if err := r.reconcileNotificationsConfigurationCR(cr); err != nil {
 		return err
 	}
 
-	log.Info("reconciling notifications secret")
+	instanceLog(cr).Info("reconciling notifications secret")
 	if err := r.reconcileNotificationsSecret(cr); err != nil {
 		return err
 	}
 
-	log.Info("reconciling notifications deployment")
+	instanceLog(cr).Info("reconciling notifications deployment")
 	if err := r.reconcileNotificationsDeployment(cr, sa); err != nil {
 		return err
 	}
 
-	log.Info("reconciling notifications metrics service")
+	instanceLog(cr).Info("reconciling notifications metrics service")
 	if err := r.reconcileNotificationsMetricsService(cr); err != nil {
 		return err
 	}
 
 	if prometheusAPIFound {
-		log.Info("reconciling notifications metrics service monitor")
+		instanceLog(cr).Info("reconciling notifications metrics service monitor")
 		if err := r.reconcileNotificationsServiceMonitor(cr); err != nil {
 			return err
 		}
@@ -90,7 +90,7 @@ func (r *ReconcileArgoCD) reconcileNotificationsConfigurationCR(cr *argoproj.Arg
 	}
 
 	if !cr.Spec.Notifications.Enabled {
-		log.Info("Deleting NotificationsConfiguration as notifications is disabled")
+		instanceLog(cr).Info("Deleting NotificationsConfiguration as notifications is disabled")
 		return r.Client.Delete(context.TODO(), defaultNotificationsConfigurationCR)
 	}
 
@@ -113,7 +113,46 @@ func (r *ReconcileArgoCD) reconcileNotificationsConfigurationCR(cr *argoproj.Arg
 		}
 	}
 
-	return nil
+	return r.reconcileNotificationsSubscriptions(cr, defaultNotificationsConfigurationCR)
+}
+
+// reconcileNotificationsSubscriptions will ensure that the subscriptions key of the given NotificationsConfiguration
+// holds the default subscriptions of the given ArgoCD. The key is left to the user when no default subscription is
+// set, and removed only if it was set by the operator.
+func (r *ReconcileArgoCD) reconcileNotificationsSubscriptions(cr *argoproj.ArgoCD, nc *v1alpha1.NotificationsConfiguration) error {
+	subscriptions, err := getNotificationsSubscriptions(cr)
+	if err != nil {
+		return err
+	}
+
+	_, managed := nc.Annotations[common.ArgoCDManagedSubscriptionsAnnotation]
+	changed := false
+	if subscriptions != "" {
+		if nc.Spec.Subscriptions[common.ArgoCDKeyNotificationsSubscriptions] != subscriptions {
+			if nc.Spec.Subscriptions == nil {
+				nc.Spec.Subscriptions = map[string]string{}
+			}
+			nc.Spec.Subscriptions[common.ArgoCDKeyNotificationsSubscriptions] = subscriptions
+			changed = true
+		}
+		if !managed {
+			if nc.Annotations == nil {
+				nc.Annotations = map[string]string{}
+			}
+			nc.Annotations[common.ArgoCDManagedSubscriptionsAnnotation] = "true"
+			changed = true
+		}
+	} else if managed {
+		delete(nc.Spec.Subscriptions, common.ArgoCDKeyNotificationsSubscriptions)
+		delete(nc.Annotations, common.ArgoCDManagedSubscriptionsAnnotation)
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	instanceLog(cr).Info(fmt.Sprintf("Updating the subscriptions of NotificationsConfiguration %s", nc.Name))
+	return r.Client.Update(context.TODO(), nc)
 }
 
 // The code to create/delete notifications resources is written within the reconciliation logic itself. However, these functions must be called
@@ -137,50 +176,51 @@ func (r *ReconcileArgoCD) deleteNotificationsResources(cr *argoproj.ArgoCD) erro
 		}
 	}
 
-	log.Info("reconciling notifications deployment")
+	instanceLog(cr).Info("reconciling notifications deployment")
 	if err := r.reconcileNotificationsDeployment(cr, sa); err != nil {
 		return err
 	}
 
-	log.Info("reconciling notifications service")
+	instanceLog(cr).Info("reconciling notifications service")
 	if err := r.reconcileNotificationsMetricsService(cr); err != nil {
 		return err
 	}
 
-	log.Info("reconciling notifications service monitor")
+	instanceLog(cr).Info("reconciling notifications service monitor")
 	if err := r.reconcileNotificationsServiceMonitor(cr); err != nil {
 		return err
 	}
 
-	log.Info("reconciling notifications secret")
+	instanceLog(cr).Info("reconciling notifications secret")
 	if err := r.reconcileNotificationsSecret(cr); err != nil {
 		return err
 	}
 
-	log.Info("reconciling notifications role binding")
+	instanceLog(cr).Info("reconciling notifications role binding")
 	if err := r.reconcileNotificationsRoleBinding(cr, role, sa); err != nil {
 		return err
 	}
 
-	log.Info("reconciling notifications role")
+	instanceLog(cr).Info("reconciling notifications role")
 	_, err := r.reconcileNotificationsRole(cr)
 	if err != nil {
 		return err
 	}
 
-	log.Info("reconciling notifications serviceaccount")
+	instanceLog(cr).Info("reconciling notifications serviceaccount")
 	_, err = r.reconcileNotificationsServiceAccount(cr)
 	if err != nil {
 		return err
 	}
 
-	log.Info("reconciling notificationsconfiguration")
+	instanceLog(cr).Info("reconciling notificationsconfiguration")
 	err = r.reconcileNotificationsConfigurationCR(cr)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	// Sweep the remaining notifications resources, such as the metrics service
+	return r.deleteComponentResources(cr, componentNotifications)
 }
 
 func (r *ReconcileArgoCD) reconcileNotificationsServiceAccount(cr *argoproj.ArgoCD) (*corev1.ServiceAccount, error) {
@@ -202,7 +242,7 @@ func (r *ReconcileArgoCD) reconcileNotificationsServiceAccount(cr *argoproj.Argo
 			return nil, err
 		}
 
-		log.Info(fmt.Sprintf("Creating serviceaccount %s", sa.Name))
+		instanceLog(cr).Info(fmt.Sprintf("Creating serviceaccount %s", sa.Name))
 		err := r.Client.Create(context.TODO(), sa)
 		if err != nil {
 			return nil, err
@@ -211,7 +251,7 @@ func (r *ReconcileArgoCD) reconcileNotificationsServiceAccount(cr *argoproj.Argo
 
 	// SA exists but shouldn't, so it should be deleted
 	if !cr.Spec.Notifications.Enabled {
-		log.Info(fmt.Sprintf("Deleting serviceaccount %s as notifications is disabled", sa.Name))
+		instanceLog(cr).Info(fmt.Sprintf("Deleting serviceaccount %s as notifications is disabled", sa.Name))
 		return nil, r.Client.Delete(context.TODO(), sa)
 	}
 
@@ -239,7 +279,7 @@ func (r *ReconcileArgoCD) reconcileNotificationsRole(cr *argoproj.ArgoCD) (*rbac
 			return nil, err
 		}
 
-		log.Info(fmt.Sprintf("Creating role %s", desiredRole.Name))
+		instanceLog(cr).Info(fmt.Sprintf("Creating role %s", desiredRole.Name))
 		err := r.Client.Create(context.TODO(), desiredRole)
 		if err != nil {
 			return nil, err
@@ -249,7 +289,7 @@ func (r *ReconcileArgoCD) reconcileNotificationsRole(cr *argoproj.ArgoCD) (*rbac
 
 	// role exists but shouldn't, so it should be deleted
 	if !cr.Spec.Notifications.Enabled {
-		log.Info(fmt.Sprintf("Deleting role %s as notifications is disabled", existingRole.Name))
+		instanceLog(cr).Info(fmt.Sprintf("Deleting role %s as notifications is disabled", existingRole.Name))
 		return nil, r.Client.Delete(context.TODO(), existingRole)
 	}
 
@@ -299,13 +339,13 @@ func (r *ReconcileArgoCD) reconcileNotificationsRoleBinding(cr *argoproj.ArgoCD,
 			return err
 		}
 
-		log.Info(fmt.Sprintf("Creating roleBinding %s", desiredRoleBinding.Name))
+		instanceLog(cr).Info(fmt.Sprintf("Creating roleBinding %s", desiredRoleBinding.Name))
 		return r.Client.Create(context.TODO(), desiredRoleBinding)
 	}
 
 	// roleBinding exists but shouldn't, so it should be deleted
 	if !cr.Spec.Notifications.Enabled {
-		log.Info(fmt.Sprintf("Deleting roleBinding %s as notifications is disabled", existingRoleBinding.Name))
+		instanceLog(cr).Info(fmt.Sprintf("Deleting roleBinding %s as notifications is disabled", existingRoleBinding.Name))
 		return r.Client.Delete(context.TODO(), existingRoleBinding)
 	}
 
@@ -338,9 +378,19 @@ func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoproj.ArgoCD,
 		desiredDeployment.Spec.Replicas = replicas
 	}
 
+	defaultEnv := proxyEnvVars()
+	if cr.Spec.Notifications.RootCASecretRef != nil {
+		// Trust the root CA in addition to the system CAs, read from the directories listed in SSL_CERT_DIR
+		defaultEnv = append(defaultEnv, corev1.EnvVar{
+			Name:  "SSL_CERT_DIR",
+			Value: "/etc/ssl/certs:" + common.ArgoCDNotificationsRootCAPath,
+		})
+	}
 	notificationEnv := cr.Spec.Notifications.Env
 	// Let user specify their own environment first
-	notificationEnv = argoutil.EnvMerge(notificationEnv, proxyEnvVars(), false)
+	notificationEnv = argoutil.EnvMerge(notificationEnv, defaultEnv, false)
+	notificationEnv = argoutil.EnvMerge(notificationEnv, getOTelResourceAttributesEnv(cr), false)
+	notificationEnv = argoutil.EnvMerge(notificationEnv, getCryptoPolicyEnv(cr), false)
 
 	podSpec := &desiredDeployment.Spec.Template.Spec
 	podSpec.SecurityContext = &corev1.PodSecurityContext{
@@ -406,6 +456,32 @@ func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoproj.ArgoCD,
 		},
 		WorkingDir: "/app",
 	}}
+	if ref := cr.Spec.Notifications.RootCASecretRef; ref != nil {
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "notifications-root-ca",
+			MountPath: common.ArgoCDNotificationsRootCAPath,
+			ReadOnly:  true,
+		})
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: "notifications-root-ca",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: ref.Name,
+					Items: []corev1.KeyToPath{{
+						Key:  ref.Key,
+						Path: "ca.crt",
+					}},
+					Optional: ref.Optional,
+				},
+			},
+		})
+	}
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, cr.Spec.Notifications.VolumeMounts...)
+	podSpec.Volumes = append(podSpec.Volumes, cr.Spec.Notifications.Volumes...)
+	applySecurityContext(podSpec, cr.Spec.Notifications.PodSecurityContext, cr.Spec.Notifications.SecurityContext, common.ArgoCDNotificationsControllerComponent)
+	applyTerminationGracePeriod(podSpec, cr.Spec.Notifications.TerminationGracePeriodSeconds)
+	applyDNSConfig(podSpec, cr.Spec.Notifications.DNSPolicy, cr.Spec.Notifications.DNSConfig)
+	applyLogSidecar(cr, podSpec, common.ArgoCDNotificationsControllerComponent)
 
 	// fetch existing deployment by name
 	deploymentChanged := false
@@ -425,18 +501,22 @@ func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoproj.ArgoCD,
 			return err
 		}
 
-		log.Info(fmt.Sprintf("Creating deployment %s", desiredDeployment.Name))
+		instanceLog(cr).Info(fmt.Sprintf("Creating deployment %s", desiredDeployment.Name))
 		return r.Client.Create(context.TODO(), desiredDeployment)
 	}
 
 	// deployment exists but shouldn't, so it should be deleted
 	if !cr.Spec.Notifications.Enabled {
-		log.Info(fmt.Sprintf("Deleting deployment %s as notifications is disabled", existingDeployment.Name))
+		instanceLog(cr).Info(fmt.Sprintf("Deleting deployment %s as notifications is disabled", existingDeployment.Name))
 		return r.Client.Delete(context.TODO(), existingDeployment)
 	}
 
 	// deployment exists and should. Reconcile deployment if changed
 	updateNodePlacement(existingDeployment, desiredDeployment, &deploymentChanged)
+	updateSecurityContext(&existingDeployment.Spec.Template.Spec, &desiredDeployment.Spec.Template.Spec, &deploymentChanged)
+	updateTerminationGracePeriod(&existingDeployment.Spec.Template.Spec, &desiredDeployment.Spec.Template.Spec, &deploymentChanged)
+	updateDNSConfig(&existingDeployment.Spec.Template.Spec, &desiredDeployment.Spec.Template.Spec, &deploymentChanged)
+	updateLogSidecar(&existingDeployment.Spec.Template.Spec, &desiredDeployment.Spec.Template.Spec, &deploymentChanged)
 
 	if existingDeployment.Spec.Template.Spec.Containers[0].Image != desiredDeployment.Spec.Template.Spec.Containers[0].Image {
 		existingDeployment.Spec.Template.Spec.Containers[0].Image = desiredDeployment.Spec.Template.Spec.Containers[0].Image
@@ -460,6 +540,7 @@ func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoproj.ArgoCD,
 		deploymentChanged = true
 	}
 
+	desiredDeployment.Spec.Replicas = getBudgetedReplicas(cr, existingDeployment.Spec.Replicas, desiredDeployment.Spec.Replicas)
 	if !reflect.DeepEqual(existingDeployment.Spec.Replicas, desiredDeployment.Spec.Replicas) {
 		existingDeployment.Spec.Replicas = desiredDeployment.Spec.Replicas
 		deploymentChanged = true
@@ -540,8 +621,7 @@ func (r *ReconcileArgoCD) reconcileNotificationsServiceMonitor(cr *argoproj.Argo
 	name := fmt.Sprintf("%s-%s", cr.Name, "notifications-controller-metrics")
 	serviceMonitor := newServiceMonitorWithName(name, cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, serviceMonitor.Name, serviceMonitor) {
-		// Service found, do nothing
-		return nil
+		return r.reconcileServiceMonitorLabels(cr, serviceMonitor)
 	}
 
 	serviceMonitor.Spec.Selector = v1.LabelSelector{
@@ -579,7 +659,7 @@ func (r *ReconcileArgoCD) reconcileNotificationsSecret(cr *argoproj.ArgoCD) erro
 	if secretExists {
 		// secret exists but shouldn't, so it should be deleted
 		if !cr.Spec.Notifications.Enabled {
-			log.Info(fmt.Sprintf("Deleting secret %s as notifications is disabled", existingSecret.Name))
+			instanceLog(cr).Info(fmt.Sprintf("Deleting secret %s as notifications is disabled", existingSecret.Name))
 			return r.Client.Delete(context.TODO(), existingSecret)
 		}
 
@@ -597,7 +677,7 @@ func (r *ReconcileArgoCD) reconcileNotificationsSecret(cr *argoproj.ArgoCD) erro
 		return err
 	}
 
-	log.Info(fmt.Sprintf("Creating secret %s", desiredSecret.Name))
+	instanceLog(cr).Info(fmt.Sprintf("Creating secret %s", desiredSecret.Name))
 	err := r.Client.Create(context.TODO(), desiredSecret)
 	if err != nil {
 		return err
@@ -617,7 +697,7 @@ func getNotificationsCommand(cr *argoproj.ArgoCD) []string {
 	if cr.Spec.Repo.IsEnabled() {
 		cmd = append(cmd, "--argocd-repo-server", getRepoServerAddress(cr))
 	} else {
-		log.Info("Repo Server is disabled. This would affect the functioning of Notification Controller.")
+		instanceLog(cr).Info("Repo Server is disabled. This would affect the functioning of Notification Controller.")
 	}
 
 	return cmd
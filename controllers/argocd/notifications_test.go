@@ -504,3 +504,107 @@ func TestReconcileNotifications_testLogLevel(t *testing.T) {
 		t.Fatalf("operator failed to override the manual changes to notification controller:\n%s", diff)
 	}
 }
+
+func TestReconcileNotifications_rootCAAndVolumes(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Notifications.Enabled = true
+		a.Spec.Notifications.Env = []corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3128"}}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	sa := corev1.ServiceAccount{}
+
+	assert.NoError(t, r.reconcileNotificationsDeployment(a, &sa))
+
+	// Trusting a private CA and mounting an extra volume updates the existing Deployment
+	a.Spec.Notifications.RootCASecretRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "corporate-ca"},
+		Key:                  "ca.pem",
+	}
+	a.Spec.Notifications.Volumes = []corev1.Volume{{
+		Name:         "templates",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}
+	a.Spec.Notifications.VolumeMounts = []corev1.VolumeMount{{Name: "templates", MountPath: "/app/templates"}}
+	assert.NoError(t, r.reconcileNotificationsDeployment(a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      a.Name + "-notifications-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3128"},
+		{Name: "SSL_CERT_DIR", Value: "/etc/ssl/certs:" + common.ArgoCDNotificationsRootCAPath},
+	}, container.Env)
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "notifications-root-ca", MountPath: common.ArgoCDNotificationsRootCAPath, ReadOnly: true})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "templates", MountPath: "/app/templates"})
+
+	volumes := deployment.Spec.Template.Spec.Volumes
+	assert.Len(t, volumes, 4)
+	assert.Equal(t, "corporate-ca", volumes[2].Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "ca.pem", Path: "ca.crt"}}, volumes[2].Secret.Items)
+	assert.Equal(t, "templates", volumes[3].Name)
+}
+
+func TestReconcileNotifications_subscriptions(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Notifications.Enabled = true
+		a.Spec.Notifications.Subscriptions = []argoproj.ArgoCDNotificationsSubscription{
+			{
+				Recipients: []string{"slack:deployments"},
+				Triggers:   []string{"on-sync-failed", "on-health-degraded"},
+				Selector:   "team=platform",
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, v1alpha1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileNotificationsConfigurationCR(a))
+
+	nc := &v1alpha1.NotificationsConfiguration{}
+	key := types.NamespacedName{Name: DefaultNotificationsConfigurationInstanceName, Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, nc))
+	expected := `- recipients:
+  - slack:deployments
+  selector: team=platform
+  triggers:
+  - on-sync-failed
+  - on-health-degraded
+`
+	assert.Equal(t, expected, nc.Spec.Subscriptions["subscriptions"])
+	assert.Equal(t, "true", nc.Annotations[common.ArgoCDManagedSubscriptionsAnnotation])
+	assert.NotEmpty(t, nc.Spec.Triggers)
+
+	// The subscriptions set by the operator are removed with the default subscriptions
+	a.Spec.Notifications.Subscriptions = nil
+	assert.NoError(t, r.reconcileNotificationsConfigurationCR(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, nc))
+	assert.NotContains(t, nc.Spec.Subscriptions, "subscriptions")
+	assert.NotContains(t, nc.Annotations, common.ArgoCDManagedSubscriptionsAnnotation)
+
+	// The subscriptions set by the user are left untouched
+	nc.Spec.Subscriptions = map[string]string{"subscriptions": "- recipients:\n  - email:ops@example.com\n"}
+	assert.NoError(t, r.Client.Update(context.TODO(), nc))
+	assert.NoError(t, r.reconcileNotificationsConfigurationCR(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, nc))
+	assert.Equal(t, "- recipients:\n  - email:ops@example.com\n", nc.Spec.Subscriptions["subscriptions"])
+}
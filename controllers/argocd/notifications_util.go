@@ -1,6 +1,25 @@
 package argocd
 
-import argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+import (
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+// getNotificationsSubscriptions will return the default subscriptions of the given ArgoCD in the format of the
+// subscriptions key of the notifications ConfigMap, or an empty string if none is set.
+func getNotificationsSubscriptions(cr *argoproj.ArgoCD) (string, error) {
+	if len(cr.Spec.Notifications.Subscriptions) == 0 {
+		return "", nil
+	}
+	subscriptions, err := yaml.Marshal(cr.Spec.Notifications.Subscriptions)
+	if err != nil {
+		return "", fmt.Errorf("failed to render the notifications subscriptions of ArgoCD %s/%s: %w", cr.Namespace, cr.Name, err)
+	}
+	return string(subscriptions), nil
+}
 
 // getDefaultNotificationsContext returns an empty map for context
 func getDefaultNotificationsContext() map[string]string {
@@ -2,15 +2,28 @@ package argocd
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v2"
 
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
 
 	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// resourceInclusion is an entry of the resource.inclusions setting of Argo CD.
+type resourceInclusion struct {
+	APIGroups []string `yaml:"apiGroups"`
+	Kinds     []string `yaml:"kinds"`
+	Clusters  []string `yaml:"clusters"`
+}
+
 func policyRuleForApplicationController() []v1.PolicyRule {
 
 	return []v1.PolicyRule{
@@ -28,6 +41,113 @@ func policyRuleForApplicationController() []v1.PolicyRule {
 	}
 }
 
+// policyRuleForApplicationControllerMinimal returns the policy rules of the Application Controller for the minimal
+// RBAC mode, which only grant access to the resource types listed in the resource inclusions of the given ArgoCD.
+func policyRuleForApplicationControllerMinimal(cr *argoproj.ArgoCD, mapper meta.RESTMapper) []v1.PolicyRule {
+	rules := []v1.PolicyRule{
+		{
+			APIGroups: []string{"argoproj.io"},
+			Resources: []string{"applications", "appprojects"},
+			Verbs:     []string{"*"},
+		}, {
+			APIGroups: []string{""},
+			Resources: []string{"configmaps", "secrets"},
+			Verbs:     []string{"get", "list", "watch"},
+		}, {
+			APIGroups: []string{""},
+			Resources: []string{"events"},
+			Verbs:     []string{"create", "list"},
+		},
+	}
+
+	var inclusions []resourceInclusion
+	if err := yaml.Unmarshal([]byte(getResourceInclusions(cr)), &inclusions); err != nil {
+		instanceLog(cr).Error(err, "failed to parse the resource inclusions, granting the base permissions only to the application controller")
+		return rules
+	}
+	if len(inclusions) == 0 {
+		instanceLog(cr).Info("no resource inclusions set for the minimal RBAC mode, granting the base permissions only to the application controller")
+	}
+
+	for _, inclusion := range inclusions {
+		// As in Argo CD, an empty list of API groups or kinds matches everything
+		groups := []string{"*"}
+		if len(inclusion.APIGroups) > 0 && !containsString(inclusion.APIGroups, "*") {
+			groups = inclusion.APIGroups
+		}
+		resources := []string{"*"}
+		if len(inclusion.Kinds) > 0 && !containsString(inclusion.Kinds, "*") {
+			resources = getResourcesForKinds(mapper, groups, inclusion.Kinds)
+		}
+		for i, group := range groups {
+			// Policy rules do not support partial wildcards
+			if strings.Contains(group, "*") {
+				groups[i] = "*"
+			}
+		}
+
+		rules = append(rules, v1.PolicyRule{
+			APIGroups: groups,
+			Resources: resources,
+			Verbs:     []string{"*"},
+		})
+	}
+
+	return rules
+}
+
+// getResourcesForKinds returns the sorted resource names of the given kinds in the given API groups. The resource
+// names are looked up in the given RESTMapper, and derived from the kind when the mapping is unknown.
+func getResourcesForKinds(mapper meta.RESTMapper, groups []string, kinds []string) []string {
+	found := make(map[string]bool)
+	for _, kind := range kinds {
+		for _, group := range groups {
+			resource := ""
+			if mapper != nil && !strings.Contains(group, "*") && !strings.Contains(kind, "*") {
+				if mapping, err := mapper.RESTMapping(schema.GroupKind{Group: group, Kind: kind}); err == nil {
+					resource = mapping.Resource.Resource
+				}
+			}
+			if resource == "" {
+				resource = pluralizeKind(kind)
+			}
+			found[resource] = true
+		}
+	}
+
+	resources := make([]string, 0, len(found))
+	for resource := range found {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	return resources
+}
+
+// pluralizeKind returns the conventional resource name of the given kind, e.g. "ingresses" for "Ingress".
+func pluralizeKind(kind string) string {
+	if strings.Contains(kind, "*") {
+		return "*"
+	}
+	resource := strings.ToLower(kind)
+	switch {
+	case strings.HasSuffix(resource, "s"), strings.HasSuffix(resource, "x"),
+		strings.HasSuffix(resource, "ch"), strings.HasSuffix(resource, "sh"):
+		return resource + "es"
+	case strings.HasSuffix(resource, "y") && len(resource) > 1 && !strings.ContainsAny(resource[len(resource)-2:len(resource)-1], "aeiou"):
+		return resource[:len(resource)-1] + "ies"
+	}
+	return resource + "s"
+}
+
+// getApplicationControllerPolicyRules returns the policy rules of the Application Controller for the RBAC mode of
+// the given ArgoCD, or the given default rules.
+func getApplicationControllerPolicyRules(cr *argoproj.ArgoCD, mapper meta.RESTMapper, defaultRules []v1.PolicyRule) []v1.PolicyRule {
+	if cr.Spec.Controller.RBAC == argoproj.ArgoCDControllerRBACModeMinimal {
+		return policyRuleForApplicationControllerMinimal(cr, mapper)
+	}
+	return defaultRules
+}
+
 func policyRuleForApplicationControllerView() []v1.PolicyRule {
 
 	return []v1.PolicyRule{
@@ -282,6 +402,51 @@ func policyRuleForNotificationsController() []v1.PolicyRule {
 	}
 }
 
+func policyRuleForImageUpdater() []v1.PolicyRule {
+	return []v1.PolicyRule{
+		{
+			APIGroups: []string{
+				"argoproj.io",
+			},
+			Resources: []string{
+				"applications",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"patch",
+				"update",
+				"watch",
+			},
+		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"configmaps",
+				"secrets",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"watch",
+			},
+		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"events",
+			},
+			Verbs: []string{
+				"create",
+			},
+		},
+	}
+}
+
 func policyRuleForServerApplicationSourceNamespaces() []v1.PolicyRule {
 	return []v1.PolicyRule{
 		{
@@ -0,0 +1,93 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+// profilePreset holds the defaults applied for a sizing profile of an Argo CD instance.
+// Values explicitly set in the ArgoCD spec always take precedence over the preset.
+type profilePreset struct {
+	statusProcessors    int32
+	operationProcessors int32
+	parallelismLimit    int32
+	repoReplicas        int32
+	controllerResources corev1.ResourceRequirements
+	repoResources       corev1.ResourceRequirements
+	redisResources      corev1.ResourceRequirements
+}
+
+// profileResources returns the ResourceRequirements for the given requests and limits.
+func profileResources(requestCPU, requestMemory, limitCPU, limitMemory string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(requestCPU),
+			corev1.ResourceMemory: resource.MustParse(requestMemory),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(limitCPU),
+			corev1.ResourceMemory: resource.MustParse(limitMemory),
+		},
+	}
+}
+
+var profilePresets = map[argoproj.ArgoCDProfileType]profilePreset{
+	argoproj.ArgoCDProfileSmall: {
+		statusProcessors:    20,
+		operationProcessors: 10,
+		parallelismLimit:    10,
+		repoReplicas:        1,
+		controllerResources: profileResources("250m", "1Gi", "1", "2Gi"),
+		repoResources:       profileResources("250m", "256Mi", "1", "1Gi"),
+		redisResources:      profileResources("250m", "128Mi", "500m", "256Mi"),
+	},
+	argoproj.ArgoCDProfileMedium: {
+		statusProcessors:    50,
+		operationProcessors: 25,
+		parallelismLimit:    20,
+		repoReplicas:        2,
+		controllerResources: profileResources("500m", "2Gi", "2", "4Gi"),
+		repoResources:       profileResources("500m", "512Mi", "1", "2Gi"),
+		redisResources:      profileResources("500m", "256Mi", "1", "1Gi"),
+	},
+	argoproj.ArgoCDProfileLarge: {
+		statusProcessors:    100,
+		operationProcessors: 50,
+		parallelismLimit:    50,
+		repoReplicas:        3,
+		controllerResources: profileResources("1", "4Gi", "4", "8Gi"),
+		repoResources:       profileResources("1", "1Gi", "2", "4Gi"),
+		redisResources:      profileResources("1", "1Gi", "2", "2Gi"),
+	},
+}
+
+// getProfilePreset will return the sizing preset for the profile set on the given ArgoCD.
+// The second return value is false when no profile, or an unknown profile, has been set.
+func getProfilePreset(cr *argoproj.ArgoCD) (profilePreset, bool) {
+	if cr.Spec.Profile == "" {
+		return profilePreset{}, false
+	}
+	preset, ok := profilePresets[cr.Spec.Profile]
+	if !ok {
+		instanceLog(cr).Info(fmt.Sprintf("Found '%s' as profile, which is invalid. Ignoring the profile.", cr.Spec.Profile))
+	}
+	return preset, ok
+}
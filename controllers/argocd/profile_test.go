@@ -0,0 +1,94 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestProfile_Defaults(t *testing.T) {
+	a := makeTestArgoCD()
+
+	assert.Equal(t, common.ArgoCDDefaultServerStatusProcessors, getArgoServerStatusProcessors(a))
+	assert.Equal(t, common.ArgoCDDefaultServerOperationProcessors, getArgoServerOperationProcessors(a))
+	assert.Equal(t, common.ArgoCDDefaultControllerParallelismLimit, getArgoControllerParellismLimit(a))
+	assert.Nil(t, getArgoCDRepoServerReplicas(a))
+	assert.Equal(t, corev1.ResourceRequirements{}, getArgoApplicationControllerResources(a))
+	assert.Equal(t, corev1.ResourceRequirements{}, getArgoRepoResources(a))
+	assert.Equal(t, corev1.ResourceRequirements{}, getRedisResources(a))
+}
+
+func TestProfile_Presets(t *testing.T) {
+	for profile, preset := range profilePresets {
+		t.Run(string(profile), func(t *testing.T) {
+			a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Spec.Profile = profile
+			})
+
+			assert.Equal(t, preset.statusProcessors, getArgoServerStatusProcessors(a))
+			assert.Equal(t, preset.operationProcessors, getArgoServerOperationProcessors(a))
+			assert.Equal(t, preset.parallelismLimit, getArgoControllerParellismLimit(a))
+			assert.Equal(t, preset.repoReplicas, *getArgoCDRepoServerReplicas(a))
+			assert.Equal(t, preset.controllerResources, getArgoApplicationControllerResources(a))
+			assert.Equal(t, preset.repoResources, getArgoRepoResources(a))
+			assert.Equal(t, preset.redisResources, getRedisResources(a))
+		})
+	}
+}
+
+func TestProfile_ExplicitValuesTakePrecedence(t *testing.T) {
+	replicas := int32(5)
+	resources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+	}
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Profile = argoproj.ArgoCDProfileLarge
+		a.Spec.Controller.Processors.Status = 7
+		a.Spec.Controller.Processors.Operation = 3
+		a.Spec.Controller.ParallelismLimit = 2
+		a.Spec.Controller.Resources = resources
+		a.Spec.Repo.Replicas = &replicas
+		a.Spec.Repo.Resources = resources
+		a.Spec.Redis.Resources = resources
+	})
+
+	assert.Equal(t, int32(7), getArgoServerStatusProcessors(a))
+	assert.Equal(t, int32(3), getArgoServerOperationProcessors(a))
+	assert.Equal(t, int32(2), getArgoControllerParellismLimit(a))
+	assert.Equal(t, replicas, *getArgoCDRepoServerReplicas(a))
+	assert.Equal(t, *resources, getArgoApplicationControllerResources(a))
+	assert.Equal(t, *resources, getArgoRepoResources(a))
+	assert.Equal(t, *resources, getRedisResources(a))
+}
+
+func TestProfile_Invalid(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Profile = "huge"
+	})
+
+	_, ok := getProfilePreset(a)
+	assert.False(t, ok)
+	assert.Equal(t, common.ArgoCDDefaultServerStatusProcessors, getArgoServerStatusProcessors(a))
+	assert.Nil(t, getArgoCDRepoServerReplicas(a))
+}
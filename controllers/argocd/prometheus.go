@@ -17,6 +17,8 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -50,6 +52,12 @@ func getPrometheusReplicas(cr *argoproj.ArgoCD) *int32 {
 	return &replicas
 }
 
+// isPrometheusManaged returns true if the operator manages a Prometheus for the given ArgoCD, rather than generating
+// the ServiceMonitors scraped by an existing Prometheus.
+func isPrometheusManaged(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Prometheus.Enabled && cr.Spec.Prometheus.Mode != argoproj.ArgoCDPrometheusModeExternal
+}
+
 // IsPrometheusAPIAvailable returns true if the Prometheus API is present.
 func IsPrometheusAPIAvailable() bool {
 	return prometheusAPIFound
@@ -114,11 +122,29 @@ func newServiceMonitorWithName(name string, cr *argoproj.ArgoCD) *monitoringv1.S
 	lbls := svcmon.ObjectMeta.Labels
 	lbls[common.ArgoCDKeyName] = name
 	lbls[common.ArgoCDKeyRelease] = "prometheus-operator"
+	// The labels of the ArgoCD win, e.g. the release matched by an existing Prometheus
+	for key, value := range cr.Spec.Prometheus.ServiceMonitorLabels {
+		lbls[key] = value
+	}
 	svcmon.ObjectMeta.Labels = lbls
+	if keys := getServiceMonitorLabelKeys(cr); keys != "" {
+		svcmon.ObjectMeta.Annotations = map[string]string{common.ArgoCDServiceMonitorLabelsAnnotation: keys}
+	}
 
 	return svcmon
 }
 
+// getServiceMonitorLabelKeys will return the sorted, comma separated keys of the ServiceMonitor labels of the given
+// ArgoCD.
+func getServiceMonitorLabelKeys(cr *argoproj.ArgoCD) string {
+	keys := make([]string, 0, len(cr.Spec.Prometheus.ServiceMonitorLabels))
+	for key := range cr.Spec.Prometheus.ServiceMonitorLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
 // newServiceMonitorWithSuffix returns a new ServiceMonitor instance for the given ArgoCD using the given suffix.
 func newServiceMonitorWithSuffix(suffix string, cr *argoproj.ArgoCD) *monitoringv1.ServiceMonitor {
 	return newServiceMonitorWithName(fmt.Sprintf("%s-%s", cr.Name, suffix), cr)
@@ -132,7 +158,7 @@ func (r *ReconcileArgoCD) reconcileMetricsServiceMonitor(cr *argoproj.ArgoCD) er
 			// ServiceMonitor exists but enabled flag has been set to false, delete the ServiceMonitor
 			return r.Client.Delete(context.TODO(), sm)
 		}
-		return nil // ServiceMonitor found, do nothing
+		return r.reconcileServiceMonitorLabels(cr, sm)
 	}
 
 	if !cr.Spec.Prometheus.Enabled {
@@ -156,12 +182,65 @@ func (r *ReconcileArgoCD) reconcileMetricsServiceMonitor(cr *argoproj.ArgoCD) er
 	return r.Client.Create(context.TODO(), sm)
 }
 
-// reconcilePrometheus will ensure that Prometheus is present for ArgoCD metrics.
+// reconcileServiceMonitorLabels will ensure that the given existing ServiceMonitor has the ServiceMonitor labels of
+// the given ArgoCD, so that a Prometheus selecting them scrapes it. The labels set from the ArgoCD on the last update
+// but removed from it since are removed, or reset to the value set by the operator.
+func (r *ReconcileArgoCD) reconcileServiceMonitorLabels(cr *argoproj.ArgoCD, sm *monitoringv1.ServiceMonitor) error {
+	desired := newServiceMonitorWithName(sm.Name, cr).Labels
+	if sm.Labels == nil {
+		sm.Labels = map[string]string{}
+	}
+
+	changed := false
+	for key := range getAnnotatedKeys(sm, common.ArgoCDServiceMonitorLabelsAnnotation) {
+		if _, ok := cr.Spec.Prometheus.ServiceMonitorLabels[key]; ok {
+			continue
+		}
+		if value, ok := desired[key]; ok {
+			if sm.Labels[key] != value {
+				sm.Labels[key] = value
+				changed = true
+			}
+		} else if _, ok := sm.Labels[key]; ok {
+			delete(sm.Labels, key)
+			changed = true
+		}
+	}
+	for key, value := range cr.Spec.Prometheus.ServiceMonitorLabels {
+		if sm.Labels[key] != value {
+			sm.Labels[key] = value
+			changed = true
+		}
+	}
+
+	if keys := getServiceMonitorLabelKeys(cr); sm.Annotations[common.ArgoCDServiceMonitorLabelsAnnotation] != keys {
+		if keys == "" {
+			delete(sm.Annotations, common.ArgoCDServiceMonitorLabelsAnnotation)
+		} else {
+			if sm.Annotations == nil {
+				sm.Annotations = map[string]string{}
+			}
+			sm.Annotations[common.ArgoCDServiceMonitorLabelsAnnotation] = keys
+		}
+		changed = true
+	}
+
+	if !changed {
+		return nil // ServiceMonitor found, do nothing
+	}
+	instanceLog(cr).Info(fmt.Sprintf("updating the labels of ServiceMonitor %s", sm.Name))
+	return r.Client.Update(context.TODO(), sm)
+}
+
+// reconcilePrometheus will ensure that Prometheus is present for ArgoCD metrics when the operator manages it.
 func (r *ReconcileArgoCD) reconcilePrometheus(cr *argoproj.ArgoCD) error {
 	prometheus := newPrometheus(cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, prometheus.Name, prometheus) {
-		if !cr.Spec.Prometheus.Enabled {
-			// Prometheus exists but enabled flag has been set to false, delete the Prometheus
+		if !isPrometheusManaged(cr) {
+			if cr.Spec.Prometheus.Enabled && !metav1.IsControlledBy(prometheus, cr) {
+				return nil // External Prometheus sharing the name of the ArgoCD, leave it alone
+			}
+			// Prometheus exists but has been disabled or replaced by an external one, delete the Prometheus
 			return r.Client.Delete(context.TODO(), prometheus)
 		}
 		if hasPrometheusSpecChanged(prometheus, cr) {
@@ -171,8 +250,8 @@ func (r *ReconcileArgoCD) reconcilePrometheus(cr *argoproj.ArgoCD) error {
 		return nil // Prometheus found, do nothing
 	}
 
-	if !cr.Spec.Prometheus.Enabled {
-		return nil // Prometheus not enabled, do nothing.
+	if !isPrometheusManaged(cr) {
+		return nil // Prometheus not managed, do nothing.
 	}
 
 	prometheus.Spec.Replicas = getPrometheusReplicas(cr)
@@ -193,7 +272,7 @@ func (r *ReconcileArgoCD) reconcileRepoServerServiceMonitor(cr *argoproj.ArgoCD)
 			// ServiceMonitor exists but enabled flag has been set to false, delete the ServiceMonitor
 			return r.Client.Delete(context.TODO(), sm)
 		}
-		return nil // ServiceMonitor found, do nothing
+		return r.reconcileServiceMonitorLabels(cr, sm)
 	}
 
 	if !cr.Spec.Prometheus.Enabled {
@@ -225,7 +304,7 @@ func (r *ReconcileArgoCD) reconcileServerMetricsServiceMonitor(cr *argoproj.Argo
 			// ServiceMonitor exists but enabled flag has been set to false, delete the ServiceMonitor
 			return r.Client.Delete(context.TODO(), sm)
 		}
-		return nil // ServiceMonitor found, do nothing
+		return r.reconcileServiceMonitorLabels(cr, sm)
 	}
 
 	if !cr.Spec.Prometheus.Enabled {
@@ -249,6 +328,109 @@ func (r *ReconcileArgoCD) reconcileServerMetricsServiceMonitor(cr *argoproj.Argo
 	return r.Client.Create(context.TODO(), sm)
 }
 
+// reconcileRedisHAProxyServiceMonitor will ensure that the ServiceMonitor is present for the Redis HA Proxy metrics.
+func (r *ReconcileArgoCD) reconcileRedisHAProxyServiceMonitor(cr *argoproj.ArgoCD) error {
+	enabled := cr.Spec.Prometheus.Enabled && cr.Spec.HA.Enabled && cr.Spec.HA.RedisProxyMetrics && cr.Spec.Redis.IsEnabled()
+
+	sm := newServiceMonitorWithSuffix("redis-ha-haproxy-metrics", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
+		if !enabled {
+			// ServiceMonitor exists but the metrics have been disabled, delete the ServiceMonitor
+			return r.Client.Delete(context.TODO(), sm)
+		}
+		return r.reconcileServiceMonitorLabels(cr, sm)
+	}
+
+	if !enabled {
+		return nil // Redis HA Proxy metrics not enabled, do nothing.
+	}
+
+	sm.Spec.Selector = metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			common.ArgoCDKeyName: nameWithSuffix("redis-ha-haproxy", cr),
+		},
+	}
+	sm.Spec.Endpoints = []monitoringv1.Endpoint{
+		{
+			Port: common.ArgoCDKeyMetrics,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(cr, sm, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), sm)
+}
+
+// reconcileRedisExporterServiceMonitor will ensure that the ServiceMonitor is present for the metrics of the Redis
+// exporter of the Redis HA server pods.
+func (r *ReconcileArgoCD) reconcileRedisExporterServiceMonitor(cr *argoproj.ArgoCD) error {
+	enabled := cr.Spec.Prometheus.Enabled && cr.Spec.HA.Enabled && isRedisExporterEnabled(cr) && cr.Spec.Redis.IsEnabled()
+
+	sm := newServiceMonitorWithSuffix("redis-ha-metrics", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
+		if !enabled {
+			// ServiceMonitor exists but the metrics have been disabled, delete the ServiceMonitor
+			return r.Client.Delete(context.TODO(), sm)
+		}
+		return r.reconcileServiceMonitorLabels(cr, sm)
+	}
+
+	if !enabled {
+		return nil // Redis exporter not enabled, do nothing.
+	}
+
+	sm.Spec.Selector = metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			common.ArgoCDKeyName: nameWithSuffix("redis-ha", cr),
+		},
+	}
+	sm.Spec.Endpoints = []monitoringv1.Endpoint{
+		{
+			Port: common.ArgoCDKeyMetrics,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(cr, sm, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), sm)
+}
+
+// reconcileDexServiceMonitor will ensure that the ServiceMonitor is present for the Dex metrics Service.
+func (r *ReconcileArgoCD) reconcileDexServiceMonitor(cr *argoproj.ArgoCD) error {
+	enabled := cr.Spec.Prometheus.Enabled && UseDex(cr)
+
+	sm := newServiceMonitorWithSuffix("dex-server-metrics", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
+		if !enabled {
+			// ServiceMonitor exists but either Prometheus or Dex has been disabled, delete the ServiceMonitor
+			return r.Client.Delete(context.TODO(), sm)
+		}
+		return r.reconcileServiceMonitorLabels(cr, sm)
+	}
+
+	if !enabled {
+		return nil // Dex metrics not scraped, do nothing.
+	}
+
+	sm.Spec.Selector = metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			common.ArgoCDKeyName: nameWithSuffix("dex-server-metrics", cr),
+		},
+	}
+	sm.Spec.Endpoints = []monitoringv1.Endpoint{
+		{
+			Port: common.ArgoCDKeyMetrics,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(cr, sm, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), sm)
+}
+
 // reconcilePrometheusRule reconciles the PrometheusRule that triggers alerts based on workload statuses
 func (r *ReconcileArgoCD) reconcilePrometheusRule(cr *argoproj.ArgoCD) error {
 
@@ -258,7 +440,7 @@ func (r *ReconcileArgoCD) reconcilePrometheusRule(cr *argoproj.ArgoCD) error {
 
 		if !cr.Spec.Monitoring.Enabled {
 			// PrometheusRule exists but enabled flag has been set to false, delete the PrometheusRule
-			log.Info("instance monitoring disabled, deleting component status tracking prometheusRule")
+			instanceLog(cr).Info("instance monitoring disabled, deleting component status tracking prometheusRule")
 			return r.Client.Delete(context.TODO(), promRule)
 		}
 		return nil // PrometheusRule found, do nothing
@@ -379,7 +561,7 @@ func (r *ReconcileArgoCD) reconcilePrometheusRule(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
-	log.Info("instance monitoring enabled, creating component status tracking prometheusRule")
+	instanceLog(cr).Info("instance monitoring enabled, creating component status tracking prometheusRule")
 	return r.Client.Create(context.TODO(), promRule) // Create PrometheusRule
 }
 
@@ -7,12 +7,15 @@ import (
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
 )
 
 func TestReconcileWorkloadStatusAlertRule(t *testing.T) {
@@ -208,3 +211,198 @@ func TestReconcileWorkloadStatusAlertRule(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileArgoCD_reconcileRedisHAProxyMetrics(t *testing.T) {
+	t.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Prometheus.Enabled = true
+		cr.Spec.HA.Enabled = true
+		cr.Spec.HA.RedisProxyMetrics = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, monitoringv1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.Contains(t, getRedisHAProxyConfig(a, false), "bind *:9101")
+
+	assert.NoError(t, r.reconcileRedisHAProxyService(a))
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-redis-ha-haproxy", Namespace: a.Namespace}, svc))
+	assert.Len(t, svc.Spec.Ports, 2)
+	assert.Equal(t, common.ArgoCDKeyMetrics, svc.Spec.Ports[1].Name)
+	assert.Equal(t, int32(common.ArgoCDDefaultRedisHAProxyMetricsPort), svc.Spec.Ports[1].Port)
+
+	assert.NoError(t, r.reconcileRedisHAProxyServiceMonitor(a))
+	sm := &monitoringv1.ServiceMonitor{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-redis-ha-haproxy-metrics", Namespace: a.Namespace}, sm))
+	assert.Equal(t, a.Name+"-redis-ha-haproxy", sm.Spec.Selector.MatchLabels[common.ArgoCDKeyName])
+	assert.Equal(t, common.ArgoCDKeyMetrics, sm.Spec.Endpoints[0].Port)
+
+	// Disabling the metrics removes the metrics port and the ServiceMonitor
+	a.Spec.HA.RedisProxyMetrics = false
+	assert.NotContains(t, getRedisHAProxyConfig(a, false), "prometheus-exporter")
+
+	assert.NoError(t, r.reconcileRedisHAProxyService(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: svc.Name, Namespace: a.Namespace}, svc))
+	assert.Len(t, svc.Spec.Ports, 1)
+
+	assert.NoError(t, r.reconcileRedisHAProxyServiceMonitor(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: sm.Name, Namespace: a.Namespace}, sm)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileArgoCD_reconcileRedisExporterMetrics(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Prometheus.Enabled = true
+		cr.Spec.HA.Enabled = true
+		cr.Spec.Redis.Exporter = &argoproj.ArgoCDRedisExporterSpec{Enabled: true}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, monitoringv1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRedisHAMasterService(a))
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-redis-ha", Namespace: a.Namespace}, svc))
+	assert.Contains(t, svc.Spec.Ports, corev1.ServicePort{
+		Name:       common.ArgoCDKeyMetrics,
+		Port:       common.ArgoCDDefaultRedisExporterPort,
+		Protocol:   corev1.ProtocolTCP,
+		TargetPort: intstr.FromString(common.ArgoCDKeyMetrics),
+	})
+
+	assert.NoError(t, r.reconcileRedisExporterServiceMonitor(a))
+	sm := &monitoringv1.ServiceMonitor{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-redis-ha-metrics", Namespace: a.Namespace}, sm))
+	assert.Equal(t, a.Name+"-redis-ha", sm.Spec.Selector.MatchLabels[common.ArgoCDKeyName])
+	assert.Equal(t, svc.Labels[common.ArgoCDKeyName], sm.Spec.Selector.MatchLabels[common.ArgoCDKeyName])
+	assert.Equal(t, common.ArgoCDKeyMetrics, sm.Spec.Endpoints[0].Port)
+
+	// Disabling the exporter removes the metrics port and the ServiceMonitor
+	a.Spec.Redis.Exporter.Enabled = false
+	assert.NoError(t, r.reconcileRedisHAMasterService(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: svc.Name, Namespace: a.Namespace}, svc))
+	assert.Equal(t, getRedisHAServicePorts(a), svc.Spec.Ports)
+
+	assert.NoError(t, r.reconcileRedisExporterServiceMonitor(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: sm.Name, Namespace: a.Namespace}, sm)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileArgoCD_reconcileDexMetrics(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Prometheus.Enabled = true
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex: &argoproj.ArgoCDDexSpec{
+				OpenShiftOAuth: true,
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, monitoringv1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileDexMetricsService(a))
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-dex-server-metrics", Namespace: a.Namespace}, svc))
+	assert.Equal(t, a.Name+"-dex-server", svc.Spec.Selector[common.ArgoCDKeyName])
+	assert.Equal(t, []corev1.ServicePort{
+		{
+			Name:       common.ArgoCDKeyMetrics,
+			Port:       common.ArgoCDDefaultDexMetricsPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexMetricsPort),
+		},
+	}, svc.Spec.Ports)
+
+	assert.NoError(t, r.reconcileDexServiceMonitor(a))
+	sm := &monitoringv1.ServiceMonitor{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-dex-server-metrics", Namespace: a.Namespace}, sm))
+	assert.Equal(t, a.Name+"-dex-server-metrics", sm.Spec.Selector.MatchLabels[common.ArgoCDKeyName])
+	assert.Equal(t, common.ArgoCDKeyMetrics, sm.Spec.Endpoints[0].Port)
+
+	// Disabling Prometheus removes the ServiceMonitor but keeps the Service
+	a.Spec.Prometheus.Enabled = false
+	assert.NoError(t, r.reconcileDexServiceMonitor(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: sm.Name, Namespace: a.Namespace}, sm)
+	assert.True(t, errors.IsNotFound(err))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: svc.Name, Namespace: a.Namespace}, svc))
+
+	// Disabling Dex removes the Service
+	a.Spec.SSO = nil
+	assert.NoError(t, r.reconcileDexMetricsService(a))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: svc.Name, Namespace: a.Namespace}, svc)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileArgoCD_reconcilePrometheus_externalMode(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Prometheus.Enabled = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, monitoringv1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// The operator manages a Prometheus by default
+	assert.NoError(t, r.reconcilePrometheus(a))
+	prometheus := &monitoringv1.Prometheus{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, prometheus))
+	assert.NoError(t, r.reconcileMetricsServiceMonitor(a))
+	sm := &monitoringv1.ServiceMonitor{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-metrics", Namespace: a.Namespace}, sm))
+	assert.Equal(t, fmt.Sprintf("http://prometheus-operated.%s.svc.cluster.local:9090", a.Namespace), getServerAutoscalePrometheusURL(a))
+
+	// In external mode, the Prometheus is removed and the ServiceMonitors are labelled for the existing Prometheus
+	a.Spec.Prometheus.Mode = argoproj.ArgoCDPrometheusModeExternal
+	a.Spec.Prometheus.ServiceMonitorLabels = map[string]string{"release": "kube-prometheus-stack"}
+	assert.NoError(t, r.reconcilePrometheus(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, prometheus)
+	assert.True(t, errors.IsNotFound(err))
+	assert.NoError(t, r.reconcileMetricsServiceMonitor(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: sm.Name, Namespace: a.Namespace}, sm))
+	assert.Equal(t, "kube-prometheus-stack", sm.Labels["release"])
+	assert.Empty(t, getServerAutoscalePrometheusURL(a))
+
+	assert.NoError(t, r.reconcileServerMetricsServiceMonitor(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-server-metrics", Namespace: a.Namespace}, sm))
+	assert.Equal(t, "kube-prometheus-stack", sm.Labels["release"])
+	assert.Equal(t, "release", sm.Annotations[common.ArgoCDServiceMonitorLabelsAnnotation])
+
+	// The labels removed from the ArgoCD are removed, or reset to the value set by the operator
+	a.Spec.Prometheus.ServiceMonitorLabels = map[string]string{"team": "platform"}
+	assert.NoError(t, r.reconcileServerMetricsServiceMonitor(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: sm.Name, Namespace: a.Namespace}, sm))
+	assert.Equal(t, "prometheus-operator", sm.Labels["release"])
+	assert.Equal(t, "platform", sm.Labels["team"])
+	assert.Equal(t, "team", sm.Annotations[common.ArgoCDServiceMonitorLabelsAnnotation])
+
+	a.Spec.Prometheus.ServiceMonitorLabels = nil
+	assert.NoError(t, r.reconcileServerMetricsServiceMonitor(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: sm.Name, Namespace: a.Namespace}, sm))
+	assert.NotContains(t, sm.Labels, "team")
+	assert.NotContains(t, sm.Annotations, common.ArgoCDServiceMonitorLabelsAnnotation)
+
+	// A Prometheus not created for the ArgoCD is left alone
+	external := newPrometheus(a)
+	assert.NoError(t, r.Client.Create(context.TODO(), external))
+	assert.NoError(t, r.reconcilePrometheus(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, prometheus))
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+// repoServerHelmWorkingDir is the directory holding the helm cache, config and data of the Repo server when its root
+// filesystem is read-only.
+const repoServerHelmWorkingDir = "/helm-working-dir"
+
+// writablePath is a path a container writes to, which must be backed by a writable volume when the root filesystem
+// of the container is read-only.
+type writablePath struct {
+	volume string
+	path   string
+}
+
+// repoServerWritablePaths are the paths written by the containers of the Repo server, keyed by container: the
+// plugins copied by the init container, the git and helm caches, and the GnuPG keyring.
+var repoServerWritablePaths = map[string][]writablePath{
+	"copyutil": {
+		{volume: "var-files", path: "/var/run/argocd"},
+	},
+	"argocd-repo-server": {
+		{volume: "tmp", path: "/tmp"},
+		{volume: "helm-working-dir", path: repoServerHelmWorkingDir},
+		{volume: "gpg-keyring", path: "/app/config/gpg/keys"},
+	},
+}
+
+// getRepoServerWritablePaths will return the paths written by the containers of the Repo server of the given ArgoCD,
+// keyed by container. Each config management plugin sidecar is given its own /tmp, which most plugins write to.
+func getRepoServerWritablePaths(cr *argoproj.ArgoCD) map[string][]writablePath {
+	writable := make(map[string][]writablePath, len(repoServerWritablePaths)+len(cr.Spec.Repo.SidecarContainers))
+	for name, paths := range repoServerWritablePaths {
+		writable[name] = paths
+	}
+	for i, sidecar := range cr.Spec.Repo.SidecarContainers {
+		writable[sidecar.Name] = append(writable[sidecar.Name], writablePath{volume: fmt.Sprintf("cmp-tmp-%d", i), path: "/tmp"})
+	}
+	return writable
+}
+
+// getRepoServerHelmEnv will return the environment pointing helm to its working directory, which is writable when
+// the root filesystem of the Repo server is read-only.
+func getRepoServerHelmEnv() []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{Name: "HELM_CACHE_HOME", Value: repoServerHelmWorkingDir},
+		{Name: "HELM_CONFIG_HOME", Value: repoServerHelmWorkingDir},
+		{Name: "HELM_DATA_HOME", Value: repoServerHelmWorkingDir},
+	}
+}
+
+// applyReadOnlyRootFilesystem will set readOnlyRootFilesystem on all the containers of the given pod spec, and mount
+// an emptyDir volume at each of the given writable paths of a container which is not mounted yet. An error is
+// returned when a writable path is mounted from a read-only volume, as the container would fail to start.
+func applyReadOnlyRootFilesystem(podSpec *corev1.PodSpec, writable map[string][]writablePath) error {
+	containers := []*corev1.Container{}
+	for i := range podSpec.InitContainers {
+		containers = append(containers, &podSpec.InitContainers[i])
+	}
+	for i := range podSpec.Containers {
+		containers = append(containers, &podSpec.Containers[i])
+	}
+
+	for _, c := range containers {
+		if c.SecurityContext == nil {
+			c.SecurityContext = &corev1.SecurityContext{}
+		} else {
+			// Copy the security context, which may be shared with the ArgoCD
+			c.SecurityContext = c.SecurityContext.DeepCopy()
+		}
+		c.SecurityContext.ReadOnlyRootFilesystem = boolPtr(true)
+
+		for _, wp := range writable[c.Name] {
+			mount := findVolumeMount(c.VolumeMounts, wp.path)
+			if mount == nil {
+				c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{Name: wp.volume, MountPath: wp.path})
+				if findVolume(podSpec.Volumes, wp.volume) == nil {
+					podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+						Name:         wp.volume,
+						VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					})
+				}
+				continue
+			}
+			if mount.ReadOnly || isReadOnlyVolume(findVolume(podSpec.Volumes, mount.Name)) {
+				return fmt.Errorf("container %s must be able to write to %s with a read-only root filesystem, but volume %s is read-only",
+					c.Name, wp.path, mount.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// findVolumeMount returns the volume mount of the given path, nil if the path is not mounted.
+func findVolumeMount(mounts []corev1.VolumeMount, path string) *corev1.VolumeMount {
+	for i := range mounts {
+		if mounts[i].MountPath == path {
+			return &mounts[i]
+		}
+	}
+	return nil
+}
+
+// findVolume returns the volume with the given name, nil if there is none.
+func findVolume(volumes []corev1.Volume, name string) *corev1.Volume {
+	for i := range volumes {
+		if volumes[i].Name == name {
+			return &volumes[i]
+		}
+	}
+	return nil
+}
+
+// isReadOnlyVolume returns true if the given volume is always mounted read-only by the kubelet.
+func isReadOnlyVolume(volume *corev1.Volume) bool {
+	if volume == nil {
+		return false
+	}
+	return volume.ConfigMap != nil || volume.Secret != nil || volume.DownwardAPI != nil || volume.Projected != nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// getDesiredRedisMode returns the Redis mode requested by the spec of the given ArgoCD.
+func getDesiredRedisMode(cr *argoproj.ArgoCD) string {
+	if cr.Spec.HA.Enabled {
+		return argoproj.ArgoCDRedisModeHA
+	}
+	return argoproj.ArgoCDRedisModeStandalone
+}
+
+// isRedisHAActive returns true if the Argo CD components connect to Redis through the HA proxy. It follows the mode
+// recorded in the status, which only changes once the Redis of the new mode is ready.
+func isRedisHAActive(cr *argoproj.ArgoCD) bool {
+	switch cr.Status.RedisMode {
+	case argoproj.ArgoCDRedisModeHA:
+		return true
+	case argoproj.ArgoCDRedisModeStandalone:
+		return false
+	}
+	return cr.Spec.HA.Enabled
+}
+
+// isRedisMigrating returns true if Redis is being migrated between the standalone and the HA modes.
+func isRedisMigrating(cr *argoproj.ArgoCD) bool {
+	return meta.IsStatusConditionTrue(cr.Status.Conditions, argoproj.ArgoCDConditionTypeRedisMigrating)
+}
+
+// wantsRedisHA returns true if the Redis HA resources must be present for the given ArgoCD: either HA is enabled, or
+// the components still rely on it while migrating to the standalone Redis.
+func wantsRedisHA(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.HA.Enabled || isRedisHAActive(cr) || isRedisMigrating(cr)
+}
+
+// wantsRedisStandalone returns true if the standalone Redis resources must be present for the given ArgoCD: either
+// HA is disabled, or the components still rely on it while migrating to Redis HA.
+func wantsRedisStandalone(cr *argoproj.ArgoCD) bool {
+	return !cr.Spec.HA.Enabled || !isRedisHAActive(cr) || isRedisMigrating(cr)
+}
+
+// reconcileRedisMigration will migrate the given ArgoCD between the standalone and the HA Redis modes without
+// downtime. The Redis of the new mode is stood up next to the current one, the components are switched over once it
+// is ready, and the Redis of the previous mode is torn down once the components have been rolled out.
+func (r *ReconcileArgoCD) reconcileRedisMigration(cr *argoproj.ArgoCD) error {
+	desired := getDesiredRedisMode(cr)
+
+	// There is nothing to migrate for new instances, nor when the operator does not manage Redis
+	external := cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != ""
+	if cr.Status.RedisMode == "" || !cr.Spec.Redis.IsEnabled() || external {
+		if cr.Status.RedisMode != desired || isRedisMigrating(cr) {
+			cr.Status.RedisMode = desired
+			meta.RemoveStatusCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionTypeRedisMigrating)
+			return r.Client.Status().Update(context.TODO(), cr)
+		}
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:   argoproj.ArgoCDConditionTypeRedisMigrating,
+		Status: metav1.ConditionTrue,
+	}
+
+	if cr.Status.RedisMode != desired {
+		if !r.isRedisModeReady(cr, desired) {
+			condition.Reason = argoproj.ArgoCDConditionReasonWaitingForRedis
+			condition.Message = fmt.Sprintf("waiting for Redis %s to be ready, the components keep using Redis %s",
+				desired, cr.Status.RedisMode)
+			if existing := meta.FindStatusCondition(cr.Status.Conditions, condition.Type); existing != nil &&
+				existing.Reason == condition.Reason && existing.Message == condition.Message {
+				return nil
+			}
+			meta.SetStatusCondition(&cr.Status.Conditions, condition)
+			return r.Client.Status().Update(context.TODO(), cr)
+		}
+
+		componentLog(cr, logComponentRedis).Info(fmt.Sprintf("Redis %s is ready, switching the components of %s over from Redis %s",
+			desired, cr.Name, cr.Status.RedisMode))
+		condition.Reason = argoproj.ArgoCDConditionReasonSwitchingComponents
+		condition.Message = fmt.Sprintf("the components are being rolled out with Redis %s", desired)
+		cr.Status.RedisMode = desired
+		meta.SetStatusCondition(&cr.Status.Conditions, condition)
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+
+	if !isRedisMigrating(cr) {
+		return nil
+	}
+
+	if !r.isRedisClientsRolledOut(cr) {
+		return nil // Components are still rolling out, keep the previous Redis
+	}
+
+	componentLog(cr, logComponentRedis).Info(fmt.Sprintf("components of %s use Redis %s, tearing down the previous Redis", cr.Name, desired))
+	meta.RemoveStatusCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionTypeRedisMigrating)
+	return r.Client.Status().Update(context.TODO(), cr)
+}
+
+// isRedisModeReady returns true if the Redis of the given mode can serve the components of the given ArgoCD. In HA
+// mode, every Redis and Sentinel replica must be ready, so that the Sentinels have reached quorum on a master, and the
+// HA proxy must be ready to route to it.
+func (r *ReconcileArgoCD) isRedisModeReady(cr *argoproj.ArgoCD, mode string) bool {
+	if mode == argoproj.ArgoCDRedisModeStandalone {
+		deploy := newDeploymentWithSuffix("redis", "redis", cr)
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
+			return false
+		}
+		return isDeploymentReady(deploy)
+	}
+
+	ss := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, ss.Name, ss) {
+		return false
+	}
+	replicas := int32(1)
+	if ss.Spec.Replicas != nil {
+		replicas = *ss.Spec.Replicas
+	}
+	if ss.Status.ReadyReplicas < replicas {
+		return false
+	}
+
+	deploy := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
+		return false
+	}
+	return isDeploymentReady(deploy)
+}
+
+// isRedisClientsRolledOut returns true once the components of the given ArgoCD connecting to Redis have been rolled
+// out with the address of the current Redis mode.
+func (r *ReconcileArgoCD) isRedisClientsRolledOut(cr *argoproj.ArgoCD) bool {
+	address := getRedisServerAddress(cr)
+
+	for _, component := range []string{"server", "repo-server"} {
+		deploy := newDeploymentWithSuffix(component, component, cr)
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
+			continue
+		}
+		if !usesRedisAddress(deploy.Spec.Template.Spec.Containers, address) ||
+			deploy.Status.ObservedGeneration < deploy.Generation || deploy.Status.UpdatedReplicas != deploy.Status.Replicas {
+			return false
+		}
+	}
+
+	ss := newStatefulSetWithSuffix("application-controller", "application-controller", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, ss.Name, ss) {
+		if !usesRedisAddress(ss.Spec.Template.Spec.Containers, address) ||
+			ss.Status.ObservedGeneration < ss.Generation || ss.Status.UpdateRevision != ss.Status.CurrentRevision {
+			return false
+		}
+	}
+	return true
+}
+
+// usesRedisAddress returns true if the main container of a component is configured with the given Redis address.
+func usesRedisAddress(containers []corev1.Container, address string) bool {
+	if len(containers) == 0 {
+		return true
+	}
+	cmd := containers[0].Command
+	for i := range cmd {
+		if cmd[i] == "--redis" {
+			return i+1 < len(cmd) && cmd[i+1] == address
+		}
+	}
+	return true // Redis is not used by the component
+}
+
+// isDeploymentReady returns true if all the desired replicas of the given Deployment are ready.
+func isDeploymentReady(deploy *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	return deploy.Status.ReadyReplicas >= replicas
+}
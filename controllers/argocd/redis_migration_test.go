@@ -0,0 +1,151 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileArgoCD_reconcileRedisMigration_newInstance(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// Nothing to migrate, the mode of the spec is used right away
+	assert.NoError(t, r.reconcileRedisMigration(a))
+	assert.Equal(t, argoproj.ArgoCDRedisModeHA, a.Status.RedisMode)
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeRedisMigrating))
+	assert.False(t, wantsRedisStandalone(a))
+	assert.Equal(t, getRedisHAProxyAddress(a), getRedisServerAddress(a))
+}
+
+func TestReconcileArgoCD_reconcileRedisMigration_toHA(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.HA.Enabled = true
+		a.Status.RedisMode = argoproj.ArgoCDRedisModeStandalone
+	})
+	standaloneAddress := fqdnServiceRef(common.ArgoCDDefaultRedisSuffix, common.ArgoCDDefaultRedisPort, a)
+
+	server := newDeploymentWithSuffix("server", "server", a)
+	server.Spec.Template.Spec.Containers = []corev1.Container{{
+		Name:    "argocd-server",
+		Command: []string{"argocd-server", "--redis", standaloneAddress},
+	}}
+
+	resObjs := []client.Object{a, server}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// Redis HA is not ready, the components keep using the standalone Redis
+	assert.NoError(t, r.reconcileRedisMigration(a))
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeRedisMigrating)
+	assert.NotNil(t, condition)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonWaitingForRedis, condition.Reason)
+	assert.Equal(t, argoproj.ArgoCDRedisModeStandalone, a.Status.RedisMode)
+	assert.Equal(t, standaloneAddress, getRedisServerAddress(a))
+	assert.True(t, wantsRedisHA(a))
+	assert.True(t, wantsRedisStandalone(a))
+
+	// Once every Redis HA replica and the HA proxy are ready, the components are switched over
+	replicas := common.ArgoCDDefaultRedisHAReplicas
+	ss := newStatefulSetWithSuffix("redis-ha-server", "redis", a)
+	ss.Spec.Replicas = &replicas
+	ss.Status.ReadyReplicas = replicas
+	assert.NoError(t, r.Client.Create(context.TODO(), ss))
+	haproxy := newDeploymentWithSuffix("redis-ha-haproxy", "redis", a)
+	haproxy.Status.ReadyReplicas = 1
+	assert.NoError(t, r.Client.Create(context.TODO(), haproxy))
+
+	assert.NoError(t, r.reconcileRedisMigration(a))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeRedisMigrating)
+	assert.NotNil(t, condition)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonSwitchingComponents, condition.Reason)
+	assert.Equal(t, argoproj.ArgoCDRedisModeHA, a.Status.RedisMode)
+	assert.Equal(t, getRedisHAProxyAddress(a), getRedisServerAddress(a))
+
+	// The standalone Redis is kept until the components have been rolled out
+	assert.NoError(t, r.reconcileRedisMigration(a))
+	assert.True(t, isRedisMigrating(a))
+	assert.True(t, wantsRedisStandalone(a))
+
+	server.Spec.Template.Spec.Containers[0].Command = []string{"argocd-server", "--redis", getRedisHAProxyAddress(a)}
+	assert.NoError(t, r.Client.Update(context.TODO(), server))
+	assert.NoError(t, r.reconcileRedisMigration(a))
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeRedisMigrating))
+	assert.False(t, wantsRedisStandalone(a))
+	assert.True(t, wantsRedisHA(a))
+}
+
+func TestReconcileArgoCD_reconcileRedisMigration_toStandalone(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	t.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Status.RedisMode = argoproj.ArgoCDRedisModeHA
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRedisMigration(a))
+	assert.Equal(t, argoproj.ArgoCDConditionReasonWaitingForRedis,
+		meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeRedisMigrating).Reason)
+
+	// The standalone Redis is stood up next to Redis HA, which is still in use
+	assert.NoError(t, r.reconcileRedisDeployment(a, false))
+	assert.NoError(t, r.reconcileRedisHAProxyService(a))
+	redis := newDeploymentWithSuffix("redis", "redis", a)
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(redis), redis))
+	svc := newServiceWithSuffix("redis-ha-haproxy", "redis", a)
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(svc), svc))
+	assert.Equal(t, getRedisHAProxyAddress(a), getRedisServerAddress(a))
+
+	redis.Status.ReadyReplicas = 1
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), redis))
+	assert.NoError(t, r.reconcileRedisMigration(a))
+	assert.Equal(t, argoproj.ArgoCDRedisModeStandalone, a.Status.RedisMode)
+
+	// Without components to roll out, Redis HA is torn down right away
+	assert.NoError(t, r.reconcileRedisMigration(a))
+	assert.False(t, isRedisMigrating(a))
+	assert.NoError(t, r.reconcileRedisHAProxyService(a))
+	assert.Error(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(svc), svc))
+}
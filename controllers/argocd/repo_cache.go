@@ -0,0 +1,147 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// isRepoServerCachePersistent returns true if the Repo server cache is backed by a persistent volume.
+func isRepoServerCachePersistent(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Repo.Cache != nil && cr.Spec.Repo.Cache.Persistent
+}
+
+// getRepoServerCacheClaimName will return the name of the PersistentVolumeClaim backing the Repo server cache.
+func getRepoServerCacheClaimName(cr *argoproj.ArgoCD) string {
+	return nameWithSuffix("repo-server-cache", cr)
+}
+
+// getRepoServerCacheClaimSpec will return the PersistentVolumeClaim spec of the Repo server cache volume.
+func getRepoServerCacheClaimSpec(cr *argoproj.ArgoCD) corev1.PersistentVolumeClaimSpec {
+	opts := cr.Spec.Repo.Cache
+
+	size := resource.MustParse(common.ArgoCDDefaultRepoCacheCapacity)
+	if opts.Size != nil {
+		size = *opts.Size
+	}
+
+	accessModes := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	if len(opts.AccessModes) > 0 {
+		accessModes = opts.AccessModes
+	}
+
+	volumeMode := corev1.PersistentVolumeFilesystem
+	return corev1.PersistentVolumeClaimSpec{
+		AccessModes:      accessModes,
+		StorageClassName: opts.StorageClass,
+		VolumeMode:       &volumeMode,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceStorage: size,
+			},
+		},
+	}
+}
+
+// isRepoServerCachePerPod returns true if each Repo server pod gets its own cache volume, either when requested or
+// when the shared claim could not be mounted by several replicas: a ReadWriteOnce claim is only mounted by the pods
+// of a single node, so the replicas scheduled elsewhere would never start.
+func isRepoServerCachePerPod(cr *argoproj.ArgoCD) bool {
+	if cr.Spec.Repo.Cache.Ephemeral {
+		return true
+	}
+	for _, mode := range getRepoServerCacheClaimSpec(cr).AccessModes {
+		if mode == corev1.ReadWriteMany {
+			return false
+		}
+	}
+	replicas := getArgoCDRepoServerReplicas(cr)
+	return isRepoKEDAEnabled(cr) || (replicas != nil && *replicas > 1)
+}
+
+// getRepoServerTmpVolume will return the volume mounted at /tmp in the Repo server, which holds the git and helm
+// caches. Defaults to an emptyDir volume.
+func getRepoServerTmpVolume(cr *argoproj.ArgoCD) corev1.Volume {
+	volume := corev1.Volume{
+		Name: "tmp",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+
+	if !isRepoServerCachePersistent(cr) {
+		return volume
+	}
+
+	if isRepoServerCachePerPod(cr) {
+		volume.VolumeSource = corev1.VolumeSource{
+			Ephemeral: &corev1.EphemeralVolumeSource{
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: argoutil.DefaultLabelsForCluster(cr, getRepoServerCacheClaimName(cr)),
+					},
+					Spec: getRepoServerCacheClaimSpec(cr),
+				},
+			},
+		}
+		return volume
+	}
+
+	volume.VolumeSource = corev1.VolumeSource{
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: getRepoServerCacheClaimName(cr),
+		},
+	}
+	return volume
+}
+
+// reconcileRepoServerCache will ensure that the PersistentVolumeClaim backing the Repo server cache is present when
+// requested, and removed otherwise. The claim is only created, as most of its spec is immutable.
+func (r *ReconcileArgoCD) reconcileRepoServerCache(cr *argoproj.ArgoCD) error {
+	pvc := argoutil.NewPersistentVolumeClaimWithName(getRepoServerCacheClaimName(cr), cr.ObjectMeta)
+	wanted := cr.Spec.Repo.IsEnabled() && isRepoServerCachePersistent(cr) && !isRepoServerCachePerPod(cr)
+
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, pvc.Name, pvc) {
+		if !wanted {
+			// PVC found but no longer used, delete it.
+			componentLog(cr, logComponentRepoServer).Info(fmt.Sprintf("deleting repo server cache pvc %s", pvc.Name))
+			return r.Client.Delete(context.TODO(), pvc)
+		}
+		return nil // PVC exists, move along...
+	}
+
+	if !wanted {
+		return nil // Persistent cache not enabled, move along...
+	}
+
+	pvc.Labels = argoutil.DefaultLabelsForCluster(cr, pvc.Name)
+	pvc.Spec = getRepoServerCacheClaimSpec(cr)
+	if err := controllerutil.SetControllerReference(cr, pvc, r.Scheme); err != nil {
+		return err
+	}
+
+	componentLog(cr, logComponentRepoServer).Info(fmt.Sprintf("creating repo server cache pvc %s", pvc.Name))
+	return r.Client.Create(context.TODO(), pvc)
+}
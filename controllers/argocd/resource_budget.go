@@ -0,0 +1,308 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// componentRequests are the resources of the containers of a component, run by each of its replicas.
+type componentRequests struct {
+	replicas       int32
+	containers     []corev1.ResourceRequirements
+	initContainers []corev1.ResourceRequirements
+}
+
+// replicasOrOne returns the given replica count, or 1 when the count is left to the workload default.
+func replicasOrOne(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// getContainerResources will return the resources of the given containers.
+func getContainerResources(containers []corev1.Container) []corev1.ResourceRequirements {
+	resources := []corev1.ResourceRequirements{}
+	for _, container := range containers {
+		resources = append(resources, container.Resources)
+	}
+	return resources
+}
+
+// withLogSidecarResources will return the given resources of the containers of a component, along with the resources
+// of the log sidecar when it is enabled for the given ArgoCD.
+func withLogSidecarResources(cr *argoproj.ArgoCD, containers []corev1.ResourceRequirements) []corev1.ResourceRequirements {
+	if isLogSidecarEnabled(cr) {
+		containers = append(containers, getLogSidecarContainer(cr).Resources)
+	}
+	return containers
+}
+
+// getComponentRequests will return the resources of the components created for the given ArgoCD, with the given
+// number of application controller replicas, including their sidecars and the init containers set in their spec.
+// The init containers created by the operator are left out, as they request no more than the main container.
+func getComponentRequests(cr *argoproj.ArgoCD, controllerReplicas int32) []componentRequests {
+	components := []componentRequests{}
+
+	if cr.Spec.Controller.IsEnabled() {
+		containers := []corev1.ResourceRequirements{getArgoApplicationControllerResources(cr)}
+		containers = append(containers, getContainerResources(cr.Spec.Controller.SidecarContainers)...)
+		components = append(components, componentRequests{
+			replicas:       controllerReplicas,
+			containers:     withLogSidecarResources(cr, containers),
+			initContainers: getContainerResources(cr.Spec.Controller.InitContainers),
+		})
+	}
+	if isServerEnabled(cr) {
+		containers := []corev1.ResourceRequirements{getArgoServerResources(cr)}
+		containers = append(containers, getContainerResources(cr.Spec.Server.SidecarContainers)...)
+		components = append(components, componentRequests{
+			replicas:       replicasOrOne(getArgoCDServerReplicas(cr)),
+			containers:     withLogSidecarResources(cr, containers),
+			initContainers: getContainerResources(cr.Spec.Server.InitContainers),
+		})
+	}
+	if cr.Spec.Repo.IsEnabled() {
+		containers := []corev1.ResourceRequirements{getArgoRepoResources(cr)}
+		for _, sidecar := range cr.Spec.Repo.SidecarContainers {
+			containers = append(containers, withResourceDefaults(sidecar.Resources, cr.Spec.Repo.PluginResourceDefaults))
+		}
+		components = append(components, componentRequests{
+			replicas:       replicasOrOne(getArgoCDRepoServerReplicas(cr)),
+			containers:     withLogSidecarResources(cr, containers),
+			initContainers: getContainerResources(cr.Spec.Repo.InitContainers),
+		})
+	}
+	if cr.Spec.Redis.IsEnabled() && (cr.Spec.Redis.Remote == nil || *cr.Spec.Redis.Remote == "") {
+		if cr.Spec.HA.Enabled {
+			// The Redis HA servers run redis and sentinel, fronted by a single HAProxy
+			server := []corev1.ResourceRequirements{getRedisHAResources(cr), getRedisHAResources(cr)}
+			if isRedisExporterEnabled(cr) {
+				server = append(server, getRedisExporterResources(cr))
+			}
+			components = append(components,
+				componentRequests{replicas: *getRedisHAReplicas(), containers: withLogSidecarResources(cr, server)},
+				componentRequests{replicas: 1, containers: []corev1.ResourceRequirements{getRedisHAResources(cr)}},
+			)
+		} else {
+			components = append(components, componentRequests{
+				replicas:   1,
+				containers: withLogSidecarResources(cr, []corev1.ResourceRequirements{getRedisResources(cr)}),
+			})
+		}
+	}
+	if cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.IsEnabled() {
+		containers := []corev1.ResourceRequirements{getApplicationSetResources(cr)}
+		containers = append(containers, getContainerResources(cr.Spec.ApplicationSet.SidecarContainers)...)
+		components = append(components, componentRequests{
+			replicas:       replicasOrOne(getApplicationSetReplicas(cr)),
+			containers:     withLogSidecarResources(cr, containers),
+			initContainers: getContainerResources(cr.Spec.ApplicationSet.InitContainers),
+		})
+	}
+	if cr.Spec.Notifications.Enabled {
+		components = append(components, componentRequests{
+			replicas:   replicasOrOne(getArgoCDNotificationsControllerReplicas(cr)),
+			containers: withLogSidecarResources(cr, []corev1.ResourceRequirements{getNotificationsResources(cr)}),
+		})
+	}
+	if cr.Spec.ImageUpdater.Enabled {
+		components = append(components, componentRequests{
+			replicas:   1,
+			containers: []corev1.ResourceRequirements{getImageUpdaterResources(cr)},
+		})
+	}
+	if UseDex(cr) {
+		components = append(components, componentRequests{
+			replicas:   1,
+			containers: withLogSidecarResources(cr, []corev1.ResourceRequirements{getDexResources(cr)}),
+		})
+	}
+	if cr.Spec.SSO != nil && cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeKeycloak {
+		components = append(components, componentRequests{
+			replicas:   1,
+			containers: []corev1.ResourceRequirements{getKeycloakResources(cr)},
+		})
+	}
+
+	return components
+}
+
+// getContainerRequest will return the request of the given resource of a container, its limit when no request is
+// set, as for the scheduler.
+func getContainerRequest(container corev1.ResourceRequirements, name corev1.ResourceName) (resource.Quantity, bool) {
+	request, ok := container.Requests[name]
+	if !ok {
+		request, ok = container.Limits[name]
+	}
+	return request, ok
+}
+
+// getResourceBudgetUsage will return the CPU and memory requested in total by the components of the given ArgoCD.
+// As for the scheduler, the limit of a container stands for its request when no request is set, and a pod requests
+// the sum of its containers or the largest of its init containers, whichever is greater.
+func getResourceBudgetUsage(cr *argoproj.ArgoCD, controllerReplicas int32) corev1.ResourceList {
+	usage := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.Quantity{},
+		corev1.ResourceMemory: resource.Quantity{},
+	}
+
+	for _, component := range getComponentRequests(cr, controllerReplicas) {
+		for name, total := range usage {
+			pod := resource.Quantity{}
+			for _, container := range component.containers {
+				if request, ok := getContainerRequest(container, name); ok {
+					pod.Add(request)
+				}
+			}
+			for _, container := range component.initContainers {
+				if request, ok := getContainerRequest(container, name); ok && request.Cmp(pod) > 0 {
+					pod = request
+				}
+			}
+			for i := int32(0); i < component.replicas; i++ {
+				total.Add(pod)
+			}
+			usage[name] = total
+		}
+	}
+	return usage
+}
+
+// checkResourceBudget will return an error describing the resources the components of the given ArgoCD request
+// beyond its resource budget, nil if they fit in the budget or no budget is set.
+func checkResourceBudget(cr *argoproj.ArgoCD, controllerReplicas int32) error {
+	budget := cr.Spec.ResourceBudget
+	if budget == nil {
+		return nil
+	}
+
+	usage := getResourceBudgetUsage(cr, controllerReplicas)
+	exceeded := []string{}
+	for _, limit := range []struct {
+		name   corev1.ResourceName
+		budget *resource.Quantity
+	}{
+		{corev1.ResourceCPU, budget.CPU},
+		{corev1.ResourceMemory, budget.Memory},
+	} {
+		if limit.budget == nil {
+			continue
+		}
+		if total := usage[limit.name]; total.Cmp(*limit.budget) > 0 {
+			exceeded = append(exceeded, fmt.Sprintf("%s requests of %s exceed the budget of %s",
+				limit.name, total.String(), limit.budget.String()))
+		}
+	}
+
+	if len(exceeded) == 0 {
+		return nil
+	}
+	return fmt.Errorf("the Argo CD components exceed the resource budget: %s", strings.Join(exceeded, ", "))
+}
+
+// isResourceBudgetEnforced returns true if the given ArgoCD must be refused when exceeding its resource budget.
+func isResourceBudgetEnforced(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.ResourceBudget != nil && cr.Spec.ResourceBudget.Policy != argoproj.ArgoCDResourceBudgetPolicyWarn
+}
+
+// isResourceBudgetScaleUpBlocked returns true if the components of the given ArgoCD must not be scaled up, as the
+// instance exceeds its enforced resource budget according to its ResourceBudgetExceeded condition.
+func isResourceBudgetScaleUpBlocked(cr *argoproj.ArgoCD) bool {
+	return isResourceBudgetEnforced(cr) &&
+		meta.IsStatusConditionTrue(cr.Status.Conditions, argoproj.ArgoCDConditionTypeResourceBudgetExceeded)
+}
+
+// getBudgetedReplicas will return the given desired replicas of a component of the given ArgoCD, or its current
+// replicas when the desired ones would scale the component up while the instance exceeds its enforced resource budget.
+func getBudgetedReplicas(cr *argoproj.ArgoCD, current *int32, desired *int32) *int32 {
+	if current == nil || !isResourceBudgetScaleUpBlocked(cr) || replicasOrOne(desired) <= *current {
+		return desired
+	}
+	instanceLog(cr).Info(fmt.Sprintf("keeping %d replicas instead of %d for ArgoCD %s/%s, the instance exceeds its resource budget",
+		*current, replicasOrOne(desired), cr.Namespace, cr.Name))
+	return current
+}
+
+// reconcileResourceBudget will ensure that the ResourceBudgetExceeded condition reports whether the components of the
+// given ArgoCD exceed its resource budget, emitting an Event when the instance starts or stops exceeding it. While an
+// enforced budget is exceeded, the components are reconciled but not scaled up.
+func (r *ReconcileArgoCD) reconcileResourceBudget(cr *argoproj.ArgoCD) error {
+	budgetErr := checkResourceBudget(cr, r.getApplicationControllerReplicaCount(cr))
+	existing := meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionTypeResourceBudgetExceeded)
+
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeResourceBudgetExceeded,
+		Status:             metav1.ConditionFalse,
+		Reason:             argoproj.ArgoCDConditionReasonWithinResourceBudget,
+		Message:            "The Argo CD components fit in the resource budget",
+		ObservedGeneration: cr.Generation,
+	}
+	if budgetErr == nil && existing == nil {
+		return nil // Never exceeded, no need for the condition
+	}
+	if budgetErr != nil {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = argoproj.ArgoCDConditionReasonResourceBudgetExceeded
+		condition.Message = budgetErr.Error()
+	}
+
+	if existing != nil && existing.Status == condition.Status && existing.Message == condition.Message {
+		return nil // Nothing changed, move along...
+	}
+
+	if existing == nil || existing.Status != condition.Status {
+		eventType, message := corev1.EventTypeNormal, condition.Message
+		if budgetErr != nil {
+			eventType = corev1.EventTypeWarning
+			if isResourceBudgetEnforced(cr) {
+				message += ", the components of the instance are not scaled up until the budget is met"
+			}
+		}
+		instanceLog(cr).Info(fmt.Sprintf("ArgoCD %s/%s: %s", cr.Namespace, cr.Name, message))
+		r.recordEvent(cr, eventType, condition.Reason, message)
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	return r.Client.Status().Update(context.TODO(), cr)
+}
+
+// getAdmissionControllerReplicas will return the number of application controller replicas of the given ArgoCD
+// known at admission time. The cluster secrets are not looked up, so a dynamically scaled controller is checked with
+// its minimum number of shards.
+func getAdmissionControllerReplicas(cr *argoproj.ArgoCD) int32 {
+	sharding := cr.Spec.Controller.Sharding
+	if sharding.DynamicScalingEnabled != nil && *sharding.DynamicScalingEnabled {
+		if sharding.MinShards < 1 {
+			return 1
+		}
+		return sharding.MinShards
+	}
+	if sharding.Enabled && sharding.Replicas != 0 {
+		return sharding.Replicas
+	}
+	return common.ArgocdApplicationControllerDefaultReplicas
+}
@@ -0,0 +1,205 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func makeTestResourceRequirements(cpu, memory string) *corev1.ResourceRequirements {
+	return &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		},
+	}
+}
+
+func makeTestResourceBudgetArgoCD(opts ...argoCDOpt) *argoproj.ArgoCD {
+	return makeTestArgoCD(append([]argoCDOpt{func(a *argoproj.ArgoCD) {
+		a.Spec.Controller.Resources = makeTestResourceRequirements("1", "2Gi")
+		a.Spec.Server.Resources = makeTestResourceRequirements("250m", "256Mi")
+		a.Spec.Repo.Resources = makeTestResourceRequirements("500m", "512Mi")
+		a.Spec.Redis.Resources = makeTestResourceRequirements("250m", "256Mi")
+		cpu, memory := resource.MustParse("3"), resource.MustParse("4Gi")
+		a.Spec.ResourceBudget = &argoproj.ArgoCDResourceBudgetSpec{CPU: &cpu, Memory: &memory}
+	}}, opts...)...)
+}
+
+func TestGetResourceBudgetUsage(t *testing.T) {
+	a := makeTestResourceBudgetArgoCD()
+
+	usage := getResourceBudgetUsage(a, 1)
+	assert.Equal(t, "2", usage.Cpu().String())
+	assert.Equal(t, "3Gi", usage.Memory().String())
+
+	// Replicas are counted, and limits stand for missing requests
+	replicas := int32(2)
+	a.Spec.Server.Replicas = &replicas
+	a.Spec.Repo.Resources = &corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+	}
+	usage = getResourceBudgetUsage(a, 2)
+	assert.Equal(t, "3750m", usage.Cpu().String())
+	assert.Equal(t, "4864Mi", usage.Memory().String())
+
+	// Disabled components are not counted
+	a.Spec.Repo.Enabled = boolPtr(false)
+	a.Spec.Server.Enabled = boolPtr(false)
+	usage = getResourceBudgetUsage(a, 1)
+	assert.Equal(t, "1250m", usage.Cpu().String())
+}
+
+func TestGetResourceBudgetUsage_sidecarsAndInitContainers(t *testing.T) {
+	a := makeTestResourceBudgetArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Controller.SidecarContainers = []corev1.Container{
+			{Name: "sidecar", Resources: *makeTestResourceRequirements("500m", "512Mi")},
+		}
+		a.Spec.Server.SidecarContainers = []corev1.Container{
+			{Name: "sidecar", Resources: *makeTestResourceRequirements("250m", "256Mi")},
+		}
+	})
+
+	// Sidecars are counted along with the main containers
+	usage := getResourceBudgetUsage(a, 1)
+	assert.Equal(t, "2750m", usage.Cpu().String())
+	assert.Equal(t, "3840Mi", usage.Memory().String())
+
+	// An init container smaller than the containers of its pod is not counted
+	a.Spec.Server.InitContainers = []corev1.Container{
+		{Name: "init", Resources: *makeTestResourceRequirements("100m", "128Mi")},
+	}
+	usage = getResourceBudgetUsage(a, 1)
+	assert.Equal(t, "2750m", usage.Cpu().String())
+	assert.Equal(t, "3840Mi", usage.Memory().String())
+
+	// An init container larger than the containers of its pod stands for them
+	a.Spec.Server.InitContainers = []corev1.Container{
+		{Name: "init", Resources: *makeTestResourceRequirements("1", "128Mi")},
+	}
+	usage = getResourceBudgetUsage(a, 1)
+	assert.Equal(t, "3250m", usage.Cpu().String())
+	assert.Equal(t, "3840Mi", usage.Memory().String())
+
+	// The log sidecar is counted in each pod it runs in, the Argo CD Server pod still requesting the CPU of its init
+	// container
+	a.Spec.Monitoring.LogSidecar = &argoproj.ArgoCDLogSidecarSpec{
+		Endpoint:  "https://logs.example.com",
+		Resources: makeTestResourceRequirements("10m", "16Mi"),
+	}
+	usage = getResourceBudgetUsage(a, 1)
+	assert.Equal(t, "3280m", usage.Cpu().String())
+	assert.Equal(t, "3904Mi", usage.Memory().String())
+}
+
+func TestCheckResourceBudget(t *testing.T) {
+	a := makeTestResourceBudgetArgoCD()
+	assert.NoError(t, checkResourceBudget(a, 1))
+
+	assert.EqualError(t, checkResourceBudget(a, 3),
+		"the Argo CD components exceed the resource budget: cpu requests of 4 exceed the budget of 3, memory requests of 7Gi exceed the budget of 4Gi")
+
+	a.Spec.ResourceBudget.CPU = nil
+	assert.EqualError(t, checkResourceBudget(a, 3),
+		"the Argo CD components exceed the resource budget: memory requests of 7Gi exceed the budget of 4Gi")
+
+	a.Spec.ResourceBudget = nil
+	assert.NoError(t, checkResourceBudget(a, 3))
+}
+
+func TestReconcileArgoCD_reconcileResourceBudget(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestResourceBudgetArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	// No condition while the budget was never exceeded
+	assert.NoError(t, r.reconcileResourceBudget(a))
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeResourceBudgetExceeded))
+	assert.False(t, isResourceBudgetScaleUpBlocked(a))
+
+	a.Spec.Controller.Resources = makeTestResourceRequirements("3", "2Gi")
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileResourceBudget(a))
+	assert.True(t, meta.IsStatusConditionTrue(a.Status.Conditions, argoproj.ArgoCDConditionTypeResourceBudgetExceeded))
+	assert.True(t, isResourceBudgetScaleUpBlocked(a))
+
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, corev1.EventTypeWarning+" "+argoproj.ArgoCDConditionReasonResourceBudgetExceeded)
+
+	// A change of the excess updates the condition without another Event
+	a.Spec.Controller.Resources = makeTestResourceRequirements("4", "2Gi")
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileResourceBudget(a))
+	assert.Contains(t, meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeResourceBudgetExceeded).Message, "cpu requests of")
+	assert.Empty(t, recorder.Events)
+
+	// Scale-ups are only blocked with the Enforce policy
+	a.Spec.ResourceBudget.Policy = argoproj.ArgoCDResourceBudgetPolicyWarn
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileResourceBudget(a))
+	assert.False(t, isResourceBudgetScaleUpBlocked(a))
+	assert.Empty(t, recorder.Events)
+
+	a.Spec.ResourceBudget = nil
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileResourceBudget(a))
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeResourceBudgetExceeded)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonWithinResourceBudget, condition.Reason)
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, corev1.EventTypeNormal+" "+argoproj.ArgoCDConditionReasonWithinResourceBudget)
+}
+
+func TestGetBudgetedReplicas(t *testing.T) {
+	a := makeTestResourceBudgetArgoCD()
+	two, three := int32(2), int32(3)
+
+	assert.Equal(t, &three, getBudgetedReplicas(a, &two, &three))
+
+	meta.SetStatusCondition(&a.Status.Conditions, metav1.Condition{
+		Type:   argoproj.ArgoCDConditionTypeResourceBudgetExceeded,
+		Status: metav1.ConditionTrue,
+		Reason: argoproj.ArgoCDConditionReasonResourceBudgetExceeded,
+	})
+	// Scaling up is blocked, scaling down is not
+	assert.Equal(t, &two, getBudgetedReplicas(a, &two, &three))
+	assert.Equal(t, &two, getBudgetedReplicas(a, &three, &two))
+	// The replicas of a component being created are left as desired
+	assert.Equal(t, &three, getBudgetedReplicas(a, nil, &three))
+
+	a.Spec.ResourceBudget.Policy = argoproj.ArgoCDResourceBudgetPolicyWarn
+	assert.Equal(t, &three, getBudgetedReplicas(a, &two, &three))
+}
@@ -53,7 +53,7 @@ func GenerateUniqueResourceName(argoComponentName string, cr *argoproj.ArgoCD) s
 }
 
 func newClusterRole(name string, rules []v1.PolicyRule, cr *argoproj.ArgoCD) *v1.ClusterRole {
-	return &v1.ClusterRole{
+	clusterRole := &v1.ClusterRole{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        GenerateUniqueResourceName(name, cr),
 			Labels:      argoutil.LabelsForCluster(cr),
@@ -61,6 +61,10 @@ func newClusterRole(name string, rules []v1.PolicyRule, cr *argoproj.ArgoCD) *v1
 		},
 		Rules: rules,
 	}
+	// Record the additional labels in the annotations as the label policy does, so the ClusterRoles annotations are
+	// compared against the ones the label policy maintains.
+	applyLabelPolicy(cr, clusterRole)
+	return clusterRole
 }
 
 // reconcileRoles will ensure that all ArgoCD Service Accounts are configured.
@@ -81,14 +85,14 @@ func (r *ReconcileArgoCD) reconcileRoles(cr *argoproj.ArgoCD) error {
 		}
 	}
 
-	log.Info("reconciling roles for source namespaces")
+	instanceLog(cr).Info("reconciling roles for source namespaces")
 	policyRuleForApplicationSourceNamespaces := policyRuleForServerApplicationSourceNamespaces()
 	// reconcile roles is source namespaces for ArgoCD Server
 	if err := r.reconcileRoleForApplicationSourceNamespaces(common.ArgoCDServerComponent, policyRuleForApplicationSourceNamespaces, cr); err != nil {
 		return err
 	}
 
-	log.Info("performing cleanup for source namespaces")
+	instanceLog(cr).Info("performing cleanup for source namespaces")
 	// remove resources for namespaces not part of SourceNamespaces
 	if err := r.removeUnmanagedSourceNamespaceResources(cr); err != nil {
 		return err
@@ -102,6 +106,10 @@ func (r *ReconcileArgoCD) reconcileRoles(cr *argoproj.ArgoCD) error {
 func (r *ReconcileArgoCD) reconcileRole(name string, policyRules []v1.PolicyRule, cr *argoproj.ArgoCD) ([]*v1.Role, error) {
 	var roles []*v1.Role
 
+	if name == common.ArgoCDApplicationControllerComponent {
+		policyRules = getApplicationControllerPolicyRules(cr, r.Client.RESTMapper(), policyRules)
+	}
+
 	// create policy rules for each namespace
 	for _, namespace := range r.ManagedNamespaces.Items {
 		// If encountering a terminating namespace remove managed-by label from it and skip reconciliation - This should trigger
@@ -156,7 +164,7 @@ func (r *ReconcileArgoCD) reconcileRole(name string, policyRules []v1.PolicyRule
 				}
 			}
 
-			log.Info(fmt.Sprintf("creating role %s for Argo CD instance %s in namespace %s", role.Name, cr.Name, cr.Namespace))
+			instanceLog(cr).Info(fmt.Sprintf("creating role %s for Argo CD instance %s in namespace %s", role.Name, cr.Name, cr.Namespace))
 			if err := r.Client.Create(context.TODO(), role); err != nil {
 				return nil, err
 			}
@@ -168,7 +176,7 @@ func (r *ReconcileArgoCD) reconcileRole(name string, policyRules []v1.PolicyRule
 		if customRole != "" ||
 			(name == common.ArgoCDDexServerComponent && !UseDex(cr)) {
 
-			log.Info("deleting the existing Dex role because dex is not configured")
+			instanceLog(cr).Info("deleting the existing Dex role because dex is not configured")
 			if err := r.Client.Delete(context.TODO(), &existingRole); err != nil {
 				return nil, err
 			}
@@ -204,12 +212,12 @@ func (r *ReconcileArgoCD) reconcileRoleForApplicationSourceNamespaces(name strin
 		// as it already contains roles with permissions to manipulate application resources
 		// reconciled during reconcilation of ManagedNamespaces
 		if value, ok := namespace.Labels[common.ArgoCDManagedByLabel]; ok && value != "" {
-			log.Info(fmt.Sprintf("Skipping reconciling resources for namespace %s as it is already managed-by namespace %s.", namespace.Name, value))
+			instanceLog(cr).Info(fmt.Sprintf("Skipping reconciling resources for namespace %s as it is already managed-by namespace %s.", namespace.Name, value))
 			// if managed-by-cluster-argocd label is also present, remove the namespace from the ManagedSourceNamespaces.
 			if val, ok1 := namespace.Labels[common.ArgoCDManagedByClusterArgoCDLabel]; ok1 && val == cr.Namespace {
 				delete(r.ManagedSourceNamespaces, namespace.Name)
 				if err := r.cleanupUnmanagedSourceNamespaceResources(cr, namespace.Name); err != nil {
-					log.Error(err, fmt.Sprintf("error cleaning up resources for namespace %s", namespace.Name))
+					instanceLog(cr).Error(err, fmt.Sprintf("error cleaning up resources for namespace %s", namespace.Name))
 				}
 			}
 			continue
@@ -217,11 +225,11 @@ func (r *ReconcileArgoCD) reconcileRoleForApplicationSourceNamespaces(name strin
 
 		// reconcile roles only if another ArgoCD instance is not already set as value for managed-by-cluster-argocd label
 		if value, ok := namespace.Labels[common.ArgoCDManagedByClusterArgoCDLabel]; ok && value != cr.Namespace {
-			log.Info(fmt.Sprintf("Namespace already has label set to argocd instance %s. Thus, skipping namespace %s", value, namespace.Name))
+			instanceLog(cr).Info(fmt.Sprintf("Namespace already has label set to argocd instance %s. Thus, skipping namespace %s", value, namespace.Name))
 			continue
 		}
 
-		log.Info(fmt.Sprintf("Reconciling role for %s", namespace.Name))
+		instanceLog(cr).Info(fmt.Sprintf("Reconciling role for %s", namespace.Name))
 
 		role := newRoleForApplicationSourceNamespaces(namespace.Name, policyRules, cr)
 		if err := applyReconcilerHook(cr, role, ""); err != nil {
@@ -241,7 +249,7 @@ func (r *ReconcileArgoCD) reconcileRoleForApplicationSourceNamespaces(name strin
 				return fmt.Errorf("failed to reconcile the role for the service account associated with %s : %s", name, err)
 			}
 
-			log.Info(fmt.Sprintf("creating role %s for Argo CD instance %s in namespace %s", role.Name, cr.Name, namespace))
+			instanceLog(cr).Info(fmt.Sprintf("creating role %s for Argo CD instance %s in namespace %s", role.Name, cr.Name, namespace))
 			if err := r.Client.Create(context.TODO(), role); err != nil {
 				return err
 			}
@@ -258,7 +266,7 @@ func (r *ReconcileArgoCD) reconcileRoleForApplicationSourceNamespaces(name strin
 		}
 		namespace.Labels[common.ArgoCDManagedByClusterArgoCDLabel] = cr.Namespace
 		if err := r.Client.Update(context.TODO(), namespace); err != nil {
-			log.Error(err, fmt.Sprintf("failed to add label from namespace [%s]", namespace.Name))
+			instanceLog(cr).Error(err, fmt.Sprintf("failed to add label from namespace [%s]", namespace.Name))
 		}
 
 		// if the Rules differ, update the Role
@@ -289,7 +297,7 @@ func (r *ReconcileArgoCD) reconcileClusterRole(componentName string, policyRules
 	}
 
 	if err := verifyInstallationMode(cr, allowed); err != nil {
-		log.Error(err, "error occurred in reconcileClusterRole")
+		instanceLog(cr).Error(err, "error occurred in reconcileClusterRole")
 		return nil, nil
 	}
 
@@ -305,11 +313,18 @@ func (r *ReconcileArgoCD) reconcileClusterRole(componentName string, policyRules
 		return nil, err
 	}
 
+	if componentName == common.ArgoCDApplicationControllerComponent {
+		policyRules = getApplicationControllerPolicyRules(cr, r.Client.RESTMapper(), policyRules)
+	}
 	expectedClusterRole := newClusterRole(componentName, policyRules, cr)
 
-	if allowed && cr.Spec.AggregatedClusterRoles {
+	if allowed && useAggregatedClusterRoles(cr) {
 		// if aggregated ClusterRole mode is enabled, then add required fields in ClusterRole
-		configureAggregatedClusterRole(cr, expectedClusterRole, componentName)
+		legacyManagedBy, err := r.getLegacyAggregationManagedBy(cr)
+		if err != nil {
+			return nil, err
+		}
+		configureAggregatedClusterRole(cr, expectedClusterRole, componentName, legacyManagedBy)
 	} else {
 		// if current mode is default mode, but last one was aggregated mode, then delete ClusterRoles for View and Admin permissions
 		if componentName == common.ArgoCDApplicationControllerComponentView || componentName == common.ArgoCDApplicationControllerComponentAdmin {
@@ -358,7 +373,7 @@ func (r *ReconcileArgoCD) reconcileClusterRole(componentName string, policyRules
 	changed := false
 
 	// if existing ClusterRole field values differ from expected values then update them
-	if cr.Spec.AggregatedClusterRoles {
+	if useAggregatedClusterRoles(cr) {
 		changed = matchAggregatedClusterRoleFields(expectedClusterRole, existingClusterRole, componentName)
 	} else {
 		changed = matchDefaultClusterRoleFields(expectedClusterRole, existingClusterRole, componentName)
@@ -373,6 +388,12 @@ func (r *ReconcileArgoCD) reconcileClusterRole(componentName string, policyRules
 	return existingClusterRole, nil
 }
 
+// useAggregatedClusterRoles returns true if the aggregated ClusterRole mode is enabled for the given ArgoCD, either
+// with spec.aggregatedClusterRoles or with the aggregated RBAC mode of the Application Controller.
+func useAggregatedClusterRoles(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.AggregatedClusterRoles || cr.Spec.Controller.RBAC == argoproj.ArgoCDControllerRBACModeAggregated
+}
+
 func deleteClusterRoles(c client.Client, clusterRoleList *v1.ClusterRoleList) error {
 	for _, clusterRole := range clusterRoleList.Items {
 		if err := c.Delete(context.TODO(), &clusterRole); err != nil {
@@ -409,20 +430,14 @@ func checkCustomClusterRoleMode(r *ReconcileArgoCD, cr *argoproj.ArgoCD, compone
 	return false, nil
 }
 
-// configureAggregatedClusterRole updates the ClusterRole and adds required fields for aggregated ClusterRole mode
-func configureAggregatedClusterRole(cr *argoproj.ArgoCD, clusterRole *v1.ClusterRole, componentName string) {
+// configureAggregatedClusterRole updates the ClusterRole and adds required fields for aggregated ClusterRole mode. The
+// ClusterRoles labeled with the given legacy managed-by value, if any, are aggregated as well.
+func configureAggregatedClusterRole(cr *argoproj.ArgoCD, clusterRole *v1.ClusterRole, componentName string, legacyManagedBy string) {
 
 	// if it is base ClusterRole then add AggregationRule, Annotations fields and remove default Rules
 	if componentName == common.ArgoCDApplicationControllerComponent {
 		clusterRole.AggregationRule = &v1.AggregationRule{
-			ClusterRoleSelectors: []metav1.LabelSelector{
-				{
-					MatchLabels: map[string]string{
-						common.ArgoCDAggregateToControllerLabelKey: "true",
-						common.ArgoCDKeyManagedBy:                  cr.Name,
-					},
-				},
-			},
+			ClusterRoleSelectors: getAggregationSelectors(cr, common.ArgoCDAggregateToControllerLabelKey, legacyManagedBy),
 		}
 		clusterRole.Annotations[common.AutoUpdateAnnotationKey] = "true"
 		clusterRole.Rules = []v1.PolicyRule{}
@@ -431,14 +446,7 @@ func configureAggregatedClusterRole(cr *argoproj.ArgoCD, clusterRole *v1.Cluster
 	// if ClusterRole is for Admin permissions then add AggregationRule and Labels
 	if componentName == common.ArgoCDApplicationControllerComponentAdmin {
 		clusterRole.AggregationRule = &v1.AggregationRule{
-			ClusterRoleSelectors: []metav1.LabelSelector{
-				{
-					MatchLabels: map[string]string{
-						common.ArgoCDAggregateToAdminLabelKey: "true",
-						common.ArgoCDKeyManagedBy:             cr.Name,
-					},
-				},
-			},
+			ClusterRoleSelectors: getAggregationSelectors(cr, common.ArgoCDAggregateToAdminLabelKey, legacyManagedBy),
 		}
 		clusterRole.Labels[common.ArgoCDAggregateToControllerLabelKey] = "true"
 	}
@@ -449,6 +457,28 @@ func configureAggregatedClusterRole(cr *argoproj.ArgoCD, clusterRole *v1.Cluster
 	}
 }
 
+// getAggregationSelectors will return the selectors of the ClusterRoles with the given aggregation label and the
+// managed-by label of the given ArgoCD, or the given legacy managed-by value when set.
+func getAggregationSelectors(cr *argoproj.ArgoCD, key string, legacyManagedBy string) []metav1.LabelSelector {
+	selectors := []metav1.LabelSelector{
+		{
+			MatchLabels: map[string]string{
+				key:                       "true",
+				common.ArgoCDKeyManagedBy: argoutil.ManagedByLabelValue(cr),
+			},
+		},
+	}
+	if legacyManagedBy != "" {
+		selectors = append(selectors, metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				key:                       "true",
+				common.ArgoCDKeyManagedBy: legacyManagedBy,
+			},
+		})
+	}
+	return selectors
+}
+
 // matchAggregatedClusterRoleFields compares field values of expected and existing ClusterRoles for aggregated ClusterRole
 func matchAggregatedClusterRoleFields(expectedClusterRole *v1.ClusterRole, existingClusterRole *v1.ClusterRole, name string) bool {
 	changed := false
@@ -525,7 +555,7 @@ func matchDefaultClusterRoleFields(expectedClusterRole *v1.ClusterRole, existing
 }
 
 func verifyInstallationMode(cr *argoproj.ArgoCD, allowed bool) error {
-	if allowed && cr.Spec.DefaultClusterScopedRoleDisabled && cr.Spec.AggregatedClusterRoles {
+	if allowed && cr.Spec.DefaultClusterScopedRoleDisabled && useAggregatedClusterRoles(cr) {
 		return fmt.Errorf("Custom Cluster Roles and Aggregated Cluster Roles can not be used together.")
 	}
 	return nil
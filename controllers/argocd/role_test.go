@@ -1181,3 +1181,79 @@ func enableDefaultClusterRoles(t *testing.T, ctx context.Context, a *argoproj.Ar
 	a.Spec.AggregatedClusterRoles = false
 	assert.NoError(t, cl.Update(ctx, a))
 }
+
+func TestReconcileArgoCD_reconcileRoles_minimalControllerRBAC(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Controller.RBAC = argoproj.ArgoCDControllerRBACModeMinimal
+		a.Spec.ResourceInclusions = `- apiGroups:
+  - apps
+  kinds:
+  - Deployment
+  - StatefulSet
+- apiGroups:
+  - ""
+  kinds:
+  - ConfigMap
+  - Service
+`
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	t.Setenv("ARGOCD_CLUSTER_CONFIG_NAMESPACES", a.Namespace)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	assert.NoError(t, r.reconcileRoles(a))
+
+	role := &v1.Role{}
+	roleName := fmt.Sprintf("%s-%s", a.Name, common.ArgoCDApplicationControllerComponent)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: roleName, Namespace: a.Namespace}, role))
+	assert.NotContains(t, role.Rules, policyRuleForApplicationController()[0])
+	assert.Contains(t, role.Rules, v1.PolicyRule{
+		APIGroups: []string{"apps"},
+		Resources: []string{"deployments", "statefulsets"},
+		Verbs:     []string{"*"},
+	})
+	assert.Contains(t, role.Rules, v1.PolicyRule{
+		APIGroups: []string{""},
+		Resources: []string{"configmaps", "services"},
+		Verbs:     []string{"*"},
+	})
+
+	// The ServiceAccount permissions share the roles, which are left untouched once reconciled
+	clusterRole := &v1.ClusterRole{}
+	clusterRoleName := GenerateUniqueResourceName(common.ArgoCDApplicationControllerComponent, a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRoleName}, clusterRole))
+	assert.Equal(t, role.Rules, clusterRole.Rules)
+	roleVersion, clusterRoleVersion := role.ResourceVersion, clusterRole.ResourceVersion
+
+	assert.NoError(t, r.reconcileServiceAccounts(a))
+	assert.NoError(t, r.reconcileRoles(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: roleName, Namespace: a.Namespace}, role))
+	assert.Equal(t, roleVersion, role.ResourceVersion)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterRoleName}, clusterRole))
+	assert.Equal(t, clusterRoleVersion, clusterRole.ResourceVersion)
+
+	// Switching back to the full mode restores the default permissions
+	a.Spec.Controller.RBAC = argoproj.ArgoCDControllerRBACModeFull
+	assert.NoError(t, r.reconcileRoles(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: roleName, Namespace: a.Namespace}, role))
+	assert.Equal(t, policyRuleForApplicationController(), role.Rules)
+}
+
+func TestPluralizeKind(t *testing.T) {
+	for kind, resource := range map[string]string{
+		"Deployment":    "deployments",
+		"Ingress":       "ingresses",
+		"NetworkPolicy": "networkpolicies",
+		"Gateway":       "gateways",
+		"*":             "*",
+	} {
+		assert.Equal(t, resource, pluralizeKind(kind))
+	}
+}
@@ -179,7 +179,7 @@ func (r *ReconcileArgoCD) reconcileRoleBinding(name string, rules []v1.PolicyRul
 		if roleBindingExists {
 			if name == common.ArgoCDDexServerComponent && !UseDex(cr) {
 				// Delete any existing RoleBinding created for Dex since dex uninstallation is requested
-				log.Info("deleting the existing Dex roleBinding because dex uninstallation is requested")
+				instanceLog(cr).Info("deleting the existing Dex roleBinding because dex uninstallation is requested")
 				if err = r.Client.Delete(context.TODO(), existingRoleBinding); err != nil {
 					return err
 				}
@@ -210,7 +210,7 @@ func (r *ReconcileArgoCD) reconcileRoleBinding(name string, rules []v1.PolicyRul
 			}
 		}
 
-		log.Info(fmt.Sprintf("creating rolebinding %s for Argo CD instance %s in namespace %s", roleBinding.Name, cr.Name, cr.Namespace))
+		instanceLog(cr).Info(fmt.Sprintf("creating rolebinding %s for Argo CD instance %s in namespace %s", roleBinding.Name, cr.Name, cr.Namespace))
 		if err = r.Client.Create(context.TODO(), roleBinding); err != nil {
 			return err
 		}
@@ -234,7 +234,7 @@ func (r *ReconcileArgoCD) reconcileRoleBinding(name string, rules []v1.PolicyRul
 			// as it already contains rolebindings with permissions to manipulate application resources
 			// reconciled during reconcilation of ManagedNamespaces
 			if value, ok := namespace.Labels[common.ArgoCDManagedByLabel]; ok {
-				log.Info(fmt.Sprintf("Skipping reconciling resources for namespace %s as it is already managed-by namespace %s.", namespace.Name, value))
+				instanceLog(cr).Info(fmt.Sprintf("Skipping reconciling resources for namespace %s as it is already managed-by namespace %s.", namespace.Name, value))
 				continue
 			}
 
@@ -242,7 +242,7 @@ func (r *ReconcileArgoCD) reconcileRoleBinding(name string, rules []v1.PolicyRul
 			listOption := &client.ListOptions{Namespace: namespace.Name}
 			err := r.Client.List(context.TODO(), list, listOption)
 			if err != nil {
-				log.Info(err.Error())
+				instanceLog(cr).Info(err.Error())
 				return err
 			}
 
@@ -264,7 +264,7 @@ func (r *ReconcileArgoCD) reconcileRoleBinding(name string, rules []v1.PolicyRul
 				if !errors.IsNotFound(err) {
 					return fmt.Errorf("failed to get the rolebinding associated with %s : %s", name, err)
 				}
-				log.Info(fmt.Sprintf("Existing rolebinding %s", err.Error()))
+				instanceLog(cr).Info(fmt.Sprintf("Existing rolebinding %s", err.Error()))
 				roleBindingExists = false
 			}
 
@@ -303,7 +303,7 @@ func (r *ReconcileArgoCD) reconcileRoleBinding(name string, rules []v1.PolicyRul
 				}
 			}
 
-			log.Info(fmt.Sprintf("creating rolebinding %s for Argo CD instance %s in namespace %s", roleBinding.Name, cr.Name, namespace))
+			instanceLog(cr).Info(fmt.Sprintf("creating rolebinding %s for Argo CD instance %s in namespace %s", roleBinding.Name, cr.Name, namespace))
 			if err = r.Client.Create(context.TODO(), roleBinding); err != nil {
 				return err
 			}
@@ -345,7 +345,7 @@ func (r *ReconcileArgoCD) reconcileClusterRoleBinding(name string, role *v1.Clus
 	}
 
 	if err := verifyInstallationMode(cr, true); err != nil {
-		log.Error(err, "error occurred in reconcileClusterRoleBinding")
+		instanceLog(cr).Error(err, "error occurred in reconcileClusterRoleBinding")
 		return nil
 	}
 
@@ -388,6 +388,9 @@ func (r *ReconcileArgoCD) reconcileClusterRoleBinding(name string, role *v1.Clus
 		return nil
 	}
 
+	existingSubjects := roleBinding.Subjects
+	existingRoleRef := roleBinding.RoleRef
+
 	roleBinding.Subjects = []v1.Subject{
 		{
 			Kind:      v1.ServiceAccountKind,
@@ -408,11 +411,35 @@ func (r *ReconcileArgoCD) reconcileClusterRoleBinding(name string, role *v1.Clus
 	}
 
 	if roleBindingExists {
+		if reflect.DeepEqual(existingSubjects, roleBinding.Subjects) && reflect.DeepEqual(existingRoleRef, roleBinding.RoleRef) {
+			return nil // ClusterRoleBinding found with nothing to do, move along...
+		}
+		logStaleSubjects(roleBinding.Name, existingSubjects, cr.Namespace)
 		return r.Client.Update(context.TODO(), roleBinding)
 	}
 	return r.Client.Create(context.TODO(), roleBinding)
 }
 
+// getStaleSubjects will return the ServiceAccount subjects of a binding that are not in the given namespace, which
+// happens when the Argo CD instance is moved or restored to another namespace.
+func getStaleSubjects(subjects []v1.Subject, namespace string) []v1.Subject {
+	var stale []v1.Subject
+	for _, subject := range subjects {
+		if subject.Kind == v1.ServiceAccountKind && subject.Namespace != namespace {
+			stale = append(stale, subject)
+		}
+	}
+	return stale
+}
+
+// logStaleSubjects will log the ServiceAccount subjects of the given binding that are corrected to the instance namespace.
+func logStaleSubjects(bindingName string, subjects []v1.Subject, namespace string) {
+	for _, subject := range getStaleSubjects(subjects, namespace) {
+		log.Info(fmt.Sprintf("correcting namespace of subject %s in binding %s from %s to %s",
+			subject.Name, bindingName, subject.Namespace, namespace))
+	}
+}
+
 func deleteClusterRoleBindings(c client.Client, clusterBindingList *v1.ClusterRoleBindingList) error {
 	for _, clusterBinding := range clusterBindingList.Items {
 		if err := c.Delete(context.TODO(), &clusterBinding); err != nil {
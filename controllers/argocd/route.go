@@ -17,6 +17,7 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 
 	routev1 "github.com/openshift/api/route/v1"
@@ -81,6 +82,65 @@ func newRouteWithSuffix(suffix string, cr *argoproj.ArgoCD) *routev1.Route {
 	return newRouteWithName(fmt.Sprintf("%s-%s", cr.Name, suffix), cr)
 }
 
+// getRouteHost will return the hostname of the Route with the given spec, defaulting to the given host of its component.
+func getRouteHost(spec argoproj.ArgoCDRouteSpec, host string) string {
+	if len(spec.Host) > 0 {
+		return spec.Host
+	}
+	return host
+}
+
+// getRouteTLSSecretRefs will return the names of the Secrets referenced as the TLS secret of the Routes of the given
+// ArgoCD.
+func getRouteTLSSecretRefs(cr *argoproj.ArgoCD) []string {
+	specs := []argoproj.ArgoCDRouteSpec{cr.Spec.Prometheus.Route, cr.Spec.Server.Route}
+	if cr.Spec.ApplicationSet != nil {
+		specs = append(specs, cr.Spec.ApplicationSet.WebhookServer.Route)
+	}
+
+	names := []string{}
+	for _, spec := range specs {
+		if spec.Enabled && spec.TLSSecretRef != nil && len(spec.TLSSecretRef.Name) > 0 {
+			names = append(names, spec.TLSSecretRef.Name)
+		}
+	}
+	return names
+}
+
+// applyRouteTLSSecret will write the certificate, the key and the CA certificate of the Secret referenced by the
+// given Route spec into the TLS configuration of the given Route. Edge termination is used when the Route has no TLS
+// configuration yet.
+func (r *ReconcileArgoCD) applyRouteTLSSecret(cr *argoproj.ArgoCD, spec argoproj.ArgoCDRouteSpec, route *routev1.Route) error {
+	if spec.TLSSecretRef == nil || len(spec.TLSSecretRef.Name) <= 0 {
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, spec.TLSSecretRef.Name, secret); err != nil {
+		return fmt.Errorf("failed to get the TLS secret %s of route %s: %w", spec.TLSSecretRef.Name, route.Name, err)
+	}
+
+	if route.Spec.TLS == nil {
+		route.Spec.TLS = &routev1.TLSConfig{
+			InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+			Termination:                   routev1.TLSTerminationEdge,
+		}
+	} else {
+		// The TLS configuration may be the one of the ArgoCD spec, which must be left untouched
+		route.Spec.TLS = route.Spec.TLS.DeepCopy()
+	}
+	if route.Spec.TLS.Termination == routev1.TLSTerminationPassthrough {
+		return fmt.Errorf("the TLS secret %s cannot be used by route %s with passthrough termination", spec.TLSSecretRef.Name, route.Name)
+	}
+
+	route.Spec.TLS.Certificate = string(secret.Data[corev1.TLSCertKey])
+	route.Spec.TLS.Key = string(secret.Data[corev1.TLSPrivateKeyKey])
+	if ca, ok := secret.Data[common.ArgoCDKeyTLSCACert]; ok {
+		route.Spec.TLS.CACertificate = string(ca)
+	}
+	return nil
+}
+
 // reconcileRoutes will ensure that all ArgoCD Routes are present.
 func (r *ReconcileArgoCD) reconcileRoutes(cr *argoproj.ArgoCD) error {
 	if err := r.reconcileGrafanaRoute(cr); err != nil {
@@ -110,7 +170,7 @@ func (r *ReconcileArgoCD) reconcileGrafanaRoute(cr *argoproj.ArgoCD) error {
 			// Route exists but enabled flag has been set to false, delete the Route
 			return r.Client.Delete(context.TODO(), route)
 		}
-		log.Info(grafanaDeprecatedWarning)
+		instanceLog(cr).Info(grafanaDeprecatedWarning)
 		return nil // Route found, do nothing
 	}
 
@@ -118,26 +178,29 @@ func (r *ReconcileArgoCD) reconcileGrafanaRoute(cr *argoproj.ArgoCD) error {
 		return nil // Grafana itself or Route not enabled, do nothing.
 	}
 
-	log.Info(grafanaDeprecatedWarning)
+	instanceLog(cr).Info(grafanaDeprecatedWarning)
 
 	return nil
 }
 
-// reconcilePrometheusRoute will ensure that the ArgoCD Prometheus Route is present.
+// reconcilePrometheusRoute will ensure that the ArgoCD Prometheus Route is present, and updated when it drifts from
+// the desired state.
 func (r *ReconcileArgoCD) reconcilePrometheusRoute(cr *argoproj.ArgoCD) error {
-	route := newRouteWithSuffix("prometheus", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, route.Name, route) {
-		if !cr.Spec.Prometheus.Enabled || !cr.Spec.Prometheus.Route.Enabled {
+	existing := newRouteWithSuffix("prometheus", cr)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
+	if found {
+		if !isPrometheusManaged(cr) || !cr.Spec.Prometheus.Route.Enabled {
 			// Route exists but enabled flag has been set to false, delete the Route
-			return r.Client.Delete(context.TODO(), route)
+			return r.Client.Delete(context.TODO(), existing)
 		}
-		return nil // Route found, do nothing
 	}
 
-	if !cr.Spec.Prometheus.Enabled || !cr.Spec.Prometheus.Route.Enabled {
+	if !isPrometheusManaged(cr) || !cr.Spec.Prometheus.Route.Enabled {
 		return nil // Prometheus itself or Route not enabled, do nothing.
 	}
 
+	route := newRouteWithSuffix("prometheus", cr)
+
 	// Allow override of the Annotations for the Route.
 	if len(cr.Spec.Prometheus.Route.Annotations) > 0 {
 		route.Annotations = cr.Spec.Prometheus.Route.Annotations
@@ -153,8 +216,8 @@ func (r *ReconcileArgoCD) reconcilePrometheusRoute(cr *argoproj.ArgoCD) error {
 	}
 
 	// Allow override of the Host for the Route.
-	if len(cr.Spec.Prometheus.Host) > 0 {
-		route.Spec.Host = cr.Spec.Prometheus.Host // TODO: What additional role needed for this?
+	if host := getRouteHost(cr.Spec.Prometheus.Route, cr.Spec.Prometheus.Host); len(host) > 0 {
+		route.Spec.Host = host // TODO: What additional role needed for this?
 	}
 
 	route.Spec.Port = &routev1.RoutePort{
@@ -165,6 +228,9 @@ func (r *ReconcileArgoCD) reconcilePrometheusRoute(cr *argoproj.ArgoCD) error {
 	if cr.Spec.Prometheus.Route.TLS != nil {
 		route.Spec.TLS = cr.Spec.Prometheus.Route.TLS
 	}
+	if err := r.applyRouteTLSSecret(cr, cr.Spec.Prometheus.Route, route); err != nil {
+		return err
+	}
 
 	route.Spec.To.Kind = "Service"
 	route.Spec.To.Name = "prometheus-operated"
@@ -174,10 +240,60 @@ func (r *ReconcileArgoCD) reconcilePrometheusRoute(cr *argoproj.ArgoCD) error {
 		route.Spec.WildcardPolicy = *cr.Spec.Prometheus.Route.WildcardPolicy
 	}
 
-	if err := controllerutil.SetControllerReference(cr, route, r.Scheme); err != nil {
+	if !found {
+		if err := controllerutil.SetControllerReference(cr, route, r.Scheme); err != nil {
+			return err
+		}
+		return r.Client.Create(context.TODO(), route)
+	}
+	if !updateRoute(existing, route) {
+		return nil // Route found with nothing to do, move along...
+	}
+	if err := controllerutil.SetControllerReference(cr, existing, r.Scheme); err != nil {
 		return err
 	}
-	return r.Client.Create(context.TODO(), route)
+	return r.Client.Update(context.TODO(), existing)
+}
+
+// updateRoute will update the given existing Route with the spec, labels and annotations of the given desired Route,
+// returning true if it was changed. The fields left unset in the desired spec keep the values assigned by the
+// cluster, e.g. the generated host, and the labels and annotations not set by the operator are kept.
+func updateRoute(existing *routev1.Route, desired *routev1.Route) bool {
+	changed := false
+
+	if desired.Spec.Host == "" {
+		desired.Spec.Host = existing.Spec.Host
+	}
+	if desired.Spec.To.Weight == nil {
+		desired.Spec.To.Weight = existing.Spec.To.Weight
+	}
+	if desired.Spec.WildcardPolicy == "" {
+		desired.Spec.WildcardPolicy = existing.Spec.WildcardPolicy
+	}
+	if !reflect.DeepEqual(existing.Spec, desired.Spec) {
+		existing.Spec = desired.Spec
+		changed = true
+	}
+
+	for key, value := range desired.Labels {
+		if existing.Labels[key] != value {
+			if existing.Labels == nil {
+				existing.Labels = map[string]string{}
+			}
+			existing.Labels[key] = value
+			changed = true
+		}
+	}
+	for key, value := range desired.Annotations {
+		if existing.Annotations[key] != value {
+			if existing.Annotations == nil {
+				existing.Annotations = map[string]string{}
+			}
+			existing.Annotations[key] = value
+			changed = true
+		}
+	}
+	return changed
 }
 
 // reconcileServerRoute will ensure that the ArgoCD Server Route is present.
@@ -186,13 +302,13 @@ func (r *ReconcileArgoCD) reconcileServerRoute(cr *argoproj.ArgoCD) error {
 	route := newRouteWithSuffix("server", cr)
 	found := argoutil.IsObjectFound(r.Client, cr.Namespace, route.Name, route)
 	if found {
-		if !cr.Spec.Server.Route.Enabled {
+		if !cr.Spec.Server.Route.Enabled || !isServerEnabled(cr) {
 			// Route exists but enabled flag has been set to false, delete the Route
 			return r.Client.Delete(context.TODO(), route)
 		}
 	}
 
-	if !cr.Spec.Server.Route.Enabled {
+	if !cr.Spec.Server.Route.Enabled || !isServerEnabled(cr) {
 		return nil // Route not enabled, move along...
 	}
 
@@ -211,8 +327,8 @@ func (r *ReconcileArgoCD) reconcileServerRoute(cr *argoproj.ArgoCD) error {
 	}
 
 	// Allow override of the Host for the Route.
-	if len(cr.Spec.Server.Host) > 0 {
-		route.Spec.Host = cr.Spec.Server.Host // TODO: What additional role needed for this?
+	if host := getRouteHost(cr.Spec.Server.Route, cr.Spec.Server.Host); len(host) > 0 {
+		route.Spec.Host = host // TODO: What additional role needed for this?
 	}
 
 	hostname, err := shortenHostname(route.Spec.Host)
@@ -256,6 +372,9 @@ func (r *ReconcileArgoCD) reconcileServerRoute(cr *argoproj.ArgoCD) error {
 	if cr.Spec.Server.Route.TLS != nil {
 		route.Spec.TLS = cr.Spec.Server.Route.TLS
 	}
+	if err := r.applyRouteTLSSecret(cr, cr.Spec.Server.Route, route); err != nil {
+		return err
+	}
 
 	route.Spec.To.Kind = "Service"
 	route.Spec.To.Name = nameWithSuffix("server", cr)
@@ -305,8 +424,8 @@ func (r *ReconcileArgoCD) reconcileApplicationSetControllerWebhookRoute(cr *argo
 	}
 
 	// Allow override of the Host for the Route.
-	if len(cr.Spec.ApplicationSet.WebhookServer.Host) > 0 {
-		route.Spec.Host = cr.Spec.ApplicationSet.WebhookServer.Host
+	if host := getRouteHost(cr.Spec.ApplicationSet.WebhookServer.Route, cr.Spec.ApplicationSet.WebhookServer.Host); len(host) > 0 {
+		route.Spec.Host = host
 	}
 
 	hostname, err := shortenHostname(route.Spec.Host)
@@ -366,6 +485,9 @@ func (r *ReconcileArgoCD) reconcileApplicationSetControllerWebhookRoute(cr *argo
 			Termination:                   routev1.TLSTerminationEdge,
 		}
 	}
+	if err := r.applyRouteTLSSecret(cr, cr.Spec.ApplicationSet.WebhookServer.Route, route); err != nil {
+		return err
+	}
 
 	route.Spec.To.Kind = "Service"
 	route.Spec.To.Name = nameWithSuffix(common.ApplicationSetServiceNameSuffix, cr)
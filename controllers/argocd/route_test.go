@@ -634,3 +634,100 @@ func testNamespacedName(name string) types.NamespacedName {
 		Namespace: testNamespace,
 	}
 }
+
+func TestReconcileRouteHostAndTLSSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	argoCD := makeArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Host = "argocd.example.com"
+		a.Spec.Server.Route = argoproj.ArgoCDRouteSpec{
+			Enabled: true,
+			Host:    "argocd.apps.example.com",
+			TLS: &routev1.TLSConfig{
+				Termination: routev1.TLSTerminationReencrypt,
+			},
+			TLSSecretRef: &corev1.LocalObjectReference{Name: "argocd-route-tls"},
+		}
+	})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-route-tls", Namespace: testNamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:         []byte("certificate"),
+			corev1.TLSPrivateKeyKey:   []byte("key"),
+			common.ArgoCDKeyTLSCACert: []byte("ca"),
+		},
+	}
+
+	resObjs := []client.Object{argoCD, secret}
+	subresObjs := []client.Object{argoCD}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, routev1.Install)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerRoute(argoCD))
+
+	loaded := &routev1.Route{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: testArgoCDName + "-server", Namespace: testNamespace}, loaded))
+	assert.Equal(t, "argocd.apps.example.com", loaded.Spec.Host)
+	assert.Equal(t, &routev1.TLSConfig{
+		Termination:   routev1.TLSTerminationReencrypt,
+		Certificate:   "certificate",
+		Key:           "key",
+		CACertificate: "ca",
+	}, loaded.Spec.TLS)
+	// The TLS configuration of the spec is left untouched
+	assert.Empty(t, argoCD.Spec.Server.Route.TLS.Certificate)
+
+	// The renewal of the certificate reconciles the instance and updates the Route
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: testArgoCDName, Namespace: testNamespace}}},
+		r.tlsSecretMapper(context.TODO(), secret))
+	secret.Data[corev1.TLSCertKey] = []byte("renewed")
+	assert.NoError(t, r.Client.Update(context.TODO(), secret))
+	assert.NoError(t, r.reconcileServerRoute(argoCD))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: loaded.Name, Namespace: testNamespace}, loaded))
+	assert.Equal(t, "renewed", loaded.Spec.TLS.Certificate)
+
+	// A passthrough Route cannot hold a certificate
+	argoCD.Spec.Server.Route.TLS.Termination = routev1.TLSTerminationPassthrough
+	assert.ErrorContains(t, r.reconcileServerRoute(argoCD), "passthrough")
+}
+
+func TestReconcilePrometheusRoute_drift(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	argoCD := makeArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Prometheus.Enabled = true
+		a.Spec.Prometheus.Route.Enabled = true
+		a.Spec.Prometheus.Route.TLS = &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}
+	})
+
+	resObjs := []client.Object{argoCD}
+	subresObjs := []client.Object{argoCD}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, configv1.Install, routev1.Install)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcilePrometheusRoute(argoCD))
+
+	route := &routev1.Route{}
+	key := types.NamespacedName{Name: testArgoCDName + "-prometheus", Namespace: testNamespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, route))
+	assert.Equal(t, routev1.TLSTerminationEdge, route.Spec.TLS.Termination)
+
+	// The host generated by the cluster is kept, and the Route is left untouched without drift
+	route.Spec.Host = "prometheus.apps.example.com"
+	assert.NoError(t, r.Client.Update(context.TODO(), route))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, route))
+	version := route.ResourceVersion
+	assert.NoError(t, r.reconcilePrometheusRoute(argoCD))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, route))
+	assert.Equal(t, version, route.ResourceVersion)
+	assert.Equal(t, "prometheus.apps.example.com", route.Spec.Host)
+
+	// The TLS options removed from the spec are removed from the Route
+	argoCD.Spec.Prometheus.Route.TLS = nil
+	assert.NoError(t, r.reconcilePrometheusRoute(argoCD))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, route))
+	assert.Nil(t, route.Spec.TLS)
+}
@@ -15,6 +15,7 @@
 package argocd
 
 import (
+	"bytes"
 	"context"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -22,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
 	"sort"
 	"strings"
 	"time"
@@ -35,10 +37,12 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
 )
 
 // hasArgoAdminPasswordChanged will return true if the Argo admin password has changed.
@@ -126,9 +130,9 @@ func newCertificateSecret(suffix string, caCert *x509.Certificate, caKey *rsa.Pr
 	}
 
 	if cr.Spec.Grafana.Enabled {
-		log.Info(grafanaDeprecatedWarning)
+		instanceLog(cr).Info(grafanaDeprecatedWarning)
 	}
-	if cr.Spec.Prometheus.Enabled {
+	if isPrometheusManaged(cr) {
 		dnsNames = append(dnsNames, getPrometheusHost(cr))
 	}
 
@@ -147,17 +151,17 @@ func newCertificateSecret(suffix string, caCert *x509.Certificate, caKey *rsa.Pr
 
 // reconcileArgoSecret will ensure that the Argo CD Secret is present.
 func (r *ReconcileArgoCD) reconcileArgoSecret(cr *argoproj.ArgoCD) error {
-	clusterSecret := argoutil.NewSecretWithSuffix(cr, "cluster")
+	clusterSecret := newAdminPasswordSecret(cr)
 	secret := argoutil.NewSecretWithName(cr, common.ArgoCDSecretName)
 
 	if !argoutil.IsObjectFound(r.Client, cr.Namespace, clusterSecret.Name, clusterSecret) {
-		log.Info(fmt.Sprintf("cluster secret [%s] not found, waiting to reconcile argo secret [%s]", clusterSecret.Name, secret.Name))
+		instanceLog(cr).Info(fmt.Sprintf("cluster secret [%s] not found, waiting to reconcile argo secret [%s]", clusterSecret.Name, secret.Name))
 		return nil
 	}
 
 	tlsSecret := argoutil.NewSecretWithSuffix(cr, "tls")
 	if !argoutil.IsObjectFound(r.Client, cr.Namespace, tlsSecret.Name, tlsSecret) {
-		log.Info(fmt.Sprintf("tls secret [%s] not found, waiting to reconcile argo secret [%s]", tlsSecret.Name, secret.Name))
+		instanceLog(cr).Info(fmt.Sprintf("tls secret [%s] not found, waiting to reconcile argo secret [%s]", tlsSecret.Name, secret.Name))
 		return nil
 	}
 
@@ -171,7 +175,7 @@ func (r *ReconcileArgoCD) reconcileArgoSecret(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
-	sessionKey, err := generateArgoServerSessionKey()
+	sessionKey, err := r.generateSecretValue(cr, common.ArgoCDKeyServerSecretKey, common.ArgoCDDefaultServerSessionKeyLength, generateArgoServerSessionKey)
 	if err != nil {
 		return err
 	}
@@ -192,20 +196,44 @@ func (r *ReconcileArgoCD) reconcileArgoSecret(cr *argoproj.ArgoCD) error {
 		secret.Data[common.ArgoCDDexSecretKey] = []byte(*dexOIDCClientSecret)
 	}
 
+	if UseExternalOIDC(cr) {
+		clientSecret, err := r.getExternalOIDCClientSecret(cr)
+		if err != nil {
+			return err
+		}
+		if clientSecret != nil {
+			secret.Data[common.ArgoCDExternalOIDCSecretKey] = clientSecret
+		}
+	}
+
 	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
 		return err
 	}
 	return r.Client.Create(context.TODO(), secret)
 }
 
-// reconcileClusterMainSecret will ensure that the main Secret is present for the Argo CD cluster.
+// reconcileClusterMainSecret will ensure that the main Secret is present for the Argo CD cluster, holding the
+// admin password.
 func (r *ReconcileArgoCD) reconcileClusterMainSecret(cr *argoproj.ArgoCD) error {
-	secret := argoutil.NewSecretWithSuffix(cr, "cluster")
+	secret := newAdminPasswordSecret(cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
-		return nil // Secret found, do nothing
+		if len(secret.Data[common.ArgoCDKeyAdminPassword]) > 0 {
+			return nil // Secret found, do nothing
+		}
+
+		// Secret referenced by the admin password policy, provided without a password
+		adminPassword, err := r.generateSecretValue(cr, common.ArgoCDKeyAdminPassword, common.ArgoCDDefaultAdminPasswordLength, generateArgoAdminPassword)
+		if err != nil {
+			return err
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[common.ArgoCDKeyAdminPassword] = adminPassword
+		return r.Client.Update(context.TODO(), secret)
 	}
 
-	adminPassword, err := generateArgoAdminPassword()
+	adminPassword, err := r.generateSecretValue(cr, common.ArgoCDKeyAdminPassword, common.ArgoCDDefaultAdminPasswordLength, generateArgoAdminPassword)
 	if err != nil {
 		return err
 	}
@@ -299,6 +327,14 @@ func (r *ReconcileArgoCD) reconcileClusterSecrets(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	if err := r.reconcileGrafanaDatasourceSecret(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileRepositorySecrets(cr); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -311,7 +347,7 @@ func (r *ReconcileArgoCD) reconcileExistingArgoSecret(cr *argoproj.ArgoCD, secre
 	}
 
 	if secret.Data[common.ArgoCDKeyServerSecretKey] == nil {
-		sessionKey, err := generateArgoServerSessionKey()
+		sessionKey, err := r.generateSecretValue(cr, common.ArgoCDKeyServerSecretKey, common.ArgoCDDefaultServerSessionKeyLength, generateArgoServerSessionKey)
 		if err != nil {
 			return err
 		}
@@ -354,8 +390,19 @@ func (r *ReconcileArgoCD) reconcileExistingArgoSecret(cr *argoproj.ArgoCD, secre
 		}
 	}
 
+	if UseExternalOIDC(cr) {
+		clientSecret, err := r.getExternalOIDCClientSecret(cr)
+		if err != nil {
+			return err
+		}
+		if clientSecret != nil && !bytes.Equal(secret.Data[common.ArgoCDExternalOIDCSecretKey], clientSecret) {
+			secret.Data[common.ArgoCDExternalOIDCSecretKey] = clientSecret
+			changed = true
+		}
+	}
+
 	if changed {
-		log.Info("updating argo secret")
+		instanceLog(cr).Info("updating argo secret")
 		if err := r.Client.Update(context.TODO(), secret); err != nil {
 			return err
 		}
@@ -370,11 +417,83 @@ func (r *ReconcileArgoCD) reconcileGrafanaSecret(cr *argoproj.ArgoCD) error {
 		return nil // Grafana not enabled, do nothing.
 	}
 
-	log.Info(grafanaDeprecatedWarning)
+	instanceLog(cr).Info(grafanaDeprecatedWarning)
 
 	return nil
 }
 
+// getGrafanaDatasourceSecretName returns the name of the Secret holding the Grafana datasource of the given ArgoCD.
+func getGrafanaDatasourceSecretName(cr *argoproj.ArgoCD) string {
+	if external := cr.Spec.Grafana.External; external != nil && external.DatasourceSecretRef != nil && external.DatasourceSecretRef.Name != "" {
+		return external.DatasourceSecretRef.Name
+	}
+	return nameWithSuffix("grafana-datasource", cr)
+}
+
+// getGrafanaDatasource will return the Grafana provisioning file declaring the Prometheus of the given ArgoCD as a
+// datasource.
+func getGrafanaDatasource(cr *argoproj.ArgoCD) ([]byte, error) {
+	url := cr.Spec.Grafana.External.PrometheusURL
+	if url == "" {
+		// The Service created by the Prometheus Operator for the Prometheus of the instance
+		url = fmt.Sprintf("http://prometheus-operated.%s.svc:9090", cr.Namespace)
+	}
+	return yaml.Marshal(map[string]interface{}{
+		"apiVersion": 1,
+		"datasources": []map[string]interface{}{{
+			"name":     fmt.Sprintf("Argo CD %s/%s", cr.Namespace, cr.Name),
+			"type":     "prometheus",
+			"access":   "proxy",
+			"url":      url,
+			"editable": false,
+		}},
+	})
+}
+
+// reconcileGrafanaDatasourceSecret will ensure that the Secret registering the Prometheus of the given ArgoCD as a
+// datasource of an existing Grafana is present when requested, and removed otherwise.
+func (r *ReconcileArgoCD) reconcileGrafanaDatasourceSecret(cr *argoproj.ArgoCD) error {
+	secret := argoutil.NewSecretWithName(cr, getGrafanaDatasourceSecretName(cr))
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret)
+	if cr.Spec.Grafana.External == nil {
+		if found && metav1.IsControlledBy(secret, cr) {
+			return r.Client.Delete(context.TODO(), secret)
+		}
+		return nil
+	}
+
+	datasource, err := getGrafanaDatasource(cr)
+	if err != nil {
+		return err
+	}
+	// One file per instance, so that the datasources of several instances can be provisioned in the same Grafana
+	key := fmt.Sprintf("argocd-%s-%s.yaml", cr.Namespace, cr.Name)
+
+	if !found {
+		secret.Labels[common.GrafanaDatasourceLabel] = "1"
+		secret.Data = map[string][]byte{key: datasource}
+		if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+			return err
+		}
+		instanceLog(cr).Info(fmt.Sprintf("Creating Secret %s with the Grafana datasource of ArgoCD %s", secret.Name, cr.Name))
+		return r.Client.Create(context.TODO(), secret)
+	}
+
+	// The other keys of an existing Secret are left untouched
+	if secret.Labels[common.GrafanaDatasourceLabel] == "1" && bytes.Equal(secret.Data[key], datasource) {
+		return nil
+	}
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Labels[common.GrafanaDatasourceLabel] = "1"
+	secret.Data[key] = datasource
+	return r.Client.Update(context.TODO(), secret)
+}
+
 // reconcileClusterPermissionsSecret ensures ArgoCD instance is namespace-scoped
 func (r *ReconcileArgoCD) reconcileClusterPermissionsSecret(cr *argoproj.ArgoCD) error {
 	var clusterConfigInstance bool
@@ -461,7 +580,7 @@ func (r *ReconcileArgoCD) reconcileRepoServerTLSSecret(cr *argoproj.ArgoCD) erro
 	var tlsSecretObj corev1.Secret
 	var sha256sum string
 
-	log.Info("reconciling repo-server TLS secret")
+	componentLog(cr, logComponentRepoServer).Info("reconciling repo-server TLS secret")
 
 	tlsSecretName := types.NamespacedName{Namespace: cr.Namespace, Name: common.ArgoCDRepoServerTLSSecretName}
 	err := r.Client.Get(context.TODO(), tlsSecretName, &tlsSecretObj)
@@ -521,6 +640,135 @@ func (r *ReconcileArgoCD) reconcileRepoServerTLSSecret(cr *argoproj.ArgoCD) erro
 	return nil
 }
 
+// getRedisTLSDNSNames returns the DNS names that the self-signed redis server
+// certificate is issued for. It covers the standalone and the HA services.
+func getRedisTLSDNSNames(cr *argoproj.ArgoCD) []string {
+	services := []string{
+		nameWithSuffix("redis", cr),
+		nameWithSuffix("redis-ha", cr),
+		nameWithSuffix("redis-ha-haproxy", cr),
+	}
+	for i := int32(0); i < common.ArgoCDDefaultRedisHAReplicas; i++ {
+		services = append(services, nameWithSuffix(fmt.Sprintf("redis-ha-announce-%d", i), cr))
+	}
+
+	dnsNames := []string{}
+	for _, svc := range services {
+		dnsNames = append(dnsNames,
+			svc,
+			fmt.Sprintf("%s.%s.svc", svc, cr.Namespace),
+			fmt.Sprintf("%s.%s.svc.cluster.local", svc, cr.Namespace),
+		)
+	}
+	return dnsNames
+}
+
+// newRedisSelfSignedTLSSecret creates the redis server TLS secret, signed by the given CA, for the given ArgoCD.
+func newRedisSelfSignedTLSSecret(caCert *x509.Certificate, caKey *rsa.PrivateKey, cr *argoproj.ArgoCD) (*corev1.Secret, error) {
+	secret := argoutil.NewSecretWithName(cr, common.ArgoCDRedisServerTLSSecretName)
+	secret.Type = corev1.SecretTypeTLS
+
+	key, err := argoutil.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &certmanagerv1.CertificateSpec{
+		SecretName: secret.Name,
+		CommonName: nameWithSuffix("redis", cr),
+		Subject: &certmanagerv1.X509Subject{
+			Organizations: []string{cr.ObjectMeta.Namespace},
+		},
+	}
+
+	cert, err := argoutil.NewSignedCertificate(cfg, getRedisTLSDNSNames(cr), key, caCert, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:              argoutil.EncodeCertificatePEM(cert),
+		corev1.TLSPrivateKeyKey:        argoutil.EncodePrivateKeyPEM(key),
+		corev1.ServiceAccountRootCAKey: argoutil.EncodeCertificatePEM(caCert),
+	}
+
+	return secret, nil
+}
+
+// isSelfSignedCertRenewalRequired will return true if the certificate in the
+// given secret cannot be parsed, is about to expire, or was not signed by the
+// given CA certificate.
+func isSelfSignedCertRenewalRequired(secret *corev1.Secret, caCert *x509.Certificate) bool {
+	cert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return true
+	}
+	if time.Until(cert.NotAfter) < common.ArgoCDSelfSignedCertRenewBefore {
+		return true
+	}
+	return cert.CheckSignatureFrom(caCert) != nil
+}
+
+// reconcileRedisSelfSignedTLSSecret will ensure that the argocd-operator-redis-tls
+// secret holds a valid certificate signed by the CA of the ArgoCD instance when
+// the redis autotls provider is set to self-signed. The certificate is rotated
+// before it expires; the rollout of the workloads is then handled by
+// reconcileRedisTLSSecret through the checksum in the status.
+func (r *ReconcileArgoCD) reconcileRedisSelfSignedTLSSecret(cr *argoproj.ArgoCD) error {
+	secret := argoutil.NewSecretWithName(cr, common.ArgoCDRedisServerTLSSecretName)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret)
+
+	if !cr.Spec.Redis.WantsSelfSignedTLS() || !cr.Spec.Redis.IsEnabled() || (cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "") {
+		// Only remove the secret if it was generated by the operator, user provided secrets are left alone.
+		if found && metav1.IsControlledBy(secret, cr) {
+			componentLog(cr, logComponentRedis).Info(fmt.Sprintf("deleting self-signed redis TLS secret %s", secret.Name))
+			return r.Client.Delete(context.TODO(), secret)
+		}
+		return nil
+	}
+
+	if found && !metav1.IsControlledBy(secret, cr) {
+		componentLog(cr, logComponentRedis).Info(fmt.Sprintf("skipping self-signed TLS for redis since the TLS secret %s is not managed by the operator", secret.Name))
+		return nil
+	}
+
+	caSecret := argoutil.NewSecretWithSuffix(cr, common.ArgoCDCASuffix)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, caSecret.Name, caSecret) {
+		componentLog(cr, logComponentRedis).Info(fmt.Sprintf("ca secret [%s] not found, waiting to reconcile redis tls secret [%s]", caSecret.Name, secret.Name))
+		return nil
+	}
+
+	caCert, err := argoutil.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return err
+	}
+
+	caKey, err := argoutil.ParsePEMEncodedPrivateKey(caSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return err
+	}
+
+	if found && !isSelfSignedCertRenewalRequired(secret, caCert) {
+		return nil // Certificate is still valid, do nothing
+	}
+
+	desired, err := newRedisSelfSignedTLSSecret(caCert, caKey, cr)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		componentLog(cr, logComponentRedis).Info(fmt.Sprintf("rotating self-signed redis TLS certificate in secret %s", secret.Name))
+		secret.Data = desired.Data
+		return r.Client.Update(context.TODO(), secret)
+	}
+
+	if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), desired)
+}
+
 // reconcileRedisTLSSecret checks whether the argocd-operator-redis-tls secret
 // has changed since our last reconciliation loop. It does so by comparing the
 // checksum of tls.crt and tls.key in the status of the ArgoCD CR against the
@@ -529,7 +777,7 @@ func (r *ReconcileArgoCD) reconcileRedisTLSSecret(cr *argoproj.ArgoCD, useTLSFor
 	var tlsSecretObj corev1.Secret
 	var sha256sum string
 
-	log.Info("reconciling redis-server TLS secret")
+	componentLog(cr, logComponentRedis).Info("reconciling redis-server TLS secret")
 
 	tlsSecretName := types.NamespacedName{Namespace: cr.Namespace, Name: common.ArgoCDRedisServerTLSSecretName}
 	err := r.Client.Get(context.TODO(), tlsSecretName, &tlsSecretObj)
@@ -633,6 +881,18 @@ func (r *ReconcileArgoCD) reconcileSecrets(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	if err := r.reconcileAdminPasswordRotation(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileAccountTokens(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileRedisSelfSignedTLSSecret(cr); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -660,7 +920,7 @@ func (r *ReconcileArgoCD) reconcileRedisInitialPasswordSecret(cr *argoproj.ArgoC
 		return nil // Secret found, do nothing
 	}
 
-	redisInitialPassword, err := generateRedisAdminPassword()
+	redisInitialPassword, err := r.generateSecretValue(cr, "redis-initial-password", common.RedisDefaultAdminPasswordLength, generateRedisAdminPassword)
 	if err != nil {
 		return err
 	}
@@ -675,3 +935,124 @@ func (r *ReconcileArgoCD) reconcileRedisInitialPasswordSecret(cr *argoproj.ArgoC
 	}
 	return r.Client.Create(context.TODO(), secret)
 }
+
+// getRepositorySecretName will return the name of the repository Secret of the given repository URL.
+func getRepositorySecretName(url string, cr *argoproj.ArgoCD) string {
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(url)))
+	return nameWithSuffix("repo-"+hash[:10], cr)
+}
+
+// newRepositorySecret will return the desired repository Secret of the given repository, holding the credentials
+// found in the referenced Secret.
+func (r *ReconcileArgoCD) newRepositorySecret(cr *argoproj.ArgoCD, repo argoproj.RepositorySpec) (*corev1.Secret, error) {
+	secret := argoutil.NewSecretWithName(cr, getRepositorySecretName(repo.URL, cr))
+	secret.Labels[common.ArgoCDSecretTypeLabel] = common.ArgoCDSecretTypeRepository
+	secret.Labels[common.ArgoCDKeyComponent] = common.ArgoCDSecretTypeRepository
+	secret.Data = map[string][]byte{}
+
+	if repo.SecretRef != nil {
+		creds := &corev1.Secret{}
+		if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: repo.SecretRef.Name, Namespace: cr.Namespace}, creds); err != nil {
+			return nil, fmt.Errorf("failed to get credentials Secret %s of repository %s: %w", repo.SecretRef.Name, repo.URL, err)
+		}
+		for key, value := range creds.Data {
+			secret.Data[key] = value
+		}
+	}
+
+	secret.Data["url"] = []byte(repo.URL)
+	for key, value := range map[string]string{"type": repo.Type, "name": repo.Name, "project": repo.Project} {
+		if value != "" {
+			secret.Data[key] = []byte(value)
+		} else {
+			delete(secret.Data, key)
+		}
+	}
+	return secret, nil
+}
+
+// reconcileRepositorySecrets will ensure that a repository Secret is present for each repository of the given
+// ArgoCD, and that the repository Secrets of repositories that have been removed are deleted.
+func (r *ReconcileArgoCD) reconcileRepositorySecrets(cr *argoproj.ArgoCD) error {
+	desired := make(map[string]bool)
+
+	for _, repo := range cr.Spec.Repositories {
+		secret, err := r.newRepositorySecret(cr, repo)
+		if err != nil {
+			return err
+		}
+		if desired[secret.Name] {
+			instanceLog(cr).Info(fmt.Sprintf("skipping duplicate repository %s", repo.URL))
+			continue
+		}
+		desired[secret.Name] = true
+
+		existing := &corev1.Secret{}
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, existing) {
+			// Leave alone a Secret of the same name that was not created by the operator, e.g. user-managed
+			// repository credentials
+			if existing.Labels[common.ArgoCDKeyManagedBy] != argoutil.ManagedByLabelValue(cr) && !metav1.IsControlledBy(existing, cr) {
+				message := fmt.Sprintf("skipping repository %s as its Secret %s is not managed by the operator", repo.URL, existing.Name)
+				instanceLog(cr).Info(message)
+				r.recordEvent(cr, corev1.EventTypeWarning, "RepositorySecretConflict", message)
+				continue
+			}
+
+			changed := false
+			if !reflect.DeepEqual(existing.Data, secret.Data) {
+				existing.Data = secret.Data
+				changed = true
+			}
+			for key, value := range secret.Labels {
+				if existing.Labels[key] != value {
+					if existing.Labels == nil {
+						existing.Labels = map[string]string{}
+					}
+					existing.Labels[key] = value
+					changed = true
+				}
+			}
+			if changed {
+				instanceLog(cr).Info(fmt.Sprintf("updating repository Secret %s", existing.Name))
+				if err := r.Client.Update(context.TODO(), existing); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+			return err
+		}
+		instanceLog(cr).Info(fmt.Sprintf("creating repository Secret %s", secret.Name))
+		if err := r.Client.Create(context.TODO(), secret); err != nil {
+			return err
+		}
+	}
+
+	// Delete the repository Secrets of the repositories that are no longer listed
+	secrets := &corev1.SecretList{}
+	opts := &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			common.ArgoCDSecretTypeLabel: common.ArgoCDSecretTypeRepository,
+			common.ArgoCDKeyComponent:    common.ArgoCDSecretTypeRepository,
+			common.ArgoCDKeyManagedBy:    argoutil.ManagedByLabelValue(cr),
+		}),
+		Namespace: cr.Namespace,
+	}
+	if err := r.Client.List(context.TODO(), secrets, opts); err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if desired[secret.Name] || !metav1.IsControlledBy(secret, cr) {
+			continue
+		}
+		instanceLog(cr).Info(fmt.Sprintf("deleting repository Secret %s as the repository was removed", secret.Name))
+		if err := r.Client.Delete(context.TODO(), secret); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// seededSecretAlphabet is the set of characters of the secret values derived from a seed.
+const seededSecretAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// getSecretSeed will return the seed the generated secrets of the given ArgoCD are derived from, nil if the instance
+// does not reference one. An error is returned when the referenced seed cannot be read, rather than generating
+// random secrets that would differ from the ones of a restored instance.
+func (r *ReconcileArgoCD) getSecretSeed(cr *argoproj.ArgoCD) ([]byte, error) {
+	ref := cr.Spec.SecretSeedRef
+	if ref == nil {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, ref.Name, secret); err != nil {
+		return nil, fmt.Errorf("failed to read the secret seed %s of ArgoCD %s/%s: %w", ref.Name, cr.Namespace, cr.Name, err)
+	}
+	seed := secret.Data[ref.Key]
+	if len(seed) == 0 {
+		return nil, fmt.Errorf("secret seed %s of ArgoCD %s/%s has no %s key", ref.Name, cr.Namespace, cr.Name, ref.Key)
+	}
+	return seed, nil
+}
+
+// deriveSecretValue will derive the value of the given purpose for the given ArgoCD from the given seed, e.g. the
+// admin password. The value is an HMAC-SHA256 stream of the seed over the namespace, the name of the instance and the
+// purpose, mapped to alphanumeric characters, so that the same seed yields the same value for the same instance only.
+func deriveSecretValue(seed []byte, cr *argoproj.ArgoCD, purpose string, length int) []byte {
+	value := make([]byte, 0, length)
+	for counter := uint32(0); len(value) < length; counter++ {
+		mac := hmac.New(sha256.New, seed)
+		_ = binary.Write(mac, binary.BigEndian, counter)
+		mac.Write([]byte(fmt.Sprintf("%s/%s/%s", cr.Namespace, cr.Name, purpose)))
+		for _, b := range mac.Sum(nil) {
+			// Skip the bytes above the largest multiple of the alphabet size, which would bias the characters
+			if int(b) >= 256-256%len(seededSecretAlphabet) {
+				continue
+			}
+			value = append(value, seededSecretAlphabet[int(b)%len(seededSecretAlphabet)])
+			if len(value) == length {
+				break
+			}
+		}
+	}
+	return value
+}
+
+// generateSecretValue will return the value of the given purpose for the given ArgoCD, derived from the secret seed
+// of the instance when it references one, or generated randomly with the given function otherwise.
+func (r *ReconcileArgoCD) generateSecretValue(cr *argoproj.ArgoCD, purpose string, length int, generate func() ([]byte, error)) ([]byte, error) {
+	seed, err := r.getSecretSeed(cr)
+	if err != nil {
+		return nil, err
+	}
+	if seed == nil {
+		return generate()
+	}
+	return deriveSecretValue(seed, cr, purpose, length), nil
+}
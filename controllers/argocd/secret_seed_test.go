@@ -0,0 +1,73 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileArgoCD_reconcileClusterMainSecret_secretSeed(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SecretSeedRef = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "argocd-seed"},
+			Key:                  "seed",
+		}
+	})
+	seed := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-seed", Namespace: a.Namespace},
+		Data:       map[string][]byte{"seed": []byte("s3cr3t-seed")},
+	}
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// No secret is generated while the seed is missing
+	assert.Error(t, r.reconcileClusterMainSecret(a))
+	assert.NoError(t, r.Client.Create(context.TODO(), seed))
+
+	// The admin password is derived from the seed, and derived again once the Secret is recreated
+	assert.NoError(t, r.reconcileClusterMainSecret(a))
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Name: "argocd-cluster", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	password := secret.Data[common.ArgoCDKeyAdminPassword]
+	assert.Len(t, password, common.ArgoCDDefaultAdminPasswordLength)
+
+	assert.NoError(t, r.Client.Delete(context.TODO(), secret))
+	assert.NoError(t, r.reconcileClusterMainSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	assert.Equal(t, password, secret.Data[common.ArgoCDKeyAdminPassword])
+
+	// The values differ between the purposes and the instances sharing a seed
+	assert.NotEqual(t, password[:20], deriveSecretValue(seed.Data["seed"], a, common.ArgoCDKeyServerSecretKey, 20))
+	other := a.DeepCopy()
+	other.Name = "other"
+	assert.NotEqual(t, password, deriveSecretValue(seed.Data["seed"], other, common.ArgoCDKeyAdminPassword, common.ArgoCDDefaultAdminPasswordLength))
+}
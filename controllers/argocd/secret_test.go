@@ -15,10 +15,12 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -504,6 +506,87 @@ func Test_ReconcileArgoCD_ReconcileRedisTLSSecret(t *testing.T) {
 	})
 }
 
+func Test_ReconcileArgoCD_ReconcileRedisSelfSignedTLSSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Redis.AutoTLS = "self-signed"
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// No CA secret yet, nothing should be created
+	assert.NoError(t, r.reconcileRedisSelfSignedTLSSecret(a))
+	secret := &corev1.Secret{}
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisServerTLSSecretName, Namespace: a.Namespace}, secret))
+
+	assert.NoError(t, r.reconcileClusterCASecret(a))
+	assert.NoError(t, r.reconcileRedisSelfSignedTLSSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisServerTLSSecretName, Namespace: a.Namespace}, secret))
+	assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+	assert.True(t, metav1.IsControlledBy(secret, a))
+	assert.True(t, r.redisShouldUseTLS(a))
+
+	caSecret, err := argoutil.FetchSecret(r.Client, a.ObjectMeta, fmt.Sprintf("%s-%s", a.Name, common.ArgoCDCASuffix))
+	assert.NoError(t, err)
+	caCert, err := argoutil.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	cert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.NoError(t, cert.CheckSignatureFrom(caCert))
+	assert.Contains(t, cert.DNSNames, "argocd-redis.argocd.svc.cluster.local")
+	assert.Contains(t, cert.DNSNames, "argocd-redis-ha-haproxy")
+	assert.Equal(t, caSecret.Data[corev1.TLSCertKey], secret.Data[corev1.ServiceAccountRootCAKey])
+
+	// A valid certificate must not be rotated
+	crt := secret.Data[corev1.TLSCertKey]
+	assert.NoError(t, r.reconcileRedisSelfSignedTLSSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisServerTLSSecretName, Namespace: a.Namespace}, secret))
+	assert.Equal(t, crt, secret.Data[corev1.TLSCertKey])
+
+	// An invalid certificate must be rotated
+	secret.Data[corev1.TLSCertKey] = []byte("foo")
+	assert.NoError(t, r.Client.Update(context.TODO(), secret))
+	assert.NoError(t, r.reconcileRedisSelfSignedTLSSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisServerTLSSecretName, Namespace: a.Namespace}, secret))
+	_, err = argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+
+	// Switching off self-signed TLS removes the generated secret
+	a.Spec.Redis.AutoTLS = ""
+	assert.NoError(t, r.reconcileRedisSelfSignedTLSSecret(a))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisServerTLSSecretName, Namespace: a.Namespace}, secret))
+}
+
+func Test_ReconcileArgoCD_ReconcileRedisSelfSignedTLSSecret_UserProvided(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Redis.AutoTLS = "self-signed"
+	})
+	secret := argoutil.NewSecretWithName(a, common.ArgoCDRedisServerTLSSecretName)
+	secret.Type = corev1.SecretTypeTLS
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       []byte("foo"),
+		corev1.TLSPrivateKeyKey: []byte("bar"),
+	}
+
+	resObjs := []client.Object{a, secret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileClusterCASecret(a))
+	assert.NoError(t, r.reconcileRedisSelfSignedTLSSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisServerTLSSecretName, Namespace: a.Namespace}, secret))
+	assert.Equal(t, []byte("foo"), secret.Data[corev1.TLSCertKey])
+}
+
 func Test_ReconcileArgoCD_ClusterPermissionsSecret(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
@@ -551,3 +634,140 @@ func Test_ReconcileArgoCD_ClusterPermissionsSecret(t *testing.T) {
 	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: testSecret.Name, Namespace: testSecret.Namespace}, testSecret))
 	assert.Nil(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: testSecret.Name, Namespace: testSecret.Namespace}, testSecret))
 }
+
+func TestReconcileArgoCD_reconcileRepositorySecrets(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repositories = []argoproj.RepositorySpec{
+			{
+				URL:       "https://github.com/argoproj/private-repo",
+				Project:   "default",
+				SecretRef: &corev1.LocalObjectReference{Name: "private-repo-creds"},
+			},
+			{
+				URL:  "https://charts.example.com",
+				Type: "helm",
+				Name: "example",
+			},
+		}
+	})
+	creds := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "private-repo-creds", Namespace: a.Namespace},
+		Data: map[string][]byte{
+			"username": []byte("admin"),
+			"password": []byte("secret"),
+		},
+	}
+
+	resObjs := []client.Object{a, creds}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepositorySecrets(a))
+
+	privateRepo := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: getRepositorySecretName("https://github.com/argoproj/private-repo", a), Namespace: a.Namespace}, privateRepo))
+	assert.Equal(t, common.ArgoCDSecretTypeRepository, privateRepo.Labels[common.ArgoCDSecretTypeLabel])
+	assert.Equal(t, map[string][]byte{
+		"url":      []byte("https://github.com/argoproj/private-repo"),
+		"project":  []byte("default"),
+		"username": []byte("admin"),
+		"password": []byte("secret"),
+	}, privateRepo.Data)
+
+	helmRepo := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: getRepositorySecretName("https://charts.example.com", a), Namespace: a.Namespace}, helmRepo))
+	assert.Equal(t, map[string][]byte{
+		"url":  []byte("https://charts.example.com"),
+		"type": []byte("helm"),
+		"name": []byte("example"),
+	}, helmRepo.Data)
+
+	// Changes to the repository are synced to the existing Secret
+	a.Spec.Repositories[0].Project = ""
+	assert.NoError(t, r.reconcileRepositorySecrets(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: privateRepo.Name, Namespace: a.Namespace}, privateRepo))
+	_, ok := privateRepo.Data["project"]
+	assert.False(t, ok)
+
+	// Removing a repository removes its Secret
+	a.Spec.Repositories = a.Spec.Repositories[:1]
+	assert.NoError(t, r.reconcileRepositorySecrets(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: helmRepo.Name, Namespace: a.Namespace}, helmRepo)
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: privateRepo.Name, Namespace: a.Namespace}, privateRepo))
+}
+
+func TestReconcileArgoCD_reconcileRepositorySecrets_userManaged(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repositories = []argoproj.RepositorySpec{
+			{URL: "https://github.com/argoproj/private-repo"},
+		}
+	})
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getRepositorySecretName("https://github.com/argoproj/private-repo", a),
+			Namespace: a.Namespace,
+		},
+		Data: map[string][]byte{
+			"url":      []byte("https://github.com/argoproj/private-repo"),
+			"password": []byte("secret"),
+		},
+	}
+
+	resObjs := []client.Object{a, userSecret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	// A Secret of the same name not managed by the operator is left untouched
+	assert.NoError(t, r.reconcileRepositorySecrets(a))
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: userSecret.Name, Namespace: a.Namespace}, secret))
+	assert.Equal(t, userSecret.Data, secret.Data)
+	assert.Empty(t, secret.Labels)
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "RepositorySecretConflict")
+}
+
+func TestReconcileArgoCD_reconcileGrafanaDatasourceSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Grafana.External = &argoproj.ArgoCDGrafanaExternalSpec{URL: "https://grafana.example.com"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// The Prometheus of the instance is registered as a datasource for the Grafana sidecar
+	assert.NoError(t, r.reconcileGrafanaDatasourceSecret(a))
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Name: "argocd-grafana-datasource", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	assert.Equal(t, "1", secret.Labels[common.GrafanaDatasourceLabel])
+	assert.Contains(t, string(secret.Data["argocd-argocd-argocd.yaml"]), "url: http://prometheus-operated.argocd.svc:9090")
+
+	a.Spec.Grafana.External.PrometheusURL = "https://thanos-querier.openshift-monitoring.svc:9091"
+	assert.NoError(t, r.reconcileGrafanaDatasourceSecret(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	assert.Contains(t, string(secret.Data["argocd-argocd-argocd.yaml"]), "url: https://thanos-querier.openshift-monitoring.svc:9091")
+
+	// The datasource is removed once the external Grafana is no longer set
+	a.Spec.Grafana.External = nil
+	assert.NoError(t, r.reconcileGrafanaDatasourceSecret(a))
+	assert.True(t, apierrors.IsNotFound(r.Client.Get(context.TODO(), key, secret)))
+}
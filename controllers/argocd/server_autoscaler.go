@@ -0,0 +1,285 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// serverAutoscaleState is the state of the operator autoscaler of an Argo CD Server.
+type serverAutoscaleState struct {
+	evaluatedAt time.Time
+	scaledAt    time.Time
+}
+
+// serverAutoscaleCache holds the state of the operator autoscaler of the Argo CD Server of each ArgoCD, so that the
+// metrics are evaluated once per interval rather than on each reconciliation.
+var serverAutoscaleCache = struct {
+	sync.Mutex
+	states map[types.NamespacedName]serverAutoscaleState
+}{states: map[types.NamespacedName]serverAutoscaleState{}}
+
+// queryPrometheus will return the value of the given instant query from the Prometheus API at the given URL, zero
+// when the query returns no sample. It is a variable so that it can be replaced in tests.
+var queryPrometheus = func(prometheusURL string, query string) (float64, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(strings.TrimSuffix(prometheusURL, "/") + "/api/v1/query?query=" + url.QueryEscape(query))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	result := struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			Result []struct {
+				Value []interface{} `json:"value"`
+			} `json:"result"`
+		} `json:"data"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("unexpected answer with status %d from %s: %w", resp.StatusCode, prometheusURL, err)
+	}
+	if result.Status != "success" {
+		return 0, fmt.Errorf("query %q failed: %s", query, result.Error)
+	}
+	if len(result.Data.Result) == 0 || len(result.Data.Result[0].Value) != 2 {
+		return 0, nil
+	}
+	sample, ok := result.Data.Result[0].Value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected sample %v for query %q", result.Data.Result[0].Value[1], query)
+	}
+	value, err := strconv.ParseFloat(sample, 64)
+	if err != nil || math.IsNaN(value) {
+		return 0, err
+	}
+	return value, nil
+}
+
+// isServerOperatorAutoscaleEnabled returns true if the Argo CD Server is autoscaled by the operator.
+func isServerOperatorAutoscaleEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Server.Autoscale.Enabled && cr.Spec.Server.Autoscale.Mode == argoproj.ArgoCDServerAutoscaleModeOperator
+}
+
+// getServerOperatorAutoscaleSpec will return the options of the operator autoscaler of the given ArgoCD, empty if none
+// are set.
+func getServerOperatorAutoscaleSpec(cr *argoproj.ArgoCD) argoproj.ArgoCDServerOperatorAutoscaleSpec {
+	if cr.Spec.Server.Autoscale.Operator == nil {
+		return argoproj.ArgoCDServerOperatorAutoscaleSpec{}
+	}
+	return *cr.Spec.Server.Autoscale.Operator
+}
+
+// getServerAutoscalePrometheusURL will return the URL of the Prometheus API the metrics of the Argo CD Server are read
+// from, the Prometheus managed for the instance when none is set. An empty URL is returned when there is no such
+// Prometheus.
+func getServerAutoscalePrometheusURL(cr *argoproj.ArgoCD) string {
+	if spec := getServerOperatorAutoscaleSpec(cr); spec.PrometheusURL != "" {
+		return spec.PrometheusURL
+	}
+	if isPrometheusManaged(cr) {
+		return fmt.Sprintf("http://prometheus-operated.%s.svc.cluster.local:9090", cr.Namespace)
+	}
+	return ""
+}
+
+// getServerAutoscaleInterval will return the interval between two evaluations of the operator autoscaler.
+func getServerAutoscaleInterval(cr *argoproj.ArgoCD) time.Duration {
+	if spec := getServerOperatorAutoscaleSpec(cr); spec.Interval != nil && spec.Interval.Duration > 0 {
+		return spec.Interval.Duration
+	}
+	return common.ArgoCDDefaultServerAutoscaleInterval
+}
+
+// getServerAutoscaleScaleDownDelay will return the time the operator autoscaler waits after a scaling before removing
+// a replica.
+func getServerAutoscaleScaleDownDelay(cr *argoproj.ArgoCD) time.Duration {
+	if spec := getServerOperatorAutoscaleSpec(cr); spec.ScaleDownDelay != nil {
+		return spec.ScaleDownDelay.Duration
+	}
+	return common.ArgoCDDefaultServerAutoscaleScaleDownDelay
+}
+
+// getServerAutoscaleDesiredReplicas will return the number of replicas of the Argo CD Server serving the given rate of
+// requests per second at the target rate per replica, with one more replica than the current ones when the given
+// latency exceeds the target latency. The result is bounded by the minimum and maximum replicas.
+func getServerAutoscaleDesiredReplicas(cr *argoproj.ArgoCD, current int32, rate float64, latency time.Duration) int32 {
+	spec := getServerOperatorAutoscaleSpec(cr)
+
+	target := common.ArgoCDDefaultServerAutoscaleTargetRequestsPerReplica
+	if spec.TargetRequestsPerReplica != nil && *spec.TargetRequestsPerReplica > 0 {
+		target = *spec.TargetRequestsPerReplica
+	}
+	desired := int32(math.Ceil(rate / float64(target)))
+	if spec.TargetLatency != nil && latency > spec.TargetLatency.Duration && desired <= current {
+		desired = current + 1
+	}
+
+	lower, upper := minReplicas, maxReplicas
+	if spec.MinReplicas != nil {
+		lower = *spec.MinReplicas
+	}
+	if spec.MaxReplicas != nil {
+		upper = *spec.MaxReplicas
+	}
+	if desired > upper {
+		desired = upper
+	}
+	if desired < lower {
+		desired = lower
+	}
+	return desired
+}
+
+// getServerAutoscaleDelay will return the time left until the next evaluation of the operator autoscaler of the given
+// ArgoCD, zero if the Argo CD Server is not autoscaled by the operator.
+func getServerAutoscaleDelay(cr *argoproj.ArgoCD) time.Duration {
+	if !isServerOperatorAutoscaleEnabled(cr) {
+		return 0
+	}
+
+	serverAutoscaleCache.Lock()
+	defer serverAutoscaleCache.Unlock()
+
+	state := serverAutoscaleCache.states[types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}]
+	if delay := time.Until(state.evaluatedAt.Add(getServerAutoscaleInterval(cr))); delay > 0 {
+		return delay
+	}
+	return time.Second
+}
+
+// getServerMetrics will return the rate of gRPC requests per second served by the Argo CD Server of the given ArgoCD,
+// and the 95th percentile of their latency when a target latency is set.
+func getServerMetrics(cr *argoproj.ArgoCD, prometheusURL string) (float64, time.Duration, error) {
+	selector := fmt.Sprintf(`namespace=%q,service=%q`, cr.Namespace, nameWithSuffix("server-metrics", cr))
+
+	rate, err := queryPrometheus(prometheusURL, fmt.Sprintf("sum(rate(grpc_server_handled_total{%s}[2m]))", selector))
+	if err != nil {
+		return 0, 0, err
+	}
+	if getServerOperatorAutoscaleSpec(cr).TargetLatency == nil {
+		return rate, 0, nil
+	}
+	latency, err := queryPrometheus(prometheusURL, fmt.Sprintf(
+		"histogram_quantile(0.95, sum(rate(grpc_server_handling_seconds_bucket{%s}[2m])) by (le))", selector))
+	if err != nil {
+		return 0, 0, err
+	}
+	return rate, time.Duration(latency * float64(time.Second)), nil
+}
+
+// reconcileServerOperatorAutoscaler will scale the Argo CD Server Deployment of the given ArgoCD from the metrics of
+// the server in Prometheus, when it is autoscaled by the operator. Replicas are added as soon as needed, and removed
+// one at a time once the scale down delay has elapsed since the last scaling.
+func (r *ReconcileArgoCD) reconcileServerOperatorAutoscaler(cr *argoproj.ArgoCD) error {
+	key := types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}
+	if !isServerOperatorAutoscaleEnabled(cr) || !isServerEnabled(cr) {
+		if !r.isDryRun() {
+			serverAutoscaleCache.Lock()
+			delete(serverAutoscaleCache.states, key)
+			serverAutoscaleCache.Unlock()
+		}
+		return nil
+	}
+
+	serverAutoscaleCache.Lock()
+	state := serverAutoscaleCache.states[key]
+	serverAutoscaleCache.Unlock()
+	if time.Since(state.evaluatedAt) < getServerAutoscaleInterval(cr) {
+		return nil // Evaluated recently, move along...
+	}
+
+	deploy := newDeploymentWithSuffix("server", "server", cr)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
+		return nil // Scaled once the Deployment is created
+	}
+
+	state.evaluatedAt = time.Now()
+	defer func() {
+		if r.isDryRun() {
+			return // The state of the autoscaler is only kept for the changes applied
+		}
+		serverAutoscaleCache.Lock()
+		serverAutoscaleCache.states[key] = state
+		serverAutoscaleCache.Unlock()
+	}()
+
+	prometheusURL := getServerAutoscalePrometheusURL(cr)
+	if prometheusURL == "" {
+		instanceLog(cr).Error(errors.New("no Prometheus to read the metrics from"), fmt.Sprintf(
+			"unable to autoscale the Argo CD Server of ArgoCD %s/%s, set a Prometheus URL or enable the Prometheus of the instance",
+			cr.Namespace, cr.Name))
+		return nil
+	}
+	rate, latency, err := getServerMetrics(cr, prometheusURL)
+	if err != nil {
+		// Keep the current replicas until the metrics are available again
+		instanceLog(cr).Error(err, fmt.Sprintf("unable to read the metrics of the Argo CD Server of ArgoCD %s/%s", cr.Namespace, cr.Name))
+		return nil
+	}
+
+	current := int32(1)
+	if deploy.Spec.Replicas != nil {
+		current = *deploy.Spec.Replicas
+	}
+	desired := getServerAutoscaleDesiredReplicas(cr, current, rate, latency)
+	if desired < current {
+		if time.Since(state.scaledAt) < getServerAutoscaleScaleDownDelay(cr) {
+			return nil
+		}
+		desired = current - 1
+	}
+	if desired > current && isResourceBudgetScaleUpBlocked(cr) {
+		instanceLog(cr).Info(fmt.Sprintf("not scaling up the Argo CD Server of ArgoCD %s/%s, the instance exceeds its resource budget", cr.Namespace, cr.Name))
+		return nil
+	}
+	if desired == current {
+		return nil
+	}
+
+	message := fmt.Sprintf("Scaled the Argo CD Server from %d to %d replicas, serving %.1f requests per second", current, desired, rate)
+	if latency > 0 {
+		message += fmt.Sprintf(" with a 95th percentile latency of %s", latency.Round(time.Millisecond))
+	}
+	instanceLog(cr).Info(fmt.Sprintf("%s for ArgoCD %s/%s", message, cr.Namespace, cr.Name))
+
+	deploy.Spec.Replicas = &desired
+	if err := r.Client.Update(context.TODO(), deploy); err != nil {
+		return err
+	}
+	state.scaledAt = time.Now()
+
+	r.recordEvent(cr, corev1.EventTypeNormal, "ServerRescaled", message)
+	return nil
+}
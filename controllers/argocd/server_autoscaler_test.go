@@ -0,0 +1,142 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+// stubQueryPrometheus replaces the Prometheus queries for the duration of the test and resets the autoscaler state.
+func stubQueryPrometheus(t *testing.T, rate *float64, latency *float64) {
+	orig := queryPrometheus
+	queryPrometheus = func(prometheusURL string, query string) (float64, error) {
+		if strings.HasPrefix(query, "histogram_quantile") {
+			return *latency, nil
+		}
+		return *rate, nil
+	}
+	resetCache := func() {
+		serverAutoscaleCache.states = map[types.NamespacedName]serverAutoscaleState{}
+	}
+	resetCache()
+	t.Cleanup(func() {
+		queryPrometheus = orig
+		resetCache()
+	})
+}
+
+func makeTestOperatorAutoscaleArgoCD(opts ...argoCDOpt) *argoproj.ArgoCD {
+	return makeTestArgoCD(append([]argoCDOpt{func(a *argoproj.ArgoCD) {
+		maxReplicas := int32(5)
+		a.Spec.Server.Autoscale.Enabled = true
+		a.Spec.Server.Autoscale.Mode = argoproj.ArgoCDServerAutoscaleModeOperator
+		a.Spec.Server.Autoscale.Operator = &argoproj.ArgoCDServerOperatorAutoscaleSpec{
+			PrometheusURL: "http://prometheus.example.com:9090",
+			MaxReplicas:   &maxReplicas,
+			Interval:      &metav1.Duration{Duration: time.Nanosecond},
+		}
+	}}, opts...)...)
+}
+
+func TestGetServerAutoscaleDesiredReplicas(t *testing.T) {
+	a := makeTestOperatorAutoscaleArgoCD()
+
+	assert.Equal(t, int32(1), getServerAutoscaleDesiredReplicas(a, 2, 0, 0))
+	assert.Equal(t, int32(3), getServerAutoscaleDesiredReplicas(a, 1, 120, 0))
+	assert.Equal(t, int32(5), getServerAutoscaleDesiredReplicas(a, 1, 1000, 0))
+
+	// A replica is added while the latency is over the target
+	a.Spec.Server.Autoscale.Operator.TargetLatency = &metav1.Duration{Duration: 500 * time.Millisecond}
+	assert.Equal(t, int32(3), getServerAutoscaleDesiredReplicas(a, 2, 10, time.Second))
+	assert.Equal(t, int32(1), getServerAutoscaleDesiredReplicas(a, 2, 10, 100*time.Millisecond))
+
+	minReplicas, target := int32(2), int32(10)
+	a.Spec.Server.Autoscale.Operator.MinReplicas = &minReplicas
+	a.Spec.Server.Autoscale.Operator.TargetRequestsPerReplica = &target
+	assert.Equal(t, int32(2), getServerAutoscaleDesiredReplicas(a, 1, 5, 0))
+	assert.Equal(t, int32(4), getServerAutoscaleDesiredReplicas(a, 1, 35, 0))
+}
+
+func TestGetServerAutoscalePrometheusURL(t *testing.T) {
+	a := makeTestArgoCD()
+	assert.Empty(t, getServerAutoscalePrometheusURL(a))
+
+	a.Spec.Prometheus.Enabled = true
+	assert.Equal(t, "http://prometheus-operated.argocd.svc.cluster.local:9090", getServerAutoscalePrometheusURL(a))
+
+	a.Spec.Server.Autoscale.Operator = &argoproj.ArgoCDServerOperatorAutoscaleSpec{PrometheusURL: "https://thanos.example.com"}
+	assert.Equal(t, "https://thanos.example.com", getServerAutoscalePrometheusURL(a))
+}
+
+func TestReconcileArgoCD_reconcileServerOperatorAutoscaler(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	rate, latency := 180.0, 0.0
+	stubQueryPrometheus(t, &rate, &latency)
+
+	a := makeTestOperatorAutoscaleArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.reconcileAutoscalers(a))
+
+	// The operator replaces the HorizontalPodAutoscaler
+	hpa := newHorizontalPodAutoscalerWithSuffix("server", a)
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), types.NamespacedName{Name: hpa.Name, Namespace: a.Namespace}, hpa)))
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: "argocd-server", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, int32(4), *deployment.Spec.Replicas)
+
+	// Replicas are kept for the scale down delay, and the deployment reconciliation leaves them alone
+	rate = 0
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+	assert.NoError(t, r.reconcileServerOperatorAutoscaler(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, int32(4), *deployment.Spec.Replicas)
+
+	// Then removed one at a time
+	a.Spec.Server.Autoscale.Operator.ScaleDownDelay = &metav1.Duration{}
+	assert.NoError(t, r.reconcileServerOperatorAutoscaler(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, deployment))
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+	assert.True(t, getServerAutoscaleDelay(a) > 0)
+
+	a.Spec.Server.Autoscale.Mode = argoproj.ArgoCDServerAutoscaleModeHPA
+	assert.NoError(t, r.reconcileAutoscalers(a))
+	assert.Equal(t, time.Duration(0), getServerAutoscaleDelay(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: hpa.Name, Namespace: a.Namespace}, hpa))
+}
@@ -17,9 +17,13 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	amerr "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -37,15 +41,169 @@ func getArgoServerServiceType(cr *argoproj.ArgoCD) corev1.ServiceType {
 	return corev1.ServiceTypeClusterIP
 }
 
+// applyServerServiceOptions will set the Service options of the Argo CD Server from the given ArgoCD on the
+// given Service, and return true if the Service has been changed. User provided annotations and labels are added
+// to the ones set by the operator, and the ones set from the ArgoCD on the last update but removed from it since are
+// removed, or reset to the value set by the operator.
+func applyServerServiceOptions(svc *corev1.Service, cr *argoproj.ArgoCD) bool {
+	opts := cr.Spec.Server.Service
+	desired := newServiceWithSuffix("server", "server", cr)
+	changed := false
+
+	previousAnnotations := getAnnotatedKeys(svc, common.ArgoCDServerServiceAnnotationsAnnotation)
+	previousLabels := getAnnotatedKeys(svc, common.ArgoCDServerServiceLabelsAnnotation)
+	if applyServerServiceKeys(&svc.Annotations, opts.Annotations, desired.Annotations, previousAnnotations) {
+		changed = true
+	}
+	if applyServerServiceKeys(&svc.Labels, opts.Labels, desired.Labels, previousLabels) {
+		changed = true
+	}
+	if setServerServiceKeysAnnotation(svc, common.ArgoCDServerServiceAnnotationsAnnotation, opts.Annotations) {
+		changed = true
+	}
+	if setServerServiceKeysAnnotation(svc, common.ArgoCDServerServiceLabelsAnnotation, opts.Labels) {
+		changed = true
+	}
+
+	if !reflect.DeepEqual(svc.Spec.LoadBalancerSourceRanges, opts.LoadBalancerSourceRanges) {
+		svc.Spec.LoadBalancerSourceRanges = opts.LoadBalancerSourceRanges
+		changed = true
+	}
+
+	// The external traffic policy is only valid for Services exposed outside of the cluster
+	externalType := svc.Spec.Type == corev1.ServiceTypeNodePort || svc.Spec.Type == corev1.ServiceTypeLoadBalancer
+	if opts.ExternalTrafficPolicy != "" && externalType && svc.Spec.ExternalTrafficPolicy != opts.ExternalTrafficPolicy {
+		svc.Spec.ExternalTrafficPolicy = opts.ExternalTrafficPolicy
+		changed = true
+	}
+
+	if applyIPFamilyOptions(svc, getServerServiceIPFamilyPolicy(cr), getServerServiceIPFamilies(cr)) {
+		changed = true
+	}
+
+	return changed
+}
+
+// applyServerServiceKeys will set the given user provided values on the given annotations or labels of the Argo CD
+// Server Service, and remove the given keys set on the last update that are no longer provided, or reset them to the
+// given value set by the operator. It returns true if the annotations or labels have been changed.
+func applyServerServiceKeys(current *map[string]string, values map[string]string, defaults map[string]string, previous map[string]bool) bool {
+	if *current == nil {
+		*current = map[string]string{}
+	}
+
+	changed := false
+	for key := range previous {
+		if _, ok := values[key]; ok {
+			continue
+		}
+		if value, ok := defaults[key]; ok {
+			if (*current)[key] != value {
+				(*current)[key] = value
+				changed = true
+			}
+		} else if _, ok := (*current)[key]; ok {
+			delete(*current, key)
+			changed = true
+		}
+	}
+	for key, value := range values {
+		if (*current)[key] != value {
+			(*current)[key] = value
+			changed = true
+		}
+	}
+	return changed
+}
+
+// setServerServiceKeysAnnotation will record the sorted, comma separated keys of the given user provided values in
+// the given annotation of the Argo CD Server Service, and return true if the annotation has been changed.
+func setServerServiceKeysAnnotation(svc *corev1.Service, annotation string, values map[string]string) bool {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	value := strings.Join(keys, ",")
+	if svc.Annotations[annotation] == value {
+		return false
+	}
+	if value == "" {
+		if _, ok := svc.Annotations[annotation]; !ok {
+			return false
+		}
+		delete(svc.Annotations, annotation)
+		return true
+	}
+	if svc.Annotations == nil {
+		svc.Annotations = map[string]string{}
+	}
+	svc.Annotations[annotation] = value
+	return true
+}
+
+// getServerServiceIPFamilyPolicy will return the IP family policy of the Argo CD Server Service.
+func getServerServiceIPFamilyPolicy(cr *argoproj.ArgoCD) *corev1.IPFamilyPolicy {
+	if cr.Spec.Server.Service.IPFamilyPolicy != nil {
+		return cr.Spec.Server.Service.IPFamilyPolicy
+	}
+	return cr.Spec.IPFamilyPolicy
+}
+
+// getServerServiceIPFamilies will return the IP families of the Argo CD Server Service.
+func getServerServiceIPFamilies(cr *argoproj.ArgoCD) []corev1.IPFamily {
+	if len(cr.Spec.Server.Service.IPFamilies) > 0 {
+		return cr.Spec.Server.Service.IPFamilies
+	}
+	return cr.Spec.IPFamilies
+}
+
+// applyIPFamilyOptions will set the given IP family policy and IP families on the given Service, and return true if
+// the Service has been changed. Unset options are left to the cluster defaults, and a change of the primary IP family
+// of an existing Service is ignored as it cannot be changed.
+func applyIPFamilyOptions(svc *corev1.Service, policy *corev1.IPFamilyPolicy, families []corev1.IPFamily) bool {
+	changed := false
+
+	if policy != nil && (svc.Spec.IPFamilyPolicy == nil || *svc.Spec.IPFamilyPolicy != *policy) {
+		svc.Spec.IPFamilyPolicy = policy
+		changed = true
+	}
+
+	if len(families) > 0 && !reflect.DeepEqual(svc.Spec.IPFamilies, families) {
+		if len(svc.Spec.IPFamilies) > 0 && svc.Spec.IPFamilies[0] != families[0] {
+			log.Info(fmt.Sprintf("cannot change the primary IP family of Service %s from %s to %s, ignoring",
+				svc.Name, svc.Spec.IPFamilies[0], families[0]))
+			return changed
+		}
+		svc.Spec.IPFamilies = families
+		changed = true
+	}
+
+	return changed
+}
+
+// wantsIPv6 returns true if the Services of the given ArgoCD are requested to use IPv6.
+func wantsIPv6(cr *argoproj.ArgoCD) bool {
+	for _, family := range cr.Spec.IPFamilies {
+		if family == corev1.IPv6Protocol {
+			return true
+		}
+	}
+	return false
+}
+
 // newService returns a new Service for the given ArgoCD instance.
 func newService(cr *argoproj.ArgoCD) *corev1.Service {
-	return &corev1.Service{
+	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cr.Name,
 			Namespace: cr.Namespace,
 			Labels:    argoutil.LabelsForCluster(cr),
 		},
 	}
+	applyIPFamilyOptions(svc, cr.Spec.IPFamilyPolicy, cr.Spec.IPFamilies)
+	return svc
 }
 
 // newServiceWithName returns a new Service instance for the given ArgoCD using the given name.
@@ -74,7 +232,7 @@ func (r *ReconcileArgoCD) reconcileGrafanaService(cr *argoproj.ArgoCD) error {
 			// Service exists but enabled flag has been set to false, delete the Service
 			return r.Client.Delete(context.TODO(), svc)
 		}
-		log.Info(grafanaDeprecatedWarning)
+		instanceLog(cr).Info(grafanaDeprecatedWarning)
 		return nil // Service found, do nothing
 	}
 
@@ -82,7 +240,7 @@ func (r *ReconcileArgoCD) reconcileGrafanaService(cr *argoproj.ArgoCD) error {
 		return nil // Grafana not enabled, do nothing.
 	}
 
-	log.Info(grafanaDeprecatedWarning)
+	instanceLog(cr).Info(grafanaDeprecatedWarning)
 	return nil
 }
 
@@ -113,68 +271,167 @@ func (r *ReconcileArgoCD) reconcileMetricsService(cr *argoproj.ArgoCD) error {
 	return r.Client.Create(context.TODO(), svc)
 }
 
-// reconcileRedisHAAnnounceServices will ensure that the announce Services are present for Redis when running in HA mode.
-func (r *ReconcileArgoCD) reconcileRedisHAAnnounceServices(cr *argoproj.ArgoCD) error {
-	for i := int32(0); i < common.ArgoCDDefaultRedisHAReplicas; i++ {
-		svc := newServiceWithSuffix(fmt.Sprintf("redis-ha-announce-%d", i), "redis", cr)
-		if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-			if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
-				return r.Client.Delete(context.TODO(), svc)
-			}
-			return nil // Service found, do nothing
+// reconcileApplicationControllerService will ensure that the headless Service governing the application
+// controller StatefulSet is present when sharding is enabled.
+func (r *ReconcileArgoCD) reconcileApplicationControllerService(cr *argoproj.ArgoCD) error {
+	svc := newServiceWithSuffix("application-controller", "application-controller", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
+		if !cr.Spec.Controller.IsEnabled() || !isControllerShardingEnabled(cr) {
+			return r.Client.Delete(context.TODO(), svc)
 		}
+		return nil // Service found, do nothing
+	}
 
-		if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
-			return nil //return as Ha is not enabled do nothing
-		}
+	if !cr.Spec.Controller.IsEnabled() || !isControllerShardingEnabled(cr) {
+		return nil // Sharding not enabled, do nothing.
+	}
 
-		svc.ObjectMeta.Annotations = map[string]string{
-			common.ArgoCDKeyTolerateUnreadyEndpounts: "true",
-		}
+	svc.Spec.ClusterIP = corev1.ClusterIPNone
 
-		svc.Spec.PublishNotReadyAddresses = true
+	svc.Spec.Selector = map[string]string{
+		common.ArgoCDKeyName: nameWithSuffix("application-controller", cr),
+	}
 
-		svc.Spec.Selector = map[string]string{
-			common.ArgoCDKeyName:               nameWithSuffix("redis-ha", cr),
-			common.ArgoCDKeyStatefulSetPodName: nameWithSuffix(fmt.Sprintf("redis-ha-server-%d", i), cr),
-		}
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "metrics",
+			Port:       8082,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(8082),
+		},
+	}
 
-		svc.Spec.Ports = []corev1.ServicePort{
-			{
-				Name:       "server",
-				Port:       common.ArgoCDDefaultRedisPort,
-				Protocol:   corev1.ProtocolTCP,
-				TargetPort: intstr.FromString("redis"),
-			}, {
-				Name:       "sentinel",
-				Port:       common.ArgoCDDefaultRedisSentinelPort,
-				Protocol:   corev1.ProtocolTCP,
-				TargetPort: intstr.FromString("sentinel"),
-			},
-		}
+	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), svc)
+}
 
-		if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
+// newRedisHAAnnounceService returns the announce Service of the Redis HA server with the given index, for the given
+// ArgoCD.
+func newRedisHAAnnounceService(cr *argoproj.ArgoCD, index int32) *corev1.Service {
+	svc := newServiceWithSuffix(fmt.Sprintf("redis-ha-announce-%d", index), "redis", cr)
+	svc.ObjectMeta.Annotations = map[string]string{
+		common.ArgoCDKeyTolerateUnreadyEndpounts: "true",
+	}
+	svc.Spec.PublishNotReadyAddresses = true
+	svc.Spec.Selector = map[string]string{
+		common.ArgoCDKeyName:               nameWithSuffix("redis-ha", cr),
+		common.ArgoCDKeyStatefulSetPodName: nameWithSuffix(fmt.Sprintf("redis-ha-server-%d", index), cr),
+	}
+	svc.Spec.Ports = getRedisHAServicePorts(cr)
+	return svc
+}
+
+// reconcileRedisHAAnnounceService will ensure that the given announce Service is present and up to date when Redis
+// runs in HA mode, and removed otherwise.
+func (r *ReconcileArgoCD) reconcileRedisHAAnnounceService(cr *argoproj.ArgoCD, desired *corev1.Service) error {
+	enabled := wantsRedisHA(cr) && cr.Spec.Redis.IsEnabled()
+	existing := &corev1.Service{}
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, desired.Name, existing) {
+		if !enabled {
+			return nil // Redis HA not enabled, do nothing.
+		}
+		if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
 			return err
 		}
+		componentLog(cr, logComponentRedis).Info(fmt.Sprintf("Creating service %s", desired.Name))
+		return r.Client.Create(context.TODO(), desired)
+	}
 
-		if err := r.Client.Create(context.TODO(), svc); err != nil {
-			return err
+	if !enabled {
+		componentLog(cr, logComponentRedis).Info(fmt.Sprintf("Deleting service %s as Redis HA is disabled", existing.Name))
+		return r.Client.Delete(context.TODO(), existing)
+	}
+
+	changed := false
+	if existing.Annotations[common.ArgoCDKeyTolerateUnreadyEndpounts] != "true" {
+		if existing.Annotations == nil {
+			existing.Annotations = map[string]string{}
 		}
+		existing.Annotations[common.ArgoCDKeyTolerateUnreadyEndpounts] = "true"
+		changed = true
 	}
-	return nil
+	if !existing.Spec.PublishNotReadyAddresses {
+		existing.Spec.PublishNotReadyAddresses = true
+		changed = true
+	}
+	if !reflect.DeepEqual(existing.Spec.Selector, desired.Spec.Selector) {
+		existing.Spec.Selector = desired.Spec.Selector
+		changed = true
+	}
+	if !reflect.DeepEqual(existing.Spec.Ports, desired.Spec.Ports) {
+		existing.Spec.Ports = desired.Spec.Ports
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	componentLog(cr, logComponentRedis).Info(fmt.Sprintf("Updating service %s", existing.Name))
+	return r.Client.Update(context.TODO(), existing)
+}
+
+// reconcileRedisHAAnnounceServices will ensure that the announce Services are present for Redis when running in HA mode.
+// Each Service is reconciled independently, so that a partially created setup is completed even when one of the
+// Services fails to reconcile.
+func (r *ReconcileArgoCD) reconcileRedisHAAnnounceServices(cr *argoproj.ArgoCD) error {
+	var reconciliationErrors []error
+	for i := int32(0); i < common.ArgoCDDefaultRedisHAReplicas; i++ {
+		svc := newRedisHAAnnounceService(cr, i)
+		if err := r.reconcileRedisHAAnnounceService(cr, svc); err != nil {
+			reconciliationErrors = append(reconciliationErrors, fmt.Errorf("failed to reconcile service %s: %w", svc.Name, err))
+		}
+	}
+	return amerr.NewAggregate(reconciliationErrors)
+}
+
+// getRedisHAServicePorts will return the ports of the Redis HA master and announce Services for the given ArgoCD.
+func getRedisHAServicePorts(cr *argoproj.ArgoCD) []corev1.ServicePort {
+	return []corev1.ServicePort{
+		{
+			Name:       "server",
+			Port:       getRedisPort(cr),
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromString("redis"),
+		}, {
+			Name:       "sentinel",
+			Port:       getRedisSentinelPort(cr),
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromString("sentinel"),
+		},
+	}
+}
+
+// getRedisHAMasterServicePorts will return the ports of the Redis HA master Service for the given ArgoCD, which also
+// exposes the metrics of the Redis exporter when it is enabled.
+func getRedisHAMasterServicePorts(cr *argoproj.ArgoCD) []corev1.ServicePort {
+	ports := getRedisHAServicePorts(cr)
+	if isRedisExporterEnabled(cr) {
+		ports = append(ports, corev1.ServicePort{
+			Name:       common.ArgoCDKeyMetrics,
+			Port:       common.ArgoCDDefaultRedisExporterPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromString(common.ArgoCDKeyMetrics),
+		})
+	}
+	return ports
 }
 
 // reconcileRedisHAMasterService will ensure that the "master" Service is present for Redis when running in HA mode.
 func (r *ReconcileArgoCD) reconcileRedisHAMasterService(cr *argoproj.ArgoCD) error {
 	svc := newServiceWithSuffix("redis-ha", "redis", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-		if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
+		if !wantsRedisHA(cr) || !cr.Spec.Redis.IsEnabled() {
 			return r.Client.Delete(context.TODO(), svc)
 		}
+		if ports := getRedisHAMasterServicePorts(cr); !reflect.DeepEqual(svc.Spec.Ports, ports) {
+			svc.Spec.Ports = ports
+			return r.Client.Update(context.TODO(), svc)
+		}
 		return nil // Service found, do nothing
 	}
 
-	if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
+	if !wantsRedisHA(cr) || !cr.Spec.Redis.IsEnabled() {
 		return nil //return as Ha is not enabled do nothing
 	}
 
@@ -182,24 +439,33 @@ func (r *ReconcileArgoCD) reconcileRedisHAMasterService(cr *argoproj.ArgoCD) err
 		common.ArgoCDKeyName: nameWithSuffix("redis-ha", cr),
 	}
 
-	svc.Spec.Ports = []corev1.ServicePort{
+	svc.Spec.Ports = getRedisHAMasterServicePorts(cr)
+
+	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), svc)
+}
+
+// getRedisHAProxyServicePorts will return the ports of the Redis HA Proxy Service for the given ArgoCD.
+func getRedisHAProxyServicePorts(cr *argoproj.ArgoCD) []corev1.ServicePort {
+	ports := []corev1.ServicePort{
 		{
-			Name:       "server",
-			Port:       common.ArgoCDDefaultRedisPort,
+			Name:       "haproxy",
+			Port:       getRedisPort(cr),
 			Protocol:   corev1.ProtocolTCP,
 			TargetPort: intstr.FromString("redis"),
-		}, {
-			Name:       "sentinel",
-			Port:       common.ArgoCDDefaultRedisSentinelPort,
-			Protocol:   corev1.ProtocolTCP,
-			TargetPort: intstr.FromString("sentinel"),
 		},
 	}
-
-	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
-		return err
+	if cr.Spec.HA.RedisProxyMetrics {
+		ports = append(ports, corev1.ServicePort{
+			Name:       common.ArgoCDKeyMetrics,
+			Port:       common.ArgoCDDefaultRedisHAProxyMetricsPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromString(common.ArgoCDKeyMetrics),
+		})
 	}
-	return r.Client.Create(context.TODO(), svc)
+	return ports
 }
 
 // reconcileRedisHAProxyService will ensure that the HA Proxy Service is present for Redis when running in HA mode.
@@ -207,34 +473,32 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyService(cr *argoproj.ArgoCD) erro
 	svc := newServiceWithSuffix("redis-ha-haproxy", "redis", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
 
-		if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
+		if !wantsRedisHA(cr) || !cr.Spec.Redis.IsEnabled() {
 			return r.Client.Delete(context.TODO(), svc)
 		}
 
-		if ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS()) {
+		changed := ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, wantsRedisServiceCATLS(cr))
+		if ports := getRedisHAProxyServicePorts(cr); !reflect.DeepEqual(svc.Spec.Ports, ports) {
+			svc.Spec.Ports = ports
+			changed = true
+		}
+		if changed {
 			return r.Client.Update(context.TODO(), svc)
 		}
 		return nil // Service found, do nothing
 	}
 
-	if !cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
+	if !wantsRedisHA(cr) || !cr.Spec.Redis.IsEnabled() {
 		return nil //return as Ha is not enabled do nothing
 	}
 
-	ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS())
+	ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, wantsRedisServiceCATLS(cr))
 
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("redis-ha-haproxy", cr),
 	}
 
-	svc.Spec.Ports = []corev1.ServicePort{
-		{
-			Name:       "haproxy",
-			Port:       common.ArgoCDDefaultRedisPort,
-			Protocol:   corev1.ProtocolTCP,
-			TargetPort: intstr.FromString("redis"),
-		},
-	}
+	svc.Spec.Ports = getRedisHAProxyServicePorts(cr)
 
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
@@ -259,6 +523,18 @@ func (r *ReconcileArgoCD) reconcileRedisHAServices(cr *argoproj.ArgoCD) error {
 	return nil
 }
 
+// getRedisServicePorts will return the ports of the standalone Redis Service for the given ArgoCD.
+func getRedisServicePorts(cr *argoproj.ArgoCD) []corev1.ServicePort {
+	return []corev1.ServicePort{
+		{
+			Name:       "tcp-redis",
+			Port:       getRedisPort(cr),
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(int(getRedisPort(cr))),
+		},
+	}
+}
+
 // reconcileRedisService will ensure that the Service for Redis is present.
 func (r *ReconcileArgoCD) reconcileRedisService(cr *argoproj.ArgoCD) error {
 	svc := newServiceWithSuffix("redis", "redis", cr)
@@ -267,33 +543,34 @@ func (r *ReconcileArgoCD) reconcileRedisService(cr *argoproj.ArgoCD) error {
 		if !cr.Spec.Redis.IsEnabled() {
 			return r.Client.Delete(context.TODO(), svc)
 		}
-		if ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS()) {
-			return r.Client.Update(context.TODO(), svc)
-		}
-		if cr.Spec.HA.Enabled {
+		changed := ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, wantsRedisServiceCATLS(cr))
+		if !wantsRedisStandalone(cr) {
+			if changed {
+				return r.Client.Update(context.TODO(), svc)
+			}
 			return r.Client.Delete(context.TODO(), svc)
 		}
+		if ports := getRedisServicePorts(cr); !reflect.DeepEqual(svc.Spec.Ports, ports) {
+			svc.Spec.Ports = ports
+			changed = true
+		}
+		if changed {
+			return r.Client.Update(context.TODO(), svc)
+		}
 		return nil // Service found, do nothing
 	}
 
-	if cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
+	if !wantsRedisStandalone(cr) || !cr.Spec.Redis.IsEnabled() {
 		return nil //return as Ha is enabled do nothing
 	}
 
-	ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS())
+	ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, wantsRedisServiceCATLS(cr))
 
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("redis", cr),
 	}
 
-	svc.Spec.Ports = []corev1.ServicePort{
-		{
-			Name:       "tcp-redis",
-			Port:       common.ArgoCDDefaultRedisPort,
-			Protocol:   corev1.ProtocolTCP,
-			TargetPort: intstr.FromInt(common.ArgoCDDefaultRedisPort),
-		},
-	}
+	svc.Spec.Ports = getRedisServicePorts(cr)
 
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
@@ -301,6 +578,13 @@ func (r *ReconcileArgoCD) reconcileRedisService(cr *argoproj.ArgoCD) error {
 	return r.Client.Create(context.TODO(), svc)
 }
 
+// wantsRedisServiceCATLS returns true if the redis TLS certificate should be
+// requested from the OpenShift service CA. Self-signed certificates are issued
+// by the operator itself and must not be requested through the annotation.
+func wantsRedisServiceCATLS(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Redis.WantsAutoTLS() && !cr.Spec.Redis.WantsSelfSignedTLS()
+}
+
 // ensureAutoTLSAnnotation ensures that the service svc has the desired state
 // of the auto TLS annotation set, which is either set (when enabled is true)
 // or unset (when enabled is false).
@@ -421,10 +705,15 @@ func (r *ReconcileArgoCD) reconcileServerMetricsService(cr *argoproj.ArgoCD) err
 func (r *ReconcileArgoCD) reconcileServerService(cr *argoproj.ArgoCD) error {
 	svc := newServiceWithSuffix("server", "server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-		if !cr.Spec.Server.IsEnabled() {
+		if !isServerEnabled(cr) {
 			return r.Client.Delete(context.TODO(), svc)
 		}
+		// The auto TLS annotation is ensured last, so that it is kept when pruning the annotations of the ArgoCD
+		changed := applyServerServiceOptions(svc, cr)
 		if ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDServerTLSSecretName, cr.Spec.Server.WantsAutoTLS()) {
+			changed = true
+		}
+		if changed {
 			return r.Client.Update(context.TODO(), svc)
 		}
 		return nil // Service found, do nothing
@@ -455,6 +744,12 @@ func (r *ReconcileArgoCD) reconcileServerService(cr *argoproj.ArgoCD) error {
 	}
 
 	svc.Spec.Type = getArgoServerServiceType(cr)
+	applyServerServiceOptions(svc, cr)
+
+	// The load balancer class is immutable, so it is only set when the Service is created
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		svc.Spec.LoadBalancerClass = cr.Spec.Server.Service.LoadBalancerClass
+	}
 
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
@@ -466,7 +761,7 @@ func (r *ReconcileArgoCD) reconcileServerService(cr *argoproj.ArgoCD) error {
 func (r *ReconcileArgoCD) reconcileServices(cr *argoproj.ArgoCD) error {
 
 	if err := r.reconcileDexService(cr); err != nil {
-		log.Error(err, "error reconciling dex service")
+		instanceLog(cr).Error(err, "error reconciling dex service")
 	}
 
 	err := r.reconcileGrafanaService(cr)
@@ -479,6 +774,11 @@ func (r *ReconcileArgoCD) reconcileServices(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	err = r.reconcileApplicationControllerService(cr)
+	if err != nil {
+		return err
+	}
+
 	err = r.reconcileRedisHAServices(cr)
 	if err != nil {
 		return err
@@ -503,5 +803,33 @@ func (r *ReconcileArgoCD) reconcileServices(cr *argoproj.ArgoCD) error {
 	if err != nil {
 		return err
 	}
+
+	return r.reconcileServiceIPFamilies(cr)
+}
+
+// reconcileServiceIPFamilies will ensure that the IP family options of the given ArgoCD are set on the existing
+// Services owned by it. The Argo CD Server Service is left out, as it is reconciled with the other Server options.
+func (r *ReconcileArgoCD) reconcileServiceIPFamilies(cr *argoproj.ArgoCD) error {
+	if cr.Spec.IPFamilyPolicy == nil && len(cr.Spec.IPFamilies) == 0 {
+		return nil
+	}
+
+	svcs := &corev1.ServiceList{}
+	if err := r.Client.List(context.TODO(), svcs, client.InNamespace(cr.Namespace), client.MatchingLabels{common.ArgoCDKeyManagedBy: argoutil.ManagedByLabelValue(cr)}); err != nil {
+		return fmt.Errorf("failed to list Services: %w", err)
+	}
+
+	for i := range svcs.Items {
+		svc := &svcs.Items[i]
+		if svc.Name == nameWithSuffix("server", cr) || !metav1.IsControlledBy(svc, cr) {
+			continue
+		}
+		if applyIPFamilyOptions(svc, cr.Spec.IPFamilyPolicy, cr.Spec.IPFamilies) {
+			instanceLog(cr).Info(fmt.Sprintf("updating the IP families of Service %s", svc.Name))
+			if err := r.Client.Update(context.TODO(), svc); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
@@ -17,6 +17,8 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/rbac/v1"
@@ -74,6 +76,111 @@ func (r *ReconcileArgoCD) reconcileServiceAccounts(cr *argoproj.ArgoCD) error {
 		}
 	}
 
+	return r.reconcileRepoServiceAccount(cr)
+}
+
+// getServiceAccountAnnotations will return the annotations set on the ServiceAccount of the given component of the
+// given ArgoCD, e.g. to bind the component to a cloud identity.
+func getServiceAccountAnnotations(cr *argoproj.ArgoCD, component string) map[string]string {
+	switch component {
+	case common.ArgoCDApplicationControllerComponent:
+		return cr.Spec.Controller.ServiceAccountAnnotations
+	case common.ArgoCDServerComponent:
+		return cr.Spec.Server.ServiceAccountAnnotations
+	case common.ArgoCDRepoServerComponent:
+		return cr.Spec.Repo.ServiceAccountAnnotations
+	case common.ArgoCDApplicationSetControllerComponent:
+		if cr.Spec.ApplicationSet != nil {
+			return cr.Spec.ApplicationSet.ServiceAccountAnnotations
+		}
+	}
+	return nil
+}
+
+// applyServiceAccountAnnotations will set the given annotations on the given ServiceAccount, and remove the
+// annotations previously set that are no longer given. It returns true if the ServiceAccount was changed.
+func applyServiceAccountAnnotations(sa *corev1.ServiceAccount, annotations map[string]string) bool {
+	current := sa.GetAnnotations()
+	if current == nil {
+		current = map[string]string{}
+	}
+	changed := false
+
+	if previous := current[common.ArgoCDServiceAccountAnnotationsAnnotation]; previous != "" {
+		for _, key := range strings.Split(previous, ",") {
+			if _, ok := annotations[key]; !ok {
+				delete(current, key)
+				changed = true
+			}
+		}
+	}
+	keys := make([]string, 0, len(annotations))
+	for key, val := range annotations {
+		keys = append(keys, key)
+		if value, ok := current[key]; !ok || value != val {
+			current[key] = val
+			changed = true
+		}
+	}
+	sort.Strings(keys)
+
+	if len(keys) > 0 {
+		if current[common.ArgoCDServiceAccountAnnotationsAnnotation] != strings.Join(keys, ",") {
+			current[common.ArgoCDServiceAccountAnnotationsAnnotation] = strings.Join(keys, ",")
+			changed = true
+		}
+	} else if _, ok := current[common.ArgoCDServiceAccountAnnotationsAnnotation]; ok {
+		delete(current, common.ArgoCDServiceAccountAnnotationsAnnotation)
+		changed = true
+	}
+
+	sa.SetAnnotations(current)
+	return changed
+}
+
+// getRepoServiceAccountName will return the name of the ServiceAccount of the Repo Server of the given ArgoCD, or an
+// empty string when the Repo Server runs with the default ServiceAccount of the namespace.
+func getRepoServiceAccountName(cr *argoproj.ArgoCD) string {
+	if cr.Spec.Repo.ServiceAccount != "" {
+		return cr.Spec.Repo.ServiceAccount
+	}
+	if len(cr.Spec.Repo.ServiceAccountAnnotations) > 0 {
+		return getServiceAccountName(cr.Name, common.ArgoCDRepoServerComponent)
+	}
+	return ""
+}
+
+// reconcileRepoServiceAccount will ensure that the ServiceAccount of the Repo Server is present when annotations are
+// set on it. Otherwise the Repo Server runs with the ServiceAccount named in its spec, or the default one.
+func (r *ReconcileArgoCD) reconcileRepoServiceAccount(cr *argoproj.ArgoCD) error {
+	sa := newServiceAccountWithName(common.ArgoCDRepoServerComponent, cr)
+	wanted := cr.Spec.Repo.ServiceAccount == "" && len(cr.Spec.Repo.ServiceAccountAnnotations) > 0
+
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, sa.Name, sa); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if !wanted {
+			return nil // Default ServiceAccount used, do nothing
+		}
+		applyServiceAccountAnnotations(sa, cr.Spec.Repo.ServiceAccountAnnotations)
+		if err := controllerutil.SetControllerReference(cr, sa, r.Scheme); err != nil {
+			return err
+		}
+		componentLog(cr, logComponentRepoServer).Info(fmt.Sprintf("creating serviceaccount %s for Argo CD instance %s in namespace %s", sa.Name, cr.Name, cr.Namespace))
+		return r.Client.Create(context.TODO(), sa)
+	}
+
+	if !wanted {
+		if !metav1.IsControlledBy(sa, cr) || cr.Spec.Repo.ServiceAccount == sa.Name {
+			return nil // ServiceAccount not created by the operator, or still used by the Repo Server, leave it alone
+		}
+		componentLog(cr, logComponentRepoServer).Info(fmt.Sprintf("deleting serviceaccount %s for Argo CD instance %s in namespace %s", sa.Name, cr.Name, cr.Namespace))
+		return r.Client.Delete(context.TODO(), sa)
+	}
+	if applyServiceAccountAnnotations(sa, cr.Spec.Repo.ServiceAccountAnnotations) {
+		return r.Client.Update(context.TODO(), sa)
+	}
 	return nil
 }
 
@@ -114,17 +221,21 @@ func (r *ReconcileArgoCD) reconcileServiceAccount(name string, cr *argoproj.Argo
 	if exists {
 		if name == common.ArgoCDDexServerComponent && !UseDex(cr) {
 			// Delete any existing Service Account created for Dex since dex is disabled
-			log.Info("deleting the existing Dex service account because dex uninstallation requested")
+			instanceLog(cr).Info("deleting the existing Dex service account because dex uninstallation requested")
 			return sa, r.Client.Delete(context.TODO(), sa)
 		}
+		if applyServiceAccountAnnotations(sa, getServiceAccountAnnotations(cr, name)) {
+			return sa, r.Client.Update(context.TODO(), sa)
+		}
 		return sa, nil
 	}
+	applyServiceAccountAnnotations(sa, getServiceAccountAnnotations(cr, name))
 
 	if err := controllerutil.SetControllerReference(cr, sa, r.Scheme); err != nil {
 		return nil, err
 	}
 
-	log.Info(fmt.Sprintf("creating serviceaccount %s for Argo CD instance %s in namespace %s", sa.Name, cr.Name, cr.Namespace))
+	instanceLog(cr).Info(fmt.Sprintf("creating serviceaccount %s for Argo CD instance %s in namespace %s", sa.Name, cr.Name, cr.Namespace))
 
 	err := r.Client.Create(context.TODO(), sa)
 	if err != nil {
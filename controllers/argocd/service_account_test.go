@@ -23,12 +23,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
 )
 
 func TestReconcileArgoCD_reconcileServiceAccountPermissions(t *testing.T) {
@@ -166,3 +168,81 @@ func testRules() []v1.PolicyRule {
 		},
 	}
 }
+
+func TestReconcileArgoCD_reconcileServiceAccount_annotations(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Controller.ServiceAccountAnnotations = map[string]string{
+			"eks.amazonaws.com/role-arn": "arn:aws:iam::111122223333:role/argocd-controller",
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	sa, err := r.reconcileServiceAccount(common.ArgoCDApplicationControllerComponent, a)
+	assert.NoError(t, err)
+	assert.Equal(t, "arn:aws:iam::111122223333:role/argocd-controller", sa.Annotations["eks.amazonaws.com/role-arn"])
+
+	// Annotations set out-of-band are kept, annotations removed from the spec are removed
+	sa.Annotations["example.com/owner"] = "platform"
+	assert.NoError(t, r.Client.Update(context.TODO(), sa))
+	a.Spec.Controller.ServiceAccountAnnotations = map[string]string{
+		"iam.gke.io/gcp-service-account": "argocd@project.iam.gserviceaccount.com",
+	}
+	_, err = r.reconcileServiceAccount(common.ArgoCDApplicationControllerComponent, a)
+	assert.NoError(t, err)
+
+	sa = &corev1.ServiceAccount{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-argocd-application-controller", Namespace: a.Namespace}, sa))
+	assert.Equal(t, map[string]string{
+		"iam.gke.io/gcp-service-account":                 "argocd@project.iam.gserviceaccount.com",
+		"example.com/owner":                              "platform",
+		common.ArgoCDServiceAccountAnnotationsAnnotation: "iam.gke.io/gcp-service-account",
+	}, sa.Annotations)
+}
+
+func TestReconcileArgoCD_reconcileRepoServiceAccount(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	key := types.NamespacedName{Name: "argocd-argocd-repo-server", Namespace: a.Namespace}
+
+	// The default ServiceAccount is used without annotations
+	assert.NoError(t, r.reconcileRepoServiceAccount(a))
+	assert.Error(t, r.Client.Get(context.TODO(), key, &corev1.ServiceAccount{}))
+	assert.Equal(t, "", getRepoServiceAccountName(a))
+
+	a.Spec.Repo.ServiceAccountAnnotations = map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::111122223333:role/argocd-repo"}
+	assert.NoError(t, r.reconcileRepoServiceAccount(a))
+	sa := &corev1.ServiceAccount{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, sa))
+	assert.Equal(t, "arn:aws:iam::111122223333:role/argocd-repo", sa.Annotations["eks.amazonaws.com/role-arn"])
+	assert.Equal(t, key.Name, getRepoServiceAccountName(a))
+
+	// A ServiceAccount named in the spec takes precedence
+	a.Spec.Repo.ServiceAccount = "custom"
+	assert.NoError(t, r.reconcileRepoServiceAccount(a))
+	assert.Error(t, r.Client.Get(context.TODO(), key, &corev1.ServiceAccount{}))
+	assert.Equal(t, "custom", getRepoServiceAccountName(a))
+
+	// A ServiceAccount with the same name not created by the operator is left untouched
+	a.Spec.Repo.ServiceAccount = ""
+	a.Spec.Repo.ServiceAccountAnnotations = nil
+	assert.NoError(t, r.Client.Create(context.TODO(), &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+	}))
+	assert.NoError(t, r.reconcileRepoServiceAccount(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, &corev1.ServiceAccount{}))
+}
@@ -12,6 +12,7 @@ import (
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
 func TestEnsureAutoTLSAnnotation(t *testing.T) {
@@ -81,3 +82,229 @@ func TestEnsureAutoTLSAnnotation(t *testing.T) {
 		assert.Equal(t, needUpdate, false)
 	})
 }
+
+func TestReconcileArgoCD_reconcileApplicationControllerService(t *testing.T) {
+	a := makeTestArgoCD()
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	svc := newServiceWithSuffix("application-controller", "application-controller", a)
+
+	// Sharding not enabled, no headless service
+	assert.NoError(t, r.reconcileApplicationControllerService(a))
+	assert.False(t, argoutil.IsObjectFound(r.Client, a.Namespace, svc.Name, svc))
+
+	a.Spec.Controller.Sharding.Enabled = true
+	assert.NoError(t, r.reconcileApplicationControllerService(a))
+	assert.True(t, argoutil.IsObjectFound(r.Client, a.Namespace, svc.Name, svc))
+	assert.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+	assert.Equal(t, "argocd-application-controller", svc.Spec.Selector[common.ArgoCDKeyName])
+
+	// Sharding disabled again, headless service is removed
+	a.Spec.Controller.Sharding.Enabled = false
+	assert.NoError(t, r.reconcileApplicationControllerService(a))
+	assert.False(t, argoutil.IsObjectFound(r.Client, a.Namespace, svc.Name, svc))
+}
+
+func TestReconcileArgoCD_reconcileServerService_loadBalancerOptions(t *testing.T) {
+	lbClass := "service.k8s.aws/nlb"
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Service = argoproj.ArgoCDServerServiceSpec{
+			Type:                     corev1.ServiceTypeLoadBalancer,
+			Annotations:              map[string]string{"service.beta.kubernetes.io/aws-load-balancer-scheme": "internal"},
+			Labels:                   map[string]string{"lb-pool": "internal"},
+			LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			LoadBalancerClass:        &lbClass,
+			ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyLocal,
+		}
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServerService(a))
+
+	svc := newServiceWithSuffix("server", "server", a)
+	assert.True(t, argoutil.IsObjectFound(r.Client, a.Namespace, svc.Name, svc))
+	assert.Equal(t, "internal", svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-scheme"])
+	assert.Equal(t, "internal", svc.Labels["lb-pool"])
+	assert.Equal(t, "argocd-server", svc.Labels[common.ArgoCDKeyName])
+	assert.Equal(t, []string{"10.0.0.0/8"}, svc.Spec.LoadBalancerSourceRanges)
+	assert.Equal(t, &lbClass, svc.Spec.LoadBalancerClass)
+	assert.Equal(t, corev1.ServiceExternalTrafficPolicyLocal, svc.Spec.ExternalTrafficPolicy)
+
+	// Changes to the options are synced to the existing Service
+	a.Spec.Server.Service.Annotations["service.beta.kubernetes.io/aws-load-balancer-scheme"] = "internet-facing"
+	a.Spec.Server.Service.LoadBalancerSourceRanges = nil
+	a.Spec.Server.Service.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyCluster
+	assert.NoError(t, r.reconcileServerService(a))
+
+	assert.True(t, argoutil.IsObjectFound(r.Client, a.Namespace, svc.Name, svc))
+	assert.Equal(t, "internet-facing", svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-scheme"])
+	assert.Empty(t, svc.Spec.LoadBalancerSourceRanges)
+	assert.Equal(t, corev1.ServiceExternalTrafficPolicyCluster, svc.Spec.ExternalTrafficPolicy)
+	assert.Equal(t, "service.beta.kubernetes.io/aws-load-balancer-scheme", svc.Annotations[common.ArgoCDServerServiceAnnotationsAnnotation])
+	assert.Equal(t, "lb-pool", svc.Annotations[common.ArgoCDServerServiceLabelsAnnotation])
+
+	// The annotations and labels removed from the ArgoCD are removed, or reset to the value set by the operator,
+	// while the ones set by others are kept
+	svc.Annotations["example.com/external"] = "kept"
+	assert.NoError(t, r.Client.Update(context.TODO(), svc))
+	a.Spec.Server.Service.Annotations = nil
+	a.Spec.Server.Service.Labels = map[string]string{common.ArgoCDKeyName: "overridden"}
+	assert.NoError(t, r.reconcileServerService(a))
+	assert.True(t, argoutil.IsObjectFound(r.Client, a.Namespace, svc.Name, svc))
+	assert.NotContains(t, svc.Annotations, "service.beta.kubernetes.io/aws-load-balancer-scheme")
+	assert.NotContains(t, svc.Annotations, common.ArgoCDServerServiceAnnotationsAnnotation)
+	assert.Equal(t, "kept", svc.Annotations["example.com/external"])
+	assert.NotContains(t, svc.Labels, "lb-pool")
+	assert.Equal(t, "overridden", svc.Labels[common.ArgoCDKeyName])
+
+	a.Spec.Server.Service.Labels = nil
+	assert.NoError(t, r.reconcileServerService(a))
+	assert.True(t, argoutil.IsObjectFound(r.Client, a.Namespace, svc.Name, svc))
+	assert.Equal(t, "argocd-server", svc.Labels[common.ArgoCDKeyName])
+	assert.NotContains(t, svc.Annotations, common.ArgoCDServerServiceLabelsAnnotation)
+}
+
+func TestReconcileArgoCD_reconcileServices_ipFamilies(t *testing.T) {
+	singleStack := corev1.IPFamilyPolicySingleStack
+	dualStack := corev1.IPFamilyPolicyPreferDualStack
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.IPFamilyPolicy = &singleStack
+		a.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol}
+		a.Spec.Server.Service.IPFamilyPolicy = &dualStack
+		a.Spec.Server.Service.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileServices(a))
+
+	repo := newServiceWithSuffix("repo-server", "repo-server", a)
+	assert.True(t, argoutil.IsObjectFound(r.Client, a.Namespace, repo.Name, repo))
+	assert.Equal(t, &singleStack, repo.Spec.IPFamilyPolicy)
+	assert.Equal(t, []corev1.IPFamily{corev1.IPv6Protocol}, repo.Spec.IPFamilies)
+
+	// The Server Service options override the instance options
+	server := newServiceWithSuffix("server", "server", a)
+	assert.True(t, argoutil.IsObjectFound(r.Client, a.Namespace, server.Name, server))
+	assert.Equal(t, &dualStack, server.Spec.IPFamilyPolicy)
+	assert.Equal(t, []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}, server.Spec.IPFamilies)
+
+	// Existing Services are updated, but their primary IP family is kept
+	a.Spec.IPFamilyPolicy = &dualStack
+	a.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}
+	assert.NoError(t, r.reconcileServices(a))
+
+	assert.True(t, argoutil.IsObjectFound(r.Client, a.Namespace, repo.Name, repo))
+	assert.Equal(t, &dualStack, repo.Spec.IPFamilyPolicy)
+	assert.Equal(t, []corev1.IPFamily{corev1.IPv6Protocol}, repo.Spec.IPFamilies)
+}
+
+func TestGetRedisConf_ipv6(t *testing.T) {
+	t.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+	a := makeTestArgoCD()
+	assert.Contains(t, getRedisConf(a, false), "bind 0.0.0.0\n")
+	assert.NotContains(t, getRedisHAProxyConfig(a, false), "bind :::6379")
+
+	a.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}
+	assert.Contains(t, getRedisConf(a, false), "bind 0.0.0.0 ::\n")
+	assert.Contains(t, getRedisSentinelConf(a, false), "bind 0.0.0.0 ::\n")
+	assert.Contains(t, getRedisHAProxyConfig(a, false), "bind :::6379 v6only")
+}
+
+func TestReconcileArgoCD_reconcileRedisService_customPort(t *testing.T) {
+	a := makeTestArgoCD()
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRedisService(a))
+	svc := newServiceWithSuffix("redis", "redis", a)
+	assert.True(t, argoutil.IsObjectFound(r.Client, a.Namespace, svc.Name, svc))
+	assert.Equal(t, int32(common.ArgoCDDefaultRedisPort), svc.Spec.Ports[0].Port)
+
+	// The port of an existing Service is updated
+	a.Spec.Redis.Port = 16379
+	assert.NoError(t, r.reconcileRedisService(a))
+	assert.True(t, argoutil.IsObjectFound(r.Client, a.Namespace, svc.Name, svc))
+	assert.Equal(t, int32(16379), svc.Spec.Ports[0].Port)
+	assert.Equal(t, 16379, svc.Spec.Ports[0].TargetPort.IntValue())
+	assert.Equal(t, "argocd-redis.argocd.svc.cluster.local:16379", getRedisServerAddress(a))
+}
+
+func TestGetRedisConf_customPorts(t *testing.T) {
+	t.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Redis.Port = 16379
+		a.Spec.HA.SentinelPort = 36379
+	})
+
+	assert.Contains(t, getRedisConf(a, false), "port 16379\n")
+	assert.Contains(t, getRedisConf(a, true), "tls-port 16379\n")
+	assert.Contains(t, getRedisSentinelConf(a, false), "port 36379\n")
+	assert.Contains(t, getRedisInitScript(a, false), "REDIS_PORT=16379\n")
+	assert.Contains(t, getRedisInitScript(a, false), "SENTINEL_PORT=36379\n")
+	assert.Contains(t, getRedisHAProxyConfig(a, false), "bind *:16379\n")
+	assert.Contains(t, getRedisHAProxyConfig(a, false), "argocd-redis-ha-announce-0:36379 check")
+	assert.Contains(t, getRedisHAProxyConfig(a, false), "argocd-redis-ha-announce-0:16379 check")
+	assert.Contains(t, getRedisLivenessScript(a, false), "-p 16379")
+	assert.Contains(t, getSentinelLivenessScript(a, false), "-p 36379")
+	assert.NotContains(t, getRedisHAProxyConfig(a, false), ":6379 ")
+	assert.Equal(t, []string{"--save", "", "--appendonly", "no", "--requirepass $(REDIS_PASSWORD)", "--port", "16379"},
+		getArgoRedisArgs(a, false))
+	assert.Equal(t, "argocd-redis-ha-haproxy.argocd.svc.cluster.local:16379", getRedisHAProxyAddress(a))
+}
+
+func TestReconcileArgoCD_reconcileRedisHAAnnounceServices(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	// A partially created setup, with an announce Service that drifted
+	drifted := newRedisHAAnnounceService(a, 1)
+	drifted.Annotations = nil
+	drifted.Spec.PublishNotReadyAddresses = false
+	drifted.Spec.Selector = map[string]string{"app": "other"}
+	drifted.Spec.Ports = nil
+
+	resObjs := []client.Object{a, drifted}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRedisHAAnnounceServices(a))
+	for i := int32(0); i < common.ArgoCDDefaultRedisHAReplicas; i++ {
+		want := newRedisHAAnnounceService(a, i)
+		svc := &corev1.Service{}
+		assert.True(t, argoutil.IsObjectFound(r.Client, a.Namespace, want.Name, svc))
+		assert.Equal(t, "true", svc.Annotations[common.ArgoCDKeyTolerateUnreadyEndpounts])
+		assert.True(t, svc.Spec.PublishNotReadyAddresses)
+		assert.Equal(t, want.Spec.Selector, svc.Spec.Selector)
+		assert.Equal(t, want.Spec.Ports, svc.Spec.Ports)
+	}
+
+	// All the announce Services are removed once HA is disabled
+	a.Spec.HA.Enabled = false
+	assert.NoError(t, r.reconcileRedisHAAnnounceServices(a))
+	for i := int32(0); i < common.ArgoCDDefaultRedisHAReplicas; i++ {
+		svc := newRedisHAAnnounceService(a, i)
+		assert.False(t, argoutil.IsObjectFound(r.Client, a.Namespace, svc.Name, svc))
+	}
+}
@@ -20,6 +20,7 @@ import (
 
 	deploymentConfig "github.com/openshift/api/apps/v1"
 	template "github.com/openshift/api/template/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
@@ -39,6 +40,53 @@ var (
 	ssoConfigLegalStatus     string
 )
 
+// getSSOStartupProbe will return the startup probe of an SSO container: the given default probe, with the fields set
+// in the given override, if any, taking precedence. The fields left unset are given the Kubernetes defaults, so that
+// the probe compares equal to the one stored by the API server.
+func getSSOStartupProbe(defaultProbe corev1.Probe, override *corev1.Probe) *corev1.Probe {
+	probe := defaultProbe.DeepCopy()
+	if override != nil {
+		if override.ProbeHandler != (corev1.ProbeHandler{}) {
+			probe.ProbeHandler = *override.ProbeHandler.DeepCopy()
+		}
+		if override.InitialDelaySeconds != 0 {
+			probe.InitialDelaySeconds = override.InitialDelaySeconds
+		}
+		if override.TimeoutSeconds != 0 {
+			probe.TimeoutSeconds = override.TimeoutSeconds
+		}
+		if override.PeriodSeconds != 0 {
+			probe.PeriodSeconds = override.PeriodSeconds
+		}
+		if override.SuccessThreshold != 0 {
+			probe.SuccessThreshold = override.SuccessThreshold
+		}
+		if override.FailureThreshold != 0 {
+			probe.FailureThreshold = override.FailureThreshold
+		}
+		if override.TerminationGracePeriodSeconds != nil {
+			probe.TerminationGracePeriodSeconds = override.TerminationGracePeriodSeconds
+		}
+	}
+
+	if probe.HTTPGet != nil && probe.HTTPGet.Scheme == "" {
+		probe.HTTPGet.Scheme = corev1.URISchemeHTTP
+	}
+	if probe.TimeoutSeconds == 0 {
+		probe.TimeoutSeconds = 1
+	}
+	if probe.PeriodSeconds == 0 {
+		probe.PeriodSeconds = 10
+	}
+	if probe.SuccessThreshold == 0 {
+		probe.SuccessThreshold = 1
+	}
+	if probe.FailureThreshold == 0 {
+		probe.FailureThreshold = 3
+	}
+	return probe
+}
+
 // CanUseKeycloakWithTemplate checks if the required APIs are available to
 // manage a Keycloak instance using Templates.
 func CanUseKeycloakWithTemplate() bool {
@@ -82,6 +130,21 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 		var err error
 		isError := false
 
+		if cr.IsCoreInstallation() {
+			// SSO only serves the logins to the Argo CD Server, which is not deployed in core mode, so the SSO
+			// provider is skipped and its existing resources are removed
+			message := "SSO is not configured as the Argo CD Server is not deployed when the installation mode is core"
+			instanceLog(cr).Info(message)
+			r.recordEvent(cr, corev1.EventTypeWarning, "SSOSkipped", message)
+			if err := r.reconcileDexResources(cr); err != nil && !apiErrors.IsNotFound(err) {
+				return err
+			}
+			if err := r.deleteKeycloakConfiguration(cr); err != nil && !apiErrors.IsNotFound(err) {
+				return err
+			}
+			return r.reconcileStatusSSO(cr)
+		}
+
 		// case 2
 		if cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeDex {
 			// Relevant SSO settings at play are `.spec.sso.dex` fields, `.spec.sso.keycloak`
@@ -95,11 +158,15 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 				// new keycloak spec fields are expressed when `.spec.sso.provider` is set to dex ==> conflict
 				errMsg = "cannot supply keycloak configuration in .spec.sso.keycloak when requested SSO provider is dex"
 				isError = true
+			} else if cr.Spec.SSO.OIDC != nil {
+				// external OIDC spec fields are expressed when `.spec.sso.provider` is set to dex ==> conflict
+				errMsg = "cannot supply external OIDC configuration in .spec.sso.oidc when requested SSO provider is dex"
+				isError = true
 			}
 
 			if isError {
 				err = errors.New(illegalSSOConfiguration + errMsg)
-				log.Error(err, fmt.Sprintf("Illegal expression of SSO configuration detected for Argo CD %s in namespace %s. %s", cr.Name, cr.Namespace, errMsg))
+				instanceLog(cr).Error(err, fmt.Sprintf("Illegal expression of SSO configuration detected for Argo CD %s in namespace %s. %s", cr.Name, cr.Namespace, errMsg))
 				ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
 				_ = r.reconcileStatusSSO(cr)
 				return err
@@ -115,10 +182,20 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 				errMsg = "cannot supply dex configuration when requested SSO provider is keycloak"
 				err = errors.New(illegalSSOConfiguration + errMsg)
 				isError = true
+			} else if cr.Spec.SSO.OIDC != nil {
+				// external OIDC spec fields are expressed when `.spec.sso.provider` is set to keycloak ==> conflict
+				errMsg = "cannot supply external OIDC configuration when requested SSO provider is keycloak"
+				err = errors.New(illegalSSOConfiguration + errMsg)
+				isError = true
+			} else if dbErr := validateKeycloakDatabase(cr); dbErr != nil {
+				// `.spec.sso.keycloak.database` expresses no database or both databases ==> conflict
+				errMsg = dbErr.Error()
+				err = errors.New(illegalSSOConfiguration + errMsg)
+				isError = true
 			}
 
 			if isError {
-				log.Error(err, fmt.Sprintf("Illegal expression of SSO configuration detected for Argo CD %s in namespace %s. %s", cr.Name, cr.Namespace, errMsg))
+				instanceLog(cr).Error(err, fmt.Sprintf("Illegal expression of SSO configuration detected for Argo CD %s in namespace %s. %s", cr.Name, cr.Namespace, errMsg))
 				ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
 				_ = r.reconcileStatusSSO(cr)
 				return err
@@ -136,29 +213,59 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 		}
 
 		// case 4
+		if cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeExternal {
+			// Relevant SSO settings at play are `.spec.sso.oidc` fields, `.spec.sso.dex`, `.spec.sso.keycloak`, `.spec.oidcConfig`
+
+			if cr.Spec.SSO.OIDC == nil || cr.Spec.SSO.OIDC.Issuer == "" || cr.Spec.SSO.OIDC.ClientID == "" {
+				errMsg = "must supply an issuer and a client ID in .spec.sso.oidc when requested SSO provider is external"
+				isError = true
+			} else if cr.Spec.SSO.Dex != nil || cr.Spec.SSO.Keycloak != nil {
+				// dex or keycloak spec fields are expressed when `.spec.sso.provider` is set to external ==> conflict
+				errMsg = "cannot supply dex or keycloak configuration when requested SSO provider is external"
+				isError = true
+			} else if cr.Spec.OIDCConfig != "" {
+				// both the external OIDC spec and the raw OIDC configuration are expressed ==> conflict
+				errMsg = "cannot supply .spec.oidcConfig when requested SSO provider is external"
+				isError = true
+			}
+
+			if isError {
+				err = errors.New(illegalSSOConfiguration + errMsg)
+				instanceLog(cr).Error(err, fmt.Sprintf("Illegal expression of SSO configuration detected for Argo CD %s in namespace %s. %s", cr.Name, cr.Namespace, errMsg))
+				ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
+				_ = r.reconcileStatusSSO(cr)
+				return err
+			}
+		}
+
+		// case 5
 		if cr.Spec.SSO.Provider.ToLower() == "" {
 
 			if cr.Spec.SSO.Dex != nil ||
 				// `.spec.sso.dex` expressed without specifying SSO provider ==> conflict
-				cr.Spec.SSO.Keycloak != nil {
+				cr.Spec.SSO.Keycloak != nil ||
 				// `.spec.sso.keycloak` expressed without specifying SSO provider ==> conflict
+				cr.Spec.SSO.OIDC != nil {
+				// `.spec.sso.oidc` expressed without specifying SSO provider ==> conflict
 
 				errMsg = "Cannot specify SSO provider spec without specifying SSO provider type"
 				err = errors.New(illegalSSOConfiguration + errMsg)
-				log.Error(err, fmt.Sprintf("Cannot specify SSO provider spec without specifying SSO provider type for Argo CD %s in namespace %s.", cr.Name, cr.Namespace))
+				instanceLog(cr).Error(err, fmt.Sprintf("Cannot specify SSO provider spec without specifying SSO provider type for Argo CD %s in namespace %s.", cr.Name, cr.Namespace))
 				ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
 				_ = r.reconcileStatusSSO(cr)
 				return err
 			}
 		}
 
-		// case 5
-		if cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeDex && cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeKeycloak {
+		// case 6
+		if cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeDex && cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeKeycloak &&
+			cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeExternal {
 			// `.spec.sso.provider` contains unsupported value
 
-			errMsg = fmt.Sprintf("Unsupported SSO provider type. Supported providers are %s and %s", argoproj.SSOProviderTypeDex, argoproj.SSOProviderTypeKeycloak)
+			errMsg = fmt.Sprintf("Unsupported SSO provider type. Supported providers are %s, %s and %s", argoproj.SSOProviderTypeDex,
+				argoproj.SSOProviderTypeKeycloak, argoproj.SSOProviderTypeExternal)
 			err = errors.New(illegalSSOConfiguration + errMsg)
-			log.Error(err, fmt.Sprintf("Unsupported SSO provider type for Argo CD %s in namespace %s.", cr.Name, cr.Namespace))
+			instanceLog(cr).Error(err, fmt.Sprintf("Unsupported SSO provider type for Argo CD %s in namespace %s.", cr.Name, cr.Namespace))
 			ssoConfigLegalStatus = ssoLegalFailed // set global indicator that SSO config has gone wrong
 			_ = r.reconcileStatusSSO(cr)
 			return err
@@ -175,7 +282,7 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 
 		// Trigger reconciliation of any Dex resources so they get deleted
 		if err := r.reconcileDexResources(cr); err != nil && !apiErrors.IsNotFound(err) {
-			log.Error(err, "Unable to delete existing dex resources before configuring keycloak")
+			instanceLog(cr).Error(err, "Unable to delete existing dex resources before configuring keycloak")
 			return err
 		}
 
@@ -185,14 +292,25 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 	} else if UseDex(cr) {
 		// dex
 		// Delete any lingering keycloak artifacts before Dex is configured as this is not handled by the reconcilliation loop
-		if err := deleteKeycloakConfiguration(cr); err != nil && !apiErrors.IsNotFound(err) {
-			log.Error(err, "Unable to delete existing SSO configuration before configuring Dex")
+		if err := r.deleteKeycloakConfiguration(cr); err != nil && !apiErrors.IsNotFound(err) {
+			instanceLog(cr).Error(err, "Unable to delete existing SSO configuration before configuring Dex")
 			return err
 		}
 
 		if err := r.reconcileDexResources(cr); err != nil {
 			return err
 		}
+	} else if UseExternalOIDC(cr) {
+		// external OIDC
+		// Argo CD authenticates against the provider directly, tear down any Dex or keycloak resources
+		if err := r.reconcileDexResources(cr); err != nil && !apiErrors.IsNotFound(err) {
+			instanceLog(cr).Error(err, "Unable to delete existing dex resources before configuring the external OIDC provider")
+			return err
+		}
+		if err := r.deleteKeycloakConfiguration(cr); err != nil && !apiErrors.IsNotFound(err) {
+			instanceLog(cr).Error(err, "Unable to delete existing keycloak configuration before configuring the external OIDC provider")
+			return err
+		}
 	}
 
 	_ = r.reconcileStatusSSO(cr)
@@ -202,17 +320,17 @@ func (r *ReconcileArgoCD) reconcileSSO(cr *argoproj.ArgoCD) error {
 
 func (r *ReconcileArgoCD) deleteSSOConfiguration(newCr *argoproj.ArgoCD, oldCr *argoproj.ArgoCD) error {
 
-	log.Info("uninstalling existing SSO configuration")
+	instanceLog(newCr).Info("uninstalling existing SSO configuration")
 
 	if oldCr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeKeycloak {
-		if err := deleteKeycloakConfiguration(newCr); err != nil {
-			log.Error(err, "Unable to delete existing keycloak configuration")
+		if err := r.deleteKeycloakConfiguration(newCr); err != nil {
+			instanceLog(newCr).Error(err, "Unable to delete existing keycloak configuration")
 			return err
 		}
 	} else if oldCr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeDex {
 		// Trigger reconciliation of Dex resources so they get deleted
 		if err := r.deleteDexResources(newCr); err != nil {
-			log.Error(err, "Unable to reconcile necessary resources for uninstallation of Dex")
+			instanceLog(newCr).Error(err, "Unable to reconcile necessary resources for uninstallation of Dex")
 			return err
 		}
 	}
@@ -0,0 +1,233 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// externalOIDCClaim is a claim requested in the ID token, in the oidc.config of Argo CD.
+type externalOIDCClaim struct {
+	Essential bool     `yaml:"essential,omitempty"`
+	Values    []string `yaml:"values,omitempty"`
+}
+
+// externalOIDCConfig is the oidc.config of Argo CD for an external OIDC provider.
+type externalOIDCConfig struct {
+	Name                   string                       `yaml:"name"`
+	Issuer                 string                       `yaml:"issuer"`
+	ClientID               string                       `yaml:"clientID"`
+	ClientSecret           string                       `yaml:"clientSecret,omitempty"`
+	RequestedScopes        []string                     `yaml:"requestedScopes,omitempty"`
+	RequestedIDTokenClaims map[string]externalOIDCClaim `yaml:"requestedIDTokenClaims,omitempty"`
+	RootCA                 string                       `yaml:"rootCA,omitempty"`
+}
+
+// dexConnectorsConfig holds the connectors of a Dex configuration.
+type dexConnectorsConfig struct {
+	Connectors []struct {
+		Type   string `yaml:"type"`
+		Name   string `yaml:"name"`
+		Config struct {
+			Issuer       string   `yaml:"issuer"`
+			ClientID     string   `yaml:"clientID"`
+			ClientSecret string   `yaml:"clientSecret"`
+			Scopes       []string `yaml:"scopes"`
+		} `yaml:"config"`
+	} `yaml:"connectors"`
+}
+
+// UseExternalOIDC returns true if the given ArgoCD authenticates against an external OIDC provider configured in
+// .spec.sso.oidc.
+func UseExternalOIDC(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.SSO != nil && cr.Spec.SSO.Provider.ToLower() == argoproj.SSOProviderTypeExternal
+}
+
+// getExternalOIDCConfig will return the oidc.config of Argo CD rendered from the external OIDC provider of the given
+// ArgoCD. The client secret is referenced from the Argo CD secret.
+func getExternalOIDCConfig(cr *argoproj.ArgoCD) string {
+	spec := cr.Spec.SSO.OIDC
+	if spec == nil {
+		return ""
+	}
+
+	config := externalOIDCConfig{
+		Name:            spec.Name,
+		Issuer:          spec.Issuer,
+		ClientID:        spec.ClientID,
+		RequestedScopes: spec.Scopes,
+		RootCA:          spec.RootCA,
+	}
+	if config.Name == "" {
+		config.Name = "OIDC"
+	}
+	if spec.ClientSecretRef != nil {
+		config.ClientSecret = "$" + common.ArgoCDExternalOIDCSecretKey
+	}
+	if len(spec.Claims) > 0 {
+		config.RequestedIDTokenClaims = map[string]externalOIDCClaim{}
+		for name, claim := range spec.Claims {
+			config.RequestedIDTokenClaims[name] = externalOIDCClaim{Essential: claim.Essential, Values: claim.Values}
+		}
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		instanceLog(cr).Error(err, "failed to render the external OIDC configuration")
+		return ""
+	}
+	return string(out)
+}
+
+// getExternalOIDCClientSecret will return the client secret of the external OIDC provider of the given ArgoCD, nil
+// if the provider has none.
+func (r *ReconcileArgoCD) getExternalOIDCClientSecret(cr *argoproj.ArgoCD) ([]byte, error) {
+	if cr.Spec.SSO.OIDC == nil || cr.Spec.SSO.OIDC.ClientSecretRef == nil {
+		return nil, nil
+	}
+
+	ref := cr.Spec.SSO.OIDC.ClientSecretRef
+	secret := argoutil.NewSecretWithName(cr, ref.Name)
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, ref.Name, secret); err != nil {
+		return nil, fmt.Errorf("failed to get the client secret of the external OIDC provider: %w", err)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in secret %s holding the client secret of the external OIDC provider", ref.Key, ref.Name)
+	}
+	return value, nil
+}
+
+// getExternalOIDCMigration will return the external OIDC provider equivalent to the OIDC configuration of the given
+// ArgoCD, taken from .spec.oidcConfig or from the single OIDC connector of Dex.
+func getExternalOIDCMigration(cr *argoproj.ArgoCD) (*argoproj.ArgoCDOIDCSpec, error) {
+	config := externalOIDCConfig{}
+	switch {
+	case cr.Spec.OIDCConfig != "":
+		if err := yaml.Unmarshal([]byte(cr.Spec.OIDCConfig), &config); err != nil {
+			return nil, fmt.Errorf("invalid .spec.oidcConfig: %w", err)
+		}
+	case UseDex(cr) && cr.Spec.SSO.Dex != nil && cr.Spec.SSO.Dex.Config != "":
+		if cr.Spec.SSO.Dex.OpenShiftOAuth {
+			return nil, errors.New("the OpenShift OAuth connector of Dex cannot be migrated")
+		}
+		dex := dexConnectorsConfig{}
+		if err := yaml.Unmarshal([]byte(cr.Spec.SSO.Dex.Config), &dex); err != nil {
+			return nil, fmt.Errorf("invalid .spec.sso.dex.config: %w", err)
+		}
+		if len(dex.Connectors) != 1 || dex.Connectors[0].Type != "oidc" {
+			return nil, errors.New("only a Dex configuration with a single oidc connector can be migrated")
+		}
+		connector := dex.Connectors[0]
+		config = externalOIDCConfig{
+			Name:            connector.Name,
+			Issuer:          connector.Config.Issuer,
+			ClientID:        connector.Config.ClientID,
+			ClientSecret:    connector.Config.ClientSecret,
+			RequestedScopes: connector.Config.Scopes,
+		}
+	default:
+		return nil, errors.New("no OIDC configuration to migrate")
+	}
+
+	if config.Issuer == "" || config.ClientID == "" {
+		return nil, errors.New("the OIDC configuration must set an issuer and a client ID")
+	}
+
+	spec := &argoproj.ArgoCDOIDCSpec{
+		Name:     config.Name,
+		Issuer:   config.Issuer,
+		ClientID: config.ClientID,
+		Scopes:   config.RequestedScopes,
+		RootCA:   config.RootCA,
+	}
+	if config.ClientSecret != "" {
+		// Argo CD references secrets as $<key> in argocd-secret, or as $<secret>:<key>
+		if !strings.HasPrefix(config.ClientSecret, "$") {
+			return nil, errors.New("the client secret must reference a Secret, as $<secret>:<key>, to be migrated")
+		}
+		name, key := common.ArgoCDSecretName, strings.TrimPrefix(config.ClientSecret, "$")
+		if i := strings.Index(key, ":"); i >= 0 {
+			name, key = key[:i], key[i+1:]
+		}
+		spec.ClientSecretRef = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+		}
+	}
+	if len(config.RequestedIDTokenClaims) > 0 {
+		spec.Claims = map[string]argoproj.ArgoCDOIDCClaimSpec{}
+		for name, claim := range config.RequestedIDTokenClaims {
+			spec.Claims[name] = argoproj.ArgoCDOIDCClaimSpec{Essential: claim.Essential, Values: claim.Values}
+		}
+	}
+	return spec, nil
+}
+
+// migrateSSOToExternalOIDC will migrate the OIDC configuration of the given ArgoCD to .spec.sso.oidc when requested
+// with the migrate-sso annotation. The annotation is removed once handled, and a Warning Event is emitted if the
+// configuration cannot be migrated, leaving the ArgoCD unchanged otherwise. As the redirect URI of Argo CD changes
+// when migrating from Dex, a Warning Event reminds to update it in the OIDC provider.
+func (r *ReconcileArgoCD) migrateSSOToExternalOIDC(cr *argoproj.ArgoCD) error {
+	provider, ok := cr.Annotations[common.ArgoCDMigrateSSOAnnotation]
+	if !ok {
+		return nil
+	}
+	patch := client.MergeFrom(cr.DeepCopy())
+	delete(cr.Annotations, common.ArgoCDMigrateSSOAnnotation)
+	fromDex := cr.Spec.OIDCConfig == ""
+
+	var err error
+	var spec *argoproj.ArgoCDOIDCSpec
+	if argoproj.SSOProviderType(provider).ToLower() != argoproj.SSOProviderTypeExternal {
+		err = fmt.Errorf("unsupported SSO provider %q, only %s is supported", provider, argoproj.SSOProviderTypeExternal)
+	} else {
+		spec, err = getExternalOIDCMigration(cr)
+	}
+
+	if err != nil {
+		message := fmt.Sprintf("failed to migrate the SSO configuration: %v", err)
+		instanceLog(cr).Info(fmt.Sprintf("%s for ArgoCD %s/%s", message, cr.Namespace, cr.Name))
+		r.recordEvent(cr, corev1.EventTypeWarning, "SSOMigrationFailed", message)
+	} else {
+		instanceLog(cr).Info(fmt.Sprintf("migrating the SSO configuration of ArgoCD %s/%s to the external OIDC provider %s",
+			cr.Namespace, cr.Name, spec.Issuer))
+		cr.Spec.SSO = &argoproj.ArgoCDSSOSpec{Provider: argoproj.SSOProviderTypeExternal, OIDC: spec}
+		cr.Spec.OIDCConfig = ""
+	}
+	if patchErr := r.Client.Patch(context.TODO(), cr, patch); patchErr != nil {
+		return patchErr
+	}
+
+	if err == nil && fromDex {
+		uri := r.getArgoServerURI(cr)
+		message := fmt.Sprintf("the SSO configuration was migrated from Dex, the redirect URI registered for client %s in the OIDC provider %s must be changed from %s/api/dex/callback to %s/auth/callback",
+			spec.ClientID, spec.Issuer, uri, uri)
+		instanceLog(cr).Info(fmt.Sprintf("%s for ArgoCD %s/%s", message, cr.Namespace, cr.Name))
+		r.recordEvent(cr, corev1.EventTypeWarning, "SSORedirectURIChanged", message)
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func makeTestExternalOIDCArgoCD(opts ...argoCDOpt) *argoproj.ArgoCD {
+	return makeTestArgoCD(append([]argoCDOpt{func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeExternal,
+			OIDC: &argoproj.ArgoCDOIDCSpec{
+				Issuer:   "https://idp.example.com",
+				ClientID: "argocd",
+				ClientSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "idp-client"},
+					Key:                  "secret",
+				},
+				Scopes: []string{"openid", "groups"},
+				Claims: map[string]argoproj.ArgoCDOIDCClaimSpec{
+					"groups": {Essential: true},
+				},
+			},
+		}
+	}}, opts...)...)
+}
+
+func TestGetExternalOIDCConfig(t *testing.T) {
+	a := makeTestExternalOIDCArgoCD()
+
+	assert.True(t, UseExternalOIDC(a))
+	assert.Equal(t, `name: OIDC
+issuer: https://idp.example.com
+clientID: argocd
+clientSecret: $oidc.external.clientSecret
+requestedScopes:
+- openid
+- groups
+requestedIDTokenClaims:
+  groups:
+    essential: true
+`, getOIDCConfig(a))
+
+	a.Spec.SSO.OIDC.Name = "Okta"
+	a.Spec.SSO.OIDC.ClientSecretRef = nil
+	a.Spec.SSO.OIDC.Scopes = nil
+	a.Spec.SSO.OIDC.Claims = nil
+	assert.Equal(t, "name: Okta\nissuer: https://idp.example.com\nclientID: argocd\n", getOIDCConfig(a))
+}
+
+func TestGetExternalOIDCMigration(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    argoCDOpt
+		want    *argoproj.ArgoCDOIDCSpec
+		wantErr string
+	}{
+		{
+			name: "from .spec.oidcConfig",
+			opts: func(a *argoproj.ArgoCD) {
+				a.Spec.OIDCConfig = `name: Okta
+issuer: https://example.okta.com
+clientID: argocd
+clientSecret: $okta:clientSecret
+requestedScopes: ["openid", "groups"]
+requestedIDTokenClaims: {"groups": {"essential": true}}
+`
+			},
+			want: &argoproj.ArgoCDOIDCSpec{
+				Name:     "Okta",
+				Issuer:   "https://example.okta.com",
+				ClientID: "argocd",
+				ClientSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "okta"},
+					Key:                  "clientSecret",
+				},
+				Scopes: []string{"openid", "groups"},
+				Claims: map[string]argoproj.ArgoCDOIDCClaimSpec{"groups": {Essential: true}},
+			},
+		},
+		{
+			name: "from the oidc connector of dex",
+			opts: func(a *argoproj.ArgoCD) {
+				a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+					Provider: argoproj.SSOProviderTypeDex,
+					Dex: &argoproj.ArgoCDDexSpec{Config: `connectors:
+- type: oidc
+  id: okta
+  name: Okta
+  config:
+    issuer: https://example.okta.com
+    clientID: argocd
+    clientSecret: $oidc.okta.clientSecret
+`},
+				}
+			},
+			want: &argoproj.ArgoCDOIDCSpec{
+				Name:     "Okta",
+				Issuer:   "https://example.okta.com",
+				ClientID: "argocd",
+				ClientSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: common.ArgoCDSecretName},
+					Key:                  "oidc.okta.clientSecret",
+				},
+			},
+		},
+		{
+			name: "literal client secret",
+			opts: func(a *argoproj.ArgoCD) {
+				a.Spec.OIDCConfig = "issuer: https://example.okta.com\nclientID: argocd\nclientSecret: s3cr3t\n"
+			},
+			wantErr: "the client secret must reference a Secret, as $<secret>:<key>, to be migrated",
+		},
+		{
+			name: "dex with a non oidc connector",
+			opts: func(a *argoproj.ArgoCD) {
+				a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+					Provider: argoproj.SSOProviderTypeDex,
+					Dex:      &argoproj.ArgoCDDexSpec{Config: "connectors:\n- type: github\n  name: GitHub\n"},
+				}
+			},
+			wantErr: "only a Dex configuration with a single oidc connector can be migrated",
+		},
+		{
+			name:    "nothing to migrate",
+			opts:    func(a *argoproj.ArgoCD) {},
+			wantErr: "no OIDC configuration to migrate",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec, err := getExternalOIDCMigration(makeTestArgoCD(test.opts))
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, spec)
+		})
+	}
+}
+
+func TestReconcileArgoCD_migrateSSOToExternalOIDC(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Annotations = map[string]string{common.ArgoCDMigrateSSOAnnotation: "external"}
+		a.Spec.OIDCConfig = "issuer: https://idp.example.com\nclientID: argocd\nclientSecret: $idp-client:secret\n"
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	assert.NoError(t, r.migrateSSOToExternalOIDC(a))
+
+	migrated := &argoproj.ArgoCD{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, migrated))
+	assert.NotContains(t, migrated.Annotations, common.ArgoCDMigrateSSOAnnotation)
+	assert.Empty(t, migrated.Spec.OIDCConfig)
+	assert.True(t, UseExternalOIDC(migrated))
+	assert.Equal(t, "https://idp.example.com", migrated.Spec.SSO.OIDC.Issuer)
+	assert.Equal(t, "idp-client", migrated.Spec.SSO.OIDC.ClientSecretRef.Name)
+
+	// A failed migration leaves the configuration unchanged and emits an Event
+	migrated.Annotations = map[string]string{common.ArgoCDMigrateSSOAnnotation: "keycloak"}
+	assert.NoError(t, r.migrateSSOToExternalOIDC(migrated))
+	assert.NotContains(t, migrated.Annotations, common.ArgoCDMigrateSSOAnnotation)
+	assert.True(t, UseExternalOIDC(migrated))
+
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, corev1.EventTypeWarning+" SSOMigrationFailed")
+}
+
+func TestReconcileArgoCD_migrateSSOToExternalOIDC_fromDex(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Annotations = map[string]string{common.ArgoCDMigrateSSOAnnotation: "external"}
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex:      &argoproj.ArgoCDDexSpec{Config: "connectors:\n- type: oidc\n  name: idp\n  config:\n    issuer: https://idp.example.com\n    clientID: argocd\n    clientSecret: $idp-client:secret\n"},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	assert.NoError(t, r.migrateSSOToExternalOIDC(a))
+
+	migrated := &argoproj.ArgoCD{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, migrated))
+	assert.True(t, UseExternalOIDC(migrated))
+	assert.Nil(t, migrated.Spec.SSO.Dex)
+
+	// The redirect URI registered in the OIDC provider must be updated
+	assert.Len(t, recorder.Events, 1)
+	event := <-recorder.Events
+	assert.Contains(t, event, corev1.EventTypeWarning+" SSORedirectURIChanged")
+	assert.Contains(t, event, "/api/dex/callback")
+	assert.Contains(t, event, "/auth/callback")
+}
+
+func TestReconcileArgoCD_reconcileArgoSecret_externalOIDC(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestExternalOIDCArgoCD()
+	clientSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "idp-client", Namespace: a.Namespace},
+		Data:       map[string][]byte{"secret": []byte("s3cr3t")},
+	}
+	clusterSecret := argoutil.NewSecretWithSuffix(a, "cluster")
+	clusterSecret.Data = map[string][]byte{common.ArgoCDKeyAdminPassword: []byte("something")}
+	tlsSecret := argoutil.NewSecretWithSuffix(a, "tls")
+
+	resObjs := []client.Object{a, clientSecret, clusterSecret, tlsSecret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoSecret(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, secret))
+	assert.Equal(t, "s3cr3t", string(secret.Data[common.ArgoCDExternalOIDCSecretKey]))
+
+	// A rotated client secret is copied again
+	clientSecret.Data["secret"] = []byte("rotated")
+	assert.NoError(t, r.Client.Update(context.TODO(), clientSecret))
+	assert.NoError(t, r.reconcileExistingArgoSecret(a, secret, clusterSecret, tlsSecret))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: a.Namespace}, secret))
+	assert.Equal(t, "rotated", string(secret.Data[common.ArgoCDExternalOIDCSecretKey]))
+}
@@ -0,0 +1,197 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// ssoLivecheckResult is the result of the last livecheck of the SSO provider of an ArgoCD.
+type ssoLivecheckResult struct {
+	url       string
+	err       error
+	checkedAt time.Time
+}
+
+// ssoLivecheckCache holds the result of the last livecheck of the SSO provider of each ArgoCD, so that the provider
+// is checked once per interval rather than on each reconciliation.
+var ssoLivecheckCache = struct {
+	sync.Mutex
+	results map[types.NamespacedName]ssoLivecheckResult
+}{results: map[types.NamespacedName]ssoLivecheckResult{}}
+
+// probeSSOProvider checks that the given SSO provider URL answers successfully. TLS verification is skipped for the
+// providers installed by the operator, which are reached through their Service and serve a cluster-issued certificate.
+// It is a variable so that it can be replaced in tests.
+var probeSSOProvider = func(url string, insecure bool) error {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if insecure {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	client := &http.Client{Transport: transport, Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	return nil
+}
+
+// getSSOLivecheckURL will return the URL checked for the SSO provider of the given ArgoCD, and whether the
+// verification of its certificate is skipped. Dex and Keycloak are checked through their Service, other OIDC providers through
+// their discovery document. An empty URL is returned when no SSO provider is configured.
+func getSSOLivecheckURL(cr *argoproj.ArgoCD) (string, bool) {
+	if cr.Spec.SSO != nil {
+		switch cr.Spec.SSO.Provider.ToLower() {
+		case argoproj.SSOProviderTypeDex:
+			return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d/api/dex/healthz",
+				nameWithSuffix("dex-server", cr), cr.Namespace, common.ArgoCDDefaultDexHTTPPort), false
+		case argoproj.SSOProviderTypeKeycloak:
+			return fmt.Sprintf("https://%s.%s.svc.cluster.local:%d/auth/realms/%s",
+				defaultKeycloakIdentifier, cr.Namespace, portTLS, keycloakRealm), true
+		case argoproj.SSOProviderTypeExternal:
+			if cr.Spec.SSO.OIDC == nil || cr.Spec.SSO.OIDC.Issuer == "" {
+				return "", false
+			}
+			return strings.TrimSuffix(cr.Spec.SSO.OIDC.Issuer, "/") + "/.well-known/openid-configuration", false
+		}
+	}
+
+	if cr.Spec.OIDCConfig != "" {
+		oidc := struct {
+			Issuer string `yaml:"issuer"`
+		}{}
+		if err := yaml.Unmarshal([]byte(cr.Spec.OIDCConfig), &oidc); err == nil && oidc.Issuer != "" {
+			return strings.TrimSuffix(oidc.Issuer, "/") + "/.well-known/openid-configuration", false
+		}
+	}
+	return "", false
+}
+
+// getSSOLivecheckResult will return the result of the livecheck of the given URL for the given ArgoCD, checking the
+// URL again once the livecheck interval has elapsed or when the URL changed. The URL is probed without holding the
+// cache, so that a slow provider does not block the livechecks of the other instances.
+func getSSOLivecheckResult(cr *argoproj.ArgoCD, url string, insecure bool) ssoLivecheckResult {
+	key := types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}
+
+	ssoLivecheckCache.Lock()
+	result, ok := ssoLivecheckCache.results[key]
+	ssoLivecheckCache.Unlock()
+	if ok && result.url == url && time.Since(result.checkedAt) < common.ArgoCDDefaultSSOLivecheckInterval {
+		return result
+	}
+
+	result = ssoLivecheckResult{url: url, err: probeSSOProvider(url, insecure), checkedAt: time.Now()}
+
+	ssoLivecheckCache.Lock()
+	ssoLivecheckCache.results[key] = result
+	ssoLivecheckCache.Unlock()
+	return result
+}
+
+// getSSOLivecheckDelay will return the time left until the next livecheck of the SSO provider of the given ArgoCD,
+// zero if no SSO provider is configured.
+func getSSOLivecheckDelay(cr *argoproj.ArgoCD) time.Duration {
+	if url, _ := getSSOLivecheckURL(cr); url == "" {
+		return 0
+	}
+
+	ssoLivecheckCache.Lock()
+	defer ssoLivecheckCache.Unlock()
+
+	result, ok := ssoLivecheckCache.results[types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}]
+	if !ok {
+		return common.ArgoCDDefaultSSOLivecheckInterval
+	}
+	if delay := time.Until(result.checkedAt.Add(common.ArgoCDDefaultSSOLivecheckInterval)); delay > 0 {
+		return delay
+	}
+	return time.Second
+}
+
+// reconcileStatusSSOLivecheck will ensure that the SSOReachable condition reports the result of the livecheck of the
+// SSO provider of the given ArgoCD. The condition is removed once no SSO provider is configured.
+func (r *ReconcileArgoCD) reconcileStatusSSOLivecheck(cr *argoproj.ArgoCD) error {
+	if r.isDryRun() {
+		return nil // The livecheck results are only cached for the changes applied
+	}
+
+	conditions := make([]metav1.Condition, len(cr.Status.Conditions))
+	copy(conditions, cr.Status.Conditions)
+
+	url, insecure := getSSOLivecheckURL(cr)
+	if url == "" {
+		ssoLivecheckCache.Lock()
+		delete(ssoLivecheckCache.results, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace})
+		ssoLivecheckCache.Unlock()
+		meta.RemoveStatusCondition(&cr.Status.Conditions, argoproj.ArgoCDConditionTypeSSOReachable)
+	} else if cr.Spec.SSO != nil && !UseExternalOIDC(cr) && cr.Status.SSO != "Running" {
+		// The provider installed by the operator is not expected to answer until it runs
+		meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+			Type:               argoproj.ArgoCDConditionTypeSSOReachable,
+			Status:             metav1.ConditionUnknown,
+			Reason:             argoproj.ArgoCDConditionReasonSSOProviderNotRunning,
+			Message:            fmt.Sprintf("The SSO provider is %s", strings.ToLower(getSSOStatusOrUnknown(cr))),
+			ObservedGeneration: cr.Generation,
+		})
+	} else {
+		result := getSSOLivecheckResult(cr, url, insecure)
+		condition := metav1.Condition{
+			Type:               argoproj.ArgoCDConditionTypeSSOReachable,
+			Status:             metav1.ConditionTrue,
+			Reason:             argoproj.ArgoCDConditionReasonSSOProviderReachable,
+			Message:            fmt.Sprintf("The SSO provider answered at %s", url),
+			ObservedGeneration: cr.Generation,
+		}
+		if result.err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = argoproj.ArgoCDConditionReasonSSOProviderUnreachable
+			condition.Message = fmt.Sprintf("The SSO provider did not answer at %s: %v", url, result.err)
+		}
+		meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	}
+
+	if !reflect.DeepEqual(conditions, cr.Status.Conditions) {
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+	return nil
+}
+
+// getSSOStatusOrUnknown will return the SSO status of the given ArgoCD, Unknown if it is not set yet.
+func getSSOStatusOrUnknown(cr *argoproj.ArgoCD) string {
+	if cr.Status.SSO == "" {
+		return "Unknown"
+	}
+	return cr.Status.SSO
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// stubProbeSSOProvider replaces the SSO provider probe for the duration of the test and resets the cache.
+func stubProbeSSOProvider(t *testing.T, err *error) *[]string {
+	probed := []string{}
+	orig := probeSSOProvider
+	probeSSOProvider = func(url string, insecure bool) error {
+		probed = append(probed, url)
+		return *err
+	}
+	resetCache := func() {
+		ssoLivecheckCache.results = map[types.NamespacedName]ssoLivecheckResult{}
+	}
+	resetCache()
+	t.Cleanup(func() {
+		probeSSOProvider = orig
+		resetCache()
+	})
+	return &probed
+}
+
+func TestGetSSOLivecheckURL(t *testing.T) {
+	a := makeTestArgoCD()
+	url, _ := getSSOLivecheckURL(a)
+	assert.Empty(t, url)
+
+	a.Spec.OIDCConfig = "name: Okta\nissuer: https://example.okta.com/\nclientID: argocd\n"
+	url, insecure := getSSOLivecheckURL(a)
+	assert.Equal(t, "https://example.okta.com/.well-known/openid-configuration", url)
+	assert.False(t, insecure)
+
+	a.Spec.SSO = &argoproj.ArgoCDSSOSpec{Provider: argoproj.SSOProviderTypeDex}
+	url, _ = getSSOLivecheckURL(a)
+	assert.Equal(t, "http://argocd-dex-server.argocd.svc.cluster.local:5556/api/dex/healthz", url)
+
+	a.Spec.SSO = &argoproj.ArgoCDSSOSpec{Provider: argoproj.SSOProviderTypeKeycloak}
+	url, insecure = getSSOLivecheckURL(a)
+	assert.Equal(t, "https://keycloak.argocd.svc.cluster.local:8443/auth/realms/argocd", url)
+	assert.True(t, insecure)
+
+	a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+		Provider: argoproj.SSOProviderTypeExternal,
+		OIDC:     &argoproj.ArgoCDOIDCSpec{Issuer: "https://idp.example.com/", ClientID: "argocd"},
+	}
+	url, insecure = getSSOLivecheckURL(a)
+	assert.Equal(t, "https://idp.example.com/.well-known/openid-configuration", url)
+	assert.False(t, insecure)
+}
+
+func TestReconcileArgoCD_reconcileStatusSSOLivecheck(t *testing.T) {
+	var probeErr error
+	probed := stubProbeSSOProvider(t, &probeErr)
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{Provider: argoproj.SSOProviderTypeDex}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// Dex is not probed until it runs
+	a.Status.SSO = "Pending"
+	assert.NoError(t, r.reconcileStatusSSOLivecheck(a))
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeSSOReachable)
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonSSOProviderNotRunning, condition.Reason)
+	assert.Empty(t, *probed)
+
+	a.Status.SSO = "Running"
+	assert.NoError(t, r.reconcileStatusSSOLivecheck(a))
+	assert.True(t, meta.IsStatusConditionTrue(a.Status.Conditions, argoproj.ArgoCDConditionTypeSSOReachable))
+	assert.Len(t, *probed, 1)
+	assert.True(t, getSSOLivecheckDelay(a) <= common.ArgoCDDefaultSSOLivecheckInterval)
+
+	// The result is kept until the interval elapses
+	probeErr = errors.New("connection refused")
+	assert.NoError(t, r.reconcileStatusSSOLivecheck(a))
+	assert.True(t, meta.IsStatusConditionTrue(a.Status.Conditions, argoproj.ArgoCDConditionTypeSSOReachable))
+	assert.Len(t, *probed, 1)
+
+	// A new provider is probed right away, and failures are reported with their reason
+	a.Spec.SSO = nil
+	a.Spec.OIDCConfig = "issuer: https://idp.example.com"
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileStatusSSOLivecheck(a))
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeSSOReachable)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, argoproj.ArgoCDConditionReasonSSOProviderUnreachable, condition.Reason)
+	assert.Contains(t, condition.Message, "connection refused")
+	assert.Len(t, *probed, 2)
+
+	// The condition is removed with the provider
+	a.Spec.OIDCConfig = ""
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileStatusSSOLivecheck(a))
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionTypeSSOReachable))
+	assert.Equal(t, time.Duration(0), getSSOLivecheckDelay(a))
+}
@@ -30,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -207,7 +208,69 @@ func TestReconcile_illegalSSOConfiguration(t *testing.T) {
 				}
 			}),
 			wantErr:                  true,
-			Err:                      errors.New("illegal SSO configuration: Unsupported SSO provider type. Supported providers are dex and keycloak"),
+			Err:                      errors.New("illegal SSO configuration: Unsupported SSO provider type. Supported providers are dex, keycloak and external"),
+			wantSSOConfigLegalStatus: "Failed",
+		},
+		{
+			name: "no conflict - valid external sso configurations",
+			argoCD: makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+				ac.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+					Provider: argoproj.SSOProviderTypeExternal,
+					OIDC: &argoproj.ArgoCDOIDCSpec{
+						Issuer:   "https://idp.example.com",
+						ClientID: "argocd",
+					},
+				}
+			}),
+			wantErr:                  false,
+			wantSSOConfigLegalStatus: "Success",
+		},
+		{
+			name: "sso provider external but no issuer provided",
+			argoCD: makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+				ac.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+					Provider: argoproj.SSOProviderTypeExternal,
+					OIDC: &argoproj.ArgoCDOIDCSpec{
+						ClientID: "argocd",
+					},
+				}
+			}),
+			wantErr:                  true,
+			Err:                      errors.New("illegal SSO configuration: must supply an issuer and a client ID in .spec.sso.oidc when requested SSO provider is external"),
+			wantSSOConfigLegalStatus: "Failed",
+		},
+		{
+			name: "sso provider external + `.spec.oidcConfig`",
+			argoCD: makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+				ac.Spec.OIDCConfig = "test-config"
+				ac.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+					Provider: argoproj.SSOProviderTypeExternal,
+					OIDC: &argoproj.ArgoCDOIDCSpec{
+						Issuer:   "https://idp.example.com",
+						ClientID: "argocd",
+					},
+				}
+			}),
+			wantErr:                  true,
+			Err:                      errors.New("illegal SSO configuration: cannot supply .spec.oidcConfig when requested SSO provider is external"),
+			wantSSOConfigLegalStatus: "Failed",
+		},
+		{
+			name: "sso provider dex + `.spec.sso.oidc`",
+			argoCD: makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+				ac.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+					Provider: argoproj.SSOProviderTypeDex,
+					Dex: &argoproj.ArgoCDDexSpec{
+						Config: "test",
+					},
+					OIDC: &argoproj.ArgoCDOIDCSpec{
+						Issuer:   "https://idp.example.com",
+						ClientID: "argocd",
+					},
+				}
+			}),
+			wantErr:                  true,
+			Err:                      errors.New("illegal SSO configuration: cannot supply external OIDC configuration in .spec.sso.oidc when requested SSO provider is dex"),
 			wantSSOConfigLegalStatus: "Failed",
 		},
 	}
@@ -518,3 +581,37 @@ func TestReconcile_testKeycloakRouteHost(t *testing.T) {
 	assert.True(t, matchFound)
 
 }
+
+func TestReconcileArgoCD_reconcileResources_coreSSO(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex:      &argoproj.ArgoCDDexSpec{Config: "test-config"},
+		}
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	assert.NoError(t, r.reconcileSSO(a))
+	dexKey := types.NamespacedName{Name: "argocd-dex-server", Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), dexKey, &k8sappsv1.Deployment{}))
+
+	// Dex is skipped in core mode rather than failing the reconciliation
+	a.Spec.InstallationMode = argoproj.ArgoCDInstallationModeCore
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileResources(a))
+
+	err := r.Client.Get(context.TODO(), dexKey, &k8sappsv1.Deployment{})
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.Equal(t, ssoLegalUnknown, a.Status.SSO)
+	assert.Contains(t, <-recorder.Events, corev1.EventTypeWarning+" SSOSkipped")
+}
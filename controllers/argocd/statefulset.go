@@ -91,6 +91,54 @@ func newStatefulSetWithSuffix(suffix string, component string, cr *argoproj.Argo
 	return newStatefulSetWithName(fmt.Sprintf("%s-%s", cr.Name, suffix), component, cr)
 }
 
+// getRedisExporterContainer will return the Prometheus exporter sidecar container of the Redis HA server pods, which
+// scrapes the local Redis server.
+func getRedisExporterContainer(cr *argoproj.ArgoCD, useTLSForRedis bool) corev1.Container {
+	env := []corev1.EnvVar{
+		{
+			Name:  "REDIS_ADDR",
+			Value: fmt.Sprintf("redis://localhost:%d", getRedisPort(cr)),
+		},
+		{
+			Name: "REDIS_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: fmt.Sprintf("%s-%s", cr.Name, "redis-initial-password"),
+					},
+					Key: "admin.password",
+				},
+			},
+		},
+	}
+	if useTLSForRedis {
+		// The certificate of the Redis server is issued for its Service, not for localhost
+		env[0].Value = fmt.Sprintf("rediss://localhost:%d", getRedisPort(cr))
+		env = append(env, corev1.EnvVar{Name: "REDIS_EXPORTER_SKIP_TLS_VERIFICATION", Value: "true"})
+	}
+
+	return corev1.Container{
+		Env:             env,
+		Image:           getRedisExporterContainerImage(cr),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Name:            "metrics",
+		Ports: []corev1.ContainerPort{{
+			ContainerPort: common.ArgoCDDefaultRedisExporterPort,
+			Name:          "metrics",
+		}},
+		Resources: getRedisExporterResources(cr),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{
+					"ALL",
+				},
+			},
+			RunAsNonRoot: boolPtr(true),
+		},
+	}
+}
+
 func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 	ss := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
 
@@ -170,8 +218,9 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 			},
 			Name: "redis",
 			Ports: []corev1.ContainerPort{{
-				ContainerPort: common.ArgoCDDefaultRedisPort,
+				ContainerPort: getRedisPort(cr),
 				Name:          "redis",
+				Protocol:      corev1.ProtocolTCP,
 			}},
 			ReadinessProbe: &corev1.Probe{
 				ProbeHandler: corev1.ProbeHandler{
@@ -242,8 +291,9 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 			},
 			Name: "sentinel",
 			Ports: []corev1.ContainerPort{{
-				ContainerPort: common.ArgoCDDefaultRedisSentinelPort,
+				ContainerPort: getRedisSentinelPort(cr),
 				Name:          "sentinel",
+				Protocol:      corev1.ProtocolTCP,
 			}},
 			ReadinessProbe: &corev1.Probe{
 				ProbeHandler: corev1.ProbeHandler{
@@ -323,7 +373,7 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 		Image:           getRedisHAContainerImage(cr),
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		Name:            "config-init",
-		Resources:       getRedisHAResources(cr),
+		Resources:       getRedisInitResources(cr),
 		SecurityContext: &corev1.SecurityContext{
 			AllowPrivilegeEscalation: boolPtr(false),
 			Capabilities: &corev1.Capabilities{
@@ -360,11 +410,17 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 		RunAsUser:    &runAsUser,
 	}
 	AddSeccompProfileForOpenShift(r.Client, &ss.Spec.Template.Spec)
+	applySecurityContext(&ss.Spec.Template.Spec, cr.Spec.Redis.PodSecurityContext, cr.Spec.Redis.SecurityContext, "redis", "sentinel")
+
+	if isRedisExporterEnabled(cr) {
+		ss.Spec.Template.Spec.Containers = append(ss.Spec.Template.Spec.Containers, getRedisExporterContainer(cr, r.redisShouldUseTLS(cr)))
+	}
 
 	ss.Spec.Template.Spec.ServiceAccountName = nameWithSuffix("argocd-redis-ha", cr)
 
 	var terminationGracePeriodSeconds int64 = 60
 	ss.Spec.Template.Spec.TerminationGracePeriodSeconds = &terminationGracePeriodSeconds
+	applyTerminationGracePeriod(&ss.Spec.Template.Spec, cr.Spec.Redis.TerminationGracePeriodSeconds)
 
 	var defaultMode int32 = 493
 	ss.Spec.Template.Spec.Volumes = []corev1.Volume{
@@ -406,6 +462,8 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 		},
 	}
 
+	applyLogSidecar(cr, &ss.Spec.Template.Spec, "redis", "sentinel")
+
 	ss.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
 		Type: appsv1.RollingUpdateStatefulSetStrategyType,
 	}
@@ -416,7 +474,7 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 
 	existing := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		if !(cr.Spec.HA.Enabled && cr.Spec.Redis.IsEnabled()) {
+		if !(wantsRedisHA(cr) && cr.Spec.Redis.IsEnabled()) {
 			// StatefulSet exists but either HA or component enabled flag has been set to false, delete the StatefulSet
 			return r.Client.Delete(context.TODO(), existing)
 		}
@@ -424,7 +482,14 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 		desiredImage := getRedisHAContainerImage(cr)
 		changed := false
 		updateNodePlacementStateful(existing, ss, &changed)
+		updateSecurityContext(&existing.Spec.Template.Spec, &ss.Spec.Template.Spec, &changed)
+		updateTerminationGracePeriod(&existing.Spec.Template.Spec, &ss.Spec.Template.Spec, &changed)
+		updateLogSidecar(&existing.Spec.Template.Spec, &ss.Spec.Template.Spec, &changed)
+		updateRedisExporter(&existing.Spec.Template.Spec, &ss.Spec.Template.Spec, &changed)
 		for i, container := range existing.Spec.Template.Spec.Containers {
+			if container.Name == logSidecarName || container.Name == "metrics" {
+				continue // Synced by updateLogSidecar and updateRedisExporter
+			}
 			if container.Image != desiredImage {
 				existing.Spec.Template.Spec.Containers[i].Image = getRedisHAContainerImage(cr)
 				existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
@@ -435,6 +500,11 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 				existing.Spec.Template.Spec.Containers[i].Resources = ss.Spec.Template.Spec.Containers[i].Resources
 				changed = true
 			}
+
+			if !reflect.DeepEqual(ss.Spec.Template.Spec.Containers[i].Ports, existing.Spec.Template.Spec.Containers[i].Ports) {
+				existing.Spec.Template.Spec.Containers[i].Ports = ss.Spec.Template.Spec.Containers[i].Ports
+				changed = true
+			}
 		}
 
 		if !reflect.DeepEqual(ss.Spec.Template.Spec.InitContainers[0].Resources, existing.Spec.Template.Spec.InitContainers[0].Resources) {
@@ -450,16 +520,16 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 	}
 
 	if cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "" {
-		log.Info("Custom Redis Endpoint. Skipping starting redis.")
+		componentLog(cr, logComponentRedis).Info("Custom Redis Endpoint. Skipping starting redis.")
 		return nil
 	}
 
 	if !cr.Spec.Redis.IsEnabled() {
-		log.Info("Redis disabled. Skipping starting Redis.") // Redis not enabled, do nothing.
+		componentLog(cr, logComponentRedis).Info("Redis disabled. Skipping starting Redis.") // Redis not enabled, do nothing.
 		return nil
 	}
 
-	if !cr.Spec.HA.Enabled {
+	if !wantsRedisHA(cr) {
 		return nil // HA not enabled, do nothing.
 	}
 
@@ -469,6 +539,38 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoproj.ArgoCD) error {
 	return r.Client.Create(context.TODO(), ss)
 }
 
+// updateRedisExporter will update the Redis exporter sidecar container of the given existing pod spec to match the
+// desired pod spec, adding or removing it as needed.
+func updateRedisExporter(existing, desired *corev1.PodSpec, changed *bool) {
+	find := func(spec *corev1.PodSpec) int {
+		for i := range spec.Containers {
+			if spec.Containers[i].Name == "metrics" {
+				return i
+			}
+		}
+		return -1
+	}
+
+	e, d := find(existing), find(desired)
+	switch {
+	case e < 0 && d < 0:
+		return
+	case e < 0:
+		existing.Containers = append(existing.Containers, desired.Containers[d])
+	case d < 0:
+		existing.Containers = append(existing.Containers[:e], existing.Containers[e+1:]...)
+	case !reflect.DeepEqual(existing.Containers[e].Image, desired.Containers[d].Image) ||
+		!reflect.DeepEqual(existing.Containers[e].Env, desired.Containers[d].Env) ||
+		!reflect.DeepEqual(existing.Containers[e].Resources, desired.Containers[d].Resources):
+		existing.Containers[e].Image = desired.Containers[d].Image
+		existing.Containers[e].Env = desired.Containers[d].Env
+		existing.Containers[e].Resources = desired.Containers[d].Resources
+	default:
+		return
+	}
+	*changed = true
+}
+
 func getArgoControllerContainerEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
 	env := make([]corev1.EnvVar, 0)
 
@@ -496,6 +598,13 @@ func getArgoControllerContainerEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
 		})
 	}
 
+	if cr.Spec.Controller.Sharding.Algorithm != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "ARGOCD_CONTROLLER_SHARDING_ALGORITHM",
+			Value: cr.Spec.Controller.Sharding.Algorithm,
+		})
+	}
+
 	if cr.Spec.Controller.AppSync != nil {
 		env = append(env, corev1.EnvVar{
 			Name:  "ARGOCD_RECONCILIATION_TIMEOUT",
@@ -503,9 +612,130 @@ func getArgoControllerContainerEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
 		})
 	}
 
+	env = append(env, getArgoControllerClusterCacheEnv(cr)...)
+
+	return env
+}
+
+// getArgoControllerClusterCacheEnv will return the environment variables tuning the cluster cache of the Application
+// Controller that are set on the given ArgoCD.
+func getArgoControllerClusterCacheEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
+	clusterCache := cr.Spec.Controller.ClusterCache
+	if clusterCache == nil {
+		return nil
+	}
+
+	env := make([]corev1.EnvVar, 0)
+	for _, v := range []struct {
+		name  string
+		value *int64
+	}{
+		{"ARGOCD_CLUSTER_CACHE_LIST_PAGE_SIZE", clusterCache.ListPageSize},
+		{"ARGOCD_CLUSTER_CACHE_LIST_SEMAPHORE", clusterCache.ListSemaphore},
+	} {
+		if v.value != nil {
+			env = append(env, corev1.EnvVar{Name: v.name, Value: strconv.FormatInt(*v.value, 10)})
+		}
+	}
+	for _, v := range []struct {
+		name  string
+		value *int32
+	}{
+		{"ARGOCD_CLUSTER_CACHE_ATTEMPTS", clusterCache.Attempts},
+		{"ARGOCD_CLUSTER_CACHE_LIST_PAGE_BUFFER_SIZE", clusterCache.ListPageBufferSize},
+	} {
+		if v.value != nil {
+			env = append(env, corev1.EnvVar{Name: v.name, Value: fmt.Sprint(*v.value)})
+		}
+	}
+	for _, v := range []struct {
+		name     string
+		duration *metav1.Duration
+	}{
+		{"ARGOCD_CLUSTER_CACHE_RESYNC_DURATION", clusterCache.ResyncDuration},
+		{"ARGOCD_CLUSTER_CACHE_WATCH_RESYNC_DURATION", clusterCache.WatchResyncDuration},
+		{"ARGOCD_CLUSTER_SYNC_RETRY_TIMEOUT_DURATION", clusterCache.SyncRetryTimeout},
+	} {
+		if v.duration != nil {
+			env = append(env, corev1.EnvVar{Name: v.name, Value: v.duration.Duration.String()})
+		}
+	}
+	if clusterCache.RetryUseBackoff != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "ARGOCD_CLUSTER_CACHE_RETRY_USE_BACKOFF",
+			Value: strconv.FormatBool(*clusterCache.RetryUseBackoff),
+		})
+	}
 	return env
 }
 
+// isControllerShardingEnabled will return true if static or dynamic sharding is enabled for the application controller.
+func isControllerShardingEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Controller.Sharding.Enabled ||
+		(cr.Spec.Controller.Sharding.DynamicScalingEnabled != nil && *cr.Spec.Controller.Sharding.DynamicScalingEnabled)
+}
+
+// getApplicationControllerAffinity will return the pod affinity for the application controller StatefulSet.
+// When sharding is enabled, the shards are spread across nodes according to the anti-affinity mode of the sharding spec.
+func getApplicationControllerAffinity(cr *argoproj.ArgoCD) *corev1.Affinity {
+	partOfTerm := corev1.WeightedPodAffinityTerm{
+		PodAffinityTerm: corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					common.ArgoCDKeyPartOf: common.ArgoCDAppName,
+				},
+			},
+			TopologyKey: common.ArgoCDKeyHostname,
+		},
+		Weight: int32(5),
+	}
+
+	if !isControllerShardingEnabled(cr) {
+		return &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								common.ArgoCDKeyName: nameWithSuffix("argocd-application-controller", cr),
+							},
+						},
+						TopologyKey: common.ArgoCDKeyHostname,
+					},
+					Weight: int32(100),
+				}, partOfTerm},
+			},
+		}
+	}
+
+	shardTerm := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				common.ArgoCDKeyName: nameWithSuffix("application-controller", cr),
+			},
+		},
+		TopologyKey: common.ArgoCDKeyHostname,
+	}
+
+	if cr.Spec.Controller.Sharding.AntiAffinityMode == argoproj.ShardAntiAffinityModeHard {
+		return &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution:  []corev1.PodAffinityTerm{shardTerm},
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{partOfTerm},
+			},
+		}
+	}
+
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+				PodAffinityTerm: shardTerm,
+				Weight:          int32(100),
+			}, partOfTerm},
+		},
+	}
+}
+
 func (r *ReconcileArgoCD) getApplicationControllerReplicaCount(cr *argoproj.ArgoCD) int32 {
 	var replicas int32 = common.ArgocdApplicationControllerDefaultReplicas
 	var minShards int32 = cr.Spec.Controller.Sharding.MinShards
@@ -515,25 +745,25 @@ func (r *ReconcileArgoCD) getApplicationControllerReplicaCount(cr *argoproj.Argo
 
 		// TODO: add the same validations to Validation Webhook once webhook has been introduced
 		if minShards < 1 {
-			log.Info("Minimum number of shards cannot be less than 1. Setting default value to 1")
+			instanceLog(cr).Info("Minimum number of shards cannot be less than 1. Setting default value to 1")
 			minShards = 1
 		}
 
 		if maxShards < minShards {
-			log.Info("Maximum number of shards cannot be less than minimum number of shards. Setting maximum shards same as minimum shards")
+			instanceLog(cr).Info("Maximum number of shards cannot be less than minimum number of shards. Setting maximum shards same as minimum shards")
 			maxShards = minShards
 		}
 
 		clustersPerShard := cr.Spec.Controller.Sharding.ClustersPerShard
 		if clustersPerShard < 1 {
-			log.Info("clustersPerShard cannot be less than 1. Defaulting to 1.")
+			instanceLog(cr).Info("clustersPerShard cannot be less than 1. Defaulting to 1.")
 			clustersPerShard = 1
 		}
 
 		clusterSecrets, err := r.getClusterSecrets(cr)
 		if err != nil {
 			// If we were not able to query cluster secrets, return the default count of replicas (ArgocdApplicationControllerDefaultReplicas)
-			log.Error(err, "Error retreiving cluster secrets for ArgoCD instance %s", cr.Name)
+			instanceLog(cr).Error(err, "Error retreiving cluster secrets for ArgoCD instance %s", cr.Name)
 			return replicas
 		}
 
@@ -567,6 +797,9 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 	controllerEnv = argoutil.EnvMerge(controllerEnv, getArgoControllerContainerEnv(cr), true)
 	// Let user specify their own environment first
 	controllerEnv = argoutil.EnvMerge(controllerEnv, proxyEnvVars(), false)
+	controllerEnv = argoutil.EnvMerge(controllerEnv, getOTelResourceAttributesEnv(cr), false)
+	controllerEnv = argoutil.EnvMerge(controllerEnv, getCryptoPolicyEnv(cr), false)
+	controllerEnv = argoutil.EnvMerge(controllerEnv, getRepoGRPCMaxMessageSizeEnv(cr), false)
 
 	if cr.Spec.Controller.InitContainers != nil {
 		ss.Spec.Template.Spec.InitContainers = append(ss.Spec.Template.Spec.InitContainers, cr.Spec.Controller.InitContainers...)
@@ -583,6 +816,10 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 		},
 	}
 
+	if isApplicationControllerPersistenceEnabled(cr) {
+		controllerVolumeMounts = append(controllerVolumeMounts, getApplicationControllerPersistenceVolumeMount(cr))
+	}
+
 	if cr.Spec.Controller.VolumeMounts != nil {
 		controllerVolumeMounts = append(controllerVolumeMounts, cr.Spec.Controller.VolumeMounts...)
 	}
@@ -627,6 +864,9 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 	}
 
 	AddSeccompProfileForOpenShift(r.Client, podSpec)
+	applySecurityContext(podSpec, cr.Spec.Controller.PodSecurityContext, cr.Spec.Controller.SecurityContext, "argocd-application-controller")
+	applyApplicationControllerGracefulShutdown(cr, podSpec)
+	applyDNSConfig(podSpec, cr.Spec.Controller.DNSPolicy, cr.Spec.Controller.DNSConfig)
 	podSpec.ServiceAccountName = nameWithSuffix("argocd-application-controller", cr)
 
 	controllerVolumes := []corev1.Volume{
@@ -655,38 +895,14 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 	}
 
 	podSpec.Volumes = controllerVolumes
+	ss.Spec.VolumeClaimTemplates = getApplicationControllerVolumeClaimTemplates(cr)
 
-	ss.Spec.Template.Spec.Affinity = &corev1.Affinity{
-		PodAntiAffinity: &corev1.PodAntiAffinity{
-			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
-				PodAffinityTerm: corev1.PodAffinityTerm{
-					LabelSelector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							common.ArgoCDKeyName: nameWithSuffix("argocd-application-controller", cr),
-						},
-					},
-					TopologyKey: common.ArgoCDKeyHostname,
-				},
-				Weight: int32(100),
-			},
-				{
-					PodAffinityTerm: corev1.PodAffinityTerm{
-						LabelSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{
-								common.ArgoCDKeyPartOf: common.ArgoCDAppName,
-							},
-						},
-						TopologyKey: common.ArgoCDKeyHostname,
-					},
-					Weight: int32(5),
-				}},
-		},
-	}
+	ss.Spec.Template.Spec.Affinity = getApplicationControllerAffinity(cr)
 
 	// Handle import/restore from ArgoCDExport
 	export := r.getArgoCDExport(cr)
 	if export == nil {
-		log.Info("existing argocd export not found, skipping import")
+		instanceLog(cr).Info("existing argocd export not found, skipping import")
 	} else {
 		podSpec.InitContainers = []corev1.Container{{
 			Command:         getArgoImportCommand(r.Client, cr),
@@ -709,6 +925,8 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 
 		podSpec.Volumes = getArgoImportVolumes(export)
 	}
+	applyLogSidecar(cr, podSpec, "argocd-application-controller")
+	applyPodMetadata(&ss.Spec.Template, cr.Spec.Controller.PodLabels, cr.Spec.Controller.PodAnnotations)
 
 	invalidImagePod := containsInvalidImage(cr, r)
 	if invalidImagePod {
@@ -720,10 +938,16 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 	existing := newStatefulSetWithSuffix("application-controller", "application-controller", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 		if !cr.Spec.Controller.IsEnabled() {
-			log.Info("Existing application controller found but should be disabled. Deleting Application Controller")
+			instanceLog(cr).Info("Existing application controller found but should be disabled. Deleting Application Controller")
 			// Delete existing deployment for Application Controller, if any ..
 			return r.Client.Delete(context.TODO(), existing)
 		}
+		if volumeClaimTemplatesChanged(existing.Spec.VolumeClaimTemplates, ss.Spec.VolumeClaimTemplates) {
+			// The volume claim templates of a StatefulSet are immutable. Orphan the pods, so that they keep running
+			// until adopted by the StatefulSet recreated with the new templates on the next reconciliation.
+			instanceLog(cr).Info("Volume claim templates of the application controller changed. Recreating Application Controller")
+			return r.Client.Delete(context.TODO(), existing, client.PropagationPolicy(metav1.DeletePropagationOrphan))
+		}
 		actualImage := existing.Spec.Template.Spec.Containers[0].Image
 		desiredImage := getArgoContainerImage(cr)
 		changed := false
@@ -736,7 +960,14 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 		if isRepoServerTLSVerificationRequested(cr) {
 			desiredCommand = append(desiredCommand, "--repo-server-strict-tls")
 		}
+		desiredCommand = getLogSidecarCommand(cr, "argocd-application-controller", desiredCommand)
+		ss.Spec.Template.Spec.Containers[0].Command = desiredCommand
 		updateNodePlacementStateful(existing, ss, &changed)
+		updateSecurityContext(&existing.Spec.Template.Spec, &ss.Spec.Template.Spec, &changed)
+		updateTerminationGracePeriod(&existing.Spec.Template.Spec, &ss.Spec.Template.Spec, &changed)
+		updateDNSConfig(&existing.Spec.Template.Spec, &ss.Spec.Template.Spec, &changed)
+		updateLogSidecar(&existing.Spec.Template.Spec, &ss.Spec.Template.Spec, &changed)
+		updatePodMetadata(&existing.Spec.Template, &ss.Spec.Template, &changed)
 		if !reflect.DeepEqual(desiredCommand, existing.Spec.Template.Spec.Containers[0].Command) {
 			existing.Spec.Template.Spec.Containers[0].Command = desiredCommand
 			changed = true
@@ -763,10 +994,19 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 			existing.Spec.Template.Spec.Containers[0].Resources = ss.Spec.Template.Spec.Containers[0].Resources
 			changed = true
 		}
+		if !reflect.DeepEqual(ss.Spec.Template.Spec.Containers[0].Lifecycle, existing.Spec.Template.Spec.Containers[0].Lifecycle) {
+			existing.Spec.Template.Spec.Containers[0].Lifecycle = ss.Spec.Template.Spec.Containers[0].Lifecycle
+			changed = true
+		}
+		ss.Spec.Replicas = getBudgetedReplicas(cr, existing.Spec.Replicas, ss.Spec.Replicas)
 		if !reflect.DeepEqual(ss.Spec.Replicas, existing.Spec.Replicas) {
 			existing.Spec.Replicas = ss.Spec.Replicas
 			changed = true
 		}
+		if !reflect.DeepEqual(ss.Spec.Template.Spec.Affinity, existing.Spec.Template.Spec.Affinity) {
+			existing.Spec.Template.Spec.Affinity = ss.Spec.Template.Spec.Affinity
+			changed = true
+		}
 
 		if !reflect.DeepEqual(ss.Spec.Template.Spec.Containers[1:],
 			existing.Spec.Template.Spec.Containers[1:]) {
@@ -782,7 +1022,7 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 	}
 
 	if !cr.Spec.Controller.IsEnabled() {
-		log.Info("Application Controller disabled. Skipping starting application controller.")
+		instanceLog(cr).Info("Application Controller disabled. Skipping starting application controller.")
 		return nil
 	}
 
@@ -800,6 +1040,24 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 	return r.Client.Create(context.TODO(), ss)
 }
 
+// applyApplicationControllerGracefulShutdown will set the termination grace period of the Application Controller pods,
+// along with a pre-stop hook delaying the SIGTERM of the controller when a pre-stop delay is requested, so that the
+// sync operations in progress are not aborted mid-apply when the pods are evicted.
+func applyApplicationControllerGracefulShutdown(cr *argoproj.ArgoCD, podSpec *corev1.PodSpec) {
+	delay := cr.Spec.Controller.PreStopDelaySeconds
+	if delay != nil && *delay > 0 {
+		podSpec.Containers[0].Lifecycle = &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"sleep", strconv.FormatInt(*delay, 10)},
+				},
+			},
+		}
+		podSpec.TerminationGracePeriodSeconds = int64Ptr(*delay + corev1.DefaultTerminationGracePeriodSeconds)
+	}
+	applyTerminationGracePeriod(podSpec, cr.Spec.Controller.TerminationGracePeriodSeconds)
+}
+
 // reconcileStatefulSets will ensure that all StatefulSets are present for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatefulSets(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
 	if err := r.reconcileApplicationControllerStatefulSet(cr, useTLSForRedis); err != nil {
@@ -844,7 +1102,7 @@ func containsInvalidImage(cr *argoproj.ArgoCD, r *ReconcileArgoCD) bool {
 	listOption := client.MatchingLabels{common.ArgoCDKeyName: fmt.Sprintf("%s-%s", cr.Name, "application-controller")}
 
 	if err := r.Client.List(context.TODO(), podList, listOption); err != nil {
-		log.Error(err, "Failed to list Pods")
+		instanceLog(cr).Error(err, "Failed to list Pods")
 	}
 	if len(podList.Items) > 0 {
 		if len(podList.Items[0].Status.ContainerStatuses) > 0 {
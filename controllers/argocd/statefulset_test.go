@@ -3,9 +3,11 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/errors"
 	resourcev1 "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -136,6 +138,58 @@ func TestReconcileArgoCD_reconcileRedisStatefulSet_HA_enabled(t *testing.T) {
 	assert.Errorf(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s), "not found")
 }
 
+func TestReconcileArgoCD_reconcileRedisStatefulSet_HA_exporter(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	s := newStatefulSetWithSuffix("redis-ha-server", "redis", a)
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+	assert.Len(t, s.Spec.Template.Spec.Containers, 2)
+
+	// test the exporter is added, with its own resources
+	exporterResources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resourcev1.MustParse("64Mi"),
+			corev1.ResourceCPU:    resourcev1.MustParse("100m"),
+		},
+	}
+	initResources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resourcev1.MustParse("32Mi"),
+			corev1.ResourceCPU:    resourcev1.MustParse("50m"),
+		},
+	}
+	a.Spec.Redis.Exporter = &argoproj.ArgoCDRedisExporterSpec{Enabled: true, Resources: &exporterResources}
+	a.Spec.Redis.InitResources = &initResources
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+	assert.Len(t, s.Spec.Template.Spec.Containers, 3)
+	exporter := s.Spec.Template.Spec.Containers[2]
+	assert.Equal(t, "metrics", exporter.Name)
+	assert.Equal(t, fmt.Sprintf("%s:%s", common.ArgoCDDefaultRedisExporterImage, common.ArgoCDDefaultRedisExporterVersion), exporter.Image)
+	assert.Equal(t, exporterResources, exporter.Resources)
+	assert.Equal(t, int32(common.ArgoCDDefaultRedisExporterPort), exporter.Ports[0].ContainerPort)
+	assert.Equal(t, initResources, s.Spec.Template.Spec.InitContainers[0].Resources)
+	assert.Equal(t, corev1.ResourceRequirements{}, s.Spec.Template.Spec.Containers[0].Resources)
+
+	// test the exporter is removed once disabled
+	a.Spec.Redis.Exporter.Enabled = false
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+	assert.Len(t, s.Spec.Template.Spec.Containers, 2)
+}
+
 func TestReconcileArgoCD_reconcileApplicationController(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
@@ -452,6 +506,47 @@ func TestReconcileArgoCD_reconcileApplicationController_withSharding(t *testing.
 	}
 }
 
+func TestReconcileArgoCD_reconcileApplicationController_withShardingAntiAffinity(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Controller.Sharding = argoproj.ArgoCDApplicationControllerShardSpec{
+			Enabled:  true,
+			Replicas: 3,
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, ss))
+
+	// soft is the default mode
+	antiAffinity := ss.Spec.Template.Spec.Affinity.PodAntiAffinity
+	assert.Empty(t, antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+	assert.Equal(t, int32(100), antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Weight)
+	assert.Equal(t, "argocd-application-controller",
+		antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm.LabelSelector.MatchLabels[common.ArgoCDKeyName])
+
+	a.Spec.Controller.Sharding.AntiAffinityMode = argoproj.ShardAntiAffinityModeHard
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, ss))
+
+	antiAffinity = ss.Spec.Template.Spec.Affinity.PodAntiAffinity
+	assert.Len(t, antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, 1)
+	assert.Equal(t, "argocd-application-controller",
+		antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].LabelSelector.MatchLabels[common.ArgoCDKeyName])
+	assert.Equal(t, common.ArgoCDKeyHostname, antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].TopologyKey)
+	assert.Len(t, antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 1)
+}
+
 func TestReconcileArgoCD_reconcileApplicationController_withAppSync(t *testing.T) {
 
 	expectedEnv := []corev1.EnvVar{
@@ -499,6 +594,98 @@ func TestReconcileArgoCD_reconcileApplicationController_withAppSync(t *testing.T
 	}
 }
 
+func TestReconcileArgoCD_reconcileApplicationController_withClusterCache(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	attempts := int32(5)
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Controller.Env = []corev1.EnvVar{
+			{Name: "ARGOCD_CLUSTER_CACHE_LIST_PAGE_SIZE", Value: "1000"},
+		}
+		a.Spec.Controller.ClusterCache = &argoproj.ArgoCDApplicationControllerClusterCacheSpec{
+			Attempts:         &attempts,
+			ListPageSize:     int64Ptr(100),
+			ListSemaphore:    int64Ptr(10),
+			ResyncDuration:   &metav1.Duration{Duration: time.Hour * 6},
+			RetryUseBackoff:  boolPtr(true),
+			SyncRetryTimeout: &metav1.Duration{Duration: time.Second * 30},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, ss))
+
+	env := map[string]string{}
+	for _, e := range ss.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	// The typed fields override the environment set by the user
+	assert.Equal(t, "100", env["ARGOCD_CLUSTER_CACHE_LIST_PAGE_SIZE"])
+	assert.Equal(t, "10", env["ARGOCD_CLUSTER_CACHE_LIST_SEMAPHORE"])
+	assert.Equal(t, "5", env["ARGOCD_CLUSTER_CACHE_ATTEMPTS"])
+	assert.Equal(t, "6h0m0s", env["ARGOCD_CLUSTER_CACHE_RESYNC_DURATION"])
+	assert.Equal(t, "30s", env["ARGOCD_CLUSTER_SYNC_RETRY_TIMEOUT_DURATION"])
+	assert.Equal(t, "true", env["ARGOCD_CLUSTER_CACHE_RETRY_USE_BACKOFF"])
+	assert.NotContains(t, env, "ARGOCD_CLUSTER_CACHE_LIST_PAGE_BUFFER_SIZE")
+	assert.NotContains(t, env, "ARGOCD_CLUSTER_CACHE_WATCH_RESYNC_DURATION")
+}
+
+func TestReconcileArgoCD_reconcileApplicationController_withTimeouts(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Controller.AppHardResync = &metav1.Duration{Duration: time.Hour}
+		a.Spec.Controller.SelfHealTimeout = &metav1.Duration{Duration: time.Second * 30}
+		a.Spec.Controller.RepoServerTimeout = &metav1.Duration{Duration: time.Minute * 3}
+		a.Spec.Controller.RepoErrorGracePeriod = &metav1.Duration{Duration: time.Minute * 5}
+		a.Spec.Controller.StatusCacheExpiration = &metav1.Duration{Duration: time.Minute * 30}
+		a.Spec.Controller.DefaultCacheExpiration = &metav1.Duration{Duration: time.Hour * 12}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, ss))
+
+	command := strings.Join(ss.Spec.Template.Spec.Containers[0].Command, " ")
+	for _, arg := range []string{
+		"--app-hard-resync 3600",
+		"--self-heal-timeout-seconds 30",
+		"--repo-server-timeout-seconds 180",
+		"--repo-error-grace-period-seconds 300",
+		"--app-state-cache-expiration 30m0s",
+		"--default-cache-expiration 12h0m0s",
+	} {
+		assert.Contains(t, command, arg)
+	}
+
+	// Changes to the timeouts are synced to the StatefulSet
+	a.Spec.Controller.SelfHealTimeout = &metav1.Duration{Duration: time.Minute}
+	a.Spec.Controller.DefaultCacheExpiration = nil
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, ss))
+
+	command = strings.Join(ss.Spec.Template.Spec.Containers[0].Command, " ")
+	assert.Contains(t, command, "--self-heal-timeout-seconds 60")
+	assert.NotContains(t, command, "--default-cache-expiration")
+}
+
 func TestReconcileArgoCD_reconcileApplicationController_withEnv(t *testing.T) {
 
 	expectedEnv := []corev1.EnvVar{
@@ -549,6 +736,89 @@ func TestReconcileArgoCD_reconcileApplicationController_withEnv(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_reconcileApplicationController_withPersistence(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Controller.Persistence = &argoproj.ArgoCDApplicationControllerPersistenceSpec{Enabled: true}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+	key := types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+	assert.Len(t, ss.Spec.VolumeClaimTemplates, 1)
+	claim := ss.Spec.VolumeClaimTemplates[0]
+	assert.Equal(t, "persistence", claim.Name)
+	assert.Equal(t, resourcev1.MustParse(common.ArgoCDDefaultControllerPersistenceCapacity), claim.Spec.Resources.Requests[corev1.ResourceStorage])
+	assert.Contains(t, ss.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "persistence",
+		MountPath: common.ArgoCDDefaultControllerPersistenceMountPath,
+	})
+
+	// Unchanged templates leave the StatefulSet in place
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+
+	// Changed templates recreate the StatefulSet, as they are immutable
+	size := resourcev1.MustParse("2Gi")
+	a.Spec.Controller.Persistence.Size = &size
+	a.Spec.Controller.Persistence.MountPath = "/cache"
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, ss)))
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+	assert.Equal(t, size, ss.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage])
+	assert.Contains(t, ss.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "persistence", MountPath: "/cache"})
+}
+
+func TestReconcileArgoCD_reconcileApplicationController_withGracefulShutdown(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+
+	key := types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+	assert.Nil(t, ss.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	assert.Nil(t, ss.Spec.Template.Spec.Containers[0].Lifecycle)
+
+	// The pre-stop delay extends the default grace period
+	a.Spec.Controller.PreStopDelaySeconds = int64Ptr(120)
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+	assert.Equal(t, int64(150), *ss.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	assert.Equal(t, []string{"sleep", "120"}, ss.Spec.Template.Spec.Containers[0].Lifecycle.PreStop.Exec.Command)
+
+	a.Spec.Controller.TerminationGracePeriodSeconds = int64Ptr(300)
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+	assert.Equal(t, int64(300), *ss.Spec.Template.Spec.TerminationGracePeriodSeconds)
+
+	a.Spec.Controller.PreStopDelaySeconds = nil
+	a.Spec.Controller.TerminationGracePeriodSeconds = nil
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+	assert.Nil(t, ss.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	assert.Nil(t, ss.Spec.Template.Spec.Containers[0].Lifecycle)
+}
+
 func Test_UpdateNodePlacementStateful(t *testing.T) {
 
 	ss := &appsv1.StatefulSet{
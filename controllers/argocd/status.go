@@ -16,12 +16,15 @@ package argocd
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"strings"
 
 	oappsv1 "github.com/openshift/api/apps/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,6 +32,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
@@ -38,11 +42,19 @@ func (r *ReconcileArgoCD) reconcileStatus(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	if err := r.reconcileStatusControllerShards(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileStatusSSO(cr); err != nil {
-		log.Info(err.Error())
+		instanceLog(cr).Info(err.Error())
 	}
 
-	if err := r.reconcileStatusPhase(cr); err != nil {
+	if err := r.reconcileStatusSSOLivecheck(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusCryptoPolicy(cr); err != nil {
 		return err
 	}
 
@@ -58,10 +70,19 @@ func (r *ReconcileArgoCD) reconcileStatus(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	// The phase is aggregated from the status of the components above
+	if err := r.reconcileStatusPhase(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileStatusHost(cr); err != nil {
 		return err
 	}
 
+	if err := r.reconcileStatusEndpoints(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileStatusNotifications(cr); err != nil {
 		return err
 	}
@@ -75,20 +96,15 @@ func (r *ReconcileArgoCD) reconcileStatus(cr *argoproj.ArgoCD) error {
 
 // reconcileStatusApplicationController will ensure that the ApplicationController Status is updated for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatusApplicationController(cr *argoproj.ArgoCD) error {
-	status := "Unknown"
+	status, message := "Unknown", ""
 
 	ss := newStatefulSetWithSuffix("application-controller", "application-controller", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, ss.Name, ss) {
-		status = "Pending"
-
-		if ss.Spec.Replicas != nil {
-			if ss.Status.ReadyReplicas == *ss.Spec.Replicas {
-				status = "Running"
-			}
-		}
+		status, message = r.getStatefulSetStatus(ss)
 	}
 
-	if cr.Status.ApplicationController != status {
+	messageChanged := setComponentMessage(cr, statusComponentApplicationController, message)
+	if cr.Status.ApplicationController != status || messageChanged {
 		cr.Status.ApplicationController = status
 		return r.Client.Status().Update(context.TODO(), cr)
 	}
@@ -97,28 +113,15 @@ func (r *ReconcileArgoCD) reconcileStatusApplicationController(cr *argoproj.Argo
 
 // reconcileStatusDex will ensure that the Dex status is updated for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatusDex(cr *argoproj.ArgoCD) error {
-	status := "Unknown"
+	status, message := "Unknown", ""
 
 	deploy := newDeploymentWithSuffix("dex-server", "dex-server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
-		status = "Pending"
-
-		if deploy.Spec.Replicas != nil {
-			if deploy.Status.ReadyReplicas == *deploy.Spec.Replicas {
-				status = "Running"
-			} else if deploy.Status.Conditions != nil {
-				for _, condition := range deploy.Status.Conditions {
-					if condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue {
-						// Deployment has failed
-						status = "Failed"
-						break
-					}
-				}
-			}
-		}
+		status, message = r.getDeploymentStatus(deploy)
 	}
 
-	if cr.Status.SSO != status {
+	messageChanged := setComponentMessage(cr, statusComponentSSO, message)
+	if cr.Status.SSO != status || messageChanged {
 		cr.Status.SSO = status
 		return r.Client.Status().Update(context.TODO(), cr)
 	}
@@ -128,7 +131,7 @@ func (r *ReconcileArgoCD) reconcileStatusDex(cr *argoproj.ArgoCD) error {
 
 // reconcileStatusKeycloak will ensure that the Keycloak status is updated for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatusKeycloak(cr *argoproj.ArgoCD) error {
-	status := "Unknown"
+	status, message := "Unknown", ""
 
 	if CanUseKeycloakWithTemplate() {
 		// keycloak is installed using OpenShift templates.
@@ -148,6 +151,7 @@ func (r *ReconcileArgoCD) reconcileStatusKeycloak(cr *argoproj.ArgoCD) error {
 					if condition.Type == oappsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue {
 						// Deployment has failed
 						status = "Failed"
+						message = condition.Message
 						break
 					}
 				}
@@ -157,26 +161,12 @@ func (r *ReconcileArgoCD) reconcileStatusKeycloak(cr *argoproj.ArgoCD) error {
 	} else {
 		d := newDeploymentWithName(defaultKeycloakIdentifier, defaultKeycloakIdentifier, cr)
 		if argoutil.IsObjectFound(r.Client, cr.Namespace, d.Name, d) {
-			status = "Pending"
-
-			if d.Spec.Replicas != nil {
-				if d.Status.ReadyReplicas == *d.Spec.Replicas {
-					status = "Running"
-				} else if d.Status.Conditions != nil {
-					for _, condition := range d.Status.Conditions {
-						if condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue {
-							// Deployment has failed
-							status = "Failed"
-							break
-						}
-					}
-				}
-
-			}
+			status, message = r.getDeploymentStatus(d)
 		}
 	}
 
-	if cr.Status.SSO != status {
+	messageChanged := setComponentMessage(cr, statusComponentSSO, message)
+	if cr.Status.SSO != status || messageChanged {
 		cr.Status.SSO = status
 		return r.Client.Status().Update(context.TODO(), cr)
 	}
@@ -186,28 +176,15 @@ func (r *ReconcileArgoCD) reconcileStatusKeycloak(cr *argoproj.ArgoCD) error {
 
 // reconcileStatusApplicationSetController will ensure that the ApplicationSet controller status is updated for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatusApplicationSetController(cr *argoproj.ArgoCD) error {
-	status := "Unknown"
+	status, message := "Unknown", ""
 
 	deploy := newDeploymentWithSuffix("applicationset-controller", "controller", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
-		status = "Pending"
-
-		if deploy.Spec.Replicas != nil {
-			if deploy.Status.ReadyReplicas == *deploy.Spec.Replicas {
-				status = "Running"
-			} else if deploy.Status.Conditions != nil {
-				for _, condition := range deploy.Status.Conditions {
-					if condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue {
-						// Deployment has failed
-						status = "Failed"
-						break
-					}
-				}
-			}
-		}
+		status, message = r.getDeploymentStatus(deploy)
 	}
 
-	if cr.Status.ApplicationSetController != status {
+	messageChanged := setComponentMessage(cr, statusComponentApplicationSetController, message)
+	if cr.Status.ApplicationSetController != status || messageChanged {
 		cr.Status.ApplicationSetController = status
 		return r.Client.Status().Update(context.TODO(), cr)
 	}
@@ -238,15 +215,85 @@ func (r *ReconcileArgoCD) reconcileStatusSSO(cr *argoproj.ArgoCD) error {
 	return nil
 }
 
-// reconcileStatusPhase will ensure that the Status Phase is updated for the given ArgoCD.
+// reconcileStatusPaused will ensure that the Paused condition is updated for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusPaused(cr *argoproj.ArgoCD) error {
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypePaused,
+		Status:             metav1.ConditionTrue,
+		Reason:             argoproj.ArgoCDConditionReasonPaused,
+		Message:            "Reconciliation is paused, changes to the Argo CD resources are not reverted",
+		ObservedGeneration: cr.Generation,
+	}
+	if !cr.Spec.Paused {
+		if meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionTypePaused) == nil {
+			return nil // Never paused, no need for the condition
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = argoproj.ArgoCDConditionReasonResumed
+		condition.Message = "Reconciliation has been resumed"
+	}
+
+	conditions := make([]metav1.Condition, len(cr.Status.Conditions))
+	copy(conditions, cr.Status.Conditions)
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	if !reflect.DeepEqual(conditions, cr.Status.Conditions) {
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+	return nil
+}
+
+// reconcileStatusConfigValidation will ensure that the Degraded condition reports the given configuration validation
+// errors. A Warning Event is emitted each time the invalid configuration changes.
+func (r *ReconcileArgoCD) reconcileStatusConfigValidation(cr *argoproj.ArgoCD, invalid []error) error {
+	existing := meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionTypeDegraded)
+
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionTypeDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             argoproj.ArgoCDConditionReasonValidConfiguration,
+		Message:            "The configuration is valid",
+		ObservedGeneration: cr.Generation,
+	}
+	if len(invalid) == 0 && existing == nil {
+		return nil // Never degraded, no need for the condition
+	}
+	if len(invalid) > 0 {
+		messages := make([]string, 0, len(invalid))
+		for _, err := range invalid {
+			messages = append(messages, err.Error())
+		}
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = argoproj.ArgoCDConditionReasonInvalidConfiguration
+		condition.Message = strings.Join(messages, "; ")
+	}
+
+	if existing != nil && existing.Status == condition.Status && existing.Message == condition.Message {
+		return nil // Nothing changed, move along...
+	}
+
+	if condition.Status == metav1.ConditionTrue {
+		instanceLog(cr).Info(fmt.Sprintf("refusing to apply the configuration of ArgoCD %s/%s: %s", cr.Namespace, cr.Name, condition.Message))
+		r.recordEvent(cr, corev1.EventTypeWarning, argoproj.ArgoCDConditionReasonInvalidConfiguration, condition.Message)
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	return r.Client.Status().Update(context.TODO(), cr)
+}
+
+// reconcileStatusPhase will ensure that the Status Phase is updated for the given ArgoCD. The phase is aggregated
+// from the status of the core components: Available once all of them are running, Failed as soon as one of them
+// failed, and Pending otherwise.
 func (r *ReconcileArgoCD) reconcileStatusPhase(cr *argoproj.ArgoCD) error {
 	var phase string
 
 	if ((!cr.Spec.Controller.IsEnabled() && cr.Status.ApplicationController == "Unknown") || cr.Status.ApplicationController == "Running") &&
 		((!cr.Spec.Redis.IsEnabled() && cr.Status.Redis == "Unknown") || cr.Status.Redis == "Running" || (cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "")) &&
 		((!cr.Spec.Repo.IsEnabled() && cr.Status.Repo == "Unknown") || cr.Status.Repo == "Running") &&
-		((!cr.Spec.Server.IsEnabled() && cr.Status.Server == "Unknown") || cr.Status.Server == "Running") {
+		((!isServerEnabled(cr) && cr.Status.Server == "Unknown") || cr.Status.Server == "Running") {
 		phase = "Available"
+	} else if cr.Status.ApplicationController == "Failed" || cr.Status.Redis == "Failed" ||
+		cr.Status.Repo == "Failed" || cr.Status.Server == "Failed" {
+		phase = "Failed"
 	} else {
 		phase = "Pending"
 	}
@@ -260,40 +307,28 @@ func (r *ReconcileArgoCD) reconcileStatusPhase(cr *argoproj.ArgoCD) error {
 
 // reconcileStatusRedis will ensure that the Redis status is updated for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatusRedis(cr *argoproj.ArgoCD) error {
-	status := "Unknown"
+	status, message := "Unknown", ""
 
-	if !cr.Spec.HA.Enabled {
+	if !isRedisHAActive(cr) {
 		deploy := newDeploymentWithSuffix("redis", "redis", cr)
 		if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
-			status = "Pending"
-
-			if deploy.Spec.Replicas != nil {
-				if deploy.Status.ReadyReplicas == *deploy.Spec.Replicas {
-					status = "Running"
-				} else if deploy.Status.Conditions != nil {
-					for _, condition := range deploy.Status.Conditions {
-						if condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue {
-							// Deployment has failed
-							status = "Failed"
-							break
-						}
-					}
-				}
-			}
+			status, message = r.getDeploymentStatus(deploy)
 		}
 	} else {
 		ss := newStatefulSetWithSuffix("redis-ha-server", "redis-ha-server", cr)
 		if argoutil.IsObjectFound(r.Client, cr.Namespace, ss.Name, ss) {
-			status = "Pending"
+			status, message = r.getStatefulSetStatus(ss)
+		}
 
-			if ss.Status.ReadyReplicas == *ss.Spec.Replicas {
-				status = "Running"
-			}
+		// Redis is only reachable through the HA proxy
+		deploy := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
+		if status == "Running" && argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
+			status, message = r.getDeploymentStatus(deploy)
 		}
-		// TODO: Add check for HA proxy deployment here as well?
 	}
 
-	if cr.Status.Redis != status {
+	messageChanged := setComponentMessage(cr, statusComponentRedis, message)
+	if cr.Status.Redis != status || messageChanged {
 		cr.Status.Redis = status
 		return r.Client.Status().Update(context.TODO(), cr)
 	}
@@ -302,28 +337,15 @@ func (r *ReconcileArgoCD) reconcileStatusRedis(cr *argoproj.ArgoCD) error {
 
 // reconcileStatusRepo will ensure that the Repo status is updated for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatusRepo(cr *argoproj.ArgoCD) error {
-	status := "Unknown"
+	status, message := "Unknown", ""
 
 	deploy := newDeploymentWithSuffix("repo-server", "repo-server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
-		status = "Pending"
-
-		if deploy.Spec.Replicas != nil {
-			if deploy.Status.ReadyReplicas == *deploy.Spec.Replicas {
-				status = "Running"
-			} else if deploy.Status.Conditions != nil {
-				for _, condition := range deploy.Status.Conditions {
-					if condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue {
-						// Deployment has failed
-						status = "Failed"
-						break
-					}
-				}
-			}
-		}
+		status, message = r.getDeploymentStatus(deploy)
 	}
 
-	if cr.Status.Repo != status {
+	messageChanged := setComponentMessage(cr, statusComponentRepo, message)
+	if cr.Status.Repo != status || messageChanged {
 		cr.Status.Repo = status
 		return r.Client.Status().Update(context.TODO(), cr)
 	}
@@ -332,30 +354,26 @@ func (r *ReconcileArgoCD) reconcileStatusRepo(cr *argoproj.ArgoCD) error {
 
 // reconcileStatusServer will ensure that the Server status is updated for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatusServer(cr *argoproj.ArgoCD) error {
-	status := "Unknown"
+	status, message := "Unknown", ""
+	var replicas int32
+	selector := ""
 
 	deploy := newDeploymentWithSuffix("server", "server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
-		status = "Pending"
-
-		// TODO: Refactor these checks.
-		if deploy.Spec.Replicas != nil {
-			if deploy.Status.ReadyReplicas == *deploy.Spec.Replicas {
-				status = "Running"
-			} else if deploy.Status.Conditions != nil {
-				for _, condition := range deploy.Status.Conditions {
-					if condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue {
-						// Deployment has failed
-						status = "Failed"
-						break
-					}
-				}
+		replicas = deploy.Status.Replicas
+		if deploy.Spec.Selector != nil {
+			if s, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector); err == nil {
+				selector = s.String()
 			}
 		}
+		status, message = r.getDeploymentStatus(deploy)
 	}
 
-	if cr.Status.Server != status {
+	messageChanged := setComponentMessage(cr, statusComponentServer, message)
+	if cr.Status.Server != status || cr.Status.ServerReplicas != replicas || cr.Status.ServerSelector != selector || messageChanged {
 		cr.Status.Server = status
+		cr.Status.ServerReplicas = replicas
+		cr.Status.ServerSelector = selector
 		return r.Client.Status().Update(context.TODO(), cr)
 	}
 	return nil
@@ -363,28 +381,15 @@ func (r *ReconcileArgoCD) reconcileStatusServer(cr *argoproj.ArgoCD) error {
 
 // reconcileStatusNotifications will ensure that the Notifications status is updated for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileStatusNotifications(cr *argoproj.ArgoCD) error {
-	status := "Unknown"
+	status, message := "Unknown", ""
 
 	deploy := newDeploymentWithSuffix("notifications-controller", "controller", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
-		status = "Pending"
-
-		if deploy.Spec.Replicas != nil {
-			if deploy.Status.ReadyReplicas == *deploy.Spec.Replicas {
-				status = "Running"
-			} else if deploy.Status.Conditions != nil {
-				for _, condition := range deploy.Status.Conditions {
-					if condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue {
-						// Deployment has failed
-						status = "Failed"
-						break
-					}
-				}
-			}
-		}
+		status, message = r.getDeploymentStatus(deploy)
 	}
 
-	if cr.Status.NotificationsController != status {
+	messageChanged := setComponentMessage(cr, statusComponentNotificationsController, message)
+	if cr.Status.NotificationsController != status || messageChanged {
 		if !cr.Spec.Notifications.Enabled {
 			cr.Status.NotificationsController = ""
 		} else {
@@ -421,7 +426,7 @@ func (r *ReconcileArgoCD) reconcileStatusHost(cr *argoproj.ArgoCD) error {
 		}
 
 		if len(routeList.Items) == 0 {
-			log.Info("argocd-server route requested but not found on cluster")
+			instanceLog(cr).Info("argocd-server route requested but not found on cluster")
 			return nil
 		} else {
 			route = &routeList.Items[0]
@@ -452,7 +457,7 @@ func (r *ReconcileArgoCD) reconcileStatusHost(cr *argoproj.ArgoCD) error {
 	} else if cr.Spec.Server.Ingress.Enabled {
 		ingress := newIngressWithSuffix("server", cr)
 		if !argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-			log.Info("argocd-server ingress requested but not found on cluster")
+			instanceLog(cr).Info("argocd-server ingress requested but not found on cluster")
 			cr.Status.Phase = "Pending"
 			return nil
 		} else {
@@ -475,3 +480,255 @@ func (r *ReconcileArgoCD) reconcileStatusHost(cr *argoproj.ArgoCD) error {
 	}
 	return r.Client.Status().Update(context.TODO(), cr)
 }
+
+// Keys of the endpoints in the status of the ArgoCD.
+const (
+	statusEndpointApplicationSetWebhook = "applicationSetWebhook"
+	statusEndpointGrafana               = "grafana"
+	statusEndpointGRPC                  = "grpc"
+	statusEndpointKeycloak              = "keycloak"
+	statusEndpointServer                = "server"
+)
+
+// reconcileStatusEndpoints will ensure that the endpoints status lists the URLs of the Routes, Ingresses and
+// LoadBalancer Services exposing the given ArgoCD. Routes take precedence over Ingresses, like for the server URI.
+func (r *ReconcileArgoCD) reconcileStatusEndpoints(cr *argoproj.ArgoCD) error {
+	webhook := fmt.Sprintf("%s-%s", common.ApplicationSetServiceNameSuffix, "webhook")
+	endpoints := map[string]string{}
+
+	for key, suffix := range map[string]string{
+		statusEndpointServer:                "server",
+		statusEndpointGRPC:                  "grpc",
+		statusEndpointApplicationSetWebhook: common.ApplicationSetServiceNameSuffix,
+		statusEndpointGrafana:               "grafana",
+	} {
+		if url := getIngressURL(r.Client, newIngressWithSuffix(suffix, cr)); url != "" {
+			endpoints[key] = url
+		}
+	}
+
+	if svc := newServiceWithSuffix("server", "server", cr); argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) &&
+		svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			host := ingress.Hostname
+			if host == "" {
+				host = ingress.IP
+			}
+			if host != "" {
+				endpoints[statusEndpointServer] = fmt.Sprintf("https://%s", host)
+				break
+			}
+		}
+	}
+
+	if IsRouteAPIAvailable() {
+		for key, route := range map[string]*routev1.Route{
+			statusEndpointServer:                newRouteWithSuffix("server", cr),
+			statusEndpointApplicationSetWebhook: newRouteWithSuffix(webhook, cr),
+			statusEndpointGrafana:               newRouteWithSuffix("grafana", cr),
+			statusEndpointKeycloak:              newRouteWithName(defaultKeycloakIdentifier, cr),
+		} {
+			if url := getRouteURL(r.Client, route); url != "" {
+				endpoints[key] = url
+			}
+		}
+	}
+
+	if external := cr.Spec.Grafana.External; external != nil && external.URL != "" {
+		endpoints[statusEndpointGrafana] = external.URL
+	}
+
+	if len(endpoints) == 0 {
+		endpoints = nil
+	}
+	if reflect.DeepEqual(endpoints, cr.Status.Endpoints) {
+		return nil
+	}
+	cr.Status.Endpoints = endpoints
+	return r.Client.Status().Update(context.TODO(), cr)
+}
+
+// getRouteURL will return the URL of the given Route, empty if the Route is not found or not admitted yet.
+func getRouteURL(cl client.Client, route *routev1.Route) string {
+	if !argoutil.IsObjectFound(cl, route.Namespace, route.Name, route) {
+		return ""
+	}
+	host := route.Spec.Host
+	for _, ingress := range route.Status.Ingress {
+		for _, condition := range ingress.Conditions {
+			if condition.Type == routev1.RouteAdmitted && condition.Status != corev1.ConditionTrue {
+				return ""
+			}
+		}
+		if ingress.Host != "" {
+			host = ingress.Host
+			break
+		}
+	}
+	if host == "" {
+		return ""
+	}
+	if route.Spec.TLS != nil {
+		return fmt.Sprintf("https://%s", host)
+	}
+	return fmt.Sprintf("http://%s", host)
+}
+
+// getIngressURL will return the URL of the given Ingress, taken from its first rule or, if the rule has no host,
+// from its load balancer. An empty URL is returned if the Ingress is not found or has no address yet.
+func getIngressURL(cl client.Client, ingress *networkingv1.Ingress) string {
+	if !argoutil.IsObjectFound(cl, ingress.Namespace, ingress.Name, ingress) {
+		return ""
+	}
+	host := ""
+	if len(ingress.Spec.Rules) > 0 {
+		host = ingress.Spec.Rules[0].Host
+	}
+	if host == "" && len(ingress.Status.LoadBalancer.Ingress) > 0 {
+		host = ingress.Status.LoadBalancer.Ingress[0].Hostname
+		if host == "" {
+			host = ingress.Status.LoadBalancer.Ingress[0].IP
+		}
+	}
+	if host == "" {
+		return ""
+	}
+	if len(ingress.Spec.TLS) > 0 {
+		return fmt.Sprintf("https://%s", host)
+	}
+	return fmt.Sprintf("http://%s", host)
+}
+
+// Keys of the component messages in the status of the ArgoCD, matching the name of the component status fields.
+const (
+	statusComponentApplicationController    = "applicationController"
+	statusComponentApplicationSetController = "applicationSetController"
+	statusComponentNotificationsController  = "notificationsController"
+	statusComponentRedis                    = "redis"
+	statusComponentRepo                     = "repo"
+	statusComponentServer                   = "server"
+	statusComponentSSO                      = "sso"
+)
+
+// failedContainerReasons are the waiting reasons of a container that does not recover on its own.
+var failedContainerReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+}
+
+// setComponentMessage will set the message of the given component in the status of the ArgoCD, removing it when
+// empty. Returns true if the message changed.
+func setComponentMessage(cr *argoproj.ArgoCD, component string, message string) bool {
+	if cr.Status.ComponentMessages[component] == message {
+		return false
+	}
+
+	if message == "" {
+		delete(cr.Status.ComponentMessages, component)
+		if len(cr.Status.ComponentMessages) == 0 {
+			cr.Status.ComponentMessages = nil
+		}
+		return true
+	}
+
+	if cr.Status.ComponentMessages == nil {
+		cr.Status.ComponentMessages = map[string]string{}
+	}
+	cr.Status.ComponentMessages[component] = message
+	return true
+}
+
+// getPodsStatus will inspect the Pods matching the given selector, and return true along with the reason if one of
+// their containers failed, e.g. with an image pull back-off or a crash loop. Pods that cannot be scheduled are
+// reported with their reason, but are not considered failed.
+func (r *ReconcileArgoCD) getPodsStatus(namespace string, selector *metav1.LabelSelector) (bool, string) {
+	if selector == nil {
+		return false, ""
+	}
+
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, ""
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.Client.List(context.TODO(), pods, &client.ListOptions{Namespace: namespace, LabelSelector: s}); err != nil {
+		log.Error(err, fmt.Sprintf("failed to list pods in namespace %s", namespace))
+		return false, ""
+	}
+
+	unschedulable := ""
+	for _, pod := range pods.Items {
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, cs := range statuses {
+			if cs.State.Waiting == nil || !failedContainerReasons[cs.State.Waiting.Reason] {
+				continue
+			}
+			message := fmt.Sprintf("pod %s container %s: %s", pod.Name, cs.Name, cs.State.Waiting.Reason)
+			if cs.State.Waiting.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, cs.State.Waiting.Message)
+			}
+			return true, message
+		}
+
+		for _, condition := range pod.Status.Conditions {
+			if unschedulable == "" && condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse &&
+				condition.Reason == corev1.PodReasonUnschedulable {
+				unschedulable = fmt.Sprintf("pod %s: %s: %s", pod.Name, condition.Reason, condition.Message)
+			}
+		}
+	}
+
+	return false, unschedulable
+}
+
+// getDeploymentStatus will return the status of the given Deployment, computed from the readiness of its replicas
+// and the state of its Pods, along with the reason why it is not running, if known.
+func (r *ReconcileArgoCD) getDeploymentStatus(deploy *appsv1.Deployment) (string, string) {
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	if deploy.Status.ReadyReplicas >= replicas {
+		return "Running", ""
+	}
+
+	for _, condition := range deploy.Status.Conditions {
+		if condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue {
+			// Deployment has failed
+			return "Failed", condition.Message
+		}
+		if condition.Type == appsv1.DeploymentProgressing && condition.Status == corev1.ConditionFalse &&
+			condition.Reason == "ProgressDeadlineExceeded" {
+			return "Failed", condition.Message
+		}
+	}
+
+	failed, message := r.getPodsStatus(deploy.Namespace, deploy.Spec.Selector)
+	if failed {
+		return "Failed", message
+	}
+	return "Pending", message
+}
+
+// getStatefulSetStatus will return the status of the given StatefulSet, computed from the readiness of its replicas
+// and the state of its Pods, along with the reason why it is not running, if known.
+func (r *ReconcileArgoCD) getStatefulSetStatus(ss *appsv1.StatefulSet) (string, string) {
+	replicas := int32(1)
+	if ss.Spec.Replicas != nil {
+		replicas = *ss.Spec.Replicas
+	}
+	if ss.Status.ReadyReplicas >= replicas {
+		return "Running", ""
+	}
+
+	failed, message := r.getPodsStatus(ss.Namespace, ss.Spec.Selector)
+	if failed {
+		return "Failed", message
+	}
+	return "Pending", message
+}
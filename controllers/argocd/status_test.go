@@ -5,14 +5,17 @@ import (
 	"testing"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
 
 	oappsv1 "github.com/openshift/api/apps/v1"
 	configv1 "github.com/openshift/api/config/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -294,6 +297,59 @@ func TestReconcileArgoCD_reconcileStatusHost(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_reconcileStatusEndpoints(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	routeAPIFound = true
+	defer func() { routeAPIFound = false }()
+
+	a := makeTestArgoCD()
+	serverRoute := newRouteWithSuffix("server", a)
+	serverRoute.Spec = routev1.RouteSpec{Host: "argocd.apps.example.com", TLS: &routev1.TLSConfig{}}
+	serverRoute.Status.Ingress = []routev1.RouteIngress{{
+		Host:       "argocd.apps.example.com",
+		Conditions: []routev1.RouteIngressCondition{{Type: routev1.RouteAdmitted, Status: corev1.ConditionTrue}},
+	}}
+	keycloakRoute := newRouteWithName(defaultKeycloakIdentifier, a)
+	keycloakRoute.Spec = routev1.RouteSpec{Host: "keycloak.apps.example.com", TLS: &routev1.TLSConfig{}}
+	keycloakRoute.Status.Ingress = []routev1.RouteIngress{{
+		Host:       "keycloak.apps.example.com",
+		Conditions: []routev1.RouteIngressCondition{{Type: routev1.RouteAdmitted, Status: corev1.ConditionFalse}},
+	}}
+	serverIngress := newIngressWithSuffix("server", a)
+	serverIngress.Spec.Rules = []networkingv1.IngressRule{{Host: "argocd.example.com"}}
+	grpcIngress := newIngressWithSuffix("grpc", a)
+	grpcIngress.Spec.Rules = []networkingv1.IngressRule{{Host: "grpc.argocd.example.com"}}
+	grpcIngress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{"grpc.argocd.example.com"}}}
+	webhookIngress := newIngressWithSuffix(common.ApplicationSetServiceNameSuffix, a)
+	webhookIngress.Status.LoadBalancer.Ingress = []networkingv1.IngressLoadBalancerIngress{{IP: "12.0.0.5"}}
+
+	resObjs := []client.Object{a, serverRoute, keycloakRoute, serverIngress, grpcIngress, webhookIngress}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, routev1.Install)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// Routes take precedence over Ingresses, and Routes which are not admitted are ignored
+	assert.NoError(t, r.reconcileStatusEndpoints(a))
+	assert.Equal(t, map[string]string{
+		"server":                "https://argocd.apps.example.com",
+		"grpc":                  "https://grpc.argocd.example.com",
+		"applicationSetWebhook": "http://12.0.0.5",
+	}, a.Status.Endpoints)
+
+	// Endpoints are removed with their Route or Ingress
+	assert.NoError(t, r.Client.Delete(context.TODO(), serverRoute))
+	assert.NoError(t, r.Client.Delete(context.TODO(), grpcIngress))
+	assert.NoError(t, r.Client.Delete(context.TODO(), webhookIngress))
+	assert.NoError(t, r.reconcileStatusEndpoints(a))
+	assert.Equal(t, map[string]string{"server": "http://argocd.example.com"}, a.Status.Endpoints)
+
+	assert.NoError(t, r.Client.Delete(context.TODO(), serverIngress))
+	assert.NoError(t, r.reconcileStatusEndpoints(a))
+	assert.Nil(t, a.Status.Endpoints)
+}
+
 func TestReconcileArgoCD_reconcileStatusNotificationsController(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
@@ -338,3 +394,89 @@ func TestReconcileArgoCD_reconcileStatusApplicationSetController(t *testing.T) {
 	assert.NoError(t, r.reconcileStatusApplicationSetController(a))
 	assert.Equal(t, "Pending", a.Status.ApplicationSetController)
 }
+
+func TestReconcileArgoCD_reconcileStatusServer_scale(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileStatusServer(a))
+	assert.Equal(t, "Unknown", a.Status.Server)
+	assert.Equal(t, int32(0), a.Status.ServerReplicas)
+	assert.Equal(t, "", a.Status.ServerSelector)
+
+	assert.NoError(t, r.reconcileServerDeployment(a, false))
+
+	deploy := newDeploymentWithSuffix("server", "server", a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: deploy.Name, Namespace: a.Namespace}, deploy))
+	deploy.Status.Replicas = 2
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), deploy))
+
+	assert.NoError(t, r.reconcileStatusServer(a))
+	assert.Equal(t, "Pending", a.Status.Server)
+	assert.Equal(t, int32(2), a.Status.ServerReplicas)
+	assert.Equal(t, "app.kubernetes.io/name=argocd-server", a.Status.ServerSelector)
+}
+
+func TestReconcileArgoCD_reconcileStatusRepo_componentMessages(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	t.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.reconcileStatusRepo(a))
+	assert.Equal(t, "Pending", a.Status.Repo)
+	assert.Empty(t, a.Status.ComponentMessages)
+
+	// a repo server pod fails to pull its image
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "argocd-repo-server-abc",
+			Namespace: a.Namespace,
+			Labels:    map[string]string{"app.kubernetes.io/name": "argocd-repo-server"},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "argocd-repo-server",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"},
+					},
+				},
+			},
+		},
+	}
+	assert.NoError(t, r.Client.Create(context.TODO(), pod))
+
+	assert.NoError(t, r.reconcileStatusRepo(a))
+	assert.Equal(t, "Failed", a.Status.Repo)
+	assert.Equal(t, "pod argocd-repo-server-abc container argocd-repo-server: ImagePullBackOff: Back-off pulling image",
+		a.Status.ComponentMessages["repo"])
+
+	assert.NoError(t, r.reconcileStatusPhase(a))
+	assert.Equal(t, "Failed", a.Status.Phase)
+
+	// the repo server recovers
+	assert.NoError(t, r.Client.Delete(context.TODO(), pod))
+	deploy := newDeploymentWithSuffix("repo-server", "repo-server", a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: deploy.Name, Namespace: a.Namespace}, deploy))
+	deploy.Status.ReadyReplicas = 1
+	assert.NoError(t, r.Client.Status().Update(context.TODO(), deploy))
+
+	assert.NoError(t, r.reconcileStatusRepo(a))
+	assert.Equal(t, "Running", a.Status.Repo)
+	assert.Nil(t, a.Status.ComponentMessages)
+}
@@ -46,10 +46,12 @@ import (
 	"github.com/sethvargo/go-password/password"
 	"golang.org/x/mod/semver"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscaling "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	v1 "k8s.io/api/rbac/v1"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -125,6 +127,10 @@ func generateArgoServerSessionKey() ([]byte, error) {
 func getArgoApplicationControllerResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
 	resources := corev1.ResourceRequirements{}
 
+	if preset, ok := getProfilePreset(cr); ok {
+		resources = preset.controllerResources
+	}
+
 	// Allow override of resource requirements from CR
 	if cr.Spec.Controller.Resources != nil {
 		resources = *cr.Spec.Controller.Resources
@@ -143,7 +149,7 @@ func getArgoApplicationControllerCommand(cr *argoproj.ArgoCD, useTLSForRedis boo
 	if cr.Spec.Redis.IsEnabled() {
 		cmd = append(cmd, "--redis", getRedisServerAddress(cr))
 	} else {
-		log.Info("Redis is Disabled. Skipping adding Redis configuration to Application Controller.")
+		instanceLog(cr).Info("Redis is Disabled. Skipping adding Redis configuration to Application Controller.")
 	}
 
 	if useTLSForRedis {
@@ -158,11 +164,12 @@ func getArgoApplicationControllerCommand(cr *argoproj.ArgoCD, useTLSForRedis boo
 	if cr.Spec.Repo.IsEnabled() {
 		cmd = append(cmd, "--repo-server", getRepoServerAddress(cr))
 	} else {
-		log.Info("Repo Server is disabled. This would affect the functioning of Application Controller.")
+		instanceLog(cr).Info("Repo Server is disabled. This would affect the functioning of Application Controller.")
 	}
 
 	cmd = append(cmd, "--status-processors", fmt.Sprint(getArgoServerStatusProcessors(cr)))
 	cmd = append(cmd, "--kubectl-parallelism-limit", fmt.Sprint(getArgoControllerParellismLimit(cr)))
+	cmd = append(cmd, getArgoApplicationControllerTimeoutArgs(cr)...)
 
 	if cr.Spec.SourceNamespaces != nil && len(cr.Spec.SourceNamespaces) > 0 {
 		cmd = append(cmd, "--application-namespaces", fmt.Sprint(strings.Join(cr.Spec.SourceNamespaces, ",")))
@@ -185,8 +192,43 @@ func getArgoApplicationControllerCommand(cr *argoproj.ArgoCD, useTLSForRedis boo
 	return cmd
 }
 
+// getArgoApplicationControllerTimeoutArgs will return the command arguments for the timeouts and cache expirations
+// of the Application Controller that are set on the given ArgoCD.
+func getArgoApplicationControllerTimeoutArgs(cr *argoproj.ArgoCD) []string {
+	var args []string
+
+	// The controller expects a number of seconds for these flags
+	for _, arg := range []struct {
+		flag     string
+		duration *metav1.Duration
+	}{
+		{"--app-hard-resync", cr.Spec.Controller.AppHardResync},
+		{"--self-heal-timeout-seconds", cr.Spec.Controller.SelfHealTimeout},
+		{"--repo-server-timeout-seconds", cr.Spec.Controller.RepoServerTimeout},
+		{"--repo-error-grace-period-seconds", cr.Spec.Controller.RepoErrorGracePeriod},
+	} {
+		if arg.duration != nil {
+			args = append(args, arg.flag, strconv.FormatInt(int64(arg.duration.Seconds()), 10))
+		}
+	}
+
+	// The controller expects a duration for these flags
+	if cr.Spec.Controller.StatusCacheExpiration != nil {
+		args = append(args, "--app-state-cache-expiration", cr.Spec.Controller.StatusCacheExpiration.Duration.String())
+	}
+	if cr.Spec.Controller.DefaultCacheExpiration != nil {
+		args = append(args, "--default-cache-expiration", cr.Spec.Controller.DefaultCacheExpiration.Duration.String())
+	}
+
+	return args
+}
+
 // getArgoContainerImage will return the container image for ArgoCD.
 func getArgoContainerImage(cr *argoproj.ArgoCD) string {
+	if img := getImageOverride(cr, argoImageComponent); img != "" {
+		return img
+	}
+
 	defaultTag, defaultImg := false, false
 	img := cr.Spec.Image
 	if img == "" {
@@ -219,7 +261,14 @@ func getArgoContainerImage(cr *argoproj.ArgoCD) string {
 // that if the spec is not configured.
 // 4. the default is configured in common.ArgoCDDefaultArgoVersion and
 // common.ArgoCDDefaultArgoImage.
+//
+// The image overrides of the architecture the workloads are pinned to replace
+// options 2 to 4.
 func getRepoServerContainerImage(cr *argoproj.ArgoCD) string {
+	if img := getImageOverride(cr, argoImageComponent); img != "" && cr.Spec.Repo.Image == "" {
+		return img
+	}
+
 	defaultImg, defaultTag := false, false
 	img := cr.Spec.Repo.Image
 	if img == "" {
@@ -248,12 +297,16 @@ func getRepoServerContainerImage(cr *argoproj.ArgoCD) string {
 func getArgoRepoResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
 	resources := corev1.ResourceRequirements{}
 
+	if preset, ok := getProfilePreset(cr); ok {
+		resources = preset.repoResources
+	}
+
 	// Allow override of resource requirements from CR
 	if cr.Spec.Repo.Resources != nil {
 		resources = *cr.Spec.Repo.Resources
 	}
 
-	return resources
+	return withEphemeralStorageRequest(resources, cr.Spec.Repo.EphemeralStorage)
 }
 
 // getArgoServerInsecure returns the insecure value for the ArgoCD Server component.
@@ -261,6 +314,27 @@ func getArgoServerInsecure(cr *argoproj.ArgoCD) bool {
 	return cr.Spec.Server.Insecure
 }
 
+// getArgoServerSessionDuration will return the lifetime of the user sessions for the given ArgoCD, or an empty string
+// to use the Argo CD default.
+func getArgoServerSessionDuration(cr *argoproj.ArgoCD) string {
+	if cr.Spec.Server.Session == nil || cr.Spec.Server.Session.MaxAge == nil {
+		return ""
+	}
+	return cr.Spec.Server.Session.MaxAge.Duration.String()
+}
+
+// getArgoServerSessionEnv will return the environment variables of the Argo CD Server for the session options of the
+// given ArgoCD.
+func getArgoServerSessionEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
+	if cr.Spec.Server.Session == nil || cr.Spec.Server.Session.MaxConcurrent == nil {
+		return nil
+	}
+	return []corev1.EnvVar{{
+		Name:  common.ArgoCDMaxConcurrentLoginRequestsEnvName,
+		Value: fmt.Sprint(*cr.Spec.Server.Session.MaxConcurrent),
+	}}
+}
+
 func isRepoServerTLSVerificationRequested(cr *argoproj.ArgoCD) bool {
 	return cr.Spec.Repo.VerifyTLS
 }
@@ -269,6 +343,22 @@ func isRedisTLSVerificationDisabled(cr *argoproj.ArgoCD) bool {
 	return cr.Spec.Redis.DisableTLSVerification
 }
 
+// isServerEnabled returns true if the Argo CD Server of the given ArgoCD is deployed, i.e. it is enabled and the
+// instance is not installed in core mode.
+func isServerEnabled(cr *argoproj.ArgoCD) bool {
+	return !cr.IsCoreInstallation() && cr.Spec.Server.IsEnabled()
+}
+
+// getArgoServerRootPath will return the normalized root path for the Argo CD Server, or an empty string
+// when the server is served from the root.
+func getArgoServerRootPath(cr *argoproj.ArgoCD) string {
+	path := strings.Trim(strings.TrimSpace(cr.Spec.Server.RootPath), "/")
+	if path == "" {
+		return ""
+	}
+	return "/" + path
+}
+
 // getArgoServerGRPCHost will return the GRPC host for the given ArgoCD.
 func getArgoServerGRPCHost(cr *argoproj.ArgoCD) string {
 	host := nameWithSuffix("grpc", cr)
@@ -326,9 +416,83 @@ func getArgoServerResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
 		resources = *cr.Spec.Server.Resources
 	}
 
+	return withEphemeralStorageRequest(resources, cr.Spec.Server.EphemeralStorage)
+}
+
+// withEphemeralStorageRequest will return the given ResourceRequirements with the ephemeral-storage request of the
+// given options, unless one is already set.
+func withEphemeralStorageRequest(resources corev1.ResourceRequirements, opts *argoproj.ArgoCDEphemeralStorageSpec) corev1.ResourceRequirements {
+	if opts == nil || opts.Request == nil {
+		return resources
+	}
+	if _, ok := resources.Requests[corev1.ResourceEphemeralStorage]; ok {
+		return resources
+	}
+
+	// Copy the requests, so that the ones of the CR are left untouched
+	requests := corev1.ResourceList{corev1.ResourceEphemeralStorage: *opts.Request}
+	for name, quantity := range resources.Requests {
+		requests[name] = quantity
+	}
+	resources.Requests = requests
 	return resources
 }
 
+// withResourceDefaults will return the given ResourceRequirements with the requests and limits of the given defaults
+// for the resources they do not set. A default request above the limit set, or a default limit below the request set,
+// is left out, as the requirements would be invalid.
+func withResourceDefaults(resources corev1.ResourceRequirements, defaults *corev1.ResourceRequirements) corev1.ResourceRequirements {
+	if defaults == nil {
+		return resources
+	}
+
+	// Copy the requirements, so that the ones of the CR are left untouched
+	resources = *resources.DeepCopy()
+	for name, limit := range defaults.Limits {
+		if _, ok := resources.Limits[name]; ok {
+			continue
+		}
+		if request, ok := resources.Requests[name]; ok && request.Cmp(limit) > 0 {
+			continue
+		}
+		if resources.Limits == nil {
+			resources.Limits = corev1.ResourceList{}
+		}
+		resources.Limits[name] = limit
+	}
+	for name, request := range defaults.Requests {
+		if _, ok := resources.Requests[name]; ok {
+			continue
+		}
+		if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			continue
+		}
+		if resources.Requests == nil {
+			resources.Requests = corev1.ResourceList{}
+		}
+		resources.Requests[name] = request
+	}
+	return resources
+}
+
+// applyEmptyDirSizeLimits will set the size limits of the given options on the matching emptyDir volumes that have
+// none.
+func applyEmptyDirSizeLimits(volumes []corev1.Volume, opts *argoproj.ArgoCDEphemeralStorageSpec) {
+	if opts == nil {
+		return
+	}
+	for i, v := range volumes {
+		limit, ok := opts.SizeLimits[v.Name]
+		if !ok || v.EmptyDir == nil || v.EmptyDir.SizeLimit != nil {
+			continue
+		}
+		// Copy the volume source, which may be shared with the volumes of the CR
+		emptyDir := *v.EmptyDir
+		emptyDir.SizeLimit = &limit
+		volumes[i].EmptyDir = &emptyDir
+	}
+}
+
 // getArgoServerURI will return the URI for the ArgoCD server.
 // The hostname for argocd-server is from the route, ingress, an external hostname or service name in that order.
 func (r *ReconcileArgoCD) getArgoServerURI(cr *argoproj.ArgoCD) string {
@@ -361,6 +525,9 @@ func (r *ReconcileArgoCD) getArgoServerURI(cr *argoproj.ArgoCD) string {
 // getArgoServerOperationProcessors will return the numeric Operation Processors value for the ArgoCD Server.
 func getArgoServerOperationProcessors(cr *argoproj.ArgoCD) int32 {
 	op := common.ArgoCDDefaultServerOperationProcessors
+	if preset, ok := getProfilePreset(cr); ok {
+		op = preset.operationProcessors
+	}
 	if cr.Spec.Controller.Processors.Operation > 0 {
 		op = cr.Spec.Controller.Processors.Operation
 	}
@@ -370,6 +537,9 @@ func getArgoServerOperationProcessors(cr *argoproj.ArgoCD) int32 {
 // getArgoServerStatusProcessors will return the numeric Status Processors value for the ArgoCD Server.
 func getArgoServerStatusProcessors(cr *argoproj.ArgoCD) int32 {
 	sp := common.ArgoCDDefaultServerStatusProcessors
+	if preset, ok := getProfilePreset(cr); ok {
+		sp = preset.statusProcessors
+	}
 	if cr.Spec.Controller.Processors.Status > 0 {
 		sp = cr.Spec.Controller.Processors.Status
 	}
@@ -379,6 +549,9 @@ func getArgoServerStatusProcessors(cr *argoproj.ArgoCD) int32 {
 // getArgoControllerParellismLimit returns the parallelism limit for the application controller
 func getArgoControllerParellismLimit(cr *argoproj.ArgoCD) int32 {
 	pl := common.ArgoCDDefaultControllerParallelismLimit
+	if preset, ok := getProfilePreset(cr); ok {
+		pl = preset.parallelismLimit
+	}
 	if cr.Spec.Controller.ParallelismLimit > 0 {
 		pl = cr.Spec.Controller.ParallelismLimit
 	}
@@ -396,21 +569,51 @@ func getRedisConfigPath() string {
 
 // getRedisInitScript will load the redis configuration from a template on disk for the given ArgoCD.
 // If an error occurs, an empty string value will be returned.
-func getRedisConf(useTLSForRedis bool) string {
+func getRedisConf(cr *argoproj.ArgoCD, useTLSForRedis bool) string {
 	path := fmt.Sprintf("%s/redis.conf.tpl", getRedisConfigPath())
 	params := map[string]string{
-		"UseTLS": strconv.FormatBool(useTLSForRedis),
+		"UseTLS":          strconv.FormatBool(useTLSForRedis),
+		"IPv6":            strconv.FormatBool(wantsIPv6(cr)),
+		"RedisPort":       strconv.Itoa(int(getRedisPort(cr))),
+		"SentinelPort":    strconv.Itoa(int(getRedisSentinelPort(cr))),
+		"MaxMemory":       common.ArgoCDDefaultRedisMaxMemory,
+		"MaxMemoryPolicy": common.ArgoCDDefaultRedisHAMaxMemoryPolicy,
+		"Save":            `save ""`,
+	}
+	if cr.Spec.Redis.MaxMemory != "" {
+		params["MaxMemory"] = cr.Spec.Redis.MaxMemory
+	}
+	if cr.Spec.Redis.MaxMemoryPolicy != "" {
+		params["MaxMemoryPolicy"] = cr.Spec.Redis.MaxMemoryPolicy
+	}
+	if points := getRedisSavePoints(cr); len(points) > 0 {
+		params["Save"] = "save " + strings.Join(points, "\nsave ")
 	}
 	conf, err := loadTemplateFile(path, params)
 	if err != nil {
-		log.Error(err, "unable to load redis configuration")
+		componentLog(cr, logComponentRedis).Error(err, "unable to load redis configuration")
 		return ""
 	}
 	return conf
 }
 
+// getRedisSavePoints will return the snapshot points of the Redis data set of the given ArgoCD, as pairs of seconds
+// and number of changes. Each pair is set with its own save directive, as Redis 6 does not accept several pairs in one.
+func getRedisSavePoints(cr *argoproj.ArgoCD) []string {
+	fields := strings.Fields(cr.Spec.Redis.Save)
+	points := make([]string, 0, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		points = append(points, fields[i]+" "+fields[i+1])
+	}
+	return points
+}
+
 // getRedisContainerImage will return the container image for the Redis server.
 func getRedisContainerImage(cr *argoproj.ArgoCD) string {
+	if img := getImageOverride(cr, redisImageComponent); img != "" {
+		return img
+	}
+
 	defaultImg, defaultTag := false, false
 	img := cr.Spec.Redis.Image
 	if img == "" {
@@ -430,6 +633,10 @@ func getRedisContainerImage(cr *argoproj.ArgoCD) string {
 
 // getRedisHAContainerImage will return the container image for the Redis server in HA mode.
 func getRedisHAContainerImage(cr *argoproj.ArgoCD) string {
+	if img := getImageOverride(cr, redisImageComponent); img != "" {
+		return img
+	}
+
 	defaultImg, defaultTag := false, false
 	img := cr.Spec.Redis.Image
 	if img == "" {
@@ -449,11 +656,15 @@ func getRedisHAContainerImage(cr *argoproj.ArgoCD) string {
 
 // getRedisHAProxyAddress will return the Redis HA Proxy service address for the given ArgoCD.
 func getRedisHAProxyAddress(cr *argoproj.ArgoCD) string {
-	return fqdnServiceRef("redis-ha-haproxy", common.ArgoCDDefaultRedisPort, cr)
+	return fqdnServiceRef("redis-ha-haproxy", int(getRedisPort(cr)), cr)
 }
 
 // getRedisHAProxyContainerImage will return the container image for the Redis HA Proxy.
 func getRedisHAProxyContainerImage(cr *argoproj.ArgoCD) string {
+	if img := getImageOverride(cr, redisHAProxyImageComponent); img != "" {
+		return img
+	}
+
 	defaultImg, defaultTag := false, false
 	img := cr.Spec.HA.RedisProxyImage
 	if len(img) <= 0 {
@@ -479,13 +690,15 @@ func getRedisHAProxyContainerImage(cr *argoproj.ArgoCD) string {
 func getRedisInitScript(cr *argoproj.ArgoCD, useTLSForRedis bool) string {
 	path := fmt.Sprintf("%s/init.sh.tpl", getRedisConfigPath())
 	vars := map[string]string{
-		"ServiceName": nameWithSuffix("redis-ha", cr),
-		"UseTLS":      strconv.FormatBool(useTLSForRedis),
+		"ServiceName":  nameWithSuffix("redis-ha", cr),
+		"UseTLS":       strconv.FormatBool(useTLSForRedis),
+		"RedisPort":    strconv.Itoa(int(getRedisPort(cr))),
+		"SentinelPort": strconv.Itoa(int(getRedisSentinelPort(cr))),
 	}
 
 	script, err := loadTemplateFile(path, vars)
 	if err != nil {
-		log.Error(err, "unable to load redis init-script")
+		componentLog(cr, logComponentRedis).Error(err, "unable to load redis init-script")
 		return ""
 	}
 	return script
@@ -496,18 +709,61 @@ func getRedisInitScript(cr *argoproj.ArgoCD, useTLSForRedis bool) string {
 func getRedisHAProxyConfig(cr *argoproj.ArgoCD, useTLSForRedis bool) string {
 	path := fmt.Sprintf("%s/haproxy.cfg.tpl", getRedisConfigPath())
 	vars := map[string]string{
-		"ServiceName": nameWithSuffix("redis-ha", cr),
-		"UseTLS":      strconv.FormatBool(useTLSForRedis),
+		"ServiceName":    nameWithSuffix("redis-ha", cr),
+		"UseTLS":         strconv.FormatBool(useTLSForRedis),
+		"MetricsEnabled": strconv.FormatBool(cr.Spec.HA.RedisProxyMetrics),
+		"MetricsPort":    strconv.Itoa(common.ArgoCDDefaultRedisHAProxyMetricsPort),
+		"IPv6":           strconv.FormatBool(wantsIPv6(cr)),
+		"RedisPort":      strconv.Itoa(int(getRedisPort(cr))),
+		"SentinelPort":   strconv.Itoa(int(getRedisSentinelPort(cr))),
+		"TimeoutConnect": common.ArgoCDDefaultRedisHAProxyTimeoutConnect,
+		"TimeoutServer":  common.ArgoCDDefaultRedisHAProxyTimeoutServer,
+		"TimeoutClient":  common.ArgoCDDefaultRedisHAProxyTimeoutClient,
+		"TimeoutCheck":   common.ArgoCDDefaultRedisHAProxyTimeoutCheck,
+	}
+
+	if haproxy := cr.Spec.HA.HAProxy; haproxy != nil {
+		if timeouts := haproxy.Timeouts; timeouts != nil {
+			for key, timeout := range map[string]string{
+				"TimeoutConnect": timeouts.Connect,
+				"TimeoutServer":  timeouts.Server,
+				"TimeoutClient":  timeouts.Client,
+				"TimeoutCheck":   timeouts.Check,
+			} {
+				if len(timeout) > 0 {
+					vars[key] = timeout
+				}
+			}
+		}
+		if haproxy.MaxConn > 0 {
+			vars["MaxConn"] = strconv.Itoa(int(haproxy.MaxConn))
+		}
+		vars["AdditionalBackends"] = getRedisHAProxyAdditionalBackends(haproxy.AdditionalBackends)
+		vars["ConfigOverrides"] = strings.TrimRight(haproxy.ConfigOverrides, "\n")
 	}
 
 	script, err := loadTemplateFile(path, vars)
 	if err != nil {
-		log.Error(err, "unable to load redis haproxy configuration")
+		componentLog(cr, logComponentRedis).Error(err, "unable to load redis haproxy configuration")
 		return ""
 	}
 	return script
 }
 
+// getRedisHAProxyAdditionalBackends will return the haproxy.cfg sections of the given additional backends of the Redis
+// HA Proxy.
+func getRedisHAProxyAdditionalBackends(backends []argoproj.ArgoCDHAProxyBackendSpec) string {
+	sections := make([]string, 0, len(backends))
+	for _, backend := range backends {
+		lines := []string{fmt.Sprintf("backend %s", backend.Name)}
+		for _, line := range backend.Config {
+			lines = append(lines, "    "+strings.TrimSpace(line))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
 // getRedisHAProxyScript will load the Redis HA Proxy init script from a template on disk for the given ArgoCD.
 // If an error occurs, an empty string value will be returned.
 func getRedisHAProxyScript(cr *argoproj.ArgoCD) string {
@@ -518,7 +774,7 @@ func getRedisHAProxyScript(cr *argoproj.ArgoCD) string {
 
 	script, err := loadTemplateFile(path, vars)
 	if err != nil {
-		log.Error(err, "unable to load redis haproxy init script")
+		componentLog(cr, logComponentRedis).Error(err, "unable to load redis haproxy init script")
 		return ""
 	}
 	return script
@@ -528,6 +784,10 @@ func getRedisHAProxyScript(cr *argoproj.ArgoCD) string {
 func getRedisResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
 	resources := corev1.ResourceRequirements{}
 
+	if preset, ok := getProfilePreset(cr); ok {
+		resources = preset.redisResources
+	}
+
 	// Allow override of resource requirements from CR
 	if cr.Spec.Redis.Resources != nil {
 		resources = *cr.Spec.Redis.Resources
@@ -548,16 +808,59 @@ func getRedisHAResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
 	return resources
 }
 
+// getRedisInitResources will return the ResourceRequirements for the init container of the Redis HA server pods.
+func getRedisInitResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
+	// Allow override of resource requirements from CR
+	if cr.Spec.Redis.InitResources != nil {
+		return *cr.Spec.Redis.InitResources
+	}
+
+	return getRedisHAResources(cr)
+}
+
+// isRedisExporterEnabled returns true if the Redis HA server pods run the Prometheus exporter.
+func isRedisExporterEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Redis.Exporter != nil && cr.Spec.Redis.Exporter.Enabled
+}
+
+// getRedisExporterContainerImage will return the container image for the Redis exporter.
+func getRedisExporterContainerImage(cr *argoproj.ArgoCD) string {
+	img := cr.Spec.Redis.Exporter.Image
+	if img == "" {
+		img = common.ArgoCDDefaultRedisExporterImage
+	}
+	tag := cr.Spec.Redis.Exporter.Version
+	if tag == "" {
+		tag = common.ArgoCDDefaultRedisExporterVersion
+	}
+	return argoutil.CombineImageTag(img, tag)
+}
+
+// getRedisExporterResources will return the ResourceRequirements for the Redis exporter container.
+func getRedisExporterResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
+	resources := corev1.ResourceRequirements{}
+
+	// Allow override of resource requirements from CR
+	if cr.Spec.Redis.Exporter.Resources != nil {
+		resources = *cr.Spec.Redis.Exporter.Resources
+	}
+
+	return resources
+}
+
 // getRedisSentinelConf will load the redis sentinel configuration from a template on disk for the given ArgoCD.
 // If an error occurs, an empty string value will be returned.
-func getRedisSentinelConf(useTLSForRedis bool) string {
+func getRedisSentinelConf(cr *argoproj.ArgoCD, useTLSForRedis bool) string {
 	path := fmt.Sprintf("%s/sentinel.conf.tpl", getRedisConfigPath())
 	params := map[string]string{
-		"UseTLS": strconv.FormatBool(useTLSForRedis),
+		"UseTLS":       strconv.FormatBool(useTLSForRedis),
+		"IPv6":         strconv.FormatBool(wantsIPv6(cr)),
+		"RedisPort":    strconv.Itoa(int(getRedisPort(cr))),
+		"SentinelPort": strconv.Itoa(int(getRedisSentinelPort(cr))),
 	}
 	conf, err := loadTemplateFile(path, params)
 	if err != nil {
-		log.Error(err, "unable to load redis sentinel configuration")
+		componentLog(cr, logComponentRedis).Error(err, "unable to load redis sentinel configuration")
 		return ""
 	}
 	return conf
@@ -565,14 +868,15 @@ func getRedisSentinelConf(useTLSForRedis bool) string {
 
 // getRedisLivenessScript will load the redis liveness script from a template on disk for the given ArgoCD.
 // If an error occurs, an empty string value will be returned.
-func getRedisLivenessScript(useTLSForRedis bool) string {
+func getRedisLivenessScript(cr *argoproj.ArgoCD, useTLSForRedis bool) string {
 	path := fmt.Sprintf("%s/redis_liveness.sh.tpl", getRedisConfigPath())
 	params := map[string]string{
-		"UseTLS": strconv.FormatBool(useTLSForRedis),
+		"UseTLS":    strconv.FormatBool(useTLSForRedis),
+		"RedisPort": strconv.Itoa(int(getRedisPort(cr))),
 	}
 	conf, err := loadTemplateFile(path, params)
 	if err != nil {
-		log.Error(err, "unable to load redis liveness script")
+		componentLog(cr, logComponentRedis).Error(err, "unable to load redis liveness script")
 		return ""
 	}
 	return conf
@@ -580,14 +884,15 @@ func getRedisLivenessScript(useTLSForRedis bool) string {
 
 // getRedisReadinessScript will load the redis readiness script from a template on disk for the given ArgoCD.
 // If an error occurs, an empty string value will be returned.
-func getRedisReadinessScript(useTLSForRedis bool) string {
+func getRedisReadinessScript(cr *argoproj.ArgoCD, useTLSForRedis bool) string {
 	path := fmt.Sprintf("%s/redis_readiness.sh.tpl", getRedisConfigPath())
 	params := map[string]string{
-		"UseTLS": strconv.FormatBool(useTLSForRedis),
+		"UseTLS":    strconv.FormatBool(useTLSForRedis),
+		"RedisPort": strconv.Itoa(int(getRedisPort(cr))),
 	}
 	conf, err := loadTemplateFile(path, params)
 	if err != nil {
-		log.Error(err, "unable to load redis readiness script")
+		componentLog(cr, logComponentRedis).Error(err, "unable to load redis readiness script")
 		return ""
 	}
 	return conf
@@ -595,28 +900,45 @@ func getRedisReadinessScript(useTLSForRedis bool) string {
 
 // getSentinelLivenessScript will load the redis liveness script from a template on disk for the given ArgoCD.
 // If an error occurs, an empty string value will be returned.
-func getSentinelLivenessScript(useTLSForRedis bool) string {
+func getSentinelLivenessScript(cr *argoproj.ArgoCD, useTLSForRedis bool) string {
 	path := fmt.Sprintf("%s/sentinel_liveness.sh.tpl", getRedisConfigPath())
 	params := map[string]string{
-		"UseTLS": strconv.FormatBool(useTLSForRedis),
+		"UseTLS":       strconv.FormatBool(useTLSForRedis),
+		"SentinelPort": strconv.Itoa(int(getRedisSentinelPort(cr))),
 	}
 	conf, err := loadTemplateFile(path, params)
 	if err != nil {
-		log.Error(err, "unable to load sentinel liveness script")
+		componentLog(cr, logComponentRedis).Error(err, "unable to load sentinel liveness script")
 		return ""
 	}
 	return conf
 }
 
+// getRedisPort will return the port Redis listens on for the given ArgoCD.
+func getRedisPort(cr *argoproj.ArgoCD) int32 {
+	if cr.Spec.Redis.Port > 0 {
+		return cr.Spec.Redis.Port
+	}
+	return common.ArgoCDDefaultRedisPort
+}
+
+// getRedisSentinelPort will return the port the Redis Sentinels listen on for the given ArgoCD.
+func getRedisSentinelPort(cr *argoproj.ArgoCD) int32 {
+	if cr.Spec.HA.SentinelPort > 0 {
+		return cr.Spec.HA.SentinelPort
+	}
+	return common.ArgoCDDefaultRedisSentinelPort
+}
+
 // getRedisServerAddress will return the Redis service address for the given ArgoCD.
 func getRedisServerAddress(cr *argoproj.ArgoCD) string {
 	if cr.Spec.Redis.Remote != nil && *cr.Spec.Redis.Remote != "" {
 		return *cr.Spec.Redis.Remote
 	}
-	if cr.Spec.HA.Enabled {
+	if isRedisHAActive(cr) {
 		return getRedisHAProxyAddress(cr)
 	}
-	return fqdnServiceRef(common.ArgoCDDefaultRedisSuffix, common.ArgoCDDefaultRedisPort, cr)
+	return fqdnServiceRef(common.ArgoCDDefaultRedisSuffix, int(getRedisPort(cr)), cr)
 }
 
 // loadTemplateFile will parse a template with the given path and execute it with the given params.
@@ -660,6 +982,14 @@ func InspectCluster() error {
 		return err
 	}
 
+	if err := verifyKEDAAPI(); err != nil {
+		return err
+	}
+
+	if err := verifyConsoleLinkAPI(); err != nil {
+		return err
+	}
+
 	if err := verifyKeycloakTemplateAPIs(); err != nil {
 		return err
 	}
@@ -672,12 +1002,12 @@ func InspectCluster() error {
 
 // reconcileCertificateAuthority will reconcile all Certificate Authority resources.
 func (r *ReconcileArgoCD) reconcileCertificateAuthority(cr *argoproj.ArgoCD) error {
-	log.Info("reconciling CA secret")
+	instanceLog(cr).Info("reconciling CA secret")
 	if err := r.reconcileClusterCASecret(cr); err != nil {
 		return err
 	}
 
-	log.Info("reconciling CA config map")
+	instanceLog(cr).Info("reconciling CA config map")
 	if err := r.reconcileCAConfigMap(cr); err != nil {
 		return err
 	}
@@ -690,7 +1020,7 @@ func (r *ReconcileArgoCD) redisShouldUseTLS(cr *argoproj.ArgoCD) bool {
 	err := r.Client.Get(context.TODO(), tlsSecretName, &tlsSecretObj)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
-			log.Error(err, "error looking up redis tls secret")
+			instanceLog(cr).Error(err, "error looking up redis tls secret")
 		}
 		return false
 	}
@@ -701,6 +1031,11 @@ func (r *ReconcileArgoCD) redisShouldUseTLS(cr *argoproj.ArgoCD) bool {
 		// service, which in turn is owned by the controller. This method performs
 		// a lookup of the controller through the intermediate owning service.
 		for _, secretOwner := range secretOwnerRefs {
+			// Self-signed TLS secrets are generated by the operator and owned
+			// by the ArgoCD instance directly.
+			if secretOwner.Kind == "ArgoCD" {
+				return true
+			}
 			if isOwnerOfInterest(secretOwner) {
 				key := client.ObjectKey{Name: secretOwner.Name, Namespace: tlsSecretObj.GetNamespace()}
 				svc := &corev1.Service{}
@@ -708,7 +1043,7 @@ func (r *ReconcileArgoCD) redisShouldUseTLS(cr *argoproj.ArgoCD) bool {
 				// Get the owning object of the secret
 				err := r.Client.Get(context.TODO(), key, svc)
 				if err != nil {
-					log.Error(err, fmt.Sprintf("could not get owner of secret %s", tlsSecretObj.GetName()))
+					instanceLog(cr).Error(err, fmt.Sprintf("could not get owner of secret %s", tlsSecretObj.GetName()))
 					return false
 				}
 
@@ -738,85 +1073,104 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoproj.ArgoCD) error {
 
 	// we reconcile SSO first so that we can catch and throw errors for any illegal SSO configurations right away, and return control from here
 	// preventing dex resources from getting created anyway through the other function calls, effectively bypassing the SSO checks
-	log.Info("reconciling SSO")
+	instanceLog(cr).Info("reconciling SSO")
 	if err := r.reconcileSSO(cr); err != nil {
-		log.Info(err.Error())
+		instanceLog(cr).Info(err.Error())
 	}
 
-	log.Info("reconciling status")
+	instanceLog(cr).Info("reconciling status")
 	if err := r.reconcileStatus(cr); err != nil {
-		log.Info(err.Error())
+		instanceLog(cr).Info(err.Error())
+	}
+
+	instanceLog(cr).Info("reconciling redis migration")
+	if err := r.reconcileRedisMigration(cr); err != nil {
+		return err
+	}
+
+	// refuse to roll out workloads with images unsupported by the architecture of their nodes
+	if err := validateImageArchitecture(cr); err != nil {
+		return err
 	}
 
-	log.Info("reconciling roles")
+	instanceLog(cr).Info("reconciling roles")
 	if err := r.reconcileRoles(cr); err != nil {
-		log.Info(err.Error())
+		instanceLog(cr).Info(err.Error())
 		return err
 	}
 
-	log.Info("reconciling rolebindings")
+	instanceLog(cr).Info("reconciling rolebindings")
 	if err := r.reconcileRoleBindings(cr); err != nil {
-		log.Info(err.Error())
+		instanceLog(cr).Info(err.Error())
 		return err
 	}
 
-	log.Info("reconciling service accounts")
+	instanceLog(cr).Info("reconciling service accounts")
 	if err := r.reconcileServiceAccounts(cr); err != nil {
-		log.Info(err.Error())
+		instanceLog(cr).Info(err.Error())
 		return err
 	}
 
-	log.Info("reconciling certificate authority")
+	instanceLog(cr).Info("reconciling certificate authority")
 	if err := r.reconcileCertificateAuthority(cr); err != nil {
 		return err
 	}
 
-	log.Info("reconciling secrets")
+	instanceLog(cr).Info("reconciling secrets")
 	if err := r.reconcileSecrets(cr); err != nil {
 		return err
 	}
 
 	useTLSForRedis := r.redisShouldUseTLS(cr)
 
-	log.Info("reconciling config maps")
+	instanceLog(cr).Info("reconciling config maps")
 	if err := r.reconcileConfigMaps(cr, useTLSForRedis); err != nil {
 		return err
 	}
 
-	log.Info("reconciling services")
+	instanceLog(cr).Info("reconciling services")
 	if err := r.reconcileServices(cr); err != nil {
 		return err
 	}
 
-	log.Info("reconciling deployments")
+	instanceLog(cr).Info("reconciling deployments")
 	if err := r.reconcileDeployments(cr, useTLSForRedis); err != nil {
 		return err
 	}
 
-	log.Info("reconciling statefulsets")
+	if err := r.reconcileServerCommandArgs(cr, useTLSForRedis); err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info("reconciling statefulsets")
 	if err := r.reconcileStatefulSets(cr, useTLSForRedis); err != nil {
 		return err
 	}
 
-	log.Info("reconciling autoscalers")
+	instanceLog(cr).Info("reconciling autoscalers")
 	if err := r.reconcileAutoscalers(cr); err != nil {
 		return err
 	}
 
-	log.Info("reconciling ingresses")
+	instanceLog(cr).Info("reconciling ingresses")
 	if err := r.reconcileIngresses(cr); err != nil {
 		return err
 	}
 
 	if IsRouteAPIAvailable() {
-		log.Info("reconciling routes")
+		instanceLog(cr).Info("reconciling routes")
 		if err := r.reconcileRoutes(cr); err != nil {
 			return err
 		}
+
+		instanceLog(cr).Info("reconciling console link")
+		if err := r.reconcileConsoleLink(cr); err != nil {
+			return err
+		}
 	}
 
 	if IsPrometheusAPIAvailable() {
-		log.Info("reconciling prometheus")
+		instanceLog(cr).Info("reconciling prometheus")
 		if err := r.reconcilePrometheus(cr); err != nil {
 			return err
 		}
@@ -837,23 +1191,42 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoproj.ArgoCD) error {
 		if err := r.reconcileServerMetricsServiceMonitor(cr); err != nil {
 			return err
 		}
+
+		if err := r.reconcileRedisHAProxyServiceMonitor(cr); err != nil {
+			return err
+		}
+
+		if err := r.reconcileRedisExporterServiceMonitor(cr); err != nil {
+			return err
+		}
+
+		if err := r.reconcileDexServiceMonitor(cr); err != nil {
+			return err
+		}
 	}
 
 	// check ManagedApplicationSetSourceNamespaces for proper cleanup
 	if cr.Spec.ApplicationSet != nil || len(r.ManagedApplicationSetSourceNamespaces) > 0 {
-		log.Info("reconciling ApplicationSet controller")
+		instanceLog(cr).Info("reconciling ApplicationSet controller")
 		if err := r.reconcileApplicationSetController(cr); err != nil {
 			return err
 		}
 	}
 
 	if cr.Spec.Notifications.Enabled {
-		log.Info("reconciling Notifications controller")
+		instanceLog(cr).Info("reconciling Notifications controller")
 		if err := r.reconcileNotificationsController(cr); err != nil {
 			return err
 		}
 	}
 
+	if cr.Spec.ImageUpdater.Enabled {
+		instanceLog(cr).Info("reconciling Image Updater")
+		if err := r.reconcileImageUpdater(cr); err != nil {
+			return err
+		}
+	}
+
 	if err := r.reconcileRepoServerTLSSecret(cr); err != nil {
 		return err
 	}
@@ -866,11 +1239,31 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	instanceLog(cr).Info("reconciling initial projects and applications")
+	if err := r.reconcileInitialResources(cr); err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info("deleting resources of disabled components")
+	if err := r.deleteDisabledComponentResources(cr); err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info("reconciling label policy")
+	if err := r.reconcileLabelPolicy(cr); err != nil {
+		return err
+	}
+
+	instanceLog(cr).Info("reconciling backup labels")
+	if err := r.reconcileBackupLabels(cr); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func (r *ReconcileArgoCD) deleteClusterResources(cr *argoproj.ArgoCD) error {
-	selector, err := argocdInstanceSelector(cr.Name)
+	selector, err := argocdInstanceSelector(argoutil.ManagedByLabelValue(cr))
 	if err != nil {
 		return err
 	}
@@ -879,6 +1272,13 @@ func (r *ReconcileArgoCD) deleteClusterResources(cr *argoproj.ArgoCD) error {
 	if err := filterObjectsBySelector(r.Client, clusterRoleList, selector); err != nil {
 		return fmt.Errorf("failed to filter ClusterRoles for %s: %w", cr.Name, err)
 	}
+	clusterRoles := clusterRoleList.Items[:0]
+	for _, clusterRole := range clusterRoleList.Items {
+		if isClusterResourceOfInstance(&clusterRole, cr) {
+			clusterRoles = append(clusterRoles, clusterRole)
+		}
+	}
+	clusterRoleList.Items = clusterRoles
 
 	if err := deleteClusterRoles(r.Client, clusterRoleList); err != nil {
 		return err
@@ -888,12 +1288,19 @@ func (r *ReconcileArgoCD) deleteClusterResources(cr *argoproj.ArgoCD) error {
 	if err := filterObjectsBySelector(r.Client, clusterBindingsList, selector); err != nil {
 		return fmt.Errorf("failed to filter ClusterRoleBindings for %s: %w", cr.Name, err)
 	}
+	clusterBindings := clusterBindingsList.Items[:0]
+	for _, clusterBinding := range clusterBindingsList.Items {
+		if isClusterResourceOfInstance(&clusterBinding, cr) {
+			clusterBindings = append(clusterBindings, clusterBinding)
+		}
+	}
+	clusterBindingsList.Items = clusterBindings
 
 	if err := deleteClusterRoleBindings(r.Client, clusterBindingsList); err != nil {
 		return err
 	}
 
-	return nil
+	return r.deleteConsoleLinks(selector)
 }
 
 func (r *ReconcileArgoCD) removeManagedByLabelFromNamespaces(namespace string) error {
@@ -927,6 +1334,13 @@ func (r *ReconcileArgoCD) removeManagedByLabelFromNamespaces(namespace string) e
 	return nil
 }
 
+// isClusterResourceOfInstance returns false if the given cluster-scoped object is annotated as created for another
+// ArgoCD than the given one, as instances may share the value of their managed-by label through their label policy.
+func isClusterResourceOfInstance(obj metav1.Object, cr *argoproj.ArgoCD) bool {
+	name, namespace := obj.GetAnnotations()[common.AnnotationName], obj.GetAnnotations()[common.AnnotationNamespace]
+	return (name == "" || name == cr.Name) && (namespace == "" || namespace == cr.Namespace)
+}
+
 func filterObjectsBySelector(c client.Client, objectList client.ObjectList, selector labels.Selector) error {
 	return c.List(context.TODO(), objectList, client.MatchingLabelsSelector{Selector: selector})
 }
@@ -941,16 +1355,18 @@ func argocdInstanceSelector(name string) (labels.Selector, error) {
 }
 
 func (r *ReconcileArgoCD) removeDeletionFinalizer(argocd *argoproj.ArgoCD) error {
+	patch := client.MergeFrom(argocd.DeepCopy())
 	argocd.Finalizers = removeString(argocd.GetFinalizers(), common.ArgoCDDeletionFinalizer)
-	if err := r.Client.Update(context.TODO(), argocd); err != nil {
+	if err := r.Client.Patch(context.TODO(), argocd, patch); err != nil {
 		return fmt.Errorf("failed to remove deletion finalizer from %s: %w", argocd.Name, err)
 	}
 	return nil
 }
 
 func (r *ReconcileArgoCD) addDeletionFinalizer(argocd *argoproj.ArgoCD) error {
+	patch := client.MergeFrom(argocd.DeepCopy())
 	argocd.Finalizers = append(argocd.Finalizers, common.ArgoCDDeletionFinalizer)
-	if err := r.Client.Update(context.TODO(), argocd); err != nil {
+	if err := r.Client.Patch(context.TODO(), argocd, patch); err != nil {
 		return fmt.Errorf("failed to add deletion finalizer for %s: %w", argocd.Name, err)
 	}
 	return nil
@@ -968,7 +1384,7 @@ func removeString(slice []string, s string) []string {
 }
 
 // setResourceWatches will register Watches for each of the supported Resources.
-func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper, clusterSecretResourceMapper, applicationSetGitlabSCMTLSConfigMapMapper handler.MapFunc) *builder.Builder {
+func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper, clusterSecretResourceMapper, applicationSetGitlabSCMTLSConfigMapMapper, inheritFromMapper handler.MapFunc) *builder.Builder {
 
 	deploymentConfigPred := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -1060,20 +1476,32 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 	// Watch for changes to primary resource ArgoCD
 	bldr.For(&argoproj.ArgoCD{}, builder.WithPredicates(deleteSSOPred, deleteNotificationsPred))
 
+	// Owned resources are reverted as soon as they drift, updates that do not change them are ignored.
+	ownedPred := builder.WithPredicates(ownedResourceChangedPredicate())
+
 	// Watch for changes to ConfigMap sub-resources owned by ArgoCD instances.
-	bldr.Owns(&corev1.ConfigMap{})
+	bldr.Owns(&corev1.ConfigMap{}, ownedPred)
 
 	// Watch for changes to Secret sub-resources owned by ArgoCD instances.
-	bldr.Owns(&corev1.Secret{})
+	bldr.Owns(&corev1.Secret{}, ownedPred)
 
 	// Watch for changes to Service sub-resources owned by ArgoCD instances.
-	bldr.Owns(&corev1.Service{})
+	bldr.Owns(&corev1.Service{}, ownedPred)
+
+	// Watch for changes to ServiceAccount sub-resources owned by ArgoCD instances.
+	bldr.Owns(&corev1.ServiceAccount{}, ownedPred)
 
 	// Watch for changes to Deployment sub-resources owned by ArgoCD instances.
-	bldr.Owns(&appsv1.Deployment{})
+	bldr.Owns(&appsv1.Deployment{}, ownedPred)
 
 	// Watch for changes to Ingress sub-resources owned by ArgoCD instances.
-	bldr.Owns(&networkingv1.Ingress{})
+	bldr.Owns(&networkingv1.Ingress{}, ownedPred)
+
+	// Watch for changes to NetworkPolicy sub-resources owned by ArgoCD instances.
+	bldr.Owns(&networkingv1.NetworkPolicy{}, ownedPred)
+
+	// Watch for changes to HorizontalPodAutoscaler sub-resources owned by ArgoCD instances.
+	bldr.Owns(&autoscaling.HorizontalPodAutoscaler{}, ownedPred)
 
 	bldr.Owns(&v1.Role{})
 
@@ -1095,6 +1523,11 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 		Name: common.ArgoCDAppSetGitlabSCMTLSCertsConfigMapName,
 	}}, appSetGitlabSCMTLSConfigMapHandler)
 
+	// Watch for changes to the templates ArgoCD instances inherit their spec from
+	inheritFromHandler := handler.EnqueueRequestsFromMapFunc(inheritFromMapper)
+	bldr.Watches(&argoproj.ArgoCD{}, inheritFromHandler)
+	bldr.Watches(&corev1.ConfigMap{}, inheritFromHandler)
+
 	// Watch for secrets of type TLS that might be created by external processes
 	bldr.Watches(&corev1.Secret{Type: corev1.SecretTypeTLS}, tlsSecretHandler)
 
@@ -1104,8 +1537,8 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 			common.ArgoCDManagedByClusterArgoCDLabel: "cluster",
 		}}}, clusterSecretResourceHandler)
 
-	// Watch for changes to Secret sub-resources owned by ArgoCD instances.
-	bldr.Owns(&appsv1.StatefulSet{})
+	// Watch for changes to StatefulSet sub-resources owned by ArgoCD instances.
+	bldr.Owns(&appsv1.StatefulSet{}, ownedPred)
 
 	// Inspect cluster to verify availability of extra features
 	// This sets the flags that are used in subsequent checks
@@ -1115,7 +1548,7 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 
 	if IsRouteAPIAvailable() {
 		// Watch OpenShift Route sub-resources owned by ArgoCD instances.
-		bldr.Owns(&routev1.Route{})
+		bldr.Owns(&routev1.Route{}, ownedPred)
 	}
 
 	if IsPrometheusAPIAvailable() {
@@ -1124,6 +1557,9 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 
 		// Watch Prometheus ServiceMonitor sub-resources owned by ArgoCD instances.
 		bldr.Owns(&monitoringv1.ServiceMonitor{})
+
+		// Watch PrometheusRule sub-resources owned by ArgoCD instances.
+		bldr.Owns(&monitoringv1.PrometheusRule{})
 	}
 
 	if CanUseKeycloakWithTemplate() {
@@ -1137,7 +1573,7 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 
 	namespaceHandler := handler.EnqueueRequestsFromMapFunc(namespaceResourceMapper)
 
-	bldr.Watches(&corev1.Namespace{}, namespaceHandler, builder.WithPredicates(namespaceFilterPredicate()))
+	bldr.Watches(&corev1.Namespace{}, namespaceHandler, builder.WithPredicates(r.namespaceFilterPredicate()))
 
 	return bldr
 }
@@ -1204,14 +1640,49 @@ type DeprecationEventEmissionStatus struct {
 	SSOSpecDeprecationWarningEmitted    bool
 	DexSpecDeprecationWarningEmitted    bool
 	DisableDexDeprecationWarningEmitted bool
+	// DeprecatedFieldsWarning is the last warning emitted about the deprecated fields set on the instance.
+	DeprecatedFieldsWarning string
 }
 
-// DeprecationEventEmissionTracker map stores the namespace containing ArgoCD instance as key and DeprecationEventEmissionStatus as value,
-// where DeprecationEventEmissionStatus tracks the events that have been emitted for the instance in the particular namespace.
+// DeprecationEventEmissionTracker map stores the namespaced name of an ArgoCD instance as key and DeprecationEventEmissionStatus as value,
+// where DeprecationEventEmissionStatus tracks the events that have been emitted for the instance.
 // This is temporary and can be removed in v0.0.6 when we remove the deprecated fields.
-var DeprecationEventEmissionTracker = make(map[string]DeprecationEventEmissionStatus)
+var DeprecationEventEmissionTracker = make(map[types.NamespacedName]DeprecationEventEmissionStatus)
+
+// ownedResourceChangedPredicate filters out the update events of owned resources in which only the bookkeeping
+// metadata changed, so that reconciliation is triggered right away by actual drift but not by no-op updates.
+func ownedResourceChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return hasOwnedResourceChanged(e.ObjectOld, e.ObjectNew)
+		},
+	}
+}
+
+// hasOwnedResourceChanged will return true if the given objects differ in anything but their resourceVersion and
+// managedFields.
+func hasOwnedResourceChanged(oldObj, newObj client.Object) bool {
+	if oldObj == nil || newObj == nil {
+		return true
+	}
+	oldCopy, ok := oldObj.DeepCopyObject().(client.Object)
+	if !ok {
+		return true
+	}
+	newCopy, ok := newObj.DeepCopyObject().(client.Object)
+	if !ok {
+		return true
+	}
+	for _, obj := range []client.Object{oldCopy, newCopy} {
+		obj.SetResourceVersion("")
+		obj.SetManagedFields(nil)
+	}
+	return !equality.Semantic.DeepEqual(oldCopy, newCopy)
+}
 
-func namespaceFilterPredicate() predicate.Predicate {
+// namespaceFilterPredicate removes the RBACs and the cluster secret entry of the namespaces no longer managed by an
+// ArgoCD, postponing the cleanup until the end of the dry run of the instance if one is running.
+func (r *ReconcileArgoCD) namespaceFilterPredicate() predicate.Predicate {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			// This checks if ArgoCDManagedByLabel exists in newMeta, if exists then -
@@ -1221,6 +1692,9 @@ func namespaceFilterPredicate() predicate.Predicate {
 			// Event is then handled by the reconciler, which would create appropriate RBACs.
 			if valNew, ok := e.ObjectNew.GetLabels()[common.ArgoCDManagedByLabel]; ok {
 				if valOld, ok := e.ObjectOld.GetLabels()[common.ArgoCDManagedByLabel]; ok && valOld != valNew {
+					if r.deferNamespaceCleanup(valOld, e.ObjectOld.GetName()) {
+						return true
+					}
 					k8sClient, err := initK8sClient()
 					if err != nil {
 						return false
@@ -1243,6 +1717,9 @@ func namespaceFilterPredicate() predicate.Predicate {
 			// This checks if the old meta had the label, if it did, delete the RBACs for the namespace
 			// which were created when the label was added to the namespace.
 			if ns, ok := e.ObjectOld.GetLabels()[common.ArgoCDManagedByLabel]; ok && ns != "" {
+				if r.deferNamespaceCleanup(ns, e.ObjectOld.GetName()) {
+					return false
+				}
 				k8sClient, err := initK8sClient()
 				if err != nil {
 					return false
@@ -1264,7 +1741,7 @@ func namespaceFilterPredicate() predicate.Predicate {
 			return false
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
-			if ns, ok := e.Object.GetLabels()[common.ArgoCDManagedByLabel]; ok && ns != "" {
+			if ns, ok := e.Object.GetLabels()[common.ArgoCDManagedByLabel]; ok && ns != "" && !r.deferNamespaceCleanup(ns, e.Object.GetName()) {
 				k8sClient, err := initK8sClient()
 
 				if err != nil {
@@ -1279,9 +1756,13 @@ func namespaceFilterPredicate() predicate.Predicate {
 				}
 			}
 
-			// if a namespace is deleted, remove it from deprecationEventEmissionTracker (if exists) so that if a namespace with the same name
-			// is created in the future and contains an Argo CD instance, it will be tracked appropriately
-			delete(DeprecationEventEmissionTracker, e.Object.GetName())
+			// if a namespace is deleted, remove its instances from deprecationEventEmissionTracker (if any) so that if a namespace with the
+			// same name is created in the future and contains an Argo CD instance, it will be tracked appropriately
+			for key := range DeprecationEventEmissionTracker {
+				if key.Namespace == e.Object.GetName() {
+					delete(DeprecationEventEmissionTracker, key)
+				}
+			}
 			return false
 		},
 	}
@@ -1301,6 +1782,9 @@ func deleteRBACsForNamespace(sourceNS string, k8sClient kubernetes.Interface) er
 
 	// Delete all the retrieved roles
 	for _, role := range roles.Items {
+		if isOwnedByArgoCD(&role) {
+			continue // belongs to an Argo CD instance of the namespace itself
+		}
 		err = k8sClient.RbacV1().Roles(sourceNS).Delete(context.TODO(), role.Name, metav1.DeleteOptions{})
 		if err != nil {
 			log.Error(err, fmt.Sprintf("failed to delete roles for namespace: %s", sourceNS))
@@ -1316,6 +1800,9 @@ func deleteRBACsForNamespace(sourceNS string, k8sClient kubernetes.Interface) er
 
 	// Delete all the retrieved role bindings
 	for _, roleBinding := range roleBindings.Items {
+		if isOwnedByArgoCD(&roleBinding) {
+			continue // belongs to an Argo CD instance of the namespace itself
+		}
 		err = k8sClient.RbacV1().RoleBindings(sourceNS).Delete(context.TODO(), roleBinding.Name, metav1.DeleteOptions{})
 		if err != nil {
 			log.Error(err, fmt.Sprintf("failed to delete role binding for namespace: %s", sourceNS))
@@ -1325,6 +1812,13 @@ func deleteRBACsForNamespace(sourceNS string, k8sClient kubernetes.Interface) er
 	return nil
 }
 
+// isOwnedByArgoCD returns true if the given object is controlled by an ArgoCD instance. Only the RBACs created in the
+// namespace of an ArgoCD instance are owned by it, those created in the namespaces it manages are not.
+func isOwnedByArgoCD(obj metav1.Object) bool {
+	owner := metav1.GetControllerOf(obj)
+	return owner != nil && owner.Kind == "ArgoCD"
+}
+
 func deleteManagedNamespaceFromClusterSecret(ownerNS, sourceNS string, k8sClient kubernetes.Interface) error {
 
 	// Get the cluster secret used for configuring ArgoCD
@@ -1475,7 +1969,7 @@ func (r *ReconcileArgoCD) removeUnmanagedSourceNamespaceResources(cr *argoproj.A
 
 		if !managedNamespace {
 			if err := r.cleanupUnmanagedSourceNamespaceResources(cr, ns); err != nil {
-				log.Error(err, fmt.Sprintf("error cleaning up resources for namespace %s", ns))
+				instanceLog(cr).Error(err, fmt.Sprintf("error cleaning up resources for namespace %s", ns))
 				continue
 			}
 			delete(r.ManagedSourceNamespaces, ns)
@@ -1495,7 +1989,7 @@ func (r *ReconcileArgoCD) cleanupUnmanagedSourceNamespaceResources(cr *argoproj.
 	// Remove managed-by-cluster-argocd from the namespace
 	delete(namespace.Labels, common.ArgoCDManagedByClusterArgoCDLabel)
 	if err := r.Client.Update(context.TODO(), &namespace); err != nil {
-		log.Error(err, fmt.Sprintf("failed to remove label from namespace [%s]", namespace.Name))
+		instanceLog(cr).Error(err, fmt.Sprintf("failed to remove label from namespace [%s]", namespace.Name))
 	}
 
 	// Delete Roles for SourceNamespaces
@@ -1609,6 +2103,175 @@ func AddSeccompProfileForOpenShift(client client.Client, podspec *corev1.PodSpec
 	}
 }
 
+// applySecurityContext will set the pod security context of the given pod spec, along with the security context
+// of the containers with the given names. The overrides of the component replace the defaults, which run the
+// pod as non-root with the RuntimeDefault seccomp profile, so that the pod passes the restricted Pod Security Standard.
+func applySecurityContext(podSpec *corev1.PodSpec, podOverride *corev1.PodSecurityContext, containerOverride *corev1.SecurityContext, containers ...string) {
+	if podOverride != nil {
+		podSpec.SecurityContext = podOverride.DeepCopy()
+	} else {
+		if podSpec.SecurityContext == nil {
+			podSpec.SecurityContext = &corev1.PodSecurityContext{}
+		}
+		if podSpec.SecurityContext.RunAsNonRoot == nil {
+			podSpec.SecurityContext.RunAsNonRoot = boolPtr(true)
+		}
+		if podSpec.SecurityContext.SeccompProfile == nil {
+			podSpec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			}
+		}
+	}
+
+	if containerOverride == nil {
+		return
+	}
+	for i := range podSpec.Containers {
+		if containsString(containers, podSpec.Containers[i].Name) {
+			podSpec.Containers[i].SecurityContext = containerOverride.DeepCopy()
+		}
+	}
+}
+
+// updateSecurityContext will update the pod and container security contexts of the existing pod spec to match the desired pod spec.
+func updateSecurityContext(existing *corev1.PodSpec, desired *corev1.PodSpec, changed *bool) {
+	if !reflect.DeepEqual(existing.SecurityContext, desired.SecurityContext) {
+		existing.SecurityContext = desired.SecurityContext
+		*changed = true
+	}
+	for i := range existing.Containers {
+		for j := range desired.Containers {
+			if existing.Containers[i].Name != desired.Containers[j].Name {
+				continue
+			}
+			if !reflect.DeepEqual(existing.Containers[i].SecurityContext, desired.Containers[j].SecurityContext) {
+				existing.Containers[i].SecurityContext = desired.Containers[j].SecurityContext
+				*changed = true
+			}
+		}
+	}
+}
+
+// applyTerminationGracePeriod will set the termination grace period of the given pod spec when the component overrides it.
+func applyTerminationGracePeriod(podSpec *corev1.PodSpec, seconds *int64) {
+	if seconds != nil {
+		podSpec.TerminationGracePeriodSeconds = int64Ptr(*seconds)
+	}
+}
+
+// updateTerminationGracePeriod will update the termination grace period of the existing pod spec to match the desired
+// pod spec. An unset period is compared as the Kubernetes default, which the API server fills in.
+func updateTerminationGracePeriod(existing *corev1.PodSpec, desired *corev1.PodSpec, changed *bool) {
+	effective := func(seconds *int64) int64 {
+		if seconds == nil {
+			return corev1.DefaultTerminationGracePeriodSeconds
+		}
+		return *seconds
+	}
+	if effective(existing.TerminationGracePeriodSeconds) != effective(desired.TerminationGracePeriodSeconds) {
+		existing.TerminationGracePeriodSeconds = desired.TerminationGracePeriodSeconds
+		*changed = true
+	}
+}
+
+// applyDNSConfig will set the DNS policy and configuration of the given pod spec when the component overrides them.
+func applyDNSConfig(podSpec *corev1.PodSpec, policy corev1.DNSPolicy, config *corev1.PodDNSConfig) {
+	if policy != "" {
+		podSpec.DNSPolicy = policy
+	}
+	if config != nil {
+		podSpec.DNSConfig = config.DeepCopy()
+	}
+}
+
+// updateDNSConfig will update the DNS policy and configuration of the existing pod spec to match the desired pod spec.
+// An unset policy is compared as ClusterFirst, which the API server fills in.
+func updateDNSConfig(existing *corev1.PodSpec, desired *corev1.PodSpec, changed *bool) {
+	effective := func(policy corev1.DNSPolicy) corev1.DNSPolicy {
+		if policy == "" {
+			return corev1.DNSClusterFirst
+		}
+		return policy
+	}
+	if effective(existing.DNSPolicy) != effective(desired.DNSPolicy) {
+		existing.DNSPolicy = desired.DNSPolicy
+		*changed = true
+	}
+	if !reflect.DeepEqual(existing.DNSConfig, desired.DNSConfig) {
+		existing.DNSConfig = desired.DNSConfig
+		*changed = true
+	}
+}
+
+// applyPodMetadata will add the given labels and annotations of a component to the given pod template, and record
+// their keys in the annotations of the template. The label selecting the pods of the component is left untouched.
+func applyPodMetadata(template *corev1.PodTemplateSpec, labels map[string]string, annotations map[string]string) {
+	labelKeys := make([]string, 0, len(labels))
+	for key, value := range labels {
+		if key == common.ArgoCDKeyName {
+			continue // Selects the pods of the component
+		}
+		if template.Labels == nil {
+			template.Labels = map[string]string{}
+		}
+		template.Labels[key] = value
+		labelKeys = append(labelKeys, key)
+	}
+	annotationKeys := make([]string, 0, len(annotations))
+	for key, value := range annotations {
+		if template.Annotations == nil {
+			template.Annotations = map[string]string{}
+		}
+		template.Annotations[key] = value
+		annotationKeys = append(annotationKeys, key)
+	}
+
+	sort.Strings(labelKeys)
+	sort.Strings(annotationKeys)
+	if len(labelKeys) > 0 {
+		template.Annotations = argoutil.AppendStringMap(template.Annotations, map[string]string{common.ArgoCDPodLabelsAnnotation: strings.Join(labelKeys, ",")})
+	}
+	if len(annotationKeys) > 0 {
+		template.Annotations = argoutil.AppendStringMap(template.Annotations, map[string]string{common.ArgoCDPodAnnotationsAnnotation: strings.Join(annotationKeys, ",")})
+	}
+}
+
+// updatePodMetadata will update the labels and annotations set by applyPodMetadata on the existing pod template to
+// match the desired pod template, removing the ones no longer given. The other labels and annotations of the
+// template, e.g. the restart annotation of kubectl rollout restart, are left untouched.
+func updatePodMetadata(existing *corev1.PodTemplateSpec, desired *corev1.PodTemplateSpec, changed *bool) {
+	splitKeys := func(annotations map[string]string, key string) []string {
+		if annotations[key] == "" {
+			return nil
+		}
+		return strings.Split(annotations[key], ",")
+	}
+	sync := func(current map[string]string, wanted map[string]string, keys []string) map[string]string {
+		for _, key := range keys {
+			value, ok := wanted[key]
+			if currentValue, exists := current[key]; exists == ok && currentValue == value {
+				continue
+			}
+			if ok {
+				if current == nil {
+					current = map[string]string{}
+				}
+				current[key] = value
+			} else {
+				delete(current, key)
+			}
+			*changed = true
+		}
+		return current
+	}
+
+	labelKeys := append(splitKeys(existing.Annotations, common.ArgoCDPodLabelsAnnotation), splitKeys(desired.Annotations, common.ArgoCDPodLabelsAnnotation)...)
+	annotationKeys := append(splitKeys(existing.Annotations, common.ArgoCDPodAnnotationsAnnotation), splitKeys(desired.Annotations, common.ArgoCDPodAnnotationsAnnotation)...)
+	annotationKeys = append(annotationKeys, common.ArgoCDPodLabelsAnnotation, common.ArgoCDPodAnnotationsAnnotation)
+	existing.Labels = sync(existing.Labels, desired.Labels, labelKeys)
+	existing.Annotations = sync(existing.Annotations, desired.Annotations, annotationKeys)
+}
+
 // getClusterVersion returns the OpenShift Cluster version in which the operator is installed
 func getClusterVersion(client client.Client) (string, error) {
 	if !IsVersionAPIAvailable() {
@@ -19,6 +19,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	testclient "k8s.io/client-go/kubernetes/fake"
@@ -700,6 +701,34 @@ func TestDeleteRBACsForNamespace(t *testing.T) {
 
 }
 
+func TestDeleteRBACsForNamespace_ownedByArgoCD(t *testing.T) {
+	a := makeTestArgoCD()
+	testClient := testclient.NewSimpleClientset()
+	testNameSpace := "testNameSpace"
+
+	// RBACs of an Argo CD instance running in the namespace are owned by it
+	owner := metav1.OwnerReference{APIVersion: "argoproj.io/v1beta1", Kind: "ArgoCD", Name: "local", Controller: boolPtr(true)}
+
+	role := newRole("xyz", policyRuleForApplicationController(), a)
+	role.Namespace = testNameSpace
+	role.OwnerReferences = []metav1.OwnerReference{owner}
+	_, err := testClient.RbacV1().Roles(testNameSpace).Create(context.TODO(), role, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	roleBinding := newRoleBindingWithname("xyz", a)
+	roleBinding.Namespace = testNameSpace
+	roleBinding.OwnerReferences = []metav1.OwnerReference{owner}
+	_, err = testClient.RbacV1().RoleBindings(testNameSpace).Create(context.TODO(), roleBinding, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, deleteRBACsForNamespace(testNameSpace, testClient))
+
+	_, err = testClient.RbacV1().Roles(testNameSpace).Get(context.TODO(), role.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	_, err = testClient.RbacV1().RoleBindings(testNameSpace).Get(context.TODO(), roleBinding.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+}
+
 func TestRemoveManagedNamespaceFromClusterSecretAfterDeletion(t *testing.T) {
 	a := makeTestArgoCD()
 	testClient := testclient.NewSimpleClientset()
@@ -1094,3 +1123,117 @@ func TestReconcileArgoCD_reconcileDexOAuthClientSecret(t *testing.T) {
 	}
 	assert.True(t, tokenExists, "Dex is enabled but unable to create oauth client secret")
 }
+
+func TestHasOwnedResourceChanged(t *testing.T) {
+	old := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-cm", Namespace: "argocd", ResourceVersion: "1"},
+		Data:       map[string]string{"url": "https://argocd.example.com"},
+	}
+
+	// Only the bookkeeping metadata changed
+	noop := old.DeepCopy()
+	noop.ResourceVersion = "2"
+	noop.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+	assert.False(t, hasOwnedResourceChanged(old, noop))
+
+	// The data drifted from the desired state
+	drifted := noop.DeepCopy()
+	drifted.Data["url"] = "https://changed.example.com"
+	assert.True(t, hasOwnedResourceChanged(old, drifted))
+
+	// The labels drifted from the desired state
+	relabeled := noop.DeepCopy()
+	relabeled.Labels = map[string]string{"app": "changed"}
+	assert.True(t, hasOwnedResourceChanged(old, relabeled))
+}
+
+func TestGetRedisHAProxyConfig_haproxyOverrides(t *testing.T) {
+	t.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+
+	// The default timeouts are kept without overrides
+	conf := getRedisHAProxyConfig(a, false)
+	assert.Contains(t, conf, "    timeout connect 4s\n    timeout server 6m\n    timeout client 6m\n    timeout check 2s\n")
+	assert.NotContains(t, conf, "global")
+	assert.NotContains(t, conf, "maxconn")
+
+	a.Spec.HA.HAProxy = &argoproj.ArgoCDHAProxySpec{
+		Timeouts: &argoproj.ArgoCDHAProxyTimeoutsSpec{
+			Connect: "10s",
+			Client:  "30m",
+		},
+		MaxConn: 4096,
+		AdditionalBackends: []argoproj.ArgoCDHAProxyBackendSpec{{
+			Name:   "bk_redis_replicas",
+			Config: []string{"mode tcp", "server R0 example-argocd-redis-ha-announce-0:6379 check inter 3s"},
+		}},
+		ConfigOverrides: "frontend ft_redis_replicas\n    bind *:6380\n    use_backend bk_redis_replicas\n",
+	}
+	conf = getRedisHAProxyConfig(a, false)
+	assert.Contains(t, conf, "global\n    maxconn 4096\n")
+	assert.Contains(t, conf, "    timeout connect 10s\n    timeout server 6m\n    timeout client 30m\n    timeout check 2s\n")
+	assert.Contains(t, conf, "\n\nbackend bk_redis_replicas\n    mode tcp\n    server R0 example-argocd-redis-ha-announce-0:6379 check inter 3s\n")
+	assert.True(t, strings.HasSuffix(conf, "\n\nfrontend ft_redis_replicas\n    bind *:6380\n    use_backend bk_redis_replicas\n"))
+
+	// The maximum connections are set along the TLS settings
+	conf = getRedisHAProxyConfig(a, true)
+	assert.Contains(t, conf, "global\n    ca-base /app/config/redis/tls\n    maxconn 4096\n")
+}
+
+func TestGetRedisConf_memoryAndSave(t *testing.T) {
+	t.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+	a := makeTestArgoCD()
+
+	conf := getRedisConf(a, false)
+	assert.Contains(t, conf, "maxmemory 0\n")
+	assert.Contains(t, conf, "maxmemory-policy volatile-lru\n")
+	assert.Contains(t, conf, "save \"\"\n")
+	assert.Equal(t, []string{"--save", "", "--appendonly", "no", "--requirepass $(REDIS_PASSWORD)"}, getArgoRedisArgs(a, false))
+
+	a.Spec.Redis.MaxMemory = "2gb"
+	a.Spec.Redis.MaxMemoryPolicy = "allkeys-lru"
+	a.Spec.Redis.Save = "3600 1 300 100"
+
+	conf = getRedisConf(a, false)
+	assert.Contains(t, conf, "maxmemory 2gb\n")
+	assert.Contains(t, conf, "maxmemory-policy allkeys-lru\n")
+	assert.Contains(t, conf, "save 3600 1\nsave 300 100\n")
+	assert.NotContains(t, conf, "save \"\"")
+	assert.Equal(t, []string{"--save", "3600", "1", "--save", "300", "100", "--appendonly", "no",
+		"--maxmemory", "2gb", "--maxmemory-policy", "allkeys-lru", "--requirepass $(REDIS_PASSWORD)"},
+		getArgoRedisArgs(a, false))
+}
+
+func TestWithResourceDefaults(t *testing.T) {
+	defaults := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("250m"),
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+	}
+
+	// The defaults fill in the resources not set, unless they would make the requirements invalid
+	result := withResourceDefaults(resources, defaults)
+	assert.Equal(t, corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")}, result.Requests)
+	assert.Equal(t, corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}, result.Limits)
+
+	result = withResourceDefaults(corev1.ResourceRequirements{}, defaults)
+	assert.Equal(t, *defaults, result)
+
+	// The given requirements are left untouched
+	resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}
+	result = withResourceDefaults(resources, defaults)
+	assert.Equal(t, corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("256Mi")}, result.Requests)
+	assert.Len(t, resources.Requests, 1)
+	assert.Len(t, resources.Limits, 1)
+}
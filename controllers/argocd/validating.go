@@ -0,0 +1,154 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+//+kubebuilder:webhook:path=/validate-argoproj-io-v1beta1-argocd,mutating=false,failurePolicy=ignore,sideEffects=None,groups=argoproj.io,resources=argocds,verbs=create;update,versions=v1beta1,name=vargocd.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-argoproj-io-v1alpha1-argocd,mutating=false,failurePolicy=ignore,sideEffects=None,groups=argoproj.io,resources=argocds,verbs=create;update,versions=v1alpha1,name=vargocdv1alpha1.kb.io,admissionReviewVersions=v1,matchPolicy=Exact
+
+// argoCDValidator validates the ArgoCD resources at admission time, so that invalid instances are refused before
+// they are reconciled.
+type argoCDValidator struct{}
+
+var _ admission.CustomValidator = &argoCDValidator{}
+
+// argoCDAlphaValidator warns at admission time about the deprecated fields of the v1alpha1 ArgoCD resources that are
+// dropped when they are converted to v1beta1, and so are not seen by the argoCDValidator.
+type argoCDAlphaValidator struct{}
+
+var _ admission.CustomValidator = &argoCDAlphaValidator{}
+
+// SetupValidatingWebhookWithManager will register the validating webhooks of the ArgoCD resources with the given manager.
+func SetupValidatingWebhookWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&argoproj.ArgoCD{}).
+		WithValidator(&argoCDValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&argoprojv1alpha1.ArgoCD{}).
+		WithValidator(&argoCDAlphaValidator{}).
+		Complete()
+}
+
+// ValidateCreate will validate the given ArgoCD.
+func (v *argoCDValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr, ok := obj.(*argoproj.ArgoCD)
+	if !ok {
+		return nil, fmt.Errorf("expected an ArgoCD but got %T", obj)
+	}
+	warnings, err := validateResourceBudget(cr, nil)
+	warnings = append(warnings, getDeprecationWarnings(getDeprecatedFields(cr))...)
+	if message := getRBACPolicySizeWarning(cr); message != "" {
+		warnings = append(warnings, message)
+	}
+	if message := getLogLevelsWarning(cr); message != "" {
+		warnings = append(warnings, message)
+	}
+	if message := getTLSCipherSuitesWarning(cr); message != "" {
+		warnings = append(warnings, message)
+	}
+	return warnings, err
+}
+
+// ValidateUpdate will validate the given updated ArgoCD.
+func (v *argoCDValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	cr, ok := newObj.(*argoproj.ArgoCD)
+	if !ok {
+		return nil, fmt.Errorf("expected an ArgoCD but got %T", newObj)
+	}
+	old, ok := oldObj.(*argoproj.ArgoCD)
+	if !ok {
+		return nil, fmt.Errorf("expected an ArgoCD but got %T", oldObj)
+	}
+	warnings, err := validateResourceBudget(cr, old)
+	warnings = append(warnings, getDeprecationWarnings(getDeprecatedFields(cr))...)
+	if message := getRBACPolicySizeWarning(cr); message != "" {
+		warnings = append(warnings, message)
+	}
+	if message := getLogLevelsWarning(cr); message != "" {
+		warnings = append(warnings, message)
+	}
+	if message := getTLSCipherSuitesWarning(cr); message != "" {
+		warnings = append(warnings, message)
+	}
+	return warnings, err
+}
+
+// ValidateDelete allows the deletion of any ArgoCD.
+func (v *argoCDValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateResourceBudget will refuse the given ArgoCD when its components exceed its enforced resource budget, or
+// warn about it otherwise. An update of an instance already exceeding its budget is allowed as long as it does not
+// request more, so that the instance can be brought back within its budget step by step.
+func validateResourceBudget(cr *argoproj.ArgoCD, old *argoproj.ArgoCD) (admission.Warnings, error) {
+	err := checkResourceBudget(cr, getAdmissionControllerReplicas(cr))
+	if err == nil {
+		return nil, nil
+	}
+	if !isResourceBudgetEnforced(cr) {
+		return admission.Warnings{err.Error()}, nil
+	}
+
+	if old != nil && checkResourceBudget(old, getAdmissionControllerReplicas(old)) != nil {
+		usage := getResourceBudgetUsage(cr, getAdmissionControllerReplicas(cr))
+		oldUsage := getResourceBudgetUsage(old, getAdmissionControllerReplicas(old))
+		grows := false
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			total, oldTotal := usage[name], oldUsage[name]
+			if total.Cmp(oldTotal) > 0 {
+				grows = true
+			}
+		}
+		if !grows {
+			return admission.Warnings{err.Error()}, nil
+		}
+	}
+	return nil, err
+}
+
+// ValidateCreate will warn about the deprecated fields of the given v1alpha1 ArgoCD.
+func (v *argoCDAlphaValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr, ok := obj.(*argoprojv1alpha1.ArgoCD)
+	if !ok {
+		return nil, fmt.Errorf("expected an ArgoCD but got %T", obj)
+	}
+	return getDeprecationWarnings(getAlphaDeprecatedFields(cr)), nil
+}
+
+// ValidateUpdate will warn about the deprecated fields of the given updated v1alpha1 ArgoCD.
+func (v *argoCDAlphaValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+// ValidateDelete allows the deletion of any v1alpha1 ArgoCD.
+func (v *argoCDAlphaValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestArgoCDValidator_resourceBudget(t *testing.T) {
+	v := &argoCDValidator{}
+
+	a := makeTestResourceBudgetArgoCD()
+	warnings, err := v.ValidateCreate(context.TODO(), a)
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	// Three controller shards exceed the budget
+	over := makeTestResourceBudgetArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Controller.Sharding.Enabled = true
+		a.Spec.Controller.Sharding.Replicas = 3
+	})
+	_, err = v.ValidateCreate(context.TODO(), over)
+	assert.ErrorContains(t, err, "exceed the resource budget")
+
+	_, err = v.ValidateUpdate(context.TODO(), a, over)
+	assert.ErrorContains(t, err, "exceed the resource budget")
+
+	// An instance over its budget may be updated as long as it does not request more
+	fewer := over.DeepCopy()
+	fewer.Spec.Controller.Sharding.Replicas = 2
+	warnings, err = v.ValidateUpdate(context.TODO(), over, fewer)
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+
+	// The Warn policy only warns
+	over.Spec.ResourceBudget.Policy = argoproj.ArgoCDResourceBudgetPolicyWarn
+	warnings, err = v.ValidateCreate(context.TODO(), over)
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+}
+
+func TestArgoCDValidator_deprecatedFields(t *testing.T) {
+	v := &argoCDValidator{}
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.RepositoryCredentials = "- url: https://github.com/argoproj"
+	})
+	warnings, err := v.ValidateCreate(context.TODO(), a)
+	assert.NoError(t, err)
+	assert.Equal(t, admission.Warnings{"spec.repositoryCredentials is deprecated and will be removed in a future release, use spec.repositories instead"}, warnings)
+
+	warnings, err = v.ValidateUpdate(context.TODO(), makeTestArgoCD(), a)
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+
+	alpha := &argoCDAlphaValidator{}
+	warnings, err = alpha.ValidateCreate(context.TODO(), &argoprojv1alpha1.ArgoCD{
+		Spec: argoprojv1alpha1.ArgoCDSpec{
+			Dex: &argoprojv1alpha1.ArgoCDDexSpec{OpenShiftOAuth: true},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "spec.dex is deprecated")
+}
@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	logr "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -42,6 +43,8 @@ type ReconcileArgoCDExport struct {
 	// that reads objects from the cache and writes to the apiserver
 	Client client.Client
 	Scheme *runtime.Scheme
+	// Options of the controller, e.g. the number of concurrent reconciles and the rate limiter
+	ControllerOptions controller.Options
 }
 
 //+kubebuilder:rbac:groups=argoproj.io,resources=argocdexports;argocdexports/finalizers;argocdexports/status,verbs=*
@@ -79,7 +82,7 @@ func (r *ReconcileArgoCDExport) Reconcile(ctx context.Context, request ctrl.Requ
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReconcileArgoCDExport) SetupWithManager(mgr ctrl.Manager) error {
-	bld := ctrl.NewControllerManagedBy(mgr)
+	bld := ctrl.NewControllerManagedBy(mgr).WithOptions(r.ControllerOptions)
 	setResourceWatches(bld)
 	return bld.Complete(r)
 }
@@ -56,7 +56,12 @@ func (r *ReconcileArgoCDExport) reconcileExport(cr *argoprojv1alpha1.ArgoCDExpor
 		}
 	}
 
-	return nil
+	log.Info("reconciling export trigger job")
+	if err := r.reconcileTriggerJob(cr); err != nil {
+		return err
+	}
+
+	return r.reconcileExportStatus(cr)
 }
 
 // reconcileExportSecret will ensure that the Secret used for the export process is present.
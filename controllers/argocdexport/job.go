@@ -16,13 +16,17 @@ package argocdexport
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -45,6 +49,31 @@ func getArgoExportCommand(cr *argoproj.ArgoCDExport) []string {
 func getArgoExportContainerEnv(cr *argoproj.ArgoCDExport) []corev1.EnvVar {
 	env := make([]corev1.EnvVar, 0)
 
+	if cr.Spec.Incremental {
+		env = append(env, corev1.EnvVar{
+			Name:  "BACKUP_INCREMENTAL",
+			Value: "true",
+		})
+	}
+
+	maxBackups := common.ArgoCDDefaultExportMaxBackups
+	if cr.Spec.Retention != nil && cr.Spec.Retention.MaxBackups != nil {
+		maxBackups = int(*cr.Spec.Retention.MaxBackups)
+	}
+	env = append(env, corev1.EnvVar{
+		Name:  "BACKUP_RETENTION_MAX_BACKUPS",
+		Value: strconv.Itoa(maxBackups),
+	})
+
+	if cr.Spec.Retention != nil {
+		if cr.Spec.Retention.MaxAge != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  "BACKUP_RETENTION_MAX_AGE",
+				Value: strconv.FormatInt(int64(cr.Spec.Retention.MaxAge.Seconds()), 10),
+			})
+		}
+	}
+
 	switch cr.Spec.Storage.Backend {
 	case common.ArgoCDExportStorageBackendAWS:
 		env = append(env, corev1.EnvVar{
@@ -154,6 +183,13 @@ func newJob(cr *argoproj.ArgoCDExport) *batchv1.Job {
 	}
 }
 
+// newTriggerJob returns a new Job instance for the one-shot exports triggered on the given ArgoCDExport.
+func newTriggerJob(cr *argoproj.ArgoCDExport) *batchv1.Job {
+	job := newJob(cr)
+	job.Name = fmt.Sprintf("%s-trigger", cr.Name)
+	return job
+}
+
 // newCronJob returns a new CronJob instance for the given ArgoCDExport.
 func newCronJob(cr *argoproj.ArgoCDExport) *batchv1.CronJob {
 	return &batchv1.CronJob{
@@ -229,8 +265,18 @@ func (r *ReconcileArgoCDExport) reconcileCronJob(cr *argoproj.ArgoCDExport) erro
 
 	cj := newCronJob(cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cj.Name, cj) {
+		changed := false
 		if *cr.Spec.Schedule != cj.Spec.Schedule {
 			cj.Spec.Schedule = *cr.Spec.Schedule
+			changed = true
+		}
+		env := getArgoExportContainerEnv(cr)
+		containers := cj.Spec.JobTemplate.Spec.Template.Spec.Containers
+		if len(containers) > 0 && (len(containers[0].Env) > 0 || len(env) > 0) && !reflect.DeepEqual(containers[0].Env, env) {
+			containers[0].Env = env
+			changed = true
+		}
+		if changed {
 			return r.Client.Update(context.TODO(), cj)
 		}
 		return nil
@@ -298,3 +344,118 @@ func (r *ReconcileArgoCDExport) argocdName(namespace string) (string, error) {
 	argocd := argocds.Items[0]
 	return argocd.Name, nil
 }
+
+// reconcileTriggerJob will ensure that a one-shot export Job is started each time the Trigger of the ArgoCDExport is
+// set to a new value. The Job of the previous trigger, if any, is replaced.
+func (r *ReconcileArgoCDExport) reconcileTriggerJob(cr *argoproj.ArgoCDExport) error {
+	if cr.Spec.Storage == nil || len(cr.Spec.Trigger) <= 0 || cr.Spec.Trigger == cr.Status.LastTrigger {
+		return nil // Do nothing if storage options not set or no new trigger
+	}
+
+	job := newTriggerJob(cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, job.Name, job) {
+		log.Info(fmt.Sprintf("deleting the export job %s of the previous trigger", job.Name))
+		if err := r.Client.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		job = newTriggerJob(cr)
+	}
+
+	argocdName, err := r.argocdName(cr.Namespace)
+	if err != nil {
+		return err
+	}
+	job.Spec.Template = newPodTemplateSpec(cr, argocdName, r.Client)
+
+	if err := controllerutil.SetControllerReference(cr, job, r.Scheme); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("creating export job %s for trigger %s", job.Name, cr.Spec.Trigger))
+	if err := r.Client.Create(context.TODO(), job); err != nil {
+		return err
+	}
+
+	cr.Status.LastTrigger = cr.Spec.Trigger
+	return r.Client.Status().Update(context.TODO(), cr)
+}
+
+// exportResult is the termination message written by the export container once the export data has been stored.
+type exportResult struct {
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
+// getLastCompletedJob will return the export Job of the given ArgoCDExport that completed last, whether it was run by
+// the operator, by the CronJob or for a trigger. Nil is returned when no export Job completed.
+func (r *ReconcileArgoCDExport) getLastCompletedJob(cr *argoproj.ArgoCDExport) (*batchv1.Job, error) {
+	jobs := &batchv1.JobList{}
+	if err := r.Client.List(context.TODO(), jobs, client.InNamespace(cr.Namespace)); err != nil {
+		return nil, err
+	}
+
+	cj := newCronJob(cr)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, cj.Name, cj) {
+		cj = nil
+	}
+
+	var last *batchv1.Job
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if !metav1.IsControlledBy(job, cr) && (cj == nil || !metav1.IsControlledBy(job, cj)) {
+			continue
+		}
+		if job.Status.Succeeded == 0 || job.Status.CompletionTime == nil {
+			continue
+		}
+		if last == nil || last.Status.CompletionTime.Before(job.Status.CompletionTime) {
+			last = job
+		}
+	}
+	return last, nil
+}
+
+// reconcileExportStatus will report the time, the size and the checksum of the last successful export of the
+// ArgoCDExport, whether it was run by the Job, the CronJob or a trigger. The pods of an export Job are only read once
+// it completed after the last export reported, to get the result of the export from their termination message.
+func (r *ReconcileArgoCDExport) reconcileExportStatus(cr *argoproj.ArgoCDExport) error {
+	job, err := r.getLastCompletedJob(cr)
+	if err != nil {
+		return err
+	}
+	if job == nil || (cr.Status.LastExportTime != nil && !cr.Status.LastExportTime.Before(job.Status.CompletionTime)) {
+		return nil // No newer export, move along...
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.Client.List(context.TODO(), pods, client.InNamespace(cr.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return err
+	}
+
+	var last *corev1.ContainerStateTerminated
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			terminated := status.State.Terminated
+			if status.Name != "argocd-export" || terminated == nil || terminated.ExitCode != 0 {
+				continue
+			}
+			if last == nil || last.FinishedAt.Before(&terminated.FinishedAt) {
+				last = terminated
+			}
+		}
+	}
+
+	result := exportResult{}
+	if last != nil && len(last.Message) > 0 {
+		if err := json.Unmarshal([]byte(last.Message), &result); err != nil {
+			log.Error(err, fmt.Sprintf("failed to parse the result of the export %s/%s", cr.Namespace, cr.Name))
+		}
+	}
+
+	cr.Status.LastExportTime = job.Status.CompletionTime.DeepCopy()
+	cr.Status.LastExportSize = result.Size
+	cr.Status.LastExportChecksum = result.Checksum
+	return r.Client.Status().Update(context.TODO(), cr)
+}
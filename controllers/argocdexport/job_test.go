@@ -0,0 +1,368 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdexport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func makeTestArgoCDExport(opts ...func(*argoproj.ArgoCDExport)) *argoproj.ArgoCDExport {
+	a := &argoproj.ArgoCDExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "argocdexport",
+			Namespace: "argocd",
+		},
+		Spec: argoproj.ArgoCDExportSpec{
+			Argocd:  "argocd",
+			Storage: &argoproj.ArgoCDExportStorageSpec{Backend: "local"},
+		},
+	}
+	for _, o := range opts {
+		o(a)
+	}
+	return a
+}
+
+func TestGetArgoExportContainerEnv(t *testing.T) {
+	maxBackups := int32(7)
+
+	tests := []struct {
+		name string
+		opts []func(*argoproj.ArgoCDExport)
+		want []corev1.EnvVar
+	}{
+		{
+			name: "default",
+			want: []corev1.EnvVar{
+				{Name: "BACKUP_RETENTION_MAX_BACKUPS", Value: "30"},
+			},
+		},
+		{
+			name: "incremental",
+			opts: []func(*argoproj.ArgoCDExport){func(a *argoproj.ArgoCDExport) {
+				a.Spec.Incremental = true
+			}},
+			want: []corev1.EnvVar{
+				{Name: "BACKUP_INCREMENTAL", Value: "true"},
+				{Name: "BACKUP_RETENTION_MAX_BACKUPS", Value: "30"},
+			},
+		},
+		{
+			name: "incremental with retention",
+			opts: []func(*argoproj.ArgoCDExport){func(a *argoproj.ArgoCDExport) {
+				a.Spec.Incremental = true
+				a.Spec.Retention = &argoproj.ArgoCDExportRetentionSpec{
+					MaxAge:     &metav1.Duration{Duration: 168 * time.Hour},
+					MaxBackups: &maxBackups,
+				}
+			}},
+			want: []corev1.EnvVar{
+				{Name: "BACKUP_INCREMENTAL", Value: "true"},
+				{Name: "BACKUP_RETENTION_MAX_BACKUPS", Value: "7"},
+				{Name: "BACKUP_RETENTION_MAX_AGE", Value: "604800"},
+			},
+		},
+		{
+			name: "retention without max backups",
+			opts: []func(*argoproj.ArgoCDExport){func(a *argoproj.ArgoCDExport) {
+				a.Spec.Retention = &argoproj.ArgoCDExportRetentionSpec{
+					MaxAge: &metav1.Duration{Duration: time.Hour},
+				}
+			}},
+			want: []corev1.EnvVar{
+				{Name: "BACKUP_RETENTION_MAX_BACKUPS", Value: "30"},
+				{Name: "BACKUP_RETENTION_MAX_AGE", Value: "3600"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, getArgoExportContainerEnv(makeTestArgoCDExport(test.opts...)))
+		})
+	}
+}
+
+func TestGetArgoExportContainerEnv_aws(t *testing.T) {
+	env := getArgoExportContainerEnv(makeTestArgoCDExport(func(a *argoproj.ArgoCDExport) {
+		a.Spec.Storage.Backend = "aws"
+	}))
+
+	names := []string{}
+	for _, e := range env {
+		names = append(names, e.Name)
+	}
+	assert.Equal(t, []string{"BACKUP_RETENTION_MAX_BACKUPS", "AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"}, names)
+	assert.Equal(t, "argocdexport-export", env[1].ValueFrom.SecretKeyRef.Name)
+}
+
+func makeTestReconcileArgoCDExport(t *testing.T, objs ...client.Object) *ReconcileArgoCDExport {
+	s := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(s))
+	assert.NoError(t, argoproj.AddToScheme(s))
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).WithStatusSubresource(&argoproj.ArgoCDExport{}).Build()
+	return &ReconcileArgoCDExport{Client: cl, Scheme: s}
+}
+
+// makeTestExportJob returns an export Job controlled by the given owner, which succeeded at the given time, or failed
+// when no time is given.
+func makeTestExportJob(name string, owner metav1.Object, completed *metav1.Time) *batchv1.Job {
+	controller := true
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "argocd",
+			OwnerReferences: []metav1.OwnerReference{{Name: owner.GetName(), UID: owner.GetUID(), Controller: &controller}},
+		},
+	}
+	if completed != nil {
+		job.Status.Succeeded = 1
+		job.Status.CompletionTime = completed
+	} else {
+		job.Status.Failed = 1
+	}
+	return job
+}
+
+// makeTestExportPod returns a pod of the given Job, whose export container ended with the given termination message.
+func makeTestExportPod(job *batchv1.Job, phase corev1.PodPhase, message string) *corev1.Pod {
+	exitCode := int32(0)
+	if phase != corev1.PodSucceeded {
+		exitCode = 1
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      job.Name + "-pod",
+			Namespace: job.Namespace,
+			Labels:    map[string]string{"job-name": job.Name},
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "argocd-export",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode, Message: message},
+				},
+			}},
+		},
+	}
+}
+
+func TestReconcileTriggerJob(t *testing.T) {
+	instance := &argoproj.ArgoCD{ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: "argocd"}}
+
+	tests := []struct {
+		name            string
+		trigger         string
+		lastTrigger     string
+		previousJob     bool
+		wantJob         bool
+		wantLastTrigger string
+	}{
+		{
+			name: "no trigger",
+		},
+		{
+			name:            "new trigger",
+			trigger:         "1",
+			wantJob:         true,
+			wantLastTrigger: "1",
+		},
+		{
+			name:            "already seen trigger",
+			trigger:         "1",
+			lastTrigger:     "1",
+			wantLastTrigger: "1",
+		},
+		{
+			name:            "new trigger replacing the job of the previous one",
+			trigger:         "2",
+			lastTrigger:     "1",
+			previousJob:     true,
+			wantJob:         true,
+			wantLastTrigger: "2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := makeTestArgoCDExport(func(a *argoproj.ArgoCDExport) {
+				a.Spec.Trigger = test.trigger
+				a.Status.LastTrigger = test.lastTrigger
+			})
+			objs := []client.Object{cr, instance}
+			if test.previousJob {
+				previous := newTriggerJob(cr)
+				previous.Labels["previous"] = "true"
+				objs = append(objs, previous)
+			}
+			r := makeTestReconcileArgoCDExport(t, objs...)
+			assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr))
+
+			assert.NoError(t, r.reconcileTriggerJob(cr))
+
+			job := &batchv1.Job{}
+			err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocdexport-trigger", Namespace: cr.Namespace}, job)
+			if test.wantJob {
+				assert.NoError(t, err)
+				assert.NotContains(t, job.Labels, "previous")
+				assert.True(t, metav1.IsControlledBy(job, cr))
+			} else {
+				assert.True(t, errors.IsNotFound(err))
+			}
+			assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr))
+			assert.Equal(t, test.wantLastTrigger, cr.Status.LastTrigger)
+		})
+	}
+}
+
+func TestGetLastCompletedJob(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(earlier.Add(time.Hour))
+	cr := makeTestArgoCDExport(func(a *argoproj.ArgoCDExport) {
+		a.UID = "export"
+	})
+	cj := newCronJob(cr)
+	cj.UID = "cronjob"
+	other := makeTestArgoCDExport(func(a *argoproj.ArgoCDExport) {
+		a.Name = "other"
+		a.UID = "other"
+	})
+
+	tests := []struct {
+		name string
+		objs []client.Object
+		want string
+	}{
+		{
+			name: "no job",
+		},
+		{
+			name: "new job",
+			objs: []client.Object{makeTestExportJob("first", cr, &earlier)},
+			want: "first",
+		},
+		{
+			name: "job of the CronJob completed last",
+			objs: []client.Object{cj, makeTestExportJob("first", cr, &earlier), makeTestExportJob("scheduled", cj, &later)},
+			want: "scheduled",
+		},
+		{
+			name: "failed job",
+			objs: []client.Object{makeTestExportJob("first", cr, &earlier), makeTestExportJob("failed", cr, nil)},
+			want: "first",
+		},
+		{
+			name: "job of another export",
+			objs: []client.Object{makeTestExportJob("other", other, &later)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := makeTestReconcileArgoCDExport(t, append([]client.Object{cr}, test.objs...)...)
+
+			job, err := r.getLastCompletedJob(cr)
+			assert.NoError(t, err)
+			if test.want == "" {
+				assert.Nil(t, job)
+			} else {
+				assert.Equal(t, test.want, job.Name)
+			}
+		})
+	}
+}
+
+func TestReconcileExportStatus(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(earlier.Add(time.Hour))
+	owner := makeTestArgoCDExport(func(a *argoproj.ArgoCDExport) {
+		a.UID = "export"
+	})
+	result := `{"size":42,"checksum":"abc"}`
+
+	completedJob := makeTestExportJob("new", owner, &later)
+	failedJob := makeTestExportJob("failed", owner, nil)
+
+	tests := []struct {
+		name   string
+		status argoproj.ArgoCDExportStatus
+		objs   []client.Object
+		want   argoproj.ArgoCDExportStatus
+	}{
+		{
+			name: "new job",
+			objs: []client.Object{completedJob, makeTestExportPod(completedJob, corev1.PodSucceeded, result)},
+			want: argoproj.ArgoCDExportStatus{LastExportTime: &later, LastExportSize: 42, LastExportChecksum: "abc"},
+		},
+		{
+			name:   "job newer than the last export",
+			status: argoproj.ArgoCDExportStatus{LastExportTime: &earlier, LastExportSize: 1, LastExportChecksum: "old"},
+			objs:   []client.Object{completedJob, makeTestExportPod(completedJob, corev1.PodSucceeded, result)},
+			want:   argoproj.ArgoCDExportStatus{LastExportTime: &later, LastExportSize: 42, LastExportChecksum: "abc"},
+		},
+		{
+			name:   "already seen job",
+			status: argoproj.ArgoCDExportStatus{LastExportTime: &later, LastExportSize: 1, LastExportChecksum: "old"},
+			objs:   []client.Object{completedJob, makeTestExportPod(completedJob, corev1.PodSucceeded, result)},
+			want:   argoproj.ArgoCDExportStatus{LastExportTime: &later, LastExportSize: 1, LastExportChecksum: "old"},
+		},
+		{
+			name: "failed job",
+			objs: []client.Object{failedJob, makeTestExportPod(failedJob, corev1.PodFailed, "")},
+			want: argoproj.ArgoCDExportStatus{},
+		},
+		{
+			name: "job with a failed pod",
+			objs: []client.Object{completedJob, makeTestExportPod(completedJob, corev1.PodFailed, result)},
+			want: argoproj.ArgoCDExportStatus{LastExportTime: &later},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := owner.DeepCopy()
+			cr.Status = test.status
+			r := makeTestReconcileArgoCDExport(t, append([]client.Object{cr}, test.objs...)...)
+			assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr))
+
+			assert.NoError(t, r.reconcileExportStatus(cr))
+
+			assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr))
+			assert.Equal(t, test.want.LastExportSize, cr.Status.LastExportSize)
+			assert.Equal(t, test.want.LastExportChecksum, cr.Status.LastExportChecksum)
+			if test.want.LastExportTime == nil {
+				assert.Nil(t, cr.Status.LastExportTime)
+			} else {
+				assert.True(t, test.want.LastExportTime.Equal(cr.Status.LastExportTime))
+			}
+		})
+	}
+}
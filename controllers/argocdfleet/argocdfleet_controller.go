@@ -0,0 +1,108 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package argocdfleet
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logr "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+// blank assignment to verify that ArgoCDFleetReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ArgoCDFleetReconciler{}
+
+// ArgoCDFleetReconciler reconciles an ArgoCDFleet object
+type ArgoCDFleetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Options of the controller, e.g. the number of concurrent reconciles and the rate limiter
+	ControllerOptions controller.Options
+}
+
+//+kubebuilder:rbac:groups=argoproj.io,resources=argocdfleets;argocdfleets/status,verbs=*
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.9.2/pkg/reconcile
+func (r *ArgoCDFleetReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := logr.FromContext(ctx, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling ArgoCDFleet")
+
+	fleet := &argoproj.ArgoCDFleet{}
+	err := r.Client.Get(ctx, request.NamespacedName, fleet)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if err := r.reconcileStatus(ctx, fleet); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Return and don't requeue
+	return reconcile.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ArgoCDFleetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	bld := ctrl.NewControllerManagedBy(mgr).WithOptions(r.ControllerOptions)
+	setResourceWatches(bld, r.fleetMapper)
+	return bld.Complete(r)
+}
+
+// setResourceWatches will register Watches for each of the supported Resources.
+func setResourceWatches(bld *builder.Builder, fleetMapper handler.MapFunc) *builder.Builder {
+	// Watch for changes to primary resource ArgoCDFleet
+	bld.For(&argoproj.ArgoCDFleet{})
+
+	// Watch for changes to the ArgoCD instances, whose status is updated as their workloads are rolled out.
+	bld.Watches(&argoproj.ArgoCD{}, handler.EnqueueRequestsFromMapFunc(fleetMapper))
+
+	return bld
+}
+
+// fleetMapper maps a watch event on an ArgoCD instance to all the fleets. The selectors of the fleets are not
+// evaluated here, so that fleets are also refreshed when an instance stops matching them.
+func (r *ArgoCDFleetReconciler) fleetMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	fleets := &argoproj.ArgoCDFleetList{}
+	if err := r.Client.List(ctx, fleets); err != nil {
+		return result
+	}
+	for _, fleet := range fleets.Items {
+		result = append(result, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&fleet)})
+	}
+	return result
+}
@@ -0,0 +1,226 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdfleet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// reconcileStatus will aggregate the status of the ArgoCD instances selected by the given fleet into its status.
+func (r *ArgoCDFleetReconciler) reconcileStatus(ctx context.Context, fleet *argoproj.ArgoCDFleet) error {
+	selector := labels.Everything()
+	if fleet.Spec.Selector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(fleet.Spec.Selector); err != nil {
+			return fmt.Errorf("invalid selector of ArgoCDFleet %s: %w", fleet.Name, err)
+		}
+	}
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(ctx, argocds, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return err
+	}
+
+	expected := getExpectedVersion(fleet)
+	status := argoproj.ArgoCDFleetStatus{
+		ObservedGeneration: fleet.Generation,
+		Conditions:         fleet.Status.Conditions,
+	}
+	for i := range argocds.Items {
+		member, err := r.getMemberStatus(ctx, &argocds.Items[i], expected)
+		if err != nil {
+			return err
+		}
+
+		status.Instances++
+		if member.Phase == "Available" {
+			status.Available++
+		}
+		if member.Version != "" {
+			if status.Versions == nil {
+				status.Versions = map[string]int32{}
+			}
+			status.Versions[member.Version]++
+		}
+		if member.Degraded {
+			status.Degraded++
+		}
+		if member.Outdated {
+			status.Outdated++
+		}
+		if member.Degraded || member.Outdated {
+			status.Members = append(status.Members, member)
+		}
+	}
+	sort.Slice(status.Members, func(i, j int) bool {
+		if status.Members[i].Namespace != status.Members[j].Namespace {
+			return status.Members[i].Namespace < status.Members[j].Namespace
+		}
+		return status.Members[i].Name < status.Members[j].Name
+	})
+	setFleetConditions(fleet, &status, expected)
+
+	if reflect.DeepEqual(status, fleet.Status) {
+		return nil
+	}
+	fleet.Status = status
+	return r.Client.Status().Update(ctx, fleet)
+}
+
+// getMemberStatus will return the status of the given ArgoCD instance, as a member of a fleet expecting the given
+// Argo CD version.
+func (r *ArgoCDFleetReconciler) getMemberStatus(ctx context.Context, cr *argoproj.ArgoCD, expected string) (argoproj.ArgoCDFleetMemberStatus, error) {
+	member := argoproj.ArgoCDFleetMemberStatus{
+		Name:      cr.Name,
+		Namespace: cr.Namespace,
+		Phase:     cr.Status.Phase,
+	}
+
+	image, err := r.getRunningImage(ctx, cr)
+	if err != nil {
+		return member, err
+	}
+	if image != "" {
+		member.Version = getImageVersion(image)
+		member.Outdated = member.Version != expected
+	}
+
+	if condition := meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionTypeDegraded); condition != nil && condition.Status == metav1.ConditionTrue {
+		member.Degraded = true
+		member.Message = condition.Message
+	} else if cr.Status.Phase != "Available" {
+		member.Degraded = true
+		member.Message = getComponentMessages(cr)
+		if member.Message == "" {
+			member.Message = fmt.Sprintf("phase is %q", cr.Status.Phase)
+		}
+	}
+	return member, nil
+}
+
+// getRunningImage will return the Argo CD image deployed for the given ArgoCD instance, taken from its server or,
+// if the server is disabled, from its application controller. An empty image is returned until either is deployed.
+func (r *ArgoCDFleetReconciler) getRunningImage(ctx context.Context, cr *argoproj.ArgoCD) (string, error) {
+	deploy := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: fmt.Sprintf("%s-server", cr.Name), Namespace: cr.Namespace}
+	if err := r.Client.Get(ctx, key, deploy); err == nil {
+		if containers := deploy.Spec.Template.Spec.Containers; len(containers) > 0 {
+			return containers[0].Image, nil
+		}
+	} else if !errors.IsNotFound(err) {
+		return "", err
+	}
+
+	ss := &appsv1.StatefulSet{}
+	key = types.NamespacedName{Name: fmt.Sprintf("%s-application-controller", cr.Name), Namespace: cr.Namespace}
+	if err := r.Client.Get(ctx, key, ss); err == nil {
+		if containers := ss.Spec.Template.Spec.Containers; len(containers) > 0 {
+			return containers[0].Image, nil
+		}
+	} else if !errors.IsNotFound(err) {
+		return "", err
+	}
+	return "", nil
+}
+
+// getExpectedVersion will return the Argo CD version the instances of the given fleet are expected to run.
+func getExpectedVersion(fleet *argoproj.ArgoCDFleet) string {
+	if fleet.Spec.Version != "" {
+		return fleet.Spec.Version
+	}
+	if e := os.Getenv(common.ArgoCDImageEnvName); e != "" {
+		return getImageVersion(e)
+	}
+	return common.ArgoCDDefaultArgoVersion
+}
+
+// getImageVersion will return the digest or the tag of the given image reference. Images without either use the
+// latest tag.
+func getImageVersion(image string) string {
+	if i := strings.LastIndex(image, "@"); i >= 0 {
+		return image[i+1:]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+	return "latest"
+}
+
+// getComponentMessages will return the reasons reported by the components of the given ArgoCD instance which are
+// not running, sorted by component.
+func getComponentMessages(cr *argoproj.ArgoCD) string {
+	components := make([]string, 0, len(cr.Status.ComponentMessages))
+	for component := range cr.Status.ComponentMessages {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	messages := make([]string, 0, len(components))
+	for _, component := range components {
+		messages = append(messages, fmt.Sprintf("%s: %s", component, cr.Status.ComponentMessages[component]))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// setFleetConditions will set the Degraded and UpToDate conditions of the given status of the fleet.
+func setFleetConditions(fleet *argoproj.ArgoCDFleet, status *argoproj.ArgoCDFleetStatus, expected string) {
+	degraded := metav1.Condition{
+		Type:               argoproj.ArgoCDFleetConditionTypeDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             argoproj.ArgoCDFleetConditionReasonInstancesAvailable,
+		Message:            "No instance is degraded",
+		ObservedGeneration: fleet.Generation,
+	}
+	if status.Degraded > 0 {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = argoproj.ArgoCDFleetConditionReasonInstancesDegraded
+		degraded.Message = fmt.Sprintf("%d of %d instances are degraded", status.Degraded, status.Instances)
+	}
+
+	upToDate := metav1.Condition{
+		Type:               argoproj.ArgoCDFleetConditionTypeUpToDate,
+		Status:             metav1.ConditionTrue,
+		Reason:             argoproj.ArgoCDFleetConditionReasonExpectedVersion,
+		Message:            fmt.Sprintf("All instances run version %s", expected),
+		ObservedGeneration: fleet.Generation,
+	}
+	if status.Outdated > 0 {
+		upToDate.Status = metav1.ConditionFalse
+		upToDate.Reason = argoproj.ArgoCDFleetConditionReasonInstancesOutdated
+		upToDate.Message = fmt.Sprintf("%d of %d instances do not run version %s", status.Outdated, status.Instances, expected)
+	}
+
+	// Conditions are copied, so that the status of the fleet can be compared with the new one
+	conditions := append([]metav1.Condition{}, status.Conditions...)
+	meta.SetStatusCondition(&conditions, degraded)
+	meta.SetStatusCondition(&conditions, upToDate)
+	status.Conditions = conditions
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocdfleet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestArgoCD(name, namespace, phase string, labels map[string]string) *argoproj.ArgoCD {
+	return &argoproj.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Status: argoproj.ArgoCDStatus{
+			Phase: phase,
+		},
+	}
+}
+
+func makeTestServerDeployment(cr *argoproj.ArgoCD, image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-server",
+			Namespace: cr.Namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "argocd-server", Image: image}},
+				},
+			},
+		},
+	}
+}
+
+func makeTestReconciler(objs ...client.Object) *ArgoCDFleetReconciler {
+	sch := runtime.NewScheme()
+	_ = scheme.AddToScheme(sch)
+	_ = argoproj.AddToScheme(sch)
+	cl := fake.NewClientBuilder().
+		WithScheme(sch).
+		WithObjects(objs...).
+		WithStatusSubresource(&argoproj.ArgoCDFleet{}).
+		Build()
+	return &ArgoCDFleetReconciler{Client: cl, Scheme: sch}
+}
+
+func TestArgoCDFleetReconciler_reconcileStatus(t *testing.T) {
+	fleet := &argoproj.ArgoCDFleet{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenants"},
+		Spec: argoproj.ArgoCDFleetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "tenant"}},
+			Version:  "v2.11.6",
+		},
+	}
+	tenant := map[string]string{"tier": "tenant"}
+	healthy := makeTestArgoCD("argocd", "team-a", "Available", tenant)
+	outdated := makeTestArgoCD("argocd", "team-b", "Available", tenant)
+	failing := makeTestArgoCD("argocd", "team-c", "Failed", tenant)
+	failing.Status.ComponentMessages = map[string]string{
+		"server": "ImagePullBackOff",
+		"repo":   "CrashLoopBackOff",
+	}
+	pending := makeTestArgoCD("argocd", "team-d", "Pending", tenant)
+	other := makeTestArgoCD("argocd", "platform", "Failed", nil)
+
+	r := makeTestReconciler(fleet, healthy, outdated, failing, pending, other,
+		makeTestServerDeployment(healthy, "quay.io/argoproj/argocd:v2.11.6"),
+		makeTestServerDeployment(outdated, "quay.io/argoproj/argocd:v2.10.0"),
+		makeTestServerDeployment(failing, "registry.example.com:5000/argocd:v2.11.6"),
+		makeTestServerDeployment(other, "quay.io/argoproj/argocd:v2.9.0"),
+	)
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: fleet.Name}})
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: fleet.Name}, fleet))
+
+	status := fleet.Status
+	assert.Equal(t, int32(4), status.Instances)
+	assert.Equal(t, int32(2), status.Available)
+	assert.Equal(t, int32(2), status.Degraded)
+	assert.Equal(t, int32(1), status.Outdated)
+	assert.Equal(t, map[string]int32{"v2.11.6": 2, "v2.10.0": 1}, status.Versions)
+	assert.Equal(t, []argoproj.ArgoCDFleetMemberStatus{
+		{Name: "argocd", Namespace: "team-b", Phase: "Available", Version: "v2.10.0", Outdated: true},
+		{Name: "argocd", Namespace: "team-c", Phase: "Failed", Version: "v2.11.6", Degraded: true,
+			Message: "repo: CrashLoopBackOff; server: ImagePullBackOff"},
+		{Name: "argocd", Namespace: "team-d", Phase: "Pending", Degraded: true, Message: `phase is "Pending"`},
+	}, status.Members)
+	assert.True(t, meta.IsStatusConditionTrue(status.Conditions, argoproj.ArgoCDFleetConditionTypeDegraded))
+	assert.True(t, meta.IsStatusConditionFalse(status.Conditions, argoproj.ArgoCDFleetConditionTypeUpToDate))
+
+	// Instances no longer matching the selector leave the fleet
+	for _, cr := range []*argoproj.ArgoCD{outdated, failing, pending} {
+		assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr))
+		cr.Labels = nil
+		assert.NoError(t, r.Client.Update(context.TODO(), cr))
+	}
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: fleet.Name}})
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: fleet.Name}, fleet))
+
+	assert.Equal(t, int32(1), fleet.Status.Instances)
+	assert.Empty(t, fleet.Status.Members)
+	assert.True(t, meta.IsStatusConditionFalse(fleet.Status.Conditions, argoproj.ArgoCDFleetConditionTypeDegraded))
+	assert.True(t, meta.IsStatusConditionTrue(fleet.Status.Conditions, argoproj.ArgoCDFleetConditionTypeUpToDate))
+}
+
+func TestArgoCDFleetReconciler_fleetMapper(t *testing.T) {
+	a := &argoproj.ArgoCDFleet{ObjectMeta: metav1.ObjectMeta{Name: "a"}}
+	b := &argoproj.ArgoCDFleet{ObjectMeta: metav1.ObjectMeta{Name: "b"}}
+	r := makeTestReconciler(a, b)
+
+	want := []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "a"}},
+		{NamespacedName: types.NamespacedName{Name: "b"}},
+	}
+	assert.Equal(t, want, r.fleetMapper(context.TODO(), makeTestArgoCD("argocd", "team-a", "", nil)))
+}
+
+func TestGetExpectedVersion(t *testing.T) {
+	fleet := &argoproj.ArgoCDFleet{}
+	assert.Equal(t, common.ArgoCDDefaultArgoVersion, getExpectedVersion(fleet))
+
+	t.Setenv(common.ArgoCDImageEnvName, "registry.example.com/argocd@sha256:abc")
+	assert.Equal(t, "sha256:abc", getExpectedVersion(fleet))
+
+	fleet.Spec.Version = "v2.11.6"
+	assert.Equal(t, "v2.11.6", getExpectedVersion(fleet))
+}
+
+func TestGetImageVersion(t *testing.T) {
+	assert.Equal(t, "v2.11.6", getImageVersion("quay.io/argoproj/argocd:v2.11.6"))
+	assert.Equal(t, "sha256:abc", getImageVersion("quay.io/argoproj/argocd@sha256:abc"))
+	assert.Equal(t, "latest", getImageVersion("registry.example.com:5000/argocd"))
+	assert.Equal(t, "v1", getImageVersion("registry.example.com:5000/argocd:v1"))
+}
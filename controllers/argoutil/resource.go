@@ -17,11 +17,13 @@ package argoutil
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -108,9 +110,59 @@ func newEvent(meta metav1.ObjectMeta) *corev1.Event {
 	return event
 }
 
+// ManagedByLabelValue returns the value of the app.kubernetes.io/managed-by label of the resources of the given
+// ArgoCD, set by its label policy or by the operator, and the name of the ArgoCD otherwise.
+func ManagedByLabelValue(cr *argoproj.ArgoCD) string {
+	if cr.Spec.LabelPolicy != nil && cr.Spec.LabelPolicy.ManagedBy != "" {
+		return cr.Spec.LabelPolicy.ManagedBy
+	}
+	if v := os.Getenv(common.ArgoCDManagedByLabelValueEnvName); v != "" {
+		return v
+	}
+	return cr.Name
+}
+
+// AdditionalLabelsForCluster returns the labels added to the default labels of the resources of the given ArgoCD,
+// the labels of its label policy merged over the labels set by the operator.
+func AdditionalLabelsForCluster(cr *argoproj.ArgoCD) map[string]string {
+	additional := map[string]string{}
+	if v := os.Getenv(common.ArgoCDDefaultLabelsEnvName); v != "" {
+		operatorLabels, err := labels.ConvertSelectorToLabelsMap(v)
+		if err != nil {
+			log.Error(err, fmt.Sprintf("ignoring invalid labels in %s", common.ArgoCDDefaultLabelsEnvName))
+		}
+		for key, val := range operatorLabels {
+			additional[key] = val
+		}
+	}
+	if cr.Spec.LabelPolicy != nil {
+		for key, val := range cr.Spec.LabelPolicy.AdditionalLabels {
+			additional[key] = val
+		}
+	}
+	for key := range common.DefaultLabels(cr.Name) {
+		delete(additional, key) // The default labels cannot be overridden
+	}
+	return additional
+}
+
+// DefaultLabelsForCluster returns the default labels of the resource with the given name of the given ArgoCD,
+// following its label policy.
+func DefaultLabelsForCluster(cr *argoproj.ArgoCD, name string) map[string]string {
+	labels := AdditionalLabelsForCluster(cr)
+	for key, val := range common.DefaultLabels(name) {
+		labels[key] = val
+	}
+	labels[common.ArgoCDKeyManagedBy] = ManagedByLabelValue(cr)
+	return labels
+}
+
 // LabelsForCluster returns the labels for all cluster resources.
 func LabelsForCluster(cr *argoproj.ArgoCD) map[string]string {
-	labels := common.DefaultLabels(cr.Name)
+	labels := DefaultLabelsForCluster(cr, cr.Name)
+	if cr.Spec.BackupLabels != nil && cr.Spec.BackupLabels.Enabled {
+		labels[common.ArgoCDBackupLabel] = "true"
+	}
 	return labels
 }
 
@@ -120,5 +172,8 @@ func AnnotationsForCluster(cr *argoproj.ArgoCD) map[string]string {
 	for key, val := range cr.ObjectMeta.Annotations {
 		annotations[key] = val
 	}
+	if cr.Spec.BackupLabels != nil && cr.Spec.BackupLabels.Enabled && cr.Spec.BackupLabels.StorageLocation != "" {
+		annotations[common.ArgoCDBackupStorageLocationAnnotation] = cr.Spec.BackupLabels.StorageLocation
+	}
 	return annotations
 }
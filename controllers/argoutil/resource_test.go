@@ -57,3 +57,37 @@ func TestDefaultAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultLabelsForCluster(t *testing.T) {
+	cr := &argoproj.ArgoCD{ObjectMeta: v1.ObjectMeta{Name: "foo", Namespace: "bar"}}
+	want := map[string]string{
+		common.ArgoCDKeyName:      "foo-server",
+		common.ArgoCDKeyPartOf:    common.ArgoCDAppName,
+		common.ArgoCDKeyManagedBy: "foo",
+	}
+	if got := DefaultLabelsForCluster(cr, "foo-server"); !reflect.DeepEqual(got, want) {
+		t.Errorf("DefaultLabelsForCluster() = %v, want %v", got, want)
+	}
+
+	// The labels of the operator are merged under the labels of the policy, which cannot override the defaults
+	t.Setenv(common.ArgoCDDefaultLabelsEnvName, "team=platform,env=prod")
+	t.Setenv(common.ArgoCDManagedByLabelValueEnvName, "argocd-operator")
+	cr.Spec.LabelPolicy = &argoproj.ArgoCDLabelPolicySpec{
+		AdditionalLabels: map[string]string{"env": "staging", common.ArgoCDKeyName: "other"},
+	}
+	want = map[string]string{
+		common.ArgoCDKeyName:      "foo-server",
+		common.ArgoCDKeyPartOf:    common.ArgoCDAppName,
+		common.ArgoCDKeyManagedBy: "argocd-operator",
+		"team":                    "platform",
+		"env":                     "staging",
+	}
+	if got := DefaultLabelsForCluster(cr, "foo-server"); !reflect.DeepEqual(got, want) {
+		t.Errorf("DefaultLabelsForCluster() = %v, want %v", got, want)
+	}
+
+	cr.Spec.LabelPolicy.ManagedBy = "gitops"
+	if got := ManagedByLabelValue(cr); got != "gitops" {
+		t.Errorf("ManagedByLabelValue() = %v, want gitops", got)
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	logr "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -37,6 +38,8 @@ var _ reconcile.Reconciler = &NotificationsConfigurationReconciler{}
 type NotificationsConfigurationReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Options of the controller, e.g. the number of concurrent reconciles and the rate limiter
+	ControllerOptions controller.Options
 }
 
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;delete;patch;update
@@ -78,7 +81,7 @@ func (r *NotificationsConfigurationReconciler) Reconcile(ctx context.Context, re
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *NotificationsConfigurationReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	bldr := ctrl.NewControllerManagedBy(mgr)
+	bldr := ctrl.NewControllerManagedBy(mgr).WithOptions(r.ControllerOptions)
 	setResourceWatches(bldr)
 	return bldr.Complete(r)
 }